@@ -860,6 +860,15 @@ func readSeeds(whereCondition string, args []interface{}, limit string) ([]SeedO
 	return res, err
 }
 
+// ReadActiveSeeds reads all seeds which are not yet complete
+func ReadActiveSeeds() ([]SeedOperation, error) {
+	whereCondition := `
+		where
+			is_complete = 0
+		`
+	return readSeeds(whereCondition, sqlutils.Args(), "")
+}
+
 // ReadActiveSeedsForHost reads active seeds where host participates either as source or target
 func ReadActiveSeedsForHost(hostname string) ([]SeedOperation, error) {
 	whereCondition := `
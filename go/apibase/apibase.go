@@ -0,0 +1,133 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package apibase resolves the base URL of orchestrator's HTTP API out of the endpoint an operator configured.
+// orchestrator serves its API under /api, or under URLPrefix followed by /api (e.g. /orchestrator/api), and an
+// ingress may rewrite paths such that the API is served at the root of the endpoint. It does not import
+// orchestrator's own packages, such that any API consumer may use it.
+package apibase
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultPath is the path under which orchestrator serves its API, following URLPrefix
+const DefaultPath = "api"
+
+// probeTimeout bounds each probe, when the context has no earlier deadline
+const probeTimeout = 2 * time.Second
+
+// Options configure the resolution of an API base
+type Options struct {
+	BasePath string       // explicit API path (e.g. "/orchestrator/api"), replacing the endpoint's path; disables probing
+	User     string       // basic authentication user for probes, if any
+	Password string       // basic authentication password
+	Client   *http.Client // client for probes; defaults to http.DefaultClient
+}
+
+// Normalize returns the API base of given endpoint without probing. With a base path, it replaces the path
+// of the endpoint. Otherwise, an endpoint whose path ends with /api is used as is, and /api is appended
+// to any other endpoint.
+func Normalize(endpoint string, basePath string) string {
+	endpoint = strings.TrimRight(endpoint, "/")
+	if basePath != "" {
+		if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+			u.Path = "/" + strings.Trim(basePath, "/")
+			u.RawPath = ""
+			return strings.TrimRight(u.String(), "/")
+		}
+		return endpoint
+	}
+	if strings.HasSuffix(endpoint, "/"+DefaultPath) {
+		return endpoint
+	}
+	return endpoint + "/" + DefaultPath
+}
+
+// candidates returns the API bases to probe for given endpoint, in order of preference
+func candidates(endpoint string) []string {
+	endpoint = strings.TrimRight(endpoint, "/")
+	normalized := Normalize(endpoint, "")
+	if normalized == endpoint {
+		return []string{endpoint}
+	}
+	return []string{normalized, endpoint}
+}
+
+// isAPIBase returns true when given base serves orchestrator's API: its health check responds with an API
+// response, healthy or not, or requires authorization
+func isAPIBase(ctx context.Context, base string, options Options) bool {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+	req, err := http.NewRequest("GET", base+"/health", nil)
+	if err != nil {
+		return false
+	}
+	req = req.WithContext(ctx)
+	if options.User != "" {
+		req.SetBasicAuth(options.User, options.Password)
+	}
+	client := options.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return true
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return false
+	}
+	response := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return false
+	}
+	_, hasCode := response["Code"]
+	return hasCode
+}
+
+// Resolve returns the API base of given endpoint. With an explicit base path, the path is used as given, and
+// an endpoint whose path ends with /api is used as is. Otherwise, the candidates are probed via their health
+// check: the endpoint followed by /api, as orchestrator serves it, and then the endpoint itself, as behind an
+// ingress which rewrites paths. The first candidate which serves the API wins. When none does, e.g. as
+// orchestrator is down, Resolve falls back to Normalize, such that the API base is as predictable as
+// without probing.
+func Resolve(ctx context.Context, endpoint string, options Options) string {
+	if options.BasePath != "" {
+		return Normalize(endpoint, options.BasePath)
+	}
+	candidates := candidates(endpoint)
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+	for _, candidate := range candidates {
+		if isAPIBase(ctx, candidate, options) {
+			return candidate
+		}
+	}
+	return Normalize(endpoint, "")
+}
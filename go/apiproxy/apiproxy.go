@@ -0,0 +1,300 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package apiproxy provides an embeddable http.Handler which exposes a restricted, audited subset of
+// orchestrator's HTTP API. Requests are authenticated by the embedding service, matched against an
+// allowlist of routes, as orchestrator registers them (e.g. "cluster/:clusterHint"), and proxied to
+// orchestrator under the proxy's own credentials. Users of the proxy thus need no access to orchestrator
+// itself. It does not import orchestrator's own packages, such that any service may embed it.
+package apiproxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/openark/golib/log"
+)
+
+// forwardedHeaders are the request headers passed on to orchestrator; all others, notably credentials,
+// cookies and orchestrator's own control headers, are dropped
+var forwardedHeaders = []string{"Accept", "Accept-Encoding", "Content-Type", "Content-Length"}
+
+// Options configure a proxy
+type Options struct {
+	APIBase string   // orchestrator's API base, e.g. http://orchestrator:3000/api
+	Routes  []string // allowlisted routes, as orchestrator registers them, e.g. "cluster/:clusterHint"
+	// Authenticate identifies the user of a request; an error denies the request with 401
+	Authenticate func(req *http.Request) (user string, err error)
+	// Permissions, if given, grant users use of allowlisted routes by their roles; a denial responds with 403
+	Permissions *Permissions
+	// Authorize, if given, tells whether an authenticated user may use an allowlisted route; a denial responds with 403
+	Authorize func(user string, route string, req *http.Request) bool
+	// Audit, if given, is called for every request, allowed or denied; defaults to logging
+	Audit      func(entry AuditEntry)
+	User       string            // basic authentication user towards orchestrator, if any
+	Password   string            // basic authentication password
+	UserHeader string            // header naming the authenticated user towards orchestrator, e.g. its AuthUserHeader; none if empty
+	Transport  http.RoundTripper // transport towards orchestrator; defaults to http.DefaultTransport
+}
+
+// AuditEntry describes a request made through the proxy
+type AuditEntry struct {
+	Time    time.Time
+	User    string
+	Role    Role // the user's role, with Permissions
+	Method  string
+	Path    string
+	Route   string // the allowlisted route the request matched; empty when none did
+	Allowed bool
+	Status  int
+	Reason  string // why the request was denied
+}
+
+// Handler is the proxy. It serves API paths relative to where it is mounted, e.g. "/cluster/mycluster";
+// use http.StripPrefix to mount it under a path.
+type Handler struct {
+	options Options
+	base    *url.URL
+	routes  [][]string
+	proxy   *httputil.ReverseProxy
+}
+
+// apiResponse mimics orchestrator's own API response, such that clients of orchestrator's API may
+// handle the proxy's denials as they do orchestrator's errors
+type apiResponse struct {
+	Code    string
+	Message string
+}
+
+// NewHandler creates a proxy with given options
+func NewHandler(options Options) (*Handler, error) {
+	if options.Authenticate == nil {
+		return nil, fmt.Errorf("apiproxy: Authenticate is required")
+	}
+	base, err := url.Parse(strings.TrimRight(options.APIBase, "/"))
+	if err != nil || base.Host == "" {
+		return nil, fmt.Errorf("apiproxy: invalid API base %q", options.APIBase)
+	}
+	if options.Audit == nil {
+		options.Audit = logAuditEntry
+	}
+	handler := &Handler{options: options, base: base}
+	for _, route := range options.Routes {
+		handler.routes = append(handler.routes, splitPath(route))
+	}
+	handler.proxy = &httputil.ReverseProxy{
+		Director:      func(req *http.Request) {},
+		FlushInterval: -1,
+	}
+	if options.Transport != nil {
+		handler.proxy.Transport = options.Transport
+	}
+	handler.proxy.ErrorHandler = func(w http.ResponseWriter, req *http.Request, err error) {
+		respond(w, http.StatusBadGateway, fmt.Sprintf("orchestrator unavailable: %+v", err))
+	}
+	return handler, nil
+}
+
+// Endpoint is an API endpoint as orchestrator describes it via api-endpoints
+type Endpoint struct {
+	Path     string
+	Mutating bool
+}
+
+// ReadEndpoints reads orchestrator's API endpoints off its api-endpoints
+func ReadEndpoints(ctx context.Context, apiBase string, client *http.Client) (endpoints []Endpoint, err error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequest("GET", strings.TrimRight(apiBase, "/")+"/api-endpoints", nil)
+	if err != nil {
+		return endpoints, err
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return endpoints, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return endpoints, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return endpoints, fmt.Errorf("apiproxy: api-endpoints: %s", resp.Status)
+	}
+	err = json.Unmarshal(body, &endpoints)
+	return endpoints, err
+}
+
+// ReadOnlyRoutes reads the routes of all read-only endpoints off orchestrator's api-endpoints, for an
+// allowlist exposing everything but mutating endpoints
+func ReadOnlyRoutes(ctx context.Context, apiBase string, client *http.Client) (routes []string, err error) {
+	endpoints, err := ReadEndpoints(ctx, apiBase, client)
+	if err != nil {
+		return routes, err
+	}
+	for _, endpoint := range endpoints {
+		if !endpoint.Mutating {
+			routes = append(routes, endpoint.Path)
+		}
+	}
+	return routes, nil
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// matchRoute returns the allowlisted route given escaped path matches, if any. Segments are matched decoded,
+// as orchestrator routes them. A ":param" segment of a route matches any single segment. Paths with empty,
+// "." or ".." segments, or with segments which decode to more than one (an encoded "/"), match no route.
+func (this *Handler) matchRoute(path string) (route string, found bool) {
+	segments := splitPath(path)
+	for i, segment := range segments {
+		decoded, err := url.PathUnescape(segment)
+		if err != nil || decoded == "" || decoded == "." || decoded == ".." || strings.Contains(decoded, "/") {
+			return "", false
+		}
+		segments[i] = decoded
+	}
+	for _, routeSegments := range this.routes {
+		if len(routeSegments) != len(segments) {
+			continue
+		}
+		matches := true
+		for i, routeSegment := range routeSegments {
+			if !strings.HasPrefix(routeSegment, ":") && routeSegment != segments[i] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return strings.Join(routeSegments, "/"), true
+		}
+	}
+	return "", false
+}
+
+func respond(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(apiResponse{Code: "ERROR", Message: message})
+}
+
+func logAuditEntry(entry AuditEntry) {
+	user := entry.User
+	if entry.Role != "" {
+		user = fmt.Sprintf("%s (%s)", entry.User, entry.Role)
+	}
+	if entry.Allowed {
+		log.Infof("apiproxy: %s %s %s: %d", user, entry.Method, entry.Path, entry.Status)
+	} else {
+		log.Warningf("apiproxy: %s %s %s: denied: %s", user, entry.Method, entry.Path, entry.Reason)
+	}
+}
+
+// statusRecorder notes the status of a proxied response, for auditing
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (this *statusRecorder) WriteHeader(status int) {
+	this.status = status
+	this.ResponseWriter.WriteHeader(status)
+}
+
+func (this *statusRecorder) Flush() {
+	if flusher, ok := this.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// ServeHTTP authenticates, authorizes, audits and proxies a request
+func (this *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	entry := AuditEntry{Time: time.Now(), Method: req.Method, Path: req.URL.Path}
+	deny := func(status int, reason string) {
+		entry.Status = status
+		entry.Reason = reason
+		this.options.Audit(entry)
+		respond(w, status, reason)
+	}
+
+	user, err := this.options.Authenticate(req)
+	if err != nil {
+		deny(http.StatusUnauthorized, fmt.Sprintf("Unauthenticated: %+v", err))
+		return
+	}
+	entry.User = user
+	switch req.Method {
+	case "GET", "HEAD", "POST":
+	default:
+		deny(http.StatusMethodNotAllowed, fmt.Sprintf("Method %s not allowed", req.Method))
+		return
+	}
+	route, found := this.matchRoute(req.URL.EscapedPath())
+	if !found {
+		deny(http.StatusForbidden, fmt.Sprintf("%s is not an allowed route", req.URL.Path))
+		return
+	}
+	entry.Route = route
+	if this.options.Permissions != nil {
+		entry.Role = this.options.Permissions.RoleOf(user)
+		if err := this.options.Permissions.Check(user, route); err != nil {
+			deny(http.StatusForbidden, fmt.Sprintf("Forbidden: %+v", err))
+			return
+		}
+	}
+	if this.options.Authorize != nil && !this.options.Authorize(user, route, req) {
+		deny(http.StatusForbidden, fmt.Sprintf("%s is not authorized for %s", user, route))
+		return
+	}
+
+	outbound := req.Clone(req.Context())
+	outbound.URL = &url.URL{
+		Scheme:   this.base.Scheme,
+		Host:     this.base.Host,
+		Path:     this.base.Path + "/" + strings.Trim(req.URL.Path, "/"),
+		RawPath:  this.base.EscapedPath() + "/" + strings.Trim(req.URL.EscapedPath(), "/"),
+		RawQuery: req.URL.RawQuery,
+	}
+	outbound.Host = this.base.Host
+	outbound.Header = http.Header{}
+	for _, header := range forwardedHeaders {
+		if values, ok := req.Header[header]; ok {
+			outbound.Header[header] = values
+		}
+	}
+	if this.options.User != "" {
+		outbound.SetBasicAuth(this.options.User, this.options.Password)
+	}
+	if this.options.UserHeader != "" {
+		outbound.Header.Set(this.options.UserHeader, user)
+	}
+
+	recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	this.proxy.ServeHTTP(recorder, outbound)
+	entry.Allowed = true
+	entry.Status = recorder.status
+	this.options.Audit(entry)
+}
@@ -0,0 +1,140 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package apiproxy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Role is the privilege level of a proxy user
+type Role string
+
+const (
+	RoleReadOnly Role = "read-only"
+	RoleOperator Role = "operator"
+	RoleAdmin    Role = "admin"
+)
+
+// RouteGroup is a group of API routes, which roles are granted use of as a whole
+type RouteGroup string
+
+const (
+	ReadGroup    RouteGroup = "read"    // read-only endpoints
+	OperateGroup RouteGroup = "operate" // day to day operations: discovery, maintenance, downtime, tags, acknowledgements
+	AdminGroup   RouteGroup = "admin"   // all other mutating endpoints, e.g. relocations, recoveries, raft and configuration
+)
+
+// RoleGroups lists the route groups each role may use
+var RoleGroups = map[Role][]RouteGroup{
+	RoleReadOnly: {ReadGroup},
+	RoleOperator: {ReadGroup, OperateGroup},
+	RoleAdmin:    {ReadGroup, OperateGroup, AdminGroup},
+}
+
+// DefaultOperateRoutes are the mutating routes of OperateGroup
+var DefaultOperateRoutes = []string{
+	"discover/:host/:port",
+	"async-discover/:host/:port",
+	"refresh/:host/:port",
+	"begin-maintenance/:host/:port/:owner/:reason",
+	"end-maintenance/:host/:port",
+	"end-maintenance/:maintenanceKey",
+	"begin-downtime/:host/:port/:owner/:reason",
+	"begin-downtime/:host/:port/:owner/:reason/:duration",
+	"end-downtime/:host/:port",
+	"extend-downtime/:host/:port/:duration",
+	"tag/:host/:port",
+	"tag/:host/:port/:tagName/:tagValue",
+	"untag/:host/:port",
+	"untag/:host/:port/:tagName",
+	"register-candidate/:host/:port/:promotionRule",
+	"ack-recovery/cluster/:clusterHint",
+	"ack-recovery/cluster/alias/:clusterAlias",
+	"ack-recovery/instance/:host/:port",
+	"ack-recovery/:recoveryId",
+	"ack-recovery/uid/:uid",
+}
+
+// Permissions map users to roles, and routes to route groups. Read-only endpoints are in ReadGroup,
+// mutating endpoints listed as operate routes are in OperateGroup, and all other routes are in AdminGroup:
+// a route orchestrator does not describe is only available to admins.
+type Permissions struct {
+	Roles       map[string]Role // roles by user
+	DefaultRole Role            // role of users not listed in Roles; such users are denied when empty
+	groups      map[string]RouteGroup
+}
+
+// NewPermissions groups the routes of given endpoints, as read off ReadEndpoints. operateRoutes are the
+// mutating routes of OperateGroup; DefaultOperateRoutes when nil.
+func NewPermissions(endpoints []Endpoint, operateRoutes []string, roles map[string]Role, defaultRole Role) *Permissions {
+	if operateRoutes == nil {
+		operateRoutes = DefaultOperateRoutes
+	}
+	isOperateRoute := make(map[string]bool)
+	for _, route := range operateRoutes {
+		isOperateRoute[strings.Trim(route, "/")] = true
+	}
+	permissions := &Permissions{
+		Roles:       roles,
+		DefaultRole: defaultRole,
+		groups:      make(map[string]RouteGroup),
+	}
+	for _, endpoint := range endpoints {
+		route := strings.Trim(endpoint.Path, "/")
+		switch {
+		case !endpoint.Mutating:
+			permissions.groups[route] = ReadGroup
+		case isOperateRoute[route]:
+			permissions.groups[route] = OperateGroup
+		default:
+			permissions.groups[route] = AdminGroup
+		}
+	}
+	return permissions
+}
+
+// RoleOf returns the role of given user
+func (this *Permissions) RoleOf(user string) Role {
+	if role, found := this.Roles[user]; found {
+		return role
+	}
+	return this.DefaultRole
+}
+
+// GroupOf returns the route group of given route
+func (this *Permissions) GroupOf(route string) RouteGroup {
+	if group, found := this.groups[strings.Trim(route, "/")]; found {
+		return group
+	}
+	return AdminGroup
+}
+
+// Check returns an error when given user may not use given route
+func (this *Permissions) Check(user string, route string) error {
+	role := this.RoleOf(user)
+	group := this.GroupOf(route)
+	for _, roleGroup := range RoleGroups[role] {
+		if roleGroup == group {
+			return nil
+		}
+	}
+	if role == "" {
+		return fmt.Errorf("%s has no role; %s is in the %s group", user, route, group)
+	}
+	return fmt.Errorf("%s has the %s role; %s is in the %s group", user, role, route, group)
+}
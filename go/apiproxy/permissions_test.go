@@ -0,0 +1,80 @@
+package apiproxy
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	test "github.com/openark/golib/tests"
+)
+
+var permissionsTestEndpoints = []Endpoint{
+	{Path: "clusters", Mutating: false},
+	{Path: "cluster/:clusterHint", Mutating: false},
+	{Path: "begin-downtime/:host/:port/:owner/:reason/:duration", Mutating: true},
+	{Path: "relocate/:host/:port/:belowHost/:belowPort", Mutating: true},
+}
+
+func TestPermissionsGroups(t *testing.T) {
+	permissions := NewPermissions(permissionsTestEndpoints, nil, nil, "")
+	test.S(t).ExpectEquals(permissions.GroupOf("clusters"), ReadGroup)
+	test.S(t).ExpectEquals(permissions.GroupOf("/cluster/:clusterHint"), ReadGroup)
+	test.S(t).ExpectEquals(permissions.GroupOf("begin-downtime/:host/:port/:owner/:reason/:duration"), OperateGroup)
+	test.S(t).ExpectEquals(permissions.GroupOf("relocate/:host/:port/:belowHost/:belowPort"), AdminGroup)
+	test.S(t).ExpectEquals(permissions.GroupOf("not-described"), AdminGroup)
+
+	permissions = NewPermissions(permissionsTestEndpoints, []string{"relocate/:host/:port/:belowHost/:belowPort"}, nil, "")
+	test.S(t).ExpectEquals(permissions.GroupOf("begin-downtime/:host/:port/:owner/:reason/:duration"), AdminGroup)
+	test.S(t).ExpectEquals(permissions.GroupOf("relocate/:host/:port/:belowHost/:belowPort"), OperateGroup)
+}
+
+func TestPermissionsCheck(t *testing.T) {
+	roles := map[string]Role{"oncall": RoleOperator, "dba": RoleAdmin}
+	permissions := NewPermissions(permissionsTestEndpoints, nil, roles, RoleReadOnly)
+	test.S(t).ExpectEquals(permissions.RoleOf("dev"), RoleReadOnly)
+	test.S(t).ExpectEquals(permissions.RoleOf("dba"), RoleAdmin)
+
+	downtime := "begin-downtime/:host/:port/:owner/:reason/:duration"
+	relocate := "relocate/:host/:port/:belowHost/:belowPort"
+	test.S(t).ExpectNil(permissions.Check("dev", "clusters"))
+	test.S(t).ExpectNotNil(permissions.Check("dev", downtime))
+	test.S(t).ExpectNil(permissions.Check("oncall", downtime))
+	test.S(t).ExpectNotNil(permissions.Check("oncall", relocate))
+	test.S(t).ExpectNil(permissions.Check("dba", relocate))
+	test.S(t).ExpectEquals(permissions.Check("oncall", relocate).Error(), "oncall has the operator role; relocate/:host/:port/:belowHost/:belowPort is in the admin group")
+
+	permissions.DefaultRole = ""
+	test.S(t).ExpectEquals(permissions.Check("dev", "clusters").Error(), "dev has no role; clusters is in the read group")
+}
+
+func TestServeHTTPPermissions(t *testing.T) {
+	upstream, requests := newUpstream(t)
+	defer upstream.Close()
+
+	endpoints, err := ReadEndpoints(context.Background(), upstream.URL+"/api", nil)
+	test.S(t).ExpectNil(err)
+	audit := []AuditEntry{}
+	handler, err := NewHandler(Options{
+		APIBase:      upstream.URL + "/api",
+		Routes:       []string{"clusters", "begin-downtime/:host/:port/:owner/:reason/:duration"},
+		Authenticate: func(req *http.Request) (string, error) { return req.Header.Get("X-Test-User"), nil },
+		Permissions:  NewPermissions(endpoints, nil, map[string]Role{"oncall": RoleOperator}, RoleReadOnly),
+		Audit:        func(entry AuditEntry) { audit = append(audit, entry) },
+	})
+	test.S(t).ExpectNil(err)
+
+	recorder, _ := serve(handler, "dev", "/clusters")
+	test.S(t).ExpectEquals(recorder.Code, http.StatusOK)
+	recorder, message := serve(handler, "dev", "/begin-downtime/db1/3306/dev/test/1h")
+	test.S(t).ExpectEquals(recorder.Code, http.StatusForbidden)
+	test.S(t).ExpectEquals(message, "Forbidden: dev has the read-only role; begin-downtime/:host/:port/:owner/:reason/:duration is in the operate group")
+	recorder, _ = serve(handler, "oncall", "/begin-downtime/db1/3306/oncall/test/1h")
+	test.S(t).ExpectEquals(recorder.Code, http.StatusOK)
+
+	// api-endpoints, then the two allowed requests
+	test.S(t).ExpectEquals(len(*requests), 3)
+	test.S(t).ExpectEquals(len(audit), 3)
+	test.S(t).ExpectFalse(audit[1].Allowed)
+	test.S(t).ExpectEquals(audit[1].Role, RoleReadOnly)
+	test.S(t).ExpectEquals(audit[2].Role, RoleOperator)
+}
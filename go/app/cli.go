@@ -17,6 +17,8 @@
 package app
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"os"
@@ -30,10 +32,12 @@ import (
 	"github.com/openark/golib/util"
 	"github.com/openark/orchestrator/go/agent"
 	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/http"
 	"github.com/openark/orchestrator/go/inst"
 	"github.com/openark/orchestrator/go/kv"
 	"github.com/openark/orchestrator/go/logic"
 	"github.com/openark/orchestrator/go/process"
+	orcutil "github.com/openark/orchestrator/go/util"
 )
 
 var thisInstanceKey *inst.InstanceKey
@@ -128,10 +132,10 @@ func assignThisInstanceKey() *inst.InstanceKey {
 func validateInstanceIsFound(instanceKey *inst.InstanceKey) (instance *inst.Instance) {
 	instance, _, err := inst.ReadInstance(instanceKey)
 	if err != nil {
-		log.Fatale(err)
+		fatale(err)
 	}
 	if instance == nil {
-		log.Fatalf("Instance not found: %+v", *instanceKey)
+		fatalf(ExitNotFound, "Instance not found: %+v", *instanceKey)
 	}
 	return instance
 }
@@ -140,7 +144,7 @@ func validateInstanceIsFound(instanceKey *inst.InstanceKey) (instance *inst.Inst
 // to take multiple instance names separated by a comma or whitespace.
 func CliWrapper(command string, strict bool, instances string, destination string, owner string, reason string, duration string, pattern string, clusterAlias string, pool string, hostnameFlag string) {
 	if config.Config.RaftEnabled && !*config.RuntimeCLIFlags.IgnoreRaftSetup {
-		log.Fatalf(`Orchestrator configured to run raft ("RaftEnabled": true). All access must go through the web API of the active raft node. You may use the orchestrator-client script which has a similar interface to the command line invocation. You may override this with --ignore-raft-setup`)
+		fatalf(ExitUsage, `Orchestrator configured to run raft ("RaftEnabled": true). All access must go through the web API of the active raft node. You may use the orchestrator-client script which has a similar interface to the command line invocation. You may override this with --ignore-raft-setup`)
 	}
 	r := regexp.MustCompile(`[ ,\r\n\t]+`)
 	tokens := r.Split(instances, -1)
@@ -172,6 +176,8 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		skipDatabaseCommands = true
 	case "dump-config":
 		skipDatabaseCommands = true
+	case "api-endpoints":
+		skipDatabaseCommands = true
 	}
 
 	instanceKey, err := inst.ParseResolveInstanceKey(instance)
@@ -203,7 +209,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		// get os username as owner
 		usr, err := user.Current()
 		if err != nil {
-			log.Fatale(err)
+			fatale(err)
 		}
 		owner = usr.Username
 	}
@@ -213,6 +219,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		process.ContinuousRegistration(string(process.OrchestratorExecutionCliMode), command)
 	}
 	kv.InitKVStores()
+	logic.InitFailoverHooks()
 
 	// begin commands
 	switch command {
@@ -221,11 +228,11 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if destinationKey == nil {
-				log.Fatal("Cannot deduce destination:", destination)
+				fatalf(ExitUsage, "Cannot deduce destination: %s", destination)
 			}
 			_, err := inst.RelocateBelow(instanceKey, destinationKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(fmt.Sprintf("%s<%s", instanceKey.DisplayString(), destinationKey.DisplayString()))
 		}
@@ -233,11 +240,11 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if destinationKey == nil {
-				log.Fatal("Cannot deduce destination:", destination)
+				fatalf(ExitUsage, "Cannot deduce destination: %s", destination)
 			}
 			replicas, _, err, errs := inst.RelocateReplicas(instanceKey, destinationKey, pattern)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			} else {
 				for _, e := range errs {
 					log.Errore(e)
@@ -247,15 +254,69 @@ func Cli(command string, strict bool, instance string, destination string, owner
 				}
 			}
 		}
+	case registerCliCommand("tree-balance", "Smart relocation", `Report depth and fan-out of a cluster's replication tree, with suggested relocations to rebalance it`):
+		{
+			clusterName := getClusterName(clusterAlias, instanceKey)
+			report, err := logic.AnalyzeTreeBalance(clusterName, logic.TreeBalanceThresholds{})
+			if err != nil {
+				fatale(err)
+			}
+			for _, load := range report.IntermediateMasters {
+				fmt.Println(fmt.Sprintf("%s\t%d\t%d\t%d\t%.0f%%", load.Key.DisplayString(), load.Depth, load.DirectReplicas, load.Replicas, load.ReplicaShare*100))
+			}
+			for _, relocation := range report.Relocations {
+				fmt.Println(fmt.Sprintf("%s<%s\t%s", relocation.Key.DisplayString(), relocation.Below.DisplayString(), relocation.Reason))
+			}
+		}
+	case registerCliCommand("rebalance-tree", "Smart relocation", `Apply the relocations suggested by tree-balance`):
+		{
+			clusterName := getClusterName(clusterAlias, instanceKey)
+			report, err := logic.RebalanceTree(clusterName, logic.TreeBalanceThresholds{})
+			if report != nil {
+				for _, relocation := range report.Relocations {
+					if relocation.Applied {
+						fmt.Println(fmt.Sprintf("%s<%s", relocation.Key.DisplayString(), relocation.Below.DisplayString()))
+					} else {
+						log.Errorf("%s<%s: %s", relocation.Key.DisplayString(), relocation.Below.DisplayString(), relocation.Error)
+					}
+				}
+			}
+			if err != nil {
+				fatale(err)
+			}
+		}
+	case registerCliCommand("bootstrap-cluster", "Smart relocation", `Set up a new replication tree: point given replicas at given master, optionally set alias & pool`):
+		{
+			if destinationKey == nil {
+				fatalf(ExitUsage, "Cannot deduce master (--destination): %s", destination)
+			}
+			replicaKeys := inst.NewInstanceKeyMap()
+			if err := replicaKeys.ReadCommaDelimitedList(instance); err != nil {
+				fatale(err)
+			}
+			options := logic.BootstrapClusterOptions{ClusterAlias: clusterAlias, Pool: pool}
+			result, err := logic.BootstrapCluster(*destinationKey, replicaKeys.GetInstanceKeys(), options)
+			if err != nil {
+				if result != nil {
+					for _, replica := range result.Replicas {
+						fmt.Printf("%s\t%s\n", replica.Key.DisplayString(), replica.Outcome)
+					}
+				}
+				fatale(err)
+			}
+			for _, replicaKey := range result.ReplicaKeys {
+				fmt.Println(replicaKey.DisplayString())
+			}
+		}
 	case registerCliCommand("take-siblings", "Smart relocation", `Turn all siblings of a replica into its sub-replicas.`):
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatal("Cannot deduce instance:", instance)
+				fatalf(ExitUsage, "Cannot deduce instance: %s", instance)
 			}
 			_, _, err := inst.TakeSiblings(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -263,7 +324,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatal("Cannot deduce instance:", instance)
+				fatalf(ExitUsage, "Cannot deduce instance: %s", instance)
 			}
 			validateInstanceIsFound(instanceKey)
 
@@ -277,7 +338,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			fmt.Println(fmt.Sprintf("%s lost: %d, trivial: %d, pseudo-gtid: %d",
 				promotedReplica.Key.DisplayString(), len(lostReplicas), len(equalReplicas), len(aheadReplicas)))
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 		}
 		// General replication commands
@@ -287,7 +348,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			instance, err := inst.MoveUp(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(fmt.Sprintf("%s<%s", instanceKey.DisplayString(), instance.MasterKey.DisplayString()))
 		}
@@ -295,12 +356,12 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatal("Cannot deduce instance:", instance)
+				fatalf(ExitUsage, "Cannot deduce instance: %s", instance)
 			}
 
 			movedReplicas, _, err, errs := inst.MoveUpReplicas(instanceKey, pattern)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			} else {
 				for _, e := range errs {
 					log.Errore(e)
@@ -314,11 +375,11 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if destinationKey == nil {
-				log.Fatal("Cannot deduce destination/sibling:", destination)
+				fatalf(ExitUsage, "Cannot deduce destination/sibling: %s", destination)
 			}
 			_, err := inst.MoveBelow(instanceKey, destinationKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(fmt.Sprintf("%s<%s", instanceKey.DisplayString(), destinationKey.DisplayString()))
 		}
@@ -326,11 +387,11 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if destinationKey == nil {
-				log.Fatal("Cannot deduce destination:", destination)
+				fatalf(ExitUsage, "Cannot deduce destination: %s", destination)
 			}
 			_, err := inst.MoveEquivalent(instanceKey, destinationKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(fmt.Sprintf("%s<%s", instanceKey.DisplayString(), destinationKey.DisplayString()))
 		}
@@ -340,7 +401,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			// destinationKey can be null, in which case the instance repoints to its existing master
 			instance, err := inst.Repoint(instanceKey, destinationKey, inst.GTIDHintNeutral)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(fmt.Sprintf("%s<%s", instanceKey.DisplayString(), instance.MasterKey.DisplayString()))
 		}
@@ -349,7 +410,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			repointedReplicas, err, errs := inst.RepointReplicasTo(instanceKey, pattern, destinationKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			} else {
 				for _, e := range errs {
 					log.Errore(e)
@@ -363,11 +424,11 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatal("Cannot deduce instance:", instance)
+				fatalf(ExitUsage, "Cannot deduce instance: %s", instance)
 			}
 			_, err := inst.TakeMaster(instanceKey, false)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -376,7 +437,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			_, err := inst.MakeCoMaster(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -384,12 +445,12 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatal("Cannot deduce instance:", instance)
+				fatalf(ExitUsage, "Cannot deduce instance: %s", instance)
 			}
 
 			instance, _, _, _, _, err := inst.GetCandidateReplica(instanceKey, false)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			} else {
 				fmt.Println(instance.Key.DisplayString())
 			}
@@ -398,7 +459,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatal("Cannot deduce instance:", instance)
+				fatalf(ExitUsage, "Cannot deduce instance: %s", instance)
 			}
 			validateInstanceIsFound(instanceKey)
 
@@ -408,7 +469,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			}
 			fmt.Println(promotedBinlogServer.Key.DisplayString())
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 		}
 	// move, GTID
@@ -416,11 +477,23 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if destinationKey == nil {
-				log.Fatal("Cannot deduce destination:", destination)
+				fatalf(ExitUsage, "Cannot deduce destination: %s", destination)
 			}
 			_, err := inst.MoveBelowGTID(instanceKey, destinationKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
+			}
+			fmt.Println(fmt.Sprintf("%s<%s", instanceKey.DisplayString(), destinationKey.DisplayString()))
+		}
+	case registerCliCommand("move-to-cluster", "GTID relocation", `Move a replica beneath a master of another cluster, via GTID or Pseudo-GTID, rolling back on failure`):
+		{
+			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
+			if destinationKey == nil {
+				fatalf(ExitUsage, "Cannot deduce destination: %s", destination)
+			}
+			_, err := inst.MoveToCluster(instanceKey, destinationKey)
+			if err != nil {
+				fatale(err)
 			}
 			fmt.Println(fmt.Sprintf("%s<%s", instanceKey.DisplayString(), destinationKey.DisplayString()))
 		}
@@ -428,11 +501,11 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if destinationKey == nil {
-				log.Fatal("Cannot deduce destination:", destination)
+				fatalf(ExitUsage, "Cannot deduce destination: %s", destination)
 			}
 			movedReplicas, _, err, errs := inst.MoveReplicasGTID(instanceKey, destinationKey, pattern)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			} else {
 				for _, e := range errs {
 					log.Errore(e)
@@ -446,7 +519,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatal("Cannot deduce instance:", instance)
+				fatalf(ExitUsage, "Cannot deduce instance: %s", instance)
 			}
 			validateInstanceIsFound(instanceKey)
 
@@ -459,7 +532,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			fmt.Println(fmt.Sprintf("%s lost: %d, moved: %d",
 				promotedReplica.Key.DisplayString(), len(lostReplicas), len(movedReplicas)))
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 		}
 		// Pseudo-GTID
@@ -467,11 +540,11 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if destinationKey == nil {
-				log.Fatal("Cannot deduce destination:", destination)
+				fatalf(ExitUsage, "Cannot deduce destination: %s", destination)
 			}
 			_, _, err := inst.MatchBelow(instanceKey, destinationKey, true)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(fmt.Sprintf("%s<%s", instanceKey.DisplayString(), destinationKey.DisplayString()))
 		}
@@ -480,7 +553,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			instance, _, err := inst.MatchUp(instanceKey, true)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(fmt.Sprintf("%s<%s", instanceKey.DisplayString(), instance.MasterKey.DisplayString()))
 		}
@@ -489,7 +562,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			instance, _, err := inst.RematchReplica(instanceKey, true)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(fmt.Sprintf("%s<%s", instanceKey.DisplayString(), instance.MasterKey.DisplayString()))
 		}
@@ -498,15 +571,15 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			// Move all replicas of "instance" beneath "destination"
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatal("Cannot deduce instance:", instance)
+				fatalf(ExitUsage, "Cannot deduce instance: %s", instance)
 			}
 			if destinationKey == nil {
-				log.Fatal("Cannot deduce destination:", destination)
+				fatalf(ExitUsage, "Cannot deduce destination: %s", destination)
 			}
 
 			matchedReplicas, _, err, errs := inst.MultiMatchReplicas(instanceKey, destinationKey, pattern)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			} else {
 				for _, e := range errs {
 					log.Errore(e)
@@ -520,12 +593,12 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatal("Cannot deduce instance:", instance)
+				fatalf(ExitUsage, "Cannot deduce instance: %s", instance)
 			}
 
 			matchedReplicas, _, err, errs := inst.MatchUpReplicas(instanceKey, pattern)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			} else {
 				for _, e := range errs {
 					log.Errore(e)
@@ -539,7 +612,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatal("Cannot deduce instance:", instance)
+				fatalf(ExitUsage, "Cannot deduce instance: %s", instance)
 			}
 			validateInstanceIsFound(instanceKey)
 
@@ -553,7 +626,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			fmt.Println(fmt.Sprintf("%s lost: %d, trivial: %d, pseudo-gtid: %d",
 				promotedReplica.Key.DisplayString(), len(lostReplicas), len(equalReplicas), len(aheadReplicas)))
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 		}
 		// General replication commands
@@ -562,7 +635,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			_, err := inst.EnableGTID(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -571,7 +644,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			_, err := inst.DisableGTID(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -581,10 +654,10 @@ func Cli(command string, strict bool, instance string, destination string, owner
 
 			instance, err := inst.ReadTopologyInstance(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			if instance == nil {
-				log.Fatalf("Instance not found: %+v", *instanceKey)
+				fatalf(ExitNotFound, "Instance not found: %+v", *instanceKey)
 			}
 			fmt.Println(instance.GtidErrant)
 		}
@@ -593,16 +666,25 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			_, err := inst.ErrantGTIDResetMaster(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
+	case registerCliCommand("gtid-errant-inject-empty", "Replication, general", `Apply errant GTID as empty transactions on cluster's master`):
+		{
+			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
+			_, clusterMaster, countInjectedTransactions, err := inst.ErrantGTIDInjectEmpty(instanceKey)
+			if err != nil {
+				fatale(err)
+			}
+			fmt.Println(fmt.Sprintf("%d %s", countInjectedTransactions, clusterMaster.Key.DisplayString()))
+		}
 	case registerCliCommand("skip-query", "Replication, general", `Skip a single statement on a replica; either when running with GTID or without`):
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			_, err := inst.SkipQuery(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -611,7 +693,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			_, err := inst.StopReplication(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -620,7 +702,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			_, err := inst.StartReplication(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -629,7 +711,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			_, err := inst.RestartReplication(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -638,7 +720,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			_, err := inst.ResetReplicationOperation(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -646,11 +728,11 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatal("Cannot deduce instance:", instance)
+				fatalf(ExitUsage, "Cannot deduce instance: %s", instance)
 			}
 			_, err := inst.DetachReplicaMasterHost(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -658,11 +740,11 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatal("Cannot deduce instance:", instance)
+				fatalf(ExitUsage, "Cannot deduce instance: %s", instance)
 			}
 			_, err := inst.ReattachReplicaMasterHost(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -670,23 +752,23 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatalf("Unresolved instance")
+				fatalf(ExitUsage, "Unresolved instance")
 			}
 			instance, err := inst.ReadTopologyInstance(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			if instance == nil {
-				log.Fatalf("Instance not found: %+v", *instanceKey)
+				fatalf(ExitNotFound, "Instance not found: %+v", *instanceKey)
 			}
 			var binlogCoordinates *inst.BinlogCoordinates
 
 			if binlogCoordinates, err = inst.ParseBinlogCoordinates(*config.RuntimeCLIFlags.BinlogFile); err != nil {
-				log.Fatalf("Expecing --binlog argument as file:pos")
+				fatalf(ExitUsage, "Expecing --binlog argument as file:pos")
 			}
 			_, err = inst.MasterPosWait(instanceKey, binlogCoordinates)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -695,7 +777,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			_, err := inst.SetSemiSyncMaster(instanceKey, true)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -704,7 +786,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			_, err := inst.SetSemiSyncMaster(instanceKey, false)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -713,7 +795,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			_, err := inst.SetSemiSyncReplica(instanceKey, true)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -722,7 +804,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			_, err := inst.SetSemiSyncReplica(instanceKey, false)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -730,11 +812,11 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatalf("Unresolved instance")
+				fatalf(ExitUsage, "Unresolved instance")
 			}
 			statements, err := inst.GetReplicationRestartPreserveStatements(instanceKey, *config.RuntimeCLIFlags.Statement)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			for _, statement := range statements {
 				fmt.Println(statement)
@@ -745,11 +827,11 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatalf("Unresolved instance")
+				fatalf(ExitUsage, "Unresolved instance")
 			}
 			instance := validateInstanceIsFound(instanceKey)
 			if destinationKey == nil {
-				log.Fatal("Cannot deduce target instance:", destination)
+				fatalf(ExitUsage, "Cannot deduce target instance: %s", destination)
 			}
 			otherInstance := validateInstanceIsFound(destinationKey)
 
@@ -757,11 +839,38 @@ func Cli(command string, strict bool, instance string, destination string, owner
 				fmt.Println(destinationKey.DisplayString())
 			}
 		}
+	case registerCliCommand("relocation-advice", "Replication information", `Advise whether relocating an instance (-i) below another (-d) would use GTID, Pseudo-GTID or binlog coordinates`):
+		{
+			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
+			if instanceKey == nil {
+				fatalf(ExitUsage, "Unresolved instance")
+			}
+			if destinationKey == nil {
+				fatalf(ExitUsage, "Cannot deduce target instance: %s", destination)
+			}
+			advice, err := inst.ReadRelocationMethodAdvice(instanceKey, destinationKey)
+			if err != nil {
+				fatale(err)
+			}
+			fmt.Println(fmt.Sprintf("%s\tcan-match:%t\tgtid-compatible:%t", advice.Method, advice.CanMatch, advice.GTIDCompatible))
+			for _, reason := range advice.Reasons {
+				fmt.Println(fmt.Sprintf("  %s", reason))
+			}
+		}
+	case registerCliCommand("pseudo-gtid-status", "Replication information", `Check whether Pseudo-GTID is active on a cluster`):
+		{
+			clusterName := getClusterName(clusterAlias, instanceKey)
+			status, err := inst.ReadClusterPseudoGTIDStatus(clusterName)
+			if err != nil {
+				fatale(err)
+			}
+			fmt.Println(fmt.Sprintf("%s\tactive:%t\tinjected:%t\t%d/%d", status.ClusterName, status.Active, status.RecentlyInjected, status.CountUsingPseudoGTID, status.CountInstances))
+		}
 	case registerCliCommand("is-replicating", "Replication information", `Is an instance (-i) actively replicating right now`):
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatalf("Unresolved instance")
+				fatalf(ExitUsage, "Unresolved instance")
 			}
 			instance := validateInstanceIsFound(instanceKey)
 			if instance.ReplicaRunning() {
@@ -772,7 +881,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatalf("Unresolved instance")
+				fatalf(ExitUsage, "Unresolved instance")
 			}
 			instance := validateInstanceIsFound(instanceKey)
 			if instance.ReplicationThreadsStopped() {
@@ -785,7 +894,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			_, err := inst.SetReadOnly(instanceKey, true)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -794,7 +903,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			_, err := inst.SetReadOnly(instanceKey, false)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -809,7 +918,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 				_, err = inst.FlushBinaryLogsTo(instanceKey, *config.RuntimeCLIFlags.BinlogFile)
 			}
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -818,12 +927,12 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			var err error
 			if *config.RuntimeCLIFlags.BinlogFile == "" {
-				log.Fatal("expecting --binlog value")
+				fatalf(ExitUsage, "expecting --binlog value")
 			}
 
 			_, err = inst.PurgeBinaryLogsTo(instanceKey, *config.RuntimeCLIFlags.BinlogFile, false)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -831,18 +940,18 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatalf("Unresolved instance")
+				fatalf(ExitUsage, "Unresolved instance")
 			}
 			instance, err := inst.ReadTopologyInstance(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			if instance == nil {
-				log.Fatalf("Instance not found: %+v", *instanceKey)
+				fatalf(ExitNotFound, "Instance not found: %+v", *instanceKey)
 			}
 			coordinates, text, err := inst.FindLastPseudoGTIDEntry(instance, instance.RelaylogCoordinates, nil, strict, nil)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(fmt.Sprintf("%+v:%s", *coordinates, text))
 		}
@@ -850,28 +959,44 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatalf("Unresolved instance")
+				fatalf(ExitUsage, "Unresolved instance")
 			}
 			errantBinlogs, err := inst.LocateErrantGTID(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			for _, binlog := range errantBinlogs {
 				fmt.Println(binlog)
 			}
 		}
+	case registerCliCommand("locate-cluster-gtid-errant", "Binary logs", `List binary logs containing errant GTIDs, across the instances of a cluster`):
+		{
+			clusterName := getClusterName(clusterAlias, instanceKey)
+			scan, err := logic.ScanClusterErrantGTID(clusterName)
+			if err != nil {
+				fatale(err)
+			}
+			for _, instance := range scan.Instances {
+				for _, binlog := range instance.ErrantBinlogs {
+					fmt.Println(fmt.Sprintf("%s\t%s", instance.Key.DisplayString(), binlog))
+				}
+			}
+			for _, scanError := range scan.Errors {
+				log.Errorf("%s: %s", scanError.Key.DisplayString(), scanError.Error)
+			}
+		}
 	case registerCliCommand("last-executed-relay-entry", "Binary logs", `Find coordinates of last executed relay log entry`):
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatalf("Unresolved instance")
+				fatalf(ExitUsage, "Unresolved instance")
 			}
 			instance, err := inst.ReadTopologyInstance(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			if instance == nil {
-				log.Fatalf("Instance not found: %+v", *instanceKey)
+				fatalf(ExitNotFound, "Instance not found: %+v", *instanceKey)
 			}
 			minCoordinates, err := inst.GetPreviousKnownRelayLogCoordinatesForInstance(instance)
 			if err != nil {
@@ -879,7 +1004,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			}
 			binlogEvent, err := inst.GetLastExecutedEntryInRelayLogs(instance, minCoordinates, instance.RelaylogCoordinates)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(fmt.Sprintf("%+v:%d", *binlogEvent, binlogEvent.NextEventPos))
 		}
@@ -887,57 +1012,57 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatalf("Unresolved instance")
+				fatalf(ExitUsage, "Unresolved instance")
 			}
 			instance, err := inst.ReadTopologyInstance(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			if instance == nil {
-				log.Fatalf("Instance not found: %+v", *instanceKey)
+				fatalf(ExitNotFound, "Instance not found: %+v", *instanceKey)
 			}
 			if destinationKey == nil {
-				log.Fatal("Cannot deduce target instance:", destination)
+				fatalf(ExitUsage, "Cannot deduce target instance: %s", destination)
 			}
 			otherInstance, err := inst.ReadTopologyInstance(destinationKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			if otherInstance == nil {
-				log.Fatalf("Instance not found: %+v", *destinationKey)
+				fatalf(ExitNotFound, "Instance not found: %+v", *destinationKey)
 			}
 
 			var relaylogCoordinates *inst.BinlogCoordinates
 			if *config.RuntimeCLIFlags.BinlogFile != "" {
 				if relaylogCoordinates, err = inst.ParseBinlogCoordinates(*config.RuntimeCLIFlags.BinlogFile); err != nil {
-					log.Fatalf("Expecing --binlog argument as file:pos")
+					fatalf(ExitUsage, "Expecing --binlog argument as file:pos")
 				}
 			}
 			instanceCoordinates, correlatedCoordinates, nextCoordinates, _, err := inst.CorrelateRelaylogCoordinates(instance, relaylogCoordinates, otherInstance)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(fmt.Sprintf("%+v;%+v;%+v", *instanceCoordinates, *correlatedCoordinates, *nextCoordinates))
 		}
 	case registerCliCommand("find-binlog-entry", "Binary logs", `Get binlog file:pos of entry given by --pattern (exact full match, not a regular expression) in a given instance`):
 		{
 			if pattern == "" {
-				log.Fatal("No pattern given")
+				fatalf(ExitUsage, "No pattern given")
 			}
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatalf("Unresolved instance")
+				fatalf(ExitUsage, "Unresolved instance")
 			}
 			instance, err := inst.ReadTopologyInstance(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			if instance == nil {
-				log.Fatalf("Instance not found: %+v", *instanceKey)
+				fatalf(ExitNotFound, "Instance not found: %+v", *instanceKey)
 			}
 			coordinates, err := inst.SearchEntryInInstanceBinlogs(instance, pattern, false, nil)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(fmt.Sprintf("%+v", *coordinates))
 		}
@@ -945,40 +1070,40 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatalf("Unresolved instance")
+				fatalf(ExitUsage, "Unresolved instance")
 			}
 			instance, err := inst.ReadTopologyInstance(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			if instance == nil {
-				log.Fatalf("Instance not found: %+v", *instanceKey)
+				fatalf(ExitNotFound, "Instance not found: %+v", *instanceKey)
 			}
 			if !instance.LogBinEnabled {
 				log.Fatalf("Instance does not have binary logs: %+v", *instanceKey)
 			}
 			if destinationKey == nil {
-				log.Fatal("Cannot deduce target instance:", destination)
+				fatalf(ExitUsage, "Cannot deduce target instance: %s", destination)
 			}
 			otherInstance, err := inst.ReadTopologyInstance(destinationKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			if otherInstance == nil {
-				log.Fatalf("Instance not found: %+v", *destinationKey)
+				fatalf(ExitNotFound, "Instance not found: %+v", *destinationKey)
 			}
 			var binlogCoordinates *inst.BinlogCoordinates
 			if *config.RuntimeCLIFlags.BinlogFile == "" {
 				binlogCoordinates = &instance.SelfBinlogCoordinates
 			} else {
 				if binlogCoordinates, err = inst.ParseBinlogCoordinates(*config.RuntimeCLIFlags.BinlogFile); err != nil {
-					log.Fatalf("Expecing --binlog argument as file:pos")
+					fatalf(ExitUsage, "Expecing --binlog argument as file:pos")
 				}
 			}
 
 			coordinates, _, err := inst.CorrelateBinlogCoordinates(instance, binlogCoordinates, otherInstance)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(fmt.Sprintf("%+v", *coordinates))
 		}
@@ -986,18 +1111,21 @@ func Cli(command string, strict bool, instance string, destination string, owner
 	case registerCliCommand("submit-pool-instances", "Pools", `Submit a pool name with a list of instances in that pool`):
 		{
 			if pool == "" {
-				log.Fatal("Please submit --pool")
+				fatalf(ExitUsage, "Please submit --pool")
 			}
-			err := inst.ApplyPoolInstances(inst.NewPoolInstancesSubmission(pool, instance))
-			if err != nil {
-				log.Fatale(err)
+			submission := inst.NewPoolInstancesSubmission(pool, instance)
+			if err := submission.Validate(); err != nil {
+				fatale(err)
+			}
+			if err := inst.ApplyPoolInstances(submission); err != nil {
+				fatale(err)
 			}
 		}
 	case registerCliCommand("cluster-pool-instances", "Pools", `List all pools and their associated instances`):
 		{
 			clusterPoolInstances, err := inst.ReadAllClusterPoolInstances()
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			for _, clusterPoolInstance := range clusterPoolInstances {
 				fmt.Println(fmt.Sprintf("%s\t%s\t%s\t%s:%d", clusterPoolInstance.ClusterName, clusterPoolInstance.ClusterAlias, clusterPoolInstance.Pool, clusterPoolInstance.Hostname, clusterPoolInstance.Port))
@@ -1009,7 +1137,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 
 			instances, err := inst.GetHeuristicClusterPoolInstances(clusterName, pool)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			} else {
 				for _, instance := range instances {
 					fmt.Println(instance.Key.DisplayString())
@@ -1020,11 +1148,11 @@ func Cli(command string, strict bool, instance string, destination string, owner
 	case registerCliCommand("find", "Information", `Find instances whose hostname matches given regex pattern`):
 		{
 			if pattern == "" {
-				log.Fatal("No pattern given")
+				fatalf(ExitUsage, "No pattern given")
 			}
 			instances, err := inst.FindInstances(pattern)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			} else {
 				for _, instance := range instances {
 					fmt.Println(instance.Key.DisplayString())
@@ -1034,11 +1162,11 @@ func Cli(command string, strict bool, instance string, destination string, owner
 	case registerCliCommand("search", "Information", `Search instances by name, version, version comment, port`):
 		{
 			if pattern == "" {
-				log.Fatal("No pattern given")
+				fatalf(ExitUsage, "No pattern given")
 			}
 			instances, err := inst.SearchInstances(pattern)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			} else {
 				for _, instance := range instances {
 					fmt.Println(instance.Key.DisplayString())
@@ -1049,7 +1177,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			clusters, err := inst.ReadClusters()
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(strings.Join(clusters, "\n"))
 		}
@@ -1057,17 +1185,37 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			clusters, err := inst.ReadClustersInfo("")
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			for _, cluster := range clusters {
 				fmt.Println(fmt.Sprintf("%s\t%s", cluster.ClusterName, cluster.ClusterAlias))
 			}
 		}
+	case registerCliCommand("cluster-aliases", "Information", `List cluster alias mappings, including manual overrides`):
+		{
+			aliases, err := inst.ReadClusterAliases()
+			if err != nil {
+				fatale(err)
+			}
+			for _, alias := range aliases {
+				fmt.Println(fmt.Sprintf("%s\t%s\t%s", alias.ClusterName, alias.Alias, alias.OverrideAlias))
+			}
+		}
+	case registerCliCommand("cluster-domains", "Information", `List the registered domain names of clusters`):
+		{
+			domainNames, err := inst.ReadClusterDomainNames()
+			if err != nil {
+				fatale(err)
+			}
+			for _, domainName := range domainNames {
+				fmt.Println(fmt.Sprintf("%s\t%s\t%s", domainName.ClusterName, domainName.DomainName, domainName.LastRegistered))
+			}
+		}
 	case registerCliCommand("all-clusters-masters", "Information", `List of writeable masters, one per cluster`):
 		{
 			instances, err := inst.ReadWriteableClustersMasters()
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			} else {
 				for _, instance := range instances {
 					fmt.Println(instance.Key.DisplayString())
@@ -1079,7 +1227,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			clusterName := getClusterName(clusterAlias, instanceKey)
 			output, err := inst.ASCIITopology(clusterName, pattern, false, false)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(output)
 		}
@@ -1088,7 +1236,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			clusterName := getClusterName(clusterAlias, instanceKey)
 			output, err := inst.ASCIITopology(clusterName, pattern, true, false)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(output)
 		}
@@ -1097,7 +1245,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			clusterName := getClusterName(clusterAlias, instanceKey)
 			output, err := inst.ASCIITopology(clusterName, pattern, false, true)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(output)
 		}
@@ -1105,18 +1253,69 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instances, err := inst.SearchInstances("")
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			} else {
 				for _, instance := range instances {
 					fmt.Println(instance.Key.DisplayString())
 				}
 			}
 		}
+	case registerCliCommand("inventory", "Information", `Stream all known instances, as JSON lines, page by page`):
+		{
+			writer := orcutil.NewJSONLinesWriter(os.Stdout)
+			_, _, err := inst.SyncInventory(context.Background(), "", "", inst.DefaultInventoryPageSize, func(instances [](*inst.Instance)) error {
+				for _, instance := range instances {
+					if err := writer.Write(instance); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				fatale(err)
+			}
+		}
+	case registerCliCommand("problems", "Information", `List instances with known problems, as JSON lines, optionally of a given cluster`):
+		{
+			clusterName := ""
+			if clusterAlias != "" || instance != "" {
+				clusterName = getClusterName(clusterAlias, instanceKey)
+			}
+			instances, err := inst.ReadProblemInstances(clusterName)
+			if err != nil {
+				fatale(err)
+			}
+			writer := orcutil.NewJSONLinesWriter(os.Stdout)
+			for _, instance := range instances {
+				if err := writer.Write(instance); err != nil {
+					fatale(err)
+				}
+			}
+		}
+	case registerCliCommand("export-audit", "Information", `Export all audit entries, oldest first, as JSON lines, optionally of a given instance`):
+		{
+			var auditedInstanceKey *inst.InstanceKey
+			if instance != "" {
+				auditedInstanceKey = instanceKey
+			}
+			writer := orcutil.NewJSONLinesWriter(os.Stdout)
+			_, err := inst.ExportAudit(context.Background(), auditedInstanceKey, 0, func(audits []inst.Audit) error {
+				for _, audit := range audits {
+					if err := writer.Write(audit); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				fatale(err)
+			}
+		}
 	case registerCliCommand("which-instance", "Information", `Output the fully-qualified hostname:port representation of the given instance, or error if unknown`):
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatalf("Unable to get master: unresolved instance")
+				fatalf(ExitUsage, "Unable to get master: unresolved instance")
 			}
 			instance := validateInstanceIsFound(instanceKey)
 			fmt.Println(instance.Key.DisplayString())
@@ -1131,7 +1330,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			clusterName := getClusterName(clusterAlias, instanceKey)
 			clusterInfo, err := inst.ReadClusterInfo(clusterName)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(clusterInfo.ClusterAlias)
 		}
@@ -1140,7 +1339,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			clusterName := getClusterName(clusterAlias, instanceKey)
 			clusterInfo, err := inst.ReadClusterInfo(clusterName)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(clusterInfo.ClusterDomain)
 		}
@@ -1149,7 +1348,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			clusterName := getClusterName(clusterAlias, instanceKey)
 			instanceKey, err := inst.GetHeuristicClusterDomainInstanceAttribute(clusterName)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -1158,19 +1357,34 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			clusterName := getClusterName(clusterAlias, instanceKey)
 			masters, err := inst.ReadClusterMaster(clusterName)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			if len(masters) == 0 {
 				log.Fatalf("No writeable masters found for cluster %+v", clusterName)
 			}
 			fmt.Println(masters[0].Key.DisplayString())
 		}
+	case registerCliCommand("which-cluster-heads", "Information", `Output the master of a given cluster, or its active and passive co-masters`):
+		{
+			clusterName := getClusterName(clusterAlias, instanceKey)
+			heads, err := inst.ReadClusterHeads(clusterName)
+			if err != nil {
+				fatale(err)
+			}
+			if heads.Active == nil {
+				fatalf(ExitNotFound, "No masters found for cluster %+v", clusterName)
+			}
+			fmt.Println(fmt.Sprintf("%s\tactive", heads.Active.Key.DisplayString()))
+			if heads.Passive != nil {
+				fmt.Println(fmt.Sprintf("%s\tpassive", heads.Passive.Key.DisplayString()))
+			}
+		}
 	case registerCliCommand("which-cluster-instances", "Information", `Output the list of instances participating in same cluster as given instance`):
 		{
 			clusterName := getClusterName(clusterAlias, instanceKey)
 			instances, err := inst.ReadClusterInstances(clusterName)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			for _, clusterInstance := range instances {
 				fmt.Println(clusterInstance.Key.DisplayString())
@@ -1181,7 +1395,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			clusterName := getClusterName(clusterAlias, instanceKey)
 			instances, err := inst.GetClusterOSCReplicas(clusterName)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			for _, clusterInstance := range instances {
 				fmt.Println(clusterInstance.Key.DisplayString())
@@ -1192,7 +1406,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			clusterName := getClusterName(clusterAlias, instanceKey)
 			instances, err := inst.GetClusterGhostReplicas(clusterName)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			for _, clusterInstance := range instances {
 				fmt.Println(clusterInstance.Key.DisplayString())
@@ -1202,19 +1416,34 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatalf("Unable to get master: unresolved instance")
+				fatalf(ExitUsage, "Unable to get master: unresolved instance")
 			}
 			instance := validateInstanceIsFound(instanceKey)
 			if instance.MasterKey.IsValid() {
 				fmt.Println(instance.MasterKey.DisplayString())
 			}
 		}
+	case registerCliCommand("instance-diagnosis", "Information", `Classify an instance's replication errors, and suggest remediations to its problems`):
+		{
+			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
+			if instanceKey == nil {
+				fatalf(ExitUsage, "Unable to diagnose: unresolved instance")
+			}
+			instance := validateInstanceIsFound(instanceKey)
+			diagnosis := inst.DiagnoseInstance(instance)
+			for _, replicationError := range diagnosis.Errors {
+				fmt.Printf("%s\t%s\t%s\n", replicationError.Thread, replicationError.Class, replicationError.Error)
+			}
+			for _, remediation := range diagnosis.Remediations {
+				fmt.Printf("remediation\t%s\t%s\n", remediation.Description, remediation.Command)
+			}
+		}
 	case registerCliCommand("which-downtimed-instances", "Information", `List instances currently downtimed, potentially filtered by cluster`):
 		{
 			clusterName := getClusterName(clusterAlias, instanceKey)
 			instances, err := inst.ReadDowntimedInstances(clusterName)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			for _, clusterInstance := range instances {
 				fmt.Println(clusterInstance.Key.DisplayString())
@@ -1224,11 +1453,11 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatalf("Unable to get replicas: unresolved instance")
+				fatalf(ExitUsage, "Unable to get replicas: unresolved instance")
 			}
 			replicas, err := inst.ReadReplicaInstances(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			for _, replica := range replicas {
 				fmt.Println(replica.Key.DisplayString())
@@ -1238,7 +1467,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instances, err := inst.ReadLostInRecoveryInstances("")
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			for _, instance := range instances {
 				fmt.Println(instance.Key.DisplayString())
@@ -1248,7 +1477,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatalf("Unable to get status: unresolved instance")
+				fatalf(ExitUsage, "Unable to get status: unresolved instance")
 			}
 			instance := validateInstanceIsFound(instanceKey)
 			fmt.Println(instance.HumanReadableDescription())
@@ -1258,7 +1487,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			clusterName := getClusterName(clusterAlias, instanceKey)
 			lag, err := inst.GetClusterHeuristicLag(clusterName)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(lag)
 		}
@@ -1269,7 +1498,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 
 			kvPairs, _, err := logic.SubmitMastersToKvStores(clusterName, true)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			for _, kvPair := range kvPairs {
 				fmt.Println(fmt.Sprintf("%s:%s", kvPair.Key, kvPair.Value))
@@ -1281,7 +1510,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			tags, err := inst.ReadInstanceTags(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			for _, tag := range tags {
 				fmt.Println(tag.String())
@@ -1292,23 +1521,23 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			tag, err := inst.ParseTag(*config.RuntimeCLIFlags.Tag)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 
 			tagExists, err := inst.ReadInstanceTag(instanceKey, tag)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			if tagExists {
 				fmt.Println(tag.TagValue)
 			}
 		}
-	case registerCliCommand("tagged", "tags", `List instances tagged by tag-string. Format: "tagname" or "tagname=tagvalue" or comma separated "tag0,tag1=val1,tag2" for intersection of all.`):
+	case registerCliCommand("tagged", "tags", `List instances tagged by tag-string. Format: "tagname" or "tagname=tagvalue" or comma separated "tag0,tag1=val1,tag2" for intersection of all, or "|" separated alternatives.`):
 		{
 			tagsString := *config.RuntimeCLIFlags.Tag
 			instanceKeyMap, err := inst.GetInstanceKeysByTags(tagsString)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			keysDisplayStrings := []string{}
 			for _, key := range instanceKeyMap.GetInstanceKeys() {
@@ -1324,7 +1553,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			tag, err := inst.ParseTag(*config.RuntimeCLIFlags.Tag)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			inst.PutInstanceTag(instanceKey, tag)
 			fmt.Println(instanceKey.DisplayString())
@@ -1334,11 +1563,11 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			tag, err := inst.ParseTag(*config.RuntimeCLIFlags.Tag)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			untagged, err := inst.Untag(instanceKey, tag)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			for _, key := range untagged.GetInstanceKeys() {
 				fmt.Println(key.DisplayString())
@@ -1348,11 +1577,11 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			tag, err := inst.ParseTag(*config.RuntimeCLIFlags.Tag)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			untagged, err := inst.Untag(nil, tag)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			for _, key := range untagged.GetInstanceKeys() {
 				fmt.Println(key.DisplayString())
@@ -1360,29 +1589,63 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		}
 
 		// Instance management
+	case registerCliCommand("instance-metadata", "Instance management", `List metadata overrides of an instance (alias, data center, region, physical environment)`):
+		{
+			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
+			if instanceKey == nil {
+				fatalf(ExitUsage, "Unresolved instance")
+			}
+			overrides, err := inst.ReadInstanceMetadataOverrides(instanceKey)
+			if err != nil {
+				fatale(err)
+			}
+			for _, field := range inst.InstanceMetadataFields {
+				if value, found := overrides[field]; found {
+					fmt.Println(fmt.Sprintf("%s=%s", field, value))
+				}
+			}
+		}
+	case registerCliCommand("set-instance-metadata", "Instance management", `Override metadata of an instance (alias, data center, region, physical environment)`):
+		{
+			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
+			if instanceKey == nil {
+				fatalf(ExitUsage, "Unresolved instance")
+			}
+			if pattern == "" {
+				fatalf(ExitUsage, "--pattern option required")
+			}
+			metadata, err := inst.ParseInstanceMetadata(instanceKey, pattern, inst.GetMaintenanceOwner())
+			if err != nil {
+				fatale(err)
+			}
+			if err := inst.SetInstanceMetadata(metadata); err != nil {
+				fatale(err)
+			}
+			fmt.Println(instanceKey.DisplayString())
+		}
 	case registerCliCommand("discover", "Instance management", `Lookup an instance, investigate it`):
 		{
 			if instanceKey == nil {
 				instanceKey = thisInstanceKey
 			}
 			if instanceKey == nil {
-				log.Fatalf("Cannot figure instance key")
+				fatalf(ExitUsage, "Cannot figure instance key")
 			}
 			instance, err := inst.ReadTopologyInstance(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instance.Key.DisplayString())
 		}
 	case registerCliCommand("forget", "Instance management", `Forget about an instance's existence`):
 		{
 			if rawInstanceKey == nil {
-				log.Fatal("Cannot deduce instance:", instance)
+				fatalf(ExitUsage, "Cannot deduce instance: %s", instance)
 			}
 			instanceKey, _ = inst.FigureInstanceKey(rawInstanceKey, nil)
 			err := inst.ForgetInstance(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -1390,16 +1653,16 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if reason == "" {
-				log.Fatal("--reason option required")
+				fatalf(ExitUsage, "--reason option required")
 			}
 			var durationSeconds int = 0
 			if duration != "" {
 				durationSeconds, err = util.SimpleTimeToSeconds(duration)
 				if err != nil {
-					log.Fatale(err)
+					fatale(err)
 				}
 				if durationSeconds < 0 {
-					log.Fatalf("Duration value must be non-negative. Given value: %d", durationSeconds)
+					fatalf(ExitUsage, "Duration value must be non-negative. Given value: %d", durationSeconds)
 				}
 			}
 			maintenanceKey, err := inst.BeginBoundedMaintenance(instanceKey, inst.GetMaintenanceOwner(), reason, uint(durationSeconds), true)
@@ -1408,7 +1671,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 				log.Infof("Maintenance duration: %d seconds", durationSeconds)
 			}
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -1417,16 +1680,36 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			_, err := inst.EndMaintenanceByInstanceKey(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
+			}
+			fmt.Println(instanceKey.DisplayString())
+		}
+	case registerCliCommand("take-over-maintenance", "Instance management", `Transfer the maintenance lock of an instance to a new owner`):
+		{
+			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
+			previousOwner, err := inst.TakeOverMaintenance(instanceKey, inst.GetMaintenanceOwner())
+			if err != nil {
+				fatale(err)
 			}
+			log.Infof("Maintenance taken over from %s", previousOwner)
 			fmt.Println(instanceKey.DisplayString())
 		}
+	case registerCliCommand("maintenance-by-owner", "Instance management", `List active maintenance owned by an owner, or by any identity of a system`):
+		{
+			maintenanceList, err := inst.ReadMaintenanceByOwner(inst.GetMaintenanceOwner())
+			if err != nil {
+				fatale(err)
+			}
+			for _, maintenance := range maintenanceList {
+				fmt.Println(fmt.Sprintf("%s\t%s\t%s\t%s", maintenance.Key.DisplayString(), maintenance.EndTimestamp, maintenance.Owner, maintenance.Reason))
+			}
+		}
 	case registerCliCommand("in-maintenance", "Instance management", `Check whether instance is under maintenance`):
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			inMaintenance, err := inst.InMaintenance(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			if inMaintenance {
 				fmt.Println(instanceKey.DisplayString())
@@ -1436,16 +1719,16 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if reason == "" {
-				log.Fatal("--reason option required")
+				fatalf(ExitUsage, "--reason option required")
 			}
 			var durationSeconds int = 0
 			if duration != "" {
 				durationSeconds, err = util.SimpleTimeToSeconds(duration)
 				if err != nil {
-					log.Fatale(err)
+					fatale(err)
 				}
 				if durationSeconds < 0 {
-					log.Fatalf("Duration value must be non-negative. Given value: %d", durationSeconds)
+					fatalf(ExitUsage, "Duration value must be non-negative. Given value: %d", durationSeconds)
 				}
 			}
 			duration := time.Duration(durationSeconds) * time.Second
@@ -1453,7 +1736,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			if err == nil {
 				log.Infof("Downtime duration: %d seconds", durationSeconds)
 			} else {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -1462,21 +1745,113 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			_, err := inst.EndDowntime(instanceKey)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
+			}
+			fmt.Println(instanceKey.DisplayString())
+		}
+	case registerCliCommand("extend-downtime", "Instance management", `Extend an active downtime by given duration`):
+		{
+			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
+			durationSeconds, err := util.SimpleTimeToSeconds(duration)
+			if err != nil {
+				fatale(err)
+			}
+			if durationSeconds <= 0 {
+				fatalf(ExitUsage, "--duration option required, must be positive. Given value: %d", durationSeconds)
+			}
+			wasDowntimed, err := inst.ExtendDowntime(&inst.Downtime{Key: instanceKey, Duration: time.Duration(durationSeconds) * time.Second})
+			if err != nil {
+				fatale(err)
+			}
+			if !wasDowntimed {
+				log.Fatalf("%+v is not downtimed", *instanceKey)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
+	case registerCliCommand("forget-cluster-alias", "Instance management", `Forget the alias of a cluster, including any manual override`):
+		{
+			clusterName := getClusterName(clusterAlias, instanceKey)
+			if err := inst.ForgetClusterAlias(clusterName); err != nil {
+				fatale(err)
+			}
+			fmt.Println(clusterName)
+		}
+	case registerCliCommand("set-cluster-domain", "Instance management", `Register the domain name of a cluster's master`):
+		{
+			clusterName := getClusterName(clusterAlias, instanceKey)
+			if hostnameFlag == "" {
+				fatalf(ExitUsage, "--hostname option required")
+			}
+			if err := logic.SetClusterDomain(clusterName, hostnameFlag); err != nil {
+				fatale(err)
+			}
+			fmt.Println(clusterName)
+		}
+	case registerCliCommand("forget-cluster-domain", "Instance management", `Forget the domain name of a cluster`):
+		{
+			clusterName := getClusterName(clusterAlias, instanceKey)
+			if err := logic.ForgetClusterDomain(clusterName); err != nil {
+				fatale(err)
+			}
+			fmt.Println(clusterName)
+		}
+	case registerCliCommand("acquire-cluster-lock", "Instance management", `Acquire an advisory lock on a cluster`):
+		{
+			clusterName := getClusterName(clusterAlias, instanceKey)
+			if reason == "" {
+				fatalf(ExitUsage, "--reason option required")
+			}
+			var durationSeconds int = 0
+			if duration != "" {
+				durationSeconds, err = util.SimpleTimeToSeconds(duration)
+				if err != nil {
+					fatale(err)
+				}
+				if durationSeconds < 0 {
+					fatalf(ExitUsage, "Duration value must be non-negative. Given value: %d", durationSeconds)
+				}
+			}
+			lock, err := inst.AcquireClusterLock(clusterName, inst.GetMaintenanceOwner(), reason, uint(durationSeconds))
+			if err != nil {
+				fatale(err)
+			}
+			log.Infof("Cluster lock held until %s", lock.EndTimestamp)
+			fmt.Println(clusterName)
+		}
+	case registerCliCommand("release-cluster-lock", "Instance management", `Release an advisory lock on a cluster`):
+		{
+			clusterName := getClusterName(clusterAlias, instanceKey)
+			released, err := inst.ReleaseClusterLock(clusterName, inst.GetMaintenanceOwner())
+			if err != nil {
+				fatale(err)
+			}
+			if !released {
+				log.Fatalf("Cluster %s is not locked by %s", clusterName, inst.GetMaintenanceOwner())
+			}
+			fmt.Println(clusterName)
+		}
+	case registerCliCommand("cluster-lock", "Instance management", `Show the advisory lock held on a cluster, if any`):
+		{
+			clusterName := getClusterName(clusterAlias, instanceKey)
+			lock, err := inst.ReadClusterLock(clusterName)
+			if err != nil {
+				fatale(err)
+			}
+			if lock != nil {
+				fmt.Println(fmt.Sprintf("%s\t%s\t%s\t%s", lock.ClusterName, lock.Owner, lock.EndTimestamp, lock.Reason))
+			}
+		}
 		// Recovery & analysis
 	case registerCliCommand("recover", "Recovery", `Do auto-recovery given a dead instance`), registerCliCommand("recover-lite", "Recovery", `Do auto-recovery given a dead instance. Orchestrator chooses the best course of actionwithout executing external processes`):
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			if instanceKey == nil {
-				log.Fatal("Cannot deduce instance:", instance)
+				fatalf(ExitUsage, "Cannot deduce instance: %s", instance)
 			}
 
 			recoveryAttempted, promotedInstanceKey, err := logic.CheckAndRecover(instanceKey, destinationKey, (command == "recover-lite"))
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			if recoveryAttempted {
 				if promotedInstanceKey == nil {
@@ -1485,12 +1860,30 @@ func Cli(command string, strict bool, instance string, destination string, owner
 				fmt.Println(promotedInstanceKey.DisplayString())
 			}
 		}
+	case registerCliCommand("recover-auto", "Recovery", `Do auto-recovery given a dead instance, choosing whether to execute external processes by policy`):
+		{
+			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
+			if instanceKey == nil {
+				fatalf(ExitUsage, "Cannot deduce instance: %s", instance)
+			}
+			result, err := logic.RecoverAuto(instanceKey, destinationKey)
+			if err != nil {
+				fatale(err)
+			}
+			log.Infof("recover-auto: %s recovery: %s", result.Mode, result.ModeReason)
+			if result.RecoveryAttempted {
+				if result.PromotedReplicaKey == nil {
+					log.Fatalf("Recovery attempted yet no replica promoted")
+				}
+				fmt.Println(result.PromotedReplicaKey.DisplayString())
+			}
+		}
 	case registerCliCommand("force-master-failover", "Recovery", `Forcibly discard master and initiate a failover, even if orchestrator doesn't see a problem. This command lets orchestrator choose the replacement master`):
 		{
 			clusterName := getClusterName(clusterAlias, instanceKey)
 			topologyRecovery, err := logic.ForceMasterFailover(clusterName)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(topologyRecovery.SuccessorKey.DisplayString())
 		}
@@ -1498,12 +1891,12 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			clusterName := getClusterName(clusterAlias, instanceKey)
 			if destinationKey == nil {
-				log.Fatal("Cannot deduce destination, the instance to promote in place of the master. Please provide with -d")
+				fatalf(ExitUsage, "Cannot deduce destination, the instance to promote in place of the master. Please provide with -d")
 			}
 			destination := validateInstanceIsFound(destinationKey)
 			topologyRecovery, err := logic.ForceMasterTakeover(clusterName, destination)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(topologyRecovery.SuccessorKey.DisplayString())
 		}
@@ -1515,7 +1908,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			}
 			topologyRecovery, promotedMasterCoordinates, err := logic.GracefulMasterTakeover(clusterName, destinationKey, false)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(topologyRecovery.SuccessorKey.DisplayString())
 			fmt.Println(*promotedMasterCoordinates)
@@ -1531,7 +1924,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			}
 			topologyRecovery, promotedMasterCoordinates, err := logic.GracefulMasterTakeover(clusterName, destinationKey, true)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(topologyRecovery.SuccessorKey.DisplayString())
 			fmt.Println(*promotedMasterCoordinates)
@@ -1541,68 +1934,237 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			analysis, err := inst.GetReplicationAnalysis("", &inst.ReplicationAnalysisHints{})
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			for _, entry := range analysis {
 				fmt.Println(fmt.Sprintf("%s (cluster %s): %s", entry.AnalyzedInstanceKey.DisplayString(), entry.ClusterDetails.ClusterName, entry.AnalysisString()))
 			}
 		}
+	case registerCliCommand("failover-readiness", "Recovery", `Report whether a cluster is safe for automated master failover`):
+		{
+			clusterName := getClusterName(clusterAlias, instanceKey)
+			readiness, err := logic.AssessFailoverReadiness(clusterName, false)
+			if err != nil {
+				fatale(err)
+			}
+			fmt.Println(fmt.Sprintf("%s\t%t", readiness.ClusterName, readiness.SafeForAutoFailover))
+			for _, reason := range readiness.Reasons {
+				fmt.Println(reason)
+			}
+		}
+	case registerCliCommand("promotion-candidates", "Recovery", `Rank the replicas of a cluster's master as candidates for promotion`):
+		{
+			clusterName := getClusterName(clusterAlias, instanceKey)
+			candidates, err := logic.SuggestPromotionCandidates(clusterName, logic.PromotionCandidateConstraints{})
+			if err != nil {
+				fatale(err)
+			}
+			for _, candidate := range candidates {
+				fmt.Println(fmt.Sprintf("%s\t%t\t%s", candidate.Key.DisplayString(), candidate.Eligible, strings.Join(candidate.Reasons, "; ")))
+			}
+		}
+	case registerCliCommand("apply-data-center-promotion-rules", "Recovery", `Register the replicas of a cluster's master as promotion candidates by data center affinity with the master`):
+		{
+			clusterName := getClusterName(clusterAlias, instanceKey)
+			rules, err := logic.ApplyDataCenterPromotionRules(clusterName)
+			if err != nil {
+				fatale(err)
+			}
+			for _, rule := range rules {
+				fmt.Println(fmt.Sprintf("%s\t%s\t%s\t%t", rule.Key.DisplayString(), rule.DataCenter, rule.PromotionRule, rule.Changed))
+			}
+		}
+	case registerCliCommand("failover-rehearsal", "Recovery", `Simulate a dead master on a cluster and list the would-be recovery steps, changing nothing`):
+		{
+			clusterName := getClusterName(clusterAlias, instanceKey)
+			rehearsal, err := logic.RehearseDeadMasterFailover(clusterName)
+			if err != nil {
+				fatale(err)
+			}
+			for _, step := range rehearsal.Steps {
+				fmt.Println(step)
+			}
+		}
+	case registerCliCommand("recovery-filters", "Recovery", `List recovery filters added at runtime`):
+		{
+			filters, err := inst.ReadRecoveryFilters()
+			if err != nil {
+				fatale(err)
+			}
+			for _, filter := range filters {
+				fmt.Println(fmt.Sprintf("%s\t%s\t%s\t%s", filter.FilterType, filter.Pattern, filter.Owner, filter.AddedTimestamp))
+			}
+		}
+	case registerCliCommand("add-recovery-filter", "Recovery", `Add a master recovery filter pattern, in addition to RecoverMasterClusterFilters`),
+		registerCliCommand("add-intermediate-master-recovery-filter", "Recovery", `Add an intermediate master recovery filter pattern, in addition to RecoverIntermediateMasterClusterFilters`):
+		{
+			if pattern == "" {
+				fatalf(ExitUsage, "--pattern option required")
+			}
+			filterType := inst.MasterRecoveryFilterType
+			if command == "add-intermediate-master-recovery-filter" {
+				filterType = inst.IntermediateMasterRecoveryFilterType
+			}
+			if err := inst.AddRecoveryFilter(inst.NewRecoveryFilter(filterType, pattern, inst.GetMaintenanceOwner())); err != nil {
+				fatale(err)
+			}
+			fmt.Println(pattern)
+		}
+	case registerCliCommand("remove-recovery-filter", "Recovery", `Remove a master recovery filter pattern added at runtime`),
+		registerCliCommand("remove-intermediate-master-recovery-filter", "Recovery", `Remove an intermediate master recovery filter pattern added at runtime`):
+		{
+			if pattern == "" {
+				fatalf(ExitUsage, "--pattern option required")
+			}
+			filterType := inst.MasterRecoveryFilterType
+			if command == "remove-intermediate-master-recovery-filter" {
+				filterType = inst.IntermediateMasterRecoveryFilterType
+			}
+			if err := inst.RemoveRecoveryFilter(inst.NewRecoveryFilter(filterType, pattern, inst.GetMaintenanceOwner())); err != nil {
+				fatale(err)
+			}
+			fmt.Println(pattern)
+		}
+	case registerCliCommand("cluster-events", "Recovery", `Show audit entries, failure detections, recoveries and analysis changes on a cluster, in time order`):
+		{
+			clusterName := getClusterName(clusterAlias, instanceKey)
+			events, err := logic.ReadClusterEvents(clusterName, "", 0)
+			if err != nil {
+				fatale(err)
+			}
+			for _, event := range events {
+				fmt.Println(fmt.Sprintf("%s\t%s\t%s\t%s\t%s", event.Timestamp, event.Type, event.Key.DisplayString(), event.Summary, event.Message))
+			}
+		}
+	case registerCliCommand("instance-history", "Recovery", `Show audit entries, failure detections, recoveries, promotions, maintenance and downtime of an instance, in time order`):
+		{
+			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
+			if instanceKey == nil {
+				fatalf(ExitUsage, "Cannot deduce instance: %s", instance)
+			}
+			entries, err := logic.ReadInstanceHistory(instanceKey, 0)
+			if err != nil {
+				fatale(err)
+			}
+			for _, entry := range entries {
+				fmt.Println(fmt.Sprintf("%s\t%s\t%s\t%s", entry.Timestamp, entry.Type, entry.Summary, entry.Message))
+			}
+		}
 	case registerCliCommand("ack-all-recoveries", "Recovery", `Acknowledge all recoveries; this unblocks pending future recoveries`):
 		{
 			if reason == "" {
-				log.Fatal("--reason option required (comment your ack)")
+				fatalf(ExitUsage, "--reason option required (comment your ack)")
 			}
 			countRecoveries, err := logic.AcknowledgeAllRecoveries(inst.GetMaintenanceOwner(), reason)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(fmt.Sprintf("%d recoveries acknowledged", countRecoveries))
 		}
 	case registerCliCommand("ack-cluster-recoveries", "Recovery", `Acknowledge recoveries for a given cluster; this unblocks pending future recoveries`):
 		{
 			if reason == "" {
-				log.Fatal("--reason option required (comment your ack)")
+				fatalf(ExitUsage, "--reason option required (comment your ack)")
 			}
 			clusterName := getClusterName(clusterAlias, instanceKey)
 			countRecoveries, err := logic.AcknowledgeClusterRecoveries(clusterName, inst.GetMaintenanceOwner(), reason)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(fmt.Sprintf("%d recoveries acknowledged", countRecoveries))
 		}
 	case registerCliCommand("ack-instance-recoveries", "Recovery", `Acknowledge recoveries for a given instance; this unblocks pending future recoveries`):
 		{
 			if reason == "" {
-				log.Fatal("--reason option required (comment your ack)")
+				fatalf(ExitUsage, "--reason option required (comment your ack)")
 			}
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 
 			countRecoveries, err := logic.AcknowledgeInstanceRecoveries(instanceKey, inst.GetMaintenanceOwner(), reason)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(fmt.Sprintf("%d recoveries acknowledged", countRecoveries))
 		}
+	case registerCliCommand("auto-acknowledge-recoveries", "Recovery", `Acknowledge completed recoveries matching AutoAcknowledgeRecoveryRules`):
+		{
+			recoveries, err := logic.AutoAcknowledgeRecoveries()
+			if err != nil {
+				fatale(err)
+			}
+			for _, recovery := range recoveries {
+				fmt.Println(fmt.Sprintf("%s\t%s\t%s\t%s", recovery.UID, recovery.Analysis, recovery.FailedInstanceKey.DisplayString(), recovery.Comment))
+			}
+		}
+	case registerCliCommand("auto-acknowledgeable-recoveries", "Recovery", `List completed recoveries which AutoAcknowledgeRecoveryRules would acknowledge`):
+		{
+			recoveries, err := logic.ReadAutoAcknowledgeableRecoveries()
+			if err != nil {
+				fatale(err)
+			}
+			for _, recovery := range recoveries {
+				fmt.Println(fmt.Sprintf("%s\t%s\t%s\t%s", recovery.UID, recovery.Analysis, recovery.FailedInstanceKey.DisplayString(), recovery.Comment))
+			}
+		}
 	// Instance meta
 	case registerCliCommand("register-candidate", "Instance, meta", `Indicate that a specific instance is a preferred candidate for master promotion`):
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			promotionRule, err := inst.ParseCandidatePromotionRule(*config.RuntimeCLIFlags.PromotionRule)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			err = inst.RegisterCandidateInstance(inst.NewCandidateDatabaseInstance(instanceKey, promotionRule).WithCurrentTime())
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
+	case registerCliCommand("export-promotion-rules", "Instance, meta", `Output registered promotion rules as JSON, to be restored with apply-promotion-rules`):
+		{
+			promotionRules, err := logic.ExportPromotionRules()
+			if err != nil {
+				fatale(err)
+			}
+			exported, err := json.MarshalIndent(promotionRules, "", "  ")
+			if err != nil {
+				fatale(err)
+			}
+			fmt.Println(string(exported))
+		}
+	case registerCliCommand("diff-promotion-rules", "Instance, meta", `Compare promotion rules read as JSON from standard input with registered promotion rules`),
+		registerCliCommand("apply-promotion-rules", "Instance, meta", `Register promotion rules read as JSON from standard input, as exported by export-promotion-rules`):
+		{
+			promotionRules := map[string]inst.CandidatePromotionRule{}
+			if err := json.NewDecoder(os.Stdin).Decode(&promotionRules); err != nil {
+				fatalf(ExitUsage, "Cannot parse promotion rules: expecting a JSON object of host:port to promotion rule on standard input: %+v", err)
+			}
+			var diff *logic.PromotionRulesDiff
+			var err error
+			if command == "apply-promotion-rules" {
+				diff, err = logic.ApplyPromotionRules(promotionRules)
+			} else {
+				diff, err = logic.DiffPromotionRules(promotionRules)
+			}
+			if diff != nil {
+				for _, change := range diff.Changes {
+					if change.Error != "" {
+						log.Errorf("%s: %s", change.Key, change.Error)
+						continue
+					}
+					fmt.Println(fmt.Sprintf("%s\t%s\t%s\t%s", change.Key, change.Change, change.PreviousRule, change.PromotionRule))
+				}
+			}
+			if err != nil {
+				fatale(err)
+			}
+		}
 	case registerCliCommand("register-hostname-unresolve", "Instance, meta", `Assigns the given instance a virtual (aka "unresolved") name`):
 		{
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			err := inst.RegisterHostnameUnresolve(inst.NewHostnameRegistration(instanceKey, hostnameFlag))
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -1611,7 +2173,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			instanceKey, _ = inst.FigureInstanceKey(instanceKey, thisInstanceKey)
 			err := inst.RegisterHostnameUnresolve(inst.NewHostnameDeregistration(instanceKey))
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -1620,7 +2182,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			clusterName := getClusterName(clusterAlias, instanceKey)
 			instanceKey, err := inst.HeuristicallyApplyClusterDomainInstanceAttribute(clusterName)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(instanceKey.DisplayString())
 		}
@@ -1630,7 +2192,29 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			err := inst.SnapshotTopologies()
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
+			}
+		}
+	case registerCliCommand("purge-backend-history", "Meta", `Remove audit, failure detection and recovery history older than given -duration`):
+		{
+			if duration == "" {
+				fatalf(ExitUsage, "purge-backend-history requires -duration")
+			}
+			olderThanSeconds, err := util.SimpleTimeToSeconds(duration)
+			if err != nil {
+				fatale(err)
+			}
+			purged, err := logic.PurgeBackendHistory(olderThanSeconds)
+			if err != nil {
+				fatale(err)
+			}
+			tables := []string{}
+			for table := range purged {
+				tables = append(tables, table)
+			}
+			sort.Strings(tables)
+			for _, table := range tables {
+				fmt.Printf("%s\t%d\n", table, purged[table])
 			}
 		}
 	case registerCliCommand("continuous", "Meta", `Enter continuous mode, and actively poll for instances, diagnose problems, do maintenance`):
@@ -1641,44 +2225,60 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			nodes, err := process.ReadAvailableNodes(false)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			for _, node := range nodes {
 				fmt.Println(node)
 			}
 		}
+	case registerCliCommand("pre-upgrade-check", "Meta", `Report whether it is safe to restart orchestrator nodes, as a go/no-go for deployment pipelines`):
+		{
+			if config.Config.RaftEnabled {
+				fatalf(ExitUsage, "pre-upgrade-check: raft state is only known to running nodes; use the web API or orchestrator-client")
+			}
+			report, err := logic.PreUpgradeCheck(context.Background(), logic.PreUpgradeThresholds{})
+			if err != nil {
+				fatale(err)
+			}
+			for _, check := range report.Checks {
+				fmt.Println(fmt.Sprintf("%s\t%t\t%s", check.Name, check.Passed, check.Details))
+			}
+			if !report.SafeToUpgrade {
+				fatalf(ExitRefused, "pre-upgrade-check: no-go: %s", strings.Join(report.Reasons, "; "))
+			}
+		}
 	case registerCliCommand("access-token", "Meta", `Get a HTTP access token`):
 		{
 			publicToken, err := process.GenerateAccessToken(owner)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(publicToken)
 		}
 	case registerCliCommand("resolve", "Meta", `Resolve given hostname`):
 		{
 			if rawInstanceKey == nil {
-				log.Fatal("Cannot deduce instance:", instance)
+				fatalf(ExitUsage, "Cannot deduce instance: %s", instance)
 			}
 			if conn, err := net.Dial("tcp", rawInstanceKey.DisplayString()); err == nil {
 				log.Debugf("tcp test is good; got connection %+v", conn)
 				conn.Close()
 			} else {
-				log.Fatale(err)
+				fatale(err)
 			}
 			if cname, err := inst.GetCNAME(rawInstanceKey.Hostname); err == nil {
 				log.Debugf("GetCNAME() %+v, %+v", cname, err)
 				rawInstanceKey.Hostname = cname
 				fmt.Println(rawInstanceKey.DisplayString())
 			} else {
-				log.Fatale(err)
+				fatale(err)
 			}
 		}
 	case registerCliCommand("reset-hostname-resolve-cache", "Meta", `Clear the hostname resolve cache`):
 		{
 			err := inst.ResetHostnameResolveCache()
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println("hostname resolve cache cleared")
 		}
@@ -1687,11 +2287,25 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			jsonString := config.Config.ToJSONString()
 			fmt.Println(jsonString)
 		}
+	case registerCliCommand("api-endpoints", "Meta", `Print out a description of all API endpoints in JSON format, for client code generation`):
+		{
+			endpoints, err := json.MarshalIndent(http.ReadAPIEndpoints(), "", "  ")
+			if err != nil {
+				fatale(err)
+			}
+			fmt.Println(string(endpoints))
+		}
+	case registerCliCommand("exit-codes", "Meta", `List exit codes by class of failure, for scripting`):
+		{
+			for _, exitCode := range ExitCodes {
+				fmt.Printf("%d\t%s\t%s\n", exitCode.Code, exitCode.Name, exitCode.Description)
+			}
+		}
 	case registerCliCommand("show-resolve-hosts", "Meta", `Show the content of the hostname_resolve table. Generally used for debugging`):
 		{
 			resolves, err := inst.ReadAllHostnameResolves()
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			for _, r := range resolves {
 				fmt.Println(r)
@@ -1701,7 +2315,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			unresolves, err := inst.ReadAllHostnameUnresolves()
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			for _, r := range unresolves {
 				fmt.Println(r)
@@ -1712,7 +2326,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			config.RuntimeCLIFlags.ConfiguredVersion = ""
 			_, err := inst.ReadClusters()
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println("Redeployed internal db")
 		}
@@ -1721,7 +2335,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			destination := validateInstanceIsFound(destinationKey)
 			replacement, _, err := logic.SuggestReplacementForPromotedReplica(&logic.TopologyRecovery{}, instanceKey, destination, nil)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 			fmt.Println(replacement.Key.DisplayString())
 		}
@@ -1729,7 +2343,7 @@ func Cli(command string, strict bool, instance string, destination string, owner
 		{
 			output, err := agent.CustomCommand(hostnameFlag, pattern)
 			if err != nil {
-				log.Fatale(err)
+				fatale(err)
 			}
 
 			fmt.Printf("%v\n", output)
@@ -1756,6 +2370,80 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			}
 			fmt.Printf("OK: Global recoveries disabled: %v\n", isDisabled)
 		}
+	case registerCliCommand("disable-cluster-recoveries", "", `Disallow orchestrator from performing recoveries on a cluster`):
+		{
+			clusterName := getClusterName(clusterAlias, instanceKey)
+			if reason == "" {
+				fatalf(ExitUsage, "--reason option required")
+			}
+			var durationSeconds int = 0
+			if duration != "" {
+				durationSeconds, err = util.SimpleTimeToSeconds(duration)
+				if err != nil {
+					fatale(err)
+				}
+				if durationSeconds < 0 {
+					fatalf(ExitUsage, "Duration value must be non-negative. Given value: %d", durationSeconds)
+				}
+			}
+			if _, err := inst.DisableClusterRecoveries(clusterName, inst.GetMaintenanceOwner(), reason, uint(durationSeconds)); err != nil {
+				fatale(err)
+			}
+			fmt.Println(clusterName)
+		}
+	case registerCliCommand("enable-cluster-recoveries", "", `Allow orchestrator to perform recoveries on a cluster`):
+		{
+			clusterName := getClusterName(clusterAlias, instanceKey)
+			if _, err := inst.EnableClusterRecoveries(clusterName); err != nil {
+				fatale(err)
+			}
+			fmt.Println(clusterName)
+		}
+	case registerCliCommand("disabled-cluster-recoveries", "", `List clusters on which recoveries are disabled`):
+		{
+			disables, err := inst.ReadClusterRecoveryDisables()
+			if err != nil {
+				fatale(err)
+			}
+			for _, disable := range disables {
+				fmt.Println(fmt.Sprintf("%s\t%s\t%s\t%s", disable.ClusterName, disable.Owner, disable.EndTimestamp, disable.Reason))
+			}
+		}
+	case registerCliCommand("freeze-windows", "", `List FailoverFreezeWindows, and whether each is active`):
+		{
+			status, err := logic.ReadFreezeWindowsStatus()
+			if err != nil {
+				fatale(err)
+			}
+			for _, window := range status.Windows {
+				fmt.Printf("%s\t%s\t%s-%s\t%s\tactive=%t\n", window.Name, strings.Join(window.Days, ","), window.StartTime, window.EndTime, window.Timezone, window.IsActive)
+			}
+			fmt.Printf("OK: Global recoveries frozen: %v\n", status.IsRecoveryFrozen)
+		}
+	case registerCliCommand("recovery-stats", "", `Summarize recoveries per cluster over --duration (default 7d): counts, success rate, mean time to recover`):
+		{
+			if duration == "" {
+				duration = "7d"
+			}
+			windowSeconds, err := util.SimpleTimeToSeconds(duration)
+			if err != nil {
+				fatale(err)
+			}
+			if windowSeconds <= 0 {
+				fatalf(ExitUsage, "--duration must be positive. Given value: %d", windowSeconds)
+			}
+			stats, err := logic.ReadRecoveryStats(windowSeconds)
+			if err != nil {
+				fatale(err)
+			}
+			for _, clusterStats := range append(stats.Clusters, stats.Totals) {
+				clusterName := clusterStats.ClusterName
+				if clusterName == "" {
+					clusterName = "(total)"
+				}
+				fmt.Printf("%s\t%s\t%d\t%d\t%d\t%.3f\t%.1f\t%.1f\n", clusterName, clusterStats.ClusterAlias, clusterStats.CountRecoveries, clusterStats.CountSuccessful, clusterStats.CountFailed, clusterStats.SuccessRate, clusterStats.MeanTimeToRecoverSeconds, clusterStats.MaxTimeToRecoverSeconds)
+			}
+		}
 	case registerCliCommand("bulk-instances", "", `Return a list of sorted instance names known to orchestrator`):
 		{
 			instances, err := inst.BulkReadInstance()
@@ -1790,6 +2478,6 @@ func Cli(command string, strict bool, instance string, destination string, owner
 			fmt.Fprintf(os.Stderr, availableCommandsUsage())
 		}
 	default:
-		log.Fatalf("Unknown command: \"%s\". %s", command, availableCommandsUsage())
+		fatalf(ExitUsage, "Unknown command: \"%s\". %s", command, availableCommandsUsage())
 	}
 }
@@ -58,6 +58,26 @@ func init() {
   orchestrator -c relocate-replicas -i instance.whose.replicas.will.relocate -d instance.that.becomes.their.master --pattern=regexp.filter
       only apply to those instances that match given regex
   `
+	CommandHelp["tree-balance"] = `
+  Report on the shape of a cluster's replication tree: one line per intermediate master, listing its depth,
+  count of direct replicas, count of all replicas below it, and its share of the cluster's replicas. Intermediate
+  masters carrying 80% of the replicas or more (in clusters of 5 replicas or more), and instances deeper than 3
+  levels, are flagged. Following lines list suggested relocations, as replica<new-master followed by the reason:
+  replicas of an overloaded intermediate master move to the least loaded sibling intermediate master, or else
+  up to its master; the shallowest instances beyond the max depth move up a level. Nothing is changed.
+  Use web API for the full report, or to change thresholds. Examples:
+
+  orchestrator -c tree-balance -alias mycluster
+
+  orchestrator -c tree-balance -i instance.in.cluster.com
+	`
+	CommandHelp["rebalance-tree"] = `
+  Apply the relocations suggested by tree-balance, one by one, and list those applied. Relocations are
+  automated, and so instances relocated too often are skipped (see MaxAutomatedRelocationsPerHour).
+  Exits with error when any relocation fails. Examples:
+
+  orchestrator -c rebalance-tree -alias mycluster
+	`
 	CommandHelp["move-up-replicas"] = `
   Moves replicas of the given instance one level up the topology, making them siblings of given instance.
   This is a (faster) shortcut to executing move-up on all replicas of given instance.
@@ -90,6 +110,20 @@ func init() {
 
   orchestrator -c move-equivalent -i replica.to.revert.master.position.com -d master.to.move.to.com
 	`
+	CommandHelp["bootstrap-cluster"] = `
+  Sets up a brand new replication tree. Given master (--destination) and replicas (comma delimited -i) are
+  discovered and verified first: the master must not be a replica and must have binary logs enabled; replicas
+  must have distinct server ids, no replicas of their own, no other master, and (with GTID) no transactions
+  unknown to the master. Only then are replicas pointed at the master and replication started.
+  With GTID replicas auto-position; otherwise they replicate from the master's current binary log coordinates,
+  so they must already hold the master's data.
+  Examples:
+
+  orchestrator -c bootstrap-cluster -d new.master -i replica1.com:3306,replica2.com:3306
+
+  orchestrator -c bootstrap-cluster -d new.master -i replica1.com:3306,replica2.com:3306 --alias mycluster --pool mypool
+      also set cluster alias and submit replicas to pool
+  `
 	CommandHelp["take-siblings"] = `
   Turn all siblings of a replica into its sub-replicas. No action taken for siblings that cannot become
   replicas of given instance (e.g. incompatible versions, binlog format etc.). This is a (faster) shortcut
@@ -167,6 +201,18 @@ func init() {
 
   orchestrator -c match -d destination.instance.that.becomes.its.master
       -i not given, implicitly assumed local hostname
+	`
+	CommandHelp["move-to-cluster"] = `
+  Move a replica (along with its own replicas, if any) beneath a master (destination) of another cluster.
+  With GTID, the operation is rejected if the replica has transactions unknown to the destination, as would
+  be the case for a replica of an unrelated dataset. Without GTID, Pseudo-GTID matching is used, if configured.
+  Replication is stopped before the move, to note the replica's exact original coordinates. Once moved,
+  orchestrator waits for replication to run; should it fail, the replica is pointed back at its original master
+  and coordinates. If the replica already executed anything from the destination, it is not pointed back, as
+  that would replay events or carry errant transactions back; it is left stopped, and the command fails.
+  Example:
+
+  orchestrator -c move-to-cluster -i replica.to.move.com -d master.of.other.cluster.com
 	`
 	CommandHelp["move-replicas-gtid"] = `
   Moves all replicas of a given instance under another (destination) instance using GTID. This is a (faster)
@@ -299,6 +345,29 @@ func init() {
   Issues STOP SLAVE + START SLAVE; Example:
 
   orchestrator -c restart-slave -i replica.to.be.started.com
+	`
+	CommandHelp["gtid-errant-reset-master"] = `
+  Remove errant GTID transactions from an instance by way of RESET MASTER, and reapplying gtid_purged
+  without the errant entries. Destructive to the instance's binary logs. Example:
+
+  orchestrator -c gtid-errant-reset-master -i replica.with.errant.gtid.com
+	`
+	CommandHelp["gtid-errant-inject-empty"] = `
+  Inject the errant GTID transactions of an instance as empty transactions on the cluster's master, so that
+  they are no longer considered errant. Outputs the number of injected transactions and the master. Example:
+
+  orchestrator -c gtid-errant-inject-empty -i replica.with.errant.gtid.com
+	`
+	CommandHelp["locate-cluster-gtid-errant"] = `
+  List the binary logs containing errant GTID, on all instances of a cluster known to have errant GTID.
+  Instances are scanned concurrently, as limited by MaxConcurrentReplicaOperations; an instance is
+  given up on after ClusterFanOutInstanceTimeoutSeconds, and the scan as a whole after
+  ClusterFanOutTimeoutSeconds. Output lines are instance and binary log; instances which could not be
+  scanned are logged as errors. Examples:
+
+  orchestrator -c locate-cluster-gtid-errant -alias mycluster
+
+  orchestrator -c locate-cluster-gtid-errant -i instance.in.cluster.com
 	`
 	CommandHelp["skip-query"] = `
   On a failed replicating replica, skips a single query and attempts to resume replication.
@@ -392,6 +461,20 @@ func init() {
   and if so, output the last Pseudo-GTID entry and its location. Example:
 
   orchestrator -c last-pseudo-gtid -i instance.with.possible.pseudo-gtid.injection
+	`
+	CommandHelp["relocation-advice"] = `
+  Advise on how relocating an instance below another would be performed: via GTID, via Pseudo-GTID matching
+  (as in match-below), via binlog coordinates (as in move-below, move-up), or not at all. Prints the method,
+  whether Pseudo-GTID matching is feasible, whether the instances are GTID compatible, followed by the reasoning.
+  Nothing is changed on either instance. Example:
+
+  orchestrator -c relocation-advice -i replica.to.relocate.com -d new.master.com
+	`
+	CommandHelp["pseudo-gtid-status"] = `
+  Check whether Pseudo-GTID is active on a cluster: that PseudoGTIDPattern is configured, and that orchestrator has
+  recently injected Pseudo-GTID, or instances are known to have Pseudo-GTID entries. Example:
+
+  orchestrator -c pseudo-gtid-status -alias mycluster
 	`
 	CommandHelp["find-binlog-entry"] = `
   Get binlog file:pos of entry given by --pattern (exact full match, not a regular expression) in a given instance.
@@ -462,6 +545,21 @@ func init() {
 
   orchestrator -c clusters
       -i not given, implicitly assumed local hostname
+	`
+	CommandHelp["cluster-aliases"] = `
+  List cluster alias mappings. Output is tab delimited: cluster name, alias, and manually overridden
+  alias (empty if none).
+  Example:
+
+  orchestrator -c cluster-aliases
+	`
+	CommandHelp["cluster-domains"] = `
+  List the registered domain names of clusters: the VIP/CNAME/whatever of each cluster's master, as detected
+  via DetectClusterDomainQuery or set via set-cluster-domain. Output is tab delimited: cluster name, domain
+  name, and time of registration.
+  Example:
+
+  orchestrator -c cluster-domains
 	`
 	CommandHelp["all-clusters-masters"] = `
   List of writeable masters, one per cluster.
@@ -487,6 +585,22 @@ func init() {
   List the complete known set of instances. Similar to '-c find -pattern "."' Example:
 
     orchestrator -c all-instances
+	`
+	CommandHelp["problems"] = `
+  List instances with known problems (e.g. not replicating, lagging, downtimed), one JSON document per line. Each
+  line is written as soon as it is available, for piping into jq or a log pipeline. Examples:
+
+    orchestrator -c problems
+
+    orchestrator -c problems -alias mycluster | jq -r .Key.Hostname
+	`
+	CommandHelp["export-audit"] = `
+  Export all audit entries, oldest first, one JSON document per line. Entries are read page by page and each line is
+  written as soon as it is read, such that the export does not hold the audit log in memory. Examples:
+
+    orchestrator -c export-audit > audit.jsonl
+
+    orchestrator -c export-audit -i instance.to.check.com:3306
 	`
 	CommandHelp["which-instance"] = `
   Output the fully-qualified hostname:port representation of the given instance, or error if unknown
@@ -504,6 +618,16 @@ func init() {
 
   orchestrator -c which-cluster
       -i not given, implicitly assumed local hostname
+	`
+	CommandHelp["which-cluster-heads"] = `
+	Output the heads of a given cluster, indicated by instance or alias: its master, or, in a master-master
+	setup, both co-masters. Each line is an instance followed by "active" or "passive". The active head is
+	the writable one; when both co-masters are writable, or both are read-only, the shallower one is listed
+	as active. Examples:
+
+  orchestrator -c which-cluster-heads -i instance.to.check.com
+
+  orchestrator -c which-cluster-heads -alias some_alias
 	`
 	CommandHelp["which-cluster-instances"] = `
   Output the list of instances participating in same cluster as given instance; output is one line
@@ -629,6 +753,21 @@ func init() {
   orchestrator -c snapshot-topologies
 	`
 
+	CommandHelp["instance-metadata"] = `
+  List metadata overrides of an instance, as set via set-instance-metadata. Example:
+
+  orchestrator -c instance-metadata -i instance.to.check.com
+	`
+	CommandHelp["set-instance-metadata"] = `
+  Override metadata of an instance: any of instance_alias, data_center, region, physical_environment, given
+  as a comma delimited list of field=value via -pattern. Only given fields are affected. Overrides take
+  precedence over detected metadata (e.g. DataCenterPattern, DetectDataCenterQuery). A field given with an
+  empty value has its override removed; the detected value is restored upon next discovery. Examples:
+
+  orchestrator -c set-instance-metadata -i instance.to.set.com -pattern "data_center=dc1,region=us-east"
+
+  orchestrator -c set-instance-metadata -i instance.to.set.com -pattern "instance_alias="
+	`
 	CommandHelp["discover"] = `
   Request that orchestrator cotacts given instance, reads its status, and upsert it into
   orchestrator's repository. Examples:
@@ -675,6 +814,19 @@ func init() {
   Example:
 
   orchestrator -c end-maintenance -i locked.instance.com
+	`
+	CommandHelp["take-over-maintenance"] = `
+  Transfer the active maintenance lock of an instance to the owner given by --owner, e.g. when an automation
+  system resumes work started by another. By convention, automation systems own maintenance as "system:identity".
+  Example:
+
+  orchestrator -c take-over-maintenance -i locked.instance.com --owner=backup:backup-host-18
+	`
+	CommandHelp["maintenance-by-owner"] = `
+  List active maintenance owned by the owner given by --owner. An owner naming a system, e.g. "backup", lists
+  maintenance owned by any identity of that system, e.g. "backup:backup-host-17". Example:
+
+  orchestrator -c maintenance-by-owner --owner=backup
 	`
 	CommandHelp["begin-downtime"] = `
   Mark an instance as downtimed. A downtimed instance is assumed to be taken care of, and recovery-analysis does
@@ -700,6 +852,87 @@ func init() {
 
   orchestrator -c end-downtime -i downtimed.instance.com
 	`
+	CommandHelp["extend-downtime"] = `
+  Extend an active downtime by given duration, retaining its owner and reason. This is useful for pushing
+  forward a downtime which is about to expire while maintenance is still in progress.
+  Example:
+
+  orchestrator -c extend-downtime -i downtimed.instance.com --duration=1h
+      accepted duration format: 10s, 30m, 24h, 3d, 4w
+	`
+	CommandHelp["forget-cluster-alias"] = `
+  Forget the alias of a cluster, including any manual override set via set-cluster-alias. orchestrator
+  later re-deduces the alias from the cluster's instances (see DetectClusterAliasQuery). This is useful
+  after a cluster is renamed and the old alias lingers.
+  Example:
+
+  orchestrator -c forget-cluster-alias -alias old_alias
+	`
+	CommandHelp["set-cluster-domain"] = `
+  Register the domain name (VIP/CNAME/whatever) of a cluster's master, given by --hostname. As with domain
+  names detected via DetectClusterDomainQuery, the registration expires after ExpiryHostnameResolvesMinutes
+  unless registered again; to keep domain names in sync with an external source of truth, periodically
+  POST it to the reconcile-cluster-domains API. Examples:
+
+  orchestrator -c set-cluster-domain -alias mycluster --hostname mycluster-writer.example.com
+
+  orchestrator -c set-cluster-domain -i instance.in.cluster.com --hostname mycluster-writer.example.com
+	`
+	CommandHelp["forget-cluster-domain"] = `
+  Forget the domain name of a cluster. If DetectClusterDomainQuery is configured, the domain name is later
+  detected anew. Example:
+
+  orchestrator -c forget-cluster-domain -alias mycluster
+	`
+	CommandHelp["acquire-cluster-lock"] = `
+  Acquire an advisory lock on a cluster. The lock lets external tools and people coordinate such that
+  only one party mutates a cluster's topology at a time. The lock is held by the maintenance owner
+  (see --owner) and is bounded by --duration, defaulting to MaintenanceExpireMinutes (hard coded value).
+  Acquiring a lock already held by the same owner extends it. Acquiring a lock held by another owner fails.
+  Examples:
+
+  orchestrator -c acquire-cluster-lock -alias mycluster --reason="schema migration" --duration=30m
+      accepted duration format: 10s, 30m, 24h, 3d, 4w
+
+  orchestrator -c acquire-cluster-lock -i instance.in.cluster.com --owner=deployer --reason="rolling restart"
+	`
+	CommandHelp["disable-cluster-recoveries"] = `
+  Disallow orchestrator from performing automated recoveries on a single cluster, e.g. during maintenance
+  of that cluster, while other clusters remain protected. Recoveries are disabled on behalf of the
+  maintenance owner (see --owner), and for --duration. Without --duration, recoveries stay disabled until
+  enable-cluster-recoveries. Disabling recoveries on a cluster where they are already disabled overwrites
+  owner, reason and duration. Manually requested recoveries still apply.
+  Examples:
+
+  orchestrator -c disable-cluster-recoveries -alias mycluster --reason="hardware replacement" --duration=2h
+      accepted duration format: 10s, 30m, 24h, 3d, 4w
+
+  orchestrator -c disable-cluster-recoveries -i instance.in.cluster.com --reason="datacenter migration"
+	`
+	CommandHelp["enable-cluster-recoveries"] = `
+  Allow orchestrator to perform automated recoveries on a cluster on which they were disabled via
+  disable-cluster-recoveries. Global recoveries settings still apply.
+  Example:
+
+  orchestrator -c enable-cluster-recoveries -alias mycluster
+	`
+	CommandHelp["disabled-cluster-recoveries"] = `
+  List clusters on which recoveries are currently disabled. Output is tab delimited: cluster name, owner,
+  expiry time (empty when disabled until enabled) and reason.
+	`
+	CommandHelp["release-cluster-lock"] = `
+  Release an advisory lock on a cluster. Only the owner holding the lock may release it.
+  Example:
+
+  orchestrator -c release-cluster-lock -alias mycluster --owner=deployer
+	`
+	CommandHelp["cluster-lock"] = `
+  Show the advisory lock held on a cluster, if any. Output is tab delimited: cluster name, owner,
+  lock expiry time and reason. Nothing is printed when the cluster is not locked.
+  Example:
+
+  orchestrator -c cluster-lock -alias mycluster
+	`
 
 	CommandHelp["recover"] = `
   Do auto-recovery given a dead instance. Orchestrator chooses the best course of action.
@@ -715,6 +948,17 @@ func init() {
   as in "-c recover". Orchestratir will *not* execute external processes.
 
   orchestrator -c recover-lite -i dead.instance.com --debug
+	`
+	CommandHelp["recover-auto"] = `
+  Do auto-recovery given a dead instance, as in "-c recover", choosing whether to execute external processes
+  ("full" recovery) or not ("lite" recovery, as in "-c recover-lite"):
+  - master and co-master failures always get a full recovery
+  - analysis listed in LiteRecoveryAnalysis config get a lite recovery
+  - failures on downtimed instances get a lite recovery
+  - all others get a full recovery
+  The chosen mode is logged. Example:
+
+  orchestrator -c recover-auto -i dead.intermediate.master.com
 	`
 	CommandHelp["force-master-failover"] = `
   Forcibly begin a master failover process, even if orchestrator does not see anything wrong
@@ -774,6 +1018,97 @@ func init() {
   for automated parsing. Use web API instead, at this time. Example:
 
   orchestrator -c replication-analysis
+	`
+	CommandHelp["failover-readiness"] = `
+  Report whether a cluster is safe for automated master failover. Checks GTID usage, errant GTID,
+  semi-sync settings, binlog formats, promotion rules and recovery filters across the cluster.
+  The first line of output is the cluster name followed by true/false; following lines list the
+  reasons the cluster is not safe for automated failover, if any. Use web API for the full report.
+  Examples:
+
+  orchestrator -c failover-readiness -alias mycluster
+
+  orchestrator -c failover-readiness -i instance.in.cluster.com
+	`
+	CommandHelp["failover-rehearsal"] = `
+  Simulate a DeadMaster on a cluster, for game-day exercises. Based on the topology, promotion rules and
+  recovery settings orchestrator currently knows of, list the steps a master recovery would take: the
+  replica to be promoted, replicas to be relocated or lost, and hooks to be executed. Nothing is changed:
+  replication is not stopped and no hooks are executed. Use web API for the full report. Examples:
+
+  orchestrator -c failover-rehearsal -alias mycluster
+
+  orchestrator -c failover-rehearsal -i instance.in.cluster.com
+	`
+	CommandHelp["promotion-candidates"] = `
+  Rank the replicas of a cluster's master as candidates for promotion: by promotion rule, data center affinity
+  with the master, major version, log_slave_updates and replication lag. Each line lists a replica, whether it
+  is eligible for promotion, followed by the reasons for its ranking. Nothing is changed. Use web API to
+  constrain the suggestion by data center or lag. Examples:
+
+  orchestrator -c promotion-candidates -alias mycluster
+
+  orchestrator -c promotion-candidates -i instance.in.cluster.com
+	`
+	CommandHelp["apply-data-center-promotion-rules"] = `
+  Register the replicas of a cluster's master as promotion candidates by data center affinity with the master:
+  prefer for replicas in the master's data center, prefer_not for replicas elsewhere. Replicas registered as must
+  or must_not, banned from promotion, or of unknown data center are left as they are. Each line lists a replica,
+  its data center, its promotion rule, and whether the rule changed. See also SameDataCenterPromotionClusterFilters,
+  which has rules applied periodically. Examples:
+
+  orchestrator -c apply-data-center-promotion-rules -alias mycluster
+
+  orchestrator -c apply-data-center-promotion-rules -i instance.in.cluster.com
+	`
+	CommandHelp["recovery-filters"] = `
+  List recovery filters added at runtime via add-recovery-filter or add-intermediate-master-recovery-filter.
+  Configured filters (RecoverMasterClusterFilters, RecoverIntermediateMasterClusterFilters) are not listed.
+  Output is tab delimited: filter type, pattern, owner, time added. Example:
+
+  orchestrator -c recovery-filters
+	`
+	CommandHelp["add-recovery-filter"] = `
+  Add a master recovery filter pattern. The pattern applies in addition to RecoverMasterClusterFilters, and
+  accepts the same format: a cluster name regular expression, "alias=<alias>", "alias~=<regexp>" or "*".
+  The filter is stored in the backend database and survives configuration reloads and restarts. Example:
+
+  orchestrator -c add-recovery-filter --pattern "alias=mycluster"
+	`
+	CommandHelp["add-intermediate-master-recovery-filter"] = `
+  Add an intermediate master recovery filter pattern. The pattern applies in addition to
+  RecoverIntermediateMasterClusterFilters. See add-recovery-filter for pattern format. Example:
+
+  orchestrator -c add-intermediate-master-recovery-filter --pattern "alias~=^shard-"
+	`
+	CommandHelp["remove-recovery-filter"] = `
+  Remove a master recovery filter pattern previously added via add-recovery-filter. Configured filters
+  cannot be removed this way. Example:
+
+  orchestrator -c remove-recovery-filter --pattern "alias=mycluster"
+	`
+	CommandHelp["remove-intermediate-master-recovery-filter"] = `
+  Remove an intermediate master recovery filter pattern previously added via
+  add-intermediate-master-recovery-filter. Example:
+
+  orchestrator -c remove-intermediate-master-recovery-filter --pattern "alias~=^shard-"
+	`
+	CommandHelp["cluster-events"] = `
+  Show events on a cluster in time order, merging audit entries, failure detections, recoveries and
+  replication analysis changes. Output is tab delimited: timestamp, event type, instance, summary, message.
+  Up to 1000 events are listed, oldest first. Use the web API (/api/cluster-events/:clusterHint?cursor=...)
+  to read further, or to follow the stream. Example:
+
+  orchestrator -c cluster-events -alias mycluster
+	`
+	CommandHelp["instance-history"] = `
+  Show history of an instance in time order, for postmortem purposes: audit entries, failure detections and
+  recoveries where the instance failed, recoveries where it was promoted, maintenance and downtime.
+  Output is tab delimited: timestamp, entry type, summary, message. Up to 1000 most recent entries are listed.
+  The web API (/api/instance-history/:host/:port) also lists failed discoveries, as retained in memory by the
+  leader. Example:
+
+  orchestrator -c instance-history -i instance.to.inspect.com
 	`
 	CommandHelp["ack-cluster-recoveries"] = `
   Acknowledge recoveries for a given cluster; this unblocks pending future recoveries.
@@ -798,6 +1133,20 @@ func init() {
   orchestrator -c ack-cluster-recoveries -i instance.that.failed.com --reason="dba has taken taken necessary steps"
 	`
 
+	CommandHelp["auto-acknowledge-recoveries"] = `
+  Acknowledge completed recoveries matching any of AutoAcknowledgeRecoveryRules, each with its rule's comment.
+  orchestrator does so periodically on its own; this command does so right away. Lists acknowledged recoveries
+  as UID, analysis, failed instance and comment. Example:
+
+  orchestrator -c auto-acknowledge-recoveries
+	`
+	CommandHelp["auto-acknowledgeable-recoveries"] = `
+  List unacknowledged completed recoveries which AutoAcknowledgeRecoveryRules would acknowledge, without
+  acknowledging them. Useful for reviewing the rules. Example:
+
+  orchestrator -c auto-acknowledgeable-recoveries
+	`
+
 	CommandHelp["register-candidate"] = `
   Indicate that a specific instance is a preferred candidate for master promotion. Upon a dead master
   recovery, orchestrator will do its best to promote instances that are marked as candidates. However
@@ -818,6 +1167,29 @@ func init() {
 
   orchestrator -c register-candidate
       -i not given, implicitly assumed local hostname
+	`
+	CommandHelp["export-promotion-rules"] = `
+  Output the registered promotion rules as a JSON object of host:port to promotion rule. This is a snapshot of
+  candidate configuration, to be restored with apply-promotion-rules, e.g. once orchestrator is rebuilt on a new
+  backend database. Example:
+
+  orchestrator -c export-promotion-rules > promotion-rules.json
+	`
+	CommandHelp["diff-promotion-rules"] = `
+  Compare promotion rules, read as a JSON object of host:port to promotion rule from standard input, with the
+  registered promotion rules. Each line lists an instance, whether its rule is added, changed or unchanged, its
+  registered rule (if any) and the given rule. Nothing is changed. Example:
+
+  orchestrator -c diff-promotion-rules < promotion-rules.json
+	`
+	CommandHelp["apply-promotion-rules"] = `
+  Register promotion rules, read as a JSON object of host:port to promotion rule from standard input, as with
+  register-candidate, concurrently; output is as with diff-promotion-rules. Unchanged rules are registered as
+  well, renewing them. Registered rules of instances not listed are left as they are. Registrations expire
+  as usual (see CandidateInstanceExpireMinutes), hence apply periodically to keep rules. Example:
+
+  orchestrator -c export-promotion-rules > promotion-rules.json
+  orchestrator -c apply-promotion-rules < promotion-rules.json
 	`
 	CommandHelp["register-hostname-unresolve"] = `
   Assigns the given instance a virtual (aka "unresolved") name. When moving replicas under an instance with assigned
@@ -854,6 +1226,36 @@ func init() {
 			Cluster is inferred by a member instance (the instance is not necessarily the master)
 	`
 
+	CommandHelp["custom-command"] = `
+  Execute a custom command on an orchestrator-agent, as defined in the agent's configuration, and print
+  its output. Requires -hostname (the agent's hostname) and -pattern (the name of the custom command). Example:
+
+  orchestrator -c custom-command -hostname agent.host.com -pattern rotate-logs
+	`
+	CommandHelp["purge-backend-history"] = `
+  Remove audit, failure detection, recovery and recovery steps history older than given duration, ahead of
+  the periodic purge as per AuditPurgeDays. This cannot be undone. -duration is required, and must be at
+  least one day. Prints the number of rows removed per table. Example:
+
+  orchestrator -c purge-backend-history -duration 30d
+	`
+	CommandHelp["api-endpoints"] = `
+  Print out a description of all API endpoints in JSON format: path template, method, path params,
+  handler, response type, and whether the endpoint requires authorization. Requires no backend database.
+  This is the input to orchestrator-codegen, which generates API clients for other languages. Example:
+
+  orchestrator -c api-endpoints > api-endpoints.json
+	`
+	CommandHelp["exit-codes"] = `
+  List the exit codes of orchestrator, by class of failure, such that scripts may branch on them. Output is
+  tab delimited: code, name and description. The codes are stable, and orchestrator-client exits with the
+  same codes. Requires no backend database. Examples:
+
+  orchestrator -c exit-codes
+
+  orchestrator -c regroup-replicas -i instance.with.replicas.com
+  [ $? -eq 5 ] && echo "no such instance"
+	`
 	CommandHelp["continuous"] = `
   Enter continuous mode, and actively poll for instances, diagnose problems, do maintenance etc.
   This type of work is typically done in HTTP mode. However nothing prevents orchestrator from
@@ -868,6 +1270,15 @@ func init() {
 
 	orchestrator -c active-nodes
 	`
+	CommandHelp["pre-upgrade-check"] = `
+	Report whether it is safe to restart orchestrator nodes, e.g. before an upgrade. Checks there are
+	no active or blocked recoveries, no pending seeds, that the elected node has been leader for at
+	least 5 minutes, and that the backend answers within 500ms. Outputs a line per check: its name,
+	true/false, and details. Exits with the "refused" exit code when any check fails. With raft, raft
+	state is only known to running nodes: use the web API or orchestrator-client instead. Example:
+
+	orchestrator -c pre-upgrade-check
+	`
 	CommandHelp["access-token"] = `
 	When running HTTP with "AuthenticationMethod" : "token", receive a new access token.
 	This token must be utilized within "AccessTokenUseExpirySeconds" and can then be used
@@ -0,0 +1,102 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net"
+	"os"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/orchestrator/go/db"
+	"github.com/openark/orchestrator/go/logic"
+	orcutil "github.com/openark/orchestrator/go/util"
+)
+
+// ExitCode is the exit status of a command line invocation, by class of failure. Values are stable, such
+// that scripts may branch on them. orchestrator-client exits with the same values.
+type ExitCode int
+
+const (
+	ExitOK           ExitCode = 0
+	ExitError        ExitCode = 1
+	ExitUsage        ExitCode = 2
+	ExitUnreachable  ExitCode = 3
+	ExitUnauthorized ExitCode = 4
+	ExitNotFound     ExitCode = 5
+	ExitRefused      ExitCode = 6
+	ExitTimeout      ExitCode = 7
+)
+
+// ExitCodeDescription describes an exit code, as listed by the exit-codes command
+type ExitCodeDescription struct {
+	Code        ExitCode
+	Name        string
+	Description string
+}
+
+// ExitCodes lists all exit codes, ordered by value
+var ExitCodes = []ExitCodeDescription{
+	{ExitOK, "ok", "success"},
+	{ExitError, "error", "failure not covered by any other class"},
+	{ExitUsage, "usage", "missing or invalid command, argument or option"},
+	{ExitUnreachable, "unreachable", "cannot access orchestrator, its backend, or a MySQL server"},
+	{ExitUnauthorized, "unauthorized", "the user is not authorized for the operation"},
+	{ExitNotFound, "not-found", "no such instance, cluster or entry"},
+	{ExitRefused, "refused", "the operation was refused by its safety checks"},
+	{ExitTimeout, "timeout", "the operation did not complete in time"},
+}
+
+// ExitCodeFor classifies given error into an exit code. Errors which orchestrator does not classify
+// map to ExitError.
+func ExitCodeFor(err error) ExitCode {
+	if err == nil {
+		return ExitOK
+	}
+	var dangerousOperationError *logic.DangerousOperationError
+	if errors.As(err, &dangerousOperationError) {
+		return ExitRefused
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, orcutil.ErrFanOutDeadline) || errors.Is(err, orcutil.ErrFanOutItemTimeout) {
+		return ExitTimeout
+	}
+	if errors.Is(err, db.ErrNoMatch) || errors.Is(err, sql.ErrNoRows) {
+		return ExitNotFound
+	}
+	var netError net.Error
+	if errors.As(err, &netError) {
+		if netError.Timeout() {
+			return ExitTimeout
+		}
+		return ExitUnreachable
+	}
+	return ExitError
+}
+
+// fatale logs given error and exits with the code of its class
+func fatale(err error) {
+	log.Criticale(err)
+	os.Exit(int(ExitCodeFor(err)))
+}
+
+// fatalf logs given message and exits with given code
+func fatalf(exitCode ExitCode, message string, args ...interface{}) {
+	log.Criticalf(message, args...)
+	os.Exit(int(exitCode))
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/db"
+	"github.com/openark/orchestrator/go/logic"
+	orcutil "github.com/openark/orchestrator/go/util"
+)
+
+func TestExitCodeFor(t *testing.T) {
+	test.S(t).ExpectEquals(ExitCodeFor(nil), ExitOK)
+	test.S(t).ExpectEquals(ExitCodeFor(fmt.Errorf("something went wrong")), ExitError)
+	test.S(t).ExpectEquals(ExitCodeFor(&logic.DangerousOperationError{Operation: "force-master-failover", ClusterName: "c"}), ExitRefused)
+	test.S(t).ExpectEquals(ExitCodeFor(fmt.Errorf("refresh: %w", context.DeadlineExceeded)), ExitTimeout)
+	test.S(t).ExpectEquals(ExitCodeFor(orcutil.ErrFanOutItemTimeout), ExitTimeout)
+	test.S(t).ExpectEquals(ExitCodeFor(fmt.Errorf("read: %w", db.ErrNoMatch)), ExitNotFound)
+	test.S(t).ExpectEquals(ExitCodeFor(&net.OpError{Op: "dial", Net: "tcp", Err: fmt.Errorf("connection refused")}), ExitUnreachable)
+	test.S(t).ExpectEquals(ExitCodeFor(&net.DNSError{Err: "timeout", Name: "db", IsTimeout: true}), ExitTimeout)
+}
+
+func TestExitCodesListing(t *testing.T) {
+	names := make(map[string]bool)
+	for i, exitCode := range ExitCodes {
+		test.S(t).ExpectEquals(int(exitCode.Code), i)
+		test.S(t).ExpectFalse(names[exitCode.Name])
+		names[exitCode.Name] = true
+	}
+}
+
+func TestClientExitCodes(t *testing.T) {
+	script, err := ioutil.ReadFile("../../resources/bin/orchestrator-client")
+	test.S(t).ExpectNil(err)
+	assignments := regexp.MustCompile(`(?m)^exit_([a-z_]+)=([0-9]+)$`).FindAllSubmatch(script, -1)
+	test.S(t).ExpectEquals(len(assignments), len(ExitCodes))
+	for i, assignment := range assignments {
+		code, err := strconv.Atoi(string(assignment[2]))
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(strings.Replace(string(assignment[1]), "_", "-", -1), ExitCodes[i].Name)
+		test.S(t).ExpectEquals(ExitCode(code), ExitCodes[i].Code)
+	}
+}
@@ -74,6 +74,7 @@ func promptForSSLPasswords() {
 // standardHttp starts serving HTTP or HTTPS (api/web) requests, to be used by normal clients
 func standardHttp(continuousDiscovery bool) {
 	m := martini.Classic()
+	m.Use(http.RequestId)
 
 	switch strings.ToLower(config.Config.AuthenticationMethod) {
 	case "basic":
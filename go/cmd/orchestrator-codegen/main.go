@@ -0,0 +1,257 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// orchestrator-codegen generates orchestrator API clients from the description of the API endpoints,
+// as printed by `orchestrator -c api-endpoints` or served by /api/api-endpoints. Clients in other
+// languages, or internal SDKs, are thus kept in sync with the API by regenerating them.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/orchestrator/go/apibase"
+	orchestratorhttp "github.com/openark/orchestrator/go/http"
+)
+
+// endpoint is an API endpoint as presented to templates
+type endpoint struct {
+	orchestratorhttp.APIEndpoint
+	Name string // snake_case name, unique across endpoints, e.g. "audit_recovery_cluster_by_page"
+}
+
+const pythonTemplate = `# Code generated by orchestrator-codegen. DO NOT EDIT.
+
+import base64
+import json
+import urllib.parse
+import urllib.request
+
+
+class OrchestratorClient(object):
+    def __init__(self, api, user=None, password=None, timeout=10):
+        self.api = api.rstrip("/")
+        self.user = user
+        self.password = password
+        self.timeout = timeout
+
+    def _get(self, path, query=None):
+        url = "%s/%s" % (self.api, path)
+        if query:
+            url = "%s?%s" % (url, urllib.parse.urlencode(query))
+        request = urllib.request.Request(url)
+        if self.user:
+            credentials = base64.b64encode(("%s:%s" % (self.user, self.password)).encode()).decode()
+            request.add_header("Authorization", "Basic %s" % credentials)
+        with urllib.request.urlopen(request, timeout=self.timeout) as response:
+            return json.loads(response.read())
+{{range .}}
+    def {{.Name}}(self{{range .Params}}, {{snake .}}{{end}}, query=None):
+        """{{.Method}} /api/{{.Path}}{{if .Result}} -> {{.Result}}{{end}}{{if .Mutating}} (mutating; requires authorization){{end}}"""
+        return self._get("{{pathFormat .Path}}"{{if .Params}} % ({{range $i, $p := .Params}}{{if $i}}, {{end}}urllib.parse.quote(str({{snake $p}}), safe=""){{end}}{{if eq (len .Params) 1}},{{end}}){{end}}, query)
+{{end}}`
+
+// protoTemplate describes the API as a gRPC service, annotated with the HTTP rule of each endpoint. A gateway which
+// transcodes gRPC to HTTP (e.g. one generated with grpc-gateway's reverse mode, or a hand written one) then serves
+// non-Go services with typed stubs, while the HTTP API remains the only interface orchestrator itself serves.
+const protoTemplate = `// Code generated by orchestrator-codegen. DO NOT EDIT.
+
+syntax = "proto3";
+
+package orchestrator.api;
+
+import "google/api/annotations.proto";
+import "google/protobuf/struct.proto";
+
+// Orchestrator mirrors the orchestrator HTTP API: each rpc is an endpoint, responding with the endpoint's JSON.
+service Orchestrator {
+{{- range .}}
+  // {{.Method}} /api/{{.Path}}{{if .Result}} -> {{.Result}}{{end}}{{if .Mutating}} (mutating; requires authorization){{end}}
+  rpc {{camel .Name}}({{camel .Name}}Request) returns (google.protobuf.Value) {
+    option (google.api.http) = { get: "/api/{{protoPath .Path}}" };
+  }
+{{- end}}
+}
+{{range .}}
+message {{camel .Name}}Request {
+{{- range $i, $p := .Params}}
+  string {{snake $p}} = {{inc $i}};
+{{- end}}
+  // query params, e.g. {"override": "true"}
+  map<string, string> query = {{inc (len .Params)}};
+}
+{{end}}`
+
+var builtinTemplates = map[string]string{
+	"python": pythonTemplate,
+	"proto":  protoTemplate,
+}
+
+// snake converts a camelCase or dashed name to snake_case, e.g. "belowHost" to "below_host"
+func snake(name string) string {
+	var result strings.Builder
+	for i, c := range name {
+		switch {
+		case c == '-' || c == '/' || c == '.':
+			result.WriteRune('_')
+		case c >= 'A' && c <= 'Z':
+			if i > 0 {
+				result.WriteRune('_')
+			}
+			result.WriteRune(c - 'A' + 'a')
+		default:
+			result.WriteRune(c)
+		}
+	}
+	return result.String()
+}
+
+// camel converts a snake_case or dashed name to CamelCase, e.g. "audit_recovery" to "AuditRecovery"
+func camel(name string) string {
+	tokens := strings.FieldsFunc(snake(name), func(c rune) bool { return c == '_' })
+	for i, token := range tokens {
+		tokens[i] = strings.ToUpper(token[:1]) + token[1:]
+	}
+	return strings.Join(tokens, "")
+}
+
+// pathFormat turns a path template into a format string, e.g. "instance/:host/:port" into "instance/%s/%s"
+func pathFormat(path string) string {
+	tokens := strings.Split(path, "/")
+	for i, token := range tokens {
+		if strings.HasPrefix(token, ":") {
+			tokens[i] = "%s"
+		}
+	}
+	return strings.Join(tokens, "/")
+}
+
+// protoPath turns a path template into an HTTP rule path template, e.g. "match/:host/:port/:belowHost/:belowPort"
+// into "match/{host}/{port}/{below_host}/{below_port}"
+func protoPath(path string) string {
+	tokens := strings.Split(path, "/")
+	for i, token := range tokens {
+		if strings.HasPrefix(token, ":") {
+			tokens[i] = fmt.Sprintf("{%s}", snake(strings.TrimPrefix(token, ":")))
+		}
+	}
+	return strings.Join(tokens, "/")
+}
+
+// endpointNames assigns each endpoint a unique name, based on the static tokens of its path, and on its
+// params where static tokens are not distinctive, e.g. "audit_recovery" and "audit_recovery_by_page".
+func endpointNames(apiEndpoints []orchestratorhttp.APIEndpoint) []endpoint {
+	endpoints := []endpoint{}
+	countNames := make(map[string]int)
+	for _, apiEndpoint := range apiEndpoints {
+		tokens := []string{}
+		for _, token := range strings.Split(apiEndpoint.Path, "/") {
+			if !strings.HasPrefix(token, ":") {
+				tokens = append(tokens, token)
+			}
+		}
+		name := snake(strings.Join(tokens, "_"))
+		endpoints = append(endpoints, endpoint{APIEndpoint: apiEndpoint, Name: name})
+		countNames[name]++
+	}
+	for i := range endpoints {
+		if countNames[endpoints[i].Name] > 1 && len(endpoints[i].Params) > 0 {
+			params := []string{}
+			for _, param := range endpoints[i].Params {
+				params = append(params, snake(param))
+			}
+			endpoints[i].Name = fmt.Sprintf("%s_by_%s", endpoints[i].Name, strings.Join(params, "_"))
+		}
+	}
+	return endpoints
+}
+
+// templateFuncs are the functions available to templates, built-in or given via -template
+var templateFuncs = template.FuncMap{
+	"snake":      snake,
+	"camel":      camel,
+	"pathFormat": pathFormat,
+	"protoPath":  protoPath,
+	"inc":        func(i int) int { return i + 1 },
+}
+
+func readEndpoints(input string, api string, apiBasePath string) (apiEndpoints []orchestratorhttp.APIEndpoint, err error) {
+	var data []byte
+	switch {
+	case api != "":
+		api = apibase.Resolve(context.Background(), api, apibase.Options{BasePath: apiBasePath})
+		response, err := http.Get(fmt.Sprintf("%s/api-endpoints", api))
+		if err != nil {
+			return nil, err
+		}
+		defer response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s: got status %d", api, response.StatusCode)
+		}
+		data, err = ioutil.ReadAll(response.Body)
+		if err != nil {
+			return nil, err
+		}
+	case input == "-":
+		data, err = ioutil.ReadAll(os.Stdin)
+	default:
+		data, err = ioutil.ReadFile(input)
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, &apiEndpoints)
+	return apiEndpoints, err
+}
+
+func main() {
+	input := flag.String("input", "-", "file describing API endpoints, as printed by `orchestrator -c api-endpoints`; '-' for stdin")
+	api := flag.String("api", "", "orchestrator API endpoint to read the API endpoints from, instead of -input")
+	apiBasePath := flag.String("api-base-path", "", "path of the orchestrator API, e.g. /orchestrator/api, replacing that of -api; default: probed")
+	lang := flag.String("lang", "python", "built-in template to generate the client with: python, proto")
+	templateFile := flag.String("template", "", "Go text/template file to generate the client with, instead of -lang")
+	flag.Parse()
+
+	apiEndpoints, err := readEndpoints(*input, *api, *apiBasePath)
+	if err != nil {
+		log.Fatale(err)
+	}
+	templateText, found := builtinTemplates[*lang]
+	if *templateFile != "" {
+		data, err := ioutil.ReadFile(*templateFile)
+		if err != nil {
+			log.Fatale(err)
+		}
+		templateText = string(data)
+	} else if !found {
+		log.Fatalf("Unknown -lang: %s", *lang)
+	}
+	tmpl, err := template.New("client").Funcs(templateFuncs).Parse(templateText)
+	if err != nil {
+		log.Fatale(err)
+	}
+	if err := tmpl.Execute(os.Stdout, endpointNames(apiEndpoints)); err != nil {
+		log.Fatale(err)
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"text/template"
+
+	test "github.com/openark/golib/tests"
+	orchestratorhttp "github.com/openark/orchestrator/go/http"
+)
+
+func TestProtoPath(t *testing.T) {
+	test.S(t).ExpectEquals(protoPath("clusters"), "clusters")
+	test.S(t).ExpectEquals(protoPath("match/:host/:port/:belowHost/:belowPort"), "match/{host}/{port}/{below_host}/{below_port}")
+}
+
+func TestProtoTemplate(t *testing.T) {
+	tmpl, err := template.New("client").Funcs(templateFuncs).Parse(protoTemplate)
+	test.S(t).ExpectNil(err)
+	var proto bytes.Buffer
+	err = tmpl.Execute(&proto, endpointNames(orchestratorhttp.ReadAPIEndpoints()))
+	test.S(t).ExpectNil(err)
+
+	// rpc and message names must be unique, valid identifiers
+	rpcs := regexp.MustCompile(`(?m)^  rpc (\S+)\((\S+)\) returns`).FindAllStringSubmatch(proto.String(), -1)
+	test.S(t).ExpectTrue(len(rpcs) > 100)
+	seen := make(map[string]bool)
+	for _, rpc := range rpcs {
+		test.S(t).ExpectTrue(regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`).MatchString(rpc[1]))
+		test.S(t).ExpectFalse(seen[rpc[1]])
+		seen[rpc[1]] = true
+		test.S(t).ExpectTrue(bytes.Contains(proto.Bytes(), []byte("\nmessage "+rpc[2]+" {\n")))
+	}
+	test.S(t).ExpectTrue(bytes.Contains(proto.Bytes(), []byte(`get: "/api/relocate/{host}/{port}/{below_host}/{below_port}"`)))
+}
@@ -0,0 +1,482 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// orchestrator-exporter scrapes a running orchestrator service via its HTTP API, and exposes
+// clusters, replication analysis, recent recoveries and replication lag as Prometheus metrics.
+// It requires no access to orchestrator's configuration or backend database.
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/orchestrator/go/apibase"
+	"github.com/openark/orchestrator/go/vaultauth"
+)
+
+type instanceKey struct {
+	Hostname string
+	Port     int
+}
+
+func (this instanceKey) String() string {
+	return fmt.Sprintf("%s:%d", this.Hostname, this.Port)
+}
+
+type clusterInfo struct {
+	ClusterName                string
+	ClusterAlias               string
+	CountInstances             uint
+	HeuristicLag               int64
+	HasAutomatedMasterRecovery bool
+}
+
+type replicationAnalysis struct {
+	AnalyzedInstanceKey instanceKey
+	ClusterDetails      clusterInfo
+	Analysis            string
+	IsDowntimed         bool
+}
+
+type topologyRecovery struct {
+	AnalysisEntry replicationAnalysis
+	IsSuccessful  bool
+	Acknowledged  bool
+}
+
+type nullInt64 struct {
+	Int64 int64
+	Valid bool
+}
+
+type instance struct {
+	Key                   instanceKey
+	ReplicationLagSeconds nullInt64
+	IsLastCheckValid      bool
+}
+
+type apiResponse struct {
+	Code    string
+	Message string
+	Details json.RawMessage
+}
+
+// payloadStats accumulates what the exporter read from an API endpoint, across scrapes
+type payloadStats struct {
+	requests float64
+	bytes    float64
+	entities float64
+}
+
+// exporter scrapes the orchestrator API upon each request to /metrics
+type exporter struct {
+	api         string
+	user        string
+	password    string
+	instanceLag bool
+	client      *http.Client
+
+	payloadsMutex sync.Mutex
+	payloads      map[string]*payloadStats
+}
+
+// apiEndpoint returns the endpoint name of an API path, e.g. "cluster" for "cluster/some-cluster"
+func apiEndpoint(path string) string {
+	return strings.SplitN(path, "/", 2)[0]
+}
+
+// countEntities returns the length of v, a pointer to a decoded list, and false if v is not a list
+func countEntities(v interface{}) (int, bool) {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Slice {
+		return 0, false
+	}
+	return value.Len(), true
+}
+
+// recordPayload accounts for a response read from an API endpoint: its size in bytes, and the number
+// of entities (instances, analysis entries, recoveries etc.) it returned, if it is a list
+func (this *exporter) recordPayload(path string, size int, v interface{}) {
+	this.payloadsMutex.Lock()
+	defer this.payloadsMutex.Unlock()
+
+	endpoint := apiEndpoint(path)
+	stats, found := this.payloads[endpoint]
+	if !found {
+		stats = &payloadStats{}
+		this.payloads[endpoint] = stats
+	}
+	if size > 0 {
+		stats.requests++
+		stats.bytes += float64(size)
+	}
+	if entities, isList := countEntities(v); isList {
+		stats.entities += float64(entities)
+	}
+}
+
+func (this *exporter) writePayloadStats(w *metricsWriter) {
+	this.payloadsMutex.Lock()
+	defer this.payloadsMutex.Unlock()
+
+	endpoints := []string{}
+	for endpoint := range this.payloads {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	for _, endpoint := range endpoints {
+		w.writeCounter("orchestrator_exporter_api_requests_total", "Number of successful requests to the orchestrator API, by endpoint.", this.payloads[endpoint].requests, "endpoint", endpoint)
+		w.writeCounter("orchestrator_exporter_api_payload_bytes_total", "Bytes of response payload decoded from the orchestrator API, by endpoint.", this.payloads[endpoint].bytes, "endpoint", endpoint)
+		w.writeCounter("orchestrator_exporter_api_entities_total", "Entities (instances, analysis entries, recoveries etc.) returned by the orchestrator API, by endpoint.", this.payloads[endpoint].entities, "endpoint", endpoint)
+	}
+}
+
+// payloadSnippetLength is the number of payload bytes quoted by errors recovered from panics
+const payloadSnippetLength = 256
+
+// recoverPanic converts a panic, e.g. upon an unexpected payload shape, into an error naming the API path and
+// quoting the beginning of the payload, so that a single malformed response fails a scrape rather than the exporter.
+// It must be deferred directly.
+func recoverPanic(path string, payload *[]byte, err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	snippet := []byte{}
+	if payload != nil {
+		snippet = *payload
+	}
+	if len(snippet) > payloadSnippetLength {
+		snippet = snippet[:payloadSnippetLength]
+	}
+	log.Debugf("%s: recovered from panic: %v\n%s", path, r, debug.Stack())
+	*err = fmt.Errorf("%s: recovered from panic: %v; payload: %q", path, r, snippet)
+}
+
+func (this *exporter) get(path string, v interface{}) (err error) {
+	var body []byte
+	defer recoverPanic(path, &body, &err)
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s", this.api, path), nil)
+	if err != nil {
+		return err
+	}
+	if this.user != "" {
+		req.SetBasicAuth(this.user, this.password)
+	}
+	res, err := this.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: got status %d", path, res.StatusCode)
+	}
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return err
+	}
+	this.recordPayload(path, len(body), v)
+	return nil
+}
+
+// getDetails reads an API endpoint which responds with an APIResponse, and decodes its Details
+func (this *exporter) getDetails(path string, v interface{}) (err error) {
+	response := apiResponse{}
+	defer recoverPanic(path, (*[]byte)(&response.Details), &err)
+
+	if err := this.get(path, &response); err != nil {
+		return err
+	}
+	if response.Code != "OK" {
+		return fmt.Errorf("%s: %s", path, response.Message)
+	}
+	if err := json.Unmarshal(response.Details, v); err != nil {
+		return err
+	}
+	this.recordPayload(path, 0, v)
+	return nil
+}
+
+// metricFamily holds the samples of a single metric, which the exposition format requires to be written together
+type metricFamily struct {
+	name       string
+	help       string
+	metricType string
+	samples    []string
+}
+
+// metricsWriter writes metrics in Prometheus text exposition format. Samples are grouped by metric family,
+// in the order families are first written, so that callers may write samples of different families in any order.
+type metricsWriter struct {
+	families []*metricFamily
+	byName   map[string]*metricFamily
+}
+
+func newMetricsWriter() *metricsWriter {
+	return &metricsWriter{byName: make(map[string]*metricFamily)}
+}
+
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// write adds a gauge sample. Labels are given as alternating name, value pairs.
+func (this *metricsWriter) write(name string, help string, value float64, labels ...string) {
+	this.writeSample(name, "gauge", help, value, labels...)
+}
+
+// writeCounter adds a counter sample. Labels are given as alternating name, value pairs.
+func (this *metricsWriter) writeCounter(name string, help string, value float64, labels ...string) {
+	this.writeSample(name, "counter", help, value, labels...)
+}
+
+func (this *metricsWriter) writeSample(name string, metricType string, help string, value float64, labels ...string) {
+	family, found := this.byName[name]
+	if !found {
+		family = &metricFamily{name: name, help: help, metricType: metricType}
+		this.byName[name] = family
+		this.families = append(this.families, family)
+	}
+	tokens := []string{}
+	for i := 0; i+1 < len(labels); i += 2 {
+		tokens = append(tokens, fmt.Sprintf(`%s="%s"`, labels[i], escapeLabelValue(labels[i+1])))
+	}
+	if len(tokens) > 0 {
+		family.samples = append(family.samples, fmt.Sprintf("%s{%s} %v", name, strings.Join(tokens, ","), value))
+	} else {
+		family.samples = append(family.samples, fmt.Sprintf("%s %v", name, value))
+	}
+}
+
+// bytes renders all families, each in a single block headed by its HELP and TYPE lines
+func (this *metricsWriter) bytes() []byte {
+	var buffer bytes.Buffer
+	for _, family := range this.families {
+		fmt.Fprintf(&buffer, "# HELP %s %s\n# TYPE %s %s\n", family.name, family.help, family.name, family.metricType)
+		for _, sample := range family.samples {
+			fmt.Fprintln(&buffer, sample)
+		}
+	}
+	return buffer.Bytes()
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (this *exporter) collect(w *metricsWriter) (err error) {
+	defer recoverPanic("collect", nil, &err)
+
+	clusters := []clusterInfo{}
+	if err := this.get("clusters-info", &clusters); err != nil {
+		return err
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].ClusterName < clusters[j].ClusterName })
+	for _, cluster := range clusters {
+		labels := []string{"cluster", cluster.ClusterName, "alias", cluster.ClusterAlias}
+		w.write("orchestrator_cluster_instances", "Number of instances in the cluster.", float64(cluster.CountInstances), labels...)
+		w.write("orchestrator_cluster_heuristic_lag_seconds", "Heuristic replication lag of the cluster.", float64(cluster.HeuristicLag), labels...)
+		w.write("orchestrator_cluster_automated_master_recovery", "Whether automated master recovery is enabled for the cluster.", boolToFloat(cluster.HasAutomatedMasterRecovery), labels...)
+	}
+
+	analysis := []replicationAnalysis{}
+	if err := this.getDetails("replication-analysis", &analysis); err != nil {
+		return err
+	}
+	for _, entry := range analysis {
+		w.write("orchestrator_replication_analysis", "Replication problem currently detected on an instance.", 1,
+			"cluster", entry.ClusterDetails.ClusterName, "alias", entry.ClusterDetails.ClusterAlias,
+			"instance", entry.AnalyzedInstanceKey.String(), "analysis", entry.Analysis,
+			"downtimed", fmt.Sprintf("%t", entry.IsDowntimed))
+	}
+
+	recoveries := []topologyRecovery{}
+	if err := this.get("audit-recovery", &recoveries); err != nil {
+		return err
+	}
+	type recoveryCounts struct{ successful, failed, unacknowledged int }
+	countsByCluster := make(map[clusterInfo]*recoveryCounts)
+	for _, cluster := range clusters {
+		countsByCluster[clusterInfo{ClusterName: cluster.ClusterName, ClusterAlias: cluster.ClusterAlias}] = &recoveryCounts{}
+	}
+	for _, recovery := range recoveries {
+		cluster := clusterInfo{ClusterName: recovery.AnalysisEntry.ClusterDetails.ClusterName, ClusterAlias: recovery.AnalysisEntry.ClusterDetails.ClusterAlias}
+		counts, found := countsByCluster[cluster]
+		if !found {
+			counts = &recoveryCounts{}
+			countsByCluster[cluster] = counts
+		}
+		if recovery.IsSuccessful {
+			counts.successful++
+		} else {
+			counts.failed++
+		}
+		if !recovery.Acknowledged {
+			counts.unacknowledged++
+		}
+	}
+	recoveryClusters := []clusterInfo{}
+	for cluster := range countsByCluster {
+		recoveryClusters = append(recoveryClusters, cluster)
+	}
+	sort.Slice(recoveryClusters, func(i, j int) bool { return recoveryClusters[i].ClusterName < recoveryClusters[j].ClusterName })
+	for _, cluster := range recoveryClusters {
+		counts := countsByCluster[cluster]
+		labels := []string{"cluster", cluster.ClusterName, "alias", cluster.ClusterAlias}
+		w.write("orchestrator_cluster_recent_recoveries", "Number of recent recoveries on the cluster, by outcome.", float64(counts.successful), append(labels, "successful", "true")...)
+		w.write("orchestrator_cluster_recent_recoveries", "Number of recent recoveries on the cluster, by outcome.", float64(counts.failed), append(labels, "successful", "false")...)
+		w.write("orchestrator_cluster_unacknowledged_recoveries", "Number of recent recoveries on the cluster not yet acknowledged.", float64(counts.unacknowledged), labels...)
+	}
+
+	if !this.instanceLag {
+		return nil
+	}
+	for _, cluster := range clusters {
+		instances := []instance{}
+		if err := this.get(fmt.Sprintf("cluster/%s", url.PathEscape(cluster.ClusterName)), &instances); err != nil {
+			log.Errorf("Cannot read instances of %s: %+v", cluster.ClusterName, err)
+			continue
+		}
+		for _, clusterInstance := range instances {
+			labels := []string{"cluster", cluster.ClusterName, "alias", cluster.ClusterAlias, "instance", clusterInstance.Key.String()}
+			w.write("orchestrator_instance_last_check_valid", "Whether the last check of the instance succeeded.", boolToFloat(clusterInstance.IsLastCheckValid), labels...)
+			if clusterInstance.ReplicationLagSeconds.Valid {
+				w.write("orchestrator_instance_replication_lag_seconds", "Replication lag of the instance.", float64(clusterInstance.ReplicationLagSeconds.Int64), labels...)
+			}
+		}
+	}
+	return nil
+}
+
+func (this *exporter) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	w := newMetricsWriter()
+	startTime := time.Now()
+	err := this.collect(w)
+	if err != nil {
+		log.Errore(err)
+		// Discard partial results; report the failed scrape only
+		w = newMetricsWriter()
+	}
+	w.write("orchestrator_exporter_up", "Whether the last scrape of the orchestrator API succeeded.", boolToFloat(err == nil))
+	w.write("orchestrator_exporter_scrape_duration_seconds", "Duration of the last scrape of the orchestrator API.", time.Since(startTime).Seconds())
+	this.writePayloadStats(w)
+
+	rw.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	rw.Write(w.bytes())
+}
+
+// transportOptions tune the connections to the orchestrator API. Pollers scraping often, or scraping
+// orchestrator behind an L4 load balancer, benefit from keeping connections alive rather than reconnecting.
+type transportOptions struct {
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
+	tcpKeepAlive        time.Duration
+	http2               bool
+	compression         bool
+}
+
+func newTransport(options transportOptions) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: options.tcpKeepAlive,
+	}
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		MaxIdleConns:          options.maxIdleConnsPerHost,
+		MaxIdleConnsPerHost:   options.maxIdleConnsPerHost,
+		IdleConnTimeout:       options.idleConnTimeout,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		ForceAttemptHTTP2:     options.http2,
+		DisableCompression:    !options.compression,
+	}
+	if !options.http2 {
+		// A non-nil, empty map disables HTTP/2 negotiation over TLS
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+	return transport
+}
+
+func main() {
+	api := flag.String("api", "http://localhost:3000/api", "orchestrator API endpoint")
+	apiBasePath := flag.String("api-base-path", "", "path of the orchestrator API, e.g. /orchestrator/api, replacing that of -api; default: probed")
+	listen := flag.String("listen", ":9125", "address to serve metrics on")
+	timeout := flag.Duration("timeout", 10*time.Second, "timeout for each request to the orchestrator API")
+	instanceLag := flag.Bool("instance-lag", true, "export per-instance metrics (one API request per cluster)")
+	maxIdleConnsPerHost := flag.Int("max-idle-conns-per-host", 4, "maximum idle (keep-alive) connections kept to the orchestrator API")
+	idleConnTimeout := flag.Duration("idle-conn-timeout", 90*time.Second, "time an idle connection to the orchestrator API is kept before closing; 0 for no limit")
+	tcpKeepAlive := flag.Duration("tcp-keepalive", 30*time.Second, "TCP keep-alive period for connections to the orchestrator API; negative to disable")
+	http2 := flag.Bool("http2", true, "allow HTTP/2 with the orchestrator API, when served over TLS")
+	compression := flag.Bool("compression", true, "request gzip compressed responses from the orchestrator API")
+	debug := flag.Bool("debug", false, "debug mode (very verbose)")
+	flag.Parse()
+
+	log.SetLevel(log.ERROR)
+	if *debug {
+		log.SetLevel(log.DEBUG)
+	}
+
+	e := &exporter{
+		user:        os.Getenv("ORCHESTRATOR_AUTH_USER"),
+		password:    os.Getenv("ORCHESTRATOR_AUTH_PASSWORD"),
+		instanceLag: *instanceLag,
+		client: &http.Client{
+			Timeout: *timeout,
+			Transport: newTransport(transportOptions{
+				maxIdleConnsPerHost: *maxIdleConnsPerHost,
+				idleConnTimeout:     *idleConnTimeout,
+				tcpKeepAlive:        *tcpKeepAlive,
+				http2:               *http2,
+				compression:         *compression,
+			}),
+		},
+		payloads: make(map[string]*payloadStats),
+	}
+	if provider := vaultauth.FromEnvironment(&http.Client{Timeout: *timeout}); provider != nil {
+		go provider.Run(context.Background(), func(err error) { log.Errorf("vault: %+v", err) })
+		e.client.Transport = provider.Transport(e.client.Transport)
+	}
+	e.api = apibase.Resolve(context.Background(), *api, apibase.Options{BasePath: *apiBasePath, User: e.user, Password: e.password, Client: e.client})
+	http.Handle("/metrics", e)
+	log.Infof("Serving metrics of %s on %s/metrics", e.api, *listen)
+	if err := http.ListenAndServe(*listen, nil); err != nil {
+		log.Fatale(err)
+	}
+}
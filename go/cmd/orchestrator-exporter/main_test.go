@@ -0,0 +1,371 @@
+package main
+
+import (
+	"compress/gzip"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openark/golib/log"
+	test "github.com/openark/golib/tests"
+)
+
+func init() {
+	log.SetLevel(log.ERROR)
+}
+
+// testAPIResponses are served by the fake orchestrator API, by path
+var testAPIResponses = map[string]string{
+	"/api/clusters-info": `[
+		{"ClusterName": "c2:3306", "ClusterAlias": "c2", "CountInstances": 1, "HeuristicLag": 0, "HasAutomatedMasterRecovery": false},
+		{"ClusterName": "c1:3306", "ClusterAlias": "c1", "CountInstances": 2, "HeuristicLag": 5, "HasAutomatedMasterRecovery": true}
+	]`,
+	"/api/replication-analysis": `{"Code": "OK", "Message": "Analysis", "Details": [
+		{"AnalyzedInstanceKey": {"Hostname": "c1", "Port": 3306}, "ClusterDetails": {"ClusterName": "c1:3306", "ClusterAlias": "c1"}, "Analysis": "AllMasterReplicasNotReplicating", "IsDowntimed": false},
+		{"AnalyzedInstanceKey": {"Hostname": "c2", "Port": 3306}, "ClusterDetails": {"ClusterName": "c2:3306", "ClusterAlias": "c2"}, "Analysis": "DeadMaster", "IsDowntimed": true}
+	]}`,
+	"/api/audit-recovery": `[
+		{"AnalysisEntry": {"ClusterDetails": {"ClusterName": "c2:3306", "ClusterAlias": "c2"}}, "IsSuccessful": true, "Acknowledged": false},
+		{"AnalysisEntry": {"ClusterDetails": {"ClusterName": "c1:3306", "ClusterAlias": "c1"}}, "IsSuccessful": false, "Acknowledged": true},
+		{"AnalysisEntry": {"ClusterDetails": {"ClusterName": "gone:3306", "ClusterAlias": "gone"}}, "IsSuccessful": true, "Acknowledged": true}
+	]`,
+	"/api/cluster/c1:3306": `[
+		{"Key": {"Hostname": "c1", "Port": 3306}, "ReplicationLagSeconds": {"Int64": 0, "Valid": false}, "IsLastCheckValid": true},
+		{"Key": {"Hostname": "c1-replica", "Port": 3306}, "ReplicationLagSeconds": {"Int64": 7, "Valid": true}, "IsLastCheckValid": true}
+	]`,
+	"/api/cluster/c2:3306": `[
+		{"Key": {"Hostname": "c2", "Port": 3306}, "ReplicationLagSeconds": {"Int64": 0, "Valid": false}, "IsLastCheckValid": false}
+	]`,
+}
+
+// testAPI is a fake orchestrator API serving given responses, gzip compressed when the client accepts it
+type testAPI struct {
+	*httptest.Server
+	responses map[string]string
+
+	mutex          sync.Mutex
+	newConnections int
+	gzipResponses  int
+}
+
+func newTestAPI(responses map[string]string) *testAPI {
+	api := &testAPI{responses: responses}
+	api.Server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, found := api.responses[r.URL.Path]
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+		if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			api.mutex.Lock()
+			api.gzipResponses++
+			api.mutex.Unlock()
+			w.Header().Set("Content-Encoding", "gzip")
+			gz := gzip.NewWriter(w)
+			defer gz.Close()
+			fmt.Fprint(gz, response)
+			return
+		}
+		fmt.Fprint(w, response)
+	}))
+	api.Server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			api.mutex.Lock()
+			api.newConnections++
+			api.mutex.Unlock()
+		}
+	}
+	api.Start()
+	return api
+}
+
+func newTestExporter(api *testAPI, options transportOptions) *exporter {
+	return &exporter{
+		api:         api.URL + "/api",
+		instanceLag: true,
+		client:      &http.Client{Timeout: 5 * time.Second, Transport: newTransport(options)},
+		payloads:    make(map[string]*payloadStats),
+	}
+}
+
+var defaultTestTransportOptions = transportOptions{
+	maxIdleConnsPerHost: 4,
+	idleConnTimeout:     90 * time.Second,
+	tcpKeepAlive:        30 * time.Second,
+	http2:               true,
+	compression:         true,
+}
+
+func scrape(e *exporter) string {
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	return recorder.Body.String()
+}
+
+// parsedFamily is a metric family as read from the text exposition format: its type, and values by series
+type parsedFamily struct {
+	metricType string
+	help       string
+	samples    map[string]float64
+}
+
+var (
+	metricNameRegexp  = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	labelNameRegexp   = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	labelValueEscapes = strings.NewReplacer(`\\`, `\`, `\"`, `"`, `\n`, "\n")
+)
+
+// parseLabels parses `name="value",...`, returning the labels in canonical form, and the unparsed remainder
+func parseLabels(text string) (string, string, error) {
+	labels := []string{}
+	names := map[string]bool{}
+	for {
+		if strings.HasPrefix(text, "}") {
+			return strings.Join(labels, ","), text[1:], nil
+		}
+		tokens := strings.SplitN(text, `="`, 2)
+		if len(tokens) != 2 || !labelNameRegexp.MatchString(tokens[0]) {
+			return "", "", fmt.Errorf("invalid label in %q", text)
+		}
+		name := tokens[0]
+		if names[name] {
+			return "", "", fmt.Errorf("duplicate label %s", name)
+		}
+		names[name] = true
+		text = tokens[1]
+		end := 0
+		for ; end < len(text) && text[end] != '"'; end++ {
+			if text[end] == '\\' {
+				end++
+			}
+		}
+		if end >= len(text) {
+			return "", "", fmt.Errorf("unterminated value of label %s", name)
+		}
+		labels = append(labels, fmt.Sprintf("%s=%s", name, labelValueEscapes.Replace(text[:end])))
+		text = strings.TrimPrefix(text[end+1:], ",")
+	}
+}
+
+// parseExposition parses metrics in text exposition format, enforcing the rules of the Prometheus text parser:
+// HELP and TYPE are given at most once per family, before its samples, and the samples of a family are consecutive.
+func parseExposition(text string) (map[string]*parsedFamily, error) {
+	families := map[string]*parsedFamily{}
+	getFamily := func(name string) *parsedFamily {
+		if _, found := families[name]; !found {
+			families[name] = &parsedFamily{samples: map[string]float64{}}
+		}
+		return families[name]
+	}
+	currentFamily := ""
+	for i, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		lineError := func(format string, args ...interface{}) error {
+			return fmt.Errorf("line %d: %s: %q", i+1, fmt.Sprintf(format, args...), line)
+		}
+		if strings.HasPrefix(line, "# ") {
+			tokens := strings.SplitN(line, " ", 4)
+			if len(tokens) != 4 || (tokens[1] != "HELP" && tokens[1] != "TYPE") {
+				return nil, lineError("invalid comment")
+			}
+			name := tokens[2]
+			if !metricNameRegexp.MatchString(name) {
+				return nil, lineError("invalid metric name")
+			}
+			family := getFamily(name)
+			if len(family.samples) > 0 {
+				return nil, lineError("%s after samples of %s", tokens[1], name)
+			}
+			switch tokens[1] {
+			case "HELP":
+				if family.help != "" {
+					return nil, lineError("second HELP line for %s", name)
+				}
+				family.help = tokens[3]
+			case "TYPE":
+				if family.metricType != "" {
+					return nil, lineError("second TYPE line for %s", name)
+				}
+				if tokens[3] != "gauge" && tokens[3] != "counter" && tokens[3] != "untyped" {
+					return nil, lineError("unexpected type %s", tokens[3])
+				}
+				family.metricType = tokens[3]
+			}
+			currentFamily = name
+			continue
+		}
+		nameEnd := strings.IndexAny(line, "{ ")
+		if nameEnd < 0 || !metricNameRegexp.MatchString(line[:nameEnd]) {
+			return nil, lineError("invalid sample")
+		}
+		name, rest := line[:nameEnd], line[nameEnd:]
+		if name != currentFamily && families[name] != nil && len(families[name].samples) > 0 {
+			return nil, lineError("samples of %s are not consecutive", name)
+		}
+		series := ""
+		if strings.HasPrefix(rest, "{") {
+			var err error
+			if series, rest, err = parseLabels(rest[1:]); err != nil {
+				return nil, lineError("%+v", err)
+			}
+		}
+		value, err := strconv.ParseFloat(strings.TrimPrefix(rest, " "), 64)
+		if err != nil || !strings.HasPrefix(rest, " ") {
+			return nil, lineError("invalid value")
+		}
+		family := getFamily(name)
+		if _, found := family.samples[series]; found {
+			return nil, lineError("duplicate series")
+		}
+		family.samples[series] = value
+		currentFamily = name
+	}
+	return families, nil
+}
+
+func expectSample(t *testing.T, families map[string]*parsedFamily, name string, metricType string, series string, value float64) {
+	family, found := families[name]
+	if !found {
+		t.Fatalf("missing family %s", name)
+	}
+	test.S(t).ExpectEquals(family.metricType, metricType)
+	sample, found := family.samples[series]
+	if !found {
+		t.Fatalf("missing series %s{%s}; got %+v", name, series, family.samples)
+	}
+	test.S(t).ExpectEquals(sample, value)
+}
+
+func TestParseExpositionRejectsInterleavedFamilies(t *testing.T) {
+	_, err := parseExposition("# TYPE a gauge\na 1\n# TYPE b gauge\nb 1\na{x=\"1\"} 2\n")
+	test.S(t).ExpectNotNil(err)
+	_, err = parseExposition("# TYPE a gauge\na 1\n# TYPE a gauge\n")
+	test.S(t).ExpectNotNil(err)
+	_, err = parseExposition("a{x=\"1\"} 1\na{x=\"1\"} 2\n")
+	test.S(t).ExpectNotNil(err)
+}
+
+func TestMetricsWriterGroupsFamilies(t *testing.T) {
+	w := newMetricsWriter()
+	w.write("a", "A.", 1, "cluster", "c1")
+	w.write("b", "B.", 2, "cluster", "c1")
+	w.write("a", "A.", 3, "cluster", "c2")
+	w.writeCounter("c", "C.", 4)
+	w.write("b", "B.", 5, "cluster", "c\"2\\\n")
+
+	families, err := parseExposition(string(w.bytes()))
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(len(families), 3)
+	expectSample(t, families, "a", "gauge", "cluster=c1", 1)
+	expectSample(t, families, "a", "gauge", "cluster=c2", 3)
+	expectSample(t, families, "b", "gauge", "cluster=c1", 2)
+	expectSample(t, families, "b", "gauge", "cluster=c\"2\\\n", 5)
+	expectSample(t, families, "c", "counter", "", 4)
+}
+
+func TestScrape(t *testing.T) {
+	api := newTestAPI(testAPIResponses)
+	defer api.Close()
+	e := newTestExporter(api, defaultTestTransportOptions)
+
+	families, err := parseExposition(scrape(e))
+	test.S(t).ExpectNil(err)
+
+	expectSample(t, families, "orchestrator_exporter_up", "gauge", "", 1)
+	test.S(t).ExpectEquals(families["orchestrator_exporter_scrape_duration_seconds"].metricType, "gauge")
+
+	expectSample(t, families, "orchestrator_cluster_instances", "gauge", "cluster=c1:3306,alias=c1", 2)
+	expectSample(t, families, "orchestrator_cluster_instances", "gauge", "cluster=c2:3306,alias=c2", 1)
+	expectSample(t, families, "orchestrator_cluster_heuristic_lag_seconds", "gauge", "cluster=c1:3306,alias=c1", 5)
+	expectSample(t, families, "orchestrator_cluster_automated_master_recovery", "gauge", "cluster=c1:3306,alias=c1", 1)
+	expectSample(t, families, "orchestrator_cluster_automated_master_recovery", "gauge", "cluster=c2:3306,alias=c2", 0)
+
+	expectSample(t, families, "orchestrator_replication_analysis", "gauge", "cluster=c1:3306,alias=c1,instance=c1:3306,analysis=AllMasterReplicasNotReplicating,downtimed=false", 1)
+	expectSample(t, families, "orchestrator_replication_analysis", "gauge", "cluster=c2:3306,alias=c2,instance=c2:3306,analysis=DeadMaster,downtimed=true", 1)
+
+	expectSample(t, families, "orchestrator_cluster_recent_recoveries", "gauge", "cluster=c1:3306,alias=c1,successful=true", 0)
+	expectSample(t, families, "orchestrator_cluster_recent_recoveries", "gauge", "cluster=c1:3306,alias=c1,successful=false", 1)
+	expectSample(t, families, "orchestrator_cluster_recent_recoveries", "gauge", "cluster=c2:3306,alias=c2,successful=true", 1)
+	expectSample(t, families, "orchestrator_cluster_recent_recoveries", "gauge", "cluster=gone:3306,alias=gone,successful=true", 1)
+	expectSample(t, families, "orchestrator_cluster_unacknowledged_recoveries", "gauge", "cluster=c1:3306,alias=c1", 0)
+	expectSample(t, families, "orchestrator_cluster_unacknowledged_recoveries", "gauge", "cluster=c2:3306,alias=c2", 1)
+
+	expectSample(t, families, "orchestrator_instance_last_check_valid", "gauge", "cluster=c1:3306,alias=c1,instance=c1-replica:3306", 1)
+	expectSample(t, families, "orchestrator_instance_last_check_valid", "gauge", "cluster=c2:3306,alias=c2,instance=c2:3306", 0)
+	expectSample(t, families, "orchestrator_instance_replication_lag_seconds", "gauge", "cluster=c1:3306,alias=c1,instance=c1-replica:3306", 7)
+	test.S(t).ExpectEquals(len(families["orchestrator_instance_replication_lag_seconds"].samples), 1)
+}
+
+func TestScrapePayloadStats(t *testing.T) {
+	api := newTestAPI(testAPIResponses)
+	defer api.Close()
+	e := newTestExporter(api, defaultTestTransportOptions)
+
+	scrape(e)
+	families, err := parseExposition(scrape(e))
+	test.S(t).ExpectNil(err)
+
+	// counters accumulate across scrapes
+	expectSample(t, families, "orchestrator_exporter_api_requests_total", "counter", "endpoint=clusters-info", 2)
+	expectSample(t, families, "orchestrator_exporter_api_requests_total", "counter", "endpoint=cluster", 4)
+	expectSample(t, families, "orchestrator_exporter_api_payload_bytes_total", "counter", "endpoint=clusters-info", float64(2*len(testAPIResponses["/api/clusters-info"])))
+	expectSample(t, families, "orchestrator_exporter_api_entities_total", "counter", "endpoint=clusters-info", 4)
+	expectSample(t, families, "orchestrator_exporter_api_entities_total", "counter", "endpoint=replication-analysis", 4)
+	expectSample(t, families, "orchestrator_exporter_api_entities_total", "counter", "endpoint=audit-recovery", 6)
+	expectSample(t, families, "orchestrator_exporter_api_entities_total", "counter", "endpoint=cluster", 6)
+}
+
+func TestScrapeTransportOptions(t *testing.T) {
+	for _, compression := range []bool{true, false} {
+		api := newTestAPI(testAPIResponses)
+		options := defaultTestTransportOptions
+		options.compression = compression
+		e := newTestExporter(api, options)
+
+		scrape(e)
+		families, err := parseExposition(scrape(e))
+		api.Close()
+		test.S(t).ExpectNil(err)
+		expectSample(t, families, "orchestrator_exporter_up", "gauge", "", 1)
+		// payload bytes are counted decompressed
+		expectSample(t, families, "orchestrator_exporter_api_payload_bytes_total", "counter", "endpoint=clusters-info", float64(2*len(testAPIResponses["/api/clusters-info"])))
+		test.S(t).ExpectEquals(api.gzipResponses > 0, compression)
+		// sequential requests of both scrapes are served on a single kept-alive connection
+		test.S(t).ExpectEquals(api.newConnections, 1)
+	}
+}
+
+func TestScrapeMalformedPayload(t *testing.T) {
+	responses := map[string]string{}
+	for path, response := range testAPIResponses {
+		responses[path] = response
+	}
+	responses["/api/replication-analysis"] = `{"Code": "OK", "Message": "Analysis", "Details": {"unexpected": "shape"}}`
+	api := newTestAPI(responses)
+	defer api.Close()
+	e := newTestExporter(api, defaultTestTransportOptions)
+
+	families, err := parseExposition(scrape(e))
+	test.S(t).ExpectNil(err)
+	expectSample(t, families, "orchestrator_exporter_up", "gauge", "", 0)
+	// partial results of the failed scrape are discarded
+	_, found := families["orchestrator_cluster_instances"]
+	test.S(t).ExpectFalse(found)
+}
+
+func TestRecoverPanic(t *testing.T) {
+	payload := []byte(strings.Repeat("x", payloadSnippetLength+10))
+	err := func() (err error) {
+		defer recoverPanic("some-path", &payload, &err)
+		panic("unexpected payload")
+	}()
+	test.S(t).ExpectNotNil(err)
+	test.S(t).ExpectTrue(strings.Contains(err.Error(), "some-path: recovered from panic: unexpected payload"))
+	test.S(t).ExpectTrue(strings.Contains(err.Error(), strings.Repeat("x", payloadSnippetLength)+`"`))
+}
@@ -0,0 +1,292 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// orchestrator-smoketest verifies a running orchestrator deployment via its HTTP API, e.g. following an
+// upgrade. It runs a set of read-only capability checks and reports pass, fail or skip for each. It exits
+// with status 1 if any check failed.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/orchestrator/go/apibase"
+	"github.com/openark/orchestrator/go/vaultauth"
+)
+
+type apiResponse struct {
+	Code    string
+	Message string
+	Details json.RawMessage
+}
+
+type healthStatus struct {
+	Healthy      bool
+	Hostname     string
+	IsActiveNode bool
+	ActiveNode   struct {
+		Hostname string
+	}
+	RaftLeader string
+}
+
+type clusterInfo struct {
+	ClusterName  string
+	ClusterAlias string
+}
+
+// errSkipped is returned by checks which do not apply, e.g. cluster checks on an empty deployment
+var errSkipped = fmt.Errorf("skipped")
+
+// smoketest runs checks against an orchestrator API. Checks run in order; later checks may use
+// what earlier checks found, e.g. the cluster to check.
+type smoketest struct {
+	api      string
+	user     string
+	password string
+	client   *http.Client
+
+	clusterName string
+}
+
+// check is a single capability check
+type check struct {
+	name        string
+	description string
+	run         func(*smoketest) error
+}
+
+var checks = []check{
+	{"health", "the node is healthy", (*smoketest).checkHealth},
+	{"leader", "a leader (active node or raft leader) is elected", (*smoketest).checkLeader},
+	{"clusters", "clusters are listed", (*smoketest).checkClusters},
+	{"cluster-instances", "instances of a cluster are listed", (*smoketest).checkClusterInstances},
+	{"topology", "the topology of a cluster is rendered", (*smoketest).checkTopology},
+	{"replication-analysis", "replication analysis is served", (*smoketest).checkReplicationAnalysis},
+	{"recoveries", "recent recoveries are listed", (*smoketest).checkRecoveries},
+	{"global-recoveries", "the global recoveries setting is served", (*smoketest).checkGlobalRecoveries},
+	{"maintenance", "active maintenance is listed", (*smoketest).checkMaintenance},
+	{"api-endpoints", "API endpoints are described", (*smoketest).checkAPIEndpoints},
+}
+
+func (this *smoketest) get(path string, v interface{}) error {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s", this.api, path), nil)
+	if err != nil {
+		return err
+	}
+	if this.user != "" {
+		req.SetBasicAuth(this.user, this.password)
+	}
+	res, err := this.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: got status %d", path, res.StatusCode)
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("%s: %+v", path, err)
+	}
+	return nil
+}
+
+// getDetails reads an API endpoint which responds with an APIResponse, and decodes its Details, if v is non-nil
+func (this *smoketest) getDetails(path string, v interface{}) error {
+	response := apiResponse{}
+	if err := this.get(path, &response); err != nil {
+		return err
+	}
+	if response.Code != "OK" {
+		return fmt.Errorf("%s: %s", path, response.Message)
+	}
+	if v == nil {
+		return nil
+	}
+	if err := json.Unmarshal(response.Details, v); err != nil {
+		return fmt.Errorf("%s: %+v", path, err)
+	}
+	return nil
+}
+
+func (this *smoketest) checkHealth() error {
+	health := healthStatus{}
+	if err := this.getDetails("health", &health); err != nil {
+		return err
+	}
+	if !health.Healthy {
+		return fmt.Errorf("%s is not healthy", health.Hostname)
+	}
+	return nil
+}
+
+func (this *smoketest) checkLeader() error {
+	health := healthStatus{}
+	if err := this.getDetails("health", &health); err != nil {
+		return err
+	}
+	if health.ActiveNode.Hostname == "" && health.RaftLeader == "" {
+		return fmt.Errorf("no active node nor raft leader")
+	}
+	return nil
+}
+
+func (this *smoketest) checkClusters() error {
+	clusters := []clusterInfo{}
+	if err := this.get("clusters-info", &clusters); err != nil {
+		return err
+	}
+	if this.clusterName == "" && len(clusters) > 0 {
+		this.clusterName = clusters[0].ClusterName
+	}
+	return nil
+}
+
+func (this *smoketest) checkClusterInstances() error {
+	if this.clusterName == "" {
+		return errSkipped
+	}
+	instances := []json.RawMessage{}
+	if err := this.get(fmt.Sprintf("cluster/%s", url.PathEscape(this.clusterName)), &instances); err != nil {
+		return err
+	}
+	if len(instances) == 0 {
+		return fmt.Errorf("no instances listed for %s", this.clusterName)
+	}
+	return nil
+}
+
+func (this *smoketest) checkTopology() error {
+	if this.clusterName == "" {
+		return errSkipped
+	}
+	return this.getDetails(fmt.Sprintf("topology/%s", url.PathEscape(this.clusterName)), nil)
+}
+
+func (this *smoketest) checkReplicationAnalysis() error {
+	analysis := []json.RawMessage{}
+	return this.getDetails("replication-analysis", &analysis)
+}
+
+func (this *smoketest) checkRecoveries() error {
+	recoveries := []json.RawMessage{}
+	return this.get("audit-recovery", &recoveries)
+}
+
+func (this *smoketest) checkGlobalRecoveries() error {
+	return this.getDetails("check-global-recoveries", nil)
+}
+
+func (this *smoketest) checkMaintenance() error {
+	maintenance := []json.RawMessage{}
+	return this.get("maintenance", &maintenance)
+}
+
+func (this *smoketest) checkAPIEndpoints() error {
+	endpoints := []json.RawMessage{}
+	if err := this.get("api-endpoints", &endpoints); err != nil {
+		return err
+	}
+	if len(endpoints) == 0 {
+		return fmt.Errorf("no API endpoints described")
+	}
+	return nil
+}
+
+// selectChecks returns the checks named by given comma separated list, in their order of execution
+func selectChecks(names string) ([]check, error) {
+	if names == "" {
+		return checks, nil
+	}
+	selected := make(map[string]bool)
+	for _, name := range strings.Split(names, ",") {
+		selected[strings.TrimSpace(name)] = true
+	}
+	result := []check{}
+	for _, c := range checks {
+		if selected[c.name] {
+			result = append(result, c)
+			delete(selected, c.name)
+		}
+	}
+	for name := range selected {
+		return result, fmt.Errorf("Unknown check: %s", name)
+	}
+	return result, nil
+}
+
+func main() {
+	api := flag.String("api", "http://localhost:3000/api", "orchestrator API endpoint")
+	apiBasePath := flag.String("api-base-path", "", "path of the orchestrator API, e.g. /orchestrator/api, replacing that of -api; default: probed")
+	checkNames := flag.String("checks", "", "comma separated checks to run; default: all. See -list")
+	clusterName := flag.String("cluster", "", "cluster to run cluster checks on; default: the first listed cluster")
+	timeout := flag.Duration("timeout", 10*time.Second, "timeout for each request to the orchestrator API")
+	list := flag.Bool("list", false, "list the available checks and exit")
+	flag.Parse()
+
+	if *list {
+		for _, c := range checks {
+			fmt.Printf("%s\t%s\n", c.name, c.description)
+		}
+		return
+	}
+	selected, err := selectChecks(*checkNames)
+	if err != nil {
+		log.Fatale(err)
+	}
+	s := &smoketest{
+		user:        os.Getenv("ORCHESTRATOR_AUTH_USER"),
+		password:    os.Getenv("ORCHESTRATOR_AUTH_PASSWORD"),
+		client:      &http.Client{Timeout: *timeout},
+		clusterName: *clusterName,
+	}
+	if provider := vaultauth.FromEnvironment(&http.Client{Timeout: *timeout}); provider != nil {
+		go provider.Run(context.Background(), func(err error) { log.Errorf("vault: %+v", err) })
+		s.client.Transport = provider.Transport(s.client.Transport)
+	}
+	s.api = apibase.Resolve(context.Background(), *api, apibase.Options{BasePath: *apiBasePath, User: s.user, Password: s.password, Client: s.client})
+	failed := 0
+	for _, c := range selected {
+		startTime := time.Now()
+		err := c.run(s)
+		elapsed := time.Since(startTime).Round(time.Millisecond)
+		switch err {
+		case nil:
+			fmt.Printf("PASS\t%s\t%v\n", c.name, elapsed)
+		case errSkipped:
+			fmt.Printf("SKIP\t%s\tno cluster to check: none listed by the clusters check, nor given by -cluster\n", c.name)
+		default:
+			failed++
+			fmt.Printf("FAIL\t%s\t%v\t%+v\n", c.name, elapsed, err)
+		}
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,165 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// orchestrator-watchdog continuously assesses the high availability of an orchestrator deployment via
+// the HTTP API of each of its nodes, and runs a hook whenever the assessed status changes. See the
+// watchdog package for the assessment.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/orchestrator/go/apibase"
+	orcos "github.com/openark/orchestrator/go/os"
+	"github.com/openark/orchestrator/go/vaultauth"
+	"github.com/openark/orchestrator/go/watchdog"
+)
+
+// exitCodes map statuses to the exit code of -once
+var exitCodes = map[watchdog.Status]int{
+	watchdog.StatusHealthy:  0,
+	watchdog.StatusDegraded: 1,
+	watchdog.StatusCritical: 2,
+}
+
+// hookEnv describes an assessment to the hook via environment variables
+func hookEnv(assessment *watchdog.Assessment, previous *watchdog.Assessment) []string {
+	previousStatus := ""
+	if previous != nil {
+		previousStatus = string(previous.Status)
+	}
+	return append(os.Environ(),
+		fmt.Sprintf("ORC_WATCHDOG_STATUS=%s", assessment.Status),
+		fmt.Sprintf("ORC_WATCHDOG_PREVIOUS_STATUS=%s", previousStatus),
+		fmt.Sprintf("ORC_WATCHDOG_REASONS=%s", strings.Join(assessment.Reasons, "; ")),
+		fmt.Sprintf("ORC_WATCHDOG_LEADER=%s", assessment.Leader),
+		fmt.Sprintf("ORC_WATCHDOG_LEADER_CHANGES=%d", assessment.LeaderChanges),
+		fmt.Sprintf("ORC_WATCHDOG_TIMESTAMP=%s", assessment.Timestamp.Format(time.RFC3339)),
+	)
+}
+
+// serveAssessment serves the latest assessment as JSON. It responds with 503 unless the deployment is healthy,
+// so that it may itself be probed by a load balancer or an external monitor.
+func serveAssessment(w *watchdog.Watchdog) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		assessment := w.LastAssessment()
+		if assessment == nil {
+			http.Error(rw, "no assessment yet", http.StatusServiceUnavailable)
+			return
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		if assessment.Status != watchdog.StatusHealthy {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(rw).Encode(assessment)
+	}
+}
+
+func main() {
+	api := flag.String("api", "http://localhost:3000/api", "comma separated API endpoints of all orchestrator nodes")
+	apiBasePath := flag.String("api-base-path", "", "path of the orchestrator API, e.g. /orchestrator/api, replacing that of each -api endpoint; default: probed")
+	interval := flag.Duration("interval", 10*time.Second, "interval between assessments")
+	timeout := flag.Duration("timeout", 5*time.Second, "timeout for each request to an orchestrator node")
+	flapWindow := flag.Duration("flap-window", 10*time.Minute, "window within which leader changes are counted")
+	maxLeaderChanges := flag.Int("max-leader-changes", 2, "more leader changes than this within -flap-window are considered flapping")
+	statusPath := flag.String("status-path", "status", "path of the status check, relative to the API endpoint")
+	hook := flag.String("hook", "", "shell command to run when the assessed status changes. Details are passed via ORC_WATCHDOG_* environment variables")
+	listen := flag.String("listen", "", "address on which to serve the latest assessment at /assessment; empty to not serve")
+	once := flag.Bool("once", false, "assess once, print the assessment as JSON and exit with 0 (healthy), 1 (degraded) or 2 (critical)")
+	debug := flag.Bool("debug", false, "debug mode (very verbose)")
+	flag.Parse()
+
+	log.SetLevel(log.INFO)
+	if *debug {
+		log.SetLevel(log.DEBUG)
+	}
+
+	user, password := os.Getenv("ORCHESTRATOR_AUTH_USER"), os.Getenv("ORCHESTRATOR_AUTH_PASSWORD")
+	client := &http.Client{Timeout: *timeout}
+	if provider := vaultauth.FromEnvironment(&http.Client{Timeout: *timeout}); provider != nil {
+		go provider.Run(context.Background(), func(err error) { log.Errorf("vault: %+v", err) })
+		client.Transport = provider.Transport(client.Transport)
+	}
+	nodes := []string{}
+	for _, node := range strings.Split(*api, ",") {
+		nodes = append(nodes, apibase.Resolve(context.Background(), node, apibase.Options{BasePath: *apiBasePath, User: user, Password: password, Client: client}))
+	}
+	w := watchdog.NewWatchdog(watchdog.Options{
+		Nodes:            nodes,
+		User:             user,
+		Password:         password,
+		Client:           client,
+		StatusPath:       *statusPath,
+		Interval:         *interval,
+		FlapWindow:       *flapWindow,
+		MaxLeaderChanges: *maxLeaderChanges,
+	})
+
+	if *once {
+		assessment := w.Assess()
+		encoded, err := json.MarshalIndent(assessment, "", "  ")
+		if err != nil {
+			log.Fatale(err)
+		}
+		fmt.Println(string(encoded))
+		os.Exit(exitCodes[assessment.Status])
+	}
+
+	w.OnAssessment(func(assessment *watchdog.Assessment, previous *watchdog.Assessment) {
+		log.Debugf("assessment: %s, leader: %s, reasons: %s", assessment.Status, assessment.Leader, strings.Join(assessment.Reasons, "; "))
+	})
+	w.OnStatusChange(func(assessment *watchdog.Assessment, previous *watchdog.Assessment) {
+		if assessment.Status == watchdog.StatusHealthy {
+			log.Infof("status: %s, leader: %s", assessment.Status, assessment.Leader)
+		} else {
+			log.Warningf("status: %s, leader: %s, reasons: %s", assessment.Status, assessment.Leader, strings.Join(assessment.Reasons, "; "))
+		}
+		if *hook != "" {
+			go orcos.CommandRun(*hook, hookEnv(assessment, previous))
+		}
+	})
+	if *listen != "" {
+		http.HandleFunc("/assessment", serveAssessment(w))
+		go func() {
+			log.Infof("Serving assessments on %s/assessment", *listen)
+			if err := http.ListenAndServe(*listen, nil); err != nil {
+				log.Fatale(err)
+			}
+		}()
+	}
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		<-signals
+		log.Infof("Shutting down")
+		ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+		defer cancel()
+		if err := w.Close(ctx); err != nil {
+			log.Errore(err)
+		}
+	}()
+	w.Run(make(chan struct{}))
+}
@@ -84,6 +84,15 @@ var deprecatedConfigurationVariables = []string{
 	"MaxOutdatedKeysToShow",
 }
 
+// RecoveryAckRule describes completed recoveries which are acknowledged automatically
+type RecoveryAckRule struct {
+	Analysis       []string // Analysis codes (e.g. "DeadIntermediateMaster") the rule applies to. Empty for any analysis
+	ClusterFilters []string // Clusters the rule applies to, in the format of RecoverMasterClusterFilters. Empty for any cluster
+	MinAgeSeconds  uint     // Acknowledge recoveries which completed at least this many seconds ago
+	SuccessfulOnly bool     // Only acknowledge successful recoveries
+	Comment        string   // Acknowledgement comment. May use the placeholders of PostFailoverProcesses, e.g. "auto: {failureType} on {failedHost}"
+}
+
 // Configuration makes for orchestrator configuration input, which can be provided by user via JSON formatted file.
 // Some of the parameteres have reasonable default values, and some (like database credentials) are
 // strictly expected from user.
@@ -146,6 +155,7 @@ type Configuration struct {
 	DeadInstancePollSecondsMax                 uint     // Maximum delay between dead instance read attempts
 	DeadInstanceDiscoveryMaxConcurrency        uint     // Number of goroutines doing dead hosts discovery
 	DeadInstanceDiscoveryLogsEnabled           bool     // Enable logs related to dead instances discoveries
+	PriorityDiscoveryMaxConcurrency            uint     // Number of goroutines serving prioritized discovery requests, ahead of ordinary discovery. When 0, prioritized requests are served synchronously
 	ReasonableInstanceCheckSeconds             uint     // Number of seconds an instance read is allowed to take before it is considered invalid, i.e. before LastCheckValid will be false
 	InstanceWriteBufferSize                    int      // Instance write buffer size (max number of instances to flush in one INSERT ODKU)
 	BufferInstanceWrites                       bool     // Set to 'true' for write-optimization on backend table (compromise: writes can be stale and overwrite non stale data)
@@ -157,6 +167,8 @@ type Configuration struct {
 	DiscoveryQueueCapacity                     uint     // Buffer size of the discovery queue. Should be greater than the number of DB instances being discovered
 	DiscoveryQueueMaxStatisticsSize            int      // The maximum number of individual secondly statistics taken of the discovery queue
 	DiscoveryCollectionRetentionSeconds        uint     // Number of seconds to retain the discovery collection information
+	DiscoveryMetricsRollupWindowSeconds        uint     // When > 0, discovery metrics are rolled up into windows of this many seconds (per instance mean/p95 latencies). Must not exceed DiscoveryCollectionRetentionSeconds
+	DiscoveryMetricsRollupRetainWindows        uint     // Number of discovery metrics rollup windows to retain in memory
 	DiscoverySeeds                             []string // Hard coded array of hostname:port, ensuring orchestrator discovers these hosts upon startup, assuming not already known to orchestrator
 	InstanceBulkOperationsWaitTimeoutSeconds   uint     // Time to wait on a single instance when doing bulk (many instances) operation
 	HostnameResolveMethod                      string   // Method by which to "normalize" hostname ("none"/"default"/"cname")
@@ -169,6 +181,7 @@ type Configuration struct {
 	VerifyReplicationFilters                   bool     // Include replication filters check before approving topology refactoring
 	ReasonableMaintenanceReplicationLagSeconds int      // Above this value move-up and move-below are blocked
 	CandidateInstanceExpireMinutes             uint     // Minutes after which a suggestion to use an instance as a candidate replica (to be preferably promoted on master failover) is expired.
+	DelayedReplicaTagName                      string   // When non-empty, instances tagged by this name are managed as delayed replicas: the tag value is the desired SQL delay in seconds, enforced periodically, and such instances are never promoted.
 	AuditLogFile                               string   // Name of log file for audit operations. Disabled when empty.
 	AuditToSyslog                              bool     // If true, audit messages are written to syslog
 	AuditToBackendDB                           bool     // If true, audit messages are written to the backend DB's `audit` table (default: true)
@@ -198,6 +211,8 @@ type Configuration struct {
 	DetectRegionQuery                          string            // Optional query (executed on topology instance) that returns the region of an instance. If provided, must return one row, one column. Overrides RegionPattern and useful for installments where Region cannot be inferred by hostname
 	DetectPhysicalEnvironmentQuery             string            // Optional query (executed on topology instance) that returns the physical environment of an instance. If provided, must return one row, one column. Overrides PhysicalEnvironmentPattern and useful for installments where env cannot be inferred by hostname
 	DetectSemiSyncEnforcedQuery                string            // Optional query (executed on topology instance) to determine whether semi-sync is fully enforced for master writes (async fallback is not allowed under any circumstance). If provided, must return one row, one column, value 0 or 1.
+	InstanceEnricherCommands                   map[string]string // Commands annotating instances with extra metadata (e.g. EC2 tags, GCP labels, CMDB records), by enricher name. Each prints a JSON object of string values, and is given the instance via {hostname}, {port} placeholders and ORC_* env variables
+	InstanceEnrichmentSeconds                  uint              // Time for which an instance's enrichment is cached before it is refreshed
 	SupportFuzzyPoolHostnames                  bool              // Should "submit-pool-instances" command be able to pass list of fuzzy instances (fuzzy means non-fqdn, but unique enough to recognize). Defaults 'true', implies more queries on backend db
 	InstancePoolExpiryMinutes                  uint              // Time after which entries in database_instance_pool are expired (resubmit via `submit-pool-instances`)
 	PromotionIgnoreHostnameFilters             []string          // Orchestrator will not promote replicas with hostname matching pattern (via -c recovery; for example, avoid promoting dev-dedicated machines)
@@ -231,6 +246,8 @@ type Configuration struct {
 	BinlogEventsChunkSize                      int               // Chunk size (X) for SHOW BINLOG|RELAYLOG EVENTS LIMIT ?,X statements. Smaller means less locking and more work to be done
 	SkipBinlogEventsContaining                 []string          // When scanning/comparing binlogs for Pseudo-GTID, skip entries containing given texts. These are NOT regular expressions (would consume too much CPU while scanning binlogs), just substrings to find.
 	ReduceReplicationAnalysisCount             bool              // When true, replication analysis will only report instances where possibility of handled problems is possible in the first place (e.g. will not report most leaf nodes, that are mostly uninteresting). When false, provides an entry for every known instance
+	FlappingAnalysisChangesThreshold           int               // An instance whose replication analysis changes at least this many times within FlappingAnalysisWindowMinutes is considered to be flapping. 0 disables flapping detection
+	FlappingAnalysisWindowMinutes              int               // Time window over which analysis changes are counted for flapping detection
 	FailureDetectionPeriodBlockMinutes         int               // The time for which an instance's failure discovery is kept "active", so as to avoid concurrent "discoveries" of the instance's failure; this precedes any recovery process, if any.
 	RecoveryPeriodBlockMinutes                 int               // (supported for backwards compatibility but please use newer `RecoveryPeriodBlockSeconds` instead) The time for which an instance's recovery is kept "active", so as to avoid concurrent recoveries on same instance as well as flapping
 	RecoveryPeriodBlockSeconds                 int               // (overrides `RecoveryPeriodBlockMinutes`) The time for which an instance's recovery is kept "active", so as to avoid concurrent recoveries on same instance as well as flapping
@@ -247,6 +264,11 @@ type Configuration struct {
 	PostIntermediateMasterFailoverProcesses    []string          // Processes to execute after doing a master failover (order of execution undefined). Uses same placeholders as PostFailoverProcesses
 	PostGracefulTakeoverProcesses              []string          // Processes to execute after running a graceful master takeover. Uses same placeholders as PostFailoverProcesses
 	PostTakeMasterProcesses                    []string          // Processes to execute after a successful Take-Master event has taken place
+	LiteRecoveryAnalysis                       []string          // Analysis codes (e.g. "DeadIntermediateMaster") which recover-auto recovers without running hooks. Does not apply to master failures
+	BlockedRecoveryAgeAlertSeconds             uint              // When > 0, run BlockedRecoveryAgedProcesses once a recovery has been blocked for this many seconds
+	BlockedRecoveryAgedProcesses               []string          // Processes to execute when a blocked recovery ages past BlockedRecoveryAgeAlertSeconds. Details are passed via ORC_BLOCKED_* and ORC_BLOCKING_* environment variables
+	AutoAcknowledgeRecoveryRules               []RecoveryAckRule // Completed recoveries matching any of these rules are acknowledged automatically, keeping unacknowledged recoveries for humans to look at
+	FailoverFreezeWindows                      []FreezeWindow    // Recurring time windows (e.g. peak traffic) during which global recoveries are disabled, and after which they are enabled again
 	RecoverNonWriteableMaster                  bool              // When 'true', orchestrator treats a read-only master as a failure scenario and attempts to make the master writeable
 	CoMasterRecoveryMustPromoteOtherCoMaster   bool              // When 'false', anything can get promoted (and candidates are preferred over others). When 'true', orchestrator will promote the other co-master or else fail
 	DetachLostSlavesAfterMasterFailover        bool              // synonym to DetachLostReplicasAfterMasterFailover
@@ -254,6 +276,10 @@ type Configuration struct {
 	ApplyMySQLPromotionAfterMasterFailover     bool              // Should orchestrator take upon itself to apply MySQL master promotion: set read_only=0, detach replication, etc.
 	PreventCrossDataCenterMasterFailover       bool              // When true (default: false), cross-DC master failover are not allowed, orchestrator will do all it can to only fail over within same DC, or else not fail over at all.
 	PreventCrossRegionMasterFailover           bool              // When true (default: false), cross-region master failover are not allowed, orchestrator will do all it can to only fail over within same region, or else not fail over at all.
+	SameDataCenterPromotionClusterFilters      []string          // Clusters, in the format of RecoverMasterClusterFilters, whose master's replicas are periodically registered as prefer candidates when in the master's data center, and prefer_not otherwise
+	ForceFailoverRequiresConfirmation          bool              // When true (default: false), forced master failover/takeover via API require the cluster name or alias as confirmation, and are refused while recoveries are disabled or another recovery is active on the cluster
+	RefuseOperationsOnDowntimedInstances       bool              // When true (default: false), API calls operating on an instance refuse to act on instances which are downtimed or under maintenance, unless given override=true
+	MaxAutomatedRelocationsPerHour             int               // Automated API relocations (made with automated=true) of an instance which has already been relocated this many times within the last hour are refused as flapping. 0 (default) to disable
 	MasterFailoverLostInstancesDowntimeMinutes uint              // Number of minutes to downtime any server that was lost after a master failover (including failed master & lost replicas). 0 to disable
 	MasterFailoverDetachSlaveMasterHost        bool              // synonym to MasterFailoverDetachReplicaMasterHost
 	MasterFailoverDetachReplicaMasterHost      bool              // Should orchestrator issue a detach-replica-master-host on newly promoted master (this makes sure the new master will not attempt to replicate old master if that comes back to life). Defaults 'false'. Meaningless if ApplyMySQLPromotionAfterMasterFailover is 'true'.
@@ -281,8 +307,17 @@ type Configuration struct {
 	ConsulMaxKVsPerTransaction                 int               // Maximum number of KV operations to perform in a single Consul Transaction. Requires the "consul-txn" ConsulKVStoreProvider
 	ZkAddress                                  string            // UNSUPPERTED YET. Address where (single or multiple) ZooKeeper servers are found, in `srv1[:port1][,srv2[:port2]...]` format. Default port is 2181. Example: srv-a,srv-b:12181,srv-c
 	KVClusterMasterPrefix                      string            // Prefix to use for clusters' masters entries in KV stores (internal, consul, ZK), default: "mysql/master"
+	KVControllerLeasePrefix                    string            // Prefix to use for automation controllers' lease entries in KV stores, default: "orchestrator/controller"
+	ProxySQLAdminAddress                       string            // Address of ProxySQL admin interface. When provided, orchestrator updates ProxySQL's writer hostgroup upon master failover. Example: 127.0.0.1
+	ProxySQLAdminPort                          int               // Port of ProxySQL admin interface, default: 6032
+	ProxySQLAdminUser                          string            // User for ProxySQL admin interface, default: "admin"
+	ProxySQLAdminPassword                      string            // Password for ProxySQL admin interface
+	ProxySQLWriterHostgroup                    uint              // ProxySQL hostgroup where the master is found. Must be non-zero when ProxySQLAdminAddress is provided
+	ProxySQLPreFailoverAction                  string            // Action to apply on the failed master in ProxySQL before failover: "offline_soft" (default), "weight_zero" or "none"
 	WebMessage                                 string            // If provided, will be shown on all web pages below the title bar
 	MaxConcurrentReplicaOperations             int               // Maximum number of concurrent operations on replicas
+	ClusterFanOutInstanceTimeoutSeconds        int               // Cluster-wide operations which reach out to each instance (e.g. errant GTID scan) give up on an instance after this many seconds
+	ClusterFanOutTimeoutSeconds                int               // Cluster-wide operations which reach out to each instance return their partial results after this many seconds
 	EnforceExactSemiSyncReplicas               bool              // If true, semi-sync replicas will be enabled/disabled to match the wait count in the desired priority order; this applies to LockedSemiSyncMaster and MasterWithTooManySemiSyncReplicas
 	RecoverLockedSemiSyncMaster                bool              // If true, orchestrator will recover from a LockedSemiSync state by enabling semi-sync on replicas to match the wait count; this behavior can be overridden by EnforceExactSemiSyncReplicas
 	ReasonableLockedSemiSyncMasterSeconds      uint              // Time to evaluate the LockedSemiSyncHypothesis before triggering the LockedSemiSync analysis; falls back to ReasonableReplicationLagSeconds if not set
@@ -340,6 +375,7 @@ func newConfiguration() *Configuration {
 		DeadInstancePollSecondsMax:                 5 * 60,
 		DeadInstanceDiscoveryMaxConcurrency:        0,
 		DeadInstanceDiscoveryLogsEnabled:           false,
+		PriorityDiscoveryMaxConcurrency:            4,
 		ReasonableInstanceCheckSeconds:             1,
 		InstanceWriteBufferSize:                    100,
 		BufferInstanceWrites:                       false,
@@ -353,6 +389,8 @@ func newConfiguration() *Configuration {
 		DiscoveryQueueCapacity:                     100000,
 		DiscoveryQueueMaxStatisticsSize:            120,
 		DiscoveryCollectionRetentionSeconds:        120,
+		DiscoveryMetricsRollupWindowSeconds:        0,
+		DiscoveryMetricsRollupRetainWindows:        1440,
 		DiscoverySeeds:                             []string{},
 		InstanceBulkOperationsWaitTimeoutSeconds:   10,
 		HostnameResolveMethod:                      "default",
@@ -365,6 +403,7 @@ func newConfiguration() *Configuration {
 		VerifyReplicationFilters:                   false,
 		ReasonableMaintenanceReplicationLagSeconds: 20,
 		CandidateInstanceExpireMinutes:             60,
+		DelayedReplicaTagName:                      "",
 		AuditLogFile:                               "",
 		AuditToSyslog:                              false,
 		AuditToBackendDB:                           false,
@@ -389,6 +428,8 @@ func newConfiguration() *Configuration {
 		DetectDataCenterQuery:                      "",
 		DetectPhysicalEnvironmentQuery:             "",
 		DetectSemiSyncEnforcedQuery:                "",
+		InstanceEnricherCommands:                   make(map[string]string),
+		InstanceEnrichmentSeconds:                  300,
 		SupportFuzzyPoolHostnames:                  true,
 		InstancePoolExpiryMinutes:                  60,
 		PromotionIgnoreHostnameFilters:             []string{},
@@ -420,6 +461,8 @@ func newConfiguration() *Configuration {
 		BinlogEventsChunkSize:                      10000,
 		SkipBinlogEventsContaining:                 []string{},
 		ReduceReplicationAnalysisCount:             true,
+		FlappingAnalysisChangesThreshold:           6,
+		FlappingAnalysisWindowMinutes:              10,
 		FailureDetectionPeriodBlockMinutes:         60,
 		RecoveryPeriodBlockMinutes:                 60,
 		RecoveryPeriodBlockSeconds:                 3600,
@@ -436,12 +479,21 @@ func newConfiguration() *Configuration {
 		PostUnsuccessfulFailoverProcesses:          []string{},
 		PostGracefulTakeoverProcesses:              []string{},
 		PostTakeMasterProcesses:                    []string{},
+		LiteRecoveryAnalysis:                       []string{},
+		BlockedRecoveryAgeAlertSeconds:             0,
+		BlockedRecoveryAgedProcesses:               []string{},
+		AutoAcknowledgeRecoveryRules:               []RecoveryAckRule{},
+		FailoverFreezeWindows:                      []FreezeWindow{},
 		RecoverNonWriteableMaster:                  false,
 		CoMasterRecoveryMustPromoteOtherCoMaster:   true,
 		DetachLostSlavesAfterMasterFailover:        true,
 		ApplyMySQLPromotionAfterMasterFailover:     true,
 		PreventCrossDataCenterMasterFailover:       false,
 		PreventCrossRegionMasterFailover:           false,
+		SameDataCenterPromotionClusterFilters:      []string{},
+		ForceFailoverRequiresConfirmation:          false,
+		RefuseOperationsOnDowntimedInstances:       false,
+		MaxAutomatedRelocationsPerHour:             0,
 		MasterFailoverLostInstancesDowntimeMinutes: 0,
 		MasterFailoverDetachSlaveMasterHost:        false,
 		FailMasterPromotionOnLagMinutes:            0,
@@ -465,8 +517,17 @@ func newConfiguration() *Configuration {
 		ConsulMaxKVsPerTransaction:                 ConsulKVsPerCluster,
 		ZkAddress:                                  "",
 		KVClusterMasterPrefix:                      "mysql/master",
+		KVControllerLeasePrefix:                    "orchestrator/controller",
+		ProxySQLAdminAddress:                       "",
+		ProxySQLAdminPort:                          6032,
+		ProxySQLAdminUser:                          "admin",
+		ProxySQLAdminPassword:                      "",
+		ProxySQLWriterHostgroup:                    0,
+		ProxySQLPreFailoverAction:                  "offline_soft",
 		WebMessage:                                 "",
 		MaxConcurrentReplicaOperations:             5,
+		ClusterFanOutInstanceTimeoutSeconds:        30,
+		ClusterFanOutTimeoutSeconds:                120,
 		EnforceExactSemiSyncReplicas:               false,
 		RecoverLockedSemiSyncMaster:                false,
 		ReasonableLockedSemiSyncMasterSeconds:      0,
@@ -602,6 +663,14 @@ func (this *Configuration) postReadAdjustments() error {
 		this.KVClusterMasterPrefix = strings.TrimRight(this.KVClusterMasterPrefix, "/")
 		this.KVClusterMasterPrefix = fmt.Sprintf("%s/", this.KVClusterMasterPrefix)
 	}
+	if this.ProxySQLAdminAddress != "" && this.ProxySQLWriterHostgroup == 0 {
+		return fmt.Errorf("ProxySQLWriterHostgroup must be defined since ProxySQLAdminAddress is provided")
+	}
+	switch this.ProxySQLPreFailoverAction {
+	case "", "none", "offline_soft", "weight_zero":
+	default:
+		return fmt.Errorf("ProxySQLPreFailoverAction must be one of: offline_soft, weight_zero, none. Got: %s", this.ProxySQLPreFailoverAction)
+	}
 	if this.AutoPseudoGTID {
 		this.PseudoGTIDPattern = "drop view if exists `_pseudo_gtid_`"
 		this.PseudoGTIDPatternIsFixedSubstring = true
@@ -645,6 +714,19 @@ func (this *Configuration) postReadAdjustments() error {
 	if this.DeadInstancePollSecondsMax < this.InstancePollSeconds {
 		return fmt.Errorf(("DeadInstancePollSecondsMax can not be smaller than InstancePollSeconds"))
 	}
+	if this.DiscoveryMetricsRollupWindowSeconds > this.DiscoveryCollectionRetentionSeconds {
+		return fmt.Errorf("DiscoveryMetricsRollupWindowSeconds can not be greater than DiscoveryCollectionRetentionSeconds")
+	}
+	for name, command := range this.InstanceEnricherCommands {
+		if strings.TrimSpace(command) == "" {
+			return fmt.Errorf("InstanceEnricherCommands: empty command for %s", name)
+		}
+	}
+	for i := range this.FailoverFreezeWindows {
+		if err := this.FailoverFreezeWindows[i].Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/openark/golib/log"
 	test "github.com/openark/golib/tests"
@@ -215,3 +217,86 @@ func TestHttpAdvertise(t *testing.T) {
 		test.S(t).ExpectNotNil(err)
 	}
 }
+
+func TestSanitized(t *testing.T) {
+	c := newConfiguration()
+	c.MySQLTopologyUser = "orchestrator"
+	c.MySQLTopologyPassword = "secret"
+	settings, err := c.Sanitized()
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(settings["MySQLTopologyUser"], "orchestrator")
+	test.S(t).ExpectEquals(settings["MySQLTopologyPassword"], sanitizedValue)
+	test.S(t).ExpectEquals(settings["MySQLOrchestratorPassword"], "")
+}
+
+func TestDiffSettings(t *testing.T) {
+	c := newConfiguration()
+	before, err := c.settings()
+	test.S(t).ExpectNil(err)
+	c.InstancePollSeconds = 7
+	c.MySQLTopologyPassword = "changed"
+	after, err := c.settings()
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectTrue(reflect.DeepEqual(diffSettings(before, after), []string{"InstancePollSeconds", "MySQLTopologyPassword"}))
+	test.S(t).ExpectEquals(len(diffSettings(after, after)), 0)
+}
+
+func TestFreezeWindow(t *testing.T) {
+	// 2026-10-16 is a Friday
+	at := func(clock string) time.Time {
+		parsed, _ := time.ParseInLocation("2006-01-02 15:04", "2026-10-16 "+clock, time.UTC)
+		return parsed
+	}
+	{
+		w := &FreezeWindow{StartTime: "09:00", EndTime: "17:30", Timezone: "UTC"}
+		active, err := w.IsActive(at("08:59"))
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectFalse(active)
+		active, _ = w.IsActive(at("09:00"))
+		test.S(t).ExpectTrue(active)
+		active, _ = w.IsActive(at("17:29"))
+		test.S(t).ExpectTrue(active)
+		active, _ = w.IsActive(at("17:30"))
+		test.S(t).ExpectFalse(active)
+	}
+	{
+		w := &FreezeWindow{Days: []string{"mon", "Thursday"}, StartTime: "09:00", EndTime: "17:30", Timezone: "UTC"}
+		active, err := w.IsActive(at("12:00"))
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectFalse(active)
+		active, _ = w.IsActive(at("12:00").AddDate(0, 0, -1))
+		test.S(t).ExpectTrue(active)
+	}
+	{
+		// spans midnight; starts Thursday night
+		w := &FreezeWindow{Days: []string{"Thu"}, StartTime: "22:00", EndTime: "02:00", Timezone: "UTC"}
+		active, _ := w.IsActive(at("01:59"))
+		test.S(t).ExpectTrue(active)
+		active, _ = w.IsActive(at("02:00"))
+		test.S(t).ExpectFalse(active)
+		active, _ = w.IsActive(at("23:00"))
+		test.S(t).ExpectFalse(active)
+		active, _ = w.IsActive(at("23:00").AddDate(0, 0, -1))
+		test.S(t).ExpectTrue(active)
+	}
+	{
+		w := &FreezeWindow{StartTime: "09:00", EndTime: "17:00", Timezone: "America/New_York"}
+		active, _ := w.IsActive(at("12:00"))
+		test.S(t).ExpectFalse(active)
+		active, _ = w.IsActive(at("14:00"))
+		test.S(t).ExpectTrue(active)
+	}
+	{
+		c := newConfiguration()
+		c.FailoverFreezeWindows = []FreezeWindow{{StartTime: "9am", EndTime: "17:00"}}
+		test.S(t).ExpectNotNil(c.postReadAdjustments())
+		c.FailoverFreezeWindows = []FreezeWindow{{StartTime: "09:00", EndTime: "09:00"}}
+		test.S(t).ExpectNotNil(c.postReadAdjustments())
+		c.FailoverFreezeWindows = []FreezeWindow{{Days: []string{"Funday"}, StartTime: "09:00", EndTime: "17:00"}}
+		test.S(t).ExpectNotNil(c.postReadAdjustments())
+		c.FailoverFreezeWindows = []FreezeWindow{{StartTime: "09:00", EndTime: "17:00", Timezone: "Nowhere/Special"}}
+		test.S(t).ExpectNotNil(c.postReadAdjustments())
+		c.FailoverFreezeWindows = []FreezeWindow{{Days: []string{"Sun"}, StartTime: "09:00", EndTime: "17:00"}}
+		test.S(t).ExpectNil(c.postReadAdjustments())
+	}
+}
@@ -0,0 +1,111 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const freezeWindowTimeLayout = "15:04"
+
+// FreezeWindow is a recurring time window during which global recoveries are disabled, e.g. peak traffic hours
+type FreezeWindow struct {
+	Name      string   // Identifies the window in audit and API output
+	Days      []string // Week days on which the window starts, e.g. ["Mon", "Fri"] or ["Saturday"]. Empty for every day
+	StartTime string   // "HH:MM", in Timezone
+	EndTime   string   // "HH:MM", in Timezone. When earlier than StartTime, the window ends on the following day
+	Timezone  string   // IANA time zone name, e.g. "America/New_York". Empty for the local time zone
+}
+
+// parseFreezeWindowDay parses a week day by name or by its 3 letter abbreviation
+func parseFreezeWindowDay(day string) (time.Weekday, error) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(day, weekday.String()) || strings.EqualFold(day, weekday.String()[0:3]) {
+			return weekday, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("FreezeWindow: unknown day %s", day)
+}
+
+// parseFreezeWindowTime returns the minute of day of a "HH:MM" time
+func parseFreezeWindowTime(clock string) (int, error) {
+	t, err := time.Parse(freezeWindowTimeLayout, clock)
+	if err != nil {
+		return 0, fmt.Errorf("FreezeWindow: unable to parse time %s; expecting HH:MM", clock)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Validate checks the window is well formed
+func (this *FreezeWindow) Validate() error {
+	_, err := this.IsActive(time.Now())
+	return err
+}
+
+// IsActive checks whether given time is within the window
+func (this *FreezeWindow) IsActive(t time.Time) (bool, error) {
+	location := time.Local
+	if this.Timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(this.Timezone); err != nil {
+			return false, fmt.Errorf("FreezeWindow %s: %+v", this.Name, err)
+		}
+	}
+	startMinute, err := parseFreezeWindowTime(this.StartTime)
+	if err != nil {
+		return false, err
+	}
+	endMinute, err := parseFreezeWindowTime(this.EndTime)
+	if err != nil {
+		return false, err
+	}
+	if startMinute == endMinute {
+		return false, fmt.Errorf("FreezeWindow %s: StartTime and EndTime must differ", this.Name)
+	}
+	startsOn := func(weekday time.Weekday) bool {
+		return len(this.Days) == 0
+	}
+	if len(this.Days) > 0 {
+		days := make(map[time.Weekday]bool)
+		for _, day := range this.Days {
+			weekday, err := parseFreezeWindowDay(day)
+			if err != nil {
+				return false, err
+			}
+			days[weekday] = true
+		}
+		startsOn = func(weekday time.Weekday) bool {
+			return days[weekday]
+		}
+	}
+
+	t = t.In(location)
+	minute := t.Hour()*60 + t.Minute()
+	if startMinute < endMinute {
+		return startsOn(t.Weekday()) && minute >= startMinute && minute < endMinute, nil
+	}
+	// The window spans midnight: it is active either late on a day it starts, or early on the day that follows
+	if minute >= startMinute {
+		return startsOn(t.Weekday()), nil
+	}
+	if minute < endMinute {
+		return startsOn(t.AddDate(0, 0, -1).Weekday()), nil
+	}
+	return false, nil
+}
@@ -0,0 +1,105 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const sanitizedValue = "********"
+
+var sensitiveSettingSubstrings = []string{"Password", "Secret", "Token"}
+
+// isSensitiveSetting returns true for settings whose value must not be exposed, such as credentials
+func isSensitiveSetting(name string) bool {
+	for _, substring := range sensitiveSettingSubstrings {
+		if strings.Contains(name, substring) {
+			return true
+		}
+	}
+	return false
+}
+
+// settings returns the configuration as a map of setting name to value
+func (this *Configuration) settings() (map[string]interface{}, error) {
+	b, err := json.Marshal(this)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	settings := make(map[string]interface{})
+	if err := decoder.Decode(&settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Sanitized returns the configuration as a settings map, where non-empty credentials are masked.
+// This is the configuration as exposed by the API.
+func (this *Configuration) Sanitized() (map[string]interface{}, error) {
+	settings, err := this.settings()
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range settings {
+		if !isSensitiveSetting(name) {
+			continue
+		}
+		if s, ok := value.(string); ok && s == "" {
+			continue
+		}
+		settings[name] = sanitizedValue
+	}
+	return settings, nil
+}
+
+// diffSettings returns the sorted names of settings which differ between two settings maps
+func diffSettings(before, after map[string]interface{}) (changed []string) {
+	for name, value := range after {
+		if beforeValue, found := before[name]; !found || !reflect.DeepEqual(beforeValue, value) {
+			changed = append(changed, name)
+		}
+	}
+	for name := range before {
+		if _, found := after[name]; !found {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// ReloadAndDiff reloads the configuration, as Reload does, and returns the names of the
+// settings whose values changed. Only names are returned, so credentials are not exposed.
+func ReloadAndDiff(extraFileNames ...string) (changed []string, err error) {
+	before, err := Config.settings()
+	if err != nil {
+		return changed, err
+	}
+	Reload(extraFileNames...)
+	after, err := Config.settings()
+	if err != nil {
+		return changed, err
+	}
+	return diffSettings(before, after), nil
+}
@@ -851,4 +851,44 @@ var generateSQLBase = []string{
 	`
 		CREATE INDEX first_seen_idx_database_instance_stale_binlog_coordinates ON database_instance_stale_binlog_coordinates (first_seen)
 	`,
+	`
+		CREATE TABLE IF NOT EXISTS cluster_lock (
+			cluster_name varchar(128) CHARACTER SET ascii NOT NULL,
+			owner varchar(128) CHARACTER SET utf8 NOT NULL,
+			reason text CHARACTER SET utf8 NOT NULL,
+			begin_timestamp timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			end_timestamp timestamp NULL DEFAULT NULL,
+			PRIMARY KEY (cluster_name)
+		) ENGINE=InnoDB DEFAULT CHARSET=ascii
+	`,
+	`
+		CREATE TABLE IF NOT EXISTS recovery_filter (
+			filter_type varchar(32) CHARACTER SET ascii NOT NULL,
+			pattern varchar(255) CHARACTER SET ascii NOT NULL,
+			owner varchar(128) CHARACTER SET utf8 NOT NULL,
+			added_timestamp timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (filter_type, pattern)
+		) ENGINE=InnoDB DEFAULT CHARSET=ascii
+	`,
+	`
+		CREATE TABLE IF NOT EXISTS database_instance_metadata_override (
+			hostname varchar(128) CHARACTER SET ascii NOT NULL,
+			port smallint(5) unsigned NOT NULL,
+			field_name varchar(64) CHARACTER SET ascii NOT NULL,
+			field_value varchar(255) CHARACTER SET utf8 NOT NULL,
+			owner varchar(128) CHARACTER SET utf8 NOT NULL,
+			last_updated timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (hostname, port, field_name)
+		) ENGINE=InnoDB DEFAULT CHARSET=ascii
+	`,
+	`
+		CREATE TABLE IF NOT EXISTS cluster_recovery_disable (
+			cluster_name varchar(128) CHARACTER SET ascii NOT NULL,
+			owner varchar(128) CHARACTER SET utf8 NOT NULL,
+			reason text CHARACTER SET utf8 NOT NULL,
+			begin_timestamp timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			end_timestamp timestamp NULL DEFAULT NULL,
+			PRIMARY KEY (cluster_name)
+		) ENGINE=InnoDB DEFAULT CHARSET=ascii
+	`,
 }
@@ -617,4 +617,9 @@ var generateSQLPatches = []string{
 		database_instance
 			ADD COLUMN replication_group_primary_port smallint(5) unsigned NOT NULL DEFAULT 0 AFTER replication_group_primary_host
 	`,
+	`
+		ALTER TABLE
+		blocked_topology_recovery
+			ADD COLUMN first_blocked_timestamp timestamp NOT NULL DEFAULT '1971-01-01 00:00:00' AFTER analysis
+	`,
 }
@@ -26,6 +26,7 @@ push() operation never blocks while pop() blocks on an empty queue.
 package discovery
 
 import (
+	"sort"
 	"sync"
 	"time"
 
@@ -44,7 +45,7 @@ type QueueMetric struct {
 type Queue struct {
 	sync.Mutex
 
-	name         string
+	name         QueueName
 	done         chan struct{}
 	queue        chan inst.InstanceKey
 	queuedKeys   map[inst.InstanceKey]time.Time
@@ -55,11 +56,11 @@ type Queue struct {
 // DiscoveryQueue contains the discovery queue which can then be accessed via an API call for monitoring.
 // Currently this is accessed by ContinuousDiscovery() but also from http api calls.
 // I may need to protect this better?
-var discoveryQueue map[string](*Queue)
+var discoveryQueue map[QueueName](*Queue)
 var dcLock sync.Mutex
 
 func init() {
-	discoveryQueue = make(map[string](*Queue))
+	discoveryQueue = make(map[QueueName](*Queue))
 }
 
 // StopMonitoring stops monitoring all the queues
@@ -69,7 +70,8 @@ func StopMonitoring() {
 	}
 }
 
-func ReturnQueue(name string) *Queue {
+// ReturnQueue returns the queue by given name, or nil if it was not created
+func ReturnQueue(name QueueName) *Queue {
 	dcLock.Lock()
 	defer dcLock.Unlock()
 	if q, found := discoveryQueue[name]; found {
@@ -80,7 +82,7 @@ func ReturnQueue(name string) *Queue {
 
 // CreateOrReturnQueue allows for creation of a new discovery queue or
 // returning a pointer to an existing one given the name.
-func CreateOrReturnQueue(name string) *Queue {
+func CreateOrReturnQueue(name QueueName) *Queue {
 	dcLock.Lock()
 	defer dcLock.Unlock()
 	if q, found := discoveryQueue[name]; found {
@@ -100,6 +102,32 @@ func CreateOrReturnQueue(name string) *Queue {
 	return q
 }
 
+// QueueSummary describes the current state of a discovery queue
+type QueueSummary struct {
+	Name   QueueName
+	Queued int
+	Active int
+}
+
+// ListQueues summarizes the queues which were created, sorted by name
+func ListQueues() []QueueSummary {
+	dcLock.Lock()
+	queues := []*Queue{}
+	for _, q := range discoveryQueue {
+		queues = append(queues, q)
+	}
+	dcLock.Unlock()
+
+	summaries := []QueueSummary{}
+	for _, q := range queues {
+		q.Lock()
+		summaries = append(summaries, QueueSummary{Name: q.name, Queued: len(q.queuedKeys), Active: len(q.consumedKeys)})
+		q.Unlock()
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Name < summaries[j].Name })
+	return summaries
+}
+
 // monitoring queue sizes until we are told to stop
 func (q *Queue) startMonitoring() {
 	log.Debugf("Queue.startMonitoring(%s)", q.name)
@@ -0,0 +1,50 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package discovery
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QueueName names a discovery queue
+type QueueName string
+
+const (
+	// DefaultQueue is the queue of instances discovered in the ordinary course
+	DefaultQueue QueueName = "DEFAULT"
+	// DeadInstancesQueue is the queue of instances whose last check was invalid, used with DeadInstanceDiscoveryMaxConcurrency
+	DeadInstancesQueue QueueName = "DEADINSTANCES"
+	// PriorityQueue is the queue of instances whose discovery was requested ahead of ordinary discovery, used with PriorityDiscoveryMaxConcurrency
+	PriorityQueue QueueName = "PRIORITY"
+)
+
+// KnownQueueNames lists the names of all queues orchestrator may use
+var KnownQueueNames = []QueueName{DefaultQueue, DeadInstancesQueue, PriorityQueue}
+
+// ParseQueueName returns the known queue name matching given name, case insensitively, or an error listing
+// known queue names
+func ParseQueueName(name string) (QueueName, error) {
+	known := []string{}
+	for _, queueName := range KnownQueueNames {
+		if strings.EqualFold(strings.TrimSpace(name), string(queueName)) {
+			return queueName, nil
+		}
+		known = append(known, string(queueName))
+	}
+	return "", fmt.Errorf("Unknown discovery queue: %q. Known queues: %s", name, strings.Join(known, ", "))
+}
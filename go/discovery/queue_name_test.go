@@ -0,0 +1,51 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package discovery
+
+import (
+	"testing"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+func TestParseQueueName(t *testing.T) {
+	name, err := ParseQueueName("DEFAULT")
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(name, DefaultQueue)
+
+	name, err = ParseQueueName(" deadinstances ")
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(name, DeadInstancesQueue)
+
+	_, err = ParseQueueName("DEFUALT")
+	test.S(t).ExpectNotNil(err)
+	test.S(t).ExpectEquals(err.Error(), `Unknown discovery queue: "DEFUALT". Known queues: DEFAULT, DEADINSTANCES, PRIORITY`)
+}
+
+func TestListQueues(t *testing.T) {
+	CreateOrReturnQueue(DeadInstancesQueue)
+	CreateOrReturnQueue(DefaultQueue).Push(inst.InstanceKey{Hostname: "host1", Port: 3306})
+
+	queues := ListQueues()
+	test.S(t).ExpectEquals(len(queues), 2)
+	test.S(t).ExpectEquals(queues[0].Name, DeadInstancesQueue)
+	test.S(t).ExpectEquals(queues[0].Queued, 0)
+	test.S(t).ExpectEquals(queues[1].Name, DefaultQueue)
+	test.S(t).ExpectEquals(queues[1].Queued, 1)
+	test.S(t).ExpectEquals(queues[1].Active, 0)
+}
@@ -0,0 +1,159 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package discovery
+
+// Roll up raw discovery metrics into fixed windows, retained for longer than the raw metrics are.
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/montanaflynn/stats"
+
+	"github.com/openark/orchestrator/go/collection"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+// InstanceRollup holds the aggregated discovery latencies of a single instance within a window
+type InstanceRollup struct {
+	Hostname            string
+	Port                int
+	Discoveries         uint64
+	FailedDiscoveries   uint64
+	MeanTotalSeconds    float64
+	P95TotalSeconds     float64
+	MeanInstanceSeconds float64
+	P95InstanceSeconds  float64
+	MeanBackendSeconds  float64
+	P95BackendSeconds   float64
+}
+
+// RollupWindow holds per instance aggregates of discoveries made within [StartTime, EndTime)
+type RollupWindow struct {
+	StartTime time.Time
+	EndTime   time.Time
+	Instances []InstanceRollup
+}
+
+// MetricsAggregator periodically rolls up raw discovery metrics
+type MetricsAggregator interface {
+	// Aggregate rolls up all complete windows up to given time
+	Aggregate(now time.Time) error
+	// Windows returns retained windows, oldest first
+	Windows() []RollupWindow
+}
+
+// WindowedAggregator is a MetricsAggregator which rolls up a collection of discovery metrics
+// into fixed length windows, and retains a fixed number of windows in memory.
+type WindowedAggregator struct {
+	sync.Mutex
+	collection     *collection.Collection
+	windowDuration time.Duration
+	retainWindows  int
+	nextStartTime  time.Time
+	windows        []RollupWindow
+}
+
+// NewWindowedAggregator creates an aggregator on given collection. The first window begins at startTime.
+func NewWindowedAggregator(c *collection.Collection, windowDuration time.Duration, retainWindows int, startTime time.Time) *WindowedAggregator {
+	return &WindowedAggregator{
+		collection:     c,
+		windowDuration: windowDuration,
+		retainWindows:  retainWindows,
+		nextStartTime:  startTime.Truncate(windowDuration),
+		windows:        []RollupWindow{},
+	}
+}
+
+// Rollup aggregates given metrics per instance, over metrics taken between startTime (inclusive) and endTime (exclusive)
+func Rollup(metrics []collection.Metric, startTime, endTime time.Time) RollupWindow {
+	type instanceTimings struct {
+		rollup                   InstanceRollup
+		total, instance, backend stats.Float64Data
+	}
+	timingsMap := make(map[inst.InstanceKey]*instanceTimings)
+	for _, m := range metrics {
+		metric, ok := m.(*Metric)
+		if !ok || metric.Timestamp.Before(startTime) || !metric.Timestamp.Before(endTime) {
+			continue
+		}
+		timings, found := timingsMap[metric.InstanceKey]
+		if !found {
+			timings = &instanceTimings{rollup: InstanceRollup{Hostname: metric.InstanceKey.Hostname, Port: metric.InstanceKey.Port}}
+			timingsMap[metric.InstanceKey] = timings
+		}
+		if metric.Err != nil {
+			timings.rollup.FailedDiscoveries++
+			continue
+		}
+		timings.rollup.Discoveries++
+		timings.total = append(timings.total, metric.TotalLatency.Seconds())
+		timings.instance = append(timings.instance, metric.InstanceLatency.Seconds())
+		timings.backend = append(timings.backend, metric.BackendLatency.Seconds())
+	}
+	window := RollupWindow{StartTime: startTime, EndTime: endTime, Instances: []InstanceRollup{}}
+	for _, timings := range timingsMap {
+		instanceRollup := timings.rollup
+		instanceRollup.MeanTotalSeconds = mean(timings.total)
+		instanceRollup.P95TotalSeconds = percentile(timings.total, 95)
+		instanceRollup.MeanInstanceSeconds = mean(timings.instance)
+		instanceRollup.P95InstanceSeconds = percentile(timings.instance, 95)
+		instanceRollup.MeanBackendSeconds = mean(timings.backend)
+		instanceRollup.P95BackendSeconds = percentile(timings.backend, 95)
+		window.Instances = append(window.Instances, instanceRollup)
+	}
+	sort.Slice(window.Instances, func(i, j int) bool {
+		if window.Instances[i].Hostname == window.Instances[j].Hostname {
+			return window.Instances[i].Port < window.Instances[j].Port
+		}
+		return window.Instances[i].Hostname < window.Instances[j].Hostname
+	})
+	return window
+}
+
+// Aggregate rolls up all windows which have ended by given time. Windows older than the
+// collection's retention cannot be computed accurately; they are rolled up with whatever data remains.
+func (this *WindowedAggregator) Aggregate(now time.Time) error {
+	this.Lock()
+	defer this.Unlock()
+
+	for !this.nextStartTime.Add(this.windowDuration).After(now) {
+		startTime := this.nextStartTime
+		endTime := startTime.Add(this.windowDuration)
+		metrics, err := this.collection.Since(startTime)
+		if err != nil {
+			return err
+		}
+		this.windows = append(this.windows, Rollup(metrics, startTime, endTime))
+		if len(this.windows) > this.retainWindows {
+			this.windows = this.windows[len(this.windows)-this.retainWindows:]
+		}
+		this.nextStartTime = endTime
+	}
+	return nil
+}
+
+// Windows returns retained windows, oldest first
+func (this *WindowedAggregator) Windows() []RollupWindow {
+	this.Lock()
+	defer this.Unlock()
+
+	windows := make([]RollupWindow, len(this.windows))
+	copy(windows, this.windows)
+	return windows
+}
@@ -0,0 +1,188 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package discovery
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/collection"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+var (
+	rollupStartTime = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	rollupKey1      = inst.InstanceKey{Hostname: "host1", Port: 3306}
+	rollupKey2      = inst.InstanceKey{Hostname: "host2", Port: 3306}
+)
+
+func newRollupMetric(key inst.InstanceKey, timestamp time.Time, latencySeconds float64) *Metric {
+	latency := time.Duration(latencySeconds * float64(time.Second))
+	return &Metric{
+		Timestamp:       timestamp,
+		InstanceKey:     key,
+		TotalLatency:    latency,
+		InstanceLatency: latency / 2,
+		BackendLatency:  latency / 4,
+	}
+}
+
+func TestRollupWindowBoundaries(t *testing.T) {
+	endTime := rollupStartTime.Add(time.Minute)
+	tests := []struct {
+		name      string
+		timestamp time.Time
+		included  bool
+	}{
+		{"before start", rollupStartTime.Add(-time.Nanosecond), false},
+		{"at start", rollupStartTime, true},
+		{"within", rollupStartTime.Add(30 * time.Second), true},
+		{"just before end", endTime.Add(-time.Nanosecond), true},
+		{"at end", endTime, false},
+		{"after end", endTime.Add(time.Second), false},
+	}
+	for _, tt := range tests {
+		window := Rollup([]collection.Metric{newRollupMetric(rollupKey1, tt.timestamp, 1)}, rollupStartTime, endTime)
+		test.S(t).ExpectTrue(window.StartTime.Equal(rollupStartTime))
+		test.S(t).ExpectTrue(window.EndTime.Equal(endTime))
+		if tt.included {
+			test.S(t).ExpectEquals(len(window.Instances), 1)
+			test.S(t).ExpectEquals(window.Instances[0].Discoveries, uint64(1))
+		} else {
+			test.S(t).ExpectEquals(len(window.Instances), 0)
+		}
+	}
+}
+
+func TestRollupAggregation(t *testing.T) {
+	metrics := []collection.Metric{}
+	// host2 is listed first, yet is sorted last
+	for i := 1; i <= 10; i++ {
+		metrics = append(metrics, newRollupMetric(rollupKey2, rollupStartTime.Add(time.Duration(i)*time.Second), float64(i)))
+	}
+	for i := 20; i >= 1; i-- {
+		metrics = append(metrics, newRollupMetric(rollupKey1, rollupStartTime.Add(time.Duration(i)*time.Second), float64(i)))
+	}
+	failed := newRollupMetric(rollupKey1, rollupStartTime, 100)
+	failed.Err = errors.New("discovery failed")
+	metrics = append(metrics, failed)
+
+	tests := []struct {
+		key               inst.InstanceKey
+		discoveries       uint64
+		failedDiscoveries uint64
+		meanTotal         float64
+		p95Total          float64
+	}{
+		// failed discoveries are counted, but do not weigh in latencies
+		{rollupKey1, 20, 1, 10.5, 19},
+		// the 95th percentile of 10 values falls between the two largest
+		{rollupKey2, 10, 0, 5.5, 9.5},
+	}
+	window := Rollup(metrics, rollupStartTime, rollupStartTime.Add(time.Minute))
+	test.S(t).ExpectEquals(len(window.Instances), len(tests))
+	for i, tt := range tests {
+		instanceRollup := window.Instances[i]
+		test.S(t).ExpectEquals(instanceRollup.Hostname, tt.key.Hostname)
+		test.S(t).ExpectEquals(instanceRollup.Port, tt.key.Port)
+		test.S(t).ExpectEquals(instanceRollup.Discoveries, tt.discoveries)
+		test.S(t).ExpectEquals(instanceRollup.FailedDiscoveries, tt.failedDiscoveries)
+		test.S(t).ExpectEquals(instanceRollup.MeanTotalSeconds, tt.meanTotal)
+		test.S(t).ExpectEquals(instanceRollup.P95TotalSeconds, tt.p95Total)
+		test.S(t).ExpectEquals(instanceRollup.MeanInstanceSeconds, tt.meanTotal/2)
+		test.S(t).ExpectEquals(instanceRollup.P95InstanceSeconds, tt.p95Total/2)
+		test.S(t).ExpectEquals(instanceRollup.MeanBackendSeconds, tt.meanTotal/4)
+		test.S(t).ExpectEquals(instanceRollup.P95BackendSeconds, tt.p95Total/4)
+	}
+}
+
+func TestRollupOnlyFailedDiscoveries(t *testing.T) {
+	failed := newRollupMetric(rollupKey1, rollupStartTime, 1)
+	failed.Err = errors.New("discovery failed")
+	window := Rollup([]collection.Metric{failed}, rollupStartTime, rollupStartTime.Add(time.Minute))
+	test.S(t).ExpectEquals(len(window.Instances), 1)
+	test.S(t).ExpectEquals(window.Instances[0].Discoveries, uint64(0))
+	test.S(t).ExpectEquals(window.Instances[0].FailedDiscoveries, uint64(1))
+	test.S(t).ExpectEquals(window.Instances[0].MeanTotalSeconds, float64(0))
+	test.S(t).ExpectEquals(window.Instances[0].P95TotalSeconds, float64(0))
+}
+
+func TestWindowedAggregator(t *testing.T) {
+	windowDuration := 10 * time.Second
+	tests := []struct {
+		name            string
+		aggregatorStart time.Time
+		now             time.Duration // since rollupStartTime
+		retainWindows   int
+		windowStarts    []time.Duration // since rollupStartTime
+		discoveries     []uint64
+	}{
+		{"window not yet ended", rollupStartTime, 9 * time.Second, 10, []time.Duration{}, []uint64{}},
+		{"window ends at now", rollupStartTime, 10 * time.Second, 10, []time.Duration{0}, []uint64{2}},
+		{"partial second window", rollupStartTime, 25 * time.Second, 10, []time.Duration{0, 10 * time.Second}, []uint64{2, 1}},
+		{"start is truncated to window", rollupStartTime.Add(3 * time.Second), 20 * time.Second, 10, []time.Duration{0, 10 * time.Second}, []uint64{2, 1}},
+		{"oldest windows are dropped", rollupStartTime, 60 * time.Second, 3, []time.Duration{30 * time.Second, 40 * time.Second, 50 * time.Second}, []uint64{1, 0, 0}},
+	}
+	for _, tt := range tests {
+		c := &collection.Collection{}
+		// metrics at the start of the first window, just before its end, at the start of the second window, and before any window
+		c.Append(newRollupMetric(rollupKey1, rollupStartTime.Add(-time.Second), 1))
+		c.Append(newRollupMetric(rollupKey1, rollupStartTime, 1))
+		c.Append(newRollupMetric(rollupKey1, rollupStartTime.Add(windowDuration-time.Nanosecond), 1))
+		c.Append(newRollupMetric(rollupKey1, rollupStartTime.Add(windowDuration), 1))
+		c.Append(newRollupMetric(rollupKey1, rollupStartTime.Add(35*time.Second), 1))
+
+		aggregator := NewWindowedAggregator(c, windowDuration, tt.retainWindows, tt.aggregatorStart)
+		test.S(t).ExpectNil(aggregator.Aggregate(rollupStartTime.Add(tt.now)))
+		windows := aggregator.Windows()
+		if len(windows) != len(tt.windowStarts) {
+			t.Fatalf("%s: expected %d windows, got %d", tt.name, len(tt.windowStarts), len(windows))
+		}
+		for i, window := range windows {
+			test.S(t).ExpectTrue(window.StartTime.Equal(rollupStartTime.Add(tt.windowStarts[i])))
+			test.S(t).ExpectTrue(window.EndTime.Equal(window.StartTime.Add(windowDuration)))
+			discoveries := uint64(0)
+			for _, instanceRollup := range window.Instances {
+				discoveries += instanceRollup.Discoveries
+			}
+			test.S(t).ExpectEquals(discoveries, tt.discoveries[i])
+		}
+	}
+}
+
+func TestWindowedAggregatorIncremental(t *testing.T) {
+	c := &collection.Collection{}
+	aggregator := NewWindowedAggregator(c, 10*time.Second, 10, rollupStartTime)
+	test.S(t).ExpectNil(aggregator.Aggregate(rollupStartTime.Add(15 * time.Second)))
+	test.S(t).ExpectEquals(len(aggregator.Windows()), 1)
+
+	// windows already rolled up are not rolled up again
+	c.Append(newRollupMetric(rollupKey1, rollupStartTime.Add(16*time.Second), 1))
+	test.S(t).ExpectNil(aggregator.Aggregate(rollupStartTime.Add(15 * time.Second)))
+	test.S(t).ExpectEquals(len(aggregator.Windows()), 1)
+	test.S(t).ExpectNil(aggregator.Aggregate(rollupStartTime.Add(20 * time.Second)))
+	windows := aggregator.Windows()
+	test.S(t).ExpectEquals(len(windows), 2)
+	test.S(t).ExpectEquals(len(windows[0].Instances), 0)
+	test.S(t).ExpectEquals(windows[1].Instances[0].Discoveries, uint64(1))
+
+	// Windows returns a copy
+	windows[0] = RollupWindow{}
+	test.S(t).ExpectTrue(aggregator.Windows()[0].StartTime.Equal(rollupStartTime))
+}
@@ -43,6 +43,7 @@ import (
 	"github.com/openark/orchestrator/go/metrics/query"
 	"github.com/openark/orchestrator/go/process"
 	orcraft "github.com/openark/orchestrator/go/raft"
+	orcutil "github.com/openark/orchestrator/go/util"
 )
 
 // APIResponseCode is an OK/ERROR response code
@@ -53,6 +54,9 @@ const (
 	OK
 )
 
+// defaultVerifyTimeout is the timeout of post-relocation verification requested via "verify=true"
+const defaultVerifyTimeout = 30 * time.Second
+
 var apiSynonyms = map[string]string{
 	"relocate-slaves":            "relocate-replicas",
 	"regroup-slaves":             "regroup-replicas",
@@ -200,6 +204,67 @@ func (this *HttpAPI) getNoResolveInstanceKey(host string, port string) (inst.Ins
 	return this.getInstanceKeyInternal(host, port, false)
 }
 
+// getVerifyTimeout returns the timeout of post-relocation verification, as requested via the "verify" query
+// param: a duration such as "30s", or "true" for the default. It is zero when verification is not requested.
+func getVerifyTimeout(req *http.Request) (time.Duration, error) {
+	verify := strings.TrimSpace(req.URL.Query().Get("verify"))
+	switch verify {
+	case "", "false":
+		return 0, nil
+	case "true":
+		return defaultVerifyTimeout, nil
+	}
+	seconds, err := util.SimpleTimeToSeconds(verify)
+	if err != nil || seconds <= 0 {
+		return 0, fmt.Errorf("Invalid verify: %s. Expected a duration, e.g. 30s, or true", verify)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// respondRelocated responds with a relocated instance. When verification is requested, it first verifies the
+// instance replicates from expectedMasterKey (or from its new master, if nil), and responds with the
+// VerifiedInstance; an unverified instance is an error.
+func respondRelocated(r render.Render, instance *inst.Instance, expectedMasterKey *inst.InstanceKey, verifyTimeout time.Duration, message string) {
+	if verifyTimeout == 0 {
+		Respond(r, &APIResponse{Code: OK, Message: message, Details: instance})
+		return
+	}
+	if expectedMasterKey == nil {
+		expectedMasterKey = &instance.MasterKey
+	}
+	verified, err := inst.VerifyReplication(&instance.Key, expectedMasterKey, verifyTimeout)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%s, but: %+v", message, err), Details: verified})
+		return
+	}
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("%s; verified replicating", message), Details: verified})
+}
+
+// ValidatedResult is a result along with violations of invariants it is expected to satisfy
+type ValidatedResult struct {
+	Result              interface{}
+	InvariantViolations []inst.InvariantViolation
+}
+
+// isValidationRequested tells whether the request asks, via validate=true, for results to be validated
+func isValidationRequested(req *http.Request) bool {
+	return req.URL.Query().Get("validate") == "true"
+}
+
+// respondValidated responds with given result, which is wrapped in a ValidatedResult when validation is
+// requested. Violations are warnings: they do not fail the request.
+func respondValidated(r render.Render, req *http.Request, result interface{}, validate func() []inst.InvariantViolation) {
+	if !isValidationRequested(req) {
+		r.JSON(http.StatusOK, result)
+		return
+	}
+	violations := validate()
+	for _, violation := range violations {
+		log.Warningf("invariant violation: %+v %s: %s", violation.Key, violation.Invariant, violation.Description)
+	}
+	r.JSON(http.StatusOK, &ValidatedResult{Result: result, InvariantViolations: violations})
+}
+
 func getTag(params martini.Params, req *http.Request) (tag *inst.Tag, err error) {
 	tagString := req.URL.Query().Get("tag")
 	if tagString != "" {
@@ -248,7 +313,23 @@ func (this *HttpAPI) Instance(params martini.Params, r render.Render, req *http.
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot read instance: %+v", instanceKey)})
 		return
 	}
-	r.JSON(http.StatusOK, instance)
+	respondValidated(r, req, instance, func() []inst.InvariantViolation { return inst.CheckInstanceInvariants(instance) })
+}
+
+// InstanceDiagnosis classifies an instance's replication errors, and suggests remediations to its problems
+func (this *HttpAPI) InstanceDiagnosis(params martini.Params, r render.Render, req *http.Request) {
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	instance, found, err := inst.ReadInstance(&instanceKey)
+	if (!found) || (err != nil) {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot read instance: %+v", instanceKey)})
+		return
+	}
+
+	RespondReport(r, req, inst.DiagnoseInstance(instance), nil)
 }
 
 // AsyncDiscover issues an asynchronous read on an instance. This is
@@ -269,6 +350,100 @@ func (this *HttpAPI) AsyncDiscover(params martini.Params, r render.Render, req *
 	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Asynchronous discovery initiated for Instance: %+v", instanceKey)})
 }
 
+// getDiscoveryPriority returns the discovery priority requested by the "priority" query param, high by default
+func getDiscoveryPriority(req *http.Request) (logic.DiscoveryPriority, error) {
+	return logic.ParseDiscoveryPriority(req.URL.Query().Get("priority"))
+}
+
+// respondPriorityDiscoveries responds with the results of prioritized discovery requests, noting those which failed
+func respondPriorityDiscoveries(r render.Render, results []logic.PriorityDiscoveryResult) {
+	failed := 0
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+		}
+	}
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Prioritized discovery requested for %d instances; %d failed", len(results), failed), Details: results})
+}
+
+// DiscoverWithPriority requests the discovery of an instance ahead of ordinary discovery. The priority param
+// is one of normal, high (default) or immediate.
+func (this *HttpAPI) DiscoverWithPriority(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	priority, err := getDiscoveryPriority(req)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	result := logic.DiscoverWithPriority(req.Context(), instanceKey, priority)
+	if result.Error != "" {
+		Respond(r, &APIResponse{Code: ERROR, Message: result.Error, Details: result})
+		return
+	}
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Prioritized discovery requested for %+v", instanceKey), Details: result})
+}
+
+// DiscoverClusterWithPriority requests the discovery of all instances of a cluster ahead of ordinary discovery
+func (this *HttpAPI) DiscoverClusterWithPriority(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	clusterName, err := figureClusterName(getClusterHint(params))
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+	priority, err := getDiscoveryPriority(req)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	results, err := logic.DiscoverClusterWithPriority(req.Context(), clusterName, priority)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+	respondPriorityDiscoveries(r, results)
+}
+
+// DiscoverInstancesWithPriority requests the discovery of instances ahead of ordinary discovery. Instances are
+// POSTed as a JSON array of "host:port" strings.
+func (this *HttpAPI) DiscoverInstancesWithPriority(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	hostPorts := []string{}
+	if err := json.NewDecoder(req.Body).Decode(&hostPorts); err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot parse instances: expecting a JSON array of host:port strings: %+v", err)})
+		return
+	}
+	instanceKeys := []inst.InstanceKey{}
+	for _, hostPort := range hostPorts {
+		instanceKey, err := inst.ParseResolveInstanceKey(hostPort)
+		if err != nil {
+			Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot parse instance %q: %+v", hostPort, err)})
+			return
+		}
+		instanceKeys = append(instanceKeys, *instanceKey)
+	}
+	priority, err := getDiscoveryPriority(req)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	respondPriorityDiscoveries(r, logic.DiscoverInstancesWithPriority(req.Context(), instanceKeys, priority))
+}
+
 // Discover issues a synchronous read on an instance
 func (this *HttpAPI) Discover(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
@@ -477,307 +652,318 @@ func (this *HttpAPI) Maintenance(params martini.Params, r render.Render, req *ht
 	r.JSON(http.StatusOK, maintenanceList)
 }
 
-// BeginDowntime sets a downtime flag with default duration
-func (this *HttpAPI) BeginDowntime(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
-		return
-	}
-	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
-
-	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
-		return
-	}
-
-	var durationSeconds int = 0
-	if params["duration"] != "" {
-		durationSeconds, err = util.SimpleTimeToSeconds(params["duration"])
-		if durationSeconds < 0 {
-			err = fmt.Errorf("Duration value must be non-negative. Given value: %d", durationSeconds)
-		}
-		if err != nil {
-			Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
-			return
-		}
-	}
-	duration := time.Duration(durationSeconds) * time.Second
-	downtime := inst.NewDowntime(&instanceKey, params["owner"], params["reason"], duration)
-	if orcraft.IsRaftEnabled() {
-		_, err = orcraft.PublishCommand("begin-downtime", downtime)
-	} else {
-		err = inst.BeginDowntime(downtime)
-	}
+// MaintenanceByOwner lists active maintenance entries owned by given owner, or by any identity of given system
+func (this *HttpAPI) MaintenanceByOwner(params martini.Params, r render.Render, req *http.Request) {
+	maintenanceList, err := inst.ReadMaintenanceByOwner(strings.TrimSpace(params["owner"]))
 
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: err.Error(), Details: instanceKey})
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Downtime begun: %+v", instanceKey), Details: instanceKey})
+	r.JSON(http.StatusOK, maintenanceList)
 }
 
-// EndDowntime terminates downtime (removes downtime flag) for an instance
-func (this *HttpAPI) EndDowntime(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// TakeOverMaintenance transfers the active maintenance on given instance to a new owner
+func (this *HttpAPI) TakeOverMaintenance(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
-
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	if orcraft.IsRaftEnabled() {
-		_, err = orcraft.PublishCommand("end-downtime", instanceKey)
-	} else {
-		_, err = inst.EndDowntime(&instanceKey)
-	}
+	owner := strings.TrimSpace(params["owner"])
+	previousOwner, err := inst.TakeOverMaintenance(&instanceKey, owner)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Downtime ended: %+v", instanceKey), Details: instanceKey})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Maintenance on %+v taken over from %s by %s", instanceKey, previousOwner, owner), Details: instanceKey})
 }
 
-// MoveUp attempts to move an instance up the topology
-func (this *HttpAPI) MoveUp(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
-		return
-	}
-	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
-
+// ExpiringMaintenance lists active maintenance entries which are due to expire within given duration
+func (this *HttpAPI) ExpiringMaintenance(params martini.Params, r render.Render, req *http.Request) {
+	seconds, err := util.SimpleTimeToSeconds(params["duration"])
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	instance, err := inst.MoveUp(&instanceKey)
+	maintenanceList, err := inst.ReadMaintenanceExpiringWithin(seconds)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Instance %+v moved up", instanceKey), Details: instance})
+	r.JSON(http.StatusOK, maintenanceList)
 }
 
-// MoveUpReplicas attempts to move up all replicas of an instance
-func (this *HttpAPI) MoveUpReplicas(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// AcquireClusterLock takes (or extends) an advisory lock on a cluster on behalf of given owner
+func (this *HttpAPI) AcquireClusterLock(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+	clusterName, err := figureClusterName(getClusterHint(params))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	replicas, newMaster, err, errs := inst.MoveUpReplicas(&instanceKey, req.URL.Query().Get("pattern"))
+	var durationSeconds int = 0
+	if params["duration"] != "" {
+		durationSeconds, err = util.SimpleTimeToSeconds(params["duration"])
+		if durationSeconds < 0 {
+			err = fmt.Errorf("Duration value must be non-negative. Given value: %d", durationSeconds)
+		}
+		if err != nil {
+			Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+			return
+		}
+	}
+	lock, err := logic.AcquireClusterLock(&inst.ClusterLockRequest{
+		ClusterName:     clusterName,
+		Owner:           params["owner"],
+		Reason:          params["reason"],
+		DurationSeconds: uint(durationSeconds),
+	})
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error(), Details: lock})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Moved up %d replicas of %+v below %+v; %d errors: %+v", len(replicas), instanceKey, newMaster.Key, len(errs), errs), Details: replicas})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Cluster lock acquired: %+v", clusterName), Details: lock})
 }
 
-// Repoint positiones a replica under another (or same) master with exact same coordinates.
-// Useful for binlog servers
-func (this *HttpAPI) Repoint(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// ReleaseClusterLock releases a lock on a cluster, if held by given owner
+func (this *HttpAPI) ReleaseClusterLock(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+	clusterName, err := figureClusterName(getClusterHint(params))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	belowKey, err := this.getInstanceKey(params["belowHost"], params["belowPort"])
+	released, err := logic.ReleaseClusterLock(clusterName, params["owner"])
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	instance, err := inst.Repoint(&instanceKey, &belowKey, inst.GTIDHintNeutral)
-	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+	if !released {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cluster %s is not locked by %s", clusterName, params["owner"])})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Instance %+v repointed below %+v", instanceKey, belowKey), Details: instance})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Cluster lock released: %+v", clusterName), Details: clusterName})
 }
 
-// MoveUpReplicas attempts to move up all replicas of an instance
-func (this *HttpAPI) RepointReplicas(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+// ClusterLock returns the lock currently held on a cluster, or null when the cluster is not locked
+func (this *HttpAPI) ClusterLock(params martini.Params, r render.Render, req *http.Request) {
+	clusterName, err := figureClusterName(getClusterHint(params))
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+	lock, err := inst.ReadClusterLock(clusterName)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	replicas, err, _ := inst.RepointReplicas(&instanceKey, req.URL.Query().Get("pattern"))
+	r.JSON(http.StatusOK, lock)
+}
+
+// ClusterLocks provides list of currently held cluster locks
+func (this *HttpAPI) ClusterLocks(params martini.Params, r render.Render, req *http.Request) {
+	locks, err := inst.ReadClusterLocks()
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Repointed %d replicas of %+v", len(replicas), instanceKey), Details: replicas})
+	r.JSON(http.StatusOK, locks)
 }
 
-// MakeCoMaster attempts to make an instance co-master with its own master
-func (this *HttpAPI) MakeCoMaster(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
-		return
+// clusterFenceTTL returns the TTL given to a cluster fence request, or zero for the default
+func clusterFenceTTL(params martini.Params) (time.Duration, error) {
+	if params["duration"] == "" {
+		return 0, nil
 	}
-	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+	ttlSeconds, err := util.SimpleTimeToSeconds(params["duration"])
+	if ttlSeconds < 0 {
+		err = fmt.Errorf("Duration value must be non-negative. Given value: %d", ttlSeconds)
+	}
+	return time.Duration(ttlSeconds) * time.Second, err
+}
 
+// resumeClusterFence returns the fence a renew or release request refers to
+func resumeClusterFence(params martini.Params) (*logic.ClusterFence, error) {
+	clusterName, err := figureClusterName(getClusterHint(params))
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
-		return
+		return nil, err
 	}
-	instance, err := inst.MakeCoMaster(&instanceKey)
+	token, err := strconv.ParseInt(params["token"], 10, 64)
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
-		return
+		return nil, fmt.Errorf("Invalid fence token: %s", params["token"])
 	}
-
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Instance made co-master: %+v", instance.Key), Details: instance})
+	ttl, err := clusterFenceTTL(params)
+	if err != nil {
+		return nil, err
+	}
+	return logic.ResumeClusterFence(clusterName, params["owner"], token, ttl)
 }
 
-// ResetReplication makes a replica forget about its master, effectively breaking the replication
-func (this *HttpAPI) ResetReplication(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// AcquireClusterFence places a fence on a cluster on behalf of given owner: a maintenance entry on the cluster's
+// master. It does not wait for a fence held by another owner.
+func (this *HttpAPI) AcquireClusterFence(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
-
+	clusterName, err := figureClusterName(getClusterHint(params))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	instance, err := inst.ResetReplicationOperation(&instanceKey)
+	ttl, err := clusterFenceTTL(params)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	fence, err := logic.TryAcquireClusterFence(clusterName, params["owner"], ttl)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Replica reset on %+v", instance.Key), Details: instance})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Cluster fence acquired: %+v", clusterName), Details: fence})
 }
 
-// DetachReplicaMasterHost detaches a replica from its master by setting an invalid
-// (yet revertible) host name
-func (this *HttpAPI) DetachReplicaMasterHost(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// RenewClusterFence extends a cluster fence by its TTL, validating it is still held by given owner
+func (this *HttpAPI) RenewClusterFence(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
-
+	fence, err := resumeClusterFence(params)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	instance, err := inst.DetachReplicaMasterHost(&instanceKey)
-	if err != nil {
+	if err := fence.Renew(req.Context()); err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Replica detached: %+v", instance.Key), Details: instance})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Cluster fence renewed: %+v", fence.ClusterName), Details: fence})
 }
 
-// ReattachReplicaMasterHost reverts a detachReplicaMasterHost command
-// by resoting the original master hostname in CHANGE MASTER TO
-func (this *HttpAPI) ReattachReplicaMasterHost(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// ReleaseClusterFence releases a cluster fence, if held by given owner
+func (this *HttpAPI) ReleaseClusterFence(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
-
+	fence, err := resumeClusterFence(params)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	instance, err := inst.ReattachReplicaMasterHost(&instanceKey)
-	if err != nil {
+	if err := fence.Close(); err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Replica reattached: %+v", instance.Key), Details: instance})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Cluster fence released: %+v", fence.ClusterName), Details: fence.ClusterName})
 }
 
-// EnableGTID attempts to enable GTID on a replica
-func (this *HttpAPI) EnableGTID(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
-		return
-	}
-	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
-
+// ClusterFence returns the maintenance entry fencing a cluster, or null when the cluster is not fenced
+func (this *HttpAPI) ClusterFence(params martini.Params, r render.Render, req *http.Request) {
+	clusterName, err := figureClusterName(getClusterHint(params))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	instance, err := inst.EnableGTID(&instanceKey)
+	fence, err := logic.ReadClusterFence(clusterName)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Enabled GTID on %+v", instance.Key), Details: instance})
+	r.JSON(http.StatusOK, fence)
 }
 
-// DisableGTID attempts to disable GTID on a replica, and revert to binlog file:pos
-func (this *HttpAPI) DisableGTID(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// BeginDowntime sets a downtime flag with default duration
+func (this *HttpAPI) BeginDowntime(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 
-	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
-		return
-	}
-	instance, err := inst.DisableGTID(&instanceKey)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Disabled GTID on %+v", instance.Key), Details: instance})
-}
+	var durationSeconds int = 0
+	if params["duration"] != "" {
+		durationSeconds, err = util.SimpleTimeToSeconds(params["duration"])
+		if durationSeconds < 0 {
+			err = fmt.Errorf("Duration value must be non-negative. Given value: %d", durationSeconds)
+		}
+		if err != nil {
+			Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+			return
+		}
+	}
+	duration := time.Duration(durationSeconds) * time.Second
+	downtime := inst.NewDowntime(&instanceKey, params["owner"], params["reason"], duration)
+	if orcraft.IsRaftEnabled() {
+		_, err = orcraft.PublishCommand("begin-downtime", downtime)
+	} else {
+		err = inst.BeginDowntime(downtime)
+	}
 
-// LocateErrantGTID identifies the binlog positions for errant GTIDs on an instance
-func (this *HttpAPI) LocateErrantGTID(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error(), Details: instanceKey})
+		return
+	}
+
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Downtime begun: %+v", instanceKey), Details: instanceKey})
+}
+
+// EndDowntime terminates downtime (removes downtime flag) for an instance
+func (this *HttpAPI) EndDowntime(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	errantBinlogs, err := inst.LocateErrantGTID(&instanceKey)
+	if orcraft.IsRaftEnabled() {
+		_, err = orcraft.PublishCommand("end-downtime", instanceKey)
+	} else {
+		_, err = inst.EndDowntime(&instanceKey)
+	}
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("located errant GTID"), Details: errantBinlogs})
+
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Downtime ended: %+v", instanceKey), Details: instanceKey})
 }
 
-// ErrantGTIDResetMaster removes errant transactions on a server by way of RESET MASTER
-func (this *HttpAPI) ErrantGTIDResetMaster(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// ExtendDowntime pushes forward the end time of an active downtime, retaining its owner and reason
+func (this *HttpAPI) ExtendDowntime(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
@@ -788,64 +974,82 @@ func (this *HttpAPI) ErrantGTIDResetMaster(params martini.Params, r render.Rende
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	instance, err := inst.ErrantGTIDResetMaster(&instanceKey)
+	durationSeconds, err := util.SimpleTimeToSeconds(params["duration"])
+	if err == nil && durationSeconds <= 0 {
+		err = fmt.Errorf("Duration value must be positive. Given value: %d", durationSeconds)
+	}
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Removed errant GTID on %+v and issued a RESET MASTER", instance.Key), Details: instance})
-}
-
-// ErrantGTIDInjectEmpty removes errant transactions by injecting and empty transaction on the cluster's master
-func (this *HttpAPI) ErrantGTIDInjectEmpty(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+	downtime := &inst.Downtime{Key: &instanceKey, Duration: time.Duration(durationSeconds) * time.Second}
+	wasDowntimed := false
+	if orcraft.IsRaftEnabled() {
+		// The outcome of a published command is not returned; check beforehand, as the CLI does after the fact
+		var active *inst.Downtime
+		if active, err = inst.ReadActiveDowntime(&instanceKey); err == nil && active != nil {
+			wasDowntimed = true
+			_, err = orcraft.PublishCommand("extend-downtime", downtime)
+		}
+	} else {
+		wasDowntimed, err = inst.ExtendDowntime(downtime)
+	}
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	if !wasDowntimed {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v is not downtimed", instanceKey), Details: instanceKey})
 		return
 	}
-	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Downtime extended: %+v", instanceKey), Details: instanceKey})
+}
+
+// ExpiringDowntime lists active downtimes which are due to expire within given duration
+func (this *HttpAPI) ExpiringDowntime(params martini.Params, r render.Render, req *http.Request) {
+	seconds, err := util.SimpleTimeToSeconds(params["duration"])
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	instance, clusterMaster, countInjectedTransactions, err := inst.ErrantGTIDInjectEmpty(&instanceKey)
+	downtimes, err := inst.ReadDowntimeExpiringWithin(seconds)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Have injected %+v transactions on cluster master %+v", countInjectedTransactions, clusterMaster.Key), Details: instance})
+	r.JSON(http.StatusOK, downtimes)
 }
 
-// MoveBelow attempts to move an instance below its supposed sibling
-func (this *HttpAPI) MoveBelow(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// MoveUp attempts to move an instance up the topology
+func (this *HttpAPI) MoveUp(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+	verifyTimeout, err := getVerifyTimeout(req)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	siblingKey, err := this.getInstanceKey(params["siblingHost"], params["siblingPort"])
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	instance, err := inst.MoveBelow(&instanceKey, &siblingKey)
+	instance, err := inst.MoveUp(&instanceKey)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Instance %+v moved below %+v", instanceKey, siblingKey), Details: instance})
+	respondRelocated(r, instance, nil, verifyTimeout, fmt.Sprintf("Instance %+v moved up", instanceKey))
 }
 
-// MoveBelowGTID attempts to move an instance below another, via GTID
-func (this *HttpAPI) MoveBelowGTID(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// MoveUpReplicas attempts to move up all replicas of an instance
+func (this *HttpAPI) MoveUpReplicas(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
@@ -855,27 +1059,28 @@ func (this *HttpAPI) MoveBelowGTID(params martini.Params, r render.Render, req *
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	belowKey, err := this.getInstanceKey(params["belowHost"], params["belowPort"])
-	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
-		return
-	}
 
-	instance, err := inst.MoveBelowGTID(&instanceKey, &belowKey)
+	replicas, newMaster, err, errs := inst.MoveUpReplicas(&instanceKey, req.URL.Query().Get("pattern"))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Instance %+v moved below %+v via GTID", instanceKey, belowKey), Details: instance})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Moved up %d replicas of %+v below %+v; %d errors: %+v", len(replicas), instanceKey, newMaster.Key, len(errs), errs), Details: replicas})
 }
 
-// MoveReplicasGTID attempts to move an instance below another, via GTID
-func (this *HttpAPI) MoveReplicasGTID(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// Repoint positiones a replica under another (or same) master with exact same coordinates.
+// Useful for binlog servers
+func (this *HttpAPI) Repoint(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
+	verifyTimeout, err := getVerifyTimeout(req)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
@@ -887,17 +1092,17 @@ func (this *HttpAPI) MoveReplicasGTID(params martini.Params, r render.Render, re
 		return
 	}
 
-	movedReplicas, _, err, errs := inst.MoveReplicasGTID(&instanceKey, &belowKey, req.URL.Query().Get("pattern"))
+	instance, err := inst.Repoint(&instanceKey, &belowKey, inst.GTIDHintNeutral)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Moved %d replicas of %+v below %+v via GTID; %d errors: %+v", len(movedReplicas), instanceKey, belowKey, len(errs), errs), Details: belowKey})
+	respondRelocated(r, instance, &belowKey, verifyTimeout, fmt.Sprintf("Instance %+v repointed below %+v", instanceKey, belowKey))
 }
 
-// TakeSiblings
-func (this *HttpAPI) TakeSiblings(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// MoveUpReplicas attempts to move up all replicas of an instance
+func (this *HttpAPI) RepointReplicas(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
@@ -908,247 +1113,281 @@ func (this *HttpAPI) TakeSiblings(params martini.Params, r render.Render, req *h
 		return
 	}
 
-	instance, count, err := inst.TakeSiblings(&instanceKey)
+	replicas, err, _ := inst.RepointReplicas(&instanceKey, req.URL.Query().Get("pattern"))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Took %d siblings of %+v", count, instanceKey), Details: instance})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Repointed %d replicas of %+v", len(replicas), instanceKey), Details: replicas})
 }
 
-// TakeMaster
-func (this *HttpAPI) TakeMaster(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// MakeCoMaster attempts to make an instance co-master with its own master
+func (this *HttpAPI) MakeCoMaster(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	instance, err := inst.TakeMaster(&instanceKey, false)
+	instance, err := inst.MakeCoMaster(&instanceKey)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("%+v took its master", instanceKey), Details: instance})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Instance made co-master: %+v", instance.Key), Details: instance})
 }
 
-// RelocateBelow attempts to move an instance below another, orchestrator choosing the best (potentially multi-step)
-// relocation method
-func (this *HttpAPI) RelocateBelow(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// ResetReplication makes a replica forget about its master, effectively breaking the replication
+func (this *HttpAPI) ResetReplication(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	belowKey, err := this.getInstanceKey(params["belowHost"], params["belowPort"])
-	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
-		return
-	}
-
-	instance, err := inst.RelocateBelow(&instanceKey, &belowKey)
+	instance, err := inst.ResetReplicationOperation(&instanceKey)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Instance %+v relocated below %+v", instanceKey, belowKey), Details: instance})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Replica reset on %+v", instance.Key), Details: instance})
 }
 
-// Relocates attempts to smartly relocate replicas of a given instance below another
-func (this *HttpAPI) RelocateReplicas(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// DetachReplicaMasterHost detaches a replica from its master by setting an invalid
+// (yet revertible) host name
+func (this *HttpAPI) DetachReplicaMasterHost(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	belowKey, err := this.getInstanceKey(params["belowHost"], params["belowPort"])
-	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
-		return
-	}
-
-	replicas, _, err, errs := inst.RelocateReplicas(&instanceKey, &belowKey, req.URL.Query().Get("pattern"))
+	instance, err := inst.DetachReplicaMasterHost(&instanceKey)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Relocated %d replicas of %+v below %+v; %d errors: %+v", len(replicas), instanceKey, belowKey, len(errs), errs), Details: replicas})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Replica detached: %+v", instance.Key), Details: instance})
 }
 
-// MoveEquivalent attempts to move an instance below another, baseed on known equivalence master coordinates
-func (this *HttpAPI) MoveEquivalent(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// ReattachReplicaMasterHost reverts a detachReplicaMasterHost command
+// by resoting the original master hostname in CHANGE MASTER TO
+func (this *HttpAPI) ReattachReplicaMasterHost(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	belowKey, err := this.getInstanceKey(params["belowHost"], params["belowPort"])
+	instance, err := inst.ReattachReplicaMasterHost(&instanceKey)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	instance, err := inst.MoveEquivalent(&instanceKey, &belowKey)
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Replica reattached: %+v", instance.Key), Details: instance})
+}
+
+// EnableGTID attempts to enable GTID on a replica
+func (this *HttpAPI) EnableGTID(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	instance, err := inst.EnableGTID(&instanceKey)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Instance %+v relocated via equivalence coordinates below %+v", instanceKey, belowKey), Details: instance})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Enabled GTID on %+v", instance.Key), Details: instance})
 }
 
-// LastPseudoGTID attempts to find the last pseugo-gtid entry in an instance
-func (this *HttpAPI) LastPseudoGTID(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// DisableGTID attempts to disable GTID on a replica, and revert to binlog file:pos
+func (this *HttpAPI) DisableGTID(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	instance, found, err := inst.ReadInstance(&instanceKey)
+	instance, err := inst.DisableGTID(&instanceKey)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	if instance == nil || !found {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Instance not found: %+v", instanceKey)})
+
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Disabled GTID on %+v", instance.Key), Details: instance})
+}
+
+// LocateErrantGTID identifies the binlog positions for errant GTIDs on an instance
+func (this *HttpAPI) LocateErrantGTID(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	coordinates, text, err := inst.FindLastPseudoGTIDEntry(instance, instance.RelaylogCoordinates, nil, false, nil)
+	errantBinlogs, err := inst.LocateErrantGTID(&instanceKey)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("located errant GTID"), Details: errantBinlogs})
+}
 
+// ScanClusterErrantGTID identifies the binlog positions for errant GTIDs across the instances of a cluster
+func (this *HttpAPI) ScanClusterErrantGTID(params martini.Params, r render.Render, req *http.Request) {
+	clusterName, err := figureClusterName(getClusterHint(params))
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	scan, err := logic.ScanClusterErrantGTID(clusterName)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("%+v", *coordinates), Details: text})
+	RespondReport(r, req, scan, scan.Instances)
 }
 
-// MatchBelow attempts to move an instance below another via pseudo GTID matching of binlog entries
-func (this *HttpAPI) MatchBelow(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// ErrantGTIDResetMaster removes errant transactions on a server by way of RESET MASTER
+func (this *HttpAPI) ErrantGTIDResetMaster(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	belowKey, err := this.getInstanceKey(params["belowHost"], params["belowPort"])
-	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
-		return
-	}
-
-	instance, matchedCoordinates, err := inst.MatchBelow(&instanceKey, &belowKey, true)
+	instance, err := inst.ErrantGTIDResetMaster(&instanceKey)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Instance %+v matched below %+v at %+v", instanceKey, belowKey, *matchedCoordinates), Details: instance})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Removed errant GTID on %+v and issued a RESET MASTER", instance.Key), Details: instance})
 }
 
-// MatchBelow attempts to move an instance below another via pseudo GTID matching of binlog entries
-func (this *HttpAPI) MatchUp(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// ErrantGTIDInjectEmpty removes errant transactions by injecting and empty transaction on the cluster's master
+func (this *HttpAPI) ErrantGTIDInjectEmpty(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	instance, matchedCoordinates, err := inst.MatchUp(&instanceKey, true)
+	instance, clusterMaster, countInjectedTransactions, err := inst.ErrantGTIDInjectEmpty(&instanceKey)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Instance %+v matched up at %+v", instanceKey, *matchedCoordinates), Details: instance})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Have injected %+v transactions on cluster master %+v", countInjectedTransactions, clusterMaster.Key), Details: instance})
 }
 
-// MultiMatchReplicas attempts to match all replicas of a given instance below another, efficiently
-func (this *HttpAPI) MultiMatchReplicas(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// MoveBelow attempts to move an instance below its supposed sibling
+func (this *HttpAPI) MoveBelow(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
+	verifyTimeout, err := getVerifyTimeout(req)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	belowKey, err := this.getInstanceKey(params["belowHost"], params["belowPort"])
+	siblingKey, err := this.getInstanceKey(params["siblingHost"], params["siblingPort"])
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	replicas, newMaster, err, errs := inst.MultiMatchReplicas(&instanceKey, &belowKey, req.URL.Query().Get("pattern"))
+	instance, err := inst.MoveBelow(&instanceKey, &siblingKey)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Matched %d replicas of %+v below %+v; %d errors: %+v", len(replicas), instanceKey, newMaster.Key, len(errs), errs), Details: newMaster.Key})
+	respondRelocated(r, instance, &siblingKey, verifyTimeout, fmt.Sprintf("Instance %+v moved below %+v", instanceKey, siblingKey))
 }
 
-// MatchUpReplicas attempts to match up all replicas of an instance
-func (this *HttpAPI) MatchUpReplicas(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// MoveBelowGTID attempts to move an instance below another, via GTID
+func (this *HttpAPI) MoveBelowGTID(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
+	verifyTimeout, err := getVerifyTimeout(req)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	replicas, newMaster, err, errs := inst.MatchUpReplicas(&instanceKey, req.URL.Query().Get("pattern"))
+	belowKey, err := this.getInstanceKey(params["belowHost"], params["belowPort"])
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Matched up %d replicas of %+v below %+v; %d errors: %+v", len(replicas), instanceKey, newMaster.Key, len(errs), errs), Details: newMaster.Key})
+	instance, err := inst.MoveBelowGTID(&instanceKey, &belowKey)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+
+	respondRelocated(r, instance, &belowKey, verifyTimeout, fmt.Sprintf("Instance %+v moved below %+v via GTID", instanceKey, belowKey))
 }
 
-// RegroupReplicas attempts to pick a replica of a given instance and make it take its siblings, using any
-// method possible (GTID, Pseudo-GTID, binlog servers)
-func (this *HttpAPI) RegroupReplicas(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// MoveToCluster moves an instance below a master of another cluster, rolling back should replication fail
+func (this *HttpAPI) MoveToCluster(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
@@ -1158,21 +1397,23 @@ func (this *HttpAPI) RegroupReplicas(params martini.Params, r render.Render, req
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
+	belowKey, err := this.getInstanceKey(params["belowHost"], params["belowPort"])
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
 
-	lostReplicas, equalReplicas, aheadReplicas, cannotReplicateReplicas, promotedReplica, err := inst.RegroupReplicas(&instanceKey, false, nil, nil)
-	lostReplicas = append(lostReplicas, cannotReplicateReplicas...)
+	instance, err := inst.MoveToCluster(&instanceKey, &belowKey)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("promoted replica: %s, lost: %d, trivial: %d, pseudo-gtid: %d",
-		promotedReplica.Key.DisplayString(), len(lostReplicas), len(equalReplicas), len(aheadReplicas)), Details: promotedReplica.Key})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Instance %+v moved to cluster %s below %+v", instanceKey, instance.ClusterName, belowKey), Details: instance})
 }
 
-// RegroupReplicas attempts to pick a replica of a given instance and make it take its siblings, efficiently,
-// using pseudo-gtid if necessary
-func (this *HttpAPI) RegroupReplicasPseudoGTID(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// MoveReplicasGTID attempts to move an instance below another, via GTID
+func (this *HttpAPI) MoveReplicasGTID(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
@@ -1182,21 +1423,23 @@ func (this *HttpAPI) RegroupReplicasPseudoGTID(params martini.Params, r render.R
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
+	belowKey, err := this.getInstanceKey(params["belowHost"], params["belowPort"])
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
 
-	lostReplicas, equalReplicas, aheadReplicas, cannotReplicateReplicas, promotedReplica, err := inst.RegroupReplicasPseudoGTID(&instanceKey, false, nil, nil, nil)
-	lostReplicas = append(lostReplicas, cannotReplicateReplicas...)
-
+	movedReplicas, _, err, errs := inst.MoveReplicasGTID(&instanceKey, &belowKey, req.URL.Query().Get("pattern"))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("promoted replica: %s, lost: %d, trivial: %d, pseudo-gtid: %d",
-		promotedReplica.Key.DisplayString(), len(lostReplicas), len(equalReplicas), len(aheadReplicas)), Details: promotedReplica.Key})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Moved %d replicas of %+v below %+v via GTID; %d errors: %+v", len(movedReplicas), instanceKey, belowKey, len(errs), errs), Details: belowKey})
 }
 
-// RegroupReplicasGTID attempts to pick a replica of a given instance and make it take its siblings, efficiently, using GTID
-func (this *HttpAPI) RegroupReplicasGTID(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// TakeSiblings
+func (this *HttpAPI) TakeSiblings(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
@@ -1207,20 +1450,17 @@ func (this *HttpAPI) RegroupReplicasGTID(params martini.Params, r render.Render,
 		return
 	}
 
-	lostReplicas, movedReplicas, cannotReplicateReplicas, promotedReplica, err := inst.RegroupReplicasGTID(&instanceKey, false, true, nil, nil, nil)
-	lostReplicas = append(lostReplicas, cannotReplicateReplicas...)
-
+	instance, count, err := inst.TakeSiblings(&instanceKey)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("promoted replica: %s, lost: %d, moved: %d",
-		promotedReplica.Key.DisplayString(), len(lostReplicas), len(movedReplicas)), Details: promotedReplica.Key})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Took %d siblings of %+v", count, instanceKey), Details: instance})
 }
 
-// RegroupReplicasBinlogServers attempts to pick a replica of a given instance and make it take its siblings, efficiently, using GTID
-func (this *HttpAPI) RegroupReplicasBinlogServers(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// TakeMaster
+func (this *HttpAPI) TakeMaster(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
@@ -1231,41 +1471,49 @@ func (this *HttpAPI) RegroupReplicasBinlogServers(params martini.Params, r rende
 		return
 	}
 
-	_, promotedBinlogServer, err := inst.RegroupReplicasBinlogServers(&instanceKey, false)
-
+	instance, err := inst.TakeMaster(&instanceKey, false)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("promoted binlog server: %s",
-		promotedBinlogServer.Key.DisplayString()), Details: promotedBinlogServer.Key})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("%+v took its master", instanceKey), Details: instance})
 }
 
-// MakeMaster attempts to make the given instance a master, and match its siblings to be its replicas
-func (this *HttpAPI) MakeMaster(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// RelocateBelow attempts to move an instance below another, orchestrator choosing the best (potentially multi-step)
+// relocation method
+func (this *HttpAPI) RelocateBelow(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
+	verifyTimeout, err := getVerifyTimeout(req)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
+	belowKey, err := this.getInstanceKey(params["belowHost"], params["belowPort"])
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
 
-	instance, err := inst.MakeMaster(&instanceKey)
+	instance, err := inst.RelocateBelow(&instanceKey, &belowKey)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Instance %+v now made master", instanceKey), Details: instance})
+	respondRelocated(r, instance, &belowKey, verifyTimeout, fmt.Sprintf("Instance %+v relocated below %+v", instanceKey, belowKey))
 }
 
-// MakeLocalMaster attempts to make the given instance a local master: take over its master by
-// enslaving its siblings and replicating from its grandparent.
-func (this *HttpAPI) MakeLocalMaster(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// Relocates attempts to smartly relocate replicas of a given instance below another
+func (this *HttpAPI) RelocateReplicas(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
@@ -1275,202 +1523,233 @@ func (this *HttpAPI) MakeLocalMaster(params martini.Params, r render.Render, req
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
+	belowKey, err := this.getInstanceKey(params["belowHost"], params["belowPort"])
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
 
-	instance, err := inst.MakeLocalMaster(&instanceKey)
+	replicas, _, err, errs := inst.RelocateReplicas(&instanceKey, &belowKey, req.URL.Query().Get("pattern"))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Instance %+v now made local master", instanceKey), Details: instance})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Relocated %d replicas of %+v below %+v; %d errors: %+v", len(replicas), instanceKey, belowKey, len(errs), errs), Details: replicas})
 }
 
-// SkipQuery skips a single query on a failed replication instance
-func (this *HttpAPI) SkipQuery(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// BootstrapCluster sets up a new replication tree under given master, with replicas, candidates, alias & pool as
+// given by query params
+func (this *HttpAPI) BootstrapCluster(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
-
+	masterKey, err := this.getInstanceKey(params["host"], params["port"])
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	instance, err := inst.SkipQuery(&instanceKey)
+	replicaKeys := inst.NewInstanceKeyMap()
+	if err := replicaKeys.ReadCommaDelimitedList(strings.TrimSpace(req.URL.Query().Get("replicas"))); err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot parse replicas: %+v", err)})
+		return
+	}
+	options := logic.BootstrapClusterOptions{
+		ClusterAlias: strings.TrimSpace(req.URL.Query().Get("alias")),
+		Pool:         strings.TrimSpace(req.URL.Query().Get("pool")),
+	}
+	if candidates := strings.TrimSpace(req.URL.Query().Get("candidates")); candidates != "" {
+		candidateKeys := inst.NewInstanceKeyMap()
+		if err := candidateKeys.ReadCommaDelimitedList(candidates); err != nil {
+			Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot parse candidates: %+v", err)})
+			return
+		}
+		options.CandidateKeys = candidateKeys.GetInstanceKeys()
+	}
+
+	result, err := logic.BootstrapCluster(masterKey, replicaKeys.GetInstanceKeys(), options)
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error(), Details: result})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Query skipped on %+v", instance.Key), Details: instance})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Bootstrapped cluster %s with %d replicas", result.ClusterName, len(result.ReplicaKeys)), Details: result})
 }
 
-// StartReplication starts replication on given instance
-func (this *HttpAPI) StartReplication(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// MoveEquivalent attempts to move an instance below another, baseed on known equivalence master coordinates
+func (this *HttpAPI) MoveEquivalent(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
+	verifyTimeout, err := getVerifyTimeout(req)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
-
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	instance, err := inst.StartReplication(&instanceKey)
+	belowKey, err := this.getInstanceKey(params["belowHost"], params["belowPort"])
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Replica started: %+v", instance.Key), Details: instance})
+	instance, err := inst.MoveEquivalent(&instanceKey, &belowKey)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+
+	respondRelocated(r, instance, &belowKey, verifyTimeout, fmt.Sprintf("Instance %+v relocated via equivalence coordinates below %+v", instanceKey, belowKey))
 }
 
-// RestartReplication stops & starts replication on given instance
-func (this *HttpAPI) RestartReplication(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// LastPseudoGTID attempts to find the last pseugo-gtid entry in an instance
+func (this *HttpAPI) LastPseudoGTID(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
-
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	instance, err := inst.RestartReplication(&instanceKey)
+
+	instance, found, err := inst.ReadInstance(&instanceKey)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Replica restarted: %+v", instance.Key), Details: instance})
-}
-
-// StopReplication stops replication on given instance
-func (this *HttpAPI) StopReplication(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+	if instance == nil || !found {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Instance not found: %+v", instanceKey)})
 		return
 	}
-	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
-
+	coordinates, text, err := inst.FindLastPseudoGTIDEntry(instance, instance.RelaylogCoordinates, nil, false, nil)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	instance, err := inst.StopReplication(&instanceKey)
+
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Replica stopped: %+v", instance.Key), Details: instance})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("%+v", *coordinates), Details: text})
 }
 
-// StopReplicationNicely stops replication on given instance, such that sql thead is aligned with IO thread
-func (this *HttpAPI) StopReplicationNicely(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// MatchBelow attempts to move an instance below another via pseudo GTID matching of binlog entries
+func (this *HttpAPI) MatchBelow(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
+	verifyTimeout, err := getVerifyTimeout(req)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
-
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	instance, err := inst.StopReplicationNicely(&instanceKey, 0)
+	belowKey, err := this.getInstanceKey(params["belowHost"], params["belowPort"])
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Replica stopped nicely: %+v", instance.Key), Details: instance})
+	instance, matchedCoordinates, err := inst.MatchBelow(&instanceKey, &belowKey, true)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+
+	respondRelocated(r, instance, &belowKey, verifyTimeout, fmt.Sprintf("Instance %+v matched below %+v at %+v", instanceKey, belowKey, *matchedCoordinates))
 }
 
-// FlushBinaryLogs runs a single FLUSH BINARY LOGS
-func (this *HttpAPI) FlushBinaryLogs(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// MatchBelow attempts to move an instance below another via pseudo GTID matching of binlog entries
+func (this *HttpAPI) MatchUp(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
+	verifyTimeout, err := getVerifyTimeout(req)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
-
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	instance, err := inst.FlushBinaryLogs(&instanceKey, 1)
+
+	instance, matchedCoordinates, err := inst.MatchUp(&instanceKey, true)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Binary logs flushed on: %+v", instance.Key), Details: instance})
+	respondRelocated(r, instance, nil, verifyTimeout, fmt.Sprintf("Instance %+v matched up at %+v", instanceKey, *matchedCoordinates))
 }
 
-// PurgeBinaryLogs purges binary logs up to given binlog file
-func (this *HttpAPI) PurgeBinaryLogs(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// MultiMatchReplicas attempts to match all replicas of a given instance below another, efficiently
+func (this *HttpAPI) MultiMatchReplicas(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
-
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	logFile := params["logFile"]
-	if logFile == "" {
-		Respond(r, &APIResponse{Code: ERROR, Message: "purge-binary-logs: expected log file name or 'latest'"})
+	belowKey, err := this.getInstanceKey(params["belowHost"], params["belowPort"])
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	force := (req.URL.Query().Get("force") == "true") || (params["force"] == "true")
-	var instance *inst.Instance
-	if logFile == "latest" {
-		instance, err = inst.PurgeBinaryLogsToLatest(&instanceKey, force)
-	} else {
-		instance, err = inst.PurgeBinaryLogsTo(&instanceKey, logFile, force)
-	}
+
+	replicas, newMaster, err, errs := inst.MultiMatchReplicas(&instanceKey, &belowKey, req.URL.Query().Get("pattern"))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Binary logs flushed on: %+v", instance.Key), Details: instance})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Matched %d replicas of %+v below %+v; %d errors: %+v", len(replicas), instanceKey, newMaster.Key, len(errs), errs), Details: newMaster.Key})
 }
 
-// RestartReplicationStatements receives a query to execute that requires a replication restart to apply.
-// As an example, this may be `set global rpl_semi_sync_slave_enabled=1`. orchestrator will check
-// replication status on given host and will wrap with appropriate stop/start statements, if need be.
-func (this *HttpAPI) RestartReplicationStatements(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// MatchUpReplicas attempts to match up all replicas of an instance
+func (this *HttpAPI) MatchUpReplicas(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
-
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	query := req.URL.Query().Get("q")
-	statements, err := inst.GetReplicationRestartPreserveStatements(&instanceKey, query)
-
+	replicas, newMaster, err, errs := inst.MatchUpReplicas(&instanceKey, req.URL.Query().Get("pattern"))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("statements for: %+v", instanceKey), Details: statements})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Matched up %d replicas of %+v below %+v; %d errors: %+v", len(replicas), instanceKey, newMaster.Key, len(errs), errs), Details: newMaster.Key})
 }
 
-// MasterEquivalent provides (possibly empty) list of master coordinates equivalent to the given ones
-func (this *HttpAPI) MasterEquivalent(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// RegroupReplicas attempts to pick a replica of a given instance and make it take its siblings, using any
+// method possible (GTID, Pseudo-GTID, binlog servers)
+func (this *HttpAPI) RegroupReplicas(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
@@ -1480,126 +1759,135 @@ func (this *HttpAPI) MasterEquivalent(params martini.Params, r render.Render, re
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	coordinates, err := this.getBinlogCoordinates(params["logFile"], params["logPos"])
-	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
-		return
-	}
-	instanceCoordinates := &inst.InstanceBinlogCoordinates{Key: instanceKey, Coordinates: coordinates}
 
-	equivalentCoordinates, err := inst.GetEquivalentMasterCoordinates(instanceCoordinates)
+	lostReplicas, equalReplicas, aheadReplicas, cannotReplicateReplicas, promotedReplica, err := inst.RegroupReplicas(&instanceKey, false, nil, nil)
+	lostReplicas = append(lostReplicas, cannotReplicateReplicas...)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Found %+v equivalent coordinates", len(equivalentCoordinates)), Details: equivalentCoordinates})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("promoted replica: %s, lost: %d, trivial: %d, pseudo-gtid: %d",
+		promotedReplica.Key.DisplayString(), len(lostReplicas), len(equalReplicas), len(aheadReplicas)), Details: promotedReplica.Key})
 }
 
-// CanReplicateFrom attempts to move an instance below another via pseudo GTID matching of binlog entries
-func (this *HttpAPI) CanReplicateFrom(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
-	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
-		return
-	}
-	instance, found, err := inst.ReadInstance(&instanceKey)
-	if (!found) || (err != nil) {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot read instance: %+v", instanceKey)})
+// RegroupReplicas attempts to pick a replica of a given instance and make it take its siblings, efficiently,
+// using pseudo-gtid if necessary
+func (this *HttpAPI) RegroupReplicasPseudoGTID(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	belowKey, err := this.getInstanceKey(params["belowHost"], params["belowPort"])
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	belowInstance, found, err := inst.ReadInstance(&belowKey)
-	if (!found) || (err != nil) {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot read instance: %+v", belowKey)})
-		return
-	}
 
-	canReplicate, err := instance.CanReplicateFrom(belowInstance)
+	lostReplicas, equalReplicas, aheadReplicas, cannotReplicateReplicas, promotedReplica, err := inst.RegroupReplicasPseudoGTID(&instanceKey, false, nil, nil, nil)
+	lostReplicas = append(lostReplicas, cannotReplicateReplicas...)
+
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("%t", canReplicate), Details: belowKey})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("promoted replica: %s, lost: %d, trivial: %d, pseudo-gtid: %d",
+		promotedReplica.Key.DisplayString(), len(lostReplicas), len(equalReplicas), len(aheadReplicas)), Details: promotedReplica.Key})
 }
 
-// CanReplicateFromGTID attempts to move an instance below another via GTID.
-func (this *HttpAPI) CanReplicateFromGTID(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// RegroupReplicasGTID attempts to pick a replica of a given instance and make it take its siblings, efficiently, using GTID
+func (this *HttpAPI) RegroupReplicasGTID(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	instance, found, err := inst.ReadInstance(&instanceKey)
-	if (!found) || (err != nil) {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot read instance: %+v", instanceKey)})
-		return
-	}
-	belowKey, err := this.getInstanceKey(params["belowHost"], params["belowPort"])
+
+	lostReplicas, movedReplicas, cannotReplicateReplicas, promotedReplica, err := inst.RegroupReplicasGTID(&instanceKey, false, true, nil, nil, nil)
+	lostReplicas = append(lostReplicas, cannotReplicateReplicas...)
+
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	belowInstance, found, err := inst.ReadInstance(&belowKey)
-	if (!found) || (err != nil) {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot read instance: %+v", belowKey)})
+
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("promoted replica: %s, lost: %d, moved: %d",
+		promotedReplica.Key.DisplayString(), len(lostReplicas), len(movedReplicas)), Details: promotedReplica.Key})
+}
+
+// RegroupReplicasBinlogServers attempts to pick a replica of a given instance and make it take its siblings, efficiently, using GTID
+func (this *HttpAPI) RegroupReplicasBinlogServers(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	canReplicate, err := instance.CanReplicateFrom(belowInstance)
+	_, promotedBinlogServer, err := inst.RegroupReplicasBinlogServers(&instanceKey, false)
+
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	if !canReplicate {
-		Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("%t", canReplicate), Details: belowKey})
+
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("promoted binlog server: %s",
+		promotedBinlogServer.Key.DisplayString()), Details: promotedBinlogServer.Key})
+}
+
+// MakeMaster attempts to make the given instance a master, and match its siblings to be its replicas
+func (this *HttpAPI) MakeMaster(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	err = inst.CheckMoveViaGTID(instance, belowInstance)
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	canReplicate = (err == nil)
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("%t", canReplicate), Details: belowKey})
+	instance, err := inst.MakeMaster(&instanceKey)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Instance %+v now made master", instanceKey), Details: instance})
 }
 
-// setSemiSyncMaster
-func (this *HttpAPI) setSemiSyncMaster(params martini.Params, r render.Render, req *http.Request, user auth.User, enable bool) {
+// MakeLocalMaster attempts to make the given instance a local master: take over its master by
+// enslaving its siblings and replicating from its grandparent.
+func (this *HttpAPI) MakeLocalMaster(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
-
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	instance, err := inst.SetSemiSyncMaster(&instanceKey, enable)
+
+	instance, err := inst.MakeLocalMaster(&instanceKey)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("master semi-sync set to %t", enable), Details: instance})
-}
-
-func (this *HttpAPI) EnableSemiSyncMaster(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	this.setSemiSyncMaster(params, r, req, user, true)
-}
-func (this *HttpAPI) DisableSemiSyncMaster(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	this.setSemiSyncMaster(params, r, req, user, false)
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Instance %+v now made local master", instanceKey), Details: instance})
 }
 
-// setSemiSyncMaster
-func (this *HttpAPI) setSemiSyncReplica(params martini.Params, r render.Render, req *http.Request, user auth.User, enable bool) {
+// SkipQuery skips a single query on a failed replication instance
+func (this *HttpAPI) SkipQuery(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
@@ -1610,50 +1898,38 @@ func (this *HttpAPI) setSemiSyncReplica(params martini.Params, r render.Render,
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	instance, err := inst.SetSemiSyncReplica(&instanceKey, enable)
+	instance, err := inst.SkipQuery(&instanceKey)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("replica semi-sync set to %t", enable), Details: instance})
-}
-
-func (this *HttpAPI) EnableSemiSyncReplica(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	this.setSemiSyncReplica(params, r, req, user, true)
-}
-
-func (this *HttpAPI) DisableSemiSyncReplica(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	this.setSemiSyncReplica(params, r, req, user, false)
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Query skipped on %+v", instance.Key), Details: instance})
 }
 
-// DelayReplication delays replication on given instance with given seconds
-func (this *HttpAPI) DelayReplication(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// StartReplication starts replication on given instance
+func (this *HttpAPI) StartReplication(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	seconds, err := strconv.Atoi(params["seconds"])
-	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Invalid value provided for seconds"})
-		return
-	}
-	err = inst.DelayReplication(&instanceKey, seconds)
+	instance, err := inst.StartReplication(&instanceKey)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Replication delayed: %+v", instanceKey), Details: seconds})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Replica started: %+v", instance.Key), Details: instance})
 }
 
-// SetReadOnly sets the global read_only variable
-func (this *HttpAPI) SetReadOnly(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// RestartReplication stops & starts replication on given instance
+func (this *HttpAPI) RestartReplication(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
@@ -1664,17 +1940,17 @@ func (this *HttpAPI) SetReadOnly(params martini.Params, r render.Render, req *ht
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	instance, err := inst.SetReadOnly(&instanceKey, true)
+	instance, err := inst.RestartReplication(&instanceKey)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: "Server set as read-only", Details: instance})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Replica restarted: %+v", instance.Key), Details: instance})
 }
 
-// SetWriteable clear the global read_only variable
-func (this *HttpAPI) SetWriteable(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// StopReplication stops replication on given instance
+func (this *HttpAPI) StopReplication(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
@@ -1685,116 +1961,144 @@ func (this *HttpAPI) SetWriteable(params martini.Params, r render.Render, req *h
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	instance, err := inst.SetReadOnly(&instanceKey, false)
+	instance, err := inst.StopReplication(&instanceKey)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: "Server set as writeable", Details: instance})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Replica stopped: %+v", instance.Key), Details: instance})
 }
 
-// KillQuery kills a query running on a server
-func (this *HttpAPI) KillQuery(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// StopReplicationNicely stops replication on given instance, such that sql thead is aligned with IO thread
+func (this *HttpAPI) StopReplicationNicely(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
-	processId, err := strconv.ParseInt(params["process"], 10, 0)
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	instance, err := inst.StopReplicationNicely(&instanceKey, 0)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Replica stopped nicely: %+v", instance.Key), Details: instance})
+}
+
+// FlushBinaryLogs runs a single FLUSH BINARY LOGS
+func (this *HttpAPI) FlushBinaryLogs(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	instance, err := inst.KillQuery(&instanceKey, processId)
+	instance, err := inst.FlushBinaryLogs(&instanceKey, 1)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Query killed on : %+v", instance.Key), Details: instance})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Binary logs flushed on: %+v", instance.Key), Details: instance})
 }
 
-// AsciiTopology returns an ascii graph of cluster's instances
-func (this *HttpAPI) asciiTopology(params martini.Params, r render.Render, req *http.Request, tabulated bool, printTags bool) {
-	clusterName, err := figureClusterName(getClusterHint(params))
-	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+// PurgeBinaryLogs purges binary logs up to given binlog file
+func (this *HttpAPI) PurgeBinaryLogs(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 
-	asciiOutput, err := inst.ASCIITopology(clusterName, "", tabulated, printTags)
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	logFile := params["logFile"]
+	if logFile == "" {
+		Respond(r, &APIResponse{Code: ERROR, Message: "purge-binary-logs: expected log file name or 'latest'"})
+		return
+	}
+	force := (req.URL.Query().Get("force") == "true") || (params["force"] == "true")
+	var instance *inst.Instance
+	if logFile == "latest" {
+		instance, err = inst.PurgeBinaryLogsToLatest(&instanceKey, force)
+	} else {
+		instance, err = inst.PurgeBinaryLogsTo(&instanceKey, logFile, force)
+	}
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Topology for cluster %s", clusterName), Details: asciiOutput})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Binary logs flushed on: %+v", instance.Key), Details: instance})
 }
 
-// SnapshotTopologies triggers orchestrator to record a snapshot of host/master for all known hosts.
-func (this *HttpAPI) SnapshotTopologies(params martini.Params, r render.Render, req *http.Request) {
-	start := time.Now()
-	if err := inst.SnapshotTopologies(); err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err), Details: fmt.Sprintf("Took %v", time.Since(start))})
+// RestartReplicationStatements receives a query to execute that requires a replication restart to apply.
+// As an example, this may be `set global rpl_semi_sync_slave_enabled=1`. orchestrator will check
+// replication status on given host and will wrap with appropriate stop/start statements, if need be.
+func (this *HttpAPI) RestartReplicationStatements(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 
-	Respond(r, &APIResponse{Code: OK, Message: "Topology Snapshot completed", Details: fmt.Sprintf("Took %v", time.Since(start))})
-}
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
 
-// AsciiTopology returns an ascii graph of cluster's instances
-func (this *HttpAPI) AsciiTopology(params martini.Params, r render.Render, req *http.Request) {
-	this.asciiTopology(params, r, req, false, false)
-}
+	query := req.URL.Query().Get("q")
+	statements, err := inst.GetReplicationRestartPreserveStatements(&instanceKey, query)
 
-// AsciiTopology returns an ascii graph of cluster's instances
-func (this *HttpAPI) AsciiTopologyTabulated(params martini.Params, r render.Render, req *http.Request) {
-	this.asciiTopology(params, r, req, true, false)
-}
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
 
-// AsciiTopologyTags returns an ascii graph of cluster's instances and instance tags
-func (this *HttpAPI) AsciiTopologyTags(params martini.Params, r render.Render, req *http.Request) {
-	this.asciiTopology(params, r, req, false, true)
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("statements for: %+v", instanceKey), Details: statements})
 }
 
-// Cluster provides list of instances in given cluster
-func (this *HttpAPI) Cluster(params martini.Params, r render.Render, req *http.Request) {
-	clusterName, err := figureClusterName(getClusterHint(params))
+// MasterEquivalent provides (possibly empty) list of master coordinates equivalent to the given ones
+func (this *HttpAPI) MasterEquivalent(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	instances, err := inst.ReadClusterInstances(clusterName)
-
+	coordinates, err := this.getBinlogCoordinates(params["logFile"], params["logPos"])
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
+	instanceCoordinates := &inst.InstanceBinlogCoordinates{Key: instanceKey, Coordinates: coordinates}
 
-	r.JSON(http.StatusOK, instances)
-}
-
-// ClusterByAlias provides list of instances in given cluster
-func (this *HttpAPI) ClusterByAlias(params martini.Params, r render.Render, req *http.Request) {
-	clusterName, err := inst.GetClusterByAlias(params["clusterAlias"])
+	equivalentCoordinates, err := inst.GetEquivalentMasterCoordinates(instanceCoordinates)
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	params["clusterName"] = clusterName
-	this.Cluster(params, r, req)
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Found %+v equivalent coordinates", len(equivalentCoordinates)), Details: equivalentCoordinates})
 }
 
-// ClusterByInstance provides list of instances in cluster an instance belongs to
-func (this *HttpAPI) ClusterByInstance(params martini.Params, r render.Render, req *http.Request) {
+// CanReplicateFrom attempts to move an instance below another via pseudo GTID matching of binlog entries
+func (this *HttpAPI) CanReplicateFrom(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
@@ -1805,478 +2109,496 @@ func (this *HttpAPI) ClusterByInstance(params martini.Params, r render.Render, r
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot read instance: %+v", instanceKey)})
 		return
 	}
-
-	params["clusterName"] = instance.ClusterName
-	this.Cluster(params, r, req)
-}
-
-// ClusterInfo provides details of a given cluster
-func (this *HttpAPI) ClusterInfo(params martini.Params, r render.Render, req *http.Request) {
-	clusterName, err := figureClusterName(getClusterHint(params))
+	belowKey, err := this.getInstanceKey(params["belowHost"], params["belowPort"])
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	belowInstance, found, err := inst.ReadInstance(&belowKey)
+	if (!found) || (err != nil) {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot read instance: %+v", belowKey)})
 		return
 	}
-	clusterInfo, err := inst.ReadClusterInfo(clusterName)
 
+	canReplicate, err := instance.CanReplicateFrom(belowInstance)
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	r.JSON(http.StatusOK, clusterInfo)
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("%t", canReplicate), Details: belowKey})
 }
 
-// Cluster provides list of instances in given cluster
-func (this *HttpAPI) ClusterInfoByAlias(params martini.Params, r render.Render, req *http.Request) {
-	clusterName, err := inst.GetClusterByAlias(params["clusterAlias"])
+// RelocationAdvice advises on the method (GTID, Pseudo-GTID, binlog coordinates) by which an instance would be relocated below another
+func (this *HttpAPI) RelocationAdvice(params martini.Params, r render.Render, req *http.Request) {
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	params["clusterName"] = clusterName
-	this.ClusterInfo(params, r, req)
+	belowKey, err := this.getInstanceKey(params["belowHost"], params["belowPort"])
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	advice, err := inst.ReadRelocationMethodAdvice(&instanceKey, &belowKey)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	Respond(r, &APIResponse{Code: OK, Message: advice.Method, Details: advice})
 }
 
-// ClusterOSCReplicas returns heuristic list of OSC replicas
-func (this *HttpAPI) ClusterOSCReplicas(params martini.Params, r render.Render, req *http.Request) {
+// PseudoGTIDStatus checks whether Pseudo-GTID is active on a given cluster
+func (this *HttpAPI) PseudoGTIDStatus(params martini.Params, r render.Render, req *http.Request) {
 	clusterName, err := figureClusterName(getClusterHint(params))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-
-	instances, err := inst.GetClusterOSCReplicas(clusterName)
+	status, err := inst.ReadClusterPseudoGTIDStatus(clusterName)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-
-	r.JSON(http.StatusOK, instances)
+	r.JSON(http.StatusOK, status)
 }
 
-// SetClusterAlias will change an alias for a given clustername
-func (this *HttpAPI) SetClusterAliasManualOverride(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+// CanReplicateFromGTID attempts to move an instance below another via GTID.
+func (this *HttpAPI) CanReplicateFromGTID(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	clusterName := params["clusterName"]
-	alias := req.URL.Query().Get("alias")
-
-	var err error
-	if orcraft.IsRaftEnabled() {
-		_, err = orcraft.PublishCommand("set-cluster-alias-manual-override", []string{clusterName, alias})
-	} else {
-		err = inst.SetClusterAliasManualOverride(clusterName, alias)
+	instance, found, err := inst.ReadInstance(&instanceKey)
+	if (!found) || (err != nil) {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot read instance: %+v", instanceKey)})
+		return
 	}
-
+	belowKey, err := this.getInstanceKey(params["belowHost"], params["belowPort"])
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	belowInstance, found, err := inst.ReadInstance(&belowKey)
+	if (!found) || (err != nil) {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot read instance: %+v", belowKey)})
 		return
 	}
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Cluster %s now has alias '%s'", clusterName, alias)})
-}
-
-// Clusters provides list of known clusters
-func (this *HttpAPI) Clusters(params martini.Params, r render.Render, req *http.Request) {
-	clusterNames, err := inst.ReadClusters()
 
+	canReplicate, err := instance.CanReplicateFrom(belowInstance)
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	r.JSON(http.StatusOK, clusterNames)
-}
-
-// ClustersInfo provides list of known clusters, along with some added metadata per cluster
-func (this *HttpAPI) ClustersInfo(params martini.Params, r render.Render, req *http.Request) {
-	clustersInfo, err := inst.ReadClustersInfo("")
-
+	if !canReplicate {
+		Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("%t", canReplicate), Details: belowKey})
+		return
+	}
+	err = inst.CheckMoveViaGTID(instance, belowInstance)
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
+	canReplicate = (err == nil)
 
-	r.JSON(http.StatusOK, clustersInfo)
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("%t", canReplicate), Details: belowKey})
 }
 
-// Tags lists existing tags for a given instance
-func (this *HttpAPI) Tags(params martini.Params, r render.Render, req *http.Request) {
+// setSemiSyncMaster
+func (this *HttpAPI) setSemiSyncMaster(params martini.Params, r render.Render, req *http.Request, user auth.User, enable bool) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	tags, err := inst.ReadInstanceTags(&instanceKey)
+	instance, err := inst.SetSemiSyncMaster(&instanceKey, enable)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	tagStrings := []string{}
-	for _, tag := range tags {
-		tagStrings = append(tagStrings, tag.String())
-	}
-	r.JSON(http.StatusOK, tagStrings)
+
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("master semi-sync set to %t", enable), Details: instance})
 }
 
-// TagValue returns a given tag's value for a specific instance
-func (this *HttpAPI) TagValue(params martini.Params, r render.Render, req *http.Request) {
-	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
-	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+func (this *HttpAPI) EnableSemiSyncMaster(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	this.setSemiSyncMaster(params, r, req, user, true)
+}
+func (this *HttpAPI) DisableSemiSyncMaster(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	this.setSemiSyncMaster(params, r, req, user, false)
+}
+
+// setSemiSyncMaster
+func (this *HttpAPI) setSemiSyncReplica(params martini.Params, r render.Render, req *http.Request, user auth.User, enable bool) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	tag, err := getTag(params, req)
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	tagExists, err := inst.ReadInstanceTag(&instanceKey, tag)
+	instance, err := inst.SetSemiSyncReplica(&instanceKey, enable)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	if tagExists {
-		r.JSON(http.StatusOK, tag.TagValue)
-	} else {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("tag %s not found for %+v", tag.TagName, instanceKey)})
-	}
+
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("replica semi-sync set to %t", enable), Details: instance})
 }
 
-// Tagged return instance keys tagged by "tag" query param
-func (this *HttpAPI) Tagged(params martini.Params, r render.Render, req *http.Request) {
-	tagsString := req.URL.Query().Get("tag")
-	instanceKeyMap, err := inst.GetInstanceKeysByTags(tagsString)
-	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
-		return
-	}
+func (this *HttpAPI) EnableSemiSyncReplica(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	this.setSemiSyncReplica(params, r, req, user, true)
+}
 
-	r.JSON(http.StatusOK, instanceKeyMap.GetInstanceKeys())
+func (this *HttpAPI) DisableSemiSyncReplica(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	this.setSemiSyncReplica(params, r, req, user, false)
 }
 
-// Tags adds a tag to a given instance
-func (this *HttpAPI) Tag(params martini.Params, r render.Render, req *http.Request) {
+// DelayReplication delays replication on given instance with given seconds
+func (this *HttpAPI) DelayReplication(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	tag, err := getTag(params, req)
+	seconds, err := strconv.Atoi(params["seconds"])
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		Respond(r, &APIResponse{Code: ERROR, Message: "Invalid value provided for seconds"})
 		return
 	}
-	if orcraft.IsRaftEnabled() {
-		_, err = orcraft.PublishCommand("put-instance-tag", inst.InstanceTag{Key: instanceKey, T: *tag})
-	} else {
-		err = inst.PutInstanceTag(&instanceKey, tag)
-	}
+	err = inst.DelayReplication(&instanceKey, seconds)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("%+v tagged with %s", instanceKey, tag.String()), Details: instanceKey})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Replication delayed: %+v", instanceKey), Details: seconds})
 }
 
-// Untag removes a tag from an instance
-func (this *HttpAPI) Untag(params martini.Params, r render.Render, req *http.Request) {
-	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
-	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+// SetReadOnly sets the global read_only variable
+func (this *HttpAPI) SetReadOnly(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 
-	tag, err := getTag(params, req)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	untagged, err := inst.Untag(&instanceKey, tag)
+	instance, err := inst.SetReadOnly(&instanceKey, true)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("%s removed from %+v instances", tag.TagName, len(*untagged)), Details: untagged.GetInstanceKeys()})
+	Respond(r, &APIResponse{Code: OK, Message: "Server set as read-only", Details: instance})
 }
 
-// UntagAll removes a tag from all matching instances
-func (this *HttpAPI) UntagAll(params martini.Params, r render.Render, req *http.Request) {
-	tag, err := getTag(params, req)
+// SetWriteable clear the global read_only variable
+func (this *HttpAPI) SetWriteable(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	untagged, err := inst.Untag(nil, tag)
+	instance, err := inst.SetReadOnly(&instanceKey, false)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("%s removed from %+v instances", tag.TagName, len(*untagged)), Details: untagged.GetInstanceKeys()})
+	Respond(r, &APIResponse{Code: OK, Message: "Server set as writeable", Details: instance})
 }
 
-// Write a cluster's master (or all clusters masters) to kv stores.
-// This should generally only happen once in a lifetime of a cluster. Otherwise KV
-// stores are updated via failovers.
-func (this *HttpAPI) SubmitMastersToKvStores(params martini.Params, r render.Render, req *http.Request) {
-	clusterName, err := getClusterNameIfExists(params)
-	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+// KillQuery kills a query running on a server
+func (this *HttpAPI) KillQuery(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	kvPairs, submittedCount, err := logic.SubmitMastersToKvStores(clusterName, true)
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+	processId, err := strconv.ParseInt(params["process"], 10, 0)
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Submitted %d masters", submittedCount), Details: kvPairs})
-}
-
-// Clusters provides list of known masters
-func (this *HttpAPI) Masters(params martini.Params, r render.Render, req *http.Request) {
-	instances, err := inst.ReadWriteableClustersMasters()
 
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	instance, err := inst.KillQuery(&instanceKey, processId)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	r.JSON(http.StatusOK, instances)
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Query killed on : %+v", instance.Key), Details: instance})
 }
 
-// ClusterMaster returns the writable master of a given cluster
-func (this *HttpAPI) ClusterMaster(params martini.Params, r render.Render, req *http.Request) {
+// AsciiTopology returns an ascii graph of cluster's instances
+func (this *HttpAPI) asciiTopology(params martini.Params, r render.Render, req *http.Request, tabulated bool, printTags bool) {
 	clusterName, err := figureClusterName(getClusterHint(params))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	masters, err := inst.ReadClusterMaster(clusterName)
+	asciiOutput, err := inst.ASCIITopology(clusterName, "", tabulated, printTags)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-	if len(masters) == 0 {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("No masters found for %+v", clusterName)})
-		return
-	}
 
-	r.JSON(http.StatusOK, masters[0])
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Topology for cluster %s", clusterName), Details: asciiOutput})
 }
 
-// Downtimed lists downtimed instances, potentially filtered by cluster
-func (this *HttpAPI) Downtimed(params martini.Params, r render.Render, req *http.Request) {
-	clusterName, err := getClusterNameIfExists(params)
+// SnapshotTopologies triggers orchestrator to record a snapshot of host/master for all known hosts.
+func (this *HttpAPI) SnapshotTopologies(params martini.Params, r render.Render, req *http.Request) {
+	start := time.Now()
+	if err := inst.SnapshotTopologies(); err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err), Details: fmt.Sprintf("Took %v", time.Since(start))})
+		return
+	}
+
+	Respond(r, &APIResponse{Code: OK, Message: "Topology Snapshot completed", Details: fmt.Sprintf("Took %v", time.Since(start))})
+}
+
+// AsciiTopology returns an ascii graph of cluster's instances
+func (this *HttpAPI) AsciiTopology(params martini.Params, r render.Render, req *http.Request) {
+	this.asciiTopology(params, r, req, false, false)
+}
+
+// AsciiTopology returns an ascii graph of cluster's instances
+func (this *HttpAPI) AsciiTopologyTabulated(params martini.Params, r render.Render, req *http.Request) {
+	this.asciiTopology(params, r, req, true, false)
+}
+
+// AsciiTopologyTags returns an ascii graph of cluster's instances and instance tags
+func (this *HttpAPI) AsciiTopologyTags(params martini.Params, r render.Render, req *http.Request) {
+	this.asciiTopology(params, r, req, false, true)
+}
+
+// Cluster provides list of instances in given cluster
+func (this *HttpAPI) Cluster(params martini.Params, r render.Render, req *http.Request) {
+	clusterName, err := figureClusterName(getClusterHint(params))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	instances, err := inst.ReadDowntimedInstances(clusterName)
+	instances, err := inst.ReadClusterInstances(clusterName)
+
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	r.JSON(http.StatusOK, instances)
+	respondValidated(r, req, instances, func() []inst.InvariantViolation { return inst.CheckClusterInvariants(clusterName, instances) })
 }
 
-// AllInstances lists all known instances
-func (this *HttpAPI) AllInstances(params martini.Params, r render.Render, req *http.Request) {
-	instances, err := inst.SearchInstances("")
-
+// ClusterLag lists the lag of the instances of given cluster, with NULL values omitted rather than
+// presented as {"Int64", "Valid"} objects
+func (this *HttpAPI) ClusterLag(params martini.Params, r render.Render, req *http.Request) {
+	clusterName, err := figureClusterName(getClusterHint(params))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-
-	r.JSON(http.StatusOK, instances)
+	instances, err := inst.ReadClusterInstances(clusterName)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+	lags := []inst.InstanceLag{}
+	for _, instance := range instances {
+		lags = append(lags, instance.Lag())
+	}
+	r.JSON(http.StatusOK, lags)
 }
 
-// Search provides list of instances matching given search param via various criteria.
-func (this *HttpAPI) Search(params martini.Params, r render.Render, req *http.Request) {
-	searchString := params["searchString"]
-	if searchString == "" {
-		searchString = req.URL.Query().Get("s")
+// RollingOperationOrder lists instances of given cluster in the order a rolling operation (e.g. restart) should
+// visit them: deepest replicas first, intermediate masters later. The master is only listed, last, with include-master=true
+func (this *HttpAPI) RollingOperationOrder(params martini.Params, r render.Render, req *http.Request) {
+	clusterName, err := figureClusterName(getClusterHint(params))
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
 	}
-	instances, err := inst.SearchInstances(searchString)
-
+	instances, err := inst.ReadClusterInstances(clusterName)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-
-	r.JSON(http.StatusOK, instances)
+	includeMasters := (req.URL.Query().Get("include-master") == "true")
+	r.JSON(http.StatusOK, inst.RollingOperationOrder(instances, includeMasters))
 }
 
-// Problems provides list of instances with known problems
-func (this *HttpAPI) Problems(params martini.Params, r render.Render, req *http.Request) {
-	clusterName := params["clusterName"]
-	instances, err := inst.ReadProblemInstances(clusterName)
-
+// ClusterByAlias provides list of instances in given cluster
+func (this *HttpAPI) ClusterByAlias(params martini.Params, r render.Render, req *http.Request) {
+	clusterName, err := inst.GetClusterByAlias(params["clusterAlias"])
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	r.JSON(http.StatusOK, instances)
+	params["clusterName"] = clusterName
+	this.Cluster(params, r, req)
 }
 
-// Audit provides list of audit entries by given page number
-func (this *HttpAPI) Audit(params martini.Params, r render.Render, req *http.Request) {
-	page, err := strconv.Atoi(params["page"])
-	if err != nil || page < 0 {
-		page = 0
+// ClusterByInstance provides list of instances in cluster an instance belongs to
+func (this *HttpAPI) ClusterByInstance(params martini.Params, r render.Render, req *http.Request) {
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
 	}
-	var auditedInstanceKey *inst.InstanceKey
-	if instanceKey, err := this.getInstanceKey(params["host"], params["port"]); err == nil {
-		auditedInstanceKey = &instanceKey
+	instance, found, err := inst.ReadInstance(&instanceKey)
+	if (!found) || (err != nil) {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot read instance: %+v", instanceKey)})
+		return
 	}
 
-	audits, err := inst.ReadRecentAudit(auditedInstanceKey, page)
+	params["clusterName"] = instance.ClusterName
+	this.Cluster(params, r, req)
+}
 
+// ClusterInfo provides details of a given cluster
+func (this *HttpAPI) ClusterInfo(params martini.Params, r render.Render, req *http.Request) {
+	clusterName, err := figureClusterName(getClusterHint(params))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-
-	r.JSON(http.StatusOK, audits)
-}
-
-// HostnameResolveCache shows content of in-memory hostname cache
-func (this *HttpAPI) HostnameResolveCache(params martini.Params, r render.Render, req *http.Request) {
-	content, err := inst.HostnameResolveCache()
+	clusterInfo, err := inst.ReadClusterInfo(clusterName)
 
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: "Cache retrieved", Details: content})
+	r.JSON(http.StatusOK, clusterInfo)
 }
 
-// ResetHostnameResolveCache clears in-memory hostname resovle cache
-func (this *HttpAPI) ResetHostnameResolveCache(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+// ResolveClusterHint tells which cluster a cluster hint (cluster name, alias or instance) refers to, and how it was matched
+func (this *HttpAPI) ResolveClusterHint(params martini.Params, r render.Render, req *http.Request) {
+	resolution, err := inst.ResolveClusterHint(getClusterHint(params))
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-	err := inst.ResetHostnameResolveCache()
+	r.JSON(http.StatusOK, resolution)
+}
 
+// Cluster provides list of instances in given cluster
+func (this *HttpAPI) ClusterInfoByAlias(params martini.Params, r render.Render, req *http.Request) {
+	clusterName, err := inst.GetClusterByAlias(params["clusterAlias"])
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: "Hostname cache cleared"})
+	params["clusterName"] = clusterName
+	this.ClusterInfo(params, r, req)
 }
 
-// DeregisterHostnameUnresolve deregisters the unresolve name used previously
-func (this *HttpAPI) DeregisterHostnameUnresolve(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+// ClusterOSCReplicas returns heuristic list of OSC replicas
+func (this *HttpAPI) ClusterOSCReplicas(params martini.Params, r render.Render, req *http.Request) {
+	clusterName, err := figureClusterName(getClusterHint(params))
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	var instanceKey *inst.InstanceKey
-	if instKey, err := this.getInstanceKey(params["host"], params["port"]); err == nil {
-		instanceKey = &instKey
-	}
-
-	var err error
-	registration := inst.NewHostnameDeregistration(instanceKey)
-	if orcraft.IsRaftEnabled() {
-		_, err = orcraft.PublishCommand("register-hostname-unresolve", registration)
-	} else {
-		err = inst.RegisterHostnameUnresolve(registration)
-	}
+	instances, err := inst.GetClusterOSCReplicas(clusterName)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-	Respond(r, &APIResponse{Code: OK, Message: "Hostname deregister unresolve completed", Details: instanceKey})
+
+	r.JSON(http.StatusOK, instances)
 }
 
-// RegisterHostnameUnresolve registers the unresolve name to use
-func (this *HttpAPI) RegisterHostnameUnresolve(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// SetClusterAlias will change an alias for a given clustername
+func (this *HttpAPI) SetClusterAliasManualOverride(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
+	clusterName := params["clusterName"]
+	alias := req.URL.Query().Get("alias")
 
-	var instanceKey *inst.InstanceKey
-	if instKey, err := this.getInstanceKey(params["host"], params["port"]); err == nil {
-		instanceKey = &instKey
-	}
-
-	hostname := params["virtualname"]
 	var err error
-	registration := inst.NewHostnameRegistration(instanceKey, hostname)
 	if orcraft.IsRaftEnabled() {
-		_, err = orcraft.PublishCommand("register-hostname-unresolve", registration)
+		_, err = orcraft.PublishCommand("set-cluster-alias-manual-override", []string{clusterName, alias})
 	} else {
-		err = inst.RegisterHostnameUnresolve(registration)
+		err = inst.SetClusterAliasManualOverride(clusterName, alias)
 	}
+
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-	Respond(r, &APIResponse{Code: OK, Message: "Hostname register unresolve completed", Details: instanceKey})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Cluster %s now has alias '%s'", clusterName, alias)})
 }
 
-// SubmitPoolInstances (re-)applies the list of hostnames for a given pool
-func (this *HttpAPI) SubmitPoolInstances(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// ForgetClusterAlias removes the alias of a cluster, including any manual override. The alias
+// is later re-deduced from the cluster's instances, if possible.
+func (this *HttpAPI) ForgetClusterAlias(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	pool := params["pool"]
-	instances := req.URL.Query().Get("instances")
+	clusterName, err := figureClusterName(getClusterHint(params))
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
 
-	var err error
-	submission := inst.NewPoolInstancesSubmission(pool, instances)
 	if orcraft.IsRaftEnabled() {
-		_, err = orcraft.PublishCommand("submit-pool-instances", submission)
+		_, err = orcraft.PublishCommand("forget-cluster-alias", clusterName)
 	} else {
-		err = inst.ApplyPoolInstances(submission)
+		err = inst.ForgetClusterAlias(clusterName)
 	}
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Applied %s pool instances", pool), Details: pool})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Cluster alias forgotten: %+v", clusterName), Details: clusterName})
 }
 
-// SubmitPoolHostnames (re-)applies the list of hostnames for a given pool
-func (this *HttpAPI) ReadClusterPoolInstancesMap(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
-		return
-	}
-	clusterName := params["clusterName"]
-	pool := params["pool"]
-
-	poolInstancesMap, err := inst.ReadClusterPoolInstancesMap(clusterName, pool)
+// ClusterDomains lists the registered domain names of clusters
+func (this *HttpAPI) ClusterDomains(params martini.Params, r render.Render, req *http.Request) {
+	domainNames, err := inst.ReadClusterDomainNames()
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Read pool instances for cluster %s", clusterName), Details: poolInstancesMap})
+	r.JSON(http.StatusOK, domainNames)
 }
 
-// GetHeuristicClusterPoolInstances returns instances belonging to a cluster's pool
-func (this *HttpAPI) GetHeuristicClusterPoolInstances(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// SetClusterDomain registers the domain name of a cluster's master
+func (this *HttpAPI) SetClusterDomain(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
@@ -2286,888 +2608,1889 @@ func (this *HttpAPI) GetHeuristicClusterPoolInstances(params martini.Params, r r
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-	pool := params["pool"]
-
-	instances, err := inst.GetHeuristicClusterPoolInstances(clusterName, pool)
-	if err != nil {
+	if err := logic.SetClusterDomain(clusterName, params["domain"]); err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Heuristic pool instances for cluster %s", clusterName), Details: instances})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Cluster %s now has domain '%s'", clusterName, params["domain"]), Details: clusterName})
 }
 
-// GetHeuristicClusterPoolInstances returns instances belonging to a cluster's pool
-func (this *HttpAPI) GetHeuristicClusterPoolInstancesLag(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// ForgetClusterDomain removes the domain name registration of a cluster
+func (this *HttpAPI) ForgetClusterDomain(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	clusterName, err := inst.ReadClusterNameByAlias(params["clusterName"])
+	clusterName, err := figureClusterName(getClusterHint(params))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-	pool := params["pool"]
-
-	lag, err := inst.GetHeuristicClusterPoolInstancesLag(clusterName, pool)
-	if err != nil {
+	if err := logic.ForgetClusterDomain(clusterName); err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Heuristic pool lag for cluster %s", clusterName), Details: lag})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Cluster domain forgotten: %+v", clusterName), Details: clusterName})
 }
 
-// ReloadClusterAlias clears in-memory hostname resovle cache
-func (this *HttpAPI) ReloadClusterAlias(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// ReconcileClusterDomains keeps cluster domain names in sync with a source of truth, POSTed as a JSON object
+// mapping cluster names to domain names. With prune=true, domain names of clusters absent from it are removed.
+func (this *HttpAPI) ReconcileClusterDomains(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-
-	Respond(r, &APIResponse{Code: ERROR, Message: "This API call has been retired"})
-}
-
-// BulkPromotionRules returns a list of the known promotion rules for each instance
-func (this *HttpAPI) BulkPromotionRules(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+	domainNames := map[string]string{}
+	if err := json.NewDecoder(req.Body).Decode(&domainNames); err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot parse cluster domains: expecting a JSON object mapping cluster names to domain names: %+v", err)})
+		return
+	}
+	prune := req.URL.Query().Get("prune") == "true"
+	reconciliation, err := logic.ReconcileClusterDomains(domainNames, prune)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err), Details: reconciliation})
 		return
 	}
+	Respond(r, &APIResponse{Code: OK, Message: "Cluster domains reconciled", Details: reconciliation})
+}
 
-	promotionRules, err := inst.BulkReadCandidateDatabaseInstance()
+// ClusterAliases lists all cluster alias mappings, including manual overrides
+func (this *HttpAPI) ClusterAliases(params martini.Params, r render.Render, req *http.Request) {
+	aliases, err := inst.ReadClusterAliases()
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	r.JSON(http.StatusOK, promotionRules)
+	r.JSON(http.StatusOK, aliases)
 }
 
-// BulkInstances returns a list of all known instances
-func (this *HttpAPI) BulkInstances(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
-		return
-	}
+// Clusters provides list of known clusters
+func (this *HttpAPI) Clusters(params martini.Params, r render.Render, req *http.Request) {
+	clusterNames, err := inst.ReadClusters()
 
-	instances, err := inst.BulkReadInstance()
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	r.JSON(http.StatusOK, instances)
+	r.JSON(http.StatusOK, clusterNames)
 }
 
-// DiscoveryMetricsRaw will return the last X seconds worth of discovery information in time based order as a JSON array
-func (this *HttpAPI) DiscoveryMetricsRaw(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	seconds, err := strconv.Atoi(params["seconds"])
-	if err != nil || seconds <= 0 {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Invalid value provided for seconds"})
-		return
-	}
+// ClustersInfo provides list of known clusters, along with some added metadata per cluster
+func (this *HttpAPI) ClustersInfo(params martini.Params, r render.Render, req *http.Request) {
+	clustersInfo, err := inst.ReadClustersInfo("")
 
-	refTime := time.Now().Add(-time.Duration(seconds) * time.Second)
-	json, err := discovery.JSONSince(discoveryMetrics, refTime)
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to determine start time. Perhaps seconds value is wrong?"})
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-	log.Debugf("DiscoveryMetricsRaw data: retrieved %d entries from discovery.MC", len(json))
 
-	r.JSON(http.StatusOK, json)
+	r.JSON(http.StatusOK, clustersInfo)
 }
 
-// DiscoveryMetricsAggregated will return a single set of aggregated metrics for raw values collected since the
-// specified time.
-func (this *HttpAPI) DiscoveryMetricsAggregated(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	seconds, err := strconv.Atoi(params["seconds"])
-
-	refTime := time.Now().Add(-time.Duration(seconds) * time.Second)
-	aggregated, err := discovery.AggregatedSince(discoveryMetrics, refTime)
+// Tags lists existing tags for a given instance
+func (this *HttpAPI) Tags(params martini.Params, r render.Render, req *http.Request) {
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to generate aggregated discovery metrics"})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	// log.Debugf("DiscoveryMetricsAggregated data: %+v", aggregated)
-	r.JSON(http.StatusOK, aggregated)
-}
 
-func (this *HttpAPI) discoveryQueueMetricsAggregatedCommon(params martini.Params, r render.Render, req *http.Request, user auth.User, queueName string) {
-	seconds, err := strconv.Atoi(params["seconds"])
-	log.Debugf("DiscoveryQueueMetricsAggregated: queue: %s, seconds: %d", queueName, seconds)
+	tags, err := inst.ReadInstanceTags(&instanceKey)
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to generate discovery queue aggregated metrics"})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	queue := discovery.ReturnQueue(queueName)
-	if queue == nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to generate discovery queue aggregated metrics for unknown queue"})
-		return
+	tagStrings := []string{}
+	for _, tag := range tags {
+		tagStrings = append(tagStrings, tag.String())
 	}
-	aggregated := queue.AggregatedDiscoveryQueueMetrics(seconds)
-	log.Debugf("DiscoveryQueueMetricsAggregated data: %+v", aggregated)
-
-	r.JSON(http.StatusOK, aggregated)
+	r.JSON(http.StatusOK, tagStrings)
 }
 
-func (this *HttpAPI) discoveryQueueMetricsRawCommon(params martini.Params, r render.Render, req *http.Request, user auth.User, queueName string) {
-	seconds, err := strconv.Atoi(params["seconds"])
-	log.Debugf("DiscoveryQueueMetricsRaw: seconds: %d", seconds)
+// TagValue returns a given tag's value for a specific instance
+func (this *HttpAPI) TagValue(params martini.Params, r render.Render, req *http.Request) {
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to generate discovery queue raw metrics"})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	queue := discovery.ReturnQueue(queueName)
-	if queue == nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to generate discovery queue aggregated metrics for unknown queue"})
+	tag, err := getTag(params, req)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	metrics := queue.DiscoveryQueueMetrics(seconds)
-	log.Debugf("DiscoveryQueueMetricsRaw data: %+v", metrics)
-
-	r.JSON(http.StatusOK, metrics)
-}
-
-// DiscoveryQueueMetricsRaw returns the raw queue metrics (active and
-// queued values), data taken secondly for the last N seconds.
-func (this *HttpAPI) DiscoveryQueueMetricsRaw(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	this.discoveryQueueMetricsRawCommon(params, r, req, user, "DEFAULT")
-}
-
-// DiscoveryQueueMetricsAggregated returns a single value showing the metrics of the discovery queue over the last N seconds.
-// This is expected to be called every 60 seconds (?) and the config setting of the retention period is currently hard-coded.
-// See go/discovery/ for more information.
-func (this *HttpAPI) DiscoveryQueueMetricsAggregated(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	this.discoveryQueueMetricsAggregatedCommon(params, r, req, user, "DEFAULT")
-}
-
-// DiscoveryQueueMetricsRaw2 returns the raw queue metrics (active and
-// queued values), data taken secondly for the last N seconds.
-func (this *HttpAPI) DiscoveryQueueMetricsRaw2(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	queue, found := params["queue"]
-	if !found {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to generate discovery queue raw metrics"})
+	tagExists, err := inst.ReadInstanceTag(&instanceKey, tag)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	this.discoveryQueueMetricsRawCommon(params, r, req, user, queue)
+	if tagExists {
+		r.JSON(http.StatusOK, tag.TagValue)
+	} else {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("tag %s not found for %+v", tag.TagName, instanceKey)})
+	}
 }
 
-// DiscoveryQueueMetricsAggregated2 returns a single value showing the metrics of the discovery queue over the last N seconds.
-// This is expected to be called every 60 seconds (?) and the config setting of the retention period is currently hard-coded.
-// See go/discovery/ for more information.
-func (this *HttpAPI) DiscoveryQueueMetricsAggregated2(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	queue, found := params["queue"]
-	if !found {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to generate discovery queue aggregated metrics"})
+// Tagged return instance keys tagged by "tag" query param
+func (this *HttpAPI) Tagged(params martini.Params, r render.Render, req *http.Request) {
+	tagsString := req.URL.Query().Get("tag")
+	instanceKeyMap, err := inst.GetInstanceKeysByTags(tagsString)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	this.discoveryQueueMetricsAggregatedCommon(params, r, req, user, queue)
+	r.JSON(http.StatusOK, instanceKeyMap.GetInstanceKeys())
 }
 
-// BackendQueryMetricsRaw returns the raw backend query metrics
-func (this *HttpAPI) BackendQueryMetricsRaw(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	seconds, err := strconv.Atoi(params["seconds"])
-	log.Debugf("BackendQueryMetricsRaw: seconds: %d", seconds)
+// Tags adds a tag to a given instance
+func (this *HttpAPI) Tag(params martini.Params, r render.Render, req *http.Request) {
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to generate raw backend query metrics"})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	refTime := time.Now().Add(-time.Duration(seconds) * time.Second)
-	m, err := queryMetrics.Since(refTime)
+	tag, err := getTag(params, req)
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to return backend query metrics"})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	log.Debugf("BackendQueryMetricsRaw data: %+v", m)
-
-	r.JSON(http.StatusOK, m)
-}
-
-func (this *HttpAPI) BackendQueryMetricsAggregated(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	seconds, err := strconv.Atoi(params["seconds"])
-	log.Debugf("BackendQueryMetricsAggregated: seconds: %d", seconds)
+	if orcraft.IsRaftEnabled() {
+		_, err = orcraft.PublishCommand("put-instance-tag", inst.InstanceTag{Key: instanceKey, T: *tag})
+	} else {
+		err = inst.PutInstanceTag(&instanceKey, tag)
+	}
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to aggregated generate backend query metrics"})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	refTime := time.Now().Add(-time.Duration(seconds) * time.Second)
-	aggregated := query.AggregatedSince(queryMetrics, refTime)
-	log.Debugf("BackendQueryMetricsAggregated data: %+v", aggregated)
-
-	r.JSON(http.StatusOK, aggregated)
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("%+v tagged with %s", instanceKey, tag.String()), Details: instanceKey})
 }
 
-// WriteBufferMetricsRaw returns the raw instance write buffer metrics
-func (this *HttpAPI) WriteBufferMetricsRaw(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	seconds, err := strconv.Atoi(params["seconds"])
-	log.Debugf("WriteBufferMetricsRaw: seconds: %d", seconds)
+// Untag removes a tag from an instance
+func (this *HttpAPI) Untag(params martini.Params, r render.Render, req *http.Request) {
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to generate raw instance write buffer metrics"})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	refTime := time.Now().Add(-time.Duration(seconds) * time.Second)
-	m, err := writeBufferMetrics.Since(refTime)
+	tag, err := getTag(params, req)
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to return instance write buffermetrics"})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	untagged, err := inst.Untag(&instanceKey, tag)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	log.Debugf("WriteBufferMetricsRaw data: %+v", m)
-
-	r.JSON(http.StatusOK, m)
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("%s removed from %+v instances", tag.TagName, len(*untagged)), Details: untagged.GetInstanceKeys()})
 }
 
-// WriteBufferMetricsAggregated provides aggregate metrics of instance write buffer metrics
-func (this *HttpAPI) WriteBufferMetricsAggregated(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	seconds, err := strconv.Atoi(params["seconds"])
-	log.Debugf("WriteBufferMetricsAggregated: seconds: %d", seconds)
+// ClusterFlags lists the flags of a cluster, as encoded in tags on its master
+func (this *HttpAPI) ClusterFlags(params martini.Params, r render.Render, req *http.Request) {
+	clusterName, err := figureClusterName(getClusterHint(params))
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to aggregated instance write buffer metrics"})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	refTime := time.Now().Add(-time.Duration(seconds) * time.Second)
-	aggregated := inst.AggregatedSince(writeBufferMetrics, refTime)
-	log.Debugf("WriteBufferMetricsAggregated data: %+v", aggregated)
-
-	r.JSON(http.StatusOK, aggregated)
+	flags, err := inst.ReadClusterFlags(clusterName)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	r.JSON(http.StatusOK, flags)
 }
 
-// Agents provides complete list of registered agents (See https://github.com/openark/orchestrator-agent)
-func (this *HttpAPI) Agents(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// SetClusterFlag sets a cluster flag, encoded as a tag on the cluster's master
+func (this *HttpAPI) SetClusterFlag(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	if !config.Config.ServeAgentsHttp {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+	clusterName, err := figureClusterName(getClusterHint(params))
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	agents, err := agent.ReadAgents()
-
+	flagValue := strings.TrimSpace(params["flagValue"])
+	tag, err := inst.NewClusterFlagTag(params["flagName"], flagValue)
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	r.JSON(http.StatusOK, agents)
-}
-
-// Agent returns complete information of a given agent
-func (this *HttpAPI) Agent(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+	masterKey, err := inst.ReadClusterFlagsMasterKey(clusterName)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	if !config.Config.ServeAgentsHttp {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
-		return
+	if orcraft.IsRaftEnabled() {
+		_, err = orcraft.PublishCommand("put-instance-tag", inst.InstanceTag{Key: *masterKey, T: *tag})
+	} else {
+		err = inst.PutInstanceTag(masterKey, tag)
 	}
-
-	agent, err := agent.GetAgent(params["host"])
-
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	r.JSON(http.StatusOK, agent)
+	inst.InvalidateClusterFlags(clusterName)
+	inst.AuditOperation("set-cluster-flag", masterKey, fmt.Sprintf("cluster: %s, flag: %s", clusterName, tag.String()))
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Cluster %s: set %s", clusterName, tag.String()), Details: *masterKey})
 }
 
-// AgentUnmount instructs an agent to unmount the designated mount point
-func (this *HttpAPI) AgentUnmount(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// ClearClusterFlag removes a cluster flag
+func (this *HttpAPI) ClearClusterFlag(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	if !config.Config.ServeAgentsHttp {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+	clusterName, err := figureClusterName(getClusterHint(params))
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	output, err := agent.Unmount(params["host"])
-
+	tag, err := inst.NewClusterFlagTag(params["flagName"], "")
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	r.JSON(http.StatusOK, output)
-}
-
-// AgentMountLV instructs an agent to mount a given volume on the designated mount point
-func (this *HttpAPI) AgentMountLV(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+	masterKey, err := inst.ReadClusterFlagsMasterKey(clusterName)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	if !config.Config.ServeAgentsHttp {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
-		return
+	if orcraft.IsRaftEnabled() {
+		_, err = orcraft.PublishCommand("delete-instance-tag", inst.InstanceTag{Key: *masterKey, T: *tag})
+	} else {
+		_, err = inst.Untag(masterKey, tag)
 	}
-
-	output, err := agent.MountLV(params["host"], req.URL.Query().Get("lv"))
-
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	r.JSON(http.StatusOK, output)
+	inst.InvalidateClusterFlags(clusterName)
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Cluster %s: cleared %s", clusterName, tag.TagName), Details: *masterKey})
 }
 
-// AgentCreateSnapshot instructs an agent to create a new snapshot. Agent's DIY implementation.
-func (this *HttpAPI) AgentCreateSnapshot(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+// UntagAll removes a tag from all matching instances
+func (this *HttpAPI) UntagAll(params martini.Params, r render.Render, req *http.Request) {
+	tag, err := getTag(params, req)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	if !config.Config.ServeAgentsHttp {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+	untagged, err := inst.Untag(nil, tag)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	output, err := agent.CreateSnapshot(params["host"])
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("%s removed from %+v instances", tag.TagName, len(*untagged)), Details: untagged.GetInstanceKeys()})
+}
 
+// InstanceMetadata returns the metadata overrides of an instance
+func (this *HttpAPI) InstanceMetadata(params martini.Params, r render.Render, req *http.Request) {
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	overrides, err := inst.ReadInstanceMetadataOverrides(&instanceKey)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	r.JSON(http.StatusOK, output)
+	r.JSON(http.StatusOK, overrides)
 }
 
-// AgentRemoveLV instructs an agent to remove a logical volume
-func (this *HttpAPI) AgentRemoveLV(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// SetInstanceMetadata overrides metadata fields of an instance, given as query params
+// (instance_alias, data_center, region, physical_environment). Only given fields are affected;
+// an empty value removes the field's override.
+func (this *HttpAPI) SetInstanceMetadata(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	if !config.Config.ServeAgentsHttp {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	metadata := inst.NewInstanceMetadata(&instanceKey, getUserId(req, user))
+	for field, values := range req.URL.Query() {
+		metadata.Values[field] = strings.TrimSpace(values[0])
+	}
+	if _, err := metadata.Validate(); err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	output, err := agent.RemoveLV(params["host"], req.URL.Query().Get("lv"))
-
+	if orcraft.IsRaftEnabled() {
+		_, err = orcraft.PublishCommand("set-instance-metadata", metadata)
+	} else {
+		err = inst.SetInstanceMetadata(metadata)
+	}
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	r.JSON(http.StatusOK, output)
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Instance metadata set: %+v", instanceKey), Details: metadata.Values})
 }
 
-// AgentMySQLStop stops MySQL service on agent
-func (this *HttpAPI) AgentMySQLStop(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+// Write a cluster's master (or all clusters masters) to kv stores.
+// This should generally only happen once in a lifetime of a cluster. Otherwise KV
+// stores are updated via failovers. With verify, the entries are read back from the
+// KV stores, and resubmitted until they verify or the verification times out.
+func (this *HttpAPI) SubmitMastersToKvStores(params martini.Params, r render.Render, req *http.Request) {
+	clusterName, err := getClusterNameIfExists(params)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-	if !config.Config.ServeAgentsHttp {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+	verifyTimeout, err := getVerifyTimeout(req)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	output, err := agent.MySQLStop(params["host"])
-
+	if verifyTimeout > 0 {
+		verifications, err := logic.SubmitMastersToKvStoresVerified(clusterName, verifyTimeout)
+		if err != nil {
+			Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err), Details: verifications})
+			return
+		}
+		Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Submitted and verified %d masters", len(verifications)), Details: verifications})
+		return
+	}
+	kvPairs, submittedCount, err := logic.SubmitMastersToKvStores(clusterName, true)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-
-	r.JSON(http.StatusOK, output)
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Submitted %d masters", submittedCount), Details: kvPairs})
 }
 
-// AgentMySQLStart starts MySQL service on agent
-func (this *HttpAPI) AgentMySQLStart(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// HeartbeatController registers the liveness of a holder of an automation controller, taking or renewing
+// the controller's lease, optionally for a given duration
+func (this *HttpAPI) HeartbeatController(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	if !config.Config.ServeAgentsHttp {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
-		return
+	var durationSeconds int = 0
+	if params["duration"] != "" {
+		var err error
+		durationSeconds, err = util.SimpleTimeToSeconds(params["duration"])
+		if durationSeconds < 0 {
+			err = fmt.Errorf("Duration value must be non-negative. Given value: %d", durationSeconds)
+		}
+		if err != nil {
+			Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+			return
+		}
 	}
-
-	output, err := agent.MySQLStart(params["host"])
-
+	lease, err := logic.HeartbeatController(params["controller"], params["holder"], uint(durationSeconds))
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error(), Details: lease})
 		return
 	}
 
-	r.JSON(http.StatusOK, output)
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Controller %s active: %s", lease.Controller, lease.Holder), Details: lease})
 }
 
-func (this *HttpAPI) AgentCustomCommand(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// ReleaseController expires the lease of an automation controller, if held by given holder
+func (this *HttpAPI) ReleaseController(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	if !config.Config.ServeAgentsHttp {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+	released, err := logic.ReleaseController(params["controller"], params["holder"])
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	if !released {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Controller %s is not held by %s", params["controller"], params["holder"])})
 		return
 	}
 
-	output, err := agent.CustomCommand(params["host"], params["command"])
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Controller released: %s", params["controller"]), Details: params["controller"]})
+}
 
+// ActiveController returns the active lease of an automation controller, or null when no holder is active
+func (this *HttpAPI) ActiveController(params martini.Params, r render.Render, req *http.Request) {
+	lease, err := logic.WhoIsActiveController(params["controller"])
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	r.JSON(http.StatusOK, output)
+	r.JSON(http.StatusOK, lease)
 }
 
-// AgentSeed completely seeds a host with another host's snapshots. This is a complex operation
-// governed by orchestrator and executed by the two agents involved.
-func (this *HttpAPI) AgentSeed(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
-		return
-	}
-	if !config.Config.ServeAgentsHttp {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+// Clusters provides list of known masters
+func (this *HttpAPI) Masters(params martini.Params, r render.Render, req *http.Request) {
+	instances, err := inst.ReadWriteableClustersMasters()
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	output, err := agent.Seed(params["targetHost"], params["sourceHost"])
+	r.JSON(http.StatusOK, instances)
+}
 
+// ClusterMaster returns the writable master of a given cluster
+func (this *HttpAPI) ClusterMaster(params martini.Params, r render.Render, req *http.Request) {
+	clusterName, err := figureClusterName(getClusterHint(params))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	r.JSON(http.StatusOK, output)
-}
-
-// AgentActiveSeeds lists active seeds and their state
-func (this *HttpAPI) AgentActiveSeeds(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+	masters, err := inst.ReadClusterMaster(clusterName)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-	if !config.Config.ServeAgentsHttp {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+	if len(masters) == 0 {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("No masters found for %+v", clusterName)})
 		return
 	}
 
-	output, err := agent.ReadActiveSeedsForHost(params["host"])
+	master := masters[0]
+	respondValidated(r, req, master, func() []inst.InvariantViolation { return inst.CheckMasterInvariants(master) })
+}
 
+// ClusterHeads returns the heads of a given cluster: its master, or its active and passive co-masters
+func (this *HttpAPI) ClusterHeads(params martini.Params, r render.Render, req *http.Request) {
+	clusterName, err := figureClusterName(getClusterHint(params))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	r.JSON(http.StatusOK, output)
-}
-
-// AgentRecentSeeds lists recent seeds of a given agent
-func (this *HttpAPI) AgentRecentSeeds(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+	heads, err := inst.ReadClusterHeads(clusterName)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-	if !config.Config.ServeAgentsHttp {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+	if heads.Active == nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("No masters found for %+v", clusterName)})
 		return
 	}
 
-	output, err := agent.ReadRecentCompletedSeedsForHost(params["host"])
+	r.JSON(http.StatusOK, heads)
+}
 
+// Downtimed lists downtimed instances, potentially filtered by cluster
+func (this *HttpAPI) Downtimed(params martini.Params, r render.Render, req *http.Request) {
+	clusterName, err := getClusterNameIfExists(params)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	r.JSON(http.StatusOK, output)
-}
-
-// AgentSeedDetails provides details of a given seed
-func (this *HttpAPI) AgentSeedDetails(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
-		return
-	}
-	if !config.Config.ServeAgentsHttp {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+	instances, err := inst.ReadDowntimedInstances(clusterName)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	seedId, err := strconv.ParseInt(params["seedId"], 10, 0)
-	output, err := agent.AgentSeedDetails(seedId)
+	r.JSON(http.StatusOK, instances)
+}
+
+// AllInstances lists all known instances
+func (this *HttpAPI) AllInstances(params martini.Params, r render.Render, req *http.Request) {
+	instances, err := inst.SearchInstances("")
 
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	r.JSON(http.StatusOK, output)
+	r.JSON(http.StatusOK, instances)
 }
 
-// AgentSeedStates returns the breakdown of states (steps) of a given seed
-func (this *HttpAPI) AgentSeedStates(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+// Inventory provides a page of all known instances, for consumers mirroring the inventory. Pages are read via
+// "cursor", as returned by the previous page; "since" optionally reads only instances seen since a timestamp,
+// e.g. the SyncTimestamp of a previous sync; "limit" is the page size.
+func (this *HttpAPI) Inventory(params martini.Params, r render.Render, req *http.Request, w http.ResponseWriter) {
+	pageSize := 0
+	if limit := req.URL.Query().Get("limit"); limit != "" {
+		var err error
+		if pageSize, err = strconv.Atoi(limit); err != nil || pageSize <= 0 {
+			Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Invalid limit: %s", limit)})
+			return
+		}
+	}
+	if isJSONLinesRequest(req) {
+		// the entire inventory, following the cursor, an instance per line
+		respondJSONLines(w, func(writer *orcutil.JSONLinesWriter) error {
+			_, _, err := inst.SyncInventory(req.Context(), req.URL.Query().Get("cursor"), req.URL.Query().Get("since"), pageSize, func(instances [](*inst.Instance)) error {
+				for _, instance := range instances {
+					if err := writer.Write(instance); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			return err
+		})
 		return
 	}
-	if !config.Config.ServeAgentsHttp {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+	page, err := inst.ReadInventoryPage(req.URL.Query().Get("cursor"), req.URL.Query().Get("since"), req.URL.Query().Get("sync-timestamp"), pageSize)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	seedId, err := strconv.ParseInt(params["seedId"], 10, 0)
-	output, err := agent.ReadSeedStates(seedId)
+	r.JSON(http.StatusOK, page)
+}
+
+// Search provides list of instances matching given search param via various criteria.
+func (this *HttpAPI) Search(params martini.Params, r render.Render, req *http.Request) {
+	searchString := params["searchString"]
+	if searchString == "" {
+		searchString = req.URL.Query().Get("s")
+	}
+	instances, err := inst.SearchInstances(searchString)
 
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	r.JSON(http.StatusOK, output)
+	r.JSON(http.StatusOK, instances)
 }
 
-// Seeds returns all recent seeds
-func (this *HttpAPI) Seeds(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
-		return
-	}
-	if !config.Config.ServeAgentsHttp {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+// Problems provides list of instances with known problems
+func (this *HttpAPI) Problems(params martini.Params, r render.Render, req *http.Request) {
+	clusterName, err := getClusterNameParam(params)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-
-	output, err := agent.ReadRecentSeeds()
+	instances, err := inst.ReadProblemInstances(clusterName)
 
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	r.JSON(http.StatusOK, output)
+	RespondReport(r, req, instances, nil)
 }
 
-// AbortSeed instructs agents to abort an active seed
-func (this *HttpAPI) AbortSeed(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
-		return
+// Audit provides list of audit entries by given page number
+func (this *HttpAPI) Audit(params martini.Params, r render.Render, req *http.Request, w http.ResponseWriter) {
+	page, err := strconv.Atoi(params["page"])
+	if err != nil || page < 0 {
+		page = 0
 	}
-	if !config.Config.ServeAgentsHttp {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+	var auditedInstanceKey *inst.InstanceKey
+	if instanceKey, err := this.getInstanceKey(params["host"], params["port"]); err == nil {
+		auditedInstanceKey = &instanceKey
+	}
+	if isJSONLinesRequest(req) {
+		// an export of all entries following since-id, oldest first, an entry per line
+		var sinceId int64
+		if since := req.URL.Query().Get("since-id"); since != "" {
+			if sinceId, err = strconv.ParseInt(since, 10, 64); err != nil || sinceId < 0 {
+				Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Invalid since-id: %s", since)})
+				return
+			}
+		}
+		respondJSONLines(w, func(writer *orcutil.JSONLinesWriter) error {
+			_, err := inst.ExportAudit(req.Context(), auditedInstanceKey, sinceId, func(audits []inst.Audit) error {
+				for _, audit := range audits {
+					if err := writer.Write(audit); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			return err
+		})
 		return
 	}
 
-	seedId, err := strconv.ParseInt(params["seedId"], 10, 0)
-	err = agent.AbortSeed(seedId)
+	audits, err := inst.ReadRecentAudit(auditedInstanceKey, page)
 
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	r.JSON(http.StatusOK, err == nil)
+	r.JSON(http.StatusOK, audits)
 }
 
-// Headers is a self-test call which returns HTTP headers
-func (this *HttpAPI) Headers(params martini.Params, r render.Render, req *http.Request) {
-	r.JSON(http.StatusOK, req.Header)
-}
+// HostnameResolveCache shows content of in-memory hostname cache
+func (this *HttpAPI) HostnameResolveCache(params martini.Params, r render.Render, req *http.Request) {
+	content, err := inst.HostnameResolveCache()
 
-// Health performs a self test
-func (this *HttpAPI) Health(params martini.Params, r render.Render, req *http.Request) {
-	health, err := process.HealthTest()
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Application node is unhealthy %+v", err), Details: health})
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Application node is healthy"), Details: health})
-
-}
-
-// LBCheck returns a constant response, and this can be used by load balancers that expect a given string.
-func (this *HttpAPI) LBCheck(params martini.Params, r render.Render, req *http.Request) {
-	r.JSON(http.StatusOK, "OK")
+	Respond(r, &APIResponse{Code: OK, Message: "Cache retrieved", Details: content})
 }
 
-// LBCheck returns a constant response, and this can be used by load balancers that expect a given string.
-func (this *HttpAPI) LeaderCheck(params martini.Params, r render.Render, req *http.Request) {
-	respondStatus, err := strconv.Atoi(params["errorStatusCode"])
-	if err != nil || respondStatus < 0 {
-		respondStatus = http.StatusNotFound
+// ResetHostnameResolveCache clears in-memory hostname resovle cache
+func (this *HttpAPI) ResetHostnameResolveCache(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
 	}
+	err := inst.ResetHostnameResolveCache()
 
-	if logic.IsLeader() {
-		r.JSON(http.StatusOK, "OK")
-	} else {
-		r.JSON(respondStatus, "Not leader")
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
 	}
-}
 
-// A configurable endpoint that can be for regular status checks or whatever.  While similar to
-// Health() this returns 500 on failure.  This will prevent issues for those that have come to
-// expect a 200
-// It might be a good idea to deprecate the current Health() behavior and roll this in at some
-// point
-func (this *HttpAPI) StatusCheck(params martini.Params, r render.Render, req *http.Request) {
-	health, err := process.HealthTest()
-	if err != nil {
-		r.JSON(500, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Application node is unhealthy %+v", err), Details: health})
-		return
-	}
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Application node is healthy"), Details: health})
+	Respond(r, &APIResponse{Code: OK, Message: "Hostname cache cleared"})
 }
 
-// GrabElection forcibly grabs leadership. Use with care!!
-func (this *HttpAPI) GrabElection(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// DeregisterHostnameUnresolve deregisters the unresolve name used previously
+func (this *HttpAPI) DeregisterHostnameUnresolve(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	err := process.GrabElection()
+
+	var instanceKey *inst.InstanceKey
+	if instKey, err := this.getInstanceKey(params["host"], params["port"]); err == nil {
+		instanceKey = &instKey
+	}
+
+	var err error
+	registration := inst.NewHostnameDeregistration(instanceKey)
+	if orcraft.IsRaftEnabled() {
+		_, err = orcraft.PublishCommand("register-hostname-unresolve", registration)
+	} else {
+		err = inst.RegisterHostnameUnresolve(registration)
+	}
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Unable to grab election: %+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Node elected as leader")})
+	Respond(r, &APIResponse{Code: OK, Message: "Hostname deregister unresolve completed", Details: instanceKey})
 }
 
-// Reelect causes re-elections for an active node
-func (this *HttpAPI) Reelect(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// RegisterHostnameUnresolve registers the unresolve name to use
+func (this *HttpAPI) RegisterHostnameUnresolve(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	err := process.Reelect()
+
+	var instanceKey *inst.InstanceKey
+	if instKey, err := this.getInstanceKey(params["host"], params["port"]); err == nil {
+		instanceKey = &instKey
+	}
+
+	hostname := params["virtualname"]
+	var err error
+	registration := inst.NewHostnameRegistration(instanceKey, hostname)
+	if orcraft.IsRaftEnabled() {
+		_, err = orcraft.PublishCommand("register-hostname-unresolve", registration)
+	} else {
+		err = inst.RegisterHostnameUnresolve(registration)
+	}
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Unable to re-elect: %+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
+	Respond(r, &APIResponse{Code: OK, Message: "Hostname register unresolve completed", Details: instanceKey})
+}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Set re-elections")})
+// readPoolInstances reads the comma delimited instances of a pool submission: from the "instances" query or
+// form param, or, when POSTed as JSON, from a body listing the instances, e.g. ["host1:3306", "host2:3306"]
+func readPoolInstances(req *http.Request) (instances string, err error) {
+	if req.Method == "POST" && strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		instancesList := []string{}
+		if err := json.NewDecoder(req.Body).Decode(&instancesList); err != nil {
+			return "", fmt.Errorf("Cannot parse pool instances: expecting a JSON list of instances: %+v", err)
+		}
+		return strings.Join(instancesList, ","), nil
+	}
+	return req.FormValue("instances"), nil
 }
 
-// RaftAddPeer adds a new node to the raft cluster
-func (this *HttpAPI) RaftAddPeer(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// SubmitPoolInstances (re-)applies the list of hostnames for a given pool
+func (this *HttpAPI) SubmitPoolInstances(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	if !orcraft.IsRaftEnabled() {
-		Respond(r, &APIResponse{Code: ERROR, Message: "raft-add-peer: not running with raft setup"})
+	pool := params["pool"]
+	instances, err := readPoolInstances(req)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-	addr, err := orcraft.AddPeer(params["addr"])
 
+	submission := inst.NewPoolInstancesSubmission(pool, instances)
+	if err := submission.Validate(); err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+	if orcraft.IsRaftEnabled() {
+		_, err = orcraft.PublishCommand("submit-pool-instances", submission)
+	} else {
+		err = inst.ApplyPoolInstances(submission)
+	}
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot add raft peer: %+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	r.JSON(http.StatusOK, addr)
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Applied %s pool instances", pool), Details: pool})
 }
 
-// RaftAddPeer removes a node fro the raft cluster
-func (this *HttpAPI) RaftRemovePeer(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// SubmitPoolHostnames (re-)applies the list of hostnames for a given pool
+func (this *HttpAPI) ReadClusterPoolInstancesMap(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	if !orcraft.IsRaftEnabled() {
-		Respond(r, &APIResponse{Code: ERROR, Message: "raft-remove-peer: not running with raft setup"})
+	clusterName, err := getClusterNameParam(params)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-	addr, err := orcraft.RemovePeer(params["addr"])
+	pool := params["pool"]
 
+	poolInstancesMap, err := inst.ReadClusterPoolInstancesMap(clusterName, pool)
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot remove raft peer: %+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	r.JSON(http.StatusOK, addr)
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Read pool instances for cluster %s", clusterName), Details: poolInstancesMap})
 }
 
-// RaftYield yields to a specified host
-func (this *HttpAPI) RaftYield(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// GetHeuristicClusterPoolInstances returns instances belonging to a cluster's pool
+func (this *HttpAPI) GetHeuristicClusterPoolInstances(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	if !orcraft.IsRaftEnabled() {
-		Respond(r, &APIResponse{Code: ERROR, Message: "raft-yield: not running with raft setup"})
+	clusterName, err := figureClusterName(getClusterHint(params))
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-	orcraft.PublishYield(params["node"])
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Asynchronously yielded")})
-}
+	pool := params["pool"]
 
-// RaftYieldHint yields to a host whose name contains given hint (e.g. DC)
-func (this *HttpAPI) RaftYieldHint(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
-		return
-	}
-	if !orcraft.IsRaftEnabled() {
-		Respond(r, &APIResponse{Code: ERROR, Message: "raft-yield-hint: not running with raft setup"})
+	instances, err := inst.GetHeuristicClusterPoolInstances(clusterName, pool)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-	hint := params["hint"]
-	orcraft.PublishYieldHostnameHint(hint)
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Asynchronously yielded by hint %s", hint), Details: hint})
+
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Heuristic pool instances for cluster %s", clusterName), Details: instances})
 }
 
-// RaftPeers returns the list of peers in a raft setup
-func (this *HttpAPI) RaftPeers(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !orcraft.IsRaftEnabled() {
-		Respond(r, &APIResponse{Code: ERROR, Message: "raft-nodes: not running with raft setup"})
+// GetHeuristicClusterPoolInstances returns instances belonging to a cluster's pool
+func (this *HttpAPI) GetHeuristicClusterPoolInstancesLag(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-
-	peers, err := orcraft.GetPeers()
+	clusterName, err := getClusterNameParam(params)
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot get raft peers: %+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
+	pool := params["pool"]
 
-	r.JSON(http.StatusOK, peers)
-}
-
-// RaftState returns the state of this raft node
-func (this *HttpAPI) RaftState(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !orcraft.IsRaftEnabled() {
-		Respond(r, &APIResponse{Code: ERROR, Message: "raft-state: not running with raft setup"})
+	lag, err := inst.GetHeuristicClusterPoolInstancesLag(clusterName, pool)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	state := orcraft.GetState().String()
-	r.JSON(http.StatusOK, state)
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Heuristic pool lag for cluster %s", clusterName), Details: lag})
 }
 
-// RaftLeader returns the identify of the leader, if possible
-func (this *HttpAPI) RaftLeader(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !orcraft.IsRaftEnabled() {
-		Respond(r, &APIResponse{Code: ERROR, Message: "raft-leader: not running with raft setup"})
+// ReloadClusterAlias clears in-memory hostname resovle cache
+func (this *HttpAPI) ReloadClusterAlias(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
 
-	leader := orcraft.GetLeader()
-	r.JSON(http.StatusOK, leader)
+	Respond(r, &APIResponse{Code: ERROR, Message: "This API call has been retired"})
 }
 
-// RaftHealth indicates whether this node is part of a healthy raft group
-func (this *HttpAPI) RaftHealth(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !orcraft.IsRaftEnabled() {
-		Respond(r, &APIResponse{Code: ERROR, Message: "raft-state: not running with raft setup"})
+// BulkPromotionRules returns a list of the known promotion rules for each instance
+func (this *HttpAPI) BulkPromotionRules(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	if !orcraft.IsHealthy() {
-		Respond(r, &APIResponse{Code: ERROR, Message: "unhealthy"})
+
+	promotionRules, err := inst.BulkReadCandidateDatabaseInstance()
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-	r.JSON(http.StatusOK, "healthy")
+
+	r.JSON(http.StatusOK, promotionRules)
 }
 
-// RaftStatus exports a status summary for a raft node
-func (this *HttpAPI) RaftStatus(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !orcraft.IsRaftEnabled() {
-		Respond(r, &APIResponse{Code: ERROR, Message: "raft-state: not running with raft setup"})
+// ExportPromotionRules returns the registered promotion rules, keyed by host:port, as a snapshot to be restored
+// with apply-promotion-rules
+func (this *HttpAPI) ExportPromotionRules(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	peers, err := orcraft.GetPeers()
+
+	promotionRules, err := logic.ExportPromotionRules()
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot get raft peers: %+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
 
-	status := struct {
-		RaftBind       string
-		RaftAdvertise  string
-		State          string
-		Healthy        bool
-		IsPartOfQuorum bool
-		Leader         string
-		LeaderURI      string
-		Peers          []string
-	}{
-		RaftBind:       orcraft.GetRaftBind(),
-		RaftAdvertise:  orcraft.GetRaftAdvertise(),
-		State:          orcraft.GetState().String(),
-		Healthy:        orcraft.IsHealthy(),
-		IsPartOfQuorum: orcraft.IsPartOfQuorum(),
-		Leader:         orcraft.GetLeader(),
-		LeaderURI:      orcraft.LeaderURI.Get(),
-		Peers:          peers,
+	r.JSON(http.StatusOK, promotionRules)
+}
+
+// readPromotionRules reads promotion rules POSTed as a JSON object of host:port to promotion rule, as exported by
+// export-promotion-rules
+func readPromotionRules(req *http.Request) (map[string]inst.CandidatePromotionRule, error) {
+	promotionRules := map[string]inst.CandidatePromotionRule{}
+	if err := json.NewDecoder(req.Body).Decode(&promotionRules); err != nil {
+		return nil, fmt.Errorf("Cannot parse promotion rules: expecting a JSON object of host:port to promotion rule: %+v", err)
 	}
-	r.JSON(http.StatusOK, status)
+	return promotionRules, nil
 }
 
-// RaftFollowerHealthReport is initiated by followers to report their identity and health to the raft leader.
-func (this *HttpAPI) RaftFollowerHealthReport(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !orcraft.IsRaftEnabled() {
-		Respond(r, &APIResponse{Code: ERROR, Message: "raft-state: not running with raft setup"})
+// DiffPromotionRules lists the changes applying POSTed promotion rules would make, changing nothing
+func (this *HttpAPI) DiffPromotionRules(params martini.Params, r render.Render, req *http.Request) {
+	promotionRules, err := readPromotionRules(req)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	err := orcraft.OnHealthReport(params["authenticationToken"], params["raftBind"], params["raftAdvertise"])
+	diff, err := logic.DiffPromotionRules(promotionRules)
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot create snapshot: %+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	r.JSON(http.StatusOK, "health reported")
+
+	RespondReport(r, req, diff, diff.Changes)
 }
 
-// RaftSnapshot instructs raft to take a snapshot
-func (this *HttpAPI) RaftSnapshot(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !orcraft.IsRaftEnabled() {
-		Respond(r, &APIResponse{Code: ERROR, Message: "raft-leader: not running with raft setup"})
+// ApplyPromotionRules registers POSTed promotion rules, e.g. to restore those exported by export-promotion-rules
+func (this *HttpAPI) ApplyPromotionRules(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	err := orcraft.Snapshot()
+	promotionRules, err := readPromotionRules(req)
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot create snapshot: %+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	r.JSON(http.StatusOK, "snapshot created")
+	diff, err := logic.ApplyPromotionRules(promotionRules)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error(), Details: diff})
+		return
+	}
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Applied promotion rules: %d added, %d changed, %d unchanged", diff.CountAdded, diff.CountChanged, diff.CountUnchanged), Details: diff})
 }
 
-// ReloadConfiguration reloads confiug settings (not all of which will apply after change)
-func (this *HttpAPI) ReloadConfiguration(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// BulkInstances returns a list of all known instances
+func (this *HttpAPI) BulkInstances(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	extraConfigFile := req.URL.Query().Get("config")
-	config.Reload(extraConfigFile)
-	inst.AuditOperation("reload-configuration", nil, "Triggered via API")
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Config reloaded"), Details: extraConfigFile})
-}
-
-// ReplicationAnalysis retuens list of issues
-func (this *HttpAPI) replicationAnalysis(clusterName string, instanceKey *inst.InstanceKey, params martini.Params, r render.Render, req *http.Request) {
-	analysis, err := inst.GetReplicationAnalysis(clusterName, &inst.ReplicationAnalysisHints{IncludeDowntimed: true})
+	instances, err := inst.BulkReadInstance()
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot get analysis: %+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-	// Possibly filter single instance
-	if instanceKey != nil {
-		filtered := analysis[:0]
+
+	r.JSON(http.StatusOK, instances)
+}
+
+// DiscoveryMetricsRaw will return the last X seconds worth of discovery information in time based order as a JSON array
+func (this *HttpAPI) DiscoveryMetricsRaw(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	seconds, err := strconv.Atoi(params["seconds"])
+	if err != nil || seconds <= 0 {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Invalid value provided for seconds"})
+		return
+	}
+
+	refTime := time.Now().Add(-time.Duration(seconds) * time.Second)
+	json, err := discovery.JSONSince(discoveryMetrics, refTime)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to determine start time. Perhaps seconds value is wrong?"})
+		return
+	}
+	log.Debugf("DiscoveryMetricsRaw data: retrieved %d entries from discovery.MC", len(json))
+
+	r.JSON(http.StatusOK, json)
+}
+
+// DiscoveryMetricsAggregated will return a single set of aggregated metrics for raw values collected since the
+// specified time.
+func (this *HttpAPI) DiscoveryMetricsAggregated(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	seconds, err := strconv.Atoi(params["seconds"])
+
+	refTime := time.Now().Add(-time.Duration(seconds) * time.Second)
+	aggregated, err := discovery.AggregatedSince(discoveryMetrics, refTime)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to generate aggregated discovery metrics"})
+		return
+	}
+	// log.Debugf("DiscoveryMetricsAggregated data: %+v", aggregated)
+	r.JSON(http.StatusOK, aggregated)
+}
+
+// DiscoveryMetricsRollup returns long term, per instance rollups of discovery metrics
+func (this *HttpAPI) DiscoveryMetricsRollup(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	windows, err := logic.DiscoveryMetricsRollup()
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+
+	r.JSON(http.StatusOK, windows)
+}
+
+func (this *HttpAPI) discoveryQueueMetricsAggregatedCommon(params martini.Params, r render.Render, req *http.Request, user auth.User, queueName discovery.QueueName) {
+	seconds, err := strconv.Atoi(params["seconds"])
+	log.Debugf("DiscoveryQueueMetricsAggregated: queue: %s, seconds: %d", queueName, seconds)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to generate discovery queue aggregated metrics"})
+		return
+	}
+
+	queue := discovery.ReturnQueue(queueName)
+	if queue == nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Discovery queue %s is not in use", queueName)})
+		return
+	}
+	aggregated := queue.AggregatedDiscoveryQueueMetrics(seconds)
+	log.Debugf("DiscoveryQueueMetricsAggregated data: %+v", aggregated)
+
+	r.JSON(http.StatusOK, aggregated)
+}
+
+func (this *HttpAPI) discoveryQueueMetricsRawCommon(params martini.Params, r render.Render, req *http.Request, user auth.User, queueName discovery.QueueName) {
+	seconds, err := strconv.Atoi(params["seconds"])
+	log.Debugf("DiscoveryQueueMetricsRaw: seconds: %d", seconds)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to generate discovery queue raw metrics"})
+		return
+	}
+
+	queue := discovery.ReturnQueue(queueName)
+	if queue == nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Discovery queue %s is not in use", queueName)})
+		return
+	}
+	metrics := queue.DiscoveryQueueMetrics(seconds)
+	log.Debugf("DiscoveryQueueMetricsRaw data: %+v", metrics)
+
+	r.JSON(http.StatusOK, metrics)
+}
+
+// DiscoveryQueueMetricsRaw returns the raw queue metrics (active and
+// queued values), data taken secondly for the last N seconds.
+func (this *HttpAPI) DiscoveryQueueMetricsRaw(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	this.discoveryQueueMetricsRawCommon(params, r, req, user, discovery.DefaultQueue)
+}
+
+// DiscoveryQueueMetricsAggregated returns a single value showing the metrics of the discovery queue over the last N seconds.
+// This is expected to be called every 60 seconds (?) and the config setting of the retention period is currently hard-coded.
+// See go/discovery/ for more information.
+func (this *HttpAPI) DiscoveryQueueMetricsAggregated(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	this.discoveryQueueMetricsAggregatedCommon(params, r, req, user, discovery.DefaultQueue)
+}
+
+// DiscoveryQueueMetricsRaw2 returns the raw queue metrics (active and
+// queued values), data taken secondly for the last N seconds.
+func (this *HttpAPI) DiscoveryQueueMetricsRaw2(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	queue, err := discovery.ParseQueueName(params["queue"])
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+
+	this.discoveryQueueMetricsRawCommon(params, r, req, user, queue)
+}
+
+// DiscoveryQueueMetricsAggregated2 returns a single value showing the metrics of the discovery queue over the last N seconds.
+// This is expected to be called every 60 seconds (?) and the config setting of the retention period is currently hard-coded.
+// See go/discovery/ for more information.
+func (this *HttpAPI) DiscoveryQueueMetricsAggregated2(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	queue, err := discovery.ParseQueueName(params["queue"])
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+
+	this.discoveryQueueMetricsAggregatedCommon(params, r, req, user, queue)
+}
+
+// DiscoveryQueues lists the discovery queues in use on this node, with their current sizes
+func (this *HttpAPI) DiscoveryQueues(params martini.Params, r render.Render, req *http.Request) {
+	r.JSON(http.StatusOK, discovery.ListQueues())
+}
+
+// BackendQueryMetricsRaw returns the raw backend query metrics
+func (this *HttpAPI) BackendQueryMetricsRaw(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	seconds, err := strconv.Atoi(params["seconds"])
+	log.Debugf("BackendQueryMetricsRaw: seconds: %d", seconds)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to generate raw backend query metrics"})
+		return
+	}
+
+	refTime := time.Now().Add(-time.Duration(seconds) * time.Second)
+	m, err := queryMetrics.Since(refTime)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to return backend query metrics"})
+		return
+	}
+
+	log.Debugf("BackendQueryMetricsRaw data: %+v", m)
+
+	r.JSON(http.StatusOK, m)
+}
+
+func (this *HttpAPI) BackendQueryMetricsAggregated(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	seconds, err := strconv.Atoi(params["seconds"])
+	log.Debugf("BackendQueryMetricsAggregated: seconds: %d", seconds)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to aggregated generate backend query metrics"})
+		return
+	}
+
+	refTime := time.Now().Add(-time.Duration(seconds) * time.Second)
+	aggregated := query.AggregatedSince(queryMetrics, refTime)
+	log.Debugf("BackendQueryMetricsAggregated data: %+v", aggregated)
+
+	r.JSON(http.StatusOK, aggregated)
+}
+
+// WriteBufferMetricsRaw returns the raw instance write buffer metrics
+func (this *HttpAPI) WriteBufferMetricsRaw(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	seconds, err := strconv.Atoi(params["seconds"])
+	log.Debugf("WriteBufferMetricsRaw: seconds: %d", seconds)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to generate raw instance write buffer metrics"})
+		return
+	}
+
+	refTime := time.Now().Add(-time.Duration(seconds) * time.Second)
+	m, err := writeBufferMetrics.Since(refTime)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to return instance write buffermetrics"})
+		return
+	}
+
+	log.Debugf("WriteBufferMetricsRaw data: %+v", m)
+
+	r.JSON(http.StatusOK, m)
+}
+
+// WriteBufferMetricsAggregated provides aggregate metrics of instance write buffer metrics
+func (this *HttpAPI) WriteBufferMetricsAggregated(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	seconds, err := strconv.Atoi(params["seconds"])
+	log.Debugf("WriteBufferMetricsAggregated: seconds: %d", seconds)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unable to aggregated instance write buffer metrics"})
+		return
+	}
+
+	refTime := time.Now().Add(-time.Duration(seconds) * time.Second)
+	aggregated := inst.AggregatedSince(writeBufferMetrics, refTime)
+	log.Debugf("WriteBufferMetricsAggregated data: %+v", aggregated)
+
+	r.JSON(http.StatusOK, aggregated)
+}
+
+// Agents provides complete list of registered agents (See https://github.com/openark/orchestrator-agent)
+func (this *HttpAPI) Agents(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	if !config.Config.ServeAgentsHttp {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+		return
+	}
+
+	agents, err := agent.ReadAgents()
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, agents)
+}
+
+// Agent returns complete information of a given agent
+func (this *HttpAPI) Agent(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	if !config.Config.ServeAgentsHttp {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+		return
+	}
+
+	agent, err := agent.GetAgent(params["host"])
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, agent)
+}
+
+// AgentUnmount instructs an agent to unmount the designated mount point
+func (this *HttpAPI) AgentUnmount(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	if !config.Config.ServeAgentsHttp {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+		return
+	}
+
+	output, err := agent.Unmount(params["host"])
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, output)
+}
+
+// AgentMountLV instructs an agent to mount a given volume on the designated mount point
+func (this *HttpAPI) AgentMountLV(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	if !config.Config.ServeAgentsHttp {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+		return
+	}
+
+	output, err := agent.MountLV(params["host"], req.URL.Query().Get("lv"))
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, output)
+}
+
+// AgentCreateSnapshot instructs an agent to create a new snapshot. Agent's DIY implementation.
+func (this *HttpAPI) AgentCreateSnapshot(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	if !config.Config.ServeAgentsHttp {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+		return
+	}
+
+	output, err := agent.CreateSnapshot(params["host"])
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, output)
+}
+
+// AgentRemoveLV instructs an agent to remove a logical volume
+func (this *HttpAPI) AgentRemoveLV(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	if !config.Config.ServeAgentsHttp {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+		return
+	}
+
+	output, err := agent.RemoveLV(params["host"], req.URL.Query().Get("lv"))
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, output)
+}
+
+// AgentMySQLStop stops MySQL service on agent
+func (this *HttpAPI) AgentMySQLStop(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	if !config.Config.ServeAgentsHttp {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+		return
+	}
+
+	output, err := agent.MySQLStop(params["host"])
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, output)
+}
+
+// AgentMySQLStart starts MySQL service on agent
+func (this *HttpAPI) AgentMySQLStart(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	if !config.Config.ServeAgentsHttp {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+		return
+	}
+
+	output, err := agent.MySQLStart(params["host"])
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, output)
+}
+
+func (this *HttpAPI) AgentCustomCommand(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	if !config.Config.ServeAgentsHttp {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+		return
+	}
+
+	output, err := agent.CustomCommand(params["host"], params["command"])
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, output)
+}
+
+// AgentSeed completely seeds a host with another host's snapshots. This is a complex operation
+// governed by orchestrator and executed by the two agents involved.
+func (this *HttpAPI) AgentSeed(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	if !config.Config.ServeAgentsHttp {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+		return
+	}
+
+	output, err := agent.Seed(params["targetHost"], params["sourceHost"])
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, output)
+}
+
+// AgentActiveSeeds lists active seeds and their state
+func (this *HttpAPI) AgentActiveSeeds(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	if !config.Config.ServeAgentsHttp {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+		return
+	}
+
+	output, err := agent.ReadActiveSeedsForHost(params["host"])
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, output)
+}
+
+// AgentRecentSeeds lists recent seeds of a given agent
+func (this *HttpAPI) AgentRecentSeeds(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	if !config.Config.ServeAgentsHttp {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+		return
+	}
+
+	output, err := agent.ReadRecentCompletedSeedsForHost(params["host"])
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, output)
+}
+
+// AgentSeedDetails provides details of a given seed
+func (this *HttpAPI) AgentSeedDetails(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	if !config.Config.ServeAgentsHttp {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+		return
+	}
+
+	seedId, err := strconv.ParseInt(params["seedId"], 10, 0)
+	output, err := agent.AgentSeedDetails(seedId)
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, output)
+}
+
+// AgentSeedStates returns the breakdown of states (steps) of a given seed
+func (this *HttpAPI) AgentSeedStates(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	if !config.Config.ServeAgentsHttp {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+		return
+	}
+
+	seedId, err := strconv.ParseInt(params["seedId"], 10, 0)
+	output, err := agent.ReadSeedStates(seedId)
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, output)
+}
+
+// Seeds returns all recent seeds
+func (this *HttpAPI) Seeds(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	if !config.Config.ServeAgentsHttp {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+		return
+	}
+
+	output, err := agent.ReadRecentSeeds()
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, output)
+}
+
+// AbortSeed instructs agents to abort an active seed
+func (this *HttpAPI) AbortSeed(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	if !config.Config.ServeAgentsHttp {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Agents not served"})
+		return
+	}
+
+	seedId, err := strconv.ParseInt(params["seedId"], 10, 0)
+	err = agent.AbortSeed(seedId)
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, err == nil)
+}
+
+// Headers is a self-test call which returns HTTP headers
+func (this *HttpAPI) Headers(params martini.Params, r render.Render, req *http.Request) {
+	r.JSON(http.StatusOK, req.Header)
+}
+
+// APISchema returns the top level JSON fields of main response types, for detecting client/server schema drift
+func (this *HttpAPI) APISchema(params martini.Params, r render.Render, req *http.Request) {
+	schema, err := readAPISchema()
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+	r.JSON(http.StatusOK, schema)
+}
+
+// APIEndpoints describes all API endpoints, for client code generation
+func (this *HttpAPI) APIEndpoints(params martini.Params, r render.Render, req *http.Request) {
+	r.JSON(http.StatusOK, ReadAPIEndpoints())
+}
+
+// Health performs a self test
+func (this *HttpAPI) Health(params martini.Params, r render.Render, req *http.Request) {
+	health, err := process.HealthTest()
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Application node is unhealthy %+v", err), Details: health})
+		return
+	}
+
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Application node is healthy"), Details: health})
+
+}
+
+// LBCheck returns a constant response, and this can be used by load balancers that expect a given string.
+func (this *HttpAPI) LBCheck(params martini.Params, r render.Render, req *http.Request) {
+	r.JSON(http.StatusOK, "OK")
+}
+
+// LBCheck returns a constant response, and this can be used by load balancers that expect a given string.
+func (this *HttpAPI) LeaderCheck(params martini.Params, r render.Render, req *http.Request) {
+	respondStatus, err := strconv.Atoi(params["errorStatusCode"])
+	if err != nil || respondStatus < 0 {
+		respondStatus = http.StatusNotFound
+	}
+
+	if logic.IsLeader() {
+		r.JSON(http.StatusOK, "OK")
+	} else {
+		r.JSON(respondStatus, "Not leader")
+	}
+}
+
+// A configurable endpoint that can be for regular status checks or whatever.  While similar to
+// Health() this returns 500 on failure.  This will prevent issues for those that have come to
+// expect a 200
+// It might be a good idea to deprecate the current Health() behavior and roll this in at some
+// point
+func (this *HttpAPI) StatusCheck(params martini.Params, r render.Render, req *http.Request) {
+	health, err := process.HealthTest()
+	if err != nil {
+		r.JSON(500, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Application node is unhealthy %+v", err), Details: health})
+		return
+	}
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Application node is healthy"), Details: health})
+}
+
+// GrabElection forcibly grabs leadership. Use with care!!
+func (this *HttpAPI) GrabElection(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	err := process.GrabElection()
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Unable to grab election: %+v", err)})
+		return
+	}
+
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Node elected as leader")})
+}
+
+// Reelect causes re-elections for an active node
+func (this *HttpAPI) Reelect(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	err := process.Reelect()
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Unable to re-elect: %+v", err)})
+		return
+	}
+
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Set re-elections")})
+}
+
+// RaftAddPeer adds a new node to the raft cluster
+func (this *HttpAPI) RaftAddPeer(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	if !orcraft.IsRaftEnabled() {
+		Respond(r, &APIResponse{Code: ERROR, Message: "raft-add-peer: not running with raft setup"})
+		return
+	}
+	addr, err := orcraft.AddPeer(params["addr"])
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot add raft peer: %+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, addr)
+}
+
+// RaftAddPeer removes a node fro the raft cluster
+func (this *HttpAPI) RaftRemovePeer(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	if !orcraft.IsRaftEnabled() {
+		Respond(r, &APIResponse{Code: ERROR, Message: "raft-remove-peer: not running with raft setup"})
+		return
+	}
+	addr, err := orcraft.RemovePeer(params["addr"])
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot remove raft peer: %+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, addr)
+}
+
+// RaftYield yields to a specified host
+func (this *HttpAPI) RaftYield(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	if !orcraft.IsRaftEnabled() {
+		Respond(r, &APIResponse{Code: ERROR, Message: "raft-yield: not running with raft setup"})
+		return
+	}
+	orcraft.PublishYield(params["node"])
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Asynchronously yielded")})
+}
+
+// RaftYieldHint yields to a host whose name contains given hint (e.g. DC)
+func (this *HttpAPI) RaftYieldHint(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	if !orcraft.IsRaftEnabled() {
+		Respond(r, &APIResponse{Code: ERROR, Message: "raft-yield-hint: not running with raft setup"})
+		return
+	}
+	hint := params["hint"]
+	orcraft.PublishYieldHostnameHint(hint)
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Asynchronously yielded by hint %s", hint), Details: hint})
+}
+
+// RaftPeers returns the list of peers in a raft setup
+func (this *HttpAPI) RaftPeers(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !orcraft.IsRaftEnabled() {
+		Respond(r, &APIResponse{Code: ERROR, Message: "raft-nodes: not running with raft setup"})
+		return
+	}
+
+	peers, err := orcraft.GetPeers()
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot get raft peers: %+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, peers)
+}
+
+// RaftState returns the state of this raft node
+func (this *HttpAPI) RaftState(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !orcraft.IsRaftEnabled() {
+		Respond(r, &APIResponse{Code: ERROR, Message: "raft-state: not running with raft setup"})
+		return
+	}
+
+	state := orcraft.GetState().String()
+	r.JSON(http.StatusOK, state)
+}
+
+// RaftLeader returns the identify of the leader, if possible
+func (this *HttpAPI) RaftLeader(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !orcraft.IsRaftEnabled() {
+		Respond(r, &APIResponse{Code: ERROR, Message: "raft-leader: not running with raft setup"})
+		return
+	}
+
+	leader := orcraft.GetLeader()
+	r.JSON(http.StatusOK, leader)
+}
+
+// RaftHealth indicates whether this node is part of a healthy raft group
+func (this *HttpAPI) RaftHealth(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !orcraft.IsRaftEnabled() {
+		Respond(r, &APIResponse{Code: ERROR, Message: "raft-state: not running with raft setup"})
+		return
+	}
+	if !orcraft.IsHealthy() {
+		Respond(r, &APIResponse{Code: ERROR, Message: "unhealthy"})
+		return
+	}
+	r.JSON(http.StatusOK, "healthy")
+}
+
+// RaftStatus exports a status summary for a raft node
+func (this *HttpAPI) RaftStatus(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !orcraft.IsRaftEnabled() {
+		Respond(r, &APIResponse{Code: ERROR, Message: "raft-state: not running with raft setup"})
+		return
+	}
+	peers, err := orcraft.GetPeers()
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot get raft peers: %+v", err)})
+		return
+	}
+	leaderNode, err := orcraft.GetLeaderNode()
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot parse raft leader: %+v", err)})
+		return
+	}
+
+	status := struct {
+		RaftBind       string
+		RaftAdvertise  string
+		State          string
+		Healthy        bool
+		IsPartOfQuorum bool
+		Leader         string
+		LeaderNode     *orcraft.RaftNode
+		LeaderURI      string
+		IsConnected    bool
+		Peers          []string
+	}{
+		RaftBind:       orcraft.GetRaftBind(),
+		RaftAdvertise:  orcraft.GetRaftAdvertise(),
+		State:          orcraft.GetState().String(),
+		Healthy:        orcraft.IsHealthy(),
+		IsPartOfQuorum: orcraft.IsPartOfQuorum(),
+		Leader:         orcraft.GetLeader(),
+		LeaderNode:     leaderNode,
+		LeaderURI:      orcraft.LeaderURI.Get(),
+		IsConnected:    orcraft.IsConnectedToRaftLeader(req.Context()),
+		Peers:          peers,
+	}
+	r.JSON(http.StatusOK, status)
+}
+
+// RaftFollowerHealthReport is initiated by followers to report their identity and health to the raft leader.
+func (this *HttpAPI) RaftFollowerHealthReport(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !orcraft.IsRaftEnabled() {
+		Respond(r, &APIResponse{Code: ERROR, Message: "raft-state: not running with raft setup"})
+		return
+	}
+	err := orcraft.OnHealthReport(params["authenticationToken"], params["raftBind"], params["raftAdvertise"])
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot create snapshot: %+v", err)})
+		return
+	}
+	r.JSON(http.StatusOK, "health reported")
+}
+
+// RaftSnapshot instructs raft to take a snapshot
+func (this *HttpAPI) RaftSnapshot(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !orcraft.IsRaftEnabled() {
+		Respond(r, &APIResponse{Code: ERROR, Message: "raft-leader: not running with raft setup"})
+		return
+	}
+	err := orcraft.Snapshot()
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot create snapshot: %+v", err)})
+		return
+	}
+	r.JSON(http.StatusOK, "snapshot created")
+}
+
+// PreUpgradeCheck reports whether the orchestrator service may safely have a node restarted, as a go/no-go
+// report intended for deployment pipelines. With raft, it is served by the leader, which knows the health
+// of all members.
+func (this *HttpAPI) PreUpgradeCheck(params martini.Params, r render.Render, req *http.Request) {
+	thresholds := logic.PreUpgradeThresholds{}
+	if minLeaderStable := strings.TrimSpace(req.URL.Query().Get("min-leader-stable")); minLeaderStable != "" {
+		seconds, err := util.SimpleTimeToSeconds(minLeaderStable)
+		if err != nil {
+			Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Invalid min-leader-stable: %+v", err)})
+			return
+		}
+		thresholds.MinLeaderStableSeconds = int64(seconds)
+	}
+	if maxBackendLatency := strings.TrimSpace(req.URL.Query().Get("max-backend-latency")); maxBackendLatency != "" {
+		latency, err := time.ParseDuration(maxBackendLatency)
+		if err != nil {
+			Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Invalid max-backend-latency: %+v", err)})
+			return
+		}
+		thresholds.MaxBackendLatency = latency
+	}
+	report, err := logic.PreUpgradeCheck(req.Context(), thresholds)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+
+	RespondReport(r, req, report, report.Checks)
+}
+
+// ReloadConfiguration reloads confiug settings (not all of which will apply after change)
+func (this *HttpAPI) ReloadConfiguration(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	extraConfigFile := req.URL.Query().Get("config")
+	if _, err := reloadConfigurationAndDiff(extraConfigFile); err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Config reloaded"), Details: extraConfigFile})
+}
+
+// ReloadConfigurationDiff reloads the configuration, as ReloadConfiguration does, and details the names of the
+// settings whose values changed, so that configuration rollouts can be verified
+func (this *HttpAPI) ReloadConfigurationDiff(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	changed, err := reloadConfigurationAndDiff(req.URL.Query().Get("config"))
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Config reloaded; %d settings changed", len(changed)), Details: changed})
+}
+
+func reloadConfigurationAndDiff(extraConfigFile string) (changed []string, err error) {
+	changed, err = config.ReloadAndDiff(extraConfigFile)
+	if err != nil {
+		return changed, err
+	}
+	inst.AuditOperation("reload-configuration", nil, fmt.Sprintf("Triggered via API; changed settings: %s", strings.Join(changed, ", ")))
+	return changed, nil
+}
+
+// RuntimeConfig returns the configuration currently in effect on this node, with credentials masked
+func (this *HttpAPI) RuntimeConfig(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	settings, err := config.Config.Sanitized()
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+	r.JSON(http.StatusOK, settings)
+}
+
+// FlushInstanceWriteBuffer requests this node to immediately flush its buffered instance writes to the backend
+func (this *HttpAPI) FlushInstanceWriteBuffer(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	pending := inst.FlushInstanceWriteBuffer()
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Instance write buffer flush requested; %d pending instances", pending), Details: pending})
+}
+
+// PurgeBackendHistory removes audit, failure detection and recovery history older than given duration.
+// As this cannot be undone, the duration must be repeated in the "confirm" query param.
+func (this *HttpAPI) PurgeBackendHistory(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	duration := params["duration"]
+	if confirm := strings.TrimSpace(req.URL.Query().Get("confirm")); confirm != duration {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Purging history cannot be undone; repeat the duration to confirm: ?confirm=%s", duration)})
+		return
+	}
+	olderThanSeconds, err := util.SimpleTimeToSeconds(duration)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	if orcraft.IsRaftEnabled() {
+		if olderThanSeconds < logic.MinBackendHistoryPurgeSeconds {
+			Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Refusing to purge history younger than %d seconds", logic.MinBackendHistoryPurgeSeconds)})
+			return
+		}
+		_, err = orcraft.PublishCommand("purge-backend-history", olderThanSeconds)
+		if err != nil {
+			Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+			return
+		}
+		Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Purged history older than %s", duration)})
+		return
+	}
+	purged, err := logic.PurgeBackendHistory(olderThanSeconds)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Purged history older than %s", duration), Details: purged})
+}
+
+// ReplicationAnalysis retuens list of issues
+func (this *HttpAPI) replicationAnalysis(clusterName string, instanceKey *inst.InstanceKey, params martini.Params, r render.Render, req *http.Request) {
+	analysis, err := inst.GetReplicationAnalysis(clusterName, &inst.ReplicationAnalysisHints{IncludeDowntimed: true})
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot get analysis: %+v", err)})
+		return
+	}
+	// Possibly filter single instance
+	if instanceKey != nil {
+		filtered := analysis[:0]
 		for _, analysisEntry := range analysis {
 			if instanceKey.Equals(&analysisEntry.AnalyzedInstanceKey) {
 				filtered = append(filtered, analysisEntry)
@@ -3176,145 +4499,535 @@ func (this *HttpAPI) replicationAnalysis(clusterName string, instanceKey *inst.I
 		analysis = filtered
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Analysis"), Details: analysis})
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Analysis"), Details: analysis})
+}
+
+// ReplicationAnalysis retuens list of issues
+func (this *HttpAPI) ReplicationAnalysis(params martini.Params, r render.Render, req *http.Request) {
+	this.replicationAnalysis("", nil, params, r, req)
+}
+
+// ReplicationAnalysis retuens list of issues
+func (this *HttpAPI) ReplicationAnalysisForCluster(params martini.Params, r render.Render, req *http.Request) {
+	clusterName := params["clusterName"]
+
+	var err error
+	if clusterName, err = inst.DeduceClusterName(params["clusterName"]); err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot get analysis: %+v", err)})
+		return
+	}
+	if clusterName == "" {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot get cluster name: %+v", params["clusterName"])})
+		return
+	}
+	this.replicationAnalysis(clusterName, nil, params, r, req)
+}
+
+// ReplicationAnalysis retuens list of issues
+func (this *HttpAPI) ReplicationAnalysisForKey(params martini.Params, r render.Render, req *http.Request) {
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot get analysis: %+v", err)})
+		return
+	}
+	if !instanceKey.IsValid() {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot get analysis: invalid key %+v", instanceKey)})
+		return
+	}
+	this.replicationAnalysis("", &instanceKey, params, r, req)
+}
+
+// RecoverLite attempts recovery on a given instance, without executing external processes
+func (this *HttpAPI) RecoverLite(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	params["skipProcesses"] = "true"
+	this.Recover(params, r, req, user)
+}
+
+// RecoverAuto attempts recovery on a given instance, choosing whether to execute external processes by policy
+func (this *HttpAPI) RecoverAuto(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	var candidateKey *inst.InstanceKey
+	if key, err := this.getInstanceKey(params["candidateHost"], params["candidatePort"]); err == nil {
+		candidateKey = &key
+	}
+	result, err := logic.RecoverAuto(&instanceKey, candidateKey)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error(), Details: result})
+		return
+	}
+	if !result.RecoveryAttempted {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Recovery not attempted", Details: result})
+		return
+	}
+	if result.PromotedReplicaKey == nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Recovery attempted but no instance promoted", Details: result})
+		return
+	}
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Recovery (%s) executed on %+v", result.Mode, instanceKey), Details: result})
+}
+
+// Recover attempts recovery on a given instance
+func (this *HttpAPI) Recover(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	var candidateKey *inst.InstanceKey
+	if key, err := this.getInstanceKey(params["candidateHost"], params["candidatePort"]); err == nil {
+		candidateKey = &key
+	}
+
+	skipProcesses := (req.URL.Query().Get("skipProcesses") == "true") || (params["skipProcesses"] == "true")
+	recoveryAttempted, promotedInstanceKey, err := logic.CheckAndRecover(&instanceKey, candidateKey, skipProcesses)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error(), Details: instanceKey})
+		return
+	}
+	if !recoveryAttempted {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Recovery not attempted", Details: instanceKey})
+		return
+	}
+	if promotedInstanceKey == nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Recovery attempted but no instance promoted", Details: instanceKey})
+		return
+	}
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Recovery executed on %+v", instanceKey), Details: *promotedInstanceKey})
+}
+
+// GracefulMasterTakeover gracefully fails over a master onto its single replica.
+func (this *HttpAPI) gracefulMasterTakeover(params martini.Params, r render.Render, req *http.Request, user auth.User, auto bool) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	clusterName, err := figureClusterName(getClusterHint(params))
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	designatedKey, _ := this.getInstanceKey(params["designatedHost"], params["designatedPort"])
+	// designatedKey may be empty/invalid
+	topologyRecovery, _, err := logic.GracefulMasterTakeover(clusterName, &designatedKey, auto)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error(), Details: topologyRecovery})
+		return
+	}
+	if topologyRecovery == nil || topologyRecovery.SuccessorKey == nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: "graceful-master-takeover: no successor promoted", Details: topologyRecovery})
+		return
+	}
+	Respond(r, &APIResponse{Code: OK, Message: "graceful-master-takeover: successor promoted", Details: topologyRecovery})
+}
+
+// GracefulMasterTakeover gracefully fails over a master, either:
+// - onto its single replica, or
+// - onto a replica indicated by the user
+func (this *HttpAPI) GracefulMasterTakeover(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	this.gracefulMasterTakeover(params, r, req, user, false)
+}
+
+// GracefulMasterTakeoverAuto gracefully fails over a master onto a replica of orchestrator's choosing
+func (this *HttpAPI) GracefulMasterTakeoverAuto(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	this.gracefulMasterTakeover(params, r, req, user, true)
+}
+
+// checkForceFailoverGuardrails applies ForceFailoverRequiresConfirmation. The `confirm` query param is
+// expected to echo the cluster name or alias; `break-glass=true` skips all other checks.
+func checkForceFailoverGuardrails(operation string, clusterName string, req *http.Request) error {
+	if !config.Config.ForceFailoverRequiresConfirmation {
+		return nil
+	}
+	breakGlass := req.URL.Query().Get("break-glass") == "true"
+	return logic.CheckForceFailoverGuardrails(operation, clusterName, strings.TrimSpace(req.URL.Query().Get("confirm")), breakGlass)
 }
 
-// ReplicationAnalysis retuens list of issues
-func (this *HttpAPI) ReplicationAnalysis(params martini.Params, r render.Render, req *http.Request) {
-	this.replicationAnalysis("", nil, params, r, req)
+func respondGuardrailsError(r render.Render, err error) {
+	if guardrailsError, ok := err.(*logic.DangerousOperationError); ok {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error(), Details: guardrailsError})
+		return
+	}
+	Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 }
 
-// ReplicationAnalysis retuens list of issues
-func (this *HttpAPI) ReplicationAnalysisForCluster(params martini.Params, r render.Render, req *http.Request) {
-	clusterName := params["clusterName"]
+// ForceMasterFailover fails over a master (even if there's no particular problem with the master)
+func (this *HttpAPI) ForceMasterFailover(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	clusterName, err := figureClusterName(getClusterHint(params))
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	if err := checkForceFailoverGuardrails("force-master-failover", clusterName, req); err != nil {
+		respondGuardrailsError(r, err)
+		return
+	}
+	topologyRecovery, err := logic.ForceMasterFailover(clusterName)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	if topologyRecovery.SuccessorKey != nil {
+		Respond(r, &APIResponse{Code: OK, Message: "Master failed over", Details: topologyRecovery})
+	} else {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Master not failed over", Details: topologyRecovery})
+	}
+}
 
-	var err error
-	if clusterName, err = inst.DeduceClusterName(params["clusterName"]); err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot get analysis: %+v", err)})
+// ForceMasterTakeover fails over a master (even if there's no particular problem with the master)
+func (this *HttpAPI) ForceMasterTakeover(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
-	if clusterName == "" {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot get cluster name: %+v", params["clusterName"])})
+	clusterName, err := figureClusterName(getClusterHint(params))
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	this.replicationAnalysis(clusterName, nil, params, r, req)
+	designatedKey, _ := this.getInstanceKey(params["designatedHost"], params["designatedPort"])
+	designatedInstance, _, err := inst.ReadInstance(&designatedKey)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	if designatedInstance == nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Instance not found"})
+		return
+	}
+	if err := checkForceFailoverGuardrails("force-master-takeover", clusterName, req); err != nil {
+		respondGuardrailsError(r, err)
+		return
+	}
+
+	topologyRecovery, err := logic.ForceMasterTakeover(clusterName, designatedInstance)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	if topologyRecovery.SuccessorKey != nil {
+		Respond(r, &APIResponse{Code: OK, Message: "Master failed over", Details: topologyRecovery})
+	} else {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Master not failed over", Details: topologyRecovery})
+	}
 }
 
-// ReplicationAnalysis retuens list of issues
-func (this *HttpAPI) ReplicationAnalysisForKey(params martini.Params, r render.Render, req *http.Request) {
+// Registers promotion preference for given instance
+func (this *HttpAPI) RegisterCandidate(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot get analysis: %+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	if !instanceKey.IsValid() {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Cannot get analysis: invalid key %+v", instanceKey)})
+	promotionRule, err := inst.ParseCandidatePromotionRule(params["promotionRule"])
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	this.replicationAnalysis("", &instanceKey, params, r, req)
+
+	candidate := inst.NewCandidateDatabaseInstance(&instanceKey, promotionRule).WithCurrentTime()
+
+	if orcraft.IsRaftEnabled() {
+		_, err = orcraft.PublishCommand("register-candidate", candidate)
+	} else {
+		err = inst.RegisterCandidateInstance(candidate)
+	}
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+
+	Respond(r, &APIResponse{Code: OK, Message: "Registered candidate", Details: instanceKey})
 }
 
-// RecoverLite attempts recovery on a given instance, without executing external processes
-func (this *HttpAPI) RecoverLite(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	params["skipProcesses"] = "true"
-	this.Recover(params, r, req, user)
+// AutomatedRecoveryFilters retuens list of clusters which are configured with automated recovery
+func (this *HttpAPI) AutomatedRecoveryFilters(params martini.Params, r render.Render, req *http.Request) {
+	automatedRecoveryMap := make(map[string]interface{})
+	automatedRecoveryMap["RecoverMasterClusterFilters"] = config.Config.RecoverMasterClusterFilters
+	automatedRecoveryMap["RecoverIntermediateMasterClusterFilters"] = config.Config.RecoverIntermediateMasterClusterFilters
+	automatedRecoveryMap["RecoveryIgnoreHostnameFilters"] = config.Config.RecoveryIgnoreHostnameFilters
+	automatedRecoveryMap["EffectiveRecoverMasterClusterFilters"] = inst.GetRecoveryFilterPatterns(inst.MasterRecoveryFilterType)
+	automatedRecoveryMap["EffectiveRecoverIntermediateMasterClusterFilters"] = inst.GetRecoveryFilterPatterns(inst.IntermediateMasterRecoveryFilterType)
+
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Automated recovery configuration details"), Details: automatedRecoveryMap})
 }
 
-// Recover attempts recovery on a given instance
-func (this *HttpAPI) Recover(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// RecoveryFilters lists recovery filters added at runtime, in addition to configured filters
+func (this *HttpAPI) RecoveryFilters(params martini.Params, r render.Render, req *http.Request) {
+	filters, err := inst.ReadRecoveryFilters()
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, filters)
+}
+
+// AddRecoveryFilter adds a master or intermediate-master recovery filter, in addition to configured filters.
+// The pattern is given as the "pattern" query param, as it may contain any character, "/" included.
+func (this *HttpAPI) AddRecoveryFilter(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	filter := inst.NewRecoveryFilter(params["filterType"], strings.TrimSpace(req.URL.Query().Get("pattern")), getUserId(req, user))
+
+	var err error
+	if orcraft.IsRaftEnabled() {
+		_, err = orcraft.PublishCommand("add-recovery-filter", filter)
+	} else {
+		err = inst.AddRecoveryFilter(filter)
+	}
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Recovery filter added: %s %s", filter.FilterType, filter.Pattern), Details: filter})
+}
+
+// RemoveRecoveryFilter removes a recovery filter previously added via AddRecoveryFilter
+func (this *HttpAPI) RemoveRecoveryFilter(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
 	}
+	filter := inst.NewRecoveryFilter(params["filterType"], strings.TrimSpace(req.URL.Query().Get("pattern")), getUserId(req, user))
+
+	var err error
+	if orcraft.IsRaftEnabled() {
+		_, err = orcraft.PublishCommand("remove-recovery-filter", filter)
+	} else {
+		err = inst.RemoveRecoveryFilter(filter)
+	}
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Recovery filter removed: %s %s", filter.FilterType, filter.Pattern), Details: filter})
+}
+
+// AuditFailureDetection provides list of topology_failure_detection entries
+func (this *HttpAPI) AuditFailureDetection(params martini.Params, r render.Render, req *http.Request) {
+
+	var audits []*logic.TopologyRecovery
+	var err error
+
+	if detectionId, derr := strconv.ParseInt(params["id"], 10, 0); derr == nil && detectionId > 0 {
+		audits, err = logic.ReadFailureDetection(detectionId)
+	} else if params["sinceId"] != "" {
+		sinceId, derr := strconv.ParseInt(params["sinceId"], 10, 0)
+		if derr != nil || sinceId < 0 {
+			Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Invalid detection id: %s", params["sinceId"])})
+			return
+		}
+		audits, err = logic.ReadFailureDetectionsSince(params["clusterAlias"], sinceId)
+	} else {
+		page, derr := strconv.Atoi(params["page"])
+		if derr != nil || page < 0 {
+			page = 0
+		}
+		audits, err = logic.ReadRecentFailureDetections(params["clusterAlias"], page)
+	}
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, audits)
+}
+
+// AuditRecoverySteps returns audited steps of a given recovery
+func (this *HttpAPI) AuditRecoverySteps(params martini.Params, r render.Render, req *http.Request) {
+	recoveryUID := params["uid"]
+	audits, err := logic.ReadTopologyRecoverySteps(recoveryUID)
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, audits)
+}
+
+// ReadReplicationAnalysisChangelog lists instances and their analysis changelog
+func (this *HttpAPI) ReadReplicationAnalysisChangelog(params martini.Params, r render.Render, req *http.Request) {
+	changelogs, err := inst.ReadReplicationAnalysisChangelog()
+
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, changelogs)
+}
+
+// ClusterEvents provides a time ordered stream of audit entries, failure detections, recoveries and
+// analysis changes on a cluster. Use the `cursor` query param, as returned by a previous call, to resume.
+func (this *HttpAPI) ClusterEvents(params martini.Params, r render.Render, req *http.Request) {
+	clusterName, err := figureClusterName(getClusterHint(params))
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	limit := 0
+	if limitParam := req.URL.Query().Get("limit"); limitParam != "" {
+		if limit, err = strconv.Atoi(limitParam); err != nil {
+			Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Invalid limit: %s", limitParam)})
+			return
+		}
+	}
+	events, err := logic.ReadClusterEvents(clusterName, req.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	RespondReport(r, req, events, nil)
+}
+
+// ClusterMetricsSummary provides an overview of a cluster's health, lag, recoveries and discovery latencies,
+// over the last `window` seconds (query param), intended for dashboards
+func (this *HttpAPI) ClusterMetricsSummary(params martini.Params, r render.Render, req *http.Request) {
+	clusterName, err := figureClusterName(getClusterHint(params))
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	windowSeconds := 0
+	if windowParam := req.URL.Query().Get("window"); windowParam != "" {
+		if windowSeconds, err = strconv.Atoi(windowParam); err != nil {
+			Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Invalid window: %s", windowParam)})
+			return
+		}
+	}
+	summary, err := logic.ReadClusterMetricsSummary(clusterName, windowSeconds)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	RespondReport(r, req, summary, nil)
+}
+
+// InstanceHistory provides a time ordered history of an instance: audit entries, failure detections,
+// recoveries, promotions, maintenance, downtime and failed discoveries
+func (this *HttpAPI) InstanceHistory(params martini.Params, r render.Render, req *http.Request) {
 	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	var candidateKey *inst.InstanceKey
-	if key, err := this.getInstanceKey(params["candidateHost"], params["candidatePort"]); err == nil {
-		candidateKey = &key
+	limit := 0
+	if limitParam := req.URL.Query().Get("limit"); limitParam != "" {
+		if limit, err = strconv.Atoi(limitParam); err != nil {
+			Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Invalid limit: %s", limitParam)})
+			return
+		}
+	}
+	entries, err := logic.ReadInstanceHistory(&instanceKey, limit)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
 	}
 
-	skipProcesses := (req.URL.Query().Get("skipProcesses") == "true") || (params["skipProcesses"] == "true")
-	recoveryAttempted, promotedInstanceKey, err := logic.CheckAndRecover(&instanceKey, candidateKey, skipProcesses)
+	RespondReport(r, req, entries, nil)
+}
+
+// FlappingInstances lists instances whose replication analysis changes frequently
+func (this *HttpAPI) FlappingInstances(params martini.Params, r render.Render, req *http.Request) {
+	flapping, err := inst.ReadFlappingInstances()
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: err.Error(), Details: instanceKey})
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
 		return
 	}
-	if !recoveryAttempted {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Recovery not attempted", Details: instanceKey})
+
+	r.JSON(http.StatusOK, flapping)
+}
+
+// IsFlapping checks whether given instance's replication analysis is flapping
+func (this *HttpAPI) IsFlapping(params martini.Params, r render.Render, req *http.Request) {
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	if promotedInstanceKey == nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Recovery attempted but no instance promoted", Details: instanceKey})
+	isFlapping, err := inst.IsInstanceFlapping(&instanceKey)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Recovery executed on %+v", instanceKey), Details: *promotedInstanceKey})
+	responseDetails := ""
+	if isFlapping {
+		responseDetails = instanceKey.StringCode()
+	}
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("%+v", isFlapping), Details: responseDetails})
 }
 
-// GracefulMasterTakeover gracefully fails over a master onto its single replica.
-func (this *HttpAPI) gracefulMasterTakeover(params martini.Params, r render.Render, req *http.Request, user auth.User, auto bool) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
-		return
-	}
+// FailoverReadiness reports whether a cluster is safe for automated master failover, and why not
+func (this *HttpAPI) FailoverReadiness(params martini.Params, r render.Render, req *http.Request) {
 	clusterName, err := figureClusterName(getClusterHint(params))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	designatedKey, _ := this.getInstanceKey(params["designatedHost"], params["designatedPort"])
-	// designatedKey may be empty/invalid
-	topologyRecovery, _, err := logic.GracefulMasterTakeover(clusterName, &designatedKey, auto)
+	readiness, err := logic.AssessFailoverReadiness(clusterName, req.URL.Query().Get("refresh") == "true")
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: err.Error(), Details: topologyRecovery})
-		return
-	}
-	if topologyRecovery == nil || topologyRecovery.SuccessorKey == nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: "graceful-master-takeover: no successor promoted", Details: topologyRecovery})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	Respond(r, &APIResponse{Code: OK, Message: "graceful-master-takeover: successor promoted", Details: topologyRecovery})
-}
-
-// GracefulMasterTakeover gracefully fails over a master, either:
-// - onto its single replica, or
-// - onto a replica indicated by the user
-func (this *HttpAPI) GracefulMasterTakeover(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	this.gracefulMasterTakeover(params, r, req, user, false)
-}
 
-// GracefulMasterTakeoverAuto gracefully fails over a master onto a replica of orchestrator's choosing
-func (this *HttpAPI) GracefulMasterTakeoverAuto(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	this.gracefulMasterTakeover(params, r, req, user, true)
+	RespondReport(r, req, readiness, readiness.Instances)
 }
 
-// ForceMasterFailover fails over a master (even if there's no particular problem with the master)
-func (this *HttpAPI) ForceMasterFailover(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
-		return
-	}
+// PromotionCandidates ranks the replicas of a cluster's master as candidates for promotion, with the reasons for their ranking
+func (this *HttpAPI) PromotionCandidates(params martini.Params, r render.Render, req *http.Request) {
 	clusterName, err := figureClusterName(getClusterHint(params))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	topologyRecovery, err := logic.ForceMasterFailover(clusterName)
+	constraints := logic.PromotionCandidateConstraints{
+		DataCenter:            strings.TrimSpace(req.URL.Query().Get("dc")),
+		RequireSameDataCenter: req.URL.Query().Get("same-dc") == "true",
+	}
+	if maxLagParam := strings.TrimSpace(req.URL.Query().Get("max-lag")); maxLagParam != "" {
+		if constraints.MaxLagSeconds, err = strconv.ParseInt(maxLagParam, 10, 0); err != nil {
+			Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Invalid max-lag: %s", maxLagParam)})
+			return
+		}
+	}
+	candidates, err := logic.SuggestPromotionCandidates(clusterName, constraints)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	if topologyRecovery.SuccessorKey != nil {
-		Respond(r, &APIResponse{Code: OK, Message: "Master failed over", Details: topologyRecovery})
-	} else {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Master not failed over", Details: topologyRecovery})
-	}
+	RespondReport(r, req, candidates, nil)
 }
 
-// ForceMasterTakeover fails over a master (even if there's no particular problem with the master)
-func (this *HttpAPI) ForceMasterTakeover(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+// ApplyDataCenterPromotionRules registers the replicas of a cluster's master as prefer candidates when in the
+// master's data center, and prefer_not otherwise
+func (this *HttpAPI) ApplyDataCenterPromotionRules(params martini.Params, r render.Render, req *http.Request, user auth.User) {
 	if !isAuthorizedForAction(req, user) {
 		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
 		return
@@ -3324,119 +5037,94 @@ func (this *HttpAPI) ForceMasterTakeover(params martini.Params, r render.Render,
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	designatedKey, _ := this.getInstanceKey(params["designatedHost"], params["designatedPort"])
-	designatedInstance, _, err := inst.ReadInstance(&designatedKey)
+	rules, err := logic.ApplyDataCenterPromotionRules(clusterName)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	if designatedInstance == nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Instance not found"})
-		return
-	}
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Applied data center promotion rules on %s", clusterName), Details: rules})
+}
 
-	topologyRecovery, err := logic.ForceMasterTakeover(clusterName, designatedInstance)
+// FailoverRehearsal simulates a DeadMaster on a cluster and reports the would-be promotion and recovery steps,
+// without changing anything
+func (this *HttpAPI) FailoverRehearsal(params martini.Params, r render.Render, req *http.Request) {
+	clusterName, err := figureClusterName(getClusterHint(params))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	if topologyRecovery.SuccessorKey != nil {
-		Respond(r, &APIResponse{Code: OK, Message: "Master failed over", Details: topologyRecovery})
-	} else {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Master not failed over", Details: topologyRecovery})
+	rehearsal, err := logic.RehearseDeadMasterFailover(clusterName)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
 	}
+
+	RespondReport(r, req, rehearsal, nil)
 }
 
-// Registers promotion preference for given instance
-func (this *HttpAPI) RegisterCandidate(params martini.Params, r render.Render, req *http.Request, user auth.User) {
-	if !isAuthorizedForAction(req, user) {
-		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
-		return
+// getTreeBalanceThresholds reads tree balance thresholds from request params; unset params stand for the defaults
+func getTreeBalanceThresholds(req *http.Request) (thresholds logic.TreeBalanceThresholds, err error) {
+	if maxDepth := strings.TrimSpace(req.URL.Query().Get("max-depth")); maxDepth != "" {
+		if thresholds.MaxDepth, err = strconv.Atoi(maxDepth); err != nil {
+			return thresholds, fmt.Errorf("Invalid max-depth: %s", maxDepth)
+		}
 	}
-	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+	if maxShare := strings.TrimSpace(req.URL.Query().Get("max-share")); maxShare != "" {
+		if thresholds.MaxReplicaShare, err = strconv.ParseFloat(maxShare, 64); err != nil || thresholds.MaxReplicaShare > 1 {
+			return thresholds, fmt.Errorf("Invalid max-share: %s. Expected a fraction such as 0.8", maxShare)
+		}
+	}
+	if minReplicas := strings.TrimSpace(req.URL.Query().Get("min-replicas")); minReplicas != "" {
+		if thresholds.MinReplicas, err = strconv.Atoi(minReplicas); err != nil {
+			return thresholds, fmt.Errorf("Invalid min-replicas: %s", minReplicas)
+		}
+	}
+	return thresholds, nil
+}
+
+// TreeBalance reports on the depth and fan-out of a cluster's replication tree, with suggested relocations to rebalance it
+func (this *HttpAPI) TreeBalance(params martini.Params, r render.Render, req *http.Request) {
+	clusterName, err := figureClusterName(getClusterHint(params))
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-	promotionRule, err := inst.ParseCandidatePromotionRule(params["promotionRule"])
+	thresholds, err := getTreeBalanceThresholds(req)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	candidate := inst.NewCandidateDatabaseInstance(&instanceKey, promotionRule).WithCurrentTime()
-
-	if orcraft.IsRaftEnabled() {
-		_, err = orcraft.PublishCommand("register-candidate", candidate)
-	} else {
-		err = inst.RegisterCandidateInstance(candidate)
-	}
-
+	report, err := logic.AnalyzeTreeBalance(clusterName, thresholds)
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
 
-	Respond(r, &APIResponse{Code: OK, Message: "Registered candidate", Details: instanceKey})
-}
-
-// AutomatedRecoveryFilters retuens list of clusters which are configured with automated recovery
-func (this *HttpAPI) AutomatedRecoveryFilters(params martini.Params, r render.Render, req *http.Request) {
-	automatedRecoveryMap := make(map[string]interface{})
-	automatedRecoveryMap["RecoverMasterClusterFilters"] = config.Config.RecoverMasterClusterFilters
-	automatedRecoveryMap["RecoverIntermediateMasterClusterFilters"] = config.Config.RecoverIntermediateMasterClusterFilters
-	automatedRecoveryMap["RecoveryIgnoreHostnameFilters"] = config.Config.RecoveryIgnoreHostnameFilters
-
-	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Automated recovery configuration details"), Details: automatedRecoveryMap})
+	RespondReport(r, req, report, report.IntermediateMasters)
 }
 
-// AuditFailureDetection provides list of topology_failure_detection entries
-func (this *HttpAPI) AuditFailureDetection(params martini.Params, r render.Render, req *http.Request) {
-
-	var audits []*logic.TopologyRecovery
-	var err error
-
-	if detectionId, derr := strconv.ParseInt(params["id"], 10, 0); derr == nil && detectionId > 0 {
-		audits, err = logic.ReadFailureDetection(detectionId)
-	} else {
-		page, derr := strconv.Atoi(params["page"])
-		if derr != nil || page < 0 {
-			page = 0
-		}
-		audits, err = logic.ReadRecentFailureDetections(params["clusterAlias"], page)
+// RebalanceTree applies the relocations suggested by TreeBalance
+func (this *HttpAPI) RebalanceTree(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
 	}
-
+	clusterName, err := figureClusterName(getClusterHint(params))
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	r.JSON(http.StatusOK, audits)
-}
-
-// AuditRecoverySteps returns audited steps of a given recovery
-func (this *HttpAPI) AuditRecoverySteps(params martini.Params, r render.Render, req *http.Request) {
-	recoveryUID := params["uid"]
-	audits, err := logic.ReadTopologyRecoverySteps(recoveryUID)
-
+	thresholds, err := getTreeBalanceThresholds(req)
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
 		return
 	}
-
-	r.JSON(http.StatusOK, audits)
-}
-
-// ReadReplicationAnalysisChangelog lists instances and their analysis changelog
-func (this *HttpAPI) ReadReplicationAnalysisChangelog(params martini.Params, r render.Render, req *http.Request) {
-	changelogs, err := inst.ReadReplicationAnalysisChangelog()
-
+	report, err := logic.RebalanceTree(clusterName, thresholds)
 	if err != nil {
-		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error(), Details: report})
 		return
 	}
-
-	r.JSON(http.StatusOK, changelogs)
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Applied %d relocations on %s", len(report.Relocations), clusterName), Details: report})
 }
 
 // AuditRecovery provides list of topology-recovery entries
@@ -3466,9 +5154,35 @@ func (this *HttpAPI) AuditRecovery(params martini.Params, r render.Render, req *
 	r.JSON(http.StatusOK, audits)
 }
 
+// RecoveryStats aggregates recoveries over a time window, given by the "window" query param (e.g. 30d; default 7d):
+// per cluster counts, success rates, mean time to recover and most frequent analyses
+func (this *HttpAPI) RecoveryStats(params martini.Params, r render.Render, req *http.Request) {
+	window := strings.TrimSpace(req.URL.Query().Get("window"))
+	if window == "" {
+		window = "7d"
+	}
+	windowSeconds, err := util.SimpleTimeToSeconds(window)
+	if err != nil || windowSeconds <= 0 {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Invalid window: %s", window)})
+		return
+	}
+	stats, err := logic.ReadRecoveryStats(windowSeconds)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	RespondReport(r, req, stats, stats.Clusters)
+}
+
 // ActiveClusterRecovery returns recoveries in-progress for a given cluster
 func (this *HttpAPI) ActiveClusterRecovery(params martini.Params, r render.Render, req *http.Request) {
-	recoveries, err := logic.ReadActiveClusterRecovery(params["clusterName"])
+	clusterName, err := getClusterNameParam(params)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+	recoveries, err := logic.ReadActiveClusterRecovery(clusterName)
 
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
@@ -3480,7 +5194,12 @@ func (this *HttpAPI) ActiveClusterRecovery(params martini.Params, r render.Rende
 
 // RecentlyActiveClusterRecovery returns recoveries in-progress for a given cluster
 func (this *HttpAPI) RecentlyActiveClusterRecovery(params martini.Params, r render.Render, req *http.Request) {
-	recoveries, err := logic.ReadRecentlyActiveClusterRecovery(params["clusterName"])
+	clusterName, err := getClusterNameParam(params)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+	recoveries, err := logic.ReadRecentlyActiveClusterRecovery(clusterName)
 
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
@@ -3673,9 +5392,41 @@ func (this *HttpAPI) AcknowledgeAllRecoveries(params martini.Params, r render.Re
 	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Acknowledged all recoveries"), Details: comment})
 }
 
+// AutoAcknowledgeableRecoveries lists unacknowledged completed recoveries which match AutoAcknowledgeRecoveryRules
+func (this *HttpAPI) AutoAcknowledgeableRecoveries(params martini.Params, r render.Render, req *http.Request) {
+	recoveries, err := logic.ReadAutoAcknowledgeableRecoveries()
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	r.JSON(http.StatusOK, recoveries)
+}
+
+// AutoAcknowledgeRecoveries acknowledges completed recoveries which match AutoAcknowledgeRecoveryRules, and lists them
+func (this *HttpAPI) AutoAcknowledgeRecoveries(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+
+	recoveries, err := logic.AutoAcknowledgeRecoveries()
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Acknowledged %d recoveries", len(recoveries)), Details: recoveries})
+}
+
 // BlockedRecoveries reads list of currently blocked recoveries, optionally filtered by cluster name
 func (this *HttpAPI) BlockedRecoveries(params martini.Params, r render.Render, req *http.Request) {
-	blockedRecoveries, err := logic.ReadBlockedRecoveries(params["clusterName"])
+	clusterName, err := getClusterNameParam(params)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+	blockedRecoveries, err := logic.ReadBlockedRecoveries(clusterName)
 
 	if err != nil {
 		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
@@ -3743,6 +5494,91 @@ func (this *HttpAPI) CheckGlobalRecoveries(params martini.Params, r render.Rende
 	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Global recoveries %+v", details), Details: details})
 }
 
+// DisableClusterRecoveries disables automated recoveries on a single cluster, optionally for a given duration
+func (this *HttpAPI) DisableClusterRecoveries(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	clusterName, err := figureClusterName(getClusterHint(params))
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	var durationSeconds int = 0
+	if params["duration"] != "" {
+		durationSeconds, err = util.SimpleTimeToSeconds(params["duration"])
+		if durationSeconds < 0 {
+			err = fmt.Errorf("Duration value must be non-negative. Given value: %d", durationSeconds)
+		}
+		if err != nil {
+			Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+			return
+		}
+	}
+	disable, err := logic.DisableClusterRecoveries(&inst.ClusterRecoveryDisableRequest{
+		ClusterName:     clusterName,
+		Owner:           params["owner"],
+		Reason:          params["reason"],
+		DurationSeconds: uint(durationSeconds),
+	})
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Disabled recoveries on cluster: %+v", clusterName), Details: disable})
+}
+
+// EnableClusterRecoveries enables automated recoveries on a cluster on which they were previously disabled
+func (this *HttpAPI) EnableClusterRecoveries(params martini.Params, r render.Render, req *http.Request, user auth.User) {
+	if !isAuthorizedForAction(req, user) {
+		Respond(r, &APIResponse{Code: ERROR, Message: "Unauthorized"})
+		return
+	}
+	clusterName, err := figureClusterName(getClusterHint(params))
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	enabled, err := logic.EnableClusterRecoveries(clusterName)
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	if !enabled {
+		Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Recoveries were not disabled on cluster: %+v", clusterName), Details: clusterName})
+		return
+	}
+
+	Respond(r, &APIResponse{Code: OK, Message: fmt.Sprintf("Enabled recoveries on cluster: %+v", clusterName), Details: clusterName})
+}
+
+// DisabledClusterRecoveries lists the clusters on which recoveries are currently disabled
+func (this *HttpAPI) DisabledClusterRecoveries(params martini.Params, r render.Render, req *http.Request) {
+	disables, err := inst.ReadClusterRecoveryDisables()
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+
+	r.JSON(http.StatusOK, disables)
+}
+
+// FreezeWindows lists the configured freeze windows, and whether recoveries are disabled by them
+func (this *HttpAPI) FreezeWindows(params martini.Params, r render.Render, req *http.Request) {
+	status, err := logic.ReadFreezeWindowsStatus()
+	if err != nil {
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("%+v", err)})
+		return
+	}
+	message := "Global recoveries not frozen"
+	if status.IsRecoveryFrozen {
+		message = "Global recoveries frozen"
+	}
+	Respond(r, &APIResponse{Code: OK, Message: message, Details: status})
+}
+
 func (this *HttpAPI) getSynonymPath(path string) (synonymPath string) {
 	pathBase := strings.Split(path, "/")[0]
 	if synonym, ok := apiSynonyms[pathBase]; ok {
@@ -3751,187 +5587,267 @@ func (this *HttpAPI) getSynonymPath(path string) (synonymPath string) {
 	return synonymPath
 }
 
-func (this *HttpAPI) registerSingleAPIRequest(m *martini.ClassicMartini, path string, handler martini.Handler, allowProxy bool) {
+func (this *HttpAPI) registerSingleAPIRequest(m *martini.ClassicMartini, path string, handler martini.Handler, allowProxy bool, mutating bool, guards ...martini.Handler) {
 	registeredPaths = append(registeredPaths, path)
+	registerEndpoint(path, handler, allowProxy, mutating, len(guards) > 0)
 	fullPath := fmt.Sprintf("%s/api/%s", this.URLPrefix, path)
 
+	handlers := []martini.Handler{}
 	if allowProxy && config.Config.RaftEnabled {
-		m.Get(fullPath, raftReverseProxy, handler)
-	} else {
-		m.Get(fullPath, handler)
+		handlers = append(handlers, raftLeaderProxy(mutating))
+	}
+	handlers = append(handlers, guards...)
+	handlers = append(handlers, handler)
+	for _, method := range handlerMethods(handler) {
+		switch method {
+		case "GET":
+			m.Get(fullPath, handlers...)
+		case "POST":
+			m.Post(fullPath, handlers...)
+		}
 	}
 }
 
-func (this *HttpAPI) registerAPIRequestInternal(m *martini.ClassicMartini, path string, handler martini.Handler, allowProxy bool) {
-	this.registerSingleAPIRequest(m, path, handler, allowProxy)
+func (this *HttpAPI) registerAPIRequestInternal(m *martini.ClassicMartini, path string, handler martini.Handler, allowProxy bool, mutating bool, guards ...martini.Handler) {
+	this.registerSingleAPIRequest(m, path, handler, allowProxy, mutating, guards...)
 
 	if synonym := this.getSynonymPath(path); synonym != "" {
-		this.registerSingleAPIRequest(m, synonym, handler, allowProxy)
+		this.registerSingleAPIRequest(m, synonym, handler, allowProxy, mutating, guards...)
 	}
 }
 
+// registerAPIRequest registers a request which changes topologies or orchestrator's state. With raft, it is served by the leader.
 func (this *HttpAPI) registerAPIRequest(m *martini.ClassicMartini, path string, handler martini.Handler) {
-	this.registerAPIRequestInternal(m, path, handler, true)
+	this.registerAPIRequestInternal(m, path, handler, true, true)
 }
 
+// registerReadOnlyAPIRequest registers a request which changes nothing
+func (this *HttpAPI) registerReadOnlyAPIRequest(m *martini.ClassicMartini, path string, handler martini.Handler) {
+	this.registerAPIRequestInternal(m, path, handler, true, false)
+}
+
+// registerGuardedAPIRequest registers a request which operates on an instance, and which is subject to instanceOperationGuard
+func (this *HttpAPI) registerGuardedAPIRequest(m *martini.ClassicMartini, path string, handler martini.Handler) {
+	this.registerAPIRequestInternal(m, path, handler, true, true, this.instanceOperationGuard)
+}
+
+// registerRelocationAPIRequest registers a request which relocates an instance, and which is subject to instanceOperationGuard
+// and relocationFlapGuard
+func (this *HttpAPI) registerRelocationAPIRequest(m *martini.ClassicMartini, path string, handler martini.Handler) {
+	this.registerAPIRequestInternal(m, path, handler, true, true, this.instanceOperationGuard, this.relocationFlapGuard)
+}
+
+// registerAPIRequestNoProxy registers a request which changes the state of the node serving it
 func (this *HttpAPI) registerAPIRequestNoProxy(m *martini.ClassicMartini, path string, handler martini.Handler) {
-	this.registerAPIRequestInternal(m, path, handler, false)
+	this.registerAPIRequestInternal(m, path, handler, false, true)
+}
+
+// registerReadOnlyAPIRequestNoProxy registers a request which changes nothing, served by the node it is made to
+func (this *HttpAPI) registerReadOnlyAPIRequestNoProxy(m *martini.ClassicMartini, path string, handler martini.Handler) {
+	this.registerAPIRequestInternal(m, path, handler, false, false)
 }
 
 // RegisterRequests makes for the de-facto list of known API calls
 func (this *HttpAPI) RegisterRequests(m *martini.ClassicMartini) {
 	// Smart relocation:
-	this.registerAPIRequest(m, "relocate/:host/:port/:belowHost/:belowPort", this.RelocateBelow)
-	this.registerAPIRequest(m, "relocate-below/:host/:port/:belowHost/:belowPort", this.RelocateBelow)
-	this.registerAPIRequest(m, "relocate-slaves/:host/:port/:belowHost/:belowPort", this.RelocateReplicas)
-	this.registerAPIRequest(m, "regroup-slaves/:host/:port", this.RegroupReplicas)
+	this.registerRelocationAPIRequest(m, "relocate/:host/:port/:belowHost/:belowPort", this.RelocateBelow)
+	this.registerRelocationAPIRequest(m, "relocate-below/:host/:port/:belowHost/:belowPort", this.RelocateBelow)
+	this.registerGuardedAPIRequest(m, "relocate-slaves/:host/:port/:belowHost/:belowPort", this.RelocateReplicas)
+	this.registerGuardedAPIRequest(m, "bootstrap-cluster/:host/:port", this.BootstrapCluster)
+	this.registerGuardedAPIRequest(m, "regroup-slaves/:host/:port", this.RegroupReplicas)
 
 	// Classic file:pos relocation:
-	this.registerAPIRequest(m, "move-up/:host/:port", this.MoveUp)
-	this.registerAPIRequest(m, "move-up-slaves/:host/:port", this.MoveUpReplicas)
-	this.registerAPIRequest(m, "move-below/:host/:port/:siblingHost/:siblingPort", this.MoveBelow)
-	this.registerAPIRequest(m, "move-equivalent/:host/:port/:belowHost/:belowPort", this.MoveEquivalent)
-	this.registerAPIRequest(m, "repoint/:host/:port/:belowHost/:belowPort", this.Repoint)
-	this.registerAPIRequest(m, "repoint-slaves/:host/:port", this.RepointReplicas)
-	this.registerAPIRequest(m, "make-co-master/:host/:port", this.MakeCoMaster)
-	this.registerAPIRequest(m, "enslave-siblings/:host/:port", this.TakeSiblings)
-	this.registerAPIRequest(m, "enslave-master/:host/:port", this.TakeMaster)
-	this.registerAPIRequest(m, "master-equivalent/:host/:port/:logFile/:logPos", this.MasterEquivalent)
+	this.registerRelocationAPIRequest(m, "move-up/:host/:port", this.MoveUp)
+	this.registerGuardedAPIRequest(m, "move-up-slaves/:host/:port", this.MoveUpReplicas)
+	this.registerRelocationAPIRequest(m, "move-below/:host/:port/:siblingHost/:siblingPort", this.MoveBelow)
+	this.registerRelocationAPIRequest(m, "move-equivalent/:host/:port/:belowHost/:belowPort", this.MoveEquivalent)
+	this.registerRelocationAPIRequest(m, "repoint/:host/:port/:belowHost/:belowPort", this.Repoint)
+	this.registerGuardedAPIRequest(m, "repoint-slaves/:host/:port", this.RepointReplicas)
+	this.registerGuardedAPIRequest(m, "make-co-master/:host/:port", this.MakeCoMaster)
+	this.registerGuardedAPIRequest(m, "enslave-siblings/:host/:port", this.TakeSiblings)
+	this.registerGuardedAPIRequest(m, "enslave-master/:host/:port", this.TakeMaster)
+	this.registerReadOnlyAPIRequest(m, "master-equivalent/:host/:port/:logFile/:logPos", this.MasterEquivalent)
 
 	// Binlog server relocation:
-	this.registerAPIRequest(m, "regroup-slaves-bls/:host/:port", this.RegroupReplicasBinlogServers)
+	this.registerGuardedAPIRequest(m, "regroup-slaves-bls/:host/:port", this.RegroupReplicasBinlogServers)
 
 	// GTID relocation:
-	this.registerAPIRequest(m, "move-below-gtid/:host/:port/:belowHost/:belowPort", this.MoveBelowGTID)
-	this.registerAPIRequest(m, "move-slaves-gtid/:host/:port/:belowHost/:belowPort", this.MoveReplicasGTID)
-	this.registerAPIRequest(m, "regroup-slaves-gtid/:host/:port", this.RegroupReplicasGTID)
+	this.registerRelocationAPIRequest(m, "move-below-gtid/:host/:port/:belowHost/:belowPort", this.MoveBelowGTID)
+	this.registerRelocationAPIRequest(m, "move-to-cluster/:host/:port/:belowHost/:belowPort", this.MoveToCluster)
+	this.registerGuardedAPIRequest(m, "move-slaves-gtid/:host/:port/:belowHost/:belowPort", this.MoveReplicasGTID)
+	this.registerGuardedAPIRequest(m, "regroup-slaves-gtid/:host/:port", this.RegroupReplicasGTID)
 
 	// Pseudo-GTID relocation:
-	this.registerAPIRequest(m, "match/:host/:port/:belowHost/:belowPort", this.MatchBelow)
-	this.registerAPIRequest(m, "match-below/:host/:port/:belowHost/:belowPort", this.MatchBelow)
-	this.registerAPIRequest(m, "match-up/:host/:port", this.MatchUp)
-	this.registerAPIRequest(m, "match-slaves/:host/:port/:belowHost/:belowPort", this.MultiMatchReplicas)
-	this.registerAPIRequest(m, "match-up-slaves/:host/:port", this.MatchUpReplicas)
-	this.registerAPIRequest(m, "regroup-slaves-pgtid/:host/:port", this.RegroupReplicasPseudoGTID)
+	this.registerRelocationAPIRequest(m, "match/:host/:port/:belowHost/:belowPort", this.MatchBelow)
+	this.registerRelocationAPIRequest(m, "match-below/:host/:port/:belowHost/:belowPort", this.MatchBelow)
+	this.registerRelocationAPIRequest(m, "match-up/:host/:port", this.MatchUp)
+	this.registerGuardedAPIRequest(m, "match-slaves/:host/:port/:belowHost/:belowPort", this.MultiMatchReplicas)
+	this.registerGuardedAPIRequest(m, "match-up-slaves/:host/:port", this.MatchUpReplicas)
+	this.registerGuardedAPIRequest(m, "regroup-slaves-pgtid/:host/:port", this.RegroupReplicasPseudoGTID)
 	// Legacy, need to revisit:
-	this.registerAPIRequest(m, "make-master/:host/:port", this.MakeMaster)
-	this.registerAPIRequest(m, "make-local-master/:host/:port", this.MakeLocalMaster)
+	this.registerGuardedAPIRequest(m, "make-master/:host/:port", this.MakeMaster)
+	this.registerGuardedAPIRequest(m, "make-local-master/:host/:port", this.MakeLocalMaster)
 
 	// Replication, general:
-	this.registerAPIRequest(m, "enable-gtid/:host/:port", this.EnableGTID)
-	this.registerAPIRequest(m, "disable-gtid/:host/:port", this.DisableGTID)
-	this.registerAPIRequest(m, "locate-gtid-errant/:host/:port", this.LocateErrantGTID)
-	this.registerAPIRequest(m, "gtid-errant-reset-master/:host/:port", this.ErrantGTIDResetMaster)
-	this.registerAPIRequest(m, "gtid-errant-inject-empty/:host/:port", this.ErrantGTIDInjectEmpty)
-	this.registerAPIRequest(m, "skip-query/:host/:port", this.SkipQuery)
-	this.registerAPIRequest(m, "start-slave/:host/:port", this.StartReplication)
-	this.registerAPIRequest(m, "restart-slave/:host/:port", this.RestartReplication)
-	this.registerAPIRequest(m, "stop-slave/:host/:port", this.StopReplication)
-	this.registerAPIRequest(m, "stop-slave-nice/:host/:port", this.StopReplicationNicely)
-	this.registerAPIRequest(m, "reset-slave/:host/:port", this.ResetReplication)
-	this.registerAPIRequest(m, "detach-slave/:host/:port", this.DetachReplicaMasterHost)
-	this.registerAPIRequest(m, "reattach-slave/:host/:port", this.ReattachReplicaMasterHost)
-	this.registerAPIRequest(m, "detach-slave-master-host/:host/:port", this.DetachReplicaMasterHost)
-	this.registerAPIRequest(m, "reattach-slave-master-host/:host/:port", this.ReattachReplicaMasterHost)
-	this.registerAPIRequest(m, "flush-binary-logs/:host/:port", this.FlushBinaryLogs)
-	this.registerAPIRequest(m, "purge-binary-logs/:host/:port/:logFile", this.PurgeBinaryLogs)
-	this.registerAPIRequest(m, "restart-slave-statements/:host/:port", this.RestartReplicationStatements)
-	this.registerAPIRequest(m, "enable-semi-sync-master/:host/:port", this.EnableSemiSyncMaster)
-	this.registerAPIRequest(m, "disable-semi-sync-master/:host/:port", this.DisableSemiSyncMaster)
-	this.registerAPIRequest(m, "enable-semi-sync-replica/:host/:port", this.EnableSemiSyncReplica)
-	this.registerAPIRequest(m, "disable-semi-sync-replica/:host/:port", this.DisableSemiSyncReplica)
-	this.registerAPIRequest(m, "delay-replication/:host/:port/:seconds", this.DelayReplication)
+	this.registerGuardedAPIRequest(m, "enable-gtid/:host/:port", this.EnableGTID)
+	this.registerGuardedAPIRequest(m, "disable-gtid/:host/:port", this.DisableGTID)
+	this.registerReadOnlyAPIRequest(m, "locate-gtid-errant/:host/:port", this.LocateErrantGTID)
+	this.registerReadOnlyAPIRequest(m, "locate-cluster-gtid-errant/:clusterHint", this.ScanClusterErrantGTID)
+	this.registerGuardedAPIRequest(m, "gtid-errant-reset-master/:host/:port", this.ErrantGTIDResetMaster)
+	this.registerGuardedAPIRequest(m, "gtid-errant-inject-empty/:host/:port", this.ErrantGTIDInjectEmpty)
+	this.registerGuardedAPIRequest(m, "skip-query/:host/:port", this.SkipQuery)
+	this.registerGuardedAPIRequest(m, "start-slave/:host/:port", this.StartReplication)
+	this.registerGuardedAPIRequest(m, "restart-slave/:host/:port", this.RestartReplication)
+	this.registerGuardedAPIRequest(m, "stop-slave/:host/:port", this.StopReplication)
+	this.registerGuardedAPIRequest(m, "stop-slave-nice/:host/:port", this.StopReplicationNicely)
+	this.registerGuardedAPIRequest(m, "reset-slave/:host/:port", this.ResetReplication)
+	this.registerGuardedAPIRequest(m, "detach-slave/:host/:port", this.DetachReplicaMasterHost)
+	this.registerGuardedAPIRequest(m, "reattach-slave/:host/:port", this.ReattachReplicaMasterHost)
+	this.registerGuardedAPIRequest(m, "detach-slave-master-host/:host/:port", this.DetachReplicaMasterHost)
+	this.registerGuardedAPIRequest(m, "reattach-slave-master-host/:host/:port", this.ReattachReplicaMasterHost)
+	this.registerGuardedAPIRequest(m, "flush-binary-logs/:host/:port", this.FlushBinaryLogs)
+	this.registerGuardedAPIRequest(m, "purge-binary-logs/:host/:port/:logFile", this.PurgeBinaryLogs)
+	this.registerGuardedAPIRequest(m, "restart-slave-statements/:host/:port", this.RestartReplicationStatements)
+	this.registerGuardedAPIRequest(m, "enable-semi-sync-master/:host/:port", this.EnableSemiSyncMaster)
+	this.registerGuardedAPIRequest(m, "disable-semi-sync-master/:host/:port", this.DisableSemiSyncMaster)
+	this.registerGuardedAPIRequest(m, "enable-semi-sync-replica/:host/:port", this.EnableSemiSyncReplica)
+	this.registerGuardedAPIRequest(m, "disable-semi-sync-replica/:host/:port", this.DisableSemiSyncReplica)
+	this.registerGuardedAPIRequest(m, "delay-replication/:host/:port/:seconds", this.DelayReplication)
 
 	// Replication information:
-	this.registerAPIRequest(m, "can-replicate-from/:host/:port/:belowHost/:belowPort", this.CanReplicateFrom)
-	this.registerAPIRequest(m, "can-replicate-from-gtid/:host/:port/:belowHost/:belowPort", this.CanReplicateFromGTID)
+	this.registerReadOnlyAPIRequest(m, "can-replicate-from/:host/:port/:belowHost/:belowPort", this.CanReplicateFrom)
+	this.registerReadOnlyAPIRequest(m, "can-replicate-from-gtid/:host/:port/:belowHost/:belowPort", this.CanReplicateFromGTID)
+	this.registerReadOnlyAPIRequest(m, "relocation-advice/:host/:port/:belowHost/:belowPort", this.RelocationAdvice)
+	this.registerReadOnlyAPIRequest(m, "pseudo-gtid-status/:clusterHint", this.PseudoGTIDStatus)
 
 	// Instance:
-	this.registerAPIRequest(m, "set-read-only/:host/:port", this.SetReadOnly)
-	this.registerAPIRequest(m, "set-writeable/:host/:port", this.SetWriteable)
-	this.registerAPIRequest(m, "kill-query/:host/:port/:process", this.KillQuery)
+	this.registerGuardedAPIRequest(m, "set-read-only/:host/:port", this.SetReadOnly)
+	this.registerGuardedAPIRequest(m, "set-writeable/:host/:port", this.SetWriteable)
+	this.registerGuardedAPIRequest(m, "kill-query/:host/:port/:process", this.KillQuery)
 
 	// Binary logs:
-	this.registerAPIRequest(m, "last-pseudo-gtid/:host/:port", this.LastPseudoGTID)
+	this.registerReadOnlyAPIRequest(m, "last-pseudo-gtid/:host/:port", this.LastPseudoGTID)
 
 	// Pools:
 	this.registerAPIRequest(m, "submit-pool-instances/:pool", this.SubmitPoolInstances)
-	this.registerAPIRequest(m, "cluster-pool-instances/:clusterName", this.ReadClusterPoolInstancesMap)
-	this.registerAPIRequest(m, "cluster-pool-instances/:clusterName/:pool", this.ReadClusterPoolInstancesMap)
-	this.registerAPIRequest(m, "heuristic-cluster-pool-instances/:clusterName", this.GetHeuristicClusterPoolInstances)
-	this.registerAPIRequest(m, "heuristic-cluster-pool-instances/:clusterName/:pool", this.GetHeuristicClusterPoolInstances)
-	this.registerAPIRequest(m, "heuristic-cluster-pool-lag/:clusterName", this.GetHeuristicClusterPoolInstancesLag)
-	this.registerAPIRequest(m, "heuristic-cluster-pool-lag/:clusterName/:pool", this.GetHeuristicClusterPoolInstancesLag)
+	this.registerReadOnlyAPIRequest(m, "cluster-pool-instances/:clusterName", this.ReadClusterPoolInstancesMap)
+	this.registerReadOnlyAPIRequest(m, "cluster-pool-instances/:clusterName/:pool", this.ReadClusterPoolInstancesMap)
+	this.registerReadOnlyAPIRequest(m, "heuristic-cluster-pool-instances/:clusterName", this.GetHeuristicClusterPoolInstances)
+	this.registerReadOnlyAPIRequest(m, "heuristic-cluster-pool-instances/:clusterName/:pool", this.GetHeuristicClusterPoolInstances)
+	this.registerReadOnlyAPIRequest(m, "heuristic-cluster-pool-lag/:clusterName", this.GetHeuristicClusterPoolInstancesLag)
+	this.registerReadOnlyAPIRequest(m, "heuristic-cluster-pool-lag/:clusterName/:pool", this.GetHeuristicClusterPoolInstancesLag)
 
 	// Information:
-	this.registerAPIRequest(m, "search/:searchString", this.Search)
-	this.registerAPIRequest(m, "search", this.Search)
+	this.registerReadOnlyAPIRequest(m, "search/:searchString", this.Search)
+	this.registerReadOnlyAPIRequest(m, "search", this.Search)
 
 	// Cluster
-	this.registerAPIRequest(m, "cluster/:clusterHint", this.Cluster)
-	this.registerAPIRequest(m, "cluster/alias/:clusterAlias", this.ClusterByAlias)
-	this.registerAPIRequest(m, "cluster/instance/:host/:port", this.ClusterByInstance)
-	this.registerAPIRequest(m, "cluster-info/:clusterHint", this.ClusterInfo)
-	this.registerAPIRequest(m, "cluster-info/alias/:clusterAlias", this.ClusterInfoByAlias)
-	this.registerAPIRequest(m, "cluster-osc-slaves/:clusterHint", this.ClusterOSCReplicas)
+	this.registerReadOnlyAPIRequest(m, "cluster/:clusterHint", this.Cluster)
+	this.registerReadOnlyAPIRequest(m, "cluster-lag/:clusterHint", this.ClusterLag)
+	this.registerReadOnlyAPIRequest(m, "cluster/alias/:clusterAlias", this.ClusterByAlias)
+	this.registerReadOnlyAPIRequest(m, "cluster/instance/:host/:port", this.ClusterByInstance)
+	this.registerReadOnlyAPIRequest(m, "cluster-info/:clusterHint", this.ClusterInfo)
+	this.registerReadOnlyAPIRequest(m, "cluster-info/alias/:clusterAlias", this.ClusterInfoByAlias)
+	this.registerReadOnlyAPIRequest(m, "resolve-cluster-hint/:clusterHint", this.ResolveClusterHint)
+	this.registerReadOnlyAPIRequest(m, "rolling-operation-order/:clusterHint", this.RollingOperationOrder)
+	this.registerReadOnlyAPIRequest(m, "cluster-osc-slaves/:clusterHint", this.ClusterOSCReplicas)
 	this.registerAPIRequest(m, "set-cluster-alias/:clusterName", this.SetClusterAliasManualOverride)
-	this.registerAPIRequest(m, "clusters", this.Clusters)
-	this.registerAPIRequest(m, "clusters-info", this.ClustersInfo)
-
-	this.registerAPIRequest(m, "masters", this.Masters)
-	this.registerAPIRequest(m, "master/:clusterHint", this.ClusterMaster)
-	this.registerAPIRequest(m, "instance-replicas/:host/:port", this.InstanceReplicas)
-	this.registerAPIRequest(m, "all-instances", this.AllInstances)
-	this.registerAPIRequest(m, "downtimed", this.Downtimed)
-	this.registerAPIRequest(m, "downtimed/:clusterHint", this.Downtimed)
-	this.registerAPIRequest(m, "topology/:clusterHint", this.AsciiTopology)
-	this.registerAPIRequest(m, "topology/:host/:port", this.AsciiTopology)
-	this.registerAPIRequest(m, "topology-tabulated/:clusterHint", this.AsciiTopologyTabulated)
-	this.registerAPIRequest(m, "topology-tabulated/:host/:port", this.AsciiTopologyTabulated)
-	this.registerAPIRequest(m, "topology-tags/:clusterHint", this.AsciiTopologyTags)
-	this.registerAPIRequest(m, "topology-tags/:host/:port", this.AsciiTopologyTags)
+	this.registerAPIRequest(m, "forget-cluster-alias/:clusterHint", this.ForgetClusterAlias)
+	this.registerReadOnlyAPIRequest(m, "cluster-aliases", this.ClusterAliases)
+	this.registerReadOnlyAPIRequest(m, "cluster-domains", this.ClusterDomains)
+	this.registerAPIRequest(m, "set-cluster-domain/:clusterHint/:domain", this.SetClusterDomain)
+	this.registerAPIRequest(m, "forget-cluster-domain/:clusterHint", this.ForgetClusterDomain)
+	this.registerAPIRequest(m, "reconcile-cluster-domains", this.ReconcileClusterDomains)
+	this.registerReadOnlyAPIRequest(m, "clusters", this.Clusters)
+	this.registerReadOnlyAPIRequest(m, "clusters-info", this.ClustersInfo)
+
+	this.registerReadOnlyAPIRequest(m, "masters", this.Masters)
+	this.registerReadOnlyAPIRequest(m, "master/:clusterHint", this.ClusterMaster)
+	this.registerReadOnlyAPIRequest(m, "cluster-heads/:clusterHint", this.ClusterHeads)
+	this.registerReadOnlyAPIRequest(m, "instance-replicas/:host/:port", this.InstanceReplicas)
+	this.registerReadOnlyAPIRequest(m, "all-instances", this.AllInstances)
+	this.registerReadOnlyAPIRequest(m, "inventory", this.Inventory)
+	this.registerReadOnlyAPIRequest(m, "downtimed", this.Downtimed)
+	this.registerReadOnlyAPIRequest(m, "downtimed/:clusterHint", this.Downtimed)
+	this.registerReadOnlyAPIRequest(m, "topology/:clusterHint", this.AsciiTopology)
+	this.registerReadOnlyAPIRequest(m, "topology/:host/:port", this.AsciiTopology)
+	this.registerReadOnlyAPIRequest(m, "topology-tabulated/:clusterHint", this.AsciiTopologyTabulated)
+	this.registerReadOnlyAPIRequest(m, "topology-tabulated/:host/:port", this.AsciiTopologyTabulated)
+	this.registerReadOnlyAPIRequest(m, "topology-tags/:clusterHint", this.AsciiTopologyTags)
+	this.registerReadOnlyAPIRequest(m, "topology-tags/:host/:port", this.AsciiTopologyTags)
 	this.registerAPIRequest(m, "snapshot-topologies", this.SnapshotTopologies)
 
 	// Key-value:
 	this.registerAPIRequest(m, "submit-masters-to-kv-stores", this.SubmitMastersToKvStores)
 	this.registerAPIRequest(m, "submit-masters-to-kv-stores/:clusterHint", this.SubmitMastersToKvStores)
+	this.registerAPIRequest(m, "controller-heartbeat/:controller/:holder", this.HeartbeatController)
+	this.registerAPIRequest(m, "controller-heartbeat/:controller/:holder/:duration", this.HeartbeatController)
+	this.registerAPIRequest(m, "controller-release/:controller/:holder", this.ReleaseController)
+	this.registerReadOnlyAPIRequest(m, "active-controller/:controller", this.ActiveController)
 
 	// Tags:
-	this.registerAPIRequest(m, "tagged", this.Tagged)
-	this.registerAPIRequest(m, "tags/:host/:port", this.Tags)
-	this.registerAPIRequest(m, "tag-value/:host/:port", this.TagValue)
-	this.registerAPIRequest(m, "tag-value/:host/:port/:tagName", this.TagValue)
+	this.registerReadOnlyAPIRequest(m, "tagged", this.Tagged)
+	this.registerReadOnlyAPIRequest(m, "tags/:host/:port", this.Tags)
+	this.registerReadOnlyAPIRequest(m, "tag-value/:host/:port", this.TagValue)
+	this.registerReadOnlyAPIRequest(m, "tag-value/:host/:port/:tagName", this.TagValue)
 	this.registerAPIRequest(m, "tag/:host/:port", this.Tag)
 	this.registerAPIRequest(m, "tag/:host/:port/:tagName/:tagValue", this.Tag)
 	this.registerAPIRequest(m, "untag/:host/:port", this.Untag)
 	this.registerAPIRequest(m, "untag/:host/:port/:tagName", this.Untag)
+	this.registerReadOnlyAPIRequest(m, "cluster-flags/:clusterHint", this.ClusterFlags)
+	this.registerAPIRequest(m, "set-cluster-flag/:clusterHint/:flagName/:flagValue", this.SetClusterFlag)
+	this.registerAPIRequest(m, "clear-cluster-flag/:clusterHint/:flagName", this.ClearClusterFlag)
 	this.registerAPIRequest(m, "untag-all", this.UntagAll)
 	this.registerAPIRequest(m, "untag-all/:tagName/:tagValue", this.UntagAll)
+	this.registerReadOnlyAPIRequest(m, "instance-metadata/:host/:port", this.InstanceMetadata)
+	this.registerAPIRequest(m, "set-instance-metadata/:host/:port", this.SetInstanceMetadata)
 
 	// Instance management:
-	this.registerAPIRequest(m, "instance/:host/:port", this.Instance)
+	this.registerReadOnlyAPIRequest(m, "instance/:host/:port", this.Instance)
+	this.registerReadOnlyAPIRequest(m, "instance-diagnosis/:host/:port", this.InstanceDiagnosis)
 	this.registerAPIRequest(m, "discover/:host/:port", this.Discover)
 	this.registerAPIRequest(m, "async-discover/:host/:port", this.AsyncDiscover)
+	this.registerAPIRequest(m, "discover-with-priority/:host/:port", this.DiscoverWithPriority)
+	this.registerAPIRequest(m, "discover-cluster-with-priority/:clusterHint", this.DiscoverClusterWithPriority)
+	this.registerAPIRequest(m, "discover-instances-with-priority", this.DiscoverInstancesWithPriority)
 	this.registerAPIRequest(m, "refresh/:host/:port", this.Refresh)
 	this.registerAPIRequest(m, "forget/:host/:port", this.Forget)
 	this.registerAPIRequest(m, "forget-cluster/:clusterHint", this.ForgetCluster)
 	this.registerAPIRequest(m, "begin-maintenance/:host/:port/:owner/:reason", this.BeginMaintenance)
 	this.registerAPIRequest(m, "end-maintenance/:host/:port", this.EndMaintenanceByInstanceKey)
-	this.registerAPIRequest(m, "in-maintenance/:host/:port", this.InMaintenance)
+	this.registerReadOnlyAPIRequest(m, "in-maintenance/:host/:port", this.InMaintenance)
 	this.registerAPIRequest(m, "end-maintenance/:maintenanceKey", this.EndMaintenance)
-	this.registerAPIRequest(m, "maintenance", this.Maintenance)
+	this.registerReadOnlyAPIRequest(m, "maintenance", this.Maintenance)
+	this.registerReadOnlyAPIRequest(m, "maintenance/owner/:owner", this.MaintenanceByOwner)
+	this.registerAPIRequest(m, "take-over-maintenance/:host/:port/:owner", this.TakeOverMaintenance)
+	this.registerReadOnlyAPIRequest(m, "expiring-maintenance/:duration", this.ExpiringMaintenance)
+	this.registerAPIRequest(m, "acquire-cluster-lock/:clusterHint/:owner/:reason", this.AcquireClusterLock)
+	this.registerAPIRequest(m, "acquire-cluster-lock/:clusterHint/:owner/:reason/:duration", this.AcquireClusterLock)
+	this.registerAPIRequest(m, "release-cluster-lock/:clusterHint/:owner", this.ReleaseClusterLock)
+	this.registerReadOnlyAPIRequest(m, "cluster-lock/:clusterHint", this.ClusterLock)
+	this.registerReadOnlyAPIRequest(m, "cluster-locks", this.ClusterLocks)
+	this.registerAPIRequest(m, "acquire-cluster-fence/:clusterHint/:owner", this.AcquireClusterFence)
+	this.registerAPIRequest(m, "acquire-cluster-fence/:clusterHint/:owner/:duration", this.AcquireClusterFence)
+	this.registerAPIRequest(m, "renew-cluster-fence/:clusterHint/:owner/:token", this.RenewClusterFence)
+	this.registerAPIRequest(m, "renew-cluster-fence/:clusterHint/:owner/:token/:duration", this.RenewClusterFence)
+	this.registerAPIRequest(m, "release-cluster-fence/:clusterHint/:owner/:token", this.ReleaseClusterFence)
+	this.registerReadOnlyAPIRequest(m, "cluster-fence/:clusterHint", this.ClusterFence)
 	this.registerAPIRequest(m, "begin-downtime/:host/:port/:owner/:reason", this.BeginDowntime)
 	this.registerAPIRequest(m, "begin-downtime/:host/:port/:owner/:reason/:duration", this.BeginDowntime)
 	this.registerAPIRequest(m, "end-downtime/:host/:port", this.EndDowntime)
+	this.registerAPIRequest(m, "extend-downtime/:host/:port/:duration", this.ExtendDowntime)
+	this.registerReadOnlyAPIRequest(m, "expiring-downtime/:duration", this.ExpiringDowntime)
 
 	// Recovery:
-	this.registerAPIRequest(m, "replication-analysis", this.ReplicationAnalysis)
-	this.registerAPIRequest(m, "replication-analysis/:clusterName", this.ReplicationAnalysisForCluster)
-	this.registerAPIRequest(m, "replication-analysis/instance/:host/:port", this.ReplicationAnalysisForKey)
+	this.registerReadOnlyAPIRequest(m, "replication-analysis", this.ReplicationAnalysis)
+	this.registerReadOnlyAPIRequest(m, "replication-analysis/:clusterName", this.ReplicationAnalysisForCluster)
+	this.registerReadOnlyAPIRequest(m, "replication-analysis/instance/:host/:port", this.ReplicationAnalysisForKey)
 	this.registerAPIRequest(m, "recover/:host/:port", this.Recover)
 	this.registerAPIRequest(m, "recover/:host/:port/:candidateHost/:candidatePort", this.Recover)
 	this.registerAPIRequest(m, "recover-lite/:host/:port", this.RecoverLite)
 	this.registerAPIRequest(m, "recover-lite/:host/:port/:candidateHost/:candidatePort", this.RecoverLite)
+	this.registerAPIRequest(m, "recover-auto/:host/:port", this.RecoverAuto)
+	this.registerAPIRequest(m, "recover-auto/:host/:port/:candidateHost/:candidatePort", this.RecoverAuto)
 	this.registerAPIRequest(m, "graceful-master-takeover/:host/:port", this.GracefulMasterTakeover)
 	this.registerAPIRequest(m, "graceful-master-takeover/:host/:port/:designatedHost/:designatedPort", this.GracefulMasterTakeover)
 	this.registerAPIRequest(m, "graceful-master-takeover/:clusterHint", this.GracefulMasterTakeover)
@@ -3945,94 +5861,130 @@ func (this *HttpAPI) RegisterRequests(m *martini.ClassicMartini) {
 	this.registerAPIRequest(m, "force-master-takeover/:clusterHint/:designatedHost/:designatedPort", this.ForceMasterTakeover)
 	this.registerAPIRequest(m, "force-master-takeover/:host/:port/:designatedHost/:designatedPort", this.ForceMasterTakeover)
 	this.registerAPIRequest(m, "register-candidate/:host/:port/:promotionRule", this.RegisterCandidate)
-	this.registerAPIRequest(m, "automated-recovery-filters", this.AutomatedRecoveryFilters)
-	this.registerAPIRequest(m, "audit-failure-detection", this.AuditFailureDetection)
-	this.registerAPIRequest(m, "audit-failure-detection/:page", this.AuditFailureDetection)
-	this.registerAPIRequest(m, "audit-failure-detection/id/:id", this.AuditFailureDetection)
-	this.registerAPIRequest(m, "audit-failure-detection/alias/:clusterAlias", this.AuditFailureDetection)
-	this.registerAPIRequest(m, "audit-failure-detection/alias/:clusterAlias/:page", this.AuditFailureDetection)
-	this.registerAPIRequest(m, "replication-analysis-changelog", this.ReadReplicationAnalysisChangelog)
-	this.registerAPIRequest(m, "audit-recovery", this.AuditRecovery)
-	this.registerAPIRequest(m, "audit-recovery/:page", this.AuditRecovery)
-	this.registerAPIRequest(m, "audit-recovery/id/:id", this.AuditRecovery)
-	this.registerAPIRequest(m, "audit-recovery/uid/:uid", this.AuditRecovery)
-	this.registerAPIRequest(m, "audit-recovery/cluster/:clusterName", this.AuditRecovery)
-	this.registerAPIRequest(m, "audit-recovery/cluster/:clusterName/:page", this.AuditRecovery)
-	this.registerAPIRequest(m, "audit-recovery/alias/:clusterAlias", this.AuditRecovery)
-	this.registerAPIRequest(m, "audit-recovery/alias/:clusterAlias/:page", this.AuditRecovery)
-	this.registerAPIRequest(m, "audit-recovery-steps/:uid", this.AuditRecoverySteps)
-	this.registerAPIRequest(m, "active-cluster-recovery/:clusterName", this.ActiveClusterRecovery)
-	this.registerAPIRequest(m, "recently-active-cluster-recovery/:clusterName", this.RecentlyActiveClusterRecovery)
-	this.registerAPIRequest(m, "recently-active-instance-recovery/:host/:port", this.RecentlyActiveInstanceRecovery)
+	this.registerReadOnlyAPIRequest(m, "automated-recovery-filters", this.AutomatedRecoveryFilters)
+	this.registerReadOnlyAPIRequest(m, "recovery-filters", this.RecoveryFilters)
+	this.registerAPIRequest(m, "add-recovery-filter/:filterType", this.AddRecoveryFilter)
+	this.registerAPIRequest(m, "remove-recovery-filter/:filterType", this.RemoveRecoveryFilter)
+	this.registerReadOnlyAPIRequest(m, "audit-failure-detection", this.AuditFailureDetection)
+	this.registerReadOnlyAPIRequest(m, "audit-failure-detection/:page", this.AuditFailureDetection)
+	this.registerReadOnlyAPIRequest(m, "audit-failure-detection/id/:id", this.AuditFailureDetection)
+	this.registerReadOnlyAPIRequest(m, "audit-failure-detection/alias/:clusterAlias", this.AuditFailureDetection)
+	this.registerReadOnlyAPIRequest(m, "audit-failure-detection/alias/:clusterAlias/:page", this.AuditFailureDetection)
+	this.registerReadOnlyAPIRequest(m, "audit-failure-detection/since/:sinceId", this.AuditFailureDetection)
+	this.registerReadOnlyAPIRequest(m, "audit-failure-detection/alias/:clusterAlias/since/:sinceId", this.AuditFailureDetection)
+	this.registerReadOnlyAPIRequest(m, "replication-analysis-changelog", this.ReadReplicationAnalysisChangelog)
+	this.registerReadOnlyAPIRequest(m, "cluster-events/:clusterHint", this.ClusterEvents)
+	this.registerReadOnlyAPIRequest(m, "cluster-metrics-summary/:clusterHint", this.ClusterMetricsSummary)
+	this.registerReadOnlyAPIRequest(m, "instance-history/:host/:port", this.InstanceHistory)
+	this.registerReadOnlyAPIRequest(m, "flapping-instances", this.FlappingInstances)
+	this.registerReadOnlyAPIRequest(m, "is-flapping/:host/:port", this.IsFlapping)
+	this.registerReadOnlyAPIRequest(m, "failover-readiness/:clusterHint", this.FailoverReadiness)
+	this.registerReadOnlyAPIRequest(m, "promotion-candidates/:clusterHint", this.PromotionCandidates)
+	this.registerAPIRequest(m, "apply-data-center-promotion-rules/:clusterHint", this.ApplyDataCenterPromotionRules)
+	this.registerReadOnlyAPIRequest(m, "failover-rehearsal/:clusterHint", this.FailoverRehearsal)
+	this.registerReadOnlyAPIRequest(m, "tree-balance/:clusterHint", this.TreeBalance)
+	this.registerAPIRequest(m, "rebalance-tree/:clusterHint", this.RebalanceTree)
+	this.registerReadOnlyAPIRequest(m, "audit-recovery", this.AuditRecovery)
+	this.registerReadOnlyAPIRequest(m, "audit-recovery/:page", this.AuditRecovery)
+	this.registerReadOnlyAPIRequest(m, "audit-recovery/id/:id", this.AuditRecovery)
+	this.registerReadOnlyAPIRequest(m, "audit-recovery/uid/:uid", this.AuditRecovery)
+	this.registerReadOnlyAPIRequest(m, "audit-recovery/cluster/:clusterName", this.AuditRecovery)
+	this.registerReadOnlyAPIRequest(m, "audit-recovery/cluster/:clusterName/:page", this.AuditRecovery)
+	this.registerReadOnlyAPIRequest(m, "audit-recovery/alias/:clusterAlias", this.AuditRecovery)
+	this.registerReadOnlyAPIRequest(m, "audit-recovery/alias/:clusterAlias/:page", this.AuditRecovery)
+	this.registerReadOnlyAPIRequest(m, "audit-recovery-steps/:uid", this.AuditRecoverySteps)
+	this.registerReadOnlyAPIRequest(m, "recovery-stats", this.RecoveryStats)
+	this.registerReadOnlyAPIRequest(m, "active-cluster-recovery/:clusterName", this.ActiveClusterRecovery)
+	this.registerReadOnlyAPIRequest(m, "recently-active-cluster-recovery/:clusterName", this.RecentlyActiveClusterRecovery)
+	this.registerReadOnlyAPIRequest(m, "recently-active-instance-recovery/:host/:port", this.RecentlyActiveInstanceRecovery)
 	this.registerAPIRequest(m, "ack-recovery/cluster/:clusterHint", this.AcknowledgeClusterRecoveries)
 	this.registerAPIRequest(m, "ack-recovery/cluster/alias/:clusterAlias", this.AcknowledgeClusterRecoveries)
 	this.registerAPIRequest(m, "ack-recovery/instance/:host/:port", this.AcknowledgeInstanceRecoveries)
 	this.registerAPIRequest(m, "ack-recovery/:recoveryId", this.AcknowledgeRecovery)
 	this.registerAPIRequest(m, "ack-recovery/uid/:uid", this.AcknowledgeRecovery)
 	this.registerAPIRequest(m, "ack-all-recoveries", this.AcknowledgeAllRecoveries)
-	this.registerAPIRequest(m, "blocked-recoveries", this.BlockedRecoveries)
-	this.registerAPIRequest(m, "blocked-recoveries/cluster/:clusterName", this.BlockedRecoveries)
+	this.registerReadOnlyAPIRequest(m, "auto-acknowledgeable-recoveries", this.AutoAcknowledgeableRecoveries)
+	this.registerAPIRequest(m, "auto-acknowledge-recoveries", this.AutoAcknowledgeRecoveries)
+	this.registerReadOnlyAPIRequest(m, "blocked-recoveries", this.BlockedRecoveries)
+	this.registerReadOnlyAPIRequest(m, "blocked-recoveries/cluster/:clusterName", this.BlockedRecoveries)
 	this.registerAPIRequest(m, "disable-global-recoveries", this.DisableGlobalRecoveries)
 	this.registerAPIRequest(m, "enable-global-recoveries", this.EnableGlobalRecoveries)
-	this.registerAPIRequest(m, "check-global-recoveries", this.CheckGlobalRecoveries)
+	this.registerReadOnlyAPIRequest(m, "check-global-recoveries", this.CheckGlobalRecoveries)
+	this.registerAPIRequest(m, "disable-cluster-recoveries/:clusterHint/:owner/:reason", this.DisableClusterRecoveries)
+	this.registerAPIRequest(m, "disable-cluster-recoveries/:clusterHint/:owner/:reason/:duration", this.DisableClusterRecoveries)
+	this.registerAPIRequest(m, "enable-cluster-recoveries/:clusterHint", this.EnableClusterRecoveries)
+	this.registerReadOnlyAPIRequest(m, "disabled-cluster-recoveries", this.DisabledClusterRecoveries)
+	this.registerReadOnlyAPIRequest(m, "freeze-windows", this.FreezeWindows)
 
 	// General
-	this.registerAPIRequest(m, "problems", this.Problems)
-	this.registerAPIRequest(m, "problems/:clusterName", this.Problems)
-	this.registerAPIRequest(m, "audit", this.Audit)
-	this.registerAPIRequest(m, "audit/:page", this.Audit)
-	this.registerAPIRequest(m, "audit/instance/:host/:port", this.Audit)
-	this.registerAPIRequest(m, "audit/instance/:host/:port/:page", this.Audit)
-	this.registerAPIRequest(m, "resolve/:host/:port", this.Resolve)
+	this.registerReadOnlyAPIRequest(m, "problems", this.Problems)
+	this.registerReadOnlyAPIRequest(m, "problems/:clusterName", this.Problems)
+	this.registerReadOnlyAPIRequest(m, "audit", this.Audit)
+	this.registerReadOnlyAPIRequest(m, "audit/:page", this.Audit)
+	this.registerReadOnlyAPIRequest(m, "audit/instance/:host/:port", this.Audit)
+	this.registerReadOnlyAPIRequest(m, "audit/instance/:host/:port/:page", this.Audit)
+	this.registerReadOnlyAPIRequest(m, "resolve/:host/:port", this.Resolve)
 
 	// Meta, no proxy
-	this.registerAPIRequestNoProxy(m, "headers", this.Headers)
-	this.registerAPIRequestNoProxy(m, "health", this.Health)
-	this.registerAPIRequestNoProxy(m, "lb-check", this.LBCheck)
-	this.registerAPIRequestNoProxy(m, "_ping", this.LBCheck)
-	this.registerAPIRequestNoProxy(m, "leader-check", this.LeaderCheck)
-	this.registerAPIRequestNoProxy(m, "leader-check/:errorStatusCode", this.LeaderCheck)
+	this.registerReadOnlyAPIRequestNoProxy(m, "headers", this.Headers)
+	this.registerReadOnlyAPIRequestNoProxy(m, "api-schema", this.APISchema)
+	this.registerReadOnlyAPIRequestNoProxy(m, "api-endpoints", this.APIEndpoints)
+	this.registerReadOnlyAPIRequestNoProxy(m, "health", this.Health)
+	this.registerReadOnlyAPIRequestNoProxy(m, "lb-check", this.LBCheck)
+	this.registerReadOnlyAPIRequestNoProxy(m, "_ping", this.LBCheck)
+	this.registerReadOnlyAPIRequestNoProxy(m, "leader-check", this.LeaderCheck)
+	this.registerReadOnlyAPIRequestNoProxy(m, "leader-check/:errorStatusCode", this.LeaderCheck)
 	this.registerAPIRequestNoProxy(m, "grab-election", this.GrabElection)
 	this.registerAPIRequest(m, "raft-add-peer/:addr", this.RaftAddPeer)       // delegated to the raft leader
 	this.registerAPIRequest(m, "raft-remove-peer/:addr", this.RaftRemovePeer) // delegated to the raft leader
 	this.registerAPIRequestNoProxy(m, "raft-yield/:node", this.RaftYield)
 	this.registerAPIRequestNoProxy(m, "raft-yield-hint/:hint", this.RaftYieldHint)
-	this.registerAPIRequestNoProxy(m, "raft-peers", this.RaftPeers)
-	this.registerAPIRequestNoProxy(m, "raft-state", this.RaftState)
-	this.registerAPIRequestNoProxy(m, "raft-leader", this.RaftLeader)
-	this.registerAPIRequestNoProxy(m, "raft-health", this.RaftHealth)
-	this.registerAPIRequestNoProxy(m, "raft-status", this.RaftStatus)
+	this.registerReadOnlyAPIRequestNoProxy(m, "raft-peers", this.RaftPeers)
+	this.registerReadOnlyAPIRequestNoProxy(m, "raft-state", this.RaftState)
+	this.registerReadOnlyAPIRequestNoProxy(m, "raft-leader", this.RaftLeader)
+	this.registerReadOnlyAPIRequestNoProxy(m, "raft-health", this.RaftHealth)
+	this.registerReadOnlyAPIRequestNoProxy(m, "raft-status", this.RaftStatus)
+	this.registerReadOnlyAPIRequest(m, "pre-upgrade-check", this.PreUpgradeCheck) // delegated to the raft leader
 	this.registerAPIRequestNoProxy(m, "raft-snapshot", this.RaftSnapshot)
 	this.registerAPIRequestNoProxy(m, "raft-follower-health-report/:authenticationToken/:raftBind/:raftAdvertise", this.RaftFollowerHealthReport)
 	this.registerAPIRequestNoProxy(m, "reload-configuration", this.ReloadConfiguration)
-	this.registerAPIRequestNoProxy(m, "hostname-resolve-cache", this.HostnameResolveCache)
+	this.registerAPIRequestNoProxy(m, "reload-configuration-diff", this.ReloadConfigurationDiff)
+	this.registerReadOnlyAPIRequestNoProxy(m, "runtime-config", this.RuntimeConfig)
+	this.registerAPIRequestNoProxy(m, "flush-instance-write-buffer", this.FlushInstanceWriteBuffer)
+	this.registerAPIRequest(m, "purge-backend-history/:duration", this.PurgeBackendHistory)
+	this.registerReadOnlyAPIRequestNoProxy(m, "hostname-resolve-cache", this.HostnameResolveCache)
 	this.registerAPIRequestNoProxy(m, "reset-hostname-resolve-cache", this.ResetHostnameResolveCache)
 	// Meta
-	this.registerAPIRequest(m, "routed-leader-check", this.LeaderCheck)
+	this.registerReadOnlyAPIRequest(m, "routed-leader-check", this.LeaderCheck)
 	this.registerAPIRequest(m, "reelect", this.Reelect)
 	this.registerAPIRequest(m, "reload-cluster-alias", this.ReloadClusterAlias)
 	this.registerAPIRequest(m, "deregister-hostname-unresolve/:host/:port", this.DeregisterHostnameUnresolve)
 	this.registerAPIRequest(m, "register-hostname-unresolve/:host/:port/:virtualname", this.RegisterHostnameUnresolve)
 
 	// Bulk access to information
-	this.registerAPIRequest(m, "bulk-instances", this.BulkInstances)
-	this.registerAPIRequest(m, "bulk-promotion-rules", this.BulkPromotionRules)
+	this.registerReadOnlyAPIRequest(m, "bulk-instances", this.BulkInstances)
+	this.registerReadOnlyAPIRequest(m, "bulk-promotion-rules", this.BulkPromotionRules)
+	this.registerReadOnlyAPIRequest(m, "export-promotion-rules", this.ExportPromotionRules)
+	this.registerReadOnlyAPIRequest(m, "diff-promotion-rules", this.DiffPromotionRules)
+	this.registerAPIRequest(m, "apply-promotion-rules", this.ApplyPromotionRules)
 
 	// Monitoring
-	this.registerAPIRequest(m, "discovery-metrics-raw/:seconds", this.DiscoveryMetricsRaw)
-	this.registerAPIRequest(m, "discovery-metrics-aggregated/:seconds", this.DiscoveryMetricsAggregated)
-	this.registerAPIRequest(m, "discovery-queue-metrics-raw/:seconds", this.DiscoveryQueueMetricsRaw)
-	this.registerAPIRequest(m, "discovery-queue-metrics-aggregated/:seconds", this.DiscoveryQueueMetricsAggregated)
-	this.registerAPIRequest(m, "discovery-queue-metrics-raw/:queue/:seconds", this.DiscoveryQueueMetricsRaw2)
-	this.registerAPIRequest(m, "discovery-queue-metrics-aggregated/:queue/:seconds", this.DiscoveryQueueMetricsAggregated2)
-	this.registerAPIRequest(m, "backend-query-metrics-raw/:seconds", this.BackendQueryMetricsRaw)
-	this.registerAPIRequest(m, "backend-query-metrics-aggregated/:seconds", this.BackendQueryMetricsAggregated)
-	this.registerAPIRequest(m, "write-buffer-metrics-raw/:seconds", this.WriteBufferMetricsRaw)
-	this.registerAPIRequest(m, "write-buffer-metrics-aggregated/:seconds", this.WriteBufferMetricsAggregated)
+	this.registerReadOnlyAPIRequest(m, "discovery-metrics-raw/:seconds", this.DiscoveryMetricsRaw)
+	this.registerReadOnlyAPIRequest(m, "discovery-metrics-aggregated/:seconds", this.DiscoveryMetricsAggregated)
+	this.registerReadOnlyAPIRequestNoProxy(m, "discovery-metrics-rollup", this.DiscoveryMetricsRollup)
+	this.registerReadOnlyAPIRequest(m, "discovery-queue-metrics-raw/:seconds", this.DiscoveryQueueMetricsRaw)
+	this.registerReadOnlyAPIRequest(m, "discovery-queue-metrics-aggregated/:seconds", this.DiscoveryQueueMetricsAggregated)
+	this.registerReadOnlyAPIRequest(m, "discovery-queue-metrics-raw/:queue/:seconds", this.DiscoveryQueueMetricsRaw2)
+	this.registerReadOnlyAPIRequest(m, "discovery-queue-metrics-aggregated/:queue/:seconds", this.DiscoveryQueueMetricsAggregated2)
+	this.registerReadOnlyAPIRequest(m, "discovery-queues", this.DiscoveryQueues)
+	this.registerReadOnlyAPIRequest(m, "backend-query-metrics-raw/:seconds", this.BackendQueryMetricsRaw)
+	this.registerReadOnlyAPIRequest(m, "backend-query-metrics-aggregated/:seconds", this.BackendQueryMetricsAggregated)
+	this.registerReadOnlyAPIRequest(m, "write-buffer-metrics-raw/:seconds", this.WriteBufferMetricsRaw)
+	this.registerReadOnlyAPIRequest(m, "write-buffer-metrics-aggregated/:seconds", this.WriteBufferMetricsAggregated)
 
 	// Agents
-	this.registerAPIRequest(m, "agents", this.Agents)
-	this.registerAPIRequest(m, "agent/:host", this.Agent)
+	this.registerReadOnlyAPIRequest(m, "agents", this.Agents)
+	this.registerReadOnlyAPIRequest(m, "agent/:host", this.Agent)
 	this.registerAPIRequest(m, "agent-umount/:host", this.AgentUnmount)
 	this.registerAPIRequest(m, "agent-mount/:host", this.AgentMountLV)
 	this.registerAPIRequest(m, "agent-create-snapshot/:host", this.AgentCreateSnapshot)
@@ -4040,17 +5992,17 @@ func (this *HttpAPI) RegisterRequests(m *martini.ClassicMartini) {
 	this.registerAPIRequest(m, "agent-mysql-stop/:host", this.AgentMySQLStop)
 	this.registerAPIRequest(m, "agent-mysql-start/:host", this.AgentMySQLStart)
 	this.registerAPIRequest(m, "agent-seed/:targetHost/:sourceHost", this.AgentSeed)
-	this.registerAPIRequest(m, "agent-active-seeds/:host", this.AgentActiveSeeds)
-	this.registerAPIRequest(m, "agent-recent-seeds/:host", this.AgentRecentSeeds)
-	this.registerAPIRequest(m, "agent-seed-details/:seedId", this.AgentSeedDetails)
-	this.registerAPIRequest(m, "agent-seed-states/:seedId", this.AgentSeedStates)
+	this.registerReadOnlyAPIRequest(m, "agent-active-seeds/:host", this.AgentActiveSeeds)
+	this.registerReadOnlyAPIRequest(m, "agent-recent-seeds/:host", this.AgentRecentSeeds)
+	this.registerReadOnlyAPIRequest(m, "agent-seed-details/:seedId", this.AgentSeedDetails)
+	this.registerReadOnlyAPIRequest(m, "agent-seed-states/:seedId", this.AgentSeedStates)
 	this.registerAPIRequest(m, "agent-abort-seed/:seedId", this.AbortSeed)
 	this.registerAPIRequest(m, "agent-custom-command/:host/:command", this.AgentCustomCommand)
-	this.registerAPIRequest(m, "seeds", this.Seeds)
+	this.registerReadOnlyAPIRequest(m, "seeds", this.Seeds)
 
 	// Configurable status check endpoint
 	if config.Config.StatusEndpoint == config.DefaultStatusAPIEndpoint {
-		this.registerAPIRequestNoProxy(m, "status", this.StatusCheck)
+		this.registerReadOnlyAPIRequestNoProxy(m, "status", this.StatusCheck)
 	} else {
 		m.Get(config.Config.StatusEndpoint, this.StatusCheck)
 	}
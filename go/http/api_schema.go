@@ -0,0 +1,205 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package http
+
+import (
+	"encoding/json"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/go-martini/martini"
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/inst"
+	"github.com/openark/orchestrator/go/logic"
+	"github.com/openark/orchestrator/go/process"
+)
+
+// APISchema describes the API endpoints supported by this server, and the top level JSON fields of the main
+// API response types, so that clients are able to detect mismatches between their own expectations and this server
+type APISchema struct {
+	AppVersion string
+	Endpoints  []string
+	Types      map[string][]string
+}
+
+// apiSchemaSamples maps response type names to sample values, marshalled as the API would marshal them
+func apiSchemaSamples() map[string]interface{} {
+	return map[string]interface{}{
+		"APIResponse":             &APIResponse{},
+		"Audit":                   &inst.Audit{},
+		"BlockedTopologyRecovery": &logic.BlockedTopologyRecovery{},
+		"ClusterInfo":             &inst.ClusterInfo{},
+		"Instance":                inst.NewInstance(),
+		"Maintenance":             &inst.Maintenance{},
+		"NodeHealth":              &process.NodeHealth{},
+		"ReplicationAnalysis":     &inst.ReplicationAnalysis{},
+		"TopologyRecovery":        &logic.TopologyRecovery{},
+	}
+}
+
+// jsonFieldNames returns the sorted top level field names of the JSON encoding of given value
+func jsonFieldNames(sample interface{}) ([]string, error) {
+	bytes, err := json.Marshal(sample)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(bytes, &fields); err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// supportedEndpoints returns the sorted, distinct paths registered on this server, e.g. "takeover-auto/:clusterHint"
+func supportedEndpoints() []string {
+	endpointsMap := make(map[string]bool)
+	for _, path := range registeredPaths {
+		endpointsMap[path] = true
+	}
+	endpoints := []string{}
+	for path := range endpointsMap {
+		endpoints = append(endpoints, path)
+	}
+	sort.Strings(endpoints)
+	return endpoints
+}
+
+// readAPISchema computes the schema of the main API response types
+func readAPISchema() (*APISchema, error) {
+	schema := &APISchema{
+		AppVersion: config.RuntimeCLIFlags.ConfiguredVersion,
+		Endpoints:  supportedEndpoints(),
+		Types:      make(map[string][]string),
+	}
+	for typeName, sample := range apiSchemaSamples() {
+		names, err := jsonFieldNames(sample)
+		if err != nil {
+			return nil, err
+		}
+		schema.Types[typeName] = names
+	}
+	return schema, nil
+}
+
+// APIEndpoint describes a single API endpoint, as input to client code generation
+type APIEndpoint struct {
+	Path     string   // path template, relative to /api, e.g. "relocate/:host/:port/:belowHost/:belowPort"
+	Method   string   // HTTP method; comma separated when more than one, e.g. "GET,POST"
+	Params   []string // path params, in order of appearance in Path
+	Handler  string
+	Result   string // response type: a type listed in APISchema.Types, "[]" prefixed for lists; empty when undocumented
+	Mutating bool   // whether the endpoint changes topologies or orchestrator's state, as marked upon registration
+	Guarded  bool   // whether the endpoint operates on an instance, subject to instance operation guards
+	Proxied  bool   // whether, with raft, non-leader nodes proxy the endpoint to the leader
+}
+
+// registeredEndpoints lists the endpoints registered on this server, in order of registration
+var registeredEndpoints = []APIEndpoint{}
+
+// apiEndpointResults maps handlers to their response types. Handlers not listed respond with an APIResponse
+// or with a type not listed in APISchema.Types.
+var apiEndpointResults = map[string]string{
+	"AuditRecovery":      "[]TopologyRecovery",
+	"Audit":              "[]Audit",
+	"BlockedRecoveries":  "[]BlockedTopologyRecovery",
+	"Cluster":            "[]Instance",
+	"ClusterByAlias":     "[]Instance",
+	"ClusterByInstance":  "[]Instance",
+	"ClusterInfo":        "ClusterInfo",
+	"ClustersInfo":       "[]ClusterInfo",
+	"Instance":           "Instance",
+	"InstanceReplicas":   "[]Instance",
+	"Maintenance":        "[]Maintenance",
+	"MaintenanceByOwner": "[]Maintenance",
+	"Problems":           "[]Instance",
+	"Search":             "[]Instance",
+}
+
+// apiPostHandlers lists handlers which, other than GET, accept POST, taking their input from the request body
+var apiPostHandlers = map[string]bool{
+	"SubmitPoolInstances":           true,
+	"ReconcileClusterDomains":       true,
+	"DiscoverInstancesWithPriority": true,
+	"DiffPromotionRules":            true,
+	"ApplyPromotionRules":           true,
+}
+
+// handlerMethods returns the HTTP methods accepted by given handler
+func handlerMethods(handler martini.Handler) []string {
+	if apiPostHandlers[handlerName(handler)] {
+		return []string{"GET", "POST"}
+	}
+	return []string{"GET"}
+}
+
+// handlerName returns the method name of an API handler, e.g. "RelocateBelow"
+func handlerName(handler martini.Handler) string {
+	name := runtime.FuncForPC(reflect.ValueOf(handler).Pointer()).Name()
+	name = strings.TrimSuffix(name, "-fm")
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// pathParams returns the params of a path template, e.g. ["host", "port"] for "instance/:host/:port"
+func pathParams(path string) []string {
+	params := []string{}
+	for _, token := range strings.Split(path, "/") {
+		if strings.HasPrefix(token, ":") {
+			params = append(params, strings.TrimPrefix(token, ":"))
+		}
+	}
+	return params
+}
+
+func registerEndpoint(path string, handler martini.Handler, allowProxy bool, mutating bool, guarded bool) {
+	name := handlerName(handler)
+	registeredEndpoints = append(registeredEndpoints, APIEndpoint{
+		Path:     path,
+		Method:   strings.Join(handlerMethods(handler), ","),
+		Params:   pathParams(path),
+		Handler:  name,
+		Result:   apiEndpointResults[name],
+		Mutating: mutating,
+		Guarded:  guarded,
+		Proxied:  allowProxy,
+	})
+}
+
+// ReadAPIEndpoints describes all API endpoints, sorted by path. It does not require a running service:
+// endpoints are registered on a throwaway router.
+func ReadAPIEndpoints() []APIEndpoint {
+	if len(registeredEndpoints) == 0 {
+		api := HttpAPI{}
+		api.RegisterRequests(martini.Classic())
+	}
+	endpointsMap := make(map[string]APIEndpoint)
+	for _, endpoint := range registeredEndpoints {
+		endpointsMap[endpoint.Path] = endpoint
+	}
+	endpoints := []APIEndpoint{}
+	for _, endpoint := range endpointsMap {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].Path < endpoints[j].Path })
+	return endpoints
+}
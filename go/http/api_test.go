@@ -1,14 +1,22 @@
 package http
 
 import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-martini/martini"
 
 	"github.com/openark/golib/log"
 	test "github.com/openark/golib/tests"
 	"github.com/openark/orchestrator/go/config"
+	orcutil "github.com/openark/orchestrator/go/util"
 )
 
 func init() {
@@ -53,3 +61,185 @@ func TestKnownPaths(t *testing.T) {
 		test.S(t).ExpectTrue(pathsMap[synonym])
 	}
 }
+
+func TestReadAPISchema(t *testing.T) {
+	schema, err := readAPISchema()
+	test.S(t).ExpectNil(err)
+
+	contains := func(names []string, name string) bool {
+		for _, n := range names {
+			if n == name {
+				return true
+			}
+		}
+		return false
+	}
+	test.S(t).ExpectTrue(contains(schema.Types["Instance"], "Key"))
+	test.S(t).ExpectTrue(contains(schema.Types["Instance"], "SlaveHosts"))
+	test.S(t).ExpectTrue(contains(schema.Types["ReplicationAnalysis"], "Analysis"))
+	test.S(t).ExpectTrue(contains(schema.Types["APIResponse"], "Code"))
+	test.S(t).ExpectFalse(contains(schema.Types["Instance"], "NoSuchField"))
+
+	api := HttpAPI{}
+	api.RegisterRequests(martini.Classic())
+	schema, err = readAPISchema()
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectTrue(contains(schema.Endpoints, "api-schema"))
+	test.S(t).ExpectTrue(contains(schema.Endpoints, "relocate-replicas/:host/:port/:belowHost/:belowPort"))
+}
+
+func TestRequestId(t *testing.T) {
+	m := martini.Classic()
+	m.Use(RequestId)
+	m.Get("/ping", func() string { return "pong" })
+
+	{
+		req, _ := http.NewRequest("GET", "/ping", nil)
+		req.Header.Set(RequestIdHeader, "my-request-id")
+		recorder := httptest.NewRecorder()
+		m.ServeHTTP(recorder, req)
+		test.S(t).ExpectEquals(recorder.Header().Get(RequestIdHeader), "my-request-id")
+	}
+	{
+		req, _ := http.NewRequest("GET", "/ping", nil)
+		recorder := httptest.NewRecorder()
+		m.ServeHTTP(recorder, req)
+		test.S(t).ExpectEquals(len(recorder.Header().Get(RequestIdHeader)), 32)
+		test.S(t).ExpectEquals(req.Header.Get(RequestIdHeader), recorder.Header().Get(RequestIdHeader))
+	}
+}
+
+func TestEncodeReport(t *testing.T) {
+	type entry struct {
+		Name    string
+		Key     map[string]interface{}
+		Reasons []string
+	}
+	report := []entry{
+		{Name: "a", Key: map[string]interface{}{"Hostname": "h1", "Port": 3306}, Reasons: []string{"x: y"}},
+		{Name: "b,c", Key: map[string]interface{}{}},
+	}
+	{
+		encoded, err := encodeReportCSV(report)
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(string(encoded), "Key.Hostname,Key.Port,Name,Reasons\nh1,3306,a,\"[\"\"x: y\"\"]\"\n,,\"b,c\",\n")
+	}
+	{
+		encoded, err := encodeReportYAML(report)
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(string(encoded), "- Key:\n    Hostname: \"h1\"\n    Port: 3306\n  Name: \"a\"\n  Reasons:\n    - \"x: y\"\n- Key: {}\n  Name: \"b,c\"\n  Reasons: null\n")
+	}
+	{
+		encoded, err := encodeReportJSONLines(report)
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(string(encoded), "{\"Key\":{\"Hostname\":\"h1\",\"Port\":3306},\"Name\":\"a\",\"Reasons\":[\"x: y\"]}\n{\"Key\":{},\"Name\":\"b,c\",\"Reasons\":null}\n")
+	}
+}
+
+func TestRespondJSONLines(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	respondJSONLines(recorder, func(writer *orcutil.JSONLinesWriter) error {
+		writer.Write(map[string]int{"Id": 1})
+		return fmt.Errorf("interrupted")
+	})
+	test.S(t).ExpectEquals(recorder.Code, http.StatusOK)
+	test.S(t).ExpectEquals(recorder.Header().Get("Content-Type"), "application/x-ndjson; charset=UTF-8")
+	test.S(t).ExpectEquals(recorder.Body.String(), "{\"Id\":1}\n{\"Code\":\"ERROR\",\"Message\":\"interrupted\",\"Details\":null}\n")
+}
+
+func TestReadAPIEndpoints(t *testing.T) {
+	endpoints := ReadAPIEndpoints()
+	endpointsMap := make(map[string]APIEndpoint)
+	for _, endpoint := range endpoints {
+		endpointsMap[endpoint.Path] = endpoint
+	}
+	{
+		endpoint, found := endpointsMap["relocate/:host/:port/:belowHost/:belowPort"]
+		test.S(t).ExpectTrue(found)
+		test.S(t).ExpectEquals(endpoint.Handler, "RelocateBelow")
+		test.S(t).ExpectEquals(endpoint.Method, "GET")
+		test.S(t).ExpectEquals(strings.Join(endpoint.Params, ","), "host,port,belowHost,belowPort")
+		test.S(t).ExpectTrue(endpoint.Mutating)
+		test.S(t).ExpectTrue(endpoint.Guarded)
+		test.S(t).ExpectTrue(endpoint.Proxied)
+	}
+	{
+		endpoint, found := endpointsMap["instance/:host/:port"]
+		test.S(t).ExpectTrue(found)
+		test.S(t).ExpectEquals(endpoint.Result, "Instance")
+		test.S(t).ExpectFalse(endpoint.Mutating)
+		test.S(t).ExpectFalse(endpoint.Guarded)
+	}
+	{
+		// mutating, although the handler does not check the user
+		endpoint, found := endpointsMap["tag/:host/:port"]
+		test.S(t).ExpectTrue(found)
+		test.S(t).ExpectTrue(endpoint.Mutating)
+	}
+	{
+		// read-only, although the handler checks the user
+		endpoint, found := endpointsMap["runtime-config"]
+		test.S(t).ExpectTrue(found)
+		test.S(t).ExpectFalse(endpoint.Mutating)
+		test.S(t).ExpectFalse(endpoint.Proxied)
+	}
+	{
+		endpoint, found := endpointsMap["api-endpoints"]
+		test.S(t).ExpectTrue(found)
+		test.S(t).ExpectFalse(endpoint.Proxied)
+	}
+	{
+		endpoint, found := endpointsMap["submit-pool-instances/:pool"]
+		test.S(t).ExpectTrue(found)
+		test.S(t).ExpectEquals(endpoint.Method, "GET,POST")
+	}
+}
+
+// TestClientMutatingAPIPaths checks orchestrator-client's list of mutating API paths against the endpoints registered as mutating
+func TestClientMutatingAPIPaths(t *testing.T) {
+	script, err := ioutil.ReadFile("../../resources/bin/orchestrator-client")
+	test.S(t).ExpectNil(err)
+	listing := regexp.MustCompile(`(?s)\nmutating_api_paths="([^"]*)"`).FindSubmatch(script)
+	test.S(t).ExpectNotNil(listing)
+	clientPaths := strings.Fields(string(listing[1]))
+	sort.Strings(clientPaths)
+
+	pathsMap := make(map[string]bool)
+	for _, endpoint := range ReadAPIEndpoints() {
+		if endpoint.Mutating {
+			pathsMap[strings.SplitN(endpoint.Path, "/", 2)[0]] = true
+		}
+	}
+	serverPaths := []string{}
+	for path := range pathsMap {
+		serverPaths = append(serverPaths, path)
+	}
+	sort.Strings(serverPaths)
+	test.S(t).ExpectEquals(strings.Join(clientPaths, " "), strings.Join(serverPaths, " "))
+}
+
+func TestGetVerifyTimeout(t *testing.T) {
+	timeoutOf := func(query string) (time.Duration, error) {
+		req, _ := http.NewRequest("GET", "/api/relocate/db-1/3306/db-2/3306"+query, nil)
+		return getVerifyTimeout(req)
+	}
+	{
+		timeout, err := timeoutOf("")
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(timeout, time.Duration(0))
+	}
+	{
+		timeout, err := timeoutOf("?verify=true")
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(timeout, defaultVerifyTimeout)
+	}
+	{
+		timeout, err := timeoutOf("?verify=2m")
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(timeout, 2*time.Minute)
+	}
+	{
+		_, err := timeoutOf("?verify=soon")
+		test.S(t).ExpectNotNil(err)
+	}
+}
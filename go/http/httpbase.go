@@ -150,6 +150,9 @@ func getClusterHint(params map[string]string) string {
 	if params["clusterName"] != "" {
 		return params["clusterName"]
 	}
+	if params["clusterAlias"] != "" {
+		return params["clusterAlias"]
+	}
 	if params["host"] != "" && params["port"] != "" {
 		return fmt.Sprintf("%s:%s", params["host"], params["port"])
 	}
@@ -165,6 +168,15 @@ func figureClusterName(hint string) (clusterName string, err error) {
 	return inst.FigureClusterName(hint, instanceKey, nil)
 }
 
+// getClusterNameParam returns the cluster name by the "clusterName" param, which may be either a cluster name or
+// a cluster alias, or an empty cluster name if no such param is given
+func getClusterNameParam(params map[string]string) (clusterName string, err error) {
+	if params["clusterName"] == "" {
+		return "", nil
+	}
+	return inst.DeduceClusterName(params["clusterName"])
+}
+
 // getClusterNameIfExists returns a cluster name by params hint, or an empty cluster name
 // if no hint is given
 func getClusterNameIfExists(params map[string]string) (clusterName string, err error) {
@@ -0,0 +1,131 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-martini/martini"
+	"github.com/martini-contrib/render"
+
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/inst"
+	"github.com/openark/orchestrator/go/logic"
+)
+
+// checkInstanceOperable returns an error when given instance is downtimed or under maintenance
+func checkInstanceOperable(instanceKey *inst.InstanceKey) error {
+	instance, found, err := inst.ReadInstance(instanceKey)
+	if err != nil {
+		return err
+	}
+	if found && instance.IsDowntimed {
+		return fmt.Errorf("%+v is downtimed (owner: %s, reason: %s)", *instanceKey, instance.DowntimeOwner, instance.DowntimeReason)
+	}
+	inMaintenance, err := inst.InMaintenance(instanceKey)
+	if err != nil {
+		return err
+	}
+	if inMaintenance {
+		return fmt.Errorf("%+v is under maintenance", *instanceKey)
+	}
+	return nil
+}
+
+// instanceOperableCheck checks whether an instance may be operated on; tests substitute it
+var instanceOperableCheck = checkInstanceOperable
+
+// guardedInstanceKeyParams returns the (host, port) param name pairs by which a request identifies instances:
+// "host"/"port", as well as any "<role>Host"/"<role>Port", such as "belowHost"/"belowPort" or "siblingHost"/"siblingPort".
+// "host" comes first, the rest are sorted by name.
+func guardedInstanceKeyParams(params martini.Params) [][2]string {
+	hostParams := [][2]string{}
+	roleHostParams := [][2]string{}
+	for name := range params {
+		if name == "host" {
+			hostParams = append(hostParams, [2]string{"host", "port"})
+			continue
+		}
+		if role := strings.TrimSuffix(name, "Host"); role != name && role != "" {
+			roleHostParams = append(roleHostParams, [2]string{name, role + "Port"})
+		}
+	}
+	sort.Slice(roleHostParams, func(i, j int) bool { return roleHostParams[i][0] < roleHostParams[j][0] })
+	return append(hostParams, roleHostParams...)
+}
+
+// instanceOperationGuard precedes API calls which operate on instances. With RefuseOperationsOnDowntimedInstances,
+// it refuses the call when any instance the call's own params identify (see guardedInstanceKeyParams) is downtimed
+// or under maintenance, unless the call is made with override=true.
+func (this *HttpAPI) instanceOperationGuard(params martini.Params, r render.Render, req *http.Request) {
+	if !config.Config.RefuseOperationsOnDowntimedInstances {
+		return
+	}
+	if override, _ := strconv.ParseBool(strings.TrimSpace(req.URL.Query().Get("override"))); override {
+		return
+	}
+	for _, hostParam := range guardedInstanceKeyParams(params) {
+		if params[hostParam[0]] == "" {
+			continue
+		}
+		instanceKey, err := this.getInstanceKey(params[hostParam[0]], params[hostParam[1]])
+		if err != nil {
+			// Let the handler itself report the invalid key
+			continue
+		}
+		if err := instanceOperableCheck(&instanceKey); err != nil {
+			Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Refusing operation: %+v. Use override=true to operate nonetheless", err)})
+			return
+		}
+	}
+}
+
+// isAutomatedRequest tells whether a request identifies itself, via automated=true, as made by automation
+func isAutomatedRequest(req *http.Request) bool {
+	automated, _ := strconv.ParseBool(strings.TrimSpace(req.URL.Query().Get("automated")))
+	return automated
+}
+
+// relocationFlapGuard precedes API calls which relocate the instance identified by "host"/"port". With
+// MaxAutomatedRelocationsPerHour, it refuses automated calls (see isAutomatedRequest) relocating an instance which
+// was relocated that many times within the last hour, responding with the FlapSuppressed result. Successful
+// relocations are recorded, whether automated or not.
+func (this *HttpAPI) relocationFlapGuard(params martini.Params, r render.Render, req *http.Request, c martini.Context, rw http.ResponseWriter) {
+	if config.Config.MaxAutomatedRelocationsPerHour <= 0 {
+		return
+	}
+	instanceKey, err := this.getInstanceKey(params["host"], params["port"])
+	if err != nil {
+		// Let the handler itself report the invalid key
+		return
+	}
+	if isAutomatedRequest(req) {
+		if suppressed := logic.CheckAutomatedRelocation(instanceKey); suppressed != nil {
+			r.Header().Set("Retry-After", fmt.Sprintf("%d", int(suppressed.RetryAfter.Seconds())+1))
+			Respond(r, &APIResponse{Code: ERROR, Message: suppressed.Error(), Details: suppressed})
+			return
+		}
+	}
+	c.Next()
+	if response, ok := rw.(martini.ResponseWriter); ok && response.Status() == http.StatusOK {
+		logic.RecordRelocation(instanceKey)
+	}
+}
@@ -0,0 +1,147 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-martini/martini"
+	"github.com/martini-contrib/render"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+// guardedRecorder serves path through instanceOperationGuard, with instances in downtimed refused as operable
+func guardedRecorder(path string, url string, downtimed ...string) *httptest.ResponseRecorder {
+	originalCheck := instanceOperableCheck
+	defer func() { instanceOperableCheck = originalCheck }()
+	instanceOperableCheck = func(instanceKey *inst.InstanceKey) error {
+		for _, hostname := range downtimed {
+			if instanceKey.Hostname == hostname {
+				return fmt.Errorf("%+v is downtimed", *instanceKey)
+			}
+		}
+		return nil
+	}
+
+	api := HttpAPI{}
+	m := martini.Classic()
+	m.Use(render.Renderer())
+	m.Get("/api/"+path, api.instanceOperationGuard, func(r render.Render) {
+		Respond(r, &APIResponse{Code: OK, Message: "operated"})
+	})
+	req, _ := http.NewRequest("GET", url, nil)
+	recorder := httptest.NewRecorder()
+	m.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestGuardedInstanceKeyParams(t *testing.T) {
+	params := martini.Params{"host": "h", "port": "1", "siblingHost": "s", "siblingPort": "2", "belowHost": "b", "belowPort": "3", "seconds": "10"}
+	test.S(t).ExpectTrue(fmt.Sprintf("%v", guardedInstanceKeyParams(params)) == "[[host port] [belowHost belowPort] [siblingHost siblingPort]]")
+	test.S(t).ExpectEquals(len(guardedInstanceKeyParams(martini.Params{"clusterHint": "c"})), 0)
+}
+
+func TestGuardedEndpointsIdentifyInstances(t *testing.T) {
+	for _, endpoint := range ReadAPIEndpoints() {
+		if !endpoint.Guarded {
+			continue
+		}
+		params := martini.Params{}
+		for _, param := range endpoint.Params {
+			params[param] = param
+		}
+		// A guarded endpoint which identifies no instance would never be refused
+		test.S(t).ExpectTrue(len(guardedInstanceKeyParams(params)) > 0)
+		for _, hostParam := range guardedInstanceKeyParams(params) {
+			test.S(t).ExpectEquals(params[hostParam[1]], hostParam[1])
+		}
+	}
+}
+
+func TestInstanceOperationGuard(t *testing.T) {
+	defer func(refuse bool) { config.Config.RefuseOperationsOnDowntimedInstances = refuse }(config.Config.RefuseOperationsOnDowntimedInstances)
+	path := "relocate/:host/:port/:belowHost/:belowPort"
+
+	config.Config.RefuseOperationsOnDowntimedInstances = false
+	{
+		recorder := guardedRecorder(path, "/api/relocate/10.0.0.1/3306/10.0.0.2/3306", "10.0.0.1")
+		test.S(t).ExpectEquals(recorder.Code, http.StatusOK)
+	}
+
+	config.Config.RefuseOperationsOnDowntimedInstances = true
+	{
+		recorder := guardedRecorder(path, "/api/relocate/10.0.0.1/3306/10.0.0.2/3306")
+		test.S(t).ExpectEquals(recorder.Code, http.StatusOK)
+	}
+	{
+		recorder := guardedRecorder(path, "/api/relocate/10.0.0.1/3306/10.0.0.2/3306", "10.0.0.1")
+		test.S(t).ExpectEquals(recorder.Code, http.StatusInternalServerError)
+		test.S(t).ExpectTrue(strings.Contains(recorder.Body.String(), "Refusing operation: 10.0.0.1:3306 is downtimed. Use override=true"))
+	}
+	{
+		// The destination instance is guarded just as well
+		recorder := guardedRecorder(path, "/api/relocate/10.0.0.1/3306/10.0.0.2/3306", "10.0.0.2")
+		test.S(t).ExpectEquals(recorder.Code, http.StatusInternalServerError)
+	}
+	{
+		recorder := guardedRecorder("move-below/:host/:port/:siblingHost/:siblingPort", "/api/move-below/10.0.0.1/3306/10.0.0.3/3306", "10.0.0.3")
+		test.S(t).ExpectEquals(recorder.Code, http.StatusInternalServerError)
+	}
+	{
+		recorder := guardedRecorder(path, "/api/relocate/10.0.0.1/3306/10.0.0.2/3306?override=true", "10.0.0.1", "10.0.0.2")
+		test.S(t).ExpectEquals(recorder.Code, http.StatusOK)
+		test.S(t).ExpectTrue(strings.Contains(recorder.Body.String(), "operated"))
+	}
+	{
+		recorder := guardedRecorder(path, "/api/relocate/10.0.0.1/3306/10.0.0.2/3306?override=false", "10.0.0.1")
+		test.S(t).ExpectEquals(recorder.Code, http.StatusInternalServerError)
+	}
+}
+
+// relocationRecorder serves a relocation of the instance in url through relocationFlapGuard
+func relocationRecorder(url string, succeed bool) *httptest.ResponseRecorder {
+	api := HttpAPI{}
+	m := martini.Classic()
+	m.Use(render.Renderer())
+	m.Get("/api/relocate/:host/:port/:belowHost/:belowPort", api.relocationFlapGuard, func(r render.Render) {
+		if !succeed {
+			Respond(r, &APIResponse{Code: ERROR, Message: "failed"})
+			return
+		}
+		Respond(r, &APIResponse{Code: OK, Message: "relocated"})
+	})
+	req, _ := http.NewRequest("GET", url, nil)
+	recorder := httptest.NewRecorder()
+	m.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestRelocationFlapGuard(t *testing.T) {
+	originalMax := config.Config.MaxAutomatedRelocationsPerHour
+	defer func() { config.Config.MaxAutomatedRelocationsPerHour = originalMax }()
+	config.Config.MaxAutomatedRelocationsPerHour = 2
+
+	url := "/api/relocate/10.0.0.31/3306/10.0.0.32/3306?automated=true"
+	test.S(t).ExpectEquals(relocationRecorder(url, false).Code, http.StatusInternalServerError)
+	// Failed relocations are not counted
+	test.S(t).ExpectEquals(relocationRecorder(url, true).Code, http.StatusOK)
+	test.S(t).ExpectEquals(relocationRecorder(url, true).Code, http.StatusOK)
+
+	recorder := relocationRecorder(url, true)
+	test.S(t).ExpectEquals(recorder.Code, http.StatusInternalServerError)
+	test.S(t).ExpectTrue(strings.Contains(recorder.Body.String(), "FlapSuppressed"))
+	test.S(t).ExpectTrue(recorder.Header().Get("Retry-After") != "")
+
+	// Non automated relocations are not refused
+	test.S(t).ExpectEquals(relocationRecorder("/api/relocate/10.0.0.31/3306/10.0.0.32/3306", true).Code, http.StatusOK)
+	// Other instances are unaffected
+	test.S(t).ExpectEquals(relocationRecorder("/api/relocate/10.0.0.33/3306/10.0.0.32/3306?automated=true", true).Code, http.StatusOK)
+
+	config.Config.MaxAutomatedRelocationsPerHour = 0
+	test.S(t).ExpectEquals(relocationRecorder(url, true).Code, http.StatusOK)
+}
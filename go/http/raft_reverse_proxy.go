@@ -11,6 +11,35 @@ import (
 	"github.com/openark/orchestrator/go/raft"
 )
 
+// BypassLeaderHeader, when set on a request, has a non-leader node serve a read-only request by itself rather
+// than proxy it to the leader. This is for diagnostics, e.g. comparing a follower's view with the leader's.
+// Requests to endpoints registered as mutating (see APIEndpoint.Mutating) are proxied regardless.
+const BypassLeaderHeader = "X-Orchestrator-Bypass-Leader"
+
+// proxyToLeader proxies a request to the leader; tests substitute it
+var proxyToLeader = raftReverseProxy
+
+// raftLeaderProxy returns the handler proxying an API endpoint's requests to the leader. Which endpoints are
+// read-only is explicit upon registration (the registerReadOnlyAPIRequest variants): only those honor
+// BypassLeaderHeader. Any other endpoint is mutating, and always proxied.
+func raftLeaderProxy(mutating bool) martini.Handler {
+	if mutating {
+		return func(w http.ResponseWriter, r *http.Request, c martini.Context) {
+			proxyToLeader(w, r, c)
+		}
+	}
+	return raftReverseProxyUnlessBypassed
+}
+
+// raftReverseProxyUnlessBypassed proxies the request to the leader, as raftReverseProxy, unless BypassLeaderHeader is set
+func raftReverseProxyUnlessBypassed(w http.ResponseWriter, r *http.Request, c martini.Context) {
+	if r.Header.Get(BypassLeaderHeader) != "" {
+		log.Debugf("raftReverseProxy: %s bypasses the leader: %s", BypassLeaderHeader, r.URL.Path)
+		return
+	}
+	proxyToLeader(w, r, c)
+}
+
 func raftReverseProxy(w http.ResponseWriter, r *http.Request, c martini.Context) {
 	if !orcraft.IsRaftEnabled() {
 		// No raft, so no reverse proxy to the leader
@@ -20,7 +49,17 @@ func raftReverseProxy(w http.ResponseWriter, r *http.Request, c martini.Context)
 		// I am the leader. I will handle the request directly.
 		return
 	}
-	if orcraft.GetLeader() == "" {
+	leader, err := orcraft.GetLeaderNode()
+	if err != nil {
+		log.Errore(err)
+		return
+	}
+	if leader == nil {
+		return
+	}
+	if leader.IsSelf {
+		// Raft names me as the leader, though I'm not in leader state: probably just stepping up or down.
+		// Not going to redirect to myself.
 		return
 	}
 	if orcraft.LeaderURI.IsThisLeaderURI() {
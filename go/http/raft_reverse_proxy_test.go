@@ -0,0 +1,81 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/go-martini/martini"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/config"
+)
+
+// stubProxyToLeader substitutes proxyToLeader with a stub responding "proxied", for the duration of f
+func stubProxyToLeader(f func()) {
+	originalProxy := proxyToLeader
+	defer func() { proxyToLeader = originalProxy }()
+	proxyToLeader = func(w http.ResponseWriter, r *http.Request, c martini.Context) {
+		w.Write([]byte("proxied"))
+	}
+	f()
+}
+
+func TestRaftLeaderProxy(t *testing.T) {
+	stubProxyToLeader(func() {
+		for _, mutating := range []bool{true, false} {
+			m := martini.Classic()
+			m.Get("/api/path", raftLeaderProxy(mutating), func() string { return "served" })
+			{
+				req, _ := http.NewRequest("GET", "/api/path", nil)
+				recorder := httptest.NewRecorder()
+				m.ServeHTTP(recorder, req)
+				test.S(t).ExpectEquals(recorder.Body.String(), "proxied")
+			}
+			{
+				req, _ := http.NewRequest("GET", "/api/path", nil)
+				req.Header.Set(BypassLeaderHeader, "1")
+				recorder := httptest.NewRecorder()
+				m.ServeHTTP(recorder, req)
+				if mutating {
+					test.S(t).ExpectEquals(recorder.Body.String(), "proxied")
+				} else {
+					test.S(t).ExpectEquals(recorder.Body.String(), "served")
+				}
+			}
+		}
+	})
+}
+
+// TestMutatingEndpointsProxiedDespiteBypass checks that, with raft, every proxied mutating endpoint is proxied to
+// the leader even when the request sets BypassLeaderHeader
+func TestMutatingEndpointsProxiedDespiteBypass(t *testing.T) {
+	defer func(raftEnabled bool) { config.Config.RaftEnabled = raftEnabled }(config.Config.RaftEnabled)
+	config.Config.RaftEnabled = true
+
+	stubProxyToLeader(func() {
+		m := martini.Classic()
+		api := HttpAPI{}
+		api.RegisterRequests(m)
+
+		paramPattern := regexp.MustCompile(`:[^/]+`)
+		countMutating := 0
+		for _, endpoint := range ReadAPIEndpoints() {
+			if !endpoint.Mutating || !endpoint.Proxied {
+				continue
+			}
+			countMutating++
+			method := strings.Split(endpoint.Method, ",")[0]
+			req, _ := http.NewRequest(method, "/api/"+paramPattern.ReplaceAllString(endpoint.Path, "1"), nil)
+			req.Header.Set(BypassLeaderHeader, "1")
+			recorder := httptest.NewRecorder()
+			m.ServeHTTP(recorder, req)
+			if recorder.Body.String() != "proxied" {
+				t.Errorf("%s %s: expected to be proxied to the leader despite %s", method, endpoint.Path, BypassLeaderHeader)
+			}
+		}
+		test.S(t).ExpectTrue(countMutating > 100)
+	})
+}
@@ -0,0 +1,272 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package http
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/martini-contrib/render"
+
+	"github.com/openark/orchestrator/go/util"
+)
+
+const (
+	jsonReportFormat = "json"
+	yamlReportFormat = "yaml"
+	csvReportFormat  = "csv"
+	// jsonLinesReportFormat is JSON Lines: a JSON document per line, e.g. per instance
+	jsonLinesReportFormat = "jsonl"
+)
+
+var yamlPlainKeyRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// genericReportValue converts given report into maps, slices and scalars, as per its JSON encoding
+func genericReportValue(report interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var value interface{}
+	err = decoder.Decode(&value)
+	return value, err
+}
+
+func sortedReportKeys(m map[string]interface{}) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// yamlScalar returns the YAML representation of a scalar, empty map or empty slice, and false for any other value
+func yamlScalar(value interface{}) (string, bool) {
+	switch value := value.(type) {
+	case nil:
+		return "null", true
+	case bool:
+		return fmt.Sprintf("%t", value), true
+	case json.Number:
+		return value.String(), true
+	case string:
+		// JSON quoted strings are valid YAML double quoted scalars
+		quoted, _ := json.Marshal(value)
+		return string(quoted), true
+	case map[string]interface{}:
+		return "{}", len(value) == 0
+	case []interface{}:
+		return "[]", len(value) == 0
+	}
+	return "", false
+}
+
+func yamlKey(key string) string {
+	if yamlPlainKeyRegexp.MatchString(key) {
+		return key
+	}
+	quoted, _ := json.Marshal(key)
+	return string(quoted)
+}
+
+func yamlLines(value interface{}) []string {
+	if scalar, ok := yamlScalar(value); ok {
+		return []string{scalar}
+	}
+	lines := []string{}
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedReportKeys(value) {
+			if scalar, ok := yamlScalar(value[key]); ok {
+				lines = append(lines, fmt.Sprintf("%s: %s", yamlKey(key), scalar))
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("%s:", yamlKey(key)))
+			for _, line := range yamlLines(value[key]) {
+				lines = append(lines, "  "+line)
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			for i, line := range yamlLines(item) {
+				if i == 0 {
+					lines = append(lines, "- "+line)
+				} else {
+					lines = append(lines, "  "+line)
+				}
+			}
+		}
+	}
+	return lines
+}
+
+// encodeReportYAML encodes given report as YAML, with keys sorted by name
+func encodeReportYAML(report interface{}) ([]byte, error) {
+	value, err := genericReportValue(report)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(strings.Join(yamlLines(value), "\n") + "\n"), nil
+}
+
+// flattenReportRow flattens nested objects into dotted column names. Lists are kept as JSON.
+func flattenReportRow(prefix string, value interface{}, row map[string]string) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range value {
+			flattenReportRow(prefix+key+".", nested, row)
+		}
+		return
+	case nil:
+		row[strings.TrimSuffix(prefix, ".")] = ""
+	case string:
+		row[strings.TrimSuffix(prefix, ".")] = value
+	default:
+		encoded, _ := json.Marshal(value)
+		row[strings.TrimSuffix(prefix, ".")] = string(encoded)
+	}
+}
+
+// encodeReportCSV encodes given report as CSV: a list becomes a row per entry, any other value a single row.
+// Columns are the union of all rows' fields, sorted by name.
+func encodeReportCSV(report interface{}) ([]byte, error) {
+	value, err := genericReportValue(report)
+	if err != nil {
+		return nil, err
+	}
+	entries, isList := value.([]interface{})
+	if !isList {
+		entries = []interface{}{value}
+	}
+	rows := []map[string]string{}
+	columnsMap := make(map[string]bool)
+	for _, entry := range entries {
+		row := make(map[string]string)
+		if _, isMap := entry.(map[string]interface{}); isMap {
+			flattenReportRow("", entry, row)
+		} else {
+			flattenReportRow("Value.", entry, row)
+		}
+		for column := range row {
+			columnsMap[column] = true
+		}
+		rows = append(rows, row)
+	}
+	columns := []string{}
+	for column := range columnsMap {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write(columns)
+	for _, row := range rows {
+		record := []string{}
+		for _, column := range columns {
+			record = append(record, row[column])
+		}
+		writer.Write(record)
+	}
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// encodeReportJSONLines encodes given report as JSON Lines: a list becomes a line per entry, any other value
+// a single line
+func encodeReportJSONLines(report interface{}) ([]byte, error) {
+	value, err := genericReportValue(report)
+	if err != nil {
+		return nil, err
+	}
+	entries, isList := value.([]interface{})
+	if !isList {
+		entries = []interface{}{value}
+	}
+	var buf bytes.Buffer
+	writer := util.NewJSONLinesWriter(&buf)
+	for _, entry := range entries {
+		if err := writer.Write(entry); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// isJSONLinesRequest returns true when the request asks for a JSON Lines response via the "format" query param
+func isJSONLinesRequest(req *http.Request) bool {
+	return strings.TrimSpace(req.URL.Query().Get("format")) == jsonLinesReportFormat
+}
+
+// respondJSONLines streams a JSON Lines response: stream writes records, each flushed as written. Once streaming
+// started, the status can no longer change; a failure is then reported as a last line, an APIResponse with
+// Code ERROR.
+func respondJSONLines(w http.ResponseWriter, stream func(writer *util.JSONLinesWriter) error) {
+	w.Header().Set("Content-Type", util.JSONLinesContentType+"; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	writer := util.NewJSONLinesWriter(w)
+	if err := stream(writer); err != nil {
+		writer.Write(&APIResponse{Code: ERROR, Message: err.Error()})
+	}
+}
+
+// RespondReport responds with given report in the format requested via the "format" query param: json
+// (default), yaml, csv or jsonl. A CSV table or JSON Lines stream is made of rows when given, e.g. a report's
+// per-instance entries, or else of the report itself.
+func RespondReport(r render.Render, req *http.Request, report interface{}, rows interface{}) {
+	format := strings.TrimSpace(req.URL.Query().Get("format"))
+	var encoded []byte
+	var err error
+	switch format {
+	case "", jsonReportFormat:
+		r.JSON(http.StatusOK, report)
+		return
+	case yamlReportFormat:
+		r.Header().Set("Content-Type", "application/yaml; charset=UTF-8")
+		encoded, err = encodeReportYAML(report)
+	case csvReportFormat:
+		if rows == nil {
+			rows = report
+		}
+		r.Header().Set("Content-Type", "text/csv; charset=UTF-8")
+		encoded, err = encodeReportCSV(rows)
+	case jsonLinesReportFormat:
+		if rows == nil {
+			rows = report
+		}
+		r.Header().Set("Content-Type", util.JSONLinesContentType+"; charset=UTF-8")
+		encoded, err = encodeReportJSONLines(rows)
+	default:
+		Respond(r, &APIResponse{Code: ERROR, Message: fmt.Sprintf("Unsupported format: %s. Expected %s, %s, %s or %s", format, jsonReportFormat, yamlReportFormat, csvReportFormat, jsonLinesReportFormat)})
+		return
+	}
+	if err != nil {
+		r.Header().Del("Content-Type")
+		Respond(r, &APIResponse{Code: ERROR, Message: err.Error()})
+		return
+	}
+	r.Data(http.StatusOK, encoded)
+}
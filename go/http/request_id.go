@@ -0,0 +1,56 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-martini/martini"
+	"github.com/openark/golib/log"
+
+	"github.com/openark/orchestrator/go/util"
+)
+
+// RequestIdHeader is the header by which clients and orchestrator correlate a request with server logs
+const RequestIdHeader = "X-Request-ID"
+
+const maxRequestIdLength = 128
+
+// requestId returns the request's id as provided by the client, or generates a new one
+func requestId(req *http.Request) string {
+	id := strings.TrimSpace(req.Header.Get(RequestIdHeader))
+	if id == "" || len(id) > maxRequestIdLength {
+		id = util.NewToken().Hash[0:32]
+	}
+	return id
+}
+
+// RequestId is a martini handler which makes sure each request has an X-Request-ID header, generating one
+// if the client did not provide it. The header is kept on the request, hence passed on when proxying to the
+// raft leader, and is echoed back on the response. Failed requests are logged along with their id.
+func RequestId(w http.ResponseWriter, req *http.Request, c martini.Context) {
+	id := requestId(req)
+	req.Header.Set(RequestIdHeader, id)
+	w.Header().Set(RequestIdHeader, id)
+
+	c.Next()
+
+	if rw, ok := w.(martini.ResponseWriter); ok && rw.Status() >= http.StatusInternalServerError {
+		log.Errorf("request %s: %s %s returned status %d", id, req.Method, req.URL.Path, rw.Status())
+	}
+}
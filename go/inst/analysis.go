@@ -63,17 +63,67 @@ const (
 
 const (
 	StatementAndMixedLoggingReplicasStructureWarning     AnalysisCode = "StatementAndMixedLoggingReplicasStructureWarning"
-	StatementAndRowLoggingReplicasStructureWarning                    = "StatementAndRowLoggingReplicasStructureWarning"
-	MixedAndRowLoggingReplicasStructureWarning                        = "MixedAndRowLoggingReplicasStructureWarning"
-	MultipleMajorVersionsLoggingReplicasStructureWarning              = "MultipleMajorVersionsLoggingReplicasStructureWarning"
-	NoLoggingReplicasStructureWarning                                 = "NoLoggingReplicasStructureWarning"
-	DifferentGTIDModesStructureWarning                                = "DifferentGTIDModesStructureWarning"
-	ErrantGTIDStructureWarning                                        = "ErrantGTIDStructureWarning"
-	NoFailoverSupportStructureWarning                                 = "NoFailoverSupportStructureWarning"
-	NoWriteableMasterStructureWarning                                 = "NoWriteableMasterStructureWarning"
-	NotEnoughValidSemiSyncReplicasStructureWarning                    = "NotEnoughValidSemiSyncReplicasStructureWarning"
+	StatementAndRowLoggingReplicasStructureWarning       AnalysisCode = "StatementAndRowLoggingReplicasStructureWarning"
+	MixedAndRowLoggingReplicasStructureWarning           AnalysisCode = "MixedAndRowLoggingReplicasStructureWarning"
+	MultipleMajorVersionsLoggingReplicasStructureWarning AnalysisCode = "MultipleMajorVersionsLoggingReplicasStructureWarning"
+	NoLoggingReplicasStructureWarning                    AnalysisCode = "NoLoggingReplicasStructureWarning"
+	DifferentGTIDModesStructureWarning                   AnalysisCode = "DifferentGTIDModesStructureWarning"
+	ErrantGTIDStructureWarning                           AnalysisCode = "ErrantGTIDStructureWarning"
+	NoFailoverSupportStructureWarning                    AnalysisCode = "NoFailoverSupportStructureWarning"
+	NoWriteableMasterStructureWarning                    AnalysisCode = "NoWriteableMasterStructureWarning"
+	NotEnoughValidSemiSyncReplicasStructureWarning       AnalysisCode = "NotEnoughValidSemiSyncReplicasStructureWarning"
 )
 
+// StructureWarningSeverity tells whether a structure warning merely notes an untidy topology, or one which
+// would fail or harm a recovery, or already harms the cluster
+type StructureWarningSeverity string
+
+const (
+	CosmeticStructureWarning StructureWarningSeverity = "cosmetic"
+	BlockingStructureWarning StructureWarningSeverity = "blocking"
+)
+
+var structureWarningSeverities = map[AnalysisCode]StructureWarningSeverity{
+	StatementAndMixedLoggingReplicasStructureWarning:     CosmeticStructureWarning,
+	StatementAndRowLoggingReplicasStructureWarning:       CosmeticStructureWarning,
+	MixedAndRowLoggingReplicasStructureWarning:           CosmeticStructureWarning,
+	MultipleMajorVersionsLoggingReplicasStructureWarning: CosmeticStructureWarning,
+	DifferentGTIDModesStructureWarning:                   CosmeticStructureWarning,
+	NoLoggingReplicasStructureWarning:                    BlockingStructureWarning,
+	ErrantGTIDStructureWarning:                           BlockingStructureWarning,
+	NoFailoverSupportStructureWarning:                    BlockingStructureWarning,
+	NoWriteableMasterStructureWarning:                    BlockingStructureWarning,
+	NotEnoughValidSemiSyncReplicasStructureWarning:       BlockingStructureWarning,
+}
+
+// ParseStructureWarning returns the structure warning named by given string, or an error if it names none
+func ParseStructureWarning(name string) (AnalysisCode, error) {
+	code := AnalysisCode(strings.TrimSpace(name))
+	if _, found := structureWarningSeverities[code]; !found {
+		return code, fmt.Errorf("Unknown structure warning: %s", name)
+	}
+	return code, nil
+}
+
+// IsStructureWarning returns true when this code is a known structure warning
+func (this AnalysisCode) IsStructureWarning() bool {
+	_, found := structureWarningSeverities[this]
+	return found
+}
+
+// StructureWarningSeverity returns the severity of this structure warning. Unknown warnings are deemed blocking.
+func (this AnalysisCode) StructureWarningSeverity() StructureWarningSeverity {
+	if severity, found := structureWarningSeverities[this]; found {
+		return severity
+	}
+	return BlockingStructureWarning
+}
+
+// IsBlockingStructureWarning returns true when this structure warning would fail or harm a recovery
+func (this AnalysisCode) IsBlockingStructureWarning() bool {
+	return this.StructureWarningSeverity() == BlockingStructureWarning
+}
+
 type InstanceAnalysis struct {
 	key      *InstanceKey
 	analysis AnalysisCode
@@ -183,17 +233,44 @@ type ReplicationAnalysisChangelog struct {
 	Changelog           []string
 }
 
+// FlappingInstance indicates an instance whose replication analysis changes frequently
+type FlappingInstance struct {
+	Key             InstanceKey
+	AnalysisChanges int
+	FirstChange     string
+	LastChange      string
+	LastAnalysis    string
+}
+
 func (this *ReplicationAnalysis) MarshalJSON() ([]byte, error) {
 	i := struct {
 		ReplicationAnalysis
+		BlockingStructureAnalysis []AnalysisCode
 	}{}
 	i.ReplicationAnalysis = *this
 	// backwards compatibility
 	i.SlaveHosts = i.Replicas
+	i.BlockingStructureAnalysis = this.BlockingStructureAnalysis()
 
 	return json.Marshal(i)
 }
 
+// BlockingStructureAnalysis returns the structure warnings of this analysis which are blocking
+func (this *ReplicationAnalysis) BlockingStructureAnalysis() []AnalysisCode {
+	blocking := []AnalysisCode{}
+	for _, structureAnalysis := range this.StructureAnalysis {
+		if structureAnalysis.IsBlockingStructureWarning() {
+			blocking = append(blocking, structureAnalysis)
+		}
+	}
+	return blocking
+}
+
+// HasBlockingStructureAnalysis returns true when any of this analysis' structure warnings is blocking
+func (this *ReplicationAnalysis) HasBlockingStructureAnalysis() bool {
+	return len(this.BlockingStructureAnalysis()) > 0
+}
+
 // ReadReplicaHostsFromString parses and reads replica keys from comma delimited string
 func (this *ReplicationAnalysis) ReadReplicaHostsFromString(replicaHostsString string) error {
 	this.Replicas = *NewInstanceKeyMap()
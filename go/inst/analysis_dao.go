@@ -422,7 +422,7 @@ func GetReplicationAnalysis(clusterName string, hints *ReplicationAnalysisHints)
 	`,
 		analysisQueryReductionClause)
 
-	err := db.QueryOrchestrator(query, args, func(m sqlutils.RowMap) error {
+	err := db.QueryOrchestratorBuffered(query, args, func(m sqlutils.RowMap) error {
 		a := ReplicationAnalysis{
 			Analysis:               NoProblem,
 			ProcessingNodeHostname: process.ThisHostname,
@@ -886,6 +886,71 @@ func ReadReplicationAnalysisChangelog() (res [](*ReplicationAnalysisChangelog),
 	return res, err
 }
 
+func readFlappingInstances(whereCondition string, args []interface{}) (res []FlappingInstance, err error) {
+	res = []FlappingInstance{}
+	if config.Config.FlappingAnalysisChangesThreshold <= 0 {
+		return res, nil
+	}
+	query := fmt.Sprintf(`
+		select
+			database_instance_analysis_changelog.hostname,
+			database_instance_analysis_changelog.port,
+			count(*) as analysis_changes,
+			min(database_instance_analysis_changelog.analysis_timestamp) as first_change,
+			max(database_instance_analysis_changelog.analysis_timestamp) as last_change,
+			ifnull(database_instance_last_analysis.analysis, '') as last_analysis
+		from
+			database_instance_analysis_changelog
+			left join database_instance_last_analysis on (
+				database_instance_analysis_changelog.hostname = database_instance_last_analysis.hostname
+				and database_instance_analysis_changelog.port = database_instance_last_analysis.port
+			)
+		where
+			database_instance_analysis_changelog.analysis_timestamp >= now() - interval ? minute
+			%s
+		group by
+			database_instance_analysis_changelog.hostname,
+			database_instance_analysis_changelog.port,
+			database_instance_last_analysis.analysis
+		having
+			count(*) >= ?
+		order by
+			analysis_changes desc,
+			database_instance_analysis_changelog.hostname,
+			database_instance_analysis_changelog.port
+		`, whereCondition)
+	queryArgs := sqlutils.Args(config.Config.FlappingAnalysisWindowMinutes)
+	queryArgs = append(queryArgs, args...)
+	queryArgs = append(queryArgs, config.Config.FlappingAnalysisChangesThreshold)
+	err = db.QueryOrchestrator(query, queryArgs, func(m sqlutils.RowMap) error {
+		flapping := FlappingInstance{
+			Key:             InstanceKey{Hostname: m.GetString("hostname"), Port: m.GetInt("port")},
+			AnalysisChanges: m.GetInt("analysis_changes"),
+			FirstChange:     m.GetString("first_change"),
+			LastChange:      m.GetString("last_change"),
+			LastAnalysis:    m.GetString("last_analysis"),
+		}
+		res = append(res, flapping)
+		return nil
+	})
+	return res, log.Errore(err)
+}
+
+// ReadFlappingInstances returns instances whose analysis changed at least FlappingAnalysisChangesThreshold
+// times within the last FlappingAnalysisWindowMinutes
+func ReadFlappingInstances() ([]FlappingInstance, error) {
+	return readFlappingInstances("", sqlutils.Args())
+}
+
+// IsInstanceFlapping checks whether given instance's analysis is flapping
+func IsInstanceFlapping(instanceKey *InstanceKey) (bool, error) {
+	flapping, err := readFlappingInstances(`
+			and database_instance_analysis_changelog.hostname = ?
+			and database_instance_analysis_changelog.port = ?
+		`, sqlutils.Args(instanceKey.Hostname, instanceKey.Port))
+	return len(flapping) > 0, err
+}
+
 // ReadPeerAnalysisMap reads raft-peer failure analysis, and returns a PeerAnalysisMap,
 // indicating how many peers see which analysis
 func ReadPeerAnalysisMap() (peerAnalysisMap PeerAnalysisMap, err error) {
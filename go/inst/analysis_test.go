@@ -17,6 +17,8 @@
 package inst
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/openark/golib/log"
@@ -48,3 +50,53 @@ func TestGetAnalysisInstanceType(t *testing.T) {
 		test.S(t).ExpectEquals(string(analysis.GetAnalysisInstanceType()), "co-master")
 	}
 }
+
+func TestStructureWarnings(t *testing.T) {
+	{
+		code, err := ParseStructureWarning("ErrantGTIDStructureWarning")
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(code, ErrantGTIDStructureWarning)
+		test.S(t).ExpectTrue(code.IsStructureWarning())
+		test.S(t).ExpectTrue(code.IsBlockingStructureWarning())
+	}
+	{
+		code, err := ParseStructureWarning(" MixedAndRowLoggingReplicasStructureWarning ")
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(code.StructureWarningSeverity(), CosmeticStructureWarning)
+		test.S(t).ExpectFalse(code.IsBlockingStructureWarning())
+	}
+	{
+		_, err := ParseStructureWarning("ErrantGTIDStructureWarn")
+		test.S(t).ExpectNotNil(err)
+		_, err = ParseStructureWarning(string(DeadMaster))
+		test.S(t).ExpectNotNil(err)
+	}
+	{
+		test.S(t).ExpectFalse(AnalysisCode(DeadMaster).IsStructureWarning())
+		// unknown warnings, e.g. of a newer orchestrator version, are deemed blocking
+		test.S(t).ExpectTrue(AnalysisCode("SomeFutureStructureWarning").IsBlockingStructureWarning())
+	}
+}
+
+func TestBlockingStructureAnalysis(t *testing.T) {
+	analysis := &ReplicationAnalysis{
+		Analysis:          NoProblem,
+		StructureAnalysis: []AnalysisCode{StatementAndRowLoggingReplicasStructureWarning, NoWriteableMasterStructureWarning, DifferentGTIDModesStructureWarning},
+	}
+	test.S(t).ExpectTrue(analysis.HasBlockingStructureAnalysis())
+	test.S(t).ExpectEquals(len(analysis.BlockingStructureAnalysis()), 1)
+	test.S(t).ExpectEquals(analysis.BlockingStructureAnalysis()[0], NoWriteableMasterStructureWarning)
+
+	data, err := json.Marshal(analysis)
+	test.S(t).ExpectNil(err)
+	decoded := struct {
+		StructureAnalysis         []string
+		BlockingStructureAnalysis []string
+	}{}
+	test.S(t).ExpectNil(json.Unmarshal(data, &decoded))
+	test.S(t).ExpectEquals(len(decoded.StructureAnalysis), 3)
+	test.S(t).ExpectEquals(strings.Join(decoded.BlockingStructureAnalysis, ","), "NoWriteableMasterStructureWarning")
+
+	analysis.StructureAnalysis = []AnalysisCode{MultipleMajorVersionsLoggingReplicasStructureWarning}
+	test.S(t).ExpectFalse(analysis.HasBlockingStructureAnalysis())
+}
@@ -17,6 +17,7 @@
 package inst
 
 import (
+	"context"
 	"fmt"
 	"log/syslog"
 	"os"
@@ -29,6 +30,9 @@ import (
 	"github.com/rcrowley/go-metrics"
 )
 
+// auditExportPageSize is the number of audit entries ExportAudit reads at a time
+const auditExportPageSize = 1000
+
 // syslogWriter is optional, and defaults to nil (disabled)
 var syslogWriter *syslog.Writer
 
@@ -103,6 +107,8 @@ func AuditOperation(auditType string, instanceKey *InstanceKey, message string)
 	if !auditWrittenToFile {
 		log.Infof(logMessage)
 	}
+	invalidateMutationCaches(auditType, instanceKey, clusterName)
+	publishAuditDomainEvent(auditType, instanceKey, clusterName, message)
 	auditOperationCounter.Inc(1)
 
 	return nil
@@ -110,7 +116,6 @@ func AuditOperation(auditType string, instanceKey *InstanceKey, message string)
 
 // ReadRecentAudit returns a list of audit entries order chronologically descending, using page number.
 func ReadRecentAudit(instanceKey *InstanceKey, page int) ([]Audit, error) {
-	res := []Audit{}
 	args := sqlutils.Args()
 	whereCondition := ``
 	if instanceKey != nil {
@@ -134,6 +139,11 @@ func ReadRecentAudit(instanceKey *InstanceKey, page int) ([]Audit, error) {
 		offset ?
 		`, whereCondition)
 	args = append(args, config.AuditPageSize, page*config.AuditPageSize)
+	return readAudits(query, args)
+}
+
+func readAudits(query string, args []interface{}) ([]Audit, error) {
+	res := []Audit{}
 	err := db.QueryOrchestrator(query, args, func(m sqlutils.RowMap) error {
 		audit := Audit{}
 		audit.AuditId = m.GetInt64("audit_id")
@@ -151,7 +161,59 @@ func ReadRecentAudit(instanceKey *InstanceKey, page int) ([]Audit, error) {
 		log.Errore(err)
 	}
 	return res, err
+}
 
+// ReadAuditSince returns up to limit audit entries following given audit id, in order of id, optionally only
+// those of given instance
+func ReadAuditSince(instanceKey *InstanceKey, sinceId int64, limit int) ([]Audit, error) {
+	args := sqlutils.Args(sinceId)
+	whereCondition := ``
+	if instanceKey != nil {
+		whereCondition = `and hostname=? and port=?`
+		args = append(args, instanceKey.Hostname, instanceKey.Port)
+	}
+	query := fmt.Sprintf(`
+		select
+			audit_id,
+			audit_timestamp,
+			audit_type,
+			hostname,
+			port,
+			message
+		from
+			audit
+		where
+			audit_id > ?
+			%s
+		order by
+			audit_id asc
+		limit ?
+		`, whereCondition)
+	args = append(args, limit)
+	return readAudits(query, args)
+}
+
+// ExportAudit streams audit entries following given audit id (0 for all), oldest first, page by page, to given
+// sink, optionally only those of given instance. Entries added during the export are exported as well. It returns
+// the id of the last exported entry, from which an interrupted export resumes.
+func ExportAudit(ctx context.Context, instanceKey *InstanceKey, sinceId int64, sink func(audits []Audit) error) (lastId int64, err error) {
+	lastId = sinceId
+	for {
+		if err := ctx.Err(); err != nil {
+			return lastId, err
+		}
+		audits, err := ReadAuditSince(instanceKey, lastId, auditExportPageSize)
+		if err != nil {
+			return lastId, err
+		}
+		if len(audits) == 0 {
+			return lastId, nil
+		}
+		if err := sink(audits); err != nil {
+			return lastId, err
+		}
+		lastId = audits[len(audits)-1].AuditId
+	}
 }
 
 // ExpireAudit removes old rows from the audit table
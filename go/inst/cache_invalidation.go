@@ -0,0 +1,79 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"github.com/patrickmn/go-cache"
+)
+
+// CacheInvalidatedEvent is published after orchestrator drops in-process cache entries following a
+// mutation, so that caches kept outside of the process (or in other packages) can do the same.
+const CacheInvalidatedEvent DomainEventType = "CacheInvalidated"
+
+// InvalidateInstanceCaches drops cached entries relating to given instance: its informative cluster
+// name. It returns the cluster name the instance was cached under, if any.
+// recentInstantAnalysis is not a read cache, but dedupes analysis writes, and so is left intact.
+func InvalidateInstanceCaches(instanceKey *InstanceKey) (cachedClusterName string) {
+	if instanceKey == nil || !instanceKey.IsValid() {
+		return ""
+	}
+	if instanceKeyInformativeClusterName != nil {
+		if clusterName, found := instanceKeyInformativeClusterName.Get(instanceKey.StringCode()); found {
+			cachedClusterName = clusterName.(string)
+		}
+		instanceKeyInformativeClusterName.Delete(instanceKey.StringCode())
+	}
+	return cachedClusterName
+}
+
+// InvalidateClusterCaches drops cached entries relating to given cluster: its flags, its
+// injected pseudo-GTID indication and resolutions of aliases to it.
+func InvalidateClusterCaches(clusterName string) {
+	if clusterName == "" {
+		return
+	}
+	for _, clusterCache := range []*cache.Cache{clusterFlagsCache, clusterInjectedPseudoGTIDCache} {
+		if clusterCache != nil {
+			clusterCache.Delete(clusterName)
+		}
+	}
+	for nameOrAlias, item := range clusterNameByAliasCache.Items() {
+		if nameOrAlias == clusterName || item.Object == clusterName {
+			clusterNameByAliasCache.Delete(nameOrAlias)
+		}
+	}
+}
+
+// invalidateMutationCaches drops cache entries affected by a mutation on given instance and cluster,
+// and publishes a CacheInvalidatedEvent naming them.
+func invalidateMutationCaches(auditType string, instanceKey *InstanceKey, clusterName string) {
+	cachedClusterName := InvalidateInstanceCaches(instanceKey)
+	if clusterName == "" {
+		clusterName = cachedClusterName
+	}
+	InvalidateClusterCaches(clusterName)
+
+	event := DomainEvent{
+		Type:        CacheInvalidatedEvent,
+		AuditType:   auditType,
+		ClusterName: clusterName,
+	}
+	if instanceKey != nil {
+		event.Key = *instanceKey
+	}
+	PublishDomainEvent(event)
+}
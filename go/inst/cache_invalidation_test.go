@@ -0,0 +1,73 @@
+package inst
+
+import (
+	"testing"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+
+	test "github.com/openark/golib/tests"
+)
+
+func TestInvalidateMutationCaches(t *testing.T) {
+	if instanceKeyInformativeClusterName == nil {
+		instanceKeyInformativeClusterName = cache.New(time.Minute, time.Second)
+	}
+	if recentInstantAnalysis == nil {
+		recentInstantAnalysis = cache.New(time.Minute, time.Second)
+	}
+	if clusterInjectedPseudoGTIDCache == nil {
+		clusterInjectedPseudoGTIDCache = cache.New(time.Minute, time.Second)
+	}
+
+	key := &InstanceKey{Hostname: "host1", Port: 3306}
+	otherKey := &InstanceKey{Hostname: "host2", Port: 3306}
+	instanceKeyInformativeClusterName.Set(key.StringCode(), "cluster1", cache.DefaultExpiration)
+	instanceKeyInformativeClusterName.Set(otherKey.StringCode(), "cluster1", cache.DefaultExpiration)
+	recentInstantAnalysis.Set(key.DisplayString(), DeadMaster, cache.DefaultExpiration)
+	clusterFlagsCache.Set("cluster1", &ClusterFlags{ClusterName: "cluster1"}, cache.DefaultExpiration)
+	clusterFlagsCache.Set("cluster2", &ClusterFlags{ClusterName: "cluster2"}, cache.DefaultExpiration)
+	clusterInjectedPseudoGTIDCache.Set("cluster1", true, cache.DefaultExpiration)
+	clusterNameByAliasCache.Set("alias1", "cluster1", cache.DefaultExpiration)
+	clusterNameByAliasCache.Set("cluster1", "cluster1", cache.DefaultExpiration)
+	clusterNameByAliasCache.Set("alias2", "cluster2", cache.DefaultExpiration)
+
+	events := []DomainEvent{}
+	unsubscribe := SubscribeDomainEvents(func(event DomainEvent) {
+		events = append(events, event)
+	})
+	defer unsubscribe()
+
+	invalidateMutationCaches("relocate-below", key, "")
+
+	_, found := instanceKeyInformativeClusterName.Get(key.StringCode())
+	test.S(t).ExpectFalse(found)
+	_, found = instanceKeyInformativeClusterName.Get(otherKey.StringCode())
+	test.S(t).ExpectTrue(found)
+	// analysis write dedupe is not a read cache
+	_, found = recentInstantAnalysis.Get(key.DisplayString())
+	test.S(t).ExpectTrue(found)
+	_, found = clusterFlagsCache.Get("cluster1")
+	test.S(t).ExpectFalse(found)
+	_, found = clusterFlagsCache.Get("cluster2")
+	test.S(t).ExpectTrue(found)
+	_, found = clusterInjectedPseudoGTIDCache.Get("cluster1")
+	test.S(t).ExpectFalse(found)
+	_, found = clusterNameByAliasCache.Get("alias1")
+	test.S(t).ExpectFalse(found)
+	_, found = clusterNameByAliasCache.Get("cluster1")
+	test.S(t).ExpectFalse(found)
+	_, found = clusterNameByAliasCache.Get("alias2")
+	test.S(t).ExpectTrue(found)
+
+	test.S(t).ExpectEquals(len(events), 1)
+	test.S(t).ExpectEquals(events[0].Type, CacheInvalidatedEvent)
+	test.S(t).ExpectEquals(events[0].AuditType, "relocate-below")
+	test.S(t).ExpectEquals(events[0].Key, *key)
+	test.S(t).ExpectEquals(events[0].ClusterName, "cluster1")
+}
+
+func TestInvalidateInstanceCachesInvalidKey(t *testing.T) {
+	test.S(t).ExpectEquals(InvalidateInstanceCaches(nil), "")
+	test.S(t).ExpectEquals(InvalidateInstanceCaches(&InstanceKey{}), "")
+}
@@ -88,10 +88,26 @@ type ClusterInfo struct {
 	HasAutomatedIntermediateMasterRecovery bool
 }
 
+const (
+	ClusterHintResolvedByClusterName   = "cluster-name"
+	ClusterHintResolvedByClusterAlias  = "cluster-alias"
+	ClusterHintResolvedByInstance      = "instance"
+	ClusterHintResolvedByFuzzyInstance = "fuzzy-instance"
+)
+
+// ClusterHintResolution tells which cluster a cluster hint refers to, and how the hint was matched
+type ClusterHintResolution struct {
+	ClusterHint   string
+	ClusterName   string
+	ClusterAlias  string
+	ClusterDomain string
+	ResolvedBy    string
+}
+
 // ReadRecoveryInfo
 func (this *ClusterInfo) ReadRecoveryInfo() {
-	this.HasAutomatedMasterRecovery = this.filtersMatchCluster(config.Config.RecoverMasterClusterFilters)
-	this.HasAutomatedIntermediateMasterRecovery = this.filtersMatchCluster(config.Config.RecoverIntermediateMasterClusterFilters)
+	this.HasAutomatedMasterRecovery = this.filtersMatchCluster(GetRecoveryFilterPatterns(MasterRecoveryFilterType))
+	this.HasAutomatedIntermediateMasterRecovery = this.filtersMatchCluster(GetRecoveryFilterPatterns(IntermediateMasterRecoveryFilterType))
 }
 
 // filtersMatchCluster will see whether the given filters match the given cluster details
@@ -124,6 +140,11 @@ func (this *ClusterInfo) filtersMatchCluster(filters []string) bool {
 	return false
 }
 
+// MatchesFilters checks whether given filters, in the format of RecoverMasterClusterFilters, match the cluster
+func (this *ClusterInfo) MatchesFilters(filters []string) bool {
+	return this.filtersMatchCluster(filters)
+}
+
 // ApplyClusterAlias updates the given clusterInfo's ClusterAlias property
 func (this *ClusterInfo) ApplyClusterAlias() {
 	if this.ClusterAlias != "" && this.ClusterAlias != this.ClusterName {
@@ -16,6 +16,14 @@
 
 package inst
 
+// ClusterAlias describes the alias mapping of a cluster, along with any manual override
+type ClusterAlias struct {
+	ClusterName    string
+	Alias          string
+	LastRegistered string
+	OverrideAlias  string
+}
+
 // SetClusterAlias will write (and override) a single cluster name mapping
 func SetClusterAlias(clusterName string, alias string) error {
 	return writeClusterAlias(clusterName, alias)
@@ -33,3 +41,9 @@ func SetClusterAliasManualOverride(clusterName string, alias string) error {
 func GetClusterByAlias(alias string) (string, error) {
 	return ReadClusterNameByAlias(alias)
 }
+
+// ForgetClusterAlias removes the alias mapping of given cluster, including any manual override.
+// The alias is later re-deduced from the cluster instances' suggested alias, if any.
+func ForgetClusterAlias(clusterName string) error {
+	return deleteClusterAlias(clusterName)
+}
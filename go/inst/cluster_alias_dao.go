@@ -18,13 +18,19 @@ package inst
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/openark/golib/log"
 	"github.com/openark/golib/sqlutils"
 	"github.com/openark/orchestrator/go/config"
 	"github.com/openark/orchestrator/go/db"
+	"github.com/patrickmn/go-cache"
 )
 
+// clusterNameByAliasCache caches alias resolutions by DeduceClusterName. It is flushed upon explicit alias changes;
+// aliases changed by UpdateClusterAliases apply as entries expire.
+var clusterNameByAliasCache = cache.New(time.Duration(config.Config.InstancePollSeconds)*time.Second, time.Second)
+
 func IsSQLite() bool {
 	return config.Config.IsSQLite()
 }
@@ -59,7 +65,11 @@ func DeduceClusterName(nameOrAlias string) (clusterName string, err error) {
 	if nameOrAlias == "" {
 		return "", fmt.Errorf("empty cluster name")
 	}
+	if name, found := clusterNameByAliasCache.Get(nameOrAlias); found {
+		return name.(string), nil
+	}
 	if name, err := ReadClusterNameByAlias(nameOrAlias); err == nil {
+		clusterNameByAliasCache.Set(nameOrAlias, name, cache.DefaultExpiration)
 		return name, nil
 	}
 	return nameOrAlias, nil
@@ -96,6 +106,7 @@ func writeClusterAlias(clusterName string, alias string) error {
 			clusterName, alias)
 		return log.Errore(err)
 	}
+	defer clusterNameByAliasCache.Flush()
 	return ExecDBWriteFunc(writeFunc)
 }
 
@@ -111,9 +122,57 @@ func writeClusterAliasManualOverride(clusterName string, alias string) error {
 			clusterName, alias)
 		return log.Errore(err)
 	}
+	defer clusterNameByAliasCache.Flush()
 	return ExecDBWriteFunc(writeFunc)
 }
 
+// deleteClusterAlias removes both the deduced and the manually overridden alias of given cluster
+func deleteClusterAlias(clusterName string) error {
+	writeFunc := func() error {
+		if _, err := db.ExecOrchestrator(`
+			delete from cluster_alias_override where cluster_name = ?
+			`, clusterName); err != nil {
+			return log.Errore(err)
+		}
+		_, err := db.ExecOrchestrator(`
+			delete from cluster_alias where cluster_name = ?
+			`, clusterName)
+		return log.Errore(err)
+	}
+	defer clusterNameByAliasCache.Flush()
+	if err := ExecDBWriteFunc(writeFunc); err != nil {
+		return err
+	}
+	return AuditOperation("forget-cluster-alias", nil, fmt.Sprintf("Forgotten alias of cluster: %s", clusterName))
+}
+
+// ReadClusterAliases returns all known cluster alias mappings, along with manual overrides
+func ReadClusterAliases() (aliases []ClusterAlias, err error) {
+	aliases = []ClusterAlias{}
+	query := `
+		select
+			cluster_alias.cluster_name,
+			cluster_alias.alias,
+			cluster_alias.last_registered,
+			ifnull(cluster_alias_override.alias, '') as override_alias
+		from
+			cluster_alias
+			left join cluster_alias_override on (cluster_alias.cluster_name = cluster_alias_override.cluster_name)
+		order by
+			cluster_alias.alias, cluster_alias.cluster_name
+		`
+	err = db.QueryOrchestrator(query, sqlutils.Args(), func(m sqlutils.RowMap) error {
+		aliases = append(aliases, ClusterAlias{
+			ClusterName:    m.GetString("cluster_name"),
+			Alias:          m.GetString("alias"),
+			LastRegistered: m.GetString("last_registered"),
+			OverrideAlias:  m.GetString("override_alias"),
+		})
+		return nil
+	})
+	return aliases, log.Errore(err)
+}
+
 // Original, safe approach, which uses REPLACE INTO
 func updateClusterAliasesUsingReplace() error {
 	_, err := db.ExecOrchestrator(`
@@ -262,6 +321,7 @@ func ForgetLongUnseenClusterAliases() error {
 // ReplaceAliasClusterName replaces alis mapping of one cluster name onto a new cluster name.
 // Used in topology failover/recovery
 func ReplaceAliasClusterName(oldClusterName string, newClusterName string) (err error) {
+	defer clusterNameByAliasCache.Flush()
 	{
 		writeFunc := func() error {
 			_, err := db.ExecOrchestrator(`
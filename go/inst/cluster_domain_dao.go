@@ -18,6 +18,7 @@ package inst
 
 import (
 	"github.com/openark/golib/log"
+	"github.com/openark/golib/sqlutils"
 	"github.com/openark/orchestrator/go/config"
 	"github.com/openark/orchestrator/go/db"
 )
@@ -52,3 +53,47 @@ func ExpireClusterDomainName() error {
 	}
 	return ExecDBWriteFunc(writeFunc)
 }
+
+// ClusterDomainName is the registered domain name of a cluster
+type ClusterDomainName struct {
+	ClusterName    string
+	DomainName     string
+	LastRegistered string
+}
+
+// ReadClusterDomainNames reads the registered domain names of all clusters
+func ReadClusterDomainNames() (domainNames []ClusterDomainName, err error) {
+	domainNames = []ClusterDomainName{}
+	query := `
+		select
+			cluster_name,
+			domain_name,
+			last_registered
+		from
+			cluster_domain_name
+		order by
+			cluster_name
+		`
+	err = db.QueryOrchestrator(query, sqlutils.Args(), func(m sqlutils.RowMap) error {
+		domainNames = append(domainNames, ClusterDomainName{
+			ClusterName:    m.GetString("cluster_name"),
+			DomainName:     m.GetString("domain_name"),
+			LastRegistered: m.GetString("last_registered"),
+		})
+		return nil
+	})
+	return domainNames, log.Errore(err)
+}
+
+// DeleteClusterDomainName removes the domain name registration of a cluster
+func DeleteClusterDomainName(clusterName string) error {
+	writeFunc := func() error {
+		_, err := db.ExecOrchestrator(`
+			delete from cluster_domain_name
+				where cluster_name = ?
+			`,
+			clusterName)
+		return log.Errore(err)
+	}
+	return ExecDBWriteFunc(writeFunc)
+}
@@ -0,0 +1,127 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openark/orchestrator/go/config"
+	"github.com/patrickmn/go-cache"
+)
+
+// ClusterFlagTagPrefix prefixes the names of tags which encode cluster flags, e.g. "flag:automation_enabled"
+const ClusterFlagTagPrefix = "flag:"
+
+var clusterFlagsCache = cache.New(time.Duration(config.Config.InstancePollSeconds)*time.Second, time.Second)
+
+// ClusterFlags are cluster-level flags, encoded as tags on the cluster's master. Tools coordinate
+// their behavior per cluster via flags, e.g. `automation_enabled=false`.
+type ClusterFlags struct {
+	ClusterName string
+	MasterKey   InstanceKey
+	Flags       map[string]string
+}
+
+// Get returns the value of given flag, and whether it is set at all
+func (this *ClusterFlags) Get(name string) (value string, found bool) {
+	value, found = this.Flags[name]
+	return value, found
+}
+
+// String returns the value of given flag, or defaultValue when the flag is not set
+func (this *ClusterFlags) String(name string, defaultValue string) string {
+	if value, found := this.Get(name); found {
+		return value
+	}
+	return defaultValue
+}
+
+// Bool returns the boolean value of given flag, or defaultValue when the flag is not set or is not a boolean
+func (this *ClusterFlags) Bool(name string, defaultValue bool) bool {
+	if value, found := this.Get(name); found {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
+
+// Int returns the integer value of given flag, or defaultValue when the flag is not set or is not an integer
+func (this *ClusterFlags) Int(name string, defaultValue int64) int64 {
+	if value, found := this.Get(name); found {
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return i
+		}
+	}
+	return defaultValue
+}
+
+// NewClusterFlagTag returns the tag which encodes given cluster flag
+func NewClusterFlagTag(name string, value string) (*Tag, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.Contains(name, "=") {
+		return nil, fmt.Errorf("Invalid cluster flag name: %q", name)
+	}
+	return NewTag(ClusterFlagTagPrefix+name, value)
+}
+
+// ReadClusterFlagsMasterKey returns the key of the instance on which flags of given cluster are stored
+func ReadClusterFlagsMasterKey(clusterName string) (*InstanceKey, error) {
+	masters, err := ReadClusterMaster(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if len(masters) == 0 {
+		return nil, fmt.Errorf("Cannot find master of cluster %s", clusterName)
+	}
+	return &masters[0].Key, nil
+}
+
+// ReadClusterFlags reads the flags of given cluster. Results are cached for InstancePollSeconds.
+func ReadClusterFlags(clusterName string) (*ClusterFlags, error) {
+	if flags, found := clusterFlagsCache.Get(clusterName); found {
+		return flags.(*ClusterFlags), nil
+	}
+	masterKey, err := ReadClusterFlagsMasterKey(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := ReadInstanceTags(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	flags := &ClusterFlags{
+		ClusterName: clusterName,
+		MasterKey:   *masterKey,
+		Flags:       make(map[string]string),
+	}
+	for _, tag := range tags {
+		if strings.HasPrefix(tag.TagName, ClusterFlagTagPrefix) {
+			flags.Flags[strings.TrimPrefix(tag.TagName, ClusterFlagTagPrefix)] = tag.TagValue
+		}
+	}
+	clusterFlagsCache.Set(clusterName, flags, cache.DefaultExpiration)
+	return flags, nil
+}
+
+// InvalidateClusterFlags drops cached flags of given cluster
+func InvalidateClusterFlags(clusterName string) {
+	clusterFlagsCache.Delete(clusterName)
+}
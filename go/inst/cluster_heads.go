@@ -0,0 +1,118 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/openark/golib/sqlutils"
+)
+
+// ClusterHeads are the top of a cluster's topology: its master, or, in a master-master setup, a pair of
+// co-masters replicating from each other. Of a co-master pair, the active co-master is the one taking writes,
+// and the passive co-master is the other.
+type ClusterHeads struct {
+	ClusterName string
+	Active      *Instance
+	Passive     *Instance
+	// CountIndependentMasters is the number of instances replicating from no other; co-masters replicate from each other
+	CountIndependentMasters int
+	IsCoMaster              bool
+	BothWritable            bool
+}
+
+// Keys returns the keys of the heads, active first
+func (this *ClusterHeads) Keys() (keys []InstanceKey) {
+	keys = []InstanceKey{}
+	if this.Active != nil {
+		keys = append(keys, this.Active.Key)
+	}
+	if this.Passive != nil {
+		keys = append(keys, this.Passive.Key)
+	}
+	return keys
+}
+
+// SingleMaster returns the active head, or an error when it cannot be told which one head takes the writes:
+// there is no head, there are multiple independent masters, or both co-masters are writable
+func (this *ClusterHeads) SingleMaster() (*Instance, error) {
+	if this.Active == nil {
+		return nil, fmt.Errorf("Cannot deduce cluster master for %+v: no master found", this.ClusterName)
+	}
+	if this.CountIndependentMasters > 1 {
+		return nil, fmt.Errorf("Cannot deduce cluster master for %+v: found %d independent masters", this.ClusterName, this.CountIndependentMasters)
+	}
+	if this.BothWritable {
+		return nil, fmt.Errorf("Cannot deduce cluster master for %+v: co-masters %+v and %+v are both writable", this.ClusterName, this.Active.Key, this.Passive.Key)
+	}
+	return this.Active, nil
+}
+
+// GetClusterHeads finds the heads of a cluster out of its instances. Heads are ordered as ReadClusterMaster
+// orders them: writable first, then by replication depth. The first is the active head; with co-masters, the
+// co-master replicating from it, and from which it replicates, is the passive head.
+func GetClusterHeads(instances [](*Instance)) *ClusterHeads {
+	heads := &ClusterHeads{}
+	candidates := [](*Instance){}
+	for _, instance := range instances {
+		if instance.IsMaster() {
+			heads.CountIndependentMasters++
+		}
+		if instance.IsMaster() || instance.IsCoMaster {
+			candidates = append(candidates, instance)
+		}
+	}
+	if len(candidates) == 0 {
+		return heads
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].ReadOnly != candidates[j].ReadOnly {
+			return !candidates[i].ReadOnly
+		}
+		return candidates[i].ReplicationDepth < candidates[j].ReplicationDepth
+	})
+	heads.Active = candidates[0]
+	heads.ClusterName = heads.Active.ClusterName
+	if !heads.Active.IsCoMaster {
+		return heads
+	}
+	for _, candidate := range candidates[1:] {
+		if candidate.IsCoMaster && heads.Active.IsMasterOf(candidate) && candidate.IsMasterOf(heads.Active) {
+			heads.Passive = candidate
+			heads.IsCoMaster = true
+			heads.BothWritable = !heads.Active.ReadOnly && !heads.Passive.ReadOnly
+			break
+		}
+	}
+	return heads
+}
+
+// ReadClusterHeads reads the heads of given cluster: its master, or its active and passive co-masters
+func ReadClusterHeads(clusterName string) (*ClusterHeads, error) {
+	condition := `
+		cluster_name = ?
+		and (replication_depth = 0 or is_co_master)
+	`
+	instances, err := readInstancesByCondition(condition, sqlutils.Args(clusterName), "read_only asc, replication_depth asc")
+	if err != nil {
+		return nil, err
+	}
+	heads := GetClusterHeads(instances)
+	heads.ClusterName = clusterName
+	return heads, nil
+}
@@ -0,0 +1,79 @@
+package inst
+
+import (
+	"testing"
+
+	test "github.com/openark/golib/tests"
+)
+
+// newHeadsTestInstance returns an instance of cluster "c", replicating from given master unless it is empty
+func newHeadsTestInstance(key InstanceKey, masterKey InstanceKey, readOnly bool) *Instance {
+	instance := &Instance{Key: key, MasterKey: masterKey, ClusterName: "c", ReadOnly: readOnly}
+	if masterKey.IsValid() {
+		instance.ReadBinlogCoordinates = BinlogCoordinates{LogFile: "mysql-bin.000002", LogPos: 400}
+		instance.ReplicationDepth = 1
+	}
+	return instance
+}
+
+func TestGetClusterHeadsSingleMaster(t *testing.T) {
+	master := newHeadsTestInstance(key1, InstanceKey{}, false)
+	replica := newHeadsTestInstance(key2, key1, true)
+	heads := GetClusterHeads([]*Instance{replica, master})
+	test.S(t).ExpectEquals(heads.ClusterName, "c")
+	test.S(t).ExpectTrue(heads.Active == master)
+	test.S(t).ExpectTrue(heads.Passive == nil)
+	test.S(t).ExpectFalse(heads.IsCoMaster)
+	test.S(t).ExpectEquals(heads.CountIndependentMasters, 1)
+	test.S(t).ExpectEquals(len(heads.Keys()), 1)
+
+	singleMaster, err := heads.SingleMaster()
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectTrue(singleMaster == master)
+}
+
+func TestGetClusterHeadsCoMasters(t *testing.T) {
+	active := newHeadsTestInstance(key1, key2, false)
+	passive := newHeadsTestInstance(key2, key1, true)
+	replica := newHeadsTestInstance(key3, key1, true)
+	active.IsCoMaster = true
+	passive.IsCoMaster = true
+
+	heads := GetClusterHeads([]*Instance{passive, replica, active})
+	test.S(t).ExpectTrue(heads.IsCoMaster)
+	test.S(t).ExpectTrue(heads.Active == active)
+	test.S(t).ExpectTrue(heads.Passive == passive)
+	test.S(t).ExpectFalse(heads.BothWritable)
+	test.S(t).ExpectEquals(heads.CountIndependentMasters, 0)
+	test.S(t).ExpectEquals(len(heads.Keys()), 2)
+	test.S(t).ExpectTrue(heads.Keys()[1].Equals(&key2))
+	_, err := heads.SingleMaster()
+	test.S(t).ExpectNil(err)
+
+	// Writability tells which co-master is active
+	active.ReadOnly = true
+	passive.ReadOnly = false
+	heads = GetClusterHeads([]*Instance{active, passive, replica})
+	test.S(t).ExpectTrue(heads.Active == passive)
+	test.S(t).ExpectTrue(heads.Passive == active)
+
+	passive.ReadOnly = false
+	active.ReadOnly = false
+	heads = GetClusterHeads([]*Instance{active, passive, replica})
+	test.S(t).ExpectTrue(heads.BothWritable)
+	_, err = heads.SingleMaster()
+	test.S(t).ExpectNotNil(err)
+}
+
+func TestGetClusterHeadsMultipleMasters(t *testing.T) {
+	heads := GetClusterHeads([]*Instance{})
+	test.S(t).ExpectTrue(heads.Active == nil)
+	_, err := heads.SingleMaster()
+	test.S(t).ExpectNotNil(err)
+
+	heads = GetClusterHeads([]*Instance{newHeadsTestInstance(key1, InstanceKey{}, false), newHeadsTestInstance(key2, InstanceKey{}, true)})
+	test.S(t).ExpectTrue(heads.Active.Key.Equals(&key1))
+	test.S(t).ExpectEquals(heads.CountIndependentMasters, 2)
+	_, err = heads.SingleMaster()
+	test.S(t).ExpectNotNil(err)
+}
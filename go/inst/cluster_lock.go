@@ -0,0 +1,71 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"fmt"
+
+	"github.com/openark/orchestrator/go/config"
+)
+
+// ClusterLock is an advisory, time bounded lease on a cluster. It allows external automation
+// (and humans) to coordinate such that only one party mutates a cluster's topology at a time.
+type ClusterLock struct {
+	ClusterName    string
+	Owner          string
+	Reason         string
+	BeginTimestamp string
+	EndTimestamp   string
+	SecondsLeft    int
+}
+
+// ClusterLockRequest is a request to acquire or extend a cluster lock, as published to raft
+type ClusterLockRequest struct {
+	ClusterName     string
+	Owner           string
+	Reason          string
+	DurationSeconds uint
+}
+
+// Validate checks the request names a cluster and an owner
+func (this *ClusterLockRequest) Validate() error {
+	if this.ClusterName == "" {
+		return fmt.Errorf("Cluster lock: empty cluster name")
+	}
+	if this.Owner == "" {
+		return fmt.Errorf("Cluster lock: empty owner")
+	}
+	return nil
+}
+
+// clusterLockDurationSeconds returns the duration of a lock requested for given number of seconds, applying the
+// default when none is given
+func clusterLockDurationSeconds(durationSeconds uint) uint {
+	if durationSeconds == 0 {
+		return config.MaintenanceExpireMinutes * 60
+	}
+	return durationSeconds
+}
+
+// clusterLockHeldError returns the error of a lock on given cluster which could not be acquired, given the lock
+// held on it, if any
+func clusterLockHeldError(clusterName string, lock *ClusterLock) error {
+	if lock == nil {
+		return fmt.Errorf("Cannot acquire lock on cluster %s", clusterName)
+	}
+	return fmt.Errorf("Cluster %s is locked by %s until %s; reason: %s", clusterName, lock.Owner, lock.EndTimestamp, lock.Reason)
+}
@@ -0,0 +1,186 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"fmt"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/golib/sqlutils"
+	"github.com/openark/orchestrator/go/db"
+)
+
+// AcquireClusterLock attempts to take an advisory lock on given cluster, on behalf of given owner,
+// for given number of seconds. If the lock is already held by the same owner, it is extended.
+// An error is returned when the lock is held by another owner.
+func AcquireClusterLock(clusterName string, owner string, reason string, durationSeconds uint) (*ClusterLock, error) {
+	request := &ClusterLockRequest{ClusterName: clusterName, Owner: owner, Reason: reason, DurationSeconds: durationSeconds}
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	durationSeconds = clusterLockDurationSeconds(durationSeconds)
+	if _, err := db.ExecOrchestrator(`
+			delete from
+				cluster_lock
+			where
+				cluster_name = ?
+				and end_timestamp < NOW()
+			`,
+		clusterName,
+	); err != nil {
+		return nil, log.Errore(err)
+	}
+	// Attempt to renew our own lock
+	res, err := db.ExecOrchestrator(`
+			update
+				cluster_lock
+			set
+				reason = ?,
+				end_timestamp = NOW() + INTERVAL ? SECOND
+			where
+				cluster_name = ?
+				and owner = ?
+			`,
+		reason,
+		durationSeconds,
+		clusterName,
+		owner,
+	)
+	if err != nil {
+		return nil, log.Errore(err)
+	}
+	acquired := false
+	if affected, _ := res.RowsAffected(); affected > 0 {
+		acquired = true
+	} else {
+		res, err = db.ExecOrchestrator(`
+			insert ignore
+				into cluster_lock (
+					cluster_name, owner, reason, begin_timestamp, end_timestamp
+				) VALUES (
+					?, ?, ?, NOW(), NOW() + INTERVAL ? SECOND
+				)
+			`,
+			clusterName,
+			owner,
+			reason,
+			durationSeconds,
+		)
+		if err != nil {
+			return nil, log.Errore(err)
+		}
+		if affected, _ := res.RowsAffected(); affected > 0 {
+			acquired = true
+		}
+	}
+	lock, err := ReadClusterLock(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return lock, clusterLockHeldError(clusterName, lock)
+	}
+	AuditOperation("acquire-cluster-lock", nil, fmt.Sprintf("cluster: %s, owner: %s, duration: %ds, reason: %s", clusterName, owner, durationSeconds, reason))
+	return lock, nil
+}
+
+// ReleaseClusterLock releases a lock on given cluster, if held by given owner.
+func ReleaseClusterLock(clusterName string, owner string) (released bool, err error) {
+	res, err := db.ExecOrchestrator(`
+			delete from
+				cluster_lock
+			where
+				cluster_name = ?
+				and owner = ?
+			`,
+		clusterName,
+		owner,
+	)
+	if err != nil {
+		return released, log.Errore(err)
+	}
+	if affected, _ := res.RowsAffected(); affected > 0 {
+		released = true
+		AuditOperation("release-cluster-lock", nil, fmt.Sprintf("cluster: %s, owner: %s", clusterName, owner))
+	}
+	return released, nil
+}
+
+func readClusterLocks(whereCondition string, args []interface{}) ([]ClusterLock, error) {
+	res := []ClusterLock{}
+	query := fmt.Sprintf(`
+		select
+			cluster_name,
+			owner,
+			reason,
+			begin_timestamp,
+			end_timestamp,
+			unix_timestamp(end_timestamp) - unix_timestamp() as seconds_left
+		from
+			cluster_lock
+		where
+			end_timestamp >= NOW()
+			%s
+		order by
+			cluster_name
+		`, whereCondition)
+	err := db.QueryOrchestrator(query, args, func(m sqlutils.RowMap) error {
+		lock := ClusterLock{
+			ClusterName:    m.GetString("cluster_name"),
+			Owner:          m.GetString("owner"),
+			Reason:         m.GetString("reason"),
+			BeginTimestamp: m.GetString("begin_timestamp"),
+			EndTimestamp:   m.GetString("end_timestamp"),
+			SecondsLeft:    m.GetInt("seconds_left"),
+		}
+		res = append(res, lock)
+		return nil
+	})
+	return res, log.Errore(err)
+}
+
+// ReadClusterLocks returns all currently held cluster locks
+func ReadClusterLocks() ([]ClusterLock, error) {
+	return readClusterLocks("", sqlutils.Args())
+}
+
+// ReadClusterLock returns the lock currently held on given cluster, or nil if the cluster is not locked
+func ReadClusterLock(clusterName string) (*ClusterLock, error) {
+	locks, err := readClusterLocks("and cluster_name = ?", sqlutils.Args(clusterName))
+	if err != nil || len(locks) == 0 {
+		return nil, err
+	}
+	return &locks[0], nil
+}
+
+// ExpireClusterLocks removes locks whose lease has passed
+func ExpireClusterLocks() error {
+	res, err := db.ExecOrchestrator(`
+			delete from
+				cluster_lock
+			where
+				end_timestamp < NOW()
+			`,
+	)
+	if err != nil {
+		return log.Errore(err)
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected > 0 {
+		AuditOperation("expire-cluster-lock", nil, fmt.Sprintf("Expired: %d", rowsAffected))
+	}
+	return nil
+}
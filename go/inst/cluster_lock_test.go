@@ -0,0 +1,52 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"testing"
+
+	test "github.com/openark/golib/tests"
+)
+
+func TestClusterLockRequestValidate(t *testing.T) {
+	test.S(t).ExpectNil((&ClusterLockRequest{ClusterName: "c1", Owner: "deployer"}).Validate())
+	test.S(t).ExpectNil((&ClusterLockRequest{ClusterName: "c1", Owner: "deployer", Reason: "migration", DurationSeconds: 60}).Validate())
+	test.S(t).ExpectNotNil((&ClusterLockRequest{Owner: "deployer"}).Validate())
+	test.S(t).ExpectNotNil((&ClusterLockRequest{ClusterName: "c1"}).Validate())
+}
+
+func TestClusterLockDurationSeconds(t *testing.T) {
+	test.S(t).ExpectEquals(clusterLockDurationSeconds(0), uint(600))
+	test.S(t).ExpectEquals(clusterLockDurationSeconds(1), uint(1))
+	test.S(t).ExpectEquals(clusterLockDurationSeconds(1800), uint(1800))
+}
+
+func TestClusterLockHeldError(t *testing.T) {
+	lock := &ClusterLock{ClusterName: "c1", Owner: "deployer", Reason: "migration", EndTimestamp: "2026-10-16 10:00:00"}
+	test.S(t).ExpectEquals(clusterLockHeldError("c1", lock).Error(), "Cluster c1 is locked by deployer until 2026-10-16 10:00:00; reason: migration")
+	test.S(t).ExpectEquals(clusterLockHeldError("c1", nil).Error(), "Cannot acquire lock on cluster c1")
+}
+
+func TestAcquireClusterLockValidates(t *testing.T) {
+	lock, err := AcquireClusterLock("", "deployer", "migration", 60)
+	test.S(t).ExpectNotNil(err)
+	test.S(t).ExpectTrue(lock == nil)
+
+	lock, err = AcquireClusterLock("c1", "", "migration", 60)
+	test.S(t).ExpectNotNil(err)
+	test.S(t).ExpectTrue(lock == nil)
+}
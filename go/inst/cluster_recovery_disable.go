@@ -0,0 +1,67 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"time"
+)
+
+// ClusterRecoveryDisable suppresses automated recoveries on a single cluster, as opposed to
+// the global recovery switch. A disable with no end timestamp lasts until explicitly enabled.
+type ClusterRecoveryDisable struct {
+	ClusterName    string
+	Owner          string
+	Reason         string
+	BeginTimestamp string
+	EndTimestamp   string
+	SecondsLeft    int
+}
+
+// ClusterRecoveryDisableRequest is a request to disable recoveries on a cluster, as published to raft
+type ClusterRecoveryDisableRequest struct {
+	ClusterName     string
+	Owner           string
+	Reason          string
+	DurationSeconds uint
+}
+
+// clusterRecoveryDisableSet is the set of clusters with recoveries disabled, as read at a point in time
+type clusterRecoveryDisableSet struct {
+	disables map[string]ClusterRecoveryDisable
+	readAt   time.Time
+}
+
+func newClusterRecoveryDisableSet(disables []ClusterRecoveryDisable, readAt time.Time) *clusterRecoveryDisableSet {
+	set := &clusterRecoveryDisableSet{disables: make(map[string]ClusterRecoveryDisable), readAt: readAt}
+	for _, disable := range disables {
+		set.disables[disable.ClusterName] = disable
+	}
+	return set
+}
+
+// get returns the recovery suppression on given cluster as of given time, or nil if recoveries are enabled.
+// A timed suppression which has ended since the set was read no longer applies.
+func (set *clusterRecoveryDisableSet) get(clusterName string, now time.Time) *ClusterRecoveryDisable {
+	disable, found := set.disables[clusterName]
+	if !found {
+		return nil
+	}
+	if disable.EndTimestamp != "" && !now.Before(set.readAt.Add(time.Duration(disable.SecondsLeft)*time.Second)) {
+		return nil
+	}
+	return &disable
+}
@@ -0,0 +1,174 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/golib/sqlutils"
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/db"
+	"github.com/patrickmn/go-cache"
+)
+
+// clusterRecoveryDisablesCache holds the set of clusters with recoveries disabled, such that recovery checks read
+// it at most once per recovery poll rather than once per analysis entry. It is flushed upon changes made on this
+// node, which with raft are all changes.
+var clusterRecoveryDisablesCache = cache.New(time.Duration(config.RecoveryPollSeconds)*time.Second, time.Second)
+
+const clusterRecoveryDisablesCacheKey = "disables"
+
+// DisableClusterRecoveries suppresses automated recoveries on given cluster. A zero duration disables
+// recoveries until EnableClusterRecoveries is called. Disabling an already disabled cluster overwrites
+// the owner, reason and duration.
+func DisableClusterRecoveries(clusterName string, owner string, reason string, durationSeconds uint) (*ClusterRecoveryDisable, error) {
+	if clusterName == "" {
+		return nil, fmt.Errorf("DisableClusterRecoveries: empty cluster name")
+	}
+	if owner == "" {
+		return nil, fmt.Errorf("DisableClusterRecoveries: empty owner")
+	}
+	if reason == "" {
+		return nil, fmt.Errorf("DisableClusterRecoveries: empty reason")
+	}
+	endTimestamp := "NULL"
+	args := sqlutils.Args(clusterName, owner, reason)
+	if durationSeconds > 0 {
+		endTimestamp = "NOW() + INTERVAL ? SECOND"
+		args = append(args, durationSeconds)
+	}
+	query := fmt.Sprintf(`
+			replace
+				into cluster_recovery_disable (
+					cluster_name, owner, reason, begin_timestamp, end_timestamp
+				) VALUES (
+					?, ?, ?, NOW(), %s
+				)
+			`, endTimestamp)
+	if _, err := db.ExecOrchestrator(query, args...); err != nil {
+		return nil, log.Errore(err)
+	}
+	clusterRecoveryDisablesCache.Flush()
+	AuditOperation("disable-cluster-recoveries", nil, fmt.Sprintf("cluster: %s, owner: %s, duration: %ds, reason: %s", clusterName, owner, durationSeconds, reason))
+	return ReadClusterRecoveryDisable(clusterName)
+}
+
+// EnableClusterRecoveries lifts a per-cluster recovery suppression. It returns false when recoveries
+// were not disabled on given cluster to begin with.
+func EnableClusterRecoveries(clusterName string) (enabled bool, err error) {
+	res, err := db.ExecOrchestrator(`
+			delete from
+				cluster_recovery_disable
+			where
+				cluster_name = ?
+			`,
+		clusterName,
+	)
+	if err != nil {
+		return enabled, log.Errore(err)
+	}
+	if affected, _ := res.RowsAffected(); affected > 0 {
+		enabled = true
+		clusterRecoveryDisablesCache.Flush()
+		AuditOperation("enable-cluster-recoveries", nil, fmt.Sprintf("cluster: %s", clusterName))
+	}
+	return enabled, nil
+}
+
+func readClusterRecoveryDisables(whereCondition string, args []interface{}) ([]ClusterRecoveryDisable, error) {
+	res := []ClusterRecoveryDisable{}
+	query := fmt.Sprintf(`
+		select
+			cluster_name,
+			owner,
+			reason,
+			begin_timestamp,
+			ifnull(end_timestamp, '') as end_timestamp,
+			ifnull(unix_timestamp(end_timestamp) - unix_timestamp(), 0) as seconds_left
+		from
+			cluster_recovery_disable
+		where
+			(end_timestamp is null or end_timestamp >= NOW())
+			%s
+		order by
+			cluster_name
+		`, whereCondition)
+	err := db.QueryOrchestrator(query, args, func(m sqlutils.RowMap) error {
+		disable := ClusterRecoveryDisable{
+			ClusterName:    m.GetString("cluster_name"),
+			Owner:          m.GetString("owner"),
+			Reason:         m.GetString("reason"),
+			BeginTimestamp: m.GetString("begin_timestamp"),
+			EndTimestamp:   m.GetString("end_timestamp"),
+			SecondsLeft:    m.GetInt("seconds_left"),
+		}
+		res = append(res, disable)
+		return nil
+	})
+	return res, log.Errore(err)
+}
+
+// ReadClusterRecoveryDisables returns all clusters on which recoveries are currently disabled
+func ReadClusterRecoveryDisables() ([]ClusterRecoveryDisable, error) {
+	return readClusterRecoveryDisables("", sqlutils.Args())
+}
+
+// ReadClusterRecoveryDisable returns the recovery suppression on given cluster, or nil if recoveries are enabled
+func ReadClusterRecoveryDisable(clusterName string) (*ClusterRecoveryDisable, error) {
+	disables, err := readClusterRecoveryDisables("and cluster_name = ?", sqlutils.Args(clusterName))
+	if err != nil || len(disables) == 0 {
+		return nil, err
+	}
+	return &disables[0], nil
+}
+
+// ReadCachedClusterRecoveryDisable returns the recovery suppression on given cluster, or nil if recoveries are
+// enabled, based on the set of suppressions read at most a recovery poll ago
+func ReadCachedClusterRecoveryDisable(clusterName string) (*ClusterRecoveryDisable, error) {
+	if set, found := clusterRecoveryDisablesCache.Get(clusterRecoveryDisablesCacheKey); found {
+		return set.(*clusterRecoveryDisableSet).get(clusterName, time.Now()), nil
+	}
+	readAt := time.Now()
+	disables, err := ReadClusterRecoveryDisables()
+	if err != nil {
+		return nil, err
+	}
+	set := newClusterRecoveryDisableSet(disables, readAt)
+	clusterRecoveryDisablesCache.Set(clusterRecoveryDisablesCacheKey, set, cache.DefaultExpiration)
+	return set.get(clusterName, time.Now()), nil
+}
+
+// ExpireClusterRecoveryDisables removes timed recovery suppressions which have passed
+func ExpireClusterRecoveryDisables() error {
+	res, err := db.ExecOrchestrator(`
+			delete from
+				cluster_recovery_disable
+			where
+				end_timestamp < NOW()
+			`,
+	)
+	if err != nil {
+		return log.Errore(err)
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected > 0 {
+		clusterRecoveryDisablesCache.Flush()
+		AuditOperation("expire-cluster-recovery-disable", nil, fmt.Sprintf("Expired: %d", rowsAffected))
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"testing"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+
+	test "github.com/openark/golib/tests"
+)
+
+func TestClusterRecoveryDisableSet(t *testing.T) {
+	readAt := time.Now()
+	set := newClusterRecoveryDisableSet([]ClusterRecoveryDisable{
+		{ClusterName: "indefinite", Owner: "dba", Reason: "maintenance"},
+		{ClusterName: "timed", Owner: "dba", Reason: "migration", EndTimestamp: "2026-10-16 10:00:00", SecondsLeft: 60},
+	}, readAt)
+
+	// disabled
+	disable := set.get("indefinite", readAt.Add(time.Hour))
+	test.S(t).ExpectNotNil(disable)
+	test.S(t).ExpectEquals(disable.Owner, "dba")
+	test.S(t).ExpectNotNil(set.get("timed", readAt.Add(59*time.Second)))
+	// expired since read
+	test.S(t).ExpectTrue(set.get("timed", readAt.Add(60*time.Second)) == nil)
+	// enabled
+	test.S(t).ExpectTrue(set.get("other", readAt) == nil)
+}
+
+func TestReadCachedClusterRecoveryDisable(t *testing.T) {
+	defer clusterRecoveryDisablesCache.Flush()
+	set := newClusterRecoveryDisableSet([]ClusterRecoveryDisable{{ClusterName: "cluster1", Owner: "dba", Reason: "maintenance"}}, time.Now())
+	clusterRecoveryDisablesCache.Set(clusterRecoveryDisablesCacheKey, set, cache.DefaultExpiration)
+
+	disable, err := ReadCachedClusterRecoveryDisable("cluster1")
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectNotNil(disable)
+	test.S(t).ExpectEquals(disable.ClusterName, "cluster1")
+
+	disable, err = ReadCachedClusterRecoveryDisable("cluster2")
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectTrue(disable == nil)
+}
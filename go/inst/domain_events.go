@@ -0,0 +1,152 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"sync"
+	"time"
+
+	"github.com/openark/golib/log"
+)
+
+type DomainEventType string
+
+const (
+	RelocationPerformedEvent DomainEventType = "RelocationPerformed"
+	MasterPromotedEvent      DomainEventType = "MasterPromoted"
+	DowntimeStartedEvent     DomainEventType = "DowntimeStarted"
+	DowntimeEndedEvent       DomainEventType = "DowntimeEnded"
+	MaintenanceStartedEvent  DomainEventType = "MaintenanceStarted"
+	MaintenanceEndedEvent    DomainEventType = "MaintenanceEnded"
+	OperationPerformedEvent  DomainEventType = "OperationPerformed"
+)
+
+// auditDomainEventTypes maps audit types onto domain event types. Audited operations not listed
+// here are published as OperationPerformedEvent.
+var auditDomainEventTypes = map[string]DomainEventType{
+	"relocate-below":                 RelocationPerformedEvent,
+	"relocate-replicas":              RelocationPerformedEvent,
+	"move-up":                        RelocationPerformedEvent,
+	"move-up-replicas":               RelocationPerformedEvent,
+	"move-below":                     RelocationPerformedEvent,
+	"move-below-gtid":                RelocationPerformedEvent,
+	"move-replicas-gtid":             RelocationPerformedEvent,
+	"move-equivalent":                RelocationPerformedEvent,
+	"match-below":                    RelocationPerformedEvent,
+	"multi-match-replicas":           RelocationPerformedEvent,
+	"multi-match-below-independent":  RelocationPerformedEvent,
+	"regroup-replicas":               RelocationPerformedEvent,
+	"regroup-replicas-gtid":          RelocationPerformedEvent,
+	"regroup-replicas-bls":           RelocationPerformedEvent,
+	"regroup-replicas-including-bls": RelocationPerformedEvent,
+	"repoint":                        RelocationPerformedEvent,
+	"repoint-to":                     RelocationPerformedEvent,
+	"take-master":                    RelocationPerformedEvent,
+	"make-co-master":                 RelocationPerformedEvent,
+	"move-to-cluster":                RelocationPerformedEvent,
+	"make-master":                    MasterPromotedEvent,
+	"make-local-master":              MasterPromotedEvent,
+	"begin-downtime":                 DowntimeStartedEvent,
+	"end-downtime":                   DowntimeEndedEvent,
+	"expire-downtime":                DowntimeEndedEvent,
+	"begin-maintenance":              MaintenanceStartedEvent,
+	"end-maintenance":                MaintenanceEndedEvent,
+	"expire-maintenance":             MaintenanceEndedEvent,
+}
+
+// DomainEvent is published in-process whenever orchestrator performs an audited operation
+type DomainEvent struct {
+	Type        DomainEventType
+	AuditType   string
+	Key         InstanceKey
+	ClusterName string
+	Message     string
+	Timestamp   time.Time
+}
+
+// DomainEventSubscriber is notified of domain events. Subscribers are invoked synchronously, in
+// subscription order, by the goroutine performing the operation; they are expected to return quickly.
+type DomainEventSubscriber func(event DomainEvent)
+
+var domainEventSubscribers = make(map[int64]DomainEventSubscriber)
+var domainEventSubscriberIds = []int64{}
+var nextDomainEventSubscriberId int64
+var domainEventSubscribersMutex sync.Mutex
+
+// SubscribeDomainEvents registers a subscriber to domain events, and returns a function unregistering it
+func SubscribeDomainEvents(subscriber DomainEventSubscriber) (unsubscribe func()) {
+	domainEventSubscribersMutex.Lock()
+	defer domainEventSubscribersMutex.Unlock()
+
+	nextDomainEventSubscriberId++
+	id := nextDomainEventSubscriberId
+	domainEventSubscribers[id] = subscriber
+	domainEventSubscriberIds = append(domainEventSubscriberIds, id)
+
+	return func() {
+		domainEventSubscribersMutex.Lock()
+		defer domainEventSubscribersMutex.Unlock()
+
+		delete(domainEventSubscribers, id)
+		for i, subscriberId := range domainEventSubscriberIds {
+			if subscriberId == id {
+				domainEventSubscriberIds = append(domainEventSubscriberIds[:i], domainEventSubscriberIds[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// PublishDomainEvent notifies all subscribers of given event. A panicking subscriber does not affect
+// the operation nor other subscribers.
+func PublishDomainEvent(event DomainEvent) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	domainEventSubscribersMutex.Lock()
+	subscribers := []DomainEventSubscriber{}
+	for _, id := range domainEventSubscriberIds {
+		subscribers = append(subscribers, domainEventSubscribers[id])
+	}
+	domainEventSubscribersMutex.Unlock()
+
+	for _, subscriber := range subscribers {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Errorf("PublishDomainEvent: subscriber panic on %s event: %+v", event.Type, r)
+				}
+			}()
+			subscriber(event)
+		}()
+	}
+}
+
+// publishAuditDomainEvent publishes the domain event corresponding to an audited operation
+func publishAuditDomainEvent(auditType string, instanceKey *InstanceKey, clusterName string, message string) {
+	eventType, found := auditDomainEventTypes[auditType]
+	if !found {
+		eventType = OperationPerformedEvent
+	}
+	PublishDomainEvent(DomainEvent{
+		Type:        eventType,
+		AuditType:   auditType,
+		Key:         *instanceKey,
+		ClusterName: clusterName,
+		Message:     message,
+	})
+}
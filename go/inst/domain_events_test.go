@@ -0,0 +1,36 @@
+package inst
+
+import (
+	"testing"
+
+	test "github.com/openark/golib/tests"
+)
+
+func TestDomainEvents(t *testing.T) {
+	events := []DomainEvent{}
+	unsubscribe := SubscribeDomainEvents(func(event DomainEvent) {
+		events = append(events, event)
+	})
+	unsubscribePanic := SubscribeDomainEvents(func(event DomainEvent) {
+		panic("subscriber failure")
+	})
+	defer unsubscribePanic()
+
+	key := &InstanceKey{Hostname: "host1", Port: 3306}
+	publishAuditDomainEvent("relocate-below", key, "cluster1", "relocated")
+	publishAuditDomainEvent("begin-downtime", key, "cluster1", "owner: me")
+	publishAuditDomainEvent("flush-binary-logs", key, "cluster1", "flushed")
+
+	test.S(t).ExpectEquals(len(events), 3)
+	test.S(t).ExpectEquals(events[0].Type, RelocationPerformedEvent)
+	test.S(t).ExpectEquals(events[0].Key, *key)
+	test.S(t).ExpectEquals(events[0].ClusterName, "cluster1")
+	test.S(t).ExpectEquals(events[1].Type, DowntimeStartedEvent)
+	test.S(t).ExpectEquals(events[2].Type, OperationPerformedEvent)
+	test.S(t).ExpectEquals(events[2].AuditType, "flush-binary-logs")
+	test.S(t).ExpectFalse(events[2].Timestamp.IsZero())
+
+	unsubscribe()
+	publishAuditDomainEvent("relocate-below", key, "cluster1", "relocated")
+	test.S(t).ExpectEquals(len(events), 3)
+}
@@ -204,8 +204,8 @@ func ExpireDowntime() error {
 	return nil
 }
 
-func ReadDowntime() (result []Downtime, err error) {
-	query := `
+func readDowntime(whereCondition string, args []interface{}) (result []Downtime, err error) {
+	query := fmt.Sprintf(`
 		select
 			hostname,
 			port,
@@ -217,8 +217,11 @@ func ReadDowntime() (result []Downtime, err error) {
 			database_instance_downtime
 		where
 			end_timestamp > now()
-		`
-	err = db.QueryOrchestratorRowsMap(query, func(m sqlutils.RowMap) error {
+			%s
+		order by
+			end_timestamp
+		`, whereCondition)
+	err = db.QueryOrchestrator(query, args, func(m sqlutils.RowMap) error {
 		downtime := Downtime{
 			Key: &InstanceKey{},
 		}
@@ -238,3 +241,51 @@ func ReadDowntime() (result []Downtime, err error) {
 	})
 	return result, log.Errore(err)
 }
+
+// ReadDowntime returns all active downtimes
+func ReadDowntime() (result []Downtime, err error) {
+	return readDowntime("", sqlutils.Args())
+}
+
+// ReadDowntimeExpiringWithin returns active downtimes which are due to expire within given number of seconds
+func ReadDowntimeExpiringWithin(seconds int) (result []Downtime, err error) {
+	return readDowntime("and end_timestamp < now() + interval ? second", sqlutils.Args(seconds))
+}
+
+// ReadActiveDowntime returns the active downtime of given instance; nil when the instance is not downtimed
+func ReadActiveDowntime(instanceKey *InstanceKey) (*Downtime, error) {
+	downtimes, err := readDowntime("and downtime_active = 1 and hostname = ? and port = ?", sqlutils.Args(instanceKey.Hostname, instanceKey.Port))
+	if err != nil || len(downtimes) == 0 {
+		return nil, err
+	}
+	return &downtimes[0], nil
+}
+
+// ExtendDowntime pushes forward the end time of an active downtime by given duration,
+// retaining its owner and reason.
+func ExtendDowntime(downtime *Downtime) (wasDowntimed bool, err error) {
+	res, err := db.ExecOrchestrator(`
+			update
+				database_instance_downtime
+			set
+				end_timestamp = database_instance_downtime.end_timestamp + interval ? second
+			where
+				hostname = ?
+				and port = ?
+				and downtime_active = 1
+				and end_timestamp > now()
+			`,
+		int(downtime.Duration.Seconds()),
+		downtime.Key.Hostname,
+		downtime.Key.Port,
+	)
+	if err != nil {
+		return wasDowntimed, log.Errore(err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected > 0 {
+		wasDowntimed = true
+		AuditOperation("extend-downtime", downtime.Key, fmt.Sprintf("extended by: %+v", downtime.Duration))
+	}
+	return wasDowntimed, err
+}
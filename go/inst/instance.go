@@ -128,6 +128,8 @@ type Instance struct {
 
 	Problems []string
 
+	Extensions InstanceExtensions // Metadata by registered enrichers, e.g. cloud provider tags; see InstanceEnricher
+
 	LastDiscoveryLatency time.Duration
 
 	seed bool // Means we force this instance to be written to backend, even if it's invalid, empty or forgotten
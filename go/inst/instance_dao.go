@@ -200,6 +200,20 @@ func ExpireTableData(tableName string, timestampColumn string) error {
 	return ExecDBWriteFunc(writeFunc)
 }
 
+// PurgeTableData removes rows older than given seconds from given table, and returns the number of rows removed
+func PurgeTableData(tableName string, timestampColumn string, olderThanSeconds int) (rowsAffected int64, err error) {
+	query := fmt.Sprintf("delete from %s where %s < NOW() - INTERVAL ? SECOND", tableName, timestampColumn)
+	writeFunc := func() error {
+		res, err := db.ExecOrchestrator(query, olderThanSeconds)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err = res.RowsAffected()
+		return err
+	}
+	return rowsAffected, ExecDBWriteFunc(writeFunc)
+}
+
 // logReadTopologyInstanceError logs an error, if applicable, for a ReadTopologyInstance operation,
 // providing context and hint as for the source of the error. If there's no hint just provide the
 // original error.
@@ -1079,6 +1093,10 @@ Cleanup:
 	}()
 
 	if instanceFound {
+		latency.Start("backend")
+		overridesErr := applyInstanceMetadataOverrides(instance)
+		latency.Stop("backend")
+		logReadTopologyInstanceError(instanceKey, "applyInstanceMetadataOverrides", overridesErr)
 		if instance.IsCoMaster {
 			// Take co-master into account, and avoid infinite loop
 			instance.AncestryUUID = fmt.Sprintf("%s,%s", instance.MasterUUID, instance.ServerUUID)
@@ -1412,8 +1430,9 @@ func readInstanceRow(m sqlutils.RowMap) *Instance {
 	instance.IsCoMaster = m.GetBool("is_co_master")
 	instance.ReplicationCredentialsAvailable = m.GetBool("replication_credentials_available")
 	instance.HasReplicationCredentials = m.GetBool("has_replication_credentials")
-	instance.IsUpToDate = (m.GetUint("seconds_since_last_checked") <= config.Config.InstancePollSeconds)
-	instance.IsRecentlyChecked = (m.GetUint("seconds_since_last_checked") <= config.Config.InstancePollSeconds*5)
+	secondsSinceLastChecked := m.GetUint("seconds_since_last_checked")
+	instance.IsUpToDate = (secondsSinceLastChecked <= config.Config.InstancePollSeconds)
+	instance.IsRecentlyChecked = (secondsSinceLastChecked <= config.Config.InstancePollSeconds*5)
 	instance.LastSeenTimestamp = m.GetString("last_seen")
 	instance.IsLastCheckValid = m.GetBool("is_last_check_valid")
 	instance.SecondsSinceLastSeen = m.GetNullInt64("seconds_since_last_seen")
@@ -1511,6 +1530,7 @@ func readInstancesByCondition(condition string, args []interface{}, sort string)
 		if err != nil {
 			return instances, log.Errore(err)
 		}
+		EnrichInstances(instances)
 		return instances, err
 	}
 	instanceReadChan <- true
@@ -2454,7 +2474,7 @@ func ReadClustersInfo(clusterName string) ([]ClusterInfo, error) {
 		group by
 			cluster_name`, whereClause)
 
-	err := db.QueryOrchestrator(query, args, func(m sqlutils.RowMap) error {
+	err := db.QueryOrchestratorBuffered(query, args, func(m sqlutils.RowMap) error {
 		clusterInfo := ClusterInfo{
 			ClusterName:    m.GetString("cluster_name"),
 			CountInstances: m.GetUint("count_instances"),
@@ -2932,6 +2952,17 @@ func enqueueInstanceWrite(instance *Instance, instanceWasActuallyFound bool, las
 	instanceWriteBuffer <- instanceUpdateObject{instance, instanceWasActuallyFound, lastError}
 }
 
+// FlushInstanceWriteBuffer requests an immediate flush of the instance write buffer, and returns the number of
+// instances pending in the buffer. The request is ignored while a flush is already in progress.
+func FlushInstanceWriteBuffer() (pending int) {
+	pending = len(instanceWriteBuffer)
+	select {
+	case forceFlushInstanceWriteBuffer <- true:
+	default:
+	}
+	return pending
+}
+
 // flushInstanceWriteBuffer saves enqueued instances to Orchestrator Db
 func flushInstanceWriteBuffer() {
 	var instances []*Instance
@@ -3354,18 +3385,25 @@ func ResetInstanceRelaylogCoordinatesHistory(instanceKey *InstanceKey) error {
 // or an instanceKey. First attempt is at alias, and if that doesn't work, we try instanceKey.
 // - clusterHint may be an empty string
 func FigureClusterName(clusterHint string, instanceKey *InstanceKey, thisInstanceKey *InstanceKey) (clusterName string, err error) {
+	clusterName, _, err = figureClusterName(clusterHint, instanceKey, thisInstanceKey)
+	return clusterName, err
+}
+
+// figureClusterName deduces a cluster name as FigureClusterName does, and also returns the kind of match,
+// one of the ClusterHintResolvedBy* constants
+func figureClusterName(clusterHint string, instanceKey *InstanceKey, thisInstanceKey *InstanceKey) (clusterName string, resolvedBy string, err error) {
 	// Look for exact matches, first.
 
 	if clusterHint != "" {
 		// Exact cluster name match:
 		if clusterInfo, err := ReadClusterInfo(clusterHint); err == nil && clusterInfo != nil {
-			return clusterInfo.ClusterName, nil
+			return clusterInfo.ClusterName, ClusterHintResolvedByClusterName, nil
 		}
 		// Exact cluster alias match:
 		if clustersInfo, err := ReadClustersInfo(""); err == nil {
 			for _, clusterInfo := range clustersInfo {
 				if clusterInfo.ClusterAlias == clusterHint {
-					return clusterInfo.ClusterName, nil
+					return clusterInfo.ClusterName, ClusterHintResolvedByClusterAlias, nil
 				}
 			}
 		}
@@ -3389,17 +3427,40 @@ func FigureClusterName(clusterHint string, instanceKey *InstanceKey, thisInstanc
 	}
 	// exact instance key:
 	if hasResult, clusterName, err := clusterByInstanceKey(instanceKey); hasResult {
-		return clusterName, err
+		return clusterName, ClusterHintResolvedByInstance, err
 	}
 	// fuzzy instance key:
 	if hasResult, clusterName, err := clusterByInstanceKey(ReadFuzzyInstanceKeyIfPossible(instanceKey)); hasResult {
-		return clusterName, err
+		return clusterName, ClusterHintResolvedByFuzzyInstance, err
 	}
 	//  Let's see about _this_ instance
 	if hasResult, clusterName, err := clusterByInstanceKey(thisInstanceKey); hasResult {
-		return clusterName, err
+		return clusterName, ClusterHintResolvedByInstance, err
+	}
+	return clusterName, "", log.Errorf("Unable to determine cluster name. clusterHint=%+v", clusterHint)
+}
+
+// ResolveClusterHint resolves a cluster hint, which may be a cluster name, a cluster alias or an instance
+// (possibly a fuzzy hostname, e.g. without a domain), into its cluster. Matching is attempted in that order.
+func ResolveClusterHint(clusterHint string) (*ClusterHintResolution, error) {
+	if clusterHint == "" {
+		return nil, fmt.Errorf("Unable to determine cluster name by empty hint")
+	}
+	instanceKey, _ := ParseRawInstanceKey(clusterHint)
+	clusterName, resolvedBy, err := figureClusterName(clusterHint, instanceKey, nil)
+	if err != nil {
+		return nil, err
+	}
+	resolution := &ClusterHintResolution{
+		ClusterHint: clusterHint,
+		ClusterName: clusterName,
+		ResolvedBy:  resolvedBy,
+	}
+	if clusterInfo, err := ReadClusterInfo(clusterName); err == nil && clusterInfo != nil {
+		resolution.ClusterAlias = clusterInfo.ClusterAlias
+		resolution.ClusterDomain = clusterInfo.ClusterDomain
 	}
-	return clusterName, log.Errorf("Unable to determine cluster name. clusterHint=%+v", clusterHint)
+	return resolution, nil
 }
 
 // FigureInstanceKey tries to figure out a key
@@ -2,11 +2,13 @@ package inst
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/openark/golib/sqlutils"
 	test "github.com/openark/golib/tests"
 )
 
@@ -115,3 +117,109 @@ func fmtArgs(args []interface{}) string {
 	}
 	return b.String()
 }
+
+// mkTestInstanceRows returns backend rows of a fleet of given size: a master per 10 instances, replicated by the others
+func mkTestInstanceRows(count int) []sqlutils.RowMap {
+	cell := func(value string) sqlutils.CellData {
+		return sqlutils.CellData{String: value, Valid: true}
+	}
+	// Numeric columns are NOT NULL in the backend: they default to 0
+	numericColumns := strings.Fields(`
+		allow_tls binary_log_pos elapsed_downtime_seconds exec_master_log_pos has_replication_credentials
+		has_replication_filters is_candidate is_co_master is_downtimed is_last_check_valid last_discovery_latency
+		log_bin log_slave_updates mariadb_gtid master_port oracle_gtid port pseudo_gtid read_master_log_pos read_only
+		relay_log_pos replication_credentials_available replication_depth replication_group_is_single_primary_mode
+		replication_group_primary_port replication_io_thread_state replication_sql_thread_state
+		seconds_since_last_checked semi_sync_available semi_sync_enforced semi_sync_master_clients
+		semi_sync_master_enabled semi_sync_master_status semi_sync_master_timeout semi_sync_master_wait_for_slave_count
+		semi_sync_replica_enabled semi_sync_replica_status server_id slave_io_running slave_sql_running sql_delay
+		supports_oracle_gtid uptime`)
+	rows := make([]sqlutils.RowMap, 0, count)
+	for i := 0; i < count; i++ {
+		masterIndex := i - i%10
+		row := sqlutils.RowMap{}
+		for _, column := range numericColumns {
+			row[column] = cell("0")
+		}
+		for column, value := range map[string]sqlutils.CellData{
+			"hostname":                   cell(fmt.Sprintf("db-%05d.example.com", i)),
+			"port":                       cell("3306"),
+			"server_id":                  cell(fmt.Sprintf("%d", i+1)),
+			"server_uuid":                cell("00020192-1111-1111-1111-111111111111"),
+			"version":                    cell("8.0.36"),
+			"version_comment":            cell("MySQL Community Server - GPL"),
+			"binlog_format":              cell("ROW"),
+			"log_bin":                    cell("1"),
+			"log_slave_updates":          cell("1"),
+			"binary_log_file":            cell("mysql-bin.000123"),
+			"binary_log_pos":             cell("123456789"),
+			"oracle_gtid":                cell("1"),
+			"executed_gtid_set":          cell("00020192-1111-1111-1111-111111111111:1-1234567"),
+			"cluster_name":               cell(fmt.Sprintf("db-%05d.example.com:3306", masterIndex)),
+			"data_center":                cell("dc1"),
+			"seconds_since_last_checked": cell("1"),
+			"seconds_since_last_seen":    cell("1"),
+			"is_last_check_valid":        cell("1"),
+			"last_seen":                  cell("2026-01-01 00:00:00"),
+			"promotion_rule":             cell("neutral"),
+			"slave_hosts":                cell("[]"),
+			"replication_group_members":  cell("[]"),
+		} {
+			row[column] = value
+		}
+		if i == masterIndex {
+			replicas := []InstanceKey{}
+			for r := i + 1; r < i+10; r++ {
+				replicas = append(replicas, InstanceKey{Hostname: fmt.Sprintf("db-%05d.example.com", r), Port: 3306})
+			}
+			replicasJSON, _ := json.Marshal(replicas)
+			row["slave_hosts"] = cell(string(replicasJSON))
+			row["num_slave_hosts"] = cell("9")
+		} else {
+			row["master_host"] = cell(fmt.Sprintf("db-%05d.example.com", masterIndex))
+			row["master_port"] = cell("3306")
+			row["slave_io_running"] = cell("1")
+			row["slave_sql_running"] = cell("1")
+			row["master_log_file"] = cell("mysql-bin.000123")
+			row["read_master_log_pos"] = cell("123456789")
+			row["relay_master_log_file"] = cell("mysql-bin.000123")
+			row["exec_master_log_pos"] = cell("123456789")
+			row["seconds_behind_master"] = cell("0")
+			row["slave_lag_seconds"] = cell("0")
+			row["replication_depth"] = cell("1")
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func BenchmarkReadInstanceRows(b *testing.B) {
+	rows := mkTestInstanceRows(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		instances := [](*Instance){}
+		for _, row := range rows {
+			instances = append(instances, readInstanceRow(row))
+		}
+	}
+}
+
+func BenchmarkInstancesJSONDecode(b *testing.B) {
+	instances := [](*Instance){}
+	for _, row := range mkTestInstanceRows(10000) {
+		instances = append(instances, readInstanceRow(row))
+	}
+	instancesJSON, err := json.Marshal(instances)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		decoded := [](*Instance){}
+		if err := json.Unmarshal(instancesJSON, &decoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
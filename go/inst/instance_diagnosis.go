@@ -0,0 +1,197 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ReplicationErrorClass is the likely cause of a replication error
+type ReplicationErrorClass string
+
+const (
+	ReplicationErrorAuth         ReplicationErrorClass = "auth"
+	ReplicationErrorNetwork      ReplicationErrorClass = "network"
+	ReplicationErrorPurgedBinlog ReplicationErrorClass = "purged_binlog"
+	ReplicationErrorDuplicateKey ReplicationErrorClass = "duplicate_key"
+	ReplicationErrorMissingRow   ReplicationErrorClass = "missing_row"
+	ReplicationErrorRelayLog     ReplicationErrorClass = "relay_log"
+	ReplicationErrorOther        ReplicationErrorClass = "other"
+)
+
+// replicationErrorClassifiers match error messages to classes, in order. MySQL only exposes the message of the
+// last IO/SQL error, hence error numbers are matched where they appear in messages.
+var replicationErrorClassifiers = []struct {
+	class   ReplicationErrorClass
+	pattern *regexp.Regexp
+}{
+	{ReplicationErrorAuth, regexp.MustCompile(`(?i)access denied|authentication (plugin|requires)|\b1045\b|\b2061\b`)},
+	{ReplicationErrorPurgedBinlog, regexp.MustCompile(`(?i)could not find first log file|purged binary logs|purged required binary logs|\b1236\b`)},
+	{ReplicationErrorDuplicateKey, regexp.MustCompile(`(?i)duplicate entry|HA_ERR_FOUND_DUPP_KEY|\b1062\b`)},
+	{ReplicationErrorMissingRow, regexp.MustCompile(`(?i)can't find record|HA_ERR_KEY_NOT_FOUND|HA_ERR_END_OF_FILE|\b1032\b`)},
+	{ReplicationErrorRelayLog, regexp.MustCompile(`(?i)relay log read failure|relay log write failure|corrupted|\b1594\b|\b1595\b`)},
+	{ReplicationErrorNetwork, regexp.MustCompile(`(?i)error (connecting|reconnecting) to (master|source)|can't connect|lost connection|unknown mysql server host|timed out|timeout|network|\b200[0-9]\b|\b2013\b`)},
+}
+
+// ClassifyReplicationError returns the likely cause of a replication error message
+func ClassifyReplicationError(errorMessage string) ReplicationErrorClass {
+	for _, classifier := range replicationErrorClassifiers {
+		if classifier.pattern.MatchString(errorMessage) {
+			return classifier.class
+		}
+	}
+	return ReplicationErrorOther
+}
+
+// ReplicationErrorDiagnosis is a classified replication error
+type ReplicationErrorDiagnosis struct {
+	Thread string // "IO" or "SQL"
+	Error  string
+	Class  ReplicationErrorClass
+}
+
+// Remediation is a recommended action for a problem. Command and APIPath are orchestrator-client and API calls
+// taking it, if any; as returned by SuggestRemediation they are templates with {host}, {port}, {instance},
+// {masterHost}, {masterPort} and {master} placeholders.
+type Remediation struct {
+	Description string
+	Command     string
+	APIPath     string
+}
+
+// ForInstance fills a remediation's placeholders with given instance's details
+func (this Remediation) ForInstance(instance *Instance) Remediation {
+	replacer := strings.NewReplacer(
+		"{host}", instance.Key.Hostname,
+		"{port}", fmt.Sprintf("%d", instance.Key.Port),
+		"{instance}", instance.Key.StringCode(),
+		"{masterHost}", instance.MasterKey.Hostname,
+		"{masterPort}", fmt.Sprintf("%d", instance.MasterKey.Port),
+		"{master}", instance.MasterKey.StringCode(),
+	)
+	return Remediation{
+		Description: this.Description,
+		Command:     replacer.Replace(this.Command),
+		APIPath:     replacer.Replace(this.APIPath),
+	}
+}
+
+var remediations = map[string][]Remediation{
+	string(ReplicationErrorAuth): {
+		{Description: "The replication user cannot authenticate on the master: fix its password (as per MASTER_USER/MASTER_PASSWORD) or grants, or its authentication plugin, e.g. caching_sha2_password requiring TLS or GET_MASTER_PUBLIC_KEY. Then restart replication"},
+		{Command: "orchestrator-client -c restart-replica -i {instance}", APIPath: "restart-replica/{host}/{port}", Description: "Restart replication once credentials are fixed"},
+	},
+	string(ReplicationErrorNetwork): {
+		{Description: "The replica cannot reach its master: check the master is up, and DNS, firewall and max_connections on the master. The IO thread retries by itself, as per MASTER_CONNECT_RETRY"},
+		{Command: "orchestrator-client -c discover -i {master}", APIPath: "discover/{masterHost}/{masterPort}", Description: "Have orchestrator probe the master"},
+	},
+	string(ReplicationErrorPurgedBinlog): {
+		{Description: "The master no longer has binary logs the replica requires. Relocate the replica below a server which has them (via GTID or Pseudo-GTID), or reclone it"},
+		{Command: "orchestrator-client -c relocate -i {instance} -d <destination>", APIPath: "relocate/{host}/{port}/<destinationHost>/<destinationPort>", Description: "Relocate below another server"},
+	},
+	string(ReplicationErrorDuplicateKey): {
+		{Description: "A replicated row already exists on the replica: the replica's data has drifted from the master's, e.g. by writes to a writable replica. Verify read_only, and compare data. Skip the event only if it is known to be safe to do so, otherwise reclone"},
+		{Command: "orchestrator-client -c skip-query -i {instance}", APIPath: "skip-query/{host}/{port}", Description: "Skip the failing event (data drift remains)"},
+	},
+	string(ReplicationErrorMissingRow): {
+		{Description: "A replicated row is missing on the replica: the replica's data has drifted from the master's. Compare data. Skip the event only if it is known to be safe to do so, otherwise reclone"},
+		{Command: "orchestrator-client -c skip-query -i {instance}", APIPath: "skip-query/{host}/{port}", Description: "Skip the failing event (data drift remains)"},
+	},
+	string(ReplicationErrorRelayLog): {
+		{Description: "The replica's relay logs are unreadable. Re-point the replica at its own master, at its executed coordinates, to discard and re-fetch relay logs"},
+		{Command: "orchestrator-client -c repoint -i {instance} -d {master}", APIPath: "repoint/{host}/{port}/{masterHost}/{masterPort}", Description: "Re-fetch relay logs from the master"},
+	},
+	"not_replicating": {
+		{Command: "orchestrator-client -c start-replica -i {instance}", APIPath: "start-replica/{host}/{port}", Description: "Replication is stopped, without error: start it, unless it is stopped intentionally"},
+	},
+	"replication_lag": {
+		{Description: "The replica lags beyond ReasonableReplicationLagSeconds. Check its load, and whether it applies large transactions or runs with low replica_parallel_workers"},
+	},
+	"errant_gtid": {
+		{Description: "The replica has transactions its master does not. Inspect them: if they are known to be safe, inject empty transactions on the master, or reset the replica's master (losing its binary logs)"},
+		{Command: "orchestrator-client -c gtid-errant-inject-empty -i {instance}", APIPath: "gtid-errant-inject-empty/{host}/{port}", Description: "Inject empty transactions on the master"},
+		{Command: "orchestrator-client -c gtid-errant-reset-master -i {instance}", APIPath: "gtid-errant-reset-master/{host}/{port}", Description: "Reset master on the replica"},
+	},
+	"last_check_invalid": {
+		{Description: "orchestrator cannot access the instance: check it is up, and orchestrator's MySQLTopologyUser credentials and grants"},
+		{Command: "orchestrator-client -c discover -i {instance}", APIPath: "discover/{host}/{port}", Description: "Have orchestrator probe the instance"},
+	},
+	"not_recently_checked": {
+		{Description: "orchestrator has not polled the instance recently: check orchestrator's discovery queue and health"},
+		{Command: "orchestrator-client -c discover -i {instance}", APIPath: "discover/{host}/{port}", Description: "Have orchestrator probe the instance"},
+	},
+	"group_replication_member_not_online": {
+		{Description: "The instance is not an ONLINE member of its replication group: check its group replication status and error log"},
+	},
+}
+
+// SuggestRemediation maps a problem, being either an instance problem (as in Instance.Problems, e.g.
+// "not_replicating") or a ReplicationErrorClass, to recommended actions. It returns none for unknown problems.
+func SuggestRemediation(problem string) []Remediation {
+	return remediations[problem]
+}
+
+// InstanceDiagnosis is an instance's problems, with their likely causes and recommended actions
+type InstanceDiagnosis struct {
+	Key                InstanceKey
+	MasterKey          InstanceKey
+	ReplicationRunning bool
+	Problems           []string // the instance's problems, followed by classes of its replication errors
+	Errors             []ReplicationErrorDiagnosis
+	Remediations       []Remediation
+}
+
+// DiagnoseInstance classifies an instance's replication errors, and suggests remediations to its problems
+func DiagnoseInstance(instance *Instance) *InstanceDiagnosis {
+	diagnosis := &InstanceDiagnosis{
+		Key:                instance.Key,
+		MasterKey:          instance.MasterKey,
+		ReplicationRunning: instance.ReplicaRunning(),
+		Problems:           append([]string{}, instance.Problems...),
+		Errors:             []ReplicationErrorDiagnosis{},
+		Remediations:       []Remediation{},
+	}
+	for _, replicationError := range []ReplicationErrorDiagnosis{
+		{Thread: "IO", Error: instance.LastIOError},
+		{Thread: "SQL", Error: instance.LastSQLError},
+	} {
+		if replicationError.Error == "" {
+			continue
+		}
+		replicationError.Class = ClassifyReplicationError(replicationError.Error)
+		diagnosis.Errors = append(diagnosis.Errors, replicationError)
+		diagnosis.Problems = append(diagnosis.Problems, string(replicationError.Class))
+	}
+	if len(diagnosis.Errors) > 0 {
+		// Replication errors explain replication being stopped; "not_replicating" would only suggest restarting it
+		problems := []string{}
+		for _, problem := range diagnosis.Problems {
+			if problem != "not_replicating" {
+				problems = append(problems, problem)
+			}
+		}
+		diagnosis.Problems = problems
+	}
+	for _, problem := range diagnosis.Problems {
+		for _, remediation := range SuggestRemediation(problem) {
+			diagnosis.Remediations = append(diagnosis.Remediations, remediation.ForInstance(instance))
+		}
+	}
+	return diagnosis
+}
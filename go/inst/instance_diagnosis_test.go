@@ -0,0 +1,46 @@
+package inst
+
+import (
+	"testing"
+
+	test "github.com/openark/golib/tests"
+)
+
+func TestClassifyReplicationError(t *testing.T) {
+	test.S(t).ExpectEquals(ClassifyReplicationError("error connecting to master 'repl@db-1:3306' - retry-time: 60 retries: 1 message: Access denied for user 'repl'@'10.0.0.2' (using password: YES)"), ReplicationErrorAuth)
+	test.S(t).ExpectEquals(ClassifyReplicationError("error connecting to master 'repl@db-1:3306' - retry-time: 60 retries: 3 message: Can't connect to MySQL server on 'db-1' (111)"), ReplicationErrorNetwork)
+	test.S(t).ExpectEquals(ClassifyReplicationError("Got fatal error 1236 from master when reading data from binary log: 'Could not find first log file name in binary log index file'"), ReplicationErrorPurgedBinlog)
+	test.S(t).ExpectEquals(ClassifyReplicationError("Could not execute Write_rows event on table db.t; Duplicate entry '7' for key 'PRIMARY', Error_code: 1062; handler error HA_ERR_FOUND_DUPP_KEY"), ReplicationErrorDuplicateKey)
+	test.S(t).ExpectEquals(ClassifyReplicationError("Could not execute Delete_rows event on table db.t; Can't find record in 't', Error_code: 1032; handler error HA_ERR_KEY_NOT_FOUND"), ReplicationErrorMissingRow)
+	test.S(t).ExpectEquals(ClassifyReplicationError("Relay log read failure: Could not parse relay log event entry."), ReplicationErrorRelayLog)
+	test.S(t).ExpectEquals(ClassifyReplicationError("Error 'Table 'db.t' doesn't exist' on query."), ReplicationErrorOther)
+}
+
+func TestDiagnoseInstance(t *testing.T) {
+	{
+		i := Instance{Key: key1, MasterKey: key2, Problems: []string{"not_replicating"}}
+		i.LastSQLError = "Could not execute Write_rows event on table db.t; Duplicate entry '7' for key 'PRIMARY', Error_code: 1062"
+		diagnosis := DiagnoseInstance(&i)
+		test.S(t).ExpectEquals(len(diagnosis.Errors), 1)
+		test.S(t).ExpectEquals(diagnosis.Errors[0].Thread, "SQL")
+		test.S(t).ExpectEquals(diagnosis.Errors[0].Class, ReplicationErrorDuplicateKey)
+		test.S(t).ExpectEquals(len(diagnosis.Problems), 1)
+		test.S(t).ExpectEquals(diagnosis.Problems[0], "duplicate_key")
+		test.S(t).ExpectEquals(len(diagnosis.Remediations), len(SuggestRemediation("duplicate_key")))
+		test.S(t).ExpectEquals(diagnosis.Remediations[1].Command, "orchestrator-client -c skip-query -i host1:3306")
+		test.S(t).ExpectEquals(diagnosis.Remediations[1].APIPath, "skip-query/host1/3306")
+	}
+	{
+		i := Instance{Key: key1, MasterKey: key2, Problems: []string{"not_replicating"}}
+		diagnosis := DiagnoseInstance(&i)
+		test.S(t).ExpectEquals(len(diagnosis.Errors), 0)
+		test.S(t).ExpectEquals(diagnosis.Remediations[0].APIPath, "start-replica/host1/3306")
+	}
+	{
+		i := Instance{Key: key1, MasterKey: key2}
+		i.LastSQLError = "Relay log read failure"
+		diagnosis := DiagnoseInstance(&i)
+		test.S(t).ExpectEquals(diagnosis.Remediations[1].APIPath, "repoint/host1/3306/host2/3306")
+	}
+	test.S(t).ExpectEquals(len(SuggestRemediation("no_such_problem")), 0)
+}
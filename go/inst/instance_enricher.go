@@ -0,0 +1,211 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"encoding/json"
+	"fmt"
+	goos "os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/os"
+	"github.com/patrickmn/go-cache"
+)
+
+const (
+	instanceEnrichmentTimeout     = 10 * time.Second
+	maxConcurrentInstanceEnriches = 8
+)
+
+// InstanceExtensions is metadata annotated onto an instance by enrichers, by enricher name
+type InstanceExtensions map[string]map[string]string
+
+// InstanceEnricher annotates instances with metadata orchestrator does not otherwise know of, such as
+// cloud provider tags or CMDB records. It may be slow: instances are enriched in the background, and
+// enrichments are cached for InstanceEnrichmentSeconds.
+type InstanceEnricher interface {
+	Enrich(instance *Instance) (map[string]string, error)
+}
+
+// InstanceEnricherFunc adapts a function to an InstanceEnricher
+type InstanceEnricherFunc func(instance *Instance) (map[string]string, error)
+
+func (this InstanceEnricherFunc) Enrich(instance *Instance) (map[string]string, error) {
+	return this(instance)
+}
+
+// commandInstanceEnricher runs a configured command, which prints a JSON object of string values
+type commandInstanceEnricher struct {
+	command string
+}
+
+func (this *commandInstanceEnricher) Enrich(instance *Instance) (map[string]string, error) {
+	command := this.command
+	command = strings.Replace(command, "{hostname}", instance.Key.Hostname, -1)
+	command = strings.Replace(command, "{port}", fmt.Sprintf("%d", instance.Key.Port), -1)
+
+	env := goos.Environ()
+	env = append(env, fmt.Sprintf("ORC_INSTANCE_HOST=%s", instance.Key.Hostname))
+	env = append(env, fmt.Sprintf("ORC_INSTANCE_PORT=%d", instance.Key.Port))
+	env = append(env, fmt.Sprintf("ORC_CLUSTER_NAME=%s", instance.ClusterName))
+	env = append(env, fmt.Sprintf("ORC_DATA_CENTER=%s", instance.DataCenter))
+	env = append(env, fmt.Sprintf("ORC_REGION=%s", instance.Region))
+
+	output, err := os.CommandOutput(command, env, instanceEnrichmentTimeout)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string)
+	if err := json.Unmarshal(output, &values); err != nil {
+		return nil, fmt.Errorf("expected a JSON object of string values; %+v", err)
+	}
+	return values, nil
+}
+
+// instanceEnrichment is a cached result of an enricher
+type instanceEnrichment struct {
+	values     map[string]string
+	enrichedAt time.Time
+}
+
+var instanceEnrichersMutex sync.Mutex
+var instanceEnrichersInitOnce sync.Once
+var instanceEnrichers = make(map[string]InstanceEnricher)
+var instanceEnrichmentCache = cache.New(time.Hour, time.Minute)
+var instanceEnrichmentsInProgress = make(map[string]bool)
+var instanceEnrichmentSemaphore = make(chan bool, maxConcurrentInstanceEnriches)
+
+// RegisterInstanceEnricher registers an enricher under given name, by which its metadata is found in
+// Instance.Extensions. It replaces any enricher of the same name, including one of InstanceEnricherCommands.
+func RegisterInstanceEnricher(name string, enricher InstanceEnricher) {
+	initInstanceEnrichers()
+	instanceEnrichersMutex.Lock()
+	defer instanceEnrichersMutex.Unlock()
+
+	instanceEnrichers[name] = enricher
+}
+
+// initInstanceEnrichers registers InstanceEnricherCommands, once in the lifetime of this app
+func initInstanceEnrichers() {
+	instanceEnrichersInitOnce.Do(func() {
+		instanceEnrichersMutex.Lock()
+		defer instanceEnrichersMutex.Unlock()
+
+		for name, command := range config.Config.InstanceEnricherCommands {
+			instanceEnrichers[name] = &commandInstanceEnricher{command: command}
+		}
+	})
+}
+
+// InstanceEnricherNames returns the names of registered enrichers, sorted
+func InstanceEnricherNames() (names []string) {
+	initInstanceEnrichers()
+	instanceEnrichersMutex.Lock()
+	defer instanceEnrichersMutex.Unlock()
+
+	for name := range instanceEnrichers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func getInstanceEnrichers() map[string]InstanceEnricher {
+	initInstanceEnrichers()
+	instanceEnrichersMutex.Lock()
+	defer instanceEnrichersMutex.Unlock()
+
+	enrichers := make(map[string]InstanceEnricher)
+	for name, enricher := range instanceEnrichers {
+		enrichers[name] = enricher
+	}
+	return enrichers
+}
+
+func instanceEnrichmentCacheKey(name string, instanceKey *InstanceKey) string {
+	return fmt.Sprintf("%s/%s", name, instanceKey.StringCode())
+}
+
+// enrichInstance runs an enricher on an instance and caches its result. A failed enrichment keeps the
+// previously cached result, if any.
+func enrichInstance(name string, enricher InstanceEnricher, instance *Instance) error {
+	values, err := enricher.Enrich(instance)
+	if err != nil {
+		return fmt.Errorf("enricher %s failed on %+v: %+v", name, instance.Key, err)
+	}
+	ttl := time.Duration(config.Config.InstanceEnrichmentSeconds) * time.Second
+	// Kept beyond its refresh time, so that instances do not lose their extensions while being refreshed
+	instanceEnrichmentCache.Set(instanceEnrichmentCacheKey(name, &instance.Key), &instanceEnrichment{values: values, enrichedAt: time.Now()}, 3*ttl)
+	return nil
+}
+
+// enrichInstanceInBackground enriches an instance asynchronously, unless it is already being enriched
+func enrichInstanceInBackground(name string, enricher InstanceEnricher, instance *Instance) {
+	cacheKey := instanceEnrichmentCacheKey(name, &instance.Key)
+	instanceEnrichersMutex.Lock()
+	defer instanceEnrichersMutex.Unlock()
+	if instanceEnrichmentsInProgress[cacheKey] {
+		return
+	}
+	instanceEnrichmentsInProgress[cacheKey] = true
+
+	// The enricher is given a copy: the instance is owned by the caller
+	instanceCopy := *instance
+	go func() {
+		instanceEnrichmentSemaphore <- true
+		defer func() { <-instanceEnrichmentSemaphore }()
+		defer func() {
+			instanceEnrichersMutex.Lock()
+			defer instanceEnrichersMutex.Unlock()
+			delete(instanceEnrichmentsInProgress, cacheKey)
+		}()
+		if err := enrichInstance(name, enricher, &instanceCopy); err != nil {
+			log.Errore(err)
+		}
+	}()
+}
+
+// EnrichInstances sets the Extensions of given instances from the cached results of registered enrichers.
+// Instances not yet enriched, or whose enrichment is due for a refresh, are enriched in the background, so
+// that reading instances never waits on enrichers.
+func EnrichInstances(instances [](*Instance)) {
+	enrichers := getInstanceEnrichers()
+	if len(enrichers) == 0 {
+		return
+	}
+	ttl := time.Duration(config.Config.InstanceEnrichmentSeconds) * time.Second
+	for _, instance := range instances {
+		for name, enricher := range enrichers {
+			cached, found := instanceEnrichmentCache.Get(instanceEnrichmentCacheKey(name, &instance.Key))
+			if !found || time.Since(cached.(*instanceEnrichment).enrichedAt) >= ttl {
+				enrichInstanceInBackground(name, enricher, instance)
+			}
+			if !found {
+				continue
+			}
+			if instance.Extensions == nil {
+				instance.Extensions = make(InstanceExtensions)
+			}
+			instance.Extensions[name] = cached.(*instanceEnrichment).values
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package inst
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	test "github.com/openark/golib/tests"
+)
+
+func TestCommandInstanceEnricher(t *testing.T) {
+	instance := NewInstance()
+	instance.Key = InstanceKey{Hostname: "db-1", Port: 3306}
+	instance.DataCenter = "dc1"
+
+	enricher := &commandInstanceEnricher{command: `echo "{\"host\": \"{hostname}:{port}\", \"dc\": \"$ORC_DATA_CENTER\"}"`}
+	values, err := enricher.Enrich(instance)
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(values["host"], "db-1:3306")
+	test.S(t).ExpectEquals(values["dc"], "dc1")
+
+	_, err = (&commandInstanceEnricher{command: `echo not-json`}).Enrich(instance)
+	test.S(t).ExpectNotNil(err)
+	_, err = (&commandInstanceEnricher{command: `exit 1`}).Enrich(instance)
+	test.S(t).ExpectNotNil(err)
+}
+
+func TestEnrichInstances(t *testing.T) {
+	var countEnriches int32
+	RegisterInstanceEnricher("test-cmdb", InstanceEnricherFunc(func(instance *Instance) (map[string]string, error) {
+		atomic.AddInt32(&countEnriches, 1)
+		if instance.Key.Hostname == "db-unknown" {
+			return nil, fmt.Errorf("not found")
+		}
+		return map[string]string{"owner": "team-" + instance.Key.Hostname}, nil
+	}))
+	test.S(t).ExpectTrue(len(InstanceEnricherNames()) > 0)
+
+	known := NewInstance()
+	known.Key = InstanceKey{Hostname: "db-known", Port: 3306}
+	unknown := NewInstance()
+	unknown.Key = InstanceKey{Hostname: "db-unknown", Port: 3306}
+
+	// First read triggers enrichment in the background, and does not wait for it
+	EnrichInstances([]*Instance{known, unknown})
+	test.S(t).ExpectEquals(len(known.Extensions), 0)
+
+	for i := 0; i < 100 && atomic.LoadInt32(&countEnriches) < 2; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	EnrichInstances([]*Instance{known, unknown})
+	test.S(t).ExpectEquals(known.Extensions["test-cmdb"]["owner"], "team-db-known")
+	_, found := unknown.Extensions["test-cmdb"]
+	test.S(t).ExpectFalse(found)
+}
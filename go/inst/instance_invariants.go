@@ -0,0 +1,103 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Invariant names, as reported in InvariantViolation.Invariant
+const (
+	InvariantClusterNameSet       = "cluster_name_set"
+	InvariantNotOwnMaster         = "not_own_master"
+	InvariantExecNotAheadOfRead   = "exec_not_ahead_of_read"
+	InvariantNonNegativeLag       = "non_negative_lag"
+	InvariantMasterWritable       = "master_writable"
+	InvariantMasterNotReplica     = "master_not_replica"
+	InvariantClusterMembership    = "cluster_membership"
+	InvariantSingleWritableMaster = "single_writable_master"
+)
+
+// InvariantViolation is an anomaly in data orchestrator reports on an instance: a state which should never be
+// observed, and hints at stale or inconsistent data on orchestrator's side, or at a misbehaving server
+type InvariantViolation struct {
+	Key         InstanceKey
+	Invariant   string
+	Description string
+}
+
+func newInvariantViolation(instance *Instance, invariant string, description string, args ...interface{}) InvariantViolation {
+	return InvariantViolation{Key: instance.Key, Invariant: invariant, Description: fmt.Sprintf(description, args...)}
+}
+
+// CheckInstanceInvariants returns violations of invariants any instance is expected to satisfy
+func CheckInstanceInvariants(instance *Instance) (violations []InvariantViolation) {
+	violations = []InvariantViolation{}
+	if instance.ClusterName == "" {
+		violations = append(violations, newInvariantViolation(instance, InvariantClusterNameSet, "empty cluster name"))
+	}
+	if instance.MasterKey.Equals(&instance.Key) {
+		violations = append(violations, newInvariantViolation(instance, InvariantNotOwnMaster, "replicates from itself"))
+	}
+	if instance.IsReplica() {
+		// Exec coordinates are those of the master's binary log the SQL thread executed; it cannot execute events
+		// the IO thread has not read
+		if !instance.ExecBinlogCoordinates.IsEmpty() && instance.ReadBinlogCoordinates.SmallerThan(&instance.ExecBinlogCoordinates) {
+			violations = append(violations, newInvariantViolation(instance, InvariantExecNotAheadOfRead, "exec coordinates %s ahead of read coordinates %s", instance.ExecBinlogCoordinates.DisplayString(), instance.ReadBinlogCoordinates.DisplayString()))
+		}
+		if instance.SecondsBehindMaster.Valid && instance.SecondsBehindMaster.Int64 < 0 {
+			violations = append(violations, newInvariantViolation(instance, InvariantNonNegativeLag, "negative seconds behind master: %d", instance.SecondsBehindMaster.Int64))
+		}
+	}
+	return violations
+}
+
+// CheckMasterInvariants returns violations of invariants the master of a cluster is expected to satisfy
+func CheckMasterInvariants(master *Instance) (violations []InvariantViolation) {
+	violations = CheckInstanceInvariants(master)
+	if master.ReadOnly {
+		violations = append(violations, newInvariantViolation(master, InvariantMasterWritable, "master is read_only"))
+	}
+	if master.IsReplica() && !master.IsCoMaster {
+		violations = append(violations, newInvariantViolation(master, InvariantMasterNotReplica, "master replicates from %s", master.MasterKey.StringCode()))
+	}
+	return violations
+}
+
+// CheckClusterInvariants returns violations of invariants the instances of given cluster are expected to satisfy
+func CheckClusterInvariants(clusterName string, instances [](*Instance)) (violations []InvariantViolation) {
+	violations = []InvariantViolation{}
+	writableMasters := []string{}
+	for _, instance := range instances {
+		violations = append(violations, CheckInstanceInvariants(instance)...)
+		if instance.ClusterName != clusterName {
+			violations = append(violations, newInvariantViolation(instance, InvariantClusterMembership, "listed in cluster %s, but belongs to %s", clusterName, instance.ClusterName))
+		}
+		if instance.IsMaster() && !instance.ReadOnly && !instance.IsDowntimed {
+			writableMasters = append(writableMasters, instance.Key.StringCode())
+		}
+	}
+	if len(writableMasters) > 1 {
+		for _, instance := range instances {
+			if instance.IsMaster() && !instance.ReadOnly && !instance.IsDowntimed {
+				violations = append(violations, newInvariantViolation(instance, InvariantSingleWritableMaster, "one of %d writable masters: %s", len(writableMasters), strings.Join(writableMasters, ", ")))
+			}
+		}
+	}
+	return violations
+}
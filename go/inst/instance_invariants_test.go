@@ -0,0 +1,58 @@
+package inst
+
+import (
+	"testing"
+
+	test "github.com/openark/golib/tests"
+)
+
+func TestCheckInstanceInvariants(t *testing.T) {
+	{
+		i := Instance{Key: key1, MasterKey: key2, ClusterName: "c"}
+		i.ReadBinlogCoordinates = BinlogCoordinates{LogFile: "mysql-bin.000002", LogPos: 400}
+		i.ExecBinlogCoordinates = BinlogCoordinates{LogFile: "mysql-bin.000002", LogPos: 300}
+		test.S(t).ExpectEquals(len(CheckInstanceInvariants(&i)), 0)
+	}
+	{
+		i := Instance{Key: key1, MasterKey: key2, ClusterName: "c"}
+		i.ReadBinlogCoordinates = BinlogCoordinates{LogFile: "mysql-bin.000002", LogPos: 300}
+		i.ExecBinlogCoordinates = BinlogCoordinates{LogFile: "mysql-bin.000002", LogPos: 400}
+		i.SecondsBehindMaster.Valid = true
+		i.SecondsBehindMaster.Int64 = -3
+		violations := CheckInstanceInvariants(&i)
+		test.S(t).ExpectEquals(len(violations), 2)
+		test.S(t).ExpectEquals(violations[0].Invariant, InvariantExecNotAheadOfRead)
+		test.S(t).ExpectEquals(violations[1].Invariant, InvariantNonNegativeLag)
+	}
+	{
+		i := Instance{Key: key1, MasterKey: key1}
+		violations := CheckInstanceInvariants(&i)
+		test.S(t).ExpectEquals(len(violations), 2)
+		test.S(t).ExpectEquals(violations[0].Invariant, InvariantClusterNameSet)
+		test.S(t).ExpectEquals(violations[1].Invariant, InvariantNotOwnMaster)
+	}
+}
+
+func TestCheckMasterInvariants(t *testing.T) {
+	master := Instance{Key: key1, ClusterName: "c"}
+	test.S(t).ExpectEquals(len(CheckMasterInvariants(&master)), 0)
+
+	master.ReadOnly = true
+	violations := CheckMasterInvariants(&master)
+	test.S(t).ExpectEquals(len(violations), 1)
+	test.S(t).ExpectEquals(violations[0].Invariant, InvariantMasterWritable)
+}
+
+func TestCheckClusterInvariants(t *testing.T) {
+	master := &Instance{Key: key1, ClusterName: "c"}
+	replica := &Instance{Key: key2, MasterKey: key1, ClusterName: "c", ReadOnly: true}
+	replica.ReadBinlogCoordinates = BinlogCoordinates{LogFile: "mysql-bin.000002", LogPos: 400}
+	test.S(t).ExpectEquals(len(CheckClusterInvariants("c", []*Instance{master, replica})), 0)
+
+	other := &Instance{Key: key3, ClusterName: "other"}
+	violations := CheckClusterInvariants("c", []*Instance{master, replica, other})
+	test.S(t).ExpectEquals(len(violations), 3)
+	test.S(t).ExpectEquals(violations[0].Invariant, InvariantClusterMembership)
+	test.S(t).ExpectEquals(violations[1].Invariant, InvariantSingleWritableMaster)
+	test.S(t).ExpectEquals(violations[2].Invariant, InvariantSingleWritableMaster)
+}
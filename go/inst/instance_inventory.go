@@ -0,0 +1,126 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/openark/golib/sqlutils"
+	"github.com/openark/orchestrator/go/db"
+)
+
+const (
+	DefaultInventoryPageSize = 500
+	MaxInventoryPageSize     = 5000
+)
+
+// InventoryPage is a page of instances, ordered by hostname and port. NextCursor reads the page following it, and
+// is empty on the last page. SyncTimestamp is the backend time the sync started at: a later sync given it as
+// "since" reads instances seen since then.
+type InventoryPage struct {
+	Instances     [](*Instance)
+	NextCursor    string
+	SyncTimestamp string
+}
+
+// EncodeInventoryCursor returns an opaque cursor, reading instances following given instance key
+func EncodeInventoryCursor(instanceKey *InstanceKey) string {
+	encoded, _ := json.Marshal(instanceKey)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// DecodeInventoryCursor returns the instance key encoded by a cursor, or nil for an empty cursor
+func DecodeInventoryCursor(cursor string) (*InstanceKey, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid inventory cursor: %s", cursor)
+	}
+	instanceKey := &InstanceKey{}
+	if err := json.Unmarshal(decoded, instanceKey); err != nil || !instanceKey.IsValid() {
+		return nil, fmt.Errorf("Invalid inventory cursor: %s", cursor)
+	}
+	return instanceKey, nil
+}
+
+// ReadInventoryPage reads up to pageSize instances following given cursor, optionally only those last seen at or
+// after given backend timestamp. Paging is by instance key, so that pages are stable while instances are written.
+func ReadInventoryPage(cursor string, since string, syncTimestamp string, pageSize int) (page *InventoryPage, err error) {
+	if pageSize <= 0 {
+		pageSize = DefaultInventoryPageSize
+	}
+	if pageSize > MaxInventoryPageSize {
+		pageSize = MaxInventoryPageSize
+	}
+	afterKey, err := DecodeInventoryCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if syncTimestamp == "" {
+		if syncTimestamp, err = db.ReadTimeNow(); err != nil {
+			return nil, err
+		}
+	}
+	condition := `1 = 1`
+	args := sqlutils.Args()
+	if afterKey != nil {
+		condition = fmt.Sprintf(`%s and (hostname > ? or (hostname = ? and port > ?))`, condition)
+		args = append(args, afterKey.Hostname, afterKey.Hostname, afterKey.Port)
+	}
+	if since != "" {
+		condition = fmt.Sprintf(`%s and last_seen >= ?`, condition)
+		args = append(args, since)
+	}
+	instances, err := readInstancesByCondition(condition, args, fmt.Sprintf(`hostname, port limit %d`, pageSize))
+	if err != nil {
+		return nil, err
+	}
+	page = &InventoryPage{Instances: instances, SyncTimestamp: syncTimestamp}
+	if len(instances) == pageSize {
+		page.NextCursor = EncodeInventoryCursor(&instances[len(instances)-1].Key)
+	}
+	return page, nil
+}
+
+// SyncInventory streams instances, page by page, to given sink, starting at given cursor (empty for the first
+// page), and optionally only those seen since given backend timestamp. It never holds more than a page of
+// instances in memory. It returns the cursor at which it stopped, from which an interrupted sync resumes, along
+// with the timestamp a following incremental sync should take as "since".
+func SyncInventory(ctx context.Context, cursor string, since string, pageSize int, sink func(instances [](*Instance)) error) (resumeCursor string, syncTimestamp string, err error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return cursor, syncTimestamp, err
+		}
+		page, err := ReadInventoryPage(cursor, since, syncTimestamp, pageSize)
+		if err != nil {
+			return cursor, syncTimestamp, err
+		}
+		syncTimestamp = page.SyncTimestamp
+		if err := sink(page.Instances); err != nil {
+			return cursor, syncTimestamp, err
+		}
+		if page.NextCursor == "" {
+			return "", syncTimestamp, nil
+		}
+		cursor = page.NextCursor
+	}
+}
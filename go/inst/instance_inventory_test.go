@@ -0,0 +1,55 @@
+package inst
+
+import (
+	"encoding/json"
+	"testing"
+
+	test "github.com/openark/golib/tests"
+)
+
+func TestInventoryCursor(t *testing.T) {
+	cursor := EncodeInventoryCursor(&key1)
+	instanceKey, err := DecodeInventoryCursor(cursor)
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectTrue(instanceKey.Equals(&key1))
+
+	instanceKey, err = DecodeInventoryCursor("")
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectTrue(instanceKey == nil)
+
+	_, err = DecodeInventoryCursor("not a cursor")
+	test.S(t).ExpectNotNil(err)
+	_, err = DecodeInventoryCursor(EncodeInventoryCursor(&InstanceKey{}))
+	test.S(t).ExpectNotNil(err)
+}
+
+// BenchmarkSyncInventoryPages decodes a 10k instances inventory, page by page, as an inventory sync consumer does
+func BenchmarkSyncInventoryPages(b *testing.B) {
+	instances := [](*Instance){}
+	for _, row := range mkTestInstanceRows(10000) {
+		instances = append(instances, readInstanceRow(row))
+	}
+	pagesJSON := [][]byte{}
+	for i := 0; i < len(instances); i += DefaultInventoryPageSize {
+		page := &InventoryPage{Instances: instances[i : i+DefaultInventoryPageSize]}
+		page.NextCursor = EncodeInventoryCursor(&page.Instances[len(page.Instances)-1].Key)
+		pageJSON, err := json.Marshal(page)
+		if err != nil {
+			b.Fatal(err)
+		}
+		pagesJSON = append(pagesJSON, pageJSON)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, pageJSON := range pagesJSON {
+			page := &InventoryPage{}
+			if err := json.Unmarshal(pageJSON, page); err != nil {
+				b.Fatal(err)
+			}
+			if _, err := DecodeInventoryCursor(page.NextCursor); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -88,7 +88,7 @@ func (this *InstanceKeyMap) UnmarshalJSON(b []byte) error {
 	if err := json.Unmarshal(b, &keys); err != nil {
 		return err
 	}
-	*this = make(InstanceKeyMap)
+	*this = make(InstanceKeyMap, len(keys))
 	for _, key := range keys {
 		this.AddKey(key)
 	}
@@ -118,6 +118,10 @@ func (this *InstanceKeyMap) ToCommaDelimitedList() string {
 
 // ReadJson unmarshalls a json into this map
 func (this *InstanceKeyMap) ReadJson(jsonString string) error {
+	if jsonString == "[]" {
+		// Most instances have no replicas and are not group members: skip decoding
+		return nil
+	}
 	var keys []InstanceKey
 	err := json.Unmarshal([]byte(jsonString), &keys)
 	if err != nil {
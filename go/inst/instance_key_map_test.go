@@ -17,6 +17,7 @@
 package inst
 
 import (
+	"encoding/json"
 	"math/rand"
 	"testing"
 
@@ -125,3 +126,35 @@ func TestIntersect(t *testing.T) {
 	}
 
 }
+
+func TestReadJson(t *testing.T) {
+	m := NewInstanceKeyMap()
+	test.S(t).ExpectNil(m.ReadJson("[]"))
+	test.S(t).ExpectEquals(len(*m), 0)
+
+	replicasJSON, _ := json.Marshal([]InstanceKey{key1, key2})
+	test.S(t).ExpectNil(m.ReadJson(string(replicasJSON)))
+	test.S(t).ExpectEquals(len(*m), 2)
+	test.S(t).ExpectTrue(m.HasKey(key1))
+	test.S(t).ExpectTrue(m.HasKey(key2))
+
+	test.S(t).ExpectNotNil(m.ReadJson("not json"))
+}
+
+func BenchmarkInstanceKeyMapReadJson(b *testing.B) {
+	replicasJSON, _ := json.Marshal([]InstanceKey{key1, key2, key3})
+	b.Run("empty", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			m := NewInstanceKeyMap()
+			m.ReadJson("[]")
+		}
+	})
+	b.Run("replicas", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			m := NewInstanceKeyMap()
+			m.ReadJson(string(replicasJSON))
+		}
+	})
+}
@@ -0,0 +1,125 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// NullInt64Pointer returns given nullable integer as a pointer, which is nil when NULL
+func NullInt64Pointer(n sql.NullInt64) *int64 {
+	if !n.Valid {
+		return nil
+	}
+	value := n.Int64
+	return &value
+}
+
+// PointerNullInt64 returns a nullable integer by given pointer, which is NULL when nil
+func PointerNullInt64(value *int64) sql.NullInt64 {
+	if value == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *value, Valid: true}
+}
+
+// UnmarshalNullInt64 reads a nullable integer from JSON. It accepts orchestrator's own format, that of
+// sql.NullInt64: {"Int64": 7, "Valid": true}, as well as a plain number, or null.
+func UnmarshalNullInt64(b []byte) (n sql.NullInt64, err error) {
+	b = bytes.TrimSpace(b)
+	switch {
+	case len(b) == 0, bytes.Equal(b, []byte("null")):
+		return n, nil
+	case b[0] == '{':
+		err = json.Unmarshal(b, &n)
+	default:
+		err = json.Unmarshal(b, &n.Int64)
+		n.Valid = (err == nil)
+	}
+	if err != nil {
+		return sql.NullInt64{}, fmt.Errorf("Cannot read nullable integer from %s: %+v", b, err)
+	}
+	return n, nil
+}
+
+// InstanceLag presents the nullable lag attributes of an instance as pointers, which are omitted when NULL, rather
+// than as sql.NullInt64 objects
+type InstanceLag struct {
+	Key                   InstanceKey
+	ReplicationLagSeconds *int64 `json:",omitempty"`
+	SecondsBehindMaster   *int64 `json:",omitempty"`
+	SecondsSinceLastSeen  *int64 `json:",omitempty"`
+}
+
+// Lag returns the lag attributes of this instance
+func (this *Instance) Lag() InstanceLag {
+	return InstanceLag{
+		Key:                   this.Key,
+		ReplicationLagSeconds: NullInt64Pointer(this.ReplicationLagSeconds),
+		SecondsBehindMaster:   NullInt64Pointer(this.SecondsBehindMaster),
+		SecondsSinceLastSeen:  NullInt64Pointer(this.SecondsSinceLastSeen),
+	}
+}
+
+// LagSeconds returns this instance's replication lag, and whether it is known
+func (this *Instance) LagSeconds() (int64, bool) {
+	return this.ReplicationLagSeconds.Int64, this.ReplicationLagSeconds.Valid
+}
+
+// BehindMasterSeconds returns this instance's Seconds_Behind_Master, and whether it is known
+func (this *Instance) BehindMasterSeconds() (int64, bool) {
+	return this.SecondsBehindMaster.Int64, this.SecondsBehindMaster.Valid
+}
+
+// SinceLastSeenSeconds returns the number of seconds since this instance was last seen, and whether it was ever seen
+func (this *Instance) SinceLastSeenSeconds() (int64, bool) {
+	return this.SecondsSinceLastSeen.Int64, this.SecondsSinceLastSeen.Valid
+}
+
+// UnmarshalJSON reads an instance as marshaled by MarshalJSON. Nullable integers are read as by UnmarshalNullInt64,
+// and the legacy SlaveLagSeconds stands for ReplicationLagSeconds when the latter is absent.
+func (this *Instance) UnmarshalJSON(b []byte) error {
+	type instanceAlias Instance
+	aux := struct {
+		*instanceAlias
+		SecondsBehindMaster   json.RawMessage
+		SlaveLagSeconds       json.RawMessage
+		ReplicationLagSeconds json.RawMessage
+		SecondsSinceLastSeen  json.RawMessage
+	}{instanceAlias: (*instanceAlias)(this)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	if len(aux.ReplicationLagSeconds) == 0 {
+		aux.ReplicationLagSeconds = aux.SlaveLagSeconds
+	}
+	var err error
+	if this.SecondsBehindMaster, err = UnmarshalNullInt64(aux.SecondsBehindMaster); err != nil {
+		return err
+	}
+	if this.ReplicationLagSeconds, err = UnmarshalNullInt64(aux.ReplicationLagSeconds); err != nil {
+		return err
+	}
+	this.SlaveLagSeconds = this.ReplicationLagSeconds
+	if this.SecondsSinceLastSeen, err = UnmarshalNullInt64(aux.SecondsSinceLastSeen); err != nil {
+		return err
+	}
+	return nil
+}
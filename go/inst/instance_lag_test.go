@@ -0,0 +1,93 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	test "github.com/openark/golib/tests"
+)
+
+func TestUnmarshalNullInt64(t *testing.T) {
+	for input, expected := range map[string]sql.NullInt64{
+		`{"Int64": 7, "Valid": true}`:  {Int64: 7, Valid: true},
+		`{"Int64": 0, "Valid": false}`: {},
+		`7`:                            {Int64: 7, Valid: true},
+		`0`:                            {Int64: 0, Valid: true},
+		`null`:                         {},
+		``:                             {},
+	} {
+		n, err := UnmarshalNullInt64([]byte(input))
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(n, expected)
+	}
+	_, err := UnmarshalNullInt64([]byte(`"7"`))
+	test.S(t).ExpectNotNil(err)
+}
+
+func TestNullInt64Pointer(t *testing.T) {
+	test.S(t).ExpectTrue(NullInt64Pointer(sql.NullInt64{}) == nil)
+	test.S(t).ExpectEquals(*NullInt64Pointer(sql.NullInt64{Int64: 3, Valid: true}), int64(3))
+	test.S(t).ExpectEquals(PointerNullInt64(nil), sql.NullInt64{})
+	value := int64(3)
+	test.S(t).ExpectEquals(PointerNullInt64(&value), sql.NullInt64{Int64: 3, Valid: true})
+}
+
+func TestInstanceLagSeconds(t *testing.T) {
+	instance := NewInstance()
+	_, known := instance.LagSeconds()
+	test.S(t).ExpectFalse(known)
+
+	instance.ReplicationLagSeconds = sql.NullInt64{Int64: 5, Valid: true}
+	lag, known := instance.LagSeconds()
+	test.S(t).ExpectTrue(known)
+	test.S(t).ExpectEquals(lag, int64(5))
+
+	b, err := json.Marshal(instance.Lag())
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(string(b), `{"Key":{"Hostname":"","Port":0},"ReplicationLagSeconds":5}`)
+}
+
+func TestInstanceJSONRoundTrip(t *testing.T) {
+	instance := NewInstance()
+	instance.Key = InstanceKey{Hostname: "replica", Port: 3306}
+	instance.SecondsBehindMaster = sql.NullInt64{Int64: 4, Valid: true}
+	instance.ReplicationLagSeconds = sql.NullInt64{Int64: 5, Valid: true}
+	b, err := json.Marshal(instance)
+	test.S(t).ExpectNil(err)
+
+	read := NewInstance()
+	test.S(t).ExpectNil(json.Unmarshal(b, read))
+	test.S(t).ExpectEquals(read.Key, instance.Key)
+	test.S(t).ExpectEquals(read.SecondsBehindMaster, instance.SecondsBehindMaster)
+	test.S(t).ExpectEquals(read.ReplicationLagSeconds, instance.ReplicationLagSeconds)
+	test.S(t).ExpectEquals(read.SecondsSinceLastSeen, sql.NullInt64{})
+	rewritten, err := json.Marshal(read)
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(string(rewritten), string(b))
+}
+
+func TestInstanceUnmarshalPlainNullables(t *testing.T) {
+	read := NewInstance()
+	test.S(t).ExpectNil(json.Unmarshal([]byte(`{"Key": {"Hostname": "replica", "Port": 3306}, "SlaveLagSeconds": 9, "SecondsBehindMaster": null}`), read))
+	test.S(t).ExpectEquals(read.ReplicationLagSeconds, sql.NullInt64{Int64: 9, Valid: true})
+	test.S(t).ExpectEquals(read.SecondsBehindMaster, sql.NullInt64{})
+
+	test.S(t).ExpectNotNil(json.Unmarshal([]byte(`{"ReplicationLagSeconds": "9"}`), read))
+}
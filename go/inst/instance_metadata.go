@@ -0,0 +1,115 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	InstanceAliasMetadataField       = "instance_alias"
+	DataCenterMetadataField          = "data_center"
+	RegionMetadataField              = "region"
+	PhysicalEnvironmentMetadataField = "physical_environment"
+)
+
+// InstanceMetadataFields lists the instance metadata fields which may be overridden
+var InstanceMetadataFields = []string{
+	InstanceAliasMetadataField,
+	DataCenterMetadataField,
+	RegionMetadataField,
+	PhysicalEnvironmentMetadataField,
+}
+
+// InstanceMetadata is a partial update of instance metadata overrides. Overrides take precedence over
+// metadata detected via patterns or queries (e.g. DataCenterPattern, DetectDataCenterQuery). Only fields
+// present in Values are affected; an empty value removes the override of its field.
+type InstanceMetadata struct {
+	Key    InstanceKey
+	Values map[string]string
+	Owner  string
+}
+
+// NewInstanceMetadata creates an empty update of given instance's metadata overrides
+func NewInstanceMetadata(instanceKey *InstanceKey, owner string) *InstanceMetadata {
+	return &InstanceMetadata{
+		Key:    *instanceKey,
+		Values: make(map[string]string),
+		Owner:  owner,
+	}
+}
+
+func isInstanceMetadataField(field string) bool {
+	for _, metadataField := range InstanceMetadataFields {
+		if field == metadataField {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that at least one field is given, and that all given fields may be overridden. It returns
+// the given fields, sorted.
+func (this *InstanceMetadata) Validate() (fields []string, err error) {
+	if len(this.Values) == 0 {
+		return nil, fmt.Errorf("No metadata fields given. Expected any of: %s", strings.Join(InstanceMetadataFields, ", "))
+	}
+	for field := range this.Values {
+		if !isInstanceMetadataField(field) {
+			return nil, fmt.Errorf("Unknown instance metadata field: %s. Expected any of: %s", field, strings.Join(InstanceMetadataFields, ", "))
+		}
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
+
+// applyInstanceMetadataValues sets given overridden metadata fields on an instance
+func applyInstanceMetadataValues(instance *Instance, overrides map[string]string) {
+	for field, value := range overrides {
+		switch field {
+		case InstanceAliasMetadataField:
+			instance.InstanceAlias = value
+		case DataCenterMetadataField:
+			instance.DataCenter = value
+		case RegionMetadataField:
+			instance.Region = value
+		case PhysicalEnvironmentMetadataField:
+			instance.PhysicalEnvironment = value
+		}
+	}
+}
+
+// ParseInstanceMetadata parses a comma delimited list of field=value assignments, such as
+// "data_center=dc1,region=us-east". A field assigned an empty value has its override removed.
+func ParseInstanceMetadata(instanceKey *InstanceKey, assignments string, owner string) (*InstanceMetadata, error) {
+	metadata := NewInstanceMetadata(instanceKey, owner)
+	for _, assignment := range strings.Split(assignments, ",") {
+		assignment = strings.TrimSpace(assignment)
+		if assignment == "" {
+			continue
+		}
+		tokens := strings.SplitN(assignment, "=", 2)
+		if len(tokens) != 2 {
+			return nil, fmt.Errorf("Invalid instance metadata assignment: %s. Expected field=value", assignment)
+		}
+		metadata.Values[strings.TrimSpace(tokens[0])] = strings.TrimSpace(tokens[1])
+	}
+	return metadata, nil
+}
@@ -0,0 +1,167 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/golib/sqlutils"
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/db"
+	"github.com/patrickmn/go-cache"
+)
+
+// instanceMetadataOverridesCache holds all overrides, by instance, such that discovery reads them once per poll
+// interval rather than upon each instance poll. It is flushed upon changes made on this node, which with raft
+// are all changes.
+var instanceMetadataOverridesCache = cache.New(time.Duration(config.Config.InstancePollSeconds)*time.Second, time.Second)
+
+const instanceMetadataOverridesCacheKey = "overrides"
+
+// SetInstanceMetadata persists given metadata overrides of an instance, and applies them to the instance's
+// current record. Overrides removed via empty values are reverted upon next discovery of the instance.
+func SetInstanceMetadata(metadata *InstanceMetadata) error {
+	fields, err := metadata.Validate()
+	if err != nil {
+		return err
+	}
+	defer instanceMetadataOverridesCache.Flush()
+	for _, field := range fields {
+		value := metadata.Values[field]
+		if value == "" {
+			_, err := db.ExecOrchestrator(`
+					delete from
+						database_instance_metadata_override
+					where
+						hostname = ?
+						and port = ?
+						and field_name = ?
+					`,
+				metadata.Key.Hostname, metadata.Key.Port, field,
+			)
+			if err != nil {
+				return log.Errore(err)
+			}
+			continue
+		}
+		_, err := db.ExecOrchestrator(`
+				replace
+					into database_instance_metadata_override (
+						hostname, port, field_name, field_value, owner, last_updated
+					) VALUES (
+						?, ?, ?, ?, ?, NOW()
+					)
+				`,
+			metadata.Key.Hostname, metadata.Key.Port, field, value, metadata.Owner,
+		)
+		if err != nil {
+			return log.Errore(err)
+		}
+		// field is validated above, and safe to use as column name
+		_, err = db.ExecOrchestrator(fmt.Sprintf(`
+				update
+					database_instance
+				set
+					%s = ?
+				where
+					hostname = ?
+					and port = ?
+				`, field),
+			value, metadata.Key.Hostname, metadata.Key.Port,
+		)
+		if err != nil {
+			return log.Errore(err)
+		}
+	}
+	changes := []string{}
+	for _, field := range fields {
+		changes = append(changes, fmt.Sprintf("%s=%s", field, metadata.Values[field]))
+	}
+	AuditOperation("set-instance-metadata", &metadata.Key, fmt.Sprintf("%s, owner: %s", strings.Join(changes, ", "), metadata.Owner))
+	return nil
+}
+
+// ReadInstanceMetadataOverrides returns the metadata overrides of given instance, by field
+func ReadInstanceMetadataOverrides(instanceKey *InstanceKey) (map[string]string, error) {
+	overrides := make(map[string]string)
+	query := `
+		select
+			field_name,
+			field_value
+		from
+			database_instance_metadata_override
+		where
+			hostname = ?
+			and port = ?
+		`
+	err := db.QueryOrchestrator(query, sqlutils.Args(instanceKey.Hostname, instanceKey.Port), func(m sqlutils.RowMap) error {
+		overrides[m.GetString("field_name")] = m.GetString("field_value")
+		return nil
+	})
+	return overrides, log.Errore(err)
+}
+
+// readAllInstanceMetadataOverrides returns the metadata overrides of all instances, by instance key
+// (see InstanceKey.StringCode()) and field
+func readAllInstanceMetadataOverrides() (map[string]map[string]string, error) {
+	overrides := make(map[string]map[string]string)
+	query := `
+		select
+			hostname,
+			port,
+			field_name,
+			field_value
+		from
+			database_instance_metadata_override
+		`
+	err := db.QueryOrchestrator(query, sqlutils.Args(), func(m sqlutils.RowMap) error {
+		instanceKey := InstanceKey{Hostname: m.GetString("hostname"), Port: m.GetInt("port")}
+		if _, found := overrides[instanceKey.StringCode()]; !found {
+			overrides[instanceKey.StringCode()] = make(map[string]string)
+		}
+		overrides[instanceKey.StringCode()][m.GetString("field_name")] = m.GetString("field_value")
+		return nil
+	})
+	return overrides, log.Errore(err)
+}
+
+// readCachedInstanceMetadataOverrides returns the metadata overrides of given instance, by field, out of
+// instanceMetadataOverridesCache
+func readCachedInstanceMetadataOverrides(instanceKey *InstanceKey) (map[string]string, error) {
+	if overrides, found := instanceMetadataOverridesCache.Get(instanceMetadataOverridesCacheKey); found {
+		return overrides.(map[string]map[string]string)[instanceKey.StringCode()], nil
+	}
+	overrides, err := readAllInstanceMetadataOverrides()
+	if err != nil {
+		return nil, err
+	}
+	instanceMetadataOverridesCache.Set(instanceMetadataOverridesCacheKey, overrides, cache.DefaultExpiration)
+	return overrides[instanceKey.StringCode()], nil
+}
+
+// applyInstanceMetadataOverrides sets the overridden metadata fields on given instance
+func applyInstanceMetadataOverrides(instance *Instance) error {
+	overrides, err := readCachedInstanceMetadataOverrides(&instance.Key)
+	if err != nil {
+		return err
+	}
+	applyInstanceMetadataValues(instance, overrides)
+	return nil
+}
@@ -0,0 +1,53 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"testing"
+
+	"github.com/patrickmn/go-cache"
+
+	test "github.com/openark/golib/tests"
+)
+
+func TestApplyInstanceMetadataOverridesCached(t *testing.T) {
+	defer instanceMetadataOverridesCache.Flush()
+	overriddenKey := InstanceKey{Hostname: "host1", Port: 3306}
+	instanceMetadataOverridesCache.Set(instanceMetadataOverridesCacheKey, map[string]map[string]string{
+		overriddenKey.StringCode(): {DataCenterMetadataField: "dc1"},
+	}, cache.DefaultExpiration)
+
+	// served off the cache, without a backend
+	instance := NewInstance()
+	instance.Key = overriddenKey
+	instance.DataCenter = "detected-dc"
+	test.S(t).ExpectNil(applyInstanceMetadataOverrides(instance))
+	test.S(t).ExpectEquals(instance.DataCenter, "dc1")
+
+	instance = NewInstance()
+	instance.Key = InstanceKey{Hostname: "host2", Port: 3306}
+	instance.DataCenter = "detected-dc"
+	test.S(t).ExpectNil(applyInstanceMetadataOverrides(instance))
+	test.S(t).ExpectEquals(instance.DataCenter, "detected-dc")
+}
+
+func TestSetInstanceMetadataInvalid(t *testing.T) {
+	// refused before any backend access
+	metadata := NewInstanceMetadata(&InstanceKey{Hostname: "host1", Port: 3306}, "dba")
+	metadata.Values["server_id"] = "1"
+	test.S(t).ExpectNotNil(SetInstanceMetadata(metadata))
+}
@@ -0,0 +1,78 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"strings"
+	"testing"
+
+	test "github.com/openark/golib/tests"
+)
+
+func TestParseInstanceMetadata(t *testing.T) {
+	instanceKey := &InstanceKey{Hostname: "host1", Port: 3306}
+	metadata, err := ParseInstanceMetadata(instanceKey, " data_center=dc1, region = us-east,,physical_environment=", "dba")
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(metadata.Key, *instanceKey)
+	test.S(t).ExpectEquals(metadata.Owner, "dba")
+	test.S(t).ExpectEquals(len(metadata.Values), 3)
+	test.S(t).ExpectEquals(metadata.Values[DataCenterMetadataField], "dc1")
+	test.S(t).ExpectEquals(metadata.Values[RegionMetadataField], "us-east")
+	test.S(t).ExpectEquals(metadata.Values[PhysicalEnvironmentMetadataField], "")
+
+	_, err = ParseInstanceMetadata(instanceKey, "data_center", "dba")
+	test.S(t).ExpectNotNil(err)
+}
+
+func TestInstanceMetadataValidate(t *testing.T) {
+	instanceKey := &InstanceKey{Hostname: "host1", Port: 3306}
+	{
+		metadata := NewInstanceMetadata(instanceKey, "dba")
+		metadata.Values[RegionMetadataField] = "us-east"
+		metadata.Values[DataCenterMetadataField] = ""
+		fields, err := metadata.Validate()
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(strings.Join(fields, ","), "data_center,region")
+	}
+	{
+		_, err := NewInstanceMetadata(instanceKey, "dba").Validate()
+		test.S(t).ExpectNotNil(err)
+	}
+	{
+		metadata := NewInstanceMetadata(instanceKey, "dba")
+		metadata.Values[DataCenterMetadataField] = "dc1"
+		// not overridable, and not safe as a column name
+		metadata.Values["hostname=''; --"] = "x"
+		_, err := metadata.Validate()
+		test.S(t).ExpectNotNil(err)
+	}
+}
+
+func TestApplyInstanceMetadataValues(t *testing.T) {
+	instance := NewInstance()
+	instance.DataCenter = "detected-dc"
+	instance.Region = "detected-region"
+	applyInstanceMetadataValues(instance, map[string]string{
+		InstanceAliasMetadataField:       "alias1",
+		DataCenterMetadataField:          "dc1",
+		PhysicalEnvironmentMetadataField: "prod",
+	})
+	test.S(t).ExpectEquals(instance.InstanceAlias, "alias1")
+	test.S(t).ExpectEquals(instance.DataCenter, "dc1")
+	test.S(t).ExpectEquals(instance.Region, "detected-region")
+	test.S(t).ExpectEquals(instance.PhysicalEnvironment, "prod")
+}
@@ -238,3 +238,60 @@ func TestReplicationThreads(t *testing.T) {
 		test.S(t).ExpectFalse(i.ReplicationThreadsExist())
 	}
 }
+
+func TestReplicationVerificationFailure(t *testing.T) {
+	i := Instance{Key: key1, MasterKey: key2, ReadBinlogCoordinates: BinlogCoordinates{LogFile: "mysql-bin.000001", LogPos: 4}}
+	i.ReplicationIOThreadState = ReplicationThreadStateRunning
+	i.ReplicationSQLThreadState = ReplicationThreadStateRunning
+	test.S(t).ExpectEquals(replicationVerificationFailure(&i, &key2), "")
+	test.S(t).ExpectNotEquals(replicationVerificationFailure(&i, &key3), "")
+
+	i.LastIOError = "error connecting to master"
+	test.S(t).ExpectNotEquals(replicationVerificationFailure(&i, &key2), "")
+	i.LastIOError = ""
+	i.ReplicationSQLThreadState = ReplicationThreadStateStopped
+	test.S(t).ExpectNotEquals(replicationVerificationFailure(&i, &key2), "")
+}
+
+func TestAdviseRelocationMethod(t *testing.T) {
+	defer func(pattern string) { config.Config.PseudoGTIDPattern = pattern }(config.Config.PseudoGTIDPattern)
+	config.Config.PseudoGTIDPattern = "drop view if exists `_pseudo_gtid_`"
+
+	newInstance := func(key InstanceKey, serverID uint) *Instance {
+		return &Instance{Key: key, ServerID: serverID, Version: "5.7.26-log", LogBinEnabled: true, LogReplicationUpdatesEnabled: true, Binlog_format: "ROW", IsLastCheckValid: true, IsRecentlyChecked: true}
+	}
+	{
+		i, other := newInstance(key1, 1), newInstance(key2, 2)
+		i.UsingOracleGTID, other.SupportsOracleGTID = true, true
+		i.UsingPseudoGTID, other.UsingPseudoGTID = true, true
+		advice := AdviseRelocationMethod(i, other)
+		test.S(t).ExpectEquals(advice.Method, RelocationMethodGTID)
+		test.S(t).ExpectTrue(advice.CanMatch)
+	}
+	{
+		i, other := newInstance(key1, 1), newInstance(key2, 2)
+		i.UsingPseudoGTID, other.UsingPseudoGTID = true, true
+		advice := AdviseRelocationMethod(i, other)
+		test.S(t).ExpectEquals(advice.Method, RelocationMethodPseudoGTID)
+	}
+	{
+		i, other := newInstance(key1, 1), newInstance(key2, 2)
+		i.UsingPseudoGTID, other.UsingPseudoGTID = true, true
+		other.Binlog_format = "STATEMENT"
+		advice := AdviseRelocationMethod(i, other)
+		test.S(t).ExpectFalse(advice.CanMatch)
+	}
+	{
+		i, other := newInstance(key1, 1), newInstance(key2, 2)
+		i.MasterKey, other.MasterKey = key3, key3
+		i.ReadBinlogCoordinates.LogFile, other.ReadBinlogCoordinates.LogFile = "mysql-bin.000001", "mysql-bin.000001"
+		advice := AdviseRelocationMethod(i, other)
+		test.S(t).ExpectEquals(advice.Method, RelocationMethodBinlogCoordinates)
+		test.S(t).ExpectFalse(advice.CanMatch)
+	}
+	{
+		i, other := newInstance(key1, 1), newInstance(key2, 1)
+		advice := AdviseRelocationMethod(i, other)
+		test.S(t).ExpectEquals(advice.Method, RelocationMethodNone)
+	}
+}
@@ -686,6 +686,145 @@ func MoveBelowGTID(instanceKey, otherKey *InstanceKey) (*Instance, error) {
 	return moveInstanceBelowViaGTID(instance, other)
 }
 
+// validateReplicationRunning waits for given instance to replicate without errors, for up to given number of seconds
+func validateReplicationRunning(instanceKey *InstanceKey, seconds int) (*Instance, error) {
+	var instance *Instance
+	var err error
+	for i := 0; i <= seconds; i++ {
+		if i > 0 {
+			time.Sleep(time.Second)
+		}
+		instance, err = ReadTopologyInstance(instanceKey)
+		if err != nil {
+			continue
+		}
+		if instance.LastIOError != "" || instance.LastSQLError != "" {
+			return instance, fmt.Errorf("%+v replication error: %s %s", *instanceKey, instance.LastIOError, instance.LastSQLError)
+		}
+		if instance.ReplicaRunning() {
+			return instance, nil
+		}
+	}
+	if err != nil {
+		return instance, err
+	}
+	return instance, fmt.Errorf("%+v is not replicating after %d seconds", *instanceKey, seconds)
+}
+
+// moveToClusterRollbackRefusal tells why an instance which was moved below a master of another cluster, and
+// fails to replicate there, may not be pointed back at its original master; or "" when it may. Once the instance
+// executed anything from its new master, a rollback would replay events of its original cluster on top of them,
+// or, with GTID, carry the other cluster's transactions back as errant. movedCoordinates are those of the new
+// master the instance was pointed at (nil when moved via GTID), and transactionsFromTarget the GTID set it
+// executed since the move.
+func moveToClusterRollbackRefusal(instance *Instance, targetMasterKey *InstanceKey, movedCoordinates *BinlogCoordinates, transactionsFromTarget string) string {
+	if !instance.MasterKey.Equals(targetMasterKey) {
+		// never got to replicate from the target
+		return ""
+	}
+	if transactionsFromTarget != "" {
+		return fmt.Sprintf("%+v executed transactions from %+v: %s", instance.Key, *targetMasterKey, transactionsFromTarget)
+	}
+	if movedCoordinates != nil && movedCoordinates.SmallerThan(&instance.ExecBinlogCoordinates) {
+		return fmt.Sprintf("%+v executed events of %+v from %s up to %s", instance.Key, *targetMasterKey, movedCoordinates.DisplayString(), instance.ExecBinlogCoordinates.DisplayString())
+	}
+	return ""
+}
+
+// MoveToCluster moves an instance (along with its replicas, if any) below a master of another cluster.
+// Moving via GTID requires the instance not to have transactions unknown to the target master; otherwise
+// Pseudo-GTID matching is used if configured. Replication is stopped before the move, so as to note the
+// instance's exact original coordinates. Should replication fail to run following the move, the instance is
+// pointed back at its original master and coordinates; unless it already executed anything from the target
+// master, in which case it is left stopped below the target, and the error says why.
+func MoveToCluster(instanceKey, targetMasterKey *InstanceKey) (*Instance, error) {
+	instance, err := ReadTopologyInstance(instanceKey)
+	if err != nil {
+		return instance, err
+	}
+	target, err := ReadTopologyInstance(targetMasterKey)
+	if err != nil {
+		return instance, err
+	}
+	if instance.ClusterName == target.ClusterName {
+		return instance, fmt.Errorf("MoveToCluster: %+v and %+v are both in cluster %s; use relocate instead", *instanceKey, *targetMasterKey, instance.ClusterName)
+	}
+	if instance.IsReplicationGroupSecondary() {
+		return instance, fmt.Errorf("MoveToCluster: %+v is a secondary replication group member, hence, it cannot be relocated", *instanceKey)
+	}
+	if canReplicate, err := instance.CanReplicateFrom(target); !canReplicate {
+		return instance, err
+	}
+	moveViaGTIDErr := CheckMoveViaGTID(instance, target)
+	if moveViaGTIDErr == nil {
+		if target.SupportsOracleGTID && instance.ExecutedGtidSet != "" {
+			errantGtid, err := GTIDSubtract(targetMasterKey, instance.ExecutedGtidSet, target.ExecutedGtidSet)
+			if err != nil {
+				return instance, err
+			}
+			if errantGtid != "" {
+				return instance, fmt.Errorf("MoveToCluster: %+v has transactions unknown to %+v: %s", *instanceKey, *targetMasterKey, errantGtid)
+			}
+		}
+	} else if config.Config.PseudoGTIDPattern == "" {
+		return instance, fmt.Errorf("MoveToCluster: cannot move %+v below %+v: neither GTID nor Pseudo-GTID are applicable: %+v", *instanceKey, *targetMasterKey, moveViaGTIDErr)
+	}
+
+	// Coordinates of a running replica are stale as soon as they are read
+	instance, err = StopReplication(instanceKey)
+	if err != nil {
+		return instance, log.Errore(err)
+	}
+	originalClusterName := instance.ClusterName
+	originalMasterKey := instance.MasterKey
+	originalCoordinates := instance.ExecBinlogCoordinates
+	originalExecutedGtidSet := instance.ExecutedGtidSet
+	originalGTIDHint := GTIDHintDeny
+	if instance.UsingGTID() {
+		originalGTIDHint = GTIDHintForce
+	}
+
+	var movedCoordinates *BinlogCoordinates
+	if moveViaGTIDErr == nil {
+		instance, err = moveInstanceBelowViaGTID(instance, target)
+	} else {
+		instance, movedCoordinates, err = MatchBelow(instanceKey, targetMasterKey, true)
+	}
+	if err != nil {
+		if instance, rerr := ReadTopologyInstance(instanceKey); rerr == nil && instance.MasterKey.Equals(&originalMasterKey) {
+			StartReplication(instanceKey)
+		}
+		return instance, log.Errore(err)
+	}
+
+	if instance, err = validateReplicationRunning(instanceKey, 10); err != nil {
+		instance, rerr := StopReplication(instanceKey)
+		if rerr != nil {
+			return instance, log.Errorf("MoveToCluster: %+v; cannot stop replication to roll back: %+v", err, rerr)
+		}
+		transactionsFromTarget := ""
+		if originalExecutedGtidSet != "" && instance.ExecutedGtidSet != "" {
+			if transactionsFromTarget, rerr = GTIDSubtract(instanceKey, instance.ExecutedGtidSet, originalExecutedGtidSet); rerr != nil {
+				return instance, log.Errorf("MoveToCluster: %+v; cannot tell whether rollback is safe: %+v", err, rerr)
+			}
+		}
+		if refusal := moveToClusterRollbackRefusal(instance, targetMasterKey, movedCoordinates, transactionsFromTarget); refusal != "" {
+			AuditOperation("move-to-cluster", instanceKey, fmt.Sprintf("failed move below %+v, not rolled back, replication stopped: %+v; %s", *targetMasterKey, err, refusal))
+			return instance, log.Errorf("MoveToCluster: %+v; not rolling back to %+v, leaving replication stopped: %s", err, originalMasterKey, refusal)
+		}
+		log.Errorf("MoveToCluster: %+v; rolling back to %+v at %+v", err, originalMasterKey, originalCoordinates)
+		if _, rerr := ChangeMasterTo(instanceKey, &originalMasterKey, &originalCoordinates, false, originalGTIDHint); rerr != nil {
+			return instance, log.Errorf("MoveToCluster: %+v; rollback failed: %+v", err, rerr)
+		}
+		instance, _ = StartReplication(instanceKey)
+		AuditOperation("move-to-cluster", instanceKey, fmt.Sprintf("rolled back move below %+v: %+v", *targetMasterKey, err))
+		return instance, err
+	}
+	AuditOperation("move-to-cluster", instanceKey, fmt.Sprintf("moved %+v from cluster %s below %+v in cluster %s", *instanceKey, originalClusterName, *targetMasterKey, target.ClusterName))
+
+	return instance, nil
+}
+
 // moveReplicasViaGTID moves a list of replicas under another instance via GTID, returning those replicas
 // that could not be moved (do not use GTID or had GTID errors)
 func moveReplicasViaGTID(replicas [](*Instance), other *Instance, postponedFunctionsContainer *PostponedFunctionsContainer) (movedReplicas [](*Instance), unmovedReplicas [](*Instance), err error, errs []error) {
@@ -2263,6 +2402,16 @@ func chooseCandidateReplica(replicas [](*Instance)) (candidateReplica *Instance,
 	return candidateReplica, aheadReplicas, equalReplicas, laterReplicas, cannotReplicateReplicas, err
 }
 
+// ChooseCandidateReplica sorts given replicas of a single master and chooses the best one to promote.
+// Unlike GetCandidateReplica, it neither reads from the backend nor touches the replicas themselves,
+// and is thus suitable for offline evaluation of promotion logic.
+func ChooseCandidateReplica(replicas [](*Instance), dataCenterHint string) (candidateReplica *Instance, aheadReplicas, equalReplicas, laterReplicas, cannotReplicateReplicas [](*Instance), err error) {
+	sorted := make([](*Instance), len(replicas))
+	copy(sorted, replicas)
+	sortInstancesDataCenterHint(sorted, dataCenterHint)
+	return chooseCandidateReplica(sorted)
+}
+
 // GetCandidateReplica chooses the best replica to promote given a (possibly dead) master
 func GetCandidateReplica(masterKey *InstanceKey, forRematchPurposes bool) (*Instance, [](*Instance), [](*Instance), [](*Instance), [](*Instance), error) {
 	var candidateReplica *Instance
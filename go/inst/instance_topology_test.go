@@ -2,6 +2,7 @@ package inst
 
 import (
 	"math/rand"
+	"strings"
 
 	"github.com/openark/golib/log"
 	test "github.com/openark/golib/tests"
@@ -568,3 +569,29 @@ func TestChooseCandidateReplicaPromoteRuleOrdering3(t *testing.T) {
 	test.S(t).ExpectEquals(len(laterReplicas), 0)
 	test.S(t).ExpectEquals(len(cannotReplicateReplicas), 0)
 }
+
+func TestMoveToClusterRollbackRefusal(t *testing.T) {
+	targetKey := InstanceKey{Hostname: "target", Port: 3306}
+	originalMasterKey := InstanceKey{Hostname: "original", Port: 3306}
+	movedCoordinates := BinlogCoordinates{LogFile: "mysql-bin.000042", LogPos: 1000}
+
+	// never got to replicate from the target
+	instance := &Instance{Key: i710Key, MasterKey: originalMasterKey, ExecBinlogCoordinates: BinlogCoordinates{LogFile: "mysql.000007", LogPos: 500}}
+	test.S(t).ExpectEquals(moveToClusterRollbackRefusal(instance, &targetKey, &movedCoordinates, ""), "")
+
+	// pointed at the target, nothing executed
+	instance = &Instance{Key: i710Key, MasterKey: targetKey, ExecBinlogCoordinates: movedCoordinates}
+	test.S(t).ExpectEquals(moveToClusterRollbackRefusal(instance, &targetKey, &movedCoordinates, ""), "")
+
+	// executed events of the target
+	instance.ExecBinlogCoordinates = BinlogCoordinates{LogFile: "mysql-bin.000042", LogPos: 1500}
+	test.S(t).ExpectNotEquals(moveToClusterRollbackRefusal(instance, &targetKey, &movedCoordinates, ""), "")
+	instance.ExecBinlogCoordinates = BinlogCoordinates{LogFile: "mysql-bin.000043", LogPos: 4}
+	test.S(t).ExpectNotEquals(moveToClusterRollbackRefusal(instance, &targetKey, &movedCoordinates, ""), "")
+
+	// moved via GTID: coordinates are unknown, executed transactions tell
+	instance = &Instance{Key: i710Key, MasterKey: targetKey, ExecBinlogCoordinates: BinlogCoordinates{LogFile: "mysql-bin.000042", LogPos: 1500}}
+	test.S(t).ExpectEquals(moveToClusterRollbackRefusal(instance, &targetKey, nil, ""), "")
+	refusal := moveToClusterRollbackRefusal(instance, &targetKey, nil, "00020192-1111-1111-1111-111111111111:7-9")
+	test.S(t).ExpectTrue(strings.Contains(refusal, "00020192-1111-1111-1111-111111111111:7-9"))
+}
@@ -19,6 +19,7 @@ package inst
 import (
 	"net"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -201,6 +202,46 @@ func RemoveNilInstances(instances [](*Instance)) [](*Instance) {
 	return instances
 }
 
+// RollingOperationOrder orders given instances of a topology leaf-first, so that an instance comes before the
+// master it replicates from: deepest replicas first, then intermediate masters, and the topology master(s) last.
+// An instance is considered a topology master when it does not replicate from any of the given instances, or
+// when it is the writable member of a co-master pair. Masters are only included if includeMasters is true.
+func RollingOperationOrder(instances [](*Instance), includeMasters bool) [](*Instance) {
+	instancesMap := make(map[InstanceKey]*Instance)
+	for _, instance := range instances {
+		instancesMap[instance.Key] = instance
+	}
+	isTop := func(instance *Instance) bool {
+		if !instance.IsReplica() || (instance.IsCoMaster && !instance.ReadOnly) {
+			return true
+		}
+		_, found := instancesMap[instance.MasterKey]
+		return !found
+	}
+	depths := make(map[InstanceKey]int)
+	for _, instance := range instances {
+		depth := 0
+		// bounded walk up the topology, in case of replication cycles
+		for ancestor := instance; !isTop(ancestor) && depth < len(instances); ancestor = instancesMap[ancestor.MasterKey] {
+			depth++
+		}
+		depths[instance.Key] = depth
+	}
+	ordered := [](*Instance){}
+	for _, instance := range instances {
+		if depths[instance.Key] > 0 || includeMasters {
+			ordered = append(ordered, instance)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if depths[ordered[i].Key] != depths[ordered[j].Key] {
+			return depths[ordered[i].Key] > depths[ordered[j].Key]
+		}
+		return ordered[i].Key.SmallerThan(&ordered[j].Key)
+	})
+	return ordered
+}
+
 // SemicolonTerminated is a utility function that makes sure a statement is terminated with
 // a semicolon, if it isn't already
 func SemicolonTerminated(statement string) string {
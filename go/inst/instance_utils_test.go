@@ -1,6 +1,7 @@
 package inst
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -34,3 +35,46 @@ func TestRegexpMatchPatterns(t *testing.T) {
 		})
 	}
 }
+
+func TestRollingOperationOrder(t *testing.T) {
+	newInstance := func(hostname string, masterHostname string) *Instance {
+		instance := NewInstance()
+		instance.Key = InstanceKey{Hostname: hostname, Port: 3306}
+		if masterHostname != "" {
+			instance.MasterKey = InstanceKey{Hostname: masterHostname, Port: 3306}
+			instance.ReadBinlogCoordinates = BinlogCoordinates{LogFile: "mysql-bin.000001", LogPos: 4}
+		}
+		return instance
+	}
+	// m <- (r1 <- (r11 <- r111), r12), r2
+	instances := [](*Instance){
+		newInstance("m", ""),
+		newInstance("r12", "r1"),
+		newInstance("r1", "m"),
+		newInstance("r2", "m"),
+		newInstance("r111", "r11"),
+		newInstance("r11", "r1"),
+	}
+	orderedHostnames := func(ordered [](*Instance)) string {
+		hostnames := []string{}
+		for _, instance := range ordered {
+			hostnames = append(hostnames, instance.Key.Hostname)
+		}
+		return strings.Join(hostnames, ",")
+	}
+	if ordered := orderedHostnames(RollingOperationOrder(instances, false)); ordered != "r111,r11,r12,r1,r2" {
+		t.Errorf("unexpected order: %s", ordered)
+	}
+	if ordered := orderedHostnames(RollingOperationOrder(instances, true)); ordered != "r111,r11,r12,r1,r2,m" {
+		t.Errorf("unexpected order: %s", ordered)
+	}
+
+	// co-masters: the writable one is the topology master
+	coMasters := [](*Instance){newInstance("c1", "c2"), newInstance("c2", "c1"), newInstance("r", "c1")}
+	coMasters[0].IsCoMaster = true
+	coMasters[1].IsCoMaster = true
+	coMasters[1].ReadOnly = true
+	if ordered := orderedHostnames(RollingOperationOrder(coMasters, true)); ordered != "c2,r,c1" {
+		t.Errorf("unexpected order: %s", ordered)
+	}
+}
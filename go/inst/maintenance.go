@@ -17,6 +17,8 @@
 package inst
 
 import (
+	"strings"
+
 	"github.com/openark/orchestrator/go/config"
 )
 
@@ -25,12 +27,18 @@ type Maintenance struct {
 	MaintenanceId  uint
 	Key            InstanceKey
 	BeginTimestamp string
+	EndTimestamp   string
 	SecondsElapsed uint
 	IsActive       bool
 	Owner          string
 	Reason         string
 }
 
+// MaintenanceOwnerSystemSeparator separates the system from the identity in a maintenance owner. By convention,
+// automation systems own maintenance as "system:identity", e.g. "backup:backup-host-17", so that they neither
+// collide with each other nor with humans, and so that each can find what it owns.
+const MaintenanceOwnerSystemSeparator = ":"
+
 var maintenanceOwner string = ""
 
 func GetMaintenanceOwner() string {
@@ -43,3 +51,16 @@ func GetMaintenanceOwner() string {
 func SetMaintenanceOwner(owner string) {
 	maintenanceOwner = owner
 }
+
+// MaintenanceOwnedBy checks whether maintenance owned by entryOwner is owned by given owner: either exactly, or,
+// when owner names a system (e.g. "backup"), by any identity of that system (e.g. "backup:backup-host-17")
+func MaintenanceOwnedBy(entryOwner string, owner string) bool {
+	entryOwner = strings.TrimSpace(entryOwner)
+	if entryOwner == owner {
+		return true
+	}
+	if owner == "" || strings.Contains(owner, MaintenanceOwnerSystemSeparator) {
+		return false
+	}
+	return strings.HasPrefix(entryOwner, owner+MaintenanceOwnerSystemSeparator)
+}
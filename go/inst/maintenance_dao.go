@@ -27,15 +27,15 @@ import (
 	"github.com/openark/orchestrator/go/util"
 )
 
-// ReadActiveMaintenance returns the list of currently active maintenance entries
-func ReadActiveMaintenance() ([]Maintenance, error) {
+func readActiveMaintenance(whereCondition string, args []interface{}) ([]Maintenance, error) {
 	res := []Maintenance{}
-	query := `
+	query := fmt.Sprintf(`
 		select
 			database_instance_maintenance_id,
 			hostname,
 			port,
 			begin_timestamp,
+			end_timestamp,
 			unix_timestamp() - unix_timestamp(begin_timestamp) as seconds_elapsed,
 			maintenance_active,
 			owner,
@@ -44,15 +44,17 @@ func ReadActiveMaintenance() ([]Maintenance, error) {
 			database_instance_maintenance
 		where
 			maintenance_active = 1
+			%s
 		order by
 			database_instance_maintenance_id
-		`
-	err := db.QueryOrchestratorRowsMap(query, func(m sqlutils.RowMap) error {
+		`, whereCondition)
+	err := db.QueryOrchestrator(query, args, func(m sqlutils.RowMap) error {
 		maintenance := Maintenance{}
 		maintenance.MaintenanceId = m.GetUint("database_instance_maintenance_id")
 		maintenance.Key.Hostname = m.GetString("hostname")
 		maintenance.Key.Port = m.GetInt("port")
 		maintenance.BeginTimestamp = m.GetString("begin_timestamp")
+		maintenance.EndTimestamp = m.GetString("end_timestamp")
 		maintenance.SecondsElapsed = m.GetUint("seconds_elapsed")
 		maintenance.IsActive = m.GetBool("maintenance_active")
 		maintenance.Owner = m.GetString("owner")
@@ -66,7 +68,79 @@ func ReadActiveMaintenance() ([]Maintenance, error) {
 		log.Errore(err)
 	}
 	return res, err
+}
+
+// ReadActiveMaintenance returns the list of currently active maintenance entries
+func ReadActiveMaintenance() ([]Maintenance, error) {
+	return readActiveMaintenance("", sqlutils.Args())
+}
+
+// ReadMaintenanceExpiringWithin returns active maintenance entries which are due to expire within given number of seconds
+func ReadMaintenanceExpiringWithin(seconds int) ([]Maintenance, error) {
+	return readActiveMaintenance("and end_timestamp > now() and end_timestamp < now() + interval ? second", sqlutils.Args(seconds))
+}
+
+// ReadMaintenanceByOwner returns active maintenance entries owned by given owner, or, when owner names a
+// system, by any identity of that system. See MaintenanceOwnedBy.
+func ReadMaintenanceByOwner(owner string) ([]Maintenance, error) {
+	res := []Maintenance{}
+	maintenanceList, err := ReadActiveMaintenance()
+	if err != nil {
+		return res, err
+	}
+	for _, maintenance := range maintenanceList {
+		if MaintenanceOwnedBy(maintenance.Owner, owner) {
+			res = append(res, maintenance)
+		}
+	}
+	return res, nil
+}
+
+// TakeOverMaintenance transfers the active maintenance on given instance to a new owner, e.g. when an automation
+// system resumes work started by another. It returns the previous owner.
+func TakeOverMaintenance(instanceKey *InstanceKey, newOwner string) (previousOwner string, err error) {
+	if newOwner == "" {
+		return previousOwner, fmt.Errorf("TakeOverMaintenance: owner required")
+	}
+	maintenanceList, err := readActiveMaintenance("and hostname = ? and port = ?", sqlutils.Args(instanceKey.Hostname, instanceKey.Port))
+	if err != nil {
+		return previousOwner, err
+	}
+	if len(maintenanceList) == 0 {
+		return previousOwner, fmt.Errorf("No active maintenance on %+v", *instanceKey)
+	}
+	maintenance := maintenanceList[0]
+	previousOwner = maintenance.Owner
+	if previousOwner == newOwner {
+		return previousOwner, nil
+	}
+	res, err := db.ExecOrchestrator(`
+			update
+				database_instance_maintenance
+			set
+				owner = ?
+			where
+				database_instance_maintenance_id = ?
+				and owner = ?
+				and maintenance_active = 1
+			`,
+		newOwner,
+		maintenance.MaintenanceId,
+		previousOwner,
+	)
+	if err != nil {
+		return previousOwner, log.Errore(err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return previousOwner, fmt.Errorf("Maintenance on %+v changed while taking it over; retry", *instanceKey)
+	}
+	AuditOperation("take-over-maintenance", instanceKey, fmt.Sprintf("maintenanceToken: %d, owner: %s, previous owner: %s", maintenance.MaintenanceId, newOwner, previousOwner))
+	return previousOwner, nil
+}
 
+// ReadInstanceActiveMaintenance returns the active maintenance entries of given instance
+func ReadInstanceActiveMaintenance(instanceKey *InstanceKey) ([]Maintenance, error) {
+	return readActiveMaintenance("and hostname = ? and port = ?", sqlutils.Args(instanceKey.Hostname, instanceKey.Port))
 }
 
 // BeginBoundedMaintenance will make new maintenance entry for given instanceKey.
@@ -213,6 +287,61 @@ func EndMaintenance(maintenanceToken int64) (wasMaintenance bool, err error) {
 	return wasMaintenance, err
 }
 
+// RenewMaintenance extends an active, unexpired maintenance by given number of seconds from now, provided it is
+// owned by given owner. It returns false when the maintenance has ended, expired or changed owner.
+func RenewMaintenance(maintenanceToken int64, owner string, durationSeconds uint) (renewed bool, err error) {
+	if durationSeconds == 0 {
+		durationSeconds = config.MaintenanceExpireMinutes * 60
+	}
+	res, err := db.ExecOrchestrator(`
+			update
+				database_instance_maintenance
+			set
+				end_timestamp = NOW() + INTERVAL ? SECOND
+			where
+				database_instance_maintenance_id = ?
+				and owner = ?
+				and maintenance_active = 1
+				and end_timestamp > NOW()
+			`,
+		durationSeconds,
+		maintenanceToken,
+		owner,
+	)
+	if err != nil {
+		return renewed, log.Errore(err)
+	}
+	affected, _ := res.RowsAffected()
+	return affected > 0, nil
+}
+
+// EndMaintenanceOwnedBy terminates an active maintenance via maintenanceToken, provided it is owned by given owner
+func EndMaintenanceOwnedBy(maintenanceToken int64, owner string) (wasMaintenance bool, err error) {
+	res, err := db.ExecOrchestrator(`
+			update
+				database_instance_maintenance
+			set
+				maintenance_active = NULL,
+				end_timestamp = NOW()
+			where
+				database_instance_maintenance_id = ?
+				and owner = ?
+				and maintenance_active = 1
+			`,
+		maintenanceToken,
+		owner,
+	)
+	if err != nil {
+		return wasMaintenance, log.Errore(err)
+	}
+	if affected, _ := res.RowsAffected(); affected > 0 {
+		wasMaintenance = true
+		instanceKey, _ := ReadMaintenanceInstanceKey(maintenanceToken)
+		AuditOperation("end-maintenance", instanceKey, fmt.Sprintf("maintenanceToken: %d, owner: %s", maintenanceToken, owner))
+	}
+	return wasMaintenance, err
+}
+
 // ExpireMaintenance will remove the maintenance flag on old maintenances and on bounded maintenances
 func ExpireMaintenance() error {
 	{
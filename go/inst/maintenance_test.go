@@ -0,0 +1,35 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"testing"
+
+	test "github.com/openark/golib/tests"
+)
+
+func TestMaintenanceOwnedBy(t *testing.T) {
+	test.S(t).ExpectTrue(MaintenanceOwnedBy("backup:host-17", "backup:host-17"))
+	test.S(t).ExpectTrue(MaintenanceOwnedBy("backup:host-17", "backup"))
+	test.S(t).ExpectTrue(MaintenanceOwnedBy("backup", "backup"))
+	test.S(t).ExpectTrue(MaintenanceOwnedBy("", ""))
+	test.S(t).ExpectFalse(MaintenanceOwnedBy("backup:host-17", "backup:host-18"))
+	test.S(t).ExpectFalse(MaintenanceOwnedBy("backup:host-17", "back"))
+	test.S(t).ExpectFalse(MaintenanceOwnedBy("backups:host-17", "backup"))
+	test.S(t).ExpectFalse(MaintenanceOwnedBy("backup:host-17", ""))
+	test.S(t).ExpectFalse(MaintenanceOwnedBy("backup", "backup:host-17"))
+}
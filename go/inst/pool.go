@@ -17,6 +17,7 @@
 package inst
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -43,6 +44,27 @@ func NewPoolInstancesSubmission(pool string, instances string) *PoolInstancesSub
 	}
 }
 
+// Validate checks the submission names a pool, and that its instances parse, without resolving them.
+// An empty list of instances is valid, and empties the pool.
+func (this *PoolInstancesSubmission) Validate() error {
+	if strings.TrimSpace(this.Pool) == "" {
+		return fmt.Errorf("Pool instances submission: empty pool name")
+	}
+	if this.DelimitedInstances == "" {
+		return nil
+	}
+	for _, instanceString := range strings.Split(this.DelimitedInstances, ",") {
+		instanceString = strings.TrimSpace(instanceString)
+		if instanceString == "" {
+			return fmt.Errorf("Pool instances submission: empty instance in %s", this.DelimitedInstances)
+		}
+		if _, err := ParseRawInstanceKey(instanceString); err != nil {
+			return fmt.Errorf("Pool instances submission: %+v", err)
+		}
+	}
+	return nil
+}
+
 // ClusterPoolInstance is an instance mapping a cluster, pool & instance
 type ClusterPoolInstance struct {
 	ClusterName  string
@@ -74,6 +96,5 @@ func ApplyPoolInstances(submission *PoolInstancesSubmission) error {
 		}
 	}
 	log.Debugf("submitting %d instances in %+v pool", len(instanceKeys), submission.Pool)
-	writePoolInstances(submission.Pool, instanceKeys)
-	return nil
+	return writePoolInstances(submission.Pool, instanceKeys)
 }
@@ -33,20 +33,22 @@ func writePoolInstances(pool string, instanceKeys [](*InstanceKey)) error {
 			return log.Errore(err)
 		}
 		tx, err := dbh.Begin()
+		if err != nil {
+			return log.Errore(err)
+		}
 		if _, err := tx.Exec(`delete from database_instance_pool where pool = ?`, pool); err != nil {
 			tx.Rollback()
 			return log.Errore(err)
 		}
-		query := `insert into database_instance_pool (hostname, port, pool, registered_at) values (?, ?, ?, now())`
+		// Statements of this transaction are not translated to the sqlite dialect: current_timestamp works on both backends
+		query := `insert into database_instance_pool (hostname, port, pool, registered_at) values (?, ?, ?, current_timestamp)`
 		for _, instanceKey := range instanceKeys {
 			if _, err := tx.Exec(query, instanceKey.Hostname, instanceKey.Port, pool); err != nil {
 				tx.Rollback()
 				return log.Errore(err)
 			}
 		}
-		tx.Commit()
-
-		return nil
+		return tx.Commit()
 	}
 	return ExecDBWriteFunc(writeFunc)
 }
@@ -0,0 +1,18 @@
+package inst
+
+import (
+	"testing"
+
+	test "github.com/openark/golib/tests"
+)
+
+func TestPoolInstancesSubmissionValidate(t *testing.T) {
+	test.S(t).ExpectNil(NewPoolInstancesSubmission("mypool", "").Validate())
+	test.S(t).ExpectNil(NewPoolInstancesSubmission("mypool", "host1:3306").Validate())
+	test.S(t).ExpectNil(NewPoolInstancesSubmission("mypool", "host1:3306, host2,10.0.0.1:3307").Validate())
+	test.S(t).ExpectNotNil(NewPoolInstancesSubmission("", "host1:3306").Validate())
+	test.S(t).ExpectNotNil(NewPoolInstancesSubmission("mypool", "host1:3306,").Validate())
+	test.S(t).ExpectNotNil(NewPoolInstancesSubmission("mypool", "host1:3306,,host2:3306").Validate())
+	test.S(t).ExpectNotNil(NewPoolInstancesSubmission("mypool", "[host1:3306 host2:3306]").Validate())
+	test.S(t).ExpectNotNil(NewPoolInstancesSubmission("mypool", "host1:port").Validate())
+}
@@ -0,0 +1,166 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"fmt"
+
+	"github.com/openark/orchestrator/go/config"
+)
+
+const (
+	RelocationMethodGTID              = "gtid"
+	RelocationMethodPseudoGTID        = "pseudo-gtid"
+	RelocationMethodBinlogCoordinates = "binlog-coordinates"
+	RelocationMethodNone              = "none"
+)
+
+// PseudoGTIDStatus describes the state of Pseudo-GTID on a cluster
+type PseudoGTIDStatus struct {
+	ClusterName          string
+	PseudoGTIDEnabled    bool // PseudoGTIDPattern is configured
+	AutoPseudoGTID       bool
+	RecentlyInjected     bool // orchestrator injected Pseudo-GTID on this cluster within PseudoGTIDExpireMinutes
+	CountInstances       int
+	CountUsingPseudoGTID int
+	Active               bool
+}
+
+// ReadClusterPseudoGTIDStatus checks whether Pseudo-GTID is actively in use on given cluster
+func ReadClusterPseudoGTIDStatus(clusterName string) (*PseudoGTIDStatus, error) {
+	status := &PseudoGTIDStatus{
+		ClusterName:       clusterName,
+		PseudoGTIDEnabled: config.Config.PseudoGTIDPattern != "",
+		AutoPseudoGTID:    config.Config.AutoPseudoGTID,
+	}
+	injected, err := isInjectedPseudoGTID(clusterName)
+	if err != nil {
+		return status, err
+	}
+	status.RecentlyInjected = injected
+	instances, err := ReadClusterInstances(clusterName)
+	if err != nil {
+		return status, err
+	}
+	status.CountInstances = len(instances)
+	for _, instance := range instances {
+		if instance.UsingPseudoGTID {
+			status.CountUsingPseudoGTID++
+		}
+	}
+	status.Active = status.PseudoGTIDEnabled && (status.RecentlyInjected || status.CountUsingPseudoGTID > 0)
+	return status, nil
+}
+
+// RelocationMethodAdvice explains which method would be used to relocate an instance below another,
+// along the same order of preference as `relocate`.
+type RelocationMethodAdvice struct {
+	InstanceKey    InstanceKey
+	OtherKey       InstanceKey
+	Method         string
+	CanMatch       bool // Pseudo-GTID matching is feasible between the two instances
+	GTIDCompatible bool
+	Reasons        []string
+}
+
+func (this *RelocationMethodAdvice) addReason(format string, args ...interface{}) {
+	this.Reasons = append(this.Reasons, fmt.Sprintf(format, args...))
+}
+
+// checkPseudoGTIDMatch returns nil when instance can be matched below other via Pseudo-GTID
+func checkPseudoGTIDMatch(instance, other *Instance) error {
+	if config.Config.PseudoGTIDPattern == "" {
+		return fmt.Errorf("PseudoGTIDPattern not configured")
+	}
+	if !instance.UsingPseudoGTID {
+		return fmt.Errorf("%+v is not known to have Pseudo-GTID entries", instance.Key)
+	}
+	if !other.UsingPseudoGTID {
+		return fmt.Errorf("%+v is not known to have Pseudo-GTID entries", other.Key)
+	}
+	if canMove, err := instance.CanMoveViaMatch(); !canMove {
+		return err
+	}
+	if other.IsCoMaster && !other.ReadOnly {
+		return fmt.Errorf("%+v is a writable co-master; active-active Pseudo-GTID matching is not supported", other.Key)
+	}
+	if instance.LogBinEnabled && instance.LogReplicationUpdatesEnabled && other.LogBinEnabled {
+		if (instance.Binlog_format == "ROW") != (other.Binlog_format == "ROW") {
+			return fmt.Errorf("cannot match %s binlogs on %+v with %s binlogs on %+v", instance.Binlog_format, instance.Key, other.Binlog_format, other.Key)
+		}
+	}
+	return nil
+}
+
+// AdviseRelocationMethod examines given instances and recommends whether instance should be relocated below other
+// via GTID, via Pseudo-GTID matching (match-below), or via plain binlog coordinates (move-below, move-up).
+// It does not access the backend and does not modify any server.
+func AdviseRelocationMethod(instance, other *Instance) *RelocationMethodAdvice {
+	advice := &RelocationMethodAdvice{
+		InstanceKey: instance.Key,
+		OtherKey:    other.Key,
+		Method:      RelocationMethodNone,
+		Reasons:     []string{},
+	}
+	if canReplicate, err := instance.CanReplicateFrom(other); !canReplicate {
+		advice.addReason("cannot replicate: %+v", err)
+		return advice
+	}
+	_, _, advice.GTIDCompatible = instancesAreGTIDAndCompatible(instance, other)
+	if err := checkPseudoGTIDMatch(instance, other); err == nil {
+		advice.CanMatch = true
+	} else {
+		advice.addReason("Pseudo-GTID: %+v", err)
+	}
+
+	if advice.GTIDCompatible {
+		advice.Method = RelocationMethodGTID
+		advice.addReason("both instances are GTID compatible; GTID is preferred over Pseudo-GTID")
+		return advice
+	}
+	advice.addReason("GTID: instances are not GTID compatible or not using GTID")
+	if advice.CanMatch {
+		advice.Method = RelocationMethodPseudoGTID
+		advice.addReason("Pseudo-GTID matching only stops replication on %+v", instance.Key)
+		return advice
+	}
+	if InstancesAreSiblings(instance, other) && (!other.IsCoMaster || other.ReadOnly) {
+		advice.Method = RelocationMethodBinlogCoordinates
+		advice.addReason("instances are siblings; move-below requires briefly stopping replication on both")
+		return advice
+	}
+	if InstanceIsMasterOf(other, instance) {
+		advice.Method = RelocationMethodBinlogCoordinates
+		advice.addReason("%+v already replicates from %+v", instance.Key, other.Key)
+		return advice
+	}
+	advice.addReason("no single step binlog coordinates operation applies; moving up to grandparent may still be possible")
+	return advice
+}
+
+// ReadRelocationMethodAdvice reads given instances and advises on the method to relocate instance below other
+func ReadRelocationMethodAdvice(instanceKey, otherKey *InstanceKey) (*RelocationMethodAdvice, error) {
+	instance, found, err := ReadInstance(instanceKey)
+	if err != nil || !found {
+		return nil, fmt.Errorf("Instance not found: %+v", *instanceKey)
+	}
+	other, found, err := ReadInstance(otherKey)
+	if err != nil || !found {
+		return nil, fmt.Errorf("Instance not found: %+v", *otherKey)
+	}
+	return AdviseRelocationMethod(instance, other), nil
+}
@@ -0,0 +1,40 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+const (
+	MasterRecoveryFilterType             = "master"
+	IntermediateMasterRecoveryFilterType = "intermediate-master"
+)
+
+// RecoveryFilter is a cluster filter pattern, stored in the backend database, which extends
+// RecoverMasterClusterFilters or RecoverIntermediateMasterClusterFilters at runtime.
+type RecoveryFilter struct {
+	FilterType     string
+	Pattern        string
+	Owner          string
+	AddedTimestamp string
+}
+
+// NewRecoveryFilter creates a recovery filter of given type and pattern
+func NewRecoveryFilter(filterType string, pattern string, owner string) *RecoveryFilter {
+	return &RecoveryFilter{
+		FilterType: filterType,
+		Pattern:    pattern,
+		Owner:      owner,
+	}
+}
@@ -0,0 +1,167 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/golib/sqlutils"
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/db"
+	"github.com/patrickmn/go-cache"
+)
+
+var recoveryFilterPatternsCache = cache.New(time.Duration(config.RecoveryPollSeconds)*time.Second, time.Second)
+
+// validateRecoveryFilter checks the filter type, and that the pattern is usable by filtersMatchCluster
+func validateRecoveryFilter(filter *RecoveryFilter) error {
+	switch filter.FilterType {
+	case MasterRecoveryFilterType, IntermediateMasterRecoveryFilterType:
+	default:
+		return fmt.Errorf("Unknown recovery filter type: %s. Expected %s or %s", filter.FilterType, MasterRecoveryFilterType, IntermediateMasterRecoveryFilterType)
+	}
+	if filter.Pattern == "" {
+		return fmt.Errorf("Empty recovery filter pattern")
+	}
+	pattern := filter.Pattern
+	if strings.HasPrefix(pattern, "alias=") || pattern == "*" {
+		return nil
+	}
+	if strings.HasPrefix(pattern, "alias~=") {
+		pattern = strings.SplitN(pattern, "~=", 2)[1]
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("Invalid recovery filter pattern %s: %+v", filter.Pattern, err)
+	}
+	return nil
+}
+
+// AddRecoveryFilter persists a recovery filter, which then applies in addition to the configured filters
+func AddRecoveryFilter(filter *RecoveryFilter) error {
+	if err := validateRecoveryFilter(filter); err != nil {
+		return err
+	}
+	_, err := db.ExecOrchestrator(`
+			insert ignore
+				into recovery_filter (
+					filter_type, pattern, owner, added_timestamp
+				) VALUES (
+					?, ?, ?, NOW()
+				)
+			`,
+		filter.FilterType,
+		filter.Pattern,
+		filter.Owner,
+	)
+	if err != nil {
+		return log.Errore(err)
+	}
+	recoveryFilterPatternsCache.Flush()
+	AuditOperation("add-recovery-filter", nil, fmt.Sprintf("%s: %s, owner: %s", filter.FilterType, filter.Pattern, filter.Owner))
+	return nil
+}
+
+// RemoveRecoveryFilter removes a persisted recovery filter. Configured filters are not affected.
+func RemoveRecoveryFilter(filter *RecoveryFilter) error {
+	res, err := db.ExecOrchestrator(`
+			delete from
+				recovery_filter
+			where
+				filter_type = ?
+				and pattern = ?
+			`,
+		filter.FilterType,
+		filter.Pattern,
+	)
+	if err != nil {
+		return log.Errore(err)
+	}
+	recoveryFilterPatternsCache.Flush()
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return fmt.Errorf("No %s recovery filter found for pattern %s", filter.FilterType, filter.Pattern)
+	}
+	AuditOperation("remove-recovery-filter", nil, fmt.Sprintf("%s: %s", filter.FilterType, filter.Pattern))
+	return nil
+}
+
+// ReadRecoveryFilters returns all persisted recovery filters
+func ReadRecoveryFilters() ([]RecoveryFilter, error) {
+	filters := []RecoveryFilter{}
+	query := `
+		select
+			filter_type,
+			pattern,
+			owner,
+			added_timestamp
+		from
+			recovery_filter
+		order by
+			filter_type, pattern
+		`
+	err := db.QueryOrchestrator(query, sqlutils.Args(), func(m sqlutils.RowMap) error {
+		filter := RecoveryFilter{
+			FilterType:     m.GetString("filter_type"),
+			Pattern:        m.GetString("pattern"),
+			Owner:          m.GetString("owner"),
+			AddedTimestamp: m.GetString("added_timestamp"),
+		}
+		filters = append(filters, filter)
+		return nil
+	})
+	return filters, log.Errore(err)
+}
+
+// readRecoveryFilterPatterns returns the persisted patterns of given filter type. Results are briefly cached.
+func readRecoveryFilterPatterns(filterType string) (patterns []string, err error) {
+	if patterns, found := recoveryFilterPatternsCache.Get(filterType); found {
+		return patterns.([]string), nil
+	}
+	query := `
+		select
+			pattern
+		from
+			recovery_filter
+		where
+			filter_type = ?
+		`
+	err = db.QueryOrchestrator(query, sqlutils.Args(filterType), func(m sqlutils.RowMap) error {
+		patterns = append(patterns, m.GetString("pattern"))
+		return nil
+	})
+	if err != nil {
+		return patterns, log.Errore(err)
+	}
+	recoveryFilterPatternsCache.Set(filterType, patterns, cache.DefaultExpiration)
+	return patterns, nil
+}
+
+// GetRecoveryFilterPatterns returns the configured patterns of given filter type, followed by the persisted ones
+func GetRecoveryFilterPatterns(filterType string) []string {
+	patterns := []string{}
+	switch filterType {
+	case MasterRecoveryFilterType:
+		patterns = append(patterns, config.Config.RecoverMasterClusterFilters...)
+	case IntermediateMasterRecoveryFilterType:
+		patterns = append(patterns, config.Config.RecoverIntermediateMasterClusterFilters...)
+	}
+	persistedPatterns, _ := readRecoveryFilterPatterns(filterType)
+	return append(patterns, persistedPatterns...)
+}
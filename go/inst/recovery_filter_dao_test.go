@@ -0,0 +1,63 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/patrickmn/go-cache"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/config"
+)
+
+func TestValidateRecoveryFilter(t *testing.T) {
+	valid := []*RecoveryFilter{
+		NewRecoveryFilter(MasterRecoveryFilterType, "*", "dba"),
+		NewRecoveryFilter(MasterRecoveryFilterType, "alias=cluster(1", "dba"),
+		NewRecoveryFilter(IntermediateMasterRecoveryFilterType, "alias~=^prod-", "dba"),
+		NewRecoveryFilter(MasterRecoveryFilterType, "db/[0-9]+\\.example\\.com", "dba"),
+	}
+	for _, filter := range valid {
+		test.S(t).ExpectNil(validateRecoveryFilter(filter))
+	}
+	invalid := []*RecoveryFilter{
+		NewRecoveryFilter("replica", "*", "dba"),
+		NewRecoveryFilter(MasterRecoveryFilterType, "", "dba"),
+		NewRecoveryFilter(MasterRecoveryFilterType, "prod-(", "dba"),
+		NewRecoveryFilter(MasterRecoveryFilterType, "alias~=prod-(", "dba"),
+	}
+	for _, filter := range invalid {
+		test.S(t).ExpectNotNil(validateRecoveryFilter(filter))
+	}
+}
+
+func TestGetRecoveryFilterPatterns(t *testing.T) {
+	defer func(filters []string) { config.Config.RecoverMasterClusterFilters = filters }(config.Config.RecoverMasterClusterFilters)
+	defer func(filters []string) { config.Config.RecoverIntermediateMasterClusterFilters = filters }(config.Config.RecoverIntermediateMasterClusterFilters)
+	defer recoveryFilterPatternsCache.Flush()
+
+	config.Config.RecoverMasterClusterFilters = []string{"alias=configured"}
+	config.Config.RecoverIntermediateMasterClusterFilters = []string{"*"}
+	// persisted patterns, as read off the backend
+	recoveryFilterPatternsCache.Set(MasterRecoveryFilterType, []string{"alias=added", "db/1"}, cache.DefaultExpiration)
+	recoveryFilterPatternsCache.Set(IntermediateMasterRecoveryFilterType, []string(nil), cache.DefaultExpiration)
+
+	test.S(t).ExpectEquals(strings.Join(GetRecoveryFilterPatterns(MasterRecoveryFilterType), " "), "alias=configured alias=added db/1")
+	test.S(t).ExpectEquals(strings.Join(GetRecoveryFilterPatterns(IntermediateMasterRecoveryFilterType), " "), "*")
+}
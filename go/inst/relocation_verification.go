@@ -0,0 +1,82 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/openark/golib/log"
+)
+
+const relocationVerificationInterval = time.Second
+
+// VerifiedInstance is the outcome of verifying that an instance, following a relocation, actually replicates
+// from its expected master. A relocation completes as soon as replication is started, which does not imply
+// replication is healthy.
+type VerifiedInstance struct {
+	Instance          *Instance
+	ExpectedMasterKey InstanceKey
+	Verified          bool   // the instance replicates from ExpectedMasterKey, both replication threads running
+	Reason            string // why the instance was not verified, as of the last check
+	Attempts          int
+	ElapsedSeconds    float64
+}
+
+// replicationVerificationFailure returns why given instance does not healthily replicate from given master,
+// or an empty string if it does
+func replicationVerificationFailure(instance *Instance, masterKey *InstanceKey) string {
+	switch {
+	case !instance.MasterKey.Equals(masterKey):
+		return fmt.Sprintf("replicates from %+v, expected %+v", instance.MasterKey, *masterKey)
+	case instance.LastIOError != "":
+		return fmt.Sprintf("IO thread error: %s", instance.LastIOError)
+	case instance.LastSQLError != "":
+		return fmt.Sprintf("SQL thread error: %s", instance.LastSQLError)
+	case !instance.ReplicaRunning():
+		return "replication threads not running"
+	}
+	return ""
+}
+
+// VerifyReplication polls an instance until it replicates from given master with both replication threads
+// running and no replication errors, or until given timeout elapses, in which case an error is returned
+// along with the last observed state.
+func VerifyReplication(instanceKey *InstanceKey, masterKey *InstanceKey, timeout time.Duration) (*VerifiedInstance, error) {
+	verified := &VerifiedInstance{ExpectedMasterKey: *masterKey}
+	startTime := time.Now()
+	for {
+		verified.Attempts++
+		instance, err := ReadTopologyInstance(instanceKey)
+		if err != nil {
+			verified.Reason = err.Error()
+		} else {
+			verified.Instance = instance
+			verified.Reason = replicationVerificationFailure(instance, masterKey)
+			verified.Verified = (verified.Reason == "")
+		}
+		verified.ElapsedSeconds = time.Since(startTime).Seconds()
+		if verified.Verified {
+			AuditOperation("verify-replication", instanceKey, fmt.Sprintf("verified replicating from %+v after %d attempts", *masterKey, verified.Attempts))
+			return verified, nil
+		}
+		if time.Since(startTime)+relocationVerificationInterval > timeout {
+			return verified, log.Errorf("%+v not verified replicating from %+v within %+v: %s", *instanceKey, *masterKey, timeout, verified.Reason)
+		}
+		time.Sleep(relocationVerificationInterval)
+	}
+}
@@ -102,21 +102,11 @@ type InstanceTag struct {
 	T   Tag
 }
 
+// GetInstanceKeysByTags returns the keys of instances matching given tag query. See TagQuery.
 func GetInstanceKeysByTags(tagsString string) (tagged *InstanceKeyMap, err error) {
-	tags, err := ParseIntersectTags(tagsString)
+	query, err := ParseTagQuery(tagsString)
 	if err != nil {
 		return tagged, err
 	}
-	for i, tag := range tags {
-		taggedByTag, err := GetInstanceKeysByTag(tag)
-		if err != nil {
-			return tagged, err
-		}
-		if i == 0 {
-			tagged = taggedByTag
-		} else {
-			tagged = tagged.Intersect(taggedByTag)
-		}
-	}
-	return tagged, nil
+	return GetInstanceKeysByTagQuery(query)
 }
@@ -0,0 +1,228 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package inst
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	tagQueryAnd    = ','
+	tagQueryOr     = '|'
+	tagQueryEquals = '='
+	tagQueryEscape = '\\'
+
+	tagQueryWhitespace = " \t\r\n"
+)
+
+// TagQuery selects instances by their tags: those matching all the tags of any of its terms.
+// In its string form, tags of a term are separated by "," (and), and terms by "|" (or), e.g.
+// "role=backup,~dc=ny|role=delayed". A tag prefixed by "!" or "~" is negated. A backslash escapes
+// the character following it, so that names and values may contain any of the above.
+type TagQuery struct {
+	Terms [][]*Tag
+}
+
+// NewTagQuery returns an empty query, to be populated via Exists, Equals, NotExists, NotEquals and Or
+func NewTagQuery() *TagQuery {
+	return &TagQuery{Terms: [][]*Tag{{}}}
+}
+
+func (this *TagQuery) and(tag *Tag) *TagQuery {
+	last := len(this.Terms) - 1
+	this.Terms[last] = append(this.Terms[last], tag)
+	return this
+}
+
+// Exists requires, within the current term, that an instance is tagged by given name
+func (this *TagQuery) Exists(name string) *TagQuery {
+	return this.and(&Tag{TagName: name})
+}
+
+// Equals requires, within the current term, that an instance is tagged by given name and value
+func (this *TagQuery) Equals(name string, value string) *TagQuery {
+	return this.and(&Tag{TagName: name, TagValue: value, HasValue: true})
+}
+
+// NotExists requires, within the current term, that an instance is not tagged by given name
+func (this *TagQuery) NotExists(name string) *TagQuery {
+	return this.and(&Tag{TagName: name, Negate: true})
+}
+
+// NotEquals requires, within the current term, that an instance is tagged by given name, with a different value
+func (this *TagQuery) NotEquals(name string, value string) *TagQuery {
+	return this.and(&Tag{TagName: name, TagValue: value, HasValue: true, Negate: true})
+}
+
+// Or starts a new term. Instances matching either term are selected.
+func (this *TagQuery) Or() *TagQuery {
+	this.Terms = append(this.Terms, []*Tag{})
+	return this
+}
+
+// escapeTagQueryToken escapes the special characters of a tag name or value
+func escapeTagQueryToken(token string, isName bool) string {
+	var escaped strings.Builder
+	for i, c := range token {
+		switch {
+		case c == tagQueryEscape, c == tagQueryAnd, c == tagQueryOr, c == tagQueryEquals:
+			escaped.WriteRune(tagQueryEscape)
+		case isName && i == 0 && (c == '!' || c == '~'):
+			escaped.WriteRune(tagQueryEscape)
+		case strings.ContainsRune(tagQueryWhitespace, c) && (i == 0 || i+utf8.RuneLen(c) == len(token)):
+			// surrounding whitespace is otherwise trimmed
+			escaped.WriteRune(tagQueryEscape)
+		}
+		escaped.WriteRune(c)
+	}
+	return escaped.String()
+}
+
+// String returns the query in the form parsed by ParseTagQuery
+func (this *TagQuery) String() string {
+	terms := []string{}
+	for _, term := range this.Terms {
+		tags := []string{}
+		for _, tag := range term {
+			tagString := escapeTagQueryToken(tag.TagName, true)
+			if tag.Negate {
+				tagString = "~" + tagString
+			}
+			if tag.HasValue {
+				tagString = tagString + string(tagQueryEquals) + escapeTagQueryToken(tag.TagValue, false)
+			}
+			tags = append(tags, tagString)
+		}
+		terms = append(terms, strings.Join(tags, string(tagQueryAnd)))
+	}
+	return strings.Join(terms, string(tagQueryOr))
+}
+
+// splitUnescaped splits s by separator where not escaped. Escape sequences are kept as they are.
+func splitUnescaped(s string, separator rune) []string {
+	tokens := []string{}
+	var token strings.Builder
+	escaped := false
+	for _, c := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case c == tagQueryEscape:
+			escaped = true
+		case c == separator:
+			tokens = append(tokens, token.String())
+			token.Reset()
+			continue
+		}
+		token.WriteRune(c)
+	}
+	return append(tokens, token.String())
+}
+
+// unescapeTagQueryToken removes escape characters
+func unescapeTagQueryToken(token string) string {
+	var unescaped strings.Builder
+	escaped := false
+	for _, c := range token {
+		if c == tagQueryEscape && !escaped {
+			escaped = true
+			continue
+		}
+		escaped = false
+		unescaped.WriteRune(c)
+	}
+	return unescaped.String()
+}
+
+// trimUnescaped trims surrounding whitespace, keeping escaped whitespace
+func trimUnescaped(token string) string {
+	token = strings.TrimLeft(token, tagQueryWhitespace)
+	trimmed := strings.TrimRight(token, tagQueryWhitespace)
+	if len(trimmed) < len(token) {
+		countEscapes := len(trimmed) - len(strings.TrimRight(trimmed, string(tagQueryEscape)))
+		if countEscapes%2 == 1 {
+			// the last trimmed character is escaped
+			trimmed = token[:len(trimmed)+1]
+		}
+	}
+	return trimmed
+}
+
+// parseTagQueryTag parses a single, possibly escaped, tag of a query
+func parseTagQueryTag(tagString string) (*Tag, error) {
+	tagString = trimUnescaped(tagString)
+	tag := &Tag{}
+	if strings.HasPrefix(tagString, "!") || strings.HasPrefix(tagString, "~") {
+		tag.Negate = true
+		tagString = tagString[1:]
+	}
+	tokens := splitUnescaped(tagString, tagQueryEquals)
+	tag.TagName = unescapeTagQueryToken(tokens[0])
+	if len(tokens) > 1 {
+		tag.HasValue = true
+		// only the first unescaped "=" separates name from value
+		tag.TagValue = unescapeTagQueryToken(strings.Join(tokens[1:], string(tagQueryEquals)))
+	}
+	if tag.TagName == "" {
+		return nil, fmt.Errorf("Unable to parse tag: %s", tagString)
+	}
+	return tag, nil
+}
+
+// ParseTagQuery parses a query as returned by TagQuery.String(). Comma separated tags, as parsed by
+// ParseIntersectTags, are a query of a single term.
+func ParseTagQuery(queryString string) (*TagQuery, error) {
+	query := &TagQuery{Terms: [][]*Tag{}}
+	for _, termString := range splitUnescaped(queryString, tagQueryOr) {
+		term := []*Tag{}
+		for _, tagString := range splitUnescaped(termString, tagQueryAnd) {
+			tag, err := parseTagQueryTag(tagString)
+			if err != nil {
+				return nil, err
+			}
+			term = append(term, tag)
+		}
+		query.Terms = append(query.Terms, term)
+	}
+	return query, nil
+}
+
+// GetInstanceKeysByTagQuery returns the keys of instances matching given query
+func GetInstanceKeysByTagQuery(query *TagQuery) (tagged *InstanceKeyMap, err error) {
+	tagged = NewInstanceKeyMap()
+	for _, term := range query.Terms {
+		if len(term) == 0 {
+			return tagged, fmt.Errorf("GetInstanceKeysByTagQuery: empty term in %s", query.String())
+		}
+		var taggedByTerm *InstanceKeyMap
+		for i, tag := range term {
+			taggedByTag, err := GetInstanceKeysByTag(tag)
+			if err != nil {
+				return tagged, err
+			}
+			if i == 0 {
+				taggedByTerm = taggedByTag
+			} else {
+				taggedByTerm = taggedByTerm.Intersect(taggedByTag)
+			}
+		}
+		tagged.AddKeys(taggedByTerm.GetInstanceKeys())
+	}
+	return tagged, nil
+}
@@ -138,3 +138,83 @@ func TestParseIntersectTags(t *testing.T) {
 		test.S(t).ExpectTrue(tags[1].HasValue)
 	}
 }
+
+func TestClusterFlags(t *testing.T) {
+	flags := &ClusterFlags{Flags: map[string]string{"automation_enabled": "false", "max_lag": "30", "owner": "dba", "broken": "maybe"}}
+	test.S(t).ExpectFalse(flags.Bool("automation_enabled", true))
+	test.S(t).ExpectTrue(flags.Bool("broken", true))
+	test.S(t).ExpectTrue(flags.Bool("no_such_flag", true))
+	test.S(t).ExpectEquals(flags.Int("max_lag", 10), int64(30))
+	test.S(t).ExpectEquals(flags.Int("owner", 10), int64(10))
+	test.S(t).ExpectEquals(flags.String("owner", ""), "dba")
+	test.S(t).ExpectEquals(flags.String("no_such_flag", "none"), "none")
+	{
+		tag, err := NewClusterFlagTag(" automation_enabled ", "true")
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(tag.String(), "flag:automation_enabled=true")
+	}
+	{
+		_, err := NewClusterFlagTag("a=b", "true")
+		test.S(t).ExpectNotNil(err)
+	}
+}
+
+func TestParseTagQuery(t *testing.T) {
+	{
+		_, err := ParseTagQuery("")
+		test.S(t).ExpectNotNil(err)
+	}
+	{
+		_, err := ParseTagQuery("role,")
+		test.S(t).ExpectNotNil(err)
+	}
+	{
+		_, err := ParseTagQuery("role|")
+		test.S(t).ExpectNotNil(err)
+	}
+	{
+		query, err := ParseTagQuery("role=backup, !dc=ny")
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(len(query.Terms), 1)
+		test.S(t).ExpectEquals(len(query.Terms[0]), 2)
+		test.S(t).ExpectEquals(*query.Terms[0][0], Tag{TagName: "role", TagValue: "backup", HasValue: true})
+		test.S(t).ExpectEquals(*query.Terms[0][1], Tag{TagName: "dc", TagValue: "ny", HasValue: true, Negate: true})
+	}
+	{
+		query, err := ParseTagQuery("role=backup|~dc|role=a!b=c")
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(len(query.Terms), 3)
+		test.S(t).ExpectEquals(*query.Terms[1][0], Tag{TagName: "dc", Negate: true})
+		test.S(t).ExpectEquals(*query.Terms[2][0], Tag{TagName: "role", TagValue: "a!b=c", HasValue: true})
+	}
+	{
+		query, err := ParseTagQuery(`role=a\,b\|c\\,\~name`)
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(len(query.Terms), 1)
+		test.S(t).ExpectEquals(*query.Terms[0][0], Tag{TagName: "role", TagValue: `a,b|c\`, HasValue: true})
+		test.S(t).ExpectEquals(*query.Terms[0][1], Tag{TagName: "~name"})
+	}
+}
+
+func TestTagQueryRoundTrip(t *testing.T) {
+	queries := []*TagQuery{
+		NewTagQuery().Exists("role"),
+		NewTagQuery().Equals("role", "backup").NotExists("dc").Or().NotEquals("role", ""),
+		NewTagQuery().Equals("role", "a,b|c=d\\e").Or().Exists("!name=x"),
+		NewTagQuery().Equals(" spaced name ", " spaced value\t"),
+		NewTagQuery().NotEquals("~role", "!value").Equals("ünïcode ", " vålue"),
+	}
+	for _, query := range queries {
+		parsed, err := ParseTagQuery(query.String())
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(len(parsed.Terms), len(query.Terms))
+		for i := range query.Terms {
+			test.S(t).ExpectEquals(len(parsed.Terms[i]), len(query.Terms[i]))
+			for j := range query.Terms[i] {
+				test.S(t).ExpectEquals(*parsed.Terms[i][j], *query.Terms[i][j])
+			}
+		}
+		test.S(t).ExpectEquals(parsed.String(), query.String())
+	}
+	test.S(t).ExpectEquals(NewTagQuery().Equals("role", "backup").NotExists("dc").Or().Exists("delayed").String(), "role=backup,~dc|delayed")
+}
@@ -85,10 +85,10 @@ func (this *consulStore) GetKeyValue(key string) (value string, found bool, err
 		return value, found, nil
 	}
 	pair, _, err := this.client.KV().Get(key, nil)
-	if err != nil {
+	if err != nil || pair == nil {
 		return value, found, err
 	}
-	return string(pair.Value), (pair != nil), nil
+	return string(pair.Value), true, nil
 }
 
 func (this *consulStore) PutKVPairs(kvPairs []*KVPair) (err error) {
@@ -219,10 +219,10 @@ func (this *consulTxnStore) GetKeyValue(key string) (value string, found bool, e
 		return value, found, nil
 	}
 	pair, _, err := this.client.KV().Get(key, nil)
-	if err != nil {
+	if err != nil || pair == nil {
 		return value, found, err
 	}
-	return string(pair.Value), (pair != nil), nil
+	return string(pair.Value), true, nil
 }
 
 // PutKeyValue performs a Consul KV put operation for a key/value
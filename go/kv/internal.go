@@ -62,6 +62,42 @@ func (this *internalKVStore) GetKeyValue(key string) (value string, found bool,
 	return value, found, log.Errore(err)
 }
 
+// CompareAndPutKeyValue writes given value only if the key still holds previousValue (or, when previousFound
+// is false, does not exist yet). It returns false when the key changed meanwhile, and nothing is written.
+func (this *internalKVStore) CompareAndPutKeyValue(key string, previousValue string, previousFound bool, value string) (swapped bool, err error) {
+	var query string
+	var args []interface{}
+	if previousFound {
+		query = `
+			update
+				kv_store
+			set
+				store_value = ?,
+				last_updated = now()
+			where
+				store_key = ?
+				and store_value = ?
+			`
+		args = sqlutils.Args(value, key, previousValue)
+	} else {
+		query = `
+			insert ignore
+				into kv_store (
+					store_key, store_value, last_updated
+				) values (
+					?, ?, now()
+				)
+			`
+		args = sqlutils.Args(key, value)
+	}
+	res, err := db.ExecOrchestrator(query, args...)
+	if err != nil {
+		return false, log.Errore(err)
+	}
+	affected, err := res.RowsAffected()
+	return affected > 0, log.Errore(err)
+}
+
 func (this *internalKVStore) PutKVPairs(kvPairs []*KVPair) (err error) {
 	for _, pair := range kvPairs {
 		if err := this.PutKeyValue(pair.Key, pair.Value); err != nil {
@@ -83,6 +83,49 @@ func GetValue(key string) (value string, found bool, err error) {
 	return value, found, err
 }
 
+// StoreValue is the value of a key as read from a single KV store
+type StoreValue struct {
+	Store string
+	Value string
+	Found bool
+	Error string
+}
+
+// describeStore returns the name of a store, and whether it is configured for use
+func describeStore(store KVStore) (name string, configured bool) {
+	switch store := store.(type) {
+	case *internalKVStore:
+		return "internal", true
+	case *zkStore:
+		return "zk", store.zook != nil
+	case *consulStore:
+		return "consul", store.client != nil
+	case *consulTxnStore:
+		return "consul", store.client != nil
+	}
+	return fmt.Sprintf("%T", store), true
+}
+
+// GetStoreValues reads given key from each of the configured KV stores, as opposed to GetValue, which
+// only reads the internal store. It allows verifying that all stores agree on a value.
+func GetStoreValues(key string) (values []StoreValue) {
+	for _, store := range getKVStores() {
+		name, configured := describeStore(store)
+		if !configured {
+			continue
+		}
+		storeValue := StoreValue{Store: name}
+		value, found, err := store.GetKeyValue(key)
+		if err != nil {
+			storeValue.Error = err.Error()
+		} else {
+			storeValue.Value, storeValue.Found = value, found
+		}
+		values = append(values, storeValue)
+	}
+	return values
+}
+
 func PutValue(key string, value string) (err error) {
 	for _, store := range getKVStores() {
 		if err := store.PutKeyValue(key, value); err != nil {
@@ -92,6 +135,26 @@ func PutValue(key string, value string) (err error) {
 	return nil
 }
 
+// CompareAndPutValue writes given value to the internal store only if the key still holds previousValue (or,
+// when previousFound is false, does not exist yet), as read via GetValue; and, once written, to all other
+// stores. It returns false when the key changed meanwhile, in which case nothing is written. Only the internal
+// store is compared, being the one GetValue reads.
+func CompareAndPutValue(key string, previousValue string, previousFound bool, value string) (swapped bool, err error) {
+	for _, store := range getKVStores() {
+		if internalStore, ok := store.(*internalKVStore); ok {
+			swapped, err = internalStore.CompareAndPutKeyValue(key, previousValue, previousFound, value)
+			if err != nil || !swapped {
+				return swapped, err
+			}
+			continue
+		}
+		if err := store.PutKeyValue(key, value); err != nil {
+			return swapped, err
+		}
+	}
+	return swapped, nil
+}
+
 func PutKVPairs(kvPairs []*KVPair) (err error) {
 	if len(kvPairs) < 1 {
 		return nil
@@ -0,0 +1,56 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openark/orchestrator/go/inst"
+)
+
+// MinBackendHistoryPurgeSeconds is the minimal age of history rows purged via PurgeBackendHistory
+const MinBackendHistoryPurgeSeconds = 24 * 60 * 60
+
+// backendHistoryTables lists the history tables purged by PurgeBackendHistory, along with their timestamp columns.
+// These are otherwise purged periodically, as per AuditPurgeDays.
+var backendHistoryTables = [][2]string{
+	{"audit", "audit_timestamp"},
+	{"topology_failure_detection", "start_active_period"},
+	{"topology_recovery", "start_active_period"},
+	{"topology_recovery_steps", "audit_at"},
+}
+
+// PurgeBackendHistory removes audit, failure detection and recovery rows older than given seconds, ahead of
+// the periodic purge, and returns the number of rows removed per table.
+func PurgeBackendHistory(olderThanSeconds int) (map[string]int64, error) {
+	if olderThanSeconds < MinBackendHistoryPurgeSeconds {
+		return nil, fmt.Errorf("PurgeBackendHistory: refusing to purge history younger than %d seconds; got %d", MinBackendHistoryPurgeSeconds, olderThanSeconds)
+	}
+	purged := make(map[string]int64)
+	summary := []string{}
+	for _, table := range backendHistoryTables {
+		rowsAffected, err := inst.PurgeTableData(table[0], table[1], olderThanSeconds)
+		if err != nil {
+			return purged, err
+		}
+		purged[table[0]] = rowsAffected
+		summary = append(summary, fmt.Sprintf("%s: %d", table[0], rowsAffected))
+	}
+	inst.AuditOperation("purge-backend-history", nil, fmt.Sprintf("older than %ds; %s", olderThanSeconds, strings.Join(summary, ", ")))
+	return purged, nil
+}
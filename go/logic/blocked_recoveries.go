@@ -0,0 +1,110 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	goos "os"
+	"time"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/inst"
+	"github.com/openark/orchestrator/go/os"
+	"github.com/patrickmn/go-cache"
+)
+
+// agedBlockedRecoveriesAlerted remembers blocked recoveries already alerted on, so that each blocked
+// episode (instance and first blocked time) is alerted on once
+var agedBlockedRecoveriesAlerted = cache.New(24*time.Hour, time.Hour)
+
+// AgedBlockedTopologyRecovery is a recovery blocked for longer than BlockedRecoveryAgeAlertSeconds,
+// along with the recovery blocking it
+type AgedBlockedTopologyRecovery struct {
+	BlockedTopologyRecovery
+	BlockingRecovery *TopologyRecovery
+}
+
+// ReadAgedBlockedRecoveries reads recoveries blocked for at least given number of seconds, along with their blocking recoveries
+func ReadAgedBlockedRecoveries(blockedSeconds int64) (agedRecoveries []AgedBlockedTopologyRecovery, err error) {
+	blockedRecoveries, err := ReadBlockedRecoveriesOlderThan(blockedSeconds)
+	if err != nil {
+		return agedRecoveries, err
+	}
+	for _, blockedRecovery := range blockedRecoveries {
+		agedRecovery := AgedBlockedTopologyRecovery{BlockedTopologyRecovery: blockedRecovery}
+		if recoveries, err := ReadRecovery(blockedRecovery.BlockingRecoveryId); err == nil && len(recoveries) > 0 {
+			agedRecovery.BlockingRecovery = recoveries[0]
+		}
+		agedRecoveries = append(agedRecoveries, agedRecovery)
+	}
+	return agedRecoveries, nil
+}
+
+func agedBlockedRecoveryEnvironment(agedRecovery *AgedBlockedTopologyRecovery) []string {
+	env := goos.Environ()
+	env = append(env, fmt.Sprintf("ORC_BLOCKED_HOST=%s", agedRecovery.FailedInstanceKey.Hostname))
+	env = append(env, fmt.Sprintf("ORC_BLOCKED_PORT=%d", agedRecovery.FailedInstanceKey.Port))
+	env = append(env, fmt.Sprintf("ORC_BLOCKED_CLUSTER=%s", agedRecovery.ClusterName))
+	env = append(env, fmt.Sprintf("ORC_BLOCKED_ANALYSIS=%s", string(agedRecovery.Analysis)))
+	env = append(env, fmt.Sprintf("ORC_BLOCKED_SINCE=%s", agedRecovery.FirstBlockedTimestamp))
+	env = append(env, fmt.Sprintf("ORC_BLOCKED_SECONDS=%d", agedRecovery.BlockedSeconds))
+	env = append(env, fmt.Sprintf("ORC_BLOCKING_RECOVERY_ID=%d", agedRecovery.BlockingRecoveryId))
+	if blockingRecovery := agedRecovery.BlockingRecovery; blockingRecovery != nil {
+		env = append(env, fmt.Sprintf("ORC_BLOCKING_RECOVERY_UID=%s", blockingRecovery.UID))
+		env = append(env, fmt.Sprintf("ORC_BLOCKING_RECOVERY_START=%s", blockingRecovery.RecoveryStartTimestamp))
+		env = append(env, fmt.Sprintf("ORC_BLOCKING_FAILURE_TYPE=%s", string(blockingRecovery.AnalysisEntry.Analysis)))
+		env = append(env, fmt.Sprintf("ORC_BLOCKING_FAILED_HOST=%s", blockingRecovery.AnalysisEntry.AnalyzedInstanceKey.Hostname))
+		env = append(env, fmt.Sprintf("ORC_BLOCKING_FAILED_PORT=%d", blockingRecovery.AnalysisEntry.AnalyzedInstanceKey.Port))
+		env = append(env, fmt.Sprintf("ORC_BLOCKING_IS_SUCCESSFUL=%t", blockingRecovery.IsSuccessful))
+	}
+	return env
+}
+
+// CheckAgedBlockedRecoveries runs BlockedRecoveryAgedProcesses for recoveries blocked for longer than
+// BlockedRecoveryAgeAlertSeconds. Each blocked episode is alerted on once.
+func CheckAgedBlockedRecoveries() error {
+	if config.Config.BlockedRecoveryAgeAlertSeconds == 0 {
+		return nil
+	}
+	agedRecoveries, err := ReadAgedBlockedRecoveries(int64(config.Config.BlockedRecoveryAgeAlertSeconds))
+	if err != nil {
+		return log.Errore(err)
+	}
+	for i := range agedRecoveries {
+		agedRecovery := &agedRecoveries[i]
+		alertKey := fmt.Sprintf("%s/%s", agedRecovery.FailedInstanceKey.StringCode(), agedRecovery.FirstBlockedTimestamp)
+		if err := agedBlockedRecoveriesAlerted.Add(alertKey, true, cache.DefaultExpiration); err != nil {
+			// already alerted on
+			continue
+		}
+
+		inst.AuditOperation("aged-blocked-recovery", &agedRecovery.FailedInstanceKey, fmt.Sprintf("%s recovery blocked for %ds by recovery %d", agedRecovery.Analysis, agedRecovery.BlockedSeconds, agedRecovery.BlockingRecoveryId))
+		env := agedBlockedRecoveryEnvironment(agedRecovery)
+		processCount := len(config.Config.BlockedRecoveryAgedProcesses)
+		for j, command := range config.Config.BlockedRecoveryAgedProcesses {
+			fullDescription := fmt.Sprintf("BlockedRecoveryAgedProcesses hook %d of %d", j+1, processCount)
+			start := time.Now()
+			if err := os.CommandRun(command, env); err == nil {
+				log.Infof("Aged blocked recovery %+v: completed %s in %v", agedRecovery.FailedInstanceKey, fullDescription, time.Since(start))
+			} else {
+				log.Errorf("Aged blocked recovery %+v: execution of %s failed in %v with error: %v", agedRecovery.FailedInstanceKey, fullDescription, time.Since(start), err)
+			}
+		}
+	}
+	return nil
+}
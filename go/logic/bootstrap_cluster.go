@@ -0,0 +1,244 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/orchestrator/go/inst"
+	orcraft "github.com/openark/orchestrator/go/raft"
+)
+
+// BootstrapClusterOptions are the optional settings applied when bootstrapping a new replication tree
+type BootstrapClusterOptions struct {
+	ClusterAlias  string
+	CandidateKeys []inst.InstanceKey
+	Pool          string
+}
+
+// BootstrapReplicaOutcomeType is what became of a replica while bootstrapping a replication tree
+type BootstrapReplicaOutcomeType string
+
+const (
+	BootstrapReplicaPointed            BootstrapReplicaOutcomeType = "pointed"
+	BootstrapReplicaAlreadyReplicating BootstrapReplicaOutcomeType = "already-replicating"
+	BootstrapReplicaFailed             BootstrapReplicaOutcomeType = "failed"
+	BootstrapReplicaNotAttempted       BootstrapReplicaOutcomeType = "not-attempted"
+)
+
+// BootstrapReplicaOutcome tells what became of a single replica while bootstrapping a replication tree
+type BootstrapReplicaOutcome struct {
+	Key     inst.InstanceKey
+	Outcome BootstrapReplicaOutcomeType
+	Error   string
+}
+
+// BootstrapClusterResult describes the outcome of bootstrapping a new replication tree. ReplicaKeys lists the
+// replicas set up; Replicas lists the outcome of each replica, such that a failure midway tells which replicas
+// were changed and which were not.
+type BootstrapClusterResult struct {
+	ClusterName   string
+	MasterKey     inst.InstanceKey
+	ReplicaKeys   []inst.InstanceKey
+	Replicas      []BootstrapReplicaOutcome
+	CandidateKeys []inst.InstanceKey
+	ClusterAlias  string
+	Pool          string
+}
+
+// setUpBootstrapReplicas sets up given replicas one by one, via given function, recording the outcome of each.
+// It stops at the first failure: the replicas following it are not attempted.
+func setUpBootstrapReplicas(result *BootstrapClusterResult, replicas [](*inst.Instance), setUp func(replica *inst.Instance) (alreadyReplicating bool, err error)) error {
+	var err error
+	for _, replica := range replicas {
+		outcome := BootstrapReplicaOutcome{Key: replica.Key, Outcome: BootstrapReplicaNotAttempted}
+		if err == nil {
+			alreadyReplicating, setUpErr := setUp(replica)
+			switch {
+			case setUpErr != nil:
+				err = fmt.Errorf("%+v: %+v", replica.Key, setUpErr)
+				outcome.Outcome = BootstrapReplicaFailed
+				outcome.Error = setUpErr.Error()
+			case alreadyReplicating:
+				outcome.Outcome = BootstrapReplicaAlreadyReplicating
+			default:
+				outcome.Outcome = BootstrapReplicaPointed
+			}
+			if setUpErr == nil {
+				result.ReplicaKeys = append(result.ReplicaKeys, replica.Key)
+			}
+		}
+		result.Replicas = append(result.Replicas, outcome)
+	}
+	return err
+}
+
+// verifyBootstrapReplica checks that given replica can safely be pointed at given master as part of
+// a new replication tree
+func verifyBootstrapReplica(master *inst.Instance, replica *inst.Instance, serverIds map[uint]inst.InstanceKey) error {
+	if replica.Key.Equals(&master.Key) {
+		return fmt.Errorf("%+v is listed both as master and as replica", replica.Key)
+	}
+	if otherKey, found := serverIds[replica.ServerID]; found {
+		return fmt.Errorf("%+v has same server_id %d as %+v", replica.Key, replica.ServerID, otherKey)
+	}
+	serverIds[replica.ServerID] = replica.Key
+	if replica.ServerUUID != "" && replica.ServerUUID == master.ServerUUID {
+		return fmt.Errorf("%+v has same server_uuid as master %+v", replica.Key, master.Key)
+	}
+	if len(replica.Replicas) > 0 {
+		return fmt.Errorf("%+v has replicas of its own", replica.Key)
+	}
+	if replica.IsReplica() && !replica.MasterKey.Equals(&master.Key) {
+		return fmt.Errorf("%+v already replicates from %+v", replica.Key, replica.MasterKey)
+	}
+	if canReplicate, err := replica.CanReplicateFrom(master); !canReplicate {
+		return fmt.Errorf("%+v cannot replicate from %+v: %+v", replica.Key, master.Key, err)
+	}
+	if master.SupportsOracleGTID && replica.ExecutedGtidSet != "" {
+		// The replica must not have executed anything the master does not have
+		errantGtid, err := inst.GTIDSubtract(&master.Key, replica.ExecutedGtidSet, master.ExecutedGtidSet)
+		if err != nil {
+			return err
+		}
+		if errantGtid != "" {
+			return fmt.Errorf("%+v has transactions unknown to master %+v: %s", replica.Key, master.Key, errantGtid)
+		}
+	}
+	return nil
+}
+
+// BootstrapCluster sets up a brand new replication tree: it discovers given master and replicas, verifies
+// they are in a consistent state, points the replicas at the master, and then registers promotion candidates,
+// cluster alias and pool as requested.
+// All verifications take place before any replication change is made.
+// With GTID the replicas auto-position; otherwise they start replicating from the master's current binary log
+// coordinates, which implies they must already hold the master's data (or that all servers are empty).
+func BootstrapCluster(masterKey inst.InstanceKey, replicaKeys []inst.InstanceKey, options BootstrapClusterOptions) (result *BootstrapClusterResult, err error) {
+	if len(replicaKeys) == 0 {
+		return nil, fmt.Errorf("BootstrapCluster: no replicas given")
+	}
+	master, err := inst.ReadTopologyInstance(&masterKey)
+	if err != nil {
+		return nil, log.Errore(err)
+	}
+	if master.IsReplica() {
+		return nil, fmt.Errorf("BootstrapCluster: %+v is itself a replica of %+v", master.Key, master.MasterKey)
+	}
+	if !master.LogBinEnabled {
+		return nil, fmt.Errorf("BootstrapCluster: %+v does not have binary logs enabled", master.Key)
+	}
+	var gtidHint inst.OperationGTIDHint = inst.GTIDHintNeutral
+	if master.SupportsOracleGTID || master.IsMariaDB() {
+		gtidHint = inst.GTIDHintForce
+	}
+
+	serverIds := map[uint]inst.InstanceKey{master.ServerID: master.Key}
+	replicas := [](*inst.Instance){}
+	for _, replicaKey := range replicaKeys {
+		replicaKey := replicaKey
+		replica, err := inst.ReadTopologyInstance(&replicaKey)
+		if err != nil {
+			return nil, log.Errore(err)
+		}
+		if err := verifyBootstrapReplica(master, replica, serverIds); err != nil {
+			return nil, log.Errore(fmt.Errorf("BootstrapCluster: %+v", err))
+		}
+		replicas = append(replicas, replica)
+	}
+	replicaKeysMap := inst.NewInstanceKeyMap()
+	replicaKeysMap.AddInstances(replicas)
+	for _, candidateKey := range options.CandidateKeys {
+		if !replicaKeysMap.HasKey(candidateKey) {
+			return nil, fmt.Errorf("BootstrapCluster: candidate %+v is not one of the replicas", candidateKey)
+		}
+	}
+
+	result = &BootstrapClusterResult{MasterKey: master.Key}
+	err = setUpBootstrapReplicas(result, replicas, func(replica *inst.Instance) (alreadyReplicating bool, err error) {
+		if replica.IsReplicaOf(master) {
+			log.Infof("BootstrapCluster: %+v already replicates from %+v", replica.Key, master.Key)
+			alreadyReplicating = true
+		} else {
+			if replica.ReplicationThreadsExist() {
+				if _, err := inst.StopReplication(&replica.Key); err != nil {
+					return false, err
+				}
+			}
+			if _, err := inst.ChangeMasterTo(&replica.Key, &master.Key, &master.SelfBinlogCoordinates, false, gtidHint); err != nil {
+				return false, err
+			}
+		}
+		_, err = inst.StartReplication(&replica.Key)
+		return alreadyReplicating, err
+	})
+	if err != nil {
+		return result, log.Errore(fmt.Errorf("BootstrapCluster: %+v; %d of %d replicas set up", err, len(result.ReplicaKeys), len(replicas)))
+	}
+
+	master, err = inst.ReadTopologyInstance(&masterKey)
+	if err != nil {
+		return result, log.Errore(err)
+	}
+	result.ClusterName = master.ClusterName
+
+	for _, candidateKey := range options.CandidateKeys {
+		candidateKey := candidateKey
+		candidate := inst.NewCandidateDatabaseInstance(&candidateKey, inst.PreferPromoteRule).WithCurrentTime()
+		if orcraft.IsRaftEnabled() {
+			_, err = orcraft.PublishCommand("register-candidate", candidate)
+		} else {
+			err = inst.RegisterCandidateInstance(candidate)
+		}
+		if err != nil {
+			return result, log.Errore(err)
+		}
+		result.CandidateKeys = append(result.CandidateKeys, candidateKey)
+	}
+	if options.ClusterAlias != "" {
+		if orcraft.IsRaftEnabled() {
+			_, err = orcraft.PublishCommand("set-cluster-alias-manual-override", []string{result.ClusterName, options.ClusterAlias})
+		} else {
+			err = inst.SetClusterAliasManualOverride(result.ClusterName, options.ClusterAlias)
+		}
+		if err != nil {
+			return result, log.Errore(err)
+		}
+		result.ClusterAlias = options.ClusterAlias
+	}
+	if options.Pool != "" {
+		poolKeys := []string{}
+		for _, replicaKey := range result.ReplicaKeys {
+			poolKeys = append(poolKeys, replicaKey.StringCode())
+		}
+		submission := inst.NewPoolInstancesSubmission(options.Pool, strings.Join(poolKeys, ","))
+		if orcraft.IsRaftEnabled() {
+			_, err = orcraft.PublishCommand("submit-pool-instances", submission)
+		} else {
+			err = inst.ApplyPoolInstances(submission)
+		}
+		if err != nil {
+			return result, log.Errore(err)
+		}
+		result.Pool = options.Pool
+	}
+
+	inst.AuditOperation("bootstrap-cluster", &master.Key, fmt.Sprintf("Bootstrapped cluster %s with %d replicas", result.ClusterName, len(result.ReplicaKeys)))
+	return result, nil
+}
@@ -0,0 +1,150 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"testing"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+func newBootstrapTestInstance(hostname string, serverID uint) *inst.Instance {
+	instance := inst.NewInstance()
+	instance.Key = inst.InstanceKey{Hostname: hostname, Port: 3306}
+	instance.ServerID = serverID
+	instance.ServerUUID = fmt.Sprintf("uuid-%d", serverID)
+	instance.Version = "8.0.32"
+	instance.LogBinEnabled = true
+	return instance
+}
+
+func TestVerifyBootstrapReplica(t *testing.T) {
+	master := newBootstrapTestInstance("master", 1)
+	otherKey := inst.InstanceKey{Hostname: "other", Port: 3306}
+
+	tests := []struct {
+		name    string
+		modify  func(replica *inst.Instance)
+		isValid bool
+	}{
+		{
+			name:    "clean replica",
+			modify:  func(replica *inst.Instance) {},
+			isValid: true,
+		},
+		{
+			name: "already replicating from master",
+			modify: func(replica *inst.Instance) {
+				replica.MasterKey = master.Key
+				replica.ReadBinlogCoordinates = inst.BinlogCoordinates{LogFile: "mysql-bin.000001", LogPos: 4}
+			},
+			isValid: true,
+		},
+		{
+			name:   "master itself",
+			modify: func(replica *inst.Instance) { replica.Key = master.Key },
+		},
+		{
+			name:   "same server_id as master",
+			modify: func(replica *inst.Instance) { replica.ServerID = master.ServerID },
+		},
+		{
+			name:   "same server_uuid as master",
+			modify: func(replica *inst.Instance) { replica.ServerUUID = master.ServerUUID },
+		},
+		{
+			name:   "has replicas",
+			modify: func(replica *inst.Instance) { replica.Replicas[otherKey] = true },
+		},
+		{
+			name: "replicates from another master",
+			modify: func(replica *inst.Instance) {
+				replica.MasterKey = otherKey
+				replica.ReadBinlogCoordinates = inst.BinlogCoordinates{LogFile: "mysql-bin.000001", LogPos: 4}
+			},
+		},
+		{
+			name:   "lower version than master",
+			modify: func(replica *inst.Instance) { replica.Version = "5.7.40" },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replica := newBootstrapTestInstance("replica", 2)
+			tt.modify(replica)
+			serverIds := map[uint]inst.InstanceKey{master.ServerID: master.Key}
+			err := verifyBootstrapReplica(master, replica, serverIds)
+			test.S(t).ExpectEquals(err == nil, tt.isValid)
+		})
+	}
+}
+
+func TestVerifyBootstrapReplicaDuplicateServerIds(t *testing.T) {
+	master := newBootstrapTestInstance("master", 1)
+	serverIds := map[uint]inst.InstanceKey{master.ServerID: master.Key}
+
+	test.S(t).ExpectNil(verifyBootstrapReplica(master, newBootstrapTestInstance("replica1", 2), serverIds))
+	test.S(t).ExpectEquals(serverIds[2], inst.InstanceKey{Hostname: "replica1", Port: 3306})
+	test.S(t).ExpectNil(verifyBootstrapReplica(master, newBootstrapTestInstance("replica2", 3), serverIds))
+	test.S(t).ExpectNotNil(verifyBootstrapReplica(master, newBootstrapTestInstance("replica3", 2), serverIds))
+}
+
+func TestSetUpBootstrapReplicas(t *testing.T) {
+	replicas := [](*inst.Instance){
+		newBootstrapTestInstance("replica1", 2),
+		newBootstrapTestInstance("replica2", 3),
+		newBootstrapTestInstance("replica3", 4),
+		newBootstrapTestInstance("replica4", 5),
+	}
+	t.Run("all set up", func(t *testing.T) {
+		result := &BootstrapClusterResult{}
+		err := setUpBootstrapReplicas(result, replicas, func(replica *inst.Instance) (bool, error) {
+			return replica.Key.Hostname == "replica2", nil
+		})
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(len(result.ReplicaKeys), 4)
+		test.S(t).ExpectEquals(len(result.Replicas), 4)
+		test.S(t).ExpectEquals(result.Replicas[0].Outcome, BootstrapReplicaPointed)
+		test.S(t).ExpectEquals(result.Replicas[1].Outcome, BootstrapReplicaAlreadyReplicating)
+		test.S(t).ExpectEquals(result.Replicas[2].Outcome, BootstrapReplicaPointed)
+		test.S(t).ExpectEquals(result.Replicas[3].Outcome, BootstrapReplicaPointed)
+	})
+	t.Run("failure midway", func(t *testing.T) {
+		attempted := []string{}
+		result := &BootstrapClusterResult{}
+		err := setUpBootstrapReplicas(result, replicas, func(replica *inst.Instance) (bool, error) {
+			attempted = append(attempted, replica.Key.Hostname)
+			if replica.Key.Hostname == "replica3" {
+				return false, fmt.Errorf("change master failed")
+			}
+			return false, nil
+		})
+		test.S(t).ExpectNotNil(err)
+		test.S(t).ExpectEquals(len(attempted), 3)
+		test.S(t).ExpectEquals(len(result.ReplicaKeys), 2)
+		test.S(t).ExpectEquals(result.ReplicaKeys[1], replicas[1].Key)
+		test.S(t).ExpectEquals(len(result.Replicas), 4)
+		test.S(t).ExpectEquals(result.Replicas[0].Outcome, BootstrapReplicaPointed)
+		test.S(t).ExpectEquals(result.Replicas[1].Outcome, BootstrapReplicaPointed)
+		test.S(t).ExpectEquals(result.Replicas[2].Outcome, BootstrapReplicaFailed)
+		test.S(t).ExpectEquals(result.Replicas[2].Error, "change master failed")
+		test.S(t).ExpectEquals(result.Replicas[3].Key, replicas[3].Key)
+		test.S(t).ExpectEquals(result.Replicas[3].Outcome, BootstrapReplicaNotAttempted)
+	})
+}
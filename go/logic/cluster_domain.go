@@ -0,0 +1,152 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/orchestrator/go/inst"
+	orcraft "github.com/openark/orchestrator/go/raft"
+)
+
+// ClusterDomainReconciliation is the outcome of reconciling cluster domain names with a source of truth
+type ClusterDomainReconciliation struct {
+	Registered      []string // clusters which had no domain name, and now have one
+	Changed         []string // clusters whose domain name changed
+	Renewed         []string // clusters whose domain name is unchanged; the registration is renewed
+	Forgotten       []string // clusters whose domain name was removed, as they are absent from the source of truth
+	UnknownClusters []string // clusters in the source of truth which are unknown to orchestrator; skipped
+}
+
+// SetClusterDomain registers the domain name (e.g. a VIP or CNAME) of the master of given cluster. As with
+// domain names detected via DetectClusterDomainQuery, the registration expires after
+// ExpiryHostnameResolvesMinutes unless registered again.
+func SetClusterDomain(clusterName string, domainName string) error {
+	domainName = strings.TrimSpace(domainName)
+	if clusterName == "" {
+		return fmt.Errorf("SetClusterDomain: empty cluster name")
+	}
+	if domainName == "" {
+		return fmt.Errorf("SetClusterDomain: empty domain name for cluster %s", clusterName)
+	}
+	var err error
+	if orcraft.IsRaftEnabled() {
+		_, err = orcraft.PublishCommand("set-cluster-domain", []string{clusterName, domainName})
+	} else {
+		err = inst.WriteClusterDomainName(clusterName, domainName)
+	}
+	if err != nil {
+		return err
+	}
+	return inst.AuditOperation("set-cluster-domain", nil, fmt.Sprintf("cluster: %s, domain: %s", clusterName, domainName))
+}
+
+// ForgetClusterDomain removes the domain name registration of given cluster
+func ForgetClusterDomain(clusterName string) error {
+	var err error
+	if orcraft.IsRaftEnabled() {
+		_, err = orcraft.PublishCommand("forget-cluster-domain", clusterName)
+	} else {
+		err = inst.DeleteClusterDomainName(clusterName)
+	}
+	if err != nil {
+		return err
+	}
+	return inst.AuditOperation("forget-cluster-domain", nil, fmt.Sprintf("cluster: %s", clusterName))
+}
+
+// ReconcileClusterDomains keeps cluster domain names in sync with given source of truth, mapping cluster names
+// to domain names. Every known cluster in the source of truth is registered, which renews registrations which
+// did not change; a cluster mapped to an empty domain name has its domain name removed. With prune, registered domain names of clusters absent from the source of truth are removed.
+// Run periodically, e.g. from a cron job, more often than ExpiryHostnameResolvesMinutes.
+func ReconcileClusterDomains(domainNames map[string]string, prune bool) (*ClusterDomainReconciliation, error) {
+	knownClusters, err := inst.ReadClusters()
+	if err != nil {
+		return nil, err
+	}
+	registered, err := inst.ReadClusterDomainNames()
+	if err != nil {
+		return nil, err
+	}
+	reconciliation := reconcileClusterDomains(domainNames, knownClusters, registered, prune)
+	for _, clusterNames := range [][]string{reconciliation.Registered, reconciliation.Changed, reconciliation.Renewed} {
+		for _, clusterName := range clusterNames {
+			if err := SetClusterDomain(clusterName, domainNames[clusterName]); err != nil {
+				return reconciliation, err
+			}
+		}
+	}
+	for _, clusterName := range reconciliation.Forgotten {
+		if err := ForgetClusterDomain(clusterName); err != nil {
+			return reconciliation, err
+		}
+	}
+	log.Infof("ReconcileClusterDomains: registered: %d, changed: %d, renewed: %d, forgotten: %d, unknown clusters: %d",
+		len(reconciliation.Registered), len(reconciliation.Changed), len(reconciliation.Renewed), len(reconciliation.Forgotten), len(reconciliation.UnknownClusters))
+	return reconciliation, nil
+}
+
+// reconcileClusterDomains compares given source of truth with the registered domain names
+func reconcileClusterDomains(domainNames map[string]string, knownClusters []string, registered []inst.ClusterDomainName, prune bool) *ClusterDomainReconciliation {
+	reconciliation := &ClusterDomainReconciliation{
+		Registered:      []string{},
+		Changed:         []string{},
+		Renewed:         []string{},
+		Forgotten:       []string{},
+		UnknownClusters: []string{},
+	}
+	known := make(map[string]bool)
+	for _, clusterName := range knownClusters {
+		known[clusterName] = true
+	}
+	current := make(map[string]string)
+	for _, domainName := range registered {
+		current[domainName.ClusterName] = domainName.DomainName
+	}
+	for clusterName, domainName := range domainNames {
+		currentDomainName, found := current[clusterName]
+		switch {
+		case !known[clusterName]:
+			reconciliation.UnknownClusters = append(reconciliation.UnknownClusters, clusterName)
+		case strings.TrimSpace(domainName) == "":
+			// The source of truth has no domain name for this cluster
+			if found {
+				reconciliation.Forgotten = append(reconciliation.Forgotten, clusterName)
+			}
+		case !found:
+			reconciliation.Registered = append(reconciliation.Registered, clusterName)
+		case currentDomainName != strings.TrimSpace(domainName):
+			reconciliation.Changed = append(reconciliation.Changed, clusterName)
+		default:
+			reconciliation.Renewed = append(reconciliation.Renewed, clusterName)
+		}
+	}
+	if prune {
+		for clusterName := range current {
+			if _, found := domainNames[clusterName]; !found {
+				reconciliation.Forgotten = append(reconciliation.Forgotten, clusterName)
+			}
+		}
+	}
+	for _, clusterNames := range [][]string{reconciliation.Registered, reconciliation.Changed, reconciliation.Renewed, reconciliation.Forgotten, reconciliation.UnknownClusters} {
+		sort.Strings(clusterNames)
+	}
+	return reconciliation
+}
@@ -0,0 +1,63 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"testing"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+func TestReconcileClusterDomains(t *testing.T) {
+	knownClusters := []string{"c1:3306", "c2:3306", "c3:3306", "c4:3306", "c5:3306"}
+	registered := []inst.ClusterDomainName{
+		{ClusterName: "c2:3306", DomainName: "c2.example.com"},
+		{ClusterName: "c3:3306", DomainName: "old-c3.example.com"},
+		{ClusterName: "c4:3306", DomainName: "c4.example.com"},
+		{ClusterName: "c5:3306", DomainName: "c5.example.com"},
+	}
+	domainNames := map[string]string{
+		"c1:3306":      "c1.example.com",
+		"c2:3306":      "c2.example.com",
+		"c3:3306":      "c3.example.com",
+		"c5:3306":      "",
+		"unknown:3306": "unknown.example.com",
+	}
+
+	reconciliation := reconcileClusterDomains(domainNames, knownClusters, registered, false)
+	test.S(t).ExpectEquals(len(reconciliation.Registered), 1)
+	test.S(t).ExpectEquals(reconciliation.Registered[0], "c1:3306")
+	test.S(t).ExpectEquals(len(reconciliation.Renewed), 1)
+	test.S(t).ExpectEquals(reconciliation.Renewed[0], "c2:3306")
+	test.S(t).ExpectEquals(len(reconciliation.Changed), 1)
+	test.S(t).ExpectEquals(reconciliation.Changed[0], "c3:3306")
+	test.S(t).ExpectEquals(len(reconciliation.Forgotten), 1)
+	test.S(t).ExpectEquals(reconciliation.Forgotten[0], "c5:3306")
+	test.S(t).ExpectEquals(len(reconciliation.UnknownClusters), 1)
+	test.S(t).ExpectEquals(reconciliation.UnknownClusters[0], "unknown:3306")
+
+	reconciliation = reconcileClusterDomains(domainNames, knownClusters, registered, true)
+	test.S(t).ExpectEquals(len(reconciliation.Forgotten), 2)
+	test.S(t).ExpectEquals(reconciliation.Forgotten[0], "c4:3306")
+	test.S(t).ExpectEquals(reconciliation.Forgotten[1], "c5:3306")
+}
+
+func TestSetClusterDomainValidation(t *testing.T) {
+	test.S(t).ExpectNotNil(SetClusterDomain("", "c1.example.com"))
+	test.S(t).ExpectNotNil(SetClusterDomain("c1:3306", " "))
+}
@@ -0,0 +1,282 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/golib/sqlutils"
+	"github.com/openark/orchestrator/go/db"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+type ClusterEventType string
+
+const (
+	AuditClusterEvent            ClusterEventType = "audit"
+	FailureDetectionClusterEvent ClusterEventType = "failure-detection"
+	RecoveryClusterEvent         ClusterEventType = "recovery"
+	AnalysisChangeClusterEvent   ClusterEventType = "analysis-change"
+)
+
+// clusterEventTypeRank orders events of different types which share the same timestamp
+var clusterEventTypeRank = map[ClusterEventType]int{
+	AnalysisChangeClusterEvent:   0,
+	FailureDetectionClusterEvent: 1,
+	RecoveryClusterEvent:         2,
+	AuditClusterEvent:            3,
+}
+
+const maxClusterEventsLimit = 1000
+
+// ClusterEvent is a single entry in a cluster's event stream, which merges audit entries, failure
+// detections, recoveries and replication analysis changes.
+type ClusterEvent struct {
+	Type        ClusterEventType
+	Id          int64
+	Timestamp   string
+	Key         inst.InstanceKey
+	ClusterName string
+	Summary     string
+	Message     string
+	Cursor      string
+}
+
+func (this *ClusterEvent) makeCursor() string {
+	return fmt.Sprintf("%s,%s,%d", this.Timestamp, this.Type, this.Id)
+}
+
+// isAfter returns true when this event is ordered after given one
+func (this *ClusterEvent) isAfter(other *ClusterEvent) bool {
+	if this.Timestamp != other.Timestamp {
+		return this.Timestamp > other.Timestamp
+	}
+	if clusterEventTypeRank[this.Type] != clusterEventTypeRank[other.Type] {
+		return clusterEventTypeRank[this.Type] > clusterEventTypeRank[other.Type]
+	}
+	return this.Id > other.Id
+}
+
+// parseClusterEventCursor reads a cursor, as generated by ClusterEvent.makeCursor
+func parseClusterEventCursor(cursor string) (*ClusterEvent, error) {
+	tokens := strings.Split(cursor, ",")
+	if len(tokens) != 3 {
+		return nil, fmt.Errorf("Invalid cluster event cursor: %s", cursor)
+	}
+	eventType := ClusterEventType(tokens[1])
+	if _, found := clusterEventTypeRank[eventType]; !found {
+		return nil, fmt.Errorf("Invalid cluster event cursor: %s", cursor)
+	}
+	id, err := strconv.ParseInt(tokens[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid cluster event cursor: %s", cursor)
+	}
+	return &ClusterEvent{Timestamp: tokens[0], Type: eventType, Id: id}, nil
+}
+
+// minIdAfter returns the id following which events of given type, sharing the timestamp of given event, are
+// ordered after it: events of types ranked before the given event's are all ordered before it, and events of
+// types ranked after it are all ordered after it.
+func minIdAfter(eventType ClusterEventType, since *ClusterEvent) int64 {
+	switch {
+	case clusterEventTypeRank[eventType] < clusterEventTypeRank[since.Type]:
+		return math.MaxInt64
+	case clusterEventTypeRank[eventType] > clusterEventTypeRank[since.Type]:
+		return -1
+	}
+	return since.Id
+}
+
+// backendTimestamp formats a timestamp, as read from the backend database, such that it can be
+// compared against timestamp columns. The sqlite driver reads timestamps in RFC3339 format.
+func backendTimestamp(timestamp string) string {
+	if t, err := time.Parse(time.RFC3339, timestamp); err == nil {
+		return t.Format("2006-01-02 15:04:05")
+	}
+	return timestamp
+}
+
+// clusterEventSource is a query reading events of a single type, and a function formatting their message
+type clusterEventSource struct {
+	query   string
+	message func(m sqlutils.RowMap) string
+}
+
+// readClusterEventsOfType runs given query, which is expected to return events in chronological order, ties
+// broken by id, and collects up to `limit` events ordered after given one
+func readClusterEventsOfType(eventType ClusterEventType, source clusterEventSource, clusterName string, since *ClusterEvent, limit int) (events []ClusterEvent, err error) {
+	sinceTimestamp := backendTimestamp(since.Timestamp)
+	args := sqlutils.Args(clusterName, sinceTimestamp, sinceTimestamp, minIdAfter(eventType, since), limit)
+	err = db.QueryOrchestrator(source.query, args, func(m sqlutils.RowMap) error {
+		event := ClusterEvent{
+			Type:        eventType,
+			Id:          m.GetInt64("event_id"),
+			Timestamp:   m.GetString("event_timestamp"),
+			Key:         inst.InstanceKey{Hostname: m.GetString("hostname"), Port: m.GetInt("port")},
+			ClusterName: clusterName,
+			Summary:     m.GetString("summary"),
+			Message:     source.message(m),
+		}
+		events = append(events, event)
+		return nil
+	})
+	return events, log.Errore(err)
+}
+
+// ReadClusterEvents returns a time ordered stream of events on given cluster: audit entries, failure
+// detections, recoveries and replication analysis changes. Only events following given cursor are
+// returned; an empty cursor reads from the beginning of history. Each event carries its own cursor,
+// which can be passed on to a following call to resume reading.
+func ReadClusterEvents(clusterName string, cursor string, limit int) (events []ClusterEvent, err error) {
+	events = []ClusterEvent{}
+	if limit <= 0 || limit > maxClusterEventsLimit {
+		limit = maxClusterEventsLimit
+	}
+	since := &ClusterEvent{}
+	if cursor != "" {
+		if since, err = parseClusterEventCursor(cursor); err != nil {
+			return events, err
+		}
+	}
+	// Each source is read up to limit, following the cursor: events sharing the cursor's timestamp are
+	// told apart by type and id, such that paging never stalls on a busy timestamp.
+	sources := map[ClusterEventType]clusterEventSource{
+		AuditClusterEvent: {query: `
+			select
+				audit_id as event_id,
+				audit_timestamp as event_timestamp,
+				hostname,
+				port,
+				audit_type as summary,
+				message
+			from
+				audit
+			where
+				cluster_name = ?
+				and (audit_timestamp > ? or (audit_timestamp = ? and audit_id > ?))
+			order by
+				audit_timestamp asc, audit_id asc
+			limit ?
+			`,
+			message: func(m sqlutils.RowMap) string {
+				return m.GetString("message")
+			},
+		},
+		FailureDetectionClusterEvent: {query: `
+			select
+				detection_id as event_id,
+				start_active_period as event_timestamp,
+				hostname,
+				port,
+				analysis as summary,
+				count_affected_slaves
+			from
+				topology_failure_detection
+			where
+				cluster_name = ?
+				and (start_active_period > ? or (start_active_period = ? and detection_id > ?))
+			order by
+				start_active_period asc, detection_id asc
+			limit ?
+			`,
+			message: func(m sqlutils.RowMap) string {
+				return fmt.Sprintf("affected replicas: %d", m.GetInt("count_affected_slaves"))
+			},
+		},
+		RecoveryClusterEvent: {query: `
+			select
+				recovery_id as event_id,
+				start_active_period as event_timestamp,
+				hostname,
+				port,
+				analysis as summary,
+				is_successful,
+				ifnull(successor_hostname, '') as successor_hostname,
+				ifnull(successor_port, 0) as successor_port
+			from
+				topology_recovery
+			where
+				cluster_name = ?
+				and (start_active_period > ? or (start_active_period = ? and recovery_id > ?))
+			order by
+				start_active_period asc, recovery_id asc
+			limit ?
+			`,
+			message: func(m sqlutils.RowMap) string {
+				if !m.GetBool("is_successful") {
+					return "unsuccessful"
+				}
+				successorKey := inst.InstanceKey{Hostname: m.GetString("successor_hostname"), Port: m.GetInt("successor_port")}
+				return fmt.Sprintf("successful; successor: %s", successorKey.DisplayString())
+			},
+		},
+		AnalysisChangeClusterEvent: {query: `
+			select
+				database_instance_analysis_changelog.changelog_id as event_id,
+				database_instance_analysis_changelog.analysis_timestamp as event_timestamp,
+				database_instance_analysis_changelog.hostname,
+				database_instance_analysis_changelog.port,
+				database_instance_analysis_changelog.analysis as summary
+			from
+				database_instance_analysis_changelog
+				join database_instance using (hostname, port)
+			where
+				database_instance.cluster_name = ?
+				and (
+					database_instance_analysis_changelog.analysis_timestamp > ?
+					or (
+						database_instance_analysis_changelog.analysis_timestamp = ?
+						and database_instance_analysis_changelog.changelog_id > ?
+					)
+				)
+			order by
+				database_instance_analysis_changelog.analysis_timestamp asc, database_instance_analysis_changelog.changelog_id asc
+			limit ?
+			`,
+			message: func(m sqlutils.RowMap) string {
+				return ""
+			},
+		},
+	}
+	for eventType, source := range sources {
+		typeEvents, err := readClusterEventsOfType(eventType, source, clusterName, since, limit)
+		if err != nil {
+			return events, err
+		}
+		for _, event := range typeEvents {
+			if cursor == "" || event.isAfter(since) {
+				events = append(events, event)
+			}
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[j].isAfter(&events[i])
+	})
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	for i := range events {
+		events[i].Cursor = events[i].makeCursor()
+	}
+	return events, nil
+}
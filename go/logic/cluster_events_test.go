@@ -0,0 +1,88 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	test "github.com/openark/golib/tests"
+)
+
+func TestParseClusterEventCursor(t *testing.T) {
+	event := &ClusterEvent{Timestamp: "2026-10-16 10:00:00", Type: RecoveryClusterEvent, Id: 17}
+	parsed, err := parseClusterEventCursor(event.makeCursor())
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(*parsed, *event)
+
+	for _, cursor := range []string{
+		"",
+		"2026-10-16 10:00:00,recovery",
+		"2026-10-16 10:00:00,recovery,17,1",
+		"2026-10-16 10:00:00,no-such-type,17",
+		"2026-10-16 10:00:00,recovery,seventeen",
+	} {
+		_, err := parseClusterEventCursor(cursor)
+		test.S(t).ExpectNotNil(err)
+	}
+}
+
+func TestClusterEventIsAfter(t *testing.T) {
+	event := &ClusterEvent{Timestamp: "2026-10-16 10:00:00", Type: FailureDetectionClusterEvent, Id: 5}
+
+	test.S(t).ExpectTrue((&ClusterEvent{Timestamp: "2026-10-16 10:00:01", Type: AnalysisChangeClusterEvent, Id: 1}).isAfter(event))
+	test.S(t).ExpectFalse((&ClusterEvent{Timestamp: "2026-10-16 09:59:59", Type: AuditClusterEvent, Id: 9}).isAfter(event))
+	// same timestamp: by type, then by id
+	test.S(t).ExpectTrue((&ClusterEvent{Timestamp: event.Timestamp, Type: RecoveryClusterEvent, Id: 1}).isAfter(event))
+	test.S(t).ExpectFalse((&ClusterEvent{Timestamp: event.Timestamp, Type: AnalysisChangeClusterEvent, Id: 9}).isAfter(event))
+	test.S(t).ExpectTrue((&ClusterEvent{Timestamp: event.Timestamp, Type: FailureDetectionClusterEvent, Id: 6}).isAfter(event))
+	test.S(t).ExpectFalse((&ClusterEvent{Timestamp: event.Timestamp, Type: FailureDetectionClusterEvent, Id: 5}).isAfter(event))
+}
+
+func TestClusterEventOrdering(t *testing.T) {
+	events := []ClusterEvent{
+		{Timestamp: "2026-10-16 10:00:01", Type: AnalysisChangeClusterEvent, Id: 1},
+		{Timestamp: "2026-10-16 10:00:00", Type: AuditClusterEvent, Id: 3},
+		{Timestamp: "2026-10-16 10:00:00", Type: AuditClusterEvent, Id: 2},
+		{Timestamp: "2026-10-16 10:00:00", Type: RecoveryClusterEvent, Id: 8},
+		{Timestamp: "2026-10-16 10:00:00", Type: AnalysisChangeClusterEvent, Id: 4},
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[j].isAfter(&events[i])
+	})
+	expected := []string{
+		"2026-10-16 10:00:00,analysis-change,4",
+		"2026-10-16 10:00:00,recovery,8",
+		"2026-10-16 10:00:00,audit,2",
+		"2026-10-16 10:00:00,audit,3",
+		"2026-10-16 10:00:01,analysis-change,1",
+	}
+	for i := range events {
+		test.S(t).ExpectEquals(events[i].makeCursor(), expected[i])
+	}
+}
+
+func TestMinIdAfter(t *testing.T) {
+	since := &ClusterEvent{Timestamp: "2026-10-16 10:00:00", Type: FailureDetectionClusterEvent, Id: 5}
+
+	// events sharing the cursor's timestamp: none of a type ranked before it, all of a type ranked after it
+	test.S(t).ExpectEquals(minIdAfter(AnalysisChangeClusterEvent, since), int64(math.MaxInt64))
+	test.S(t).ExpectEquals(minIdAfter(FailureDetectionClusterEvent, since), int64(5))
+	test.S(t).ExpectEquals(minIdAfter(RecoveryClusterEvent, since), int64(-1))
+	test.S(t).ExpectEquals(minIdAfter(AuditClusterEvent, since), int64(-1))
+}
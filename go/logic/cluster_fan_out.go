@@ -0,0 +1,68 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"time"
+
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/inst"
+	"github.com/openark/orchestrator/go/util"
+)
+
+// InstanceFanOutError is the failure of a cluster-wide operation on a single instance
+type InstanceFanOutError struct {
+	Key   inst.InstanceKey
+	Error string
+}
+
+// clusterFanOutOptions returns the limits of operations which reach out to each instance of a cluster
+func clusterFanOutOptions() util.FanOutOptions {
+	return util.FanOutOptions{
+		Concurrency: config.Config.MaxConcurrentReplicaOperations,
+		ItemTimeout: time.Duration(config.Config.ClusterFanOutInstanceTimeoutSeconds) * time.Second,
+		Timeout:     time.Duration(config.Config.ClusterFanOutTimeoutSeconds) * time.Second,
+	}
+}
+
+// fanOutInstances calls f on each of given instances, within the limits of clusterFanOutOptions. It returns
+// one result per instance, in order, as well as the errors of those instances on which f did not succeed.
+func fanOutInstances(instances [](*inst.Instance), f func(instance *inst.Instance) (interface{}, error)) (results []util.FanOutResult, errors []InstanceFanOutError) {
+	results = util.FanOut(len(instances), clusterFanOutOptions(), func(i int) (interface{}, error) {
+		return f(instances[i])
+	})
+	for _, result := range util.FanOutErrors(results) {
+		errors = append(errors, InstanceFanOutError{Key: instances[result.Index].Key, Error: result.Err.Error()})
+	}
+	return results, errors
+}
+
+// refreshInstances reads given instances directly from the servers. Instances which could not be read
+// retain their backend copy, and are reported as errors.
+func refreshInstances(instances [](*inst.Instance)) (refreshed [](*inst.Instance), errors []InstanceFanOutError) {
+	results, errors := fanOutInstances(instances, func(instance *inst.Instance) (interface{}, error) {
+		return inst.ReadTopologyInstance(&instance.Key)
+	})
+	for i, result := range results {
+		if instance, ok := result.Value.(*inst.Instance); ok && result.Err == nil && instance != nil {
+			refreshed = append(refreshed, instance)
+		} else {
+			refreshed = append(refreshed, instances[i])
+		}
+	}
+	return refreshed, errors
+}
@@ -0,0 +1,53 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"testing"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+func TestClusterFanOutOptions(t *testing.T) {
+	options := clusterFanOutOptions()
+	test.S(t).ExpectEquals(options.Concurrency, config.Config.MaxConcurrentReplicaOperations)
+	test.S(t).ExpectEquals(options.ItemTimeout.Seconds(), float64(config.Config.ClusterFanOutInstanceTimeoutSeconds))
+	test.S(t).ExpectEquals(options.Timeout.Seconds(), float64(config.Config.ClusterFanOutTimeoutSeconds))
+}
+
+func TestFanOutInstances(t *testing.T) {
+	instances := [](*inst.Instance){
+		{Key: inst.InstanceKey{Hostname: "host1", Port: 3306}},
+		{Key: inst.InstanceKey{Hostname: "host2", Port: 3306}},
+		{Key: inst.InstanceKey{Hostname: "host3", Port: 3306}},
+	}
+	results, errors := fanOutInstances(instances, func(instance *inst.Instance) (interface{}, error) {
+		if instance.Key.Hostname == "host2" {
+			return nil, fmt.Errorf("cannot connect")
+		}
+		return instance.Key.Hostname, nil
+	})
+	test.S(t).ExpectEquals(len(results), 3)
+	test.S(t).ExpectEquals(results[0].Value, "host1")
+	test.S(t).ExpectEquals(results[2].Value, "host3")
+	test.S(t).ExpectEquals(len(errors), 1)
+	test.S(t).ExpectEquals(errors[0].Key.Hostname, "host2")
+	test.S(t).ExpectEquals(errors[0].Error, "cannot connect")
+}
@@ -0,0 +1,208 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openark/orchestrator/go/inst"
+)
+
+// ClusterFenceReasonPrefix prefixes the reason of maintenance entries placed by cluster fences
+const ClusterFenceReasonPrefix = "cluster-fence"
+
+// defaultClusterFenceTTL is the TTL of a fence which does not specify one
+const defaultClusterFenceTTL = time.Minute
+
+// clusterFencePollInterval is the interval at which AcquireClusterFence retries while another owner holds the fence
+var clusterFencePollInterval = time.Second
+
+// ClusterFence is a cooperative, time bounded lock on a cluster, held as a specially named maintenance entry on the
+// cluster's master. Unlike cluster locks, which are advisory only, a fence also keeps orchestrator's own operations
+// from taking the master for maintenance while held. The holder renews the fence within its TTL and closes it when
+// done; a fence not renewed expires along with its maintenance entry.
+type ClusterFence struct {
+	ClusterName string
+	Owner       string
+	MasterKey   inst.InstanceKey
+	Token       int64
+	TTLSeconds  uint
+	ExpiresAt   time.Time
+}
+
+// ClusterFenceHeldError is returned when a fence cannot be acquired because the cluster's master is in maintenance:
+// either fenced by another owner, or in maintenance for any other reason
+type ClusterFenceHeldError struct {
+	ClusterName string
+	Owner       string
+	Holder      inst.Maintenance
+}
+
+func (this *ClusterFenceHeldError) Error() string {
+	return fmt.Sprintf("fence of cluster %s by %s refused: master %s in maintenance by %s until %s; reason: %s",
+		this.ClusterName, this.Owner, this.Holder.Key.StringCode(), this.Holder.Owner, this.Holder.EndTimestamp, this.Holder.Reason)
+}
+
+// ClusterFenceLostError is returned when renewing a fence which its owner no longer holds
+type ClusterFenceLostError struct {
+	Fence  *ClusterFence
+	Reason string
+}
+
+func (this *ClusterFenceLostError) Error() string {
+	return fmt.Sprintf("fence of cluster %s by %s lost: %s", this.Fence.ClusterName, this.Fence.Owner, this.Reason)
+}
+
+// clusterFenceReason returns the maintenance reason marking a fence on given cluster
+func clusterFenceReason(clusterName string) string {
+	return fmt.Sprintf("%s:%s", ClusterFenceReasonPrefix, clusterName)
+}
+
+// clusterFenceTTLSeconds returns given TTL in whole seconds, applying the default and rounding up
+func clusterFenceTTLSeconds(ttl time.Duration) uint {
+	if ttl <= 0 {
+		ttl = defaultClusterFenceTTL
+	}
+	return uint((ttl + time.Second - 1) / time.Second)
+}
+
+// readClusterFenceMasterKey returns the key of the master a fence on given cluster is placed on
+func readClusterFenceMasterKey(clusterName string) (*inst.InstanceKey, error) {
+	masters, err := inst.ReadClusterMaster(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if len(masters) == 0 {
+		return nil, fmt.Errorf("No master found for cluster %s", clusterName)
+	}
+	return &masters[0].Key, nil
+}
+
+// TryAcquireClusterFence places a fence on given cluster on behalf of given owner, without waiting. It returns
+// a ClusterFenceHeldError when the cluster's master is already in maintenance.
+func TryAcquireClusterFence(clusterName string, owner string, ttl time.Duration) (*ClusterFence, error) {
+	if owner == "" {
+		return nil, fmt.Errorf("TryAcquireClusterFence: empty owner")
+	}
+	masterKey, err := readClusterFenceMasterKey(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	ttlSeconds := clusterFenceTTLSeconds(ttl)
+	token, err := inst.BeginBoundedMaintenance(masterKey, owner, clusterFenceReason(clusterName), ttlSeconds, true)
+	if err != nil {
+		if maintenanceList, readErr := inst.ReadInstanceActiveMaintenance(masterKey); readErr == nil && len(maintenanceList) > 0 {
+			return nil, &ClusterFenceHeldError{ClusterName: clusterName, Owner: owner, Holder: maintenanceList[0]}
+		}
+		return nil, err
+	}
+	return &ClusterFence{
+		ClusterName: clusterName,
+		Owner:       owner,
+		MasterKey:   *masterKey,
+		Token:       token,
+		TTLSeconds:  ttlSeconds,
+		ExpiresAt:   time.Now().Add(time.Duration(ttlSeconds) * time.Second),
+	}, nil
+}
+
+// AcquireClusterFence places a fence on given cluster on behalf of given owner, waiting while another owner holds
+// it, until the context is done
+func AcquireClusterFence(ctx context.Context, clusterName string, owner string, ttl time.Duration) (*ClusterFence, error) {
+	for {
+		fence, err := TryAcquireClusterFence(clusterName, owner, ttl)
+		if _, held := err.(*ClusterFenceHeldError); !held {
+			return fence, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(clusterFencePollInterval):
+		}
+	}
+}
+
+// ResumeClusterFence returns the fence of given maintenance token, e.g. for a holder which restarted or which
+// renews via the API. Ownership is validated upon renewal.
+func ResumeClusterFence(clusterName string, owner string, token int64, ttl time.Duration) (*ClusterFence, error) {
+	masterKey, err := inst.ReadMaintenanceInstanceKey(token)
+	if err != nil {
+		return nil, err
+	}
+	if masterKey == nil {
+		return nil, fmt.Errorf("No maintenance found for token %d", token)
+	}
+	return &ClusterFence{
+		ClusterName: clusterName,
+		Owner:       owner,
+		MasterKey:   *masterKey,
+		Token:       token,
+		TTLSeconds:  clusterFenceTTLSeconds(ttl),
+	}, nil
+}
+
+// Renew extends the fence by its TTL. It returns a ClusterFenceLostError when the fence was released, expired or
+// taken over, or when the cluster's master changed since it was placed, in which case the fence is released.
+func (this *ClusterFence) Renew(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	masterKey, err := readClusterFenceMasterKey(this.ClusterName)
+	if err != nil {
+		return err
+	}
+	if !masterKey.Equals(&this.MasterKey) {
+		this.Close()
+		return &ClusterFenceLostError{Fence: this, Reason: fmt.Sprintf("master changed from %s to %s", this.MasterKey.StringCode(), masterKey.StringCode())}
+	}
+	ttlSeconds := clusterFenceTTLSeconds(time.Duration(this.TTLSeconds) * time.Second)
+	renewed, err := inst.RenewMaintenance(this.Token, this.Owner, ttlSeconds)
+	if err != nil {
+		return err
+	}
+	if !renewed {
+		return &ClusterFenceLostError{Fence: this, Reason: fmt.Sprintf("maintenance %d on %s ended, expired or changed owner", this.Token, this.MasterKey.StringCode())}
+	}
+	this.ExpiresAt = time.Now().Add(time.Duration(ttlSeconds) * time.Second)
+	return nil
+}
+
+// Close releases the fence. Closing a fence no longer held is not an error.
+func (this *ClusterFence) Close() error {
+	_, err := inst.EndMaintenanceOwnedBy(this.Token, this.Owner)
+	return err
+}
+
+// ReadClusterFence returns the maintenance entry fencing given cluster, or nil when the cluster is not fenced
+func ReadClusterFence(clusterName string) (*inst.Maintenance, error) {
+	masterKey, err := readClusterFenceMasterKey(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	maintenanceList, err := inst.ReadInstanceActiveMaintenance(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	for _, maintenance := range maintenanceList {
+		if maintenance.Reason == clusterFenceReason(clusterName) {
+			return &maintenance, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,52 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+func TestClusterFenceTTLSeconds(t *testing.T) {
+	test.S(t).ExpectEquals(clusterFenceTTLSeconds(0), uint(60))
+	test.S(t).ExpectEquals(clusterFenceTTLSeconds(-time.Second), uint(60))
+	test.S(t).ExpectEquals(clusterFenceTTLSeconds(30*time.Second), uint(30))
+	test.S(t).ExpectEquals(clusterFenceTTLSeconds(1500*time.Millisecond), uint(2))
+}
+
+func TestClusterFenceErrors(t *testing.T) {
+	test.S(t).ExpectEquals(clusterFenceReason("c1"), "cluster-fence:c1")
+
+	held := &ClusterFenceHeldError{
+		ClusterName: "c1",
+		Owner:       "deploy:host-2",
+		Holder: inst.Maintenance{
+			Key:          inst.InstanceKey{Hostname: "db1", Port: 3306},
+			Owner:        "deploy:host-1",
+			Reason:       clusterFenceReason("c1"),
+			EndTimestamp: "2026-10-16 10:00:00",
+		},
+	}
+	test.S(t).ExpectTrue(strings.Contains(held.Error(), "fence of cluster c1 by deploy:host-2 refused: master db1:3306 in maintenance by deploy:host-1"))
+
+	lost := &ClusterFenceLostError{Fence: &ClusterFence{ClusterName: "c1", Owner: "deploy:host-1"}, Reason: "master changed"}
+	test.S(t).ExpectEquals(lost.Error(), "fence of cluster c1 by deploy:host-1 lost: master changed")
+}
@@ -0,0 +1,66 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"github.com/openark/orchestrator/go/inst"
+	orcraft "github.com/openark/orchestrator/go/raft"
+)
+
+// AcquireClusterLock takes or extends an advisory lock on a cluster. With raft, the lock is published
+// to all nodes, such that it survives a leader change. The current lock on the cluster is returned,
+// also when the lock is held by another owner.
+func AcquireClusterLock(request *inst.ClusterLockRequest) (*inst.ClusterLock, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	if !orcraft.IsRaftEnabled() {
+		return inst.AcquireClusterLock(request.ClusterName, request.Owner, request.Reason, request.DurationSeconds)
+	}
+	_, err := orcraft.PublishCommand("acquire-cluster-lock", request)
+	lock, readErr := inst.ReadClusterLock(request.ClusterName)
+	if err != nil {
+		return lock, err
+	}
+	return lock, readErr
+}
+
+// ReleaseClusterLock releases a lock on a cluster, if held by given owner
+func ReleaseClusterLock(clusterName string, owner string) (released bool, err error) {
+	if err := (&inst.ClusterLockRequest{ClusterName: clusterName, Owner: owner}).Validate(); err != nil {
+		return false, err
+	}
+	if !orcraft.IsRaftEnabled() {
+		return inst.ReleaseClusterLock(clusterName, owner)
+	}
+	if _, err := orcraft.PublishCommand("release-cluster-lock", []string{clusterName, owner}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ExpireClusterLocks removes locks whose lease has passed. With raft, the leader expires locks on all nodes.
+func ExpireClusterLocks() error {
+	if !orcraft.IsRaftEnabled() {
+		return inst.ExpireClusterLocks()
+	}
+	if !orcraft.IsLeader() {
+		return nil
+	}
+	_, err := orcraft.PublishCommand("expire-cluster-locks", 0)
+	return err
+}
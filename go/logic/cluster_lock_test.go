@@ -0,0 +1,45 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"testing"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+func TestAcquireClusterLockValidates(t *testing.T) {
+	for _, request := range []*inst.ClusterLockRequest{
+		{Owner: "deployer", Reason: "migration"},
+		{ClusterName: "c1", Reason: "migration"},
+	} {
+		lock, err := AcquireClusterLock(request)
+		test.S(t).ExpectNotNil(err)
+		test.S(t).ExpectTrue(lock == nil)
+	}
+}
+
+func TestReleaseClusterLockValidates(t *testing.T) {
+	released, err := ReleaseClusterLock("", "deployer")
+	test.S(t).ExpectNotNil(err)
+	test.S(t).ExpectFalse(released)
+
+	released, err = ReleaseClusterLock("c1", "")
+	test.S(t).ExpectNotNil(err)
+	test.S(t).ExpectFalse(released)
+}
@@ -0,0 +1,192 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/golib/sqlutils"
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/db"
+	"github.com/openark/orchestrator/go/discovery"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+// clusterMetricsSummaryCache keeps computed summaries for the duration of a poll interval, so that
+// dashboards refreshing frequently do not each hit the backend
+var clusterMetricsSummaryCache = cache.New(time.Minute, time.Second)
+
+func init() {
+	inst.SubscribeDomainEvents(func(event inst.DomainEvent) {
+		if event.Type == inst.CacheInvalidatedEvent {
+			invalidateClusterMetricsSummary(event.ClusterName)
+		}
+	})
+}
+
+// invalidateClusterMetricsSummary drops cached summaries of given cluster, for all windows
+func invalidateClusterMetricsSummary(clusterName string) {
+	if clusterName == "" {
+		return
+	}
+	prefix := fmt.Sprintf("%s:", clusterName)
+	for cacheKey := range clusterMetricsSummaryCache.Items() {
+		if strings.HasPrefix(cacheKey, prefix) {
+			clusterMetricsSummaryCache.Delete(cacheKey)
+		}
+	}
+}
+
+// ClusterMetricsSummary combines replication, analysis, recovery and discovery figures of a cluster
+// into a single overview
+type ClusterMetricsSummary struct {
+	ClusterName               string
+	ClusterAlias              string
+	WindowSeconds             int
+	CountInstances            int
+	CountValidInstances       int
+	CountDowntimedInstances   int
+	CountInstancesWithProblem int
+	MaxReplicationLagSeconds  int64
+	CountAnalysisProblems     int
+	CountRecoveries           int
+	CountFailedRecoveries     int
+	CountDiscoveries          uint64
+	CountFailedDiscoveries    uint64
+	MeanDiscoverySeconds      float64
+	MaxP95DiscoverySeconds    float64
+	ComputedAt                time.Time
+}
+
+// countClusterRecoveries counts recoveries, and failed recoveries, on given cluster in the last given seconds
+func countClusterRecoveries(clusterName string, windowSeconds int) (countRecoveries int, countFailed int, err error) {
+	query := `
+		select
+			count(*) as count_recoveries,
+			ifnull(sum(is_successful = 0), 0) as count_failed
+		from
+			topology_recovery
+		where
+			cluster_name = ?
+			and start_active_period >= now() - interval ? second
+		`
+	err = db.QueryOrchestrator(query, sqlutils.Args(clusterName, windowSeconds), func(m sqlutils.RowMap) error {
+		countRecoveries = m.GetInt("count_recoveries")
+		countFailed = m.GetInt("count_failed")
+		return nil
+	})
+	return countRecoveries, countFailed, log.Errore(err)
+}
+
+// summarizeClusterDiscoveries aggregates discovery metrics, as retained in memory by this node, of given instances
+func summarizeClusterDiscoveries(summary *ClusterMetricsSummary, instanceKeys *inst.InstanceKeyMap, since time.Time) error {
+	metrics, err := discoveryMetrics.Since(since)
+	if err != nil {
+		return err
+	}
+	window := discovery.Rollup(metrics, since, time.Now().Add(time.Second))
+	totalSeconds := 0.0
+	for _, instanceRollup := range window.Instances {
+		if !instanceKeys.HasKey(inst.InstanceKey{Hostname: instanceRollup.Hostname, Port: instanceRollup.Port}) {
+			continue
+		}
+		summary.CountDiscoveries += instanceRollup.Discoveries
+		summary.CountFailedDiscoveries += instanceRollup.FailedDiscoveries
+		totalSeconds += instanceRollup.MeanTotalSeconds * float64(instanceRollup.Discoveries)
+		if instanceRollup.P95TotalSeconds > summary.MaxP95DiscoverySeconds {
+			summary.MaxP95DiscoverySeconds = instanceRollup.P95TotalSeconds
+		}
+	}
+	if summary.CountDiscoveries > 0 {
+		summary.MeanDiscoverySeconds = totalSeconds / float64(summary.CountDiscoveries)
+	}
+	return nil
+}
+
+// computeClusterMetricsSummary computes the summary of given cluster over the last given seconds
+func computeClusterMetricsSummary(clusterName string, windowSeconds int) (*ClusterMetricsSummary, error) {
+	summary := &ClusterMetricsSummary{
+		ClusterName:   clusterName,
+		WindowSeconds: windowSeconds,
+		ComputedAt:    time.Now(),
+	}
+	instances, err := inst.ReadClusterInstances(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	instanceKeys := inst.NewInstanceKeyMap()
+	for _, instance := range instances {
+		instanceKeys.AddKey(instance.Key)
+		summary.CountInstances++
+		if instance.IsLastCheckValid {
+			summary.CountValidInstances++
+		}
+		if instance.IsDowntimed {
+			summary.CountDowntimedInstances++
+		}
+		if len(instance.Problems) > 0 {
+			summary.CountInstancesWithProblem++
+		}
+		if instance.ReplicationLagSeconds.Valid && instance.ReplicationLagSeconds.Int64 > summary.MaxReplicationLagSeconds {
+			summary.MaxReplicationLagSeconds = instance.ReplicationLagSeconds.Int64
+		}
+	}
+	if clusterInfo, err := inst.ReadClusterInfo(clusterName); err == nil {
+		summary.ClusterAlias = clusterInfo.ClusterAlias
+	}
+
+	analysis, err := inst.GetReplicationAnalysis(clusterName, &inst.ReplicationAnalysisHints{})
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range analysis {
+		if entry.Analysis != inst.NoProblem {
+			summary.CountAnalysisProblems++
+		}
+	}
+	if summary.CountRecoveries, summary.CountFailedRecoveries, err = countClusterRecoveries(clusterName, windowSeconds); err != nil {
+		return nil, err
+	}
+	if err := summarizeClusterDiscoveries(summary, instanceKeys, summary.ComputedAt.Add(-time.Duration(windowSeconds)*time.Second)); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// ReadClusterMetricsSummary returns an overview of given cluster: instance health and lag, analysis problems,
+// and recoveries and discoveries in the last windowSeconds. Summaries are cached for the duration of a
+// poll interval.
+func ReadClusterMetricsSummary(clusterName string, windowSeconds int) (*ClusterMetricsSummary, error) {
+	if windowSeconds <= 0 {
+		windowSeconds = int(config.Config.DiscoveryCollectionRetentionSeconds)
+	}
+	cacheKey := fmt.Sprintf("%s:%d", clusterName, windowSeconds)
+	if summary, found := clusterMetricsSummaryCache.Get(cacheKey); found {
+		return summary.(*ClusterMetricsSummary), nil
+	}
+	summary, err := computeClusterMetricsSummary(clusterName, windowSeconds)
+	if err != nil {
+		return nil, err
+	}
+	clusterMetricsSummaryCache.Set(cacheKey, summary, instancePollSecondsDuration())
+	return summary, nil
+}
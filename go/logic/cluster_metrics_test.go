@@ -0,0 +1,42 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"testing"
+
+	"github.com/patrickmn/go-cache"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+func TestClusterMetricsSummaryInvalidatedByMutation(t *testing.T) {
+	for _, cacheKey := range []string{"cluster1:60", "cluster1:300", "cluster10:60"} {
+		clusterMetricsSummaryCache.Set(cacheKey, &ClusterMetricsSummary{}, cache.DefaultExpiration)
+	}
+	defer clusterMetricsSummaryCache.Flush()
+
+	inst.PublishDomainEvent(inst.DomainEvent{Type: inst.CacheInvalidatedEvent, ClusterName: "cluster1"})
+
+	_, found := clusterMetricsSummaryCache.Get("cluster1:60")
+	test.S(t).ExpectFalse(found)
+	_, found = clusterMetricsSummaryCache.Get("cluster1:300")
+	test.S(t).ExpectFalse(found)
+	_, found = clusterMetricsSummaryCache.Get("cluster10:60")
+	test.S(t).ExpectTrue(found)
+}
@@ -0,0 +1,68 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"github.com/openark/orchestrator/go/inst"
+	orcraft "github.com/openark/orchestrator/go/raft"
+)
+
+// DisableClusterRecoveries suppresses automated recoveries on a single cluster. With raft, the
+// suppression is published to all nodes, such that it survives a leader change.
+func DisableClusterRecoveries(request *inst.ClusterRecoveryDisableRequest) (*inst.ClusterRecoveryDisable, error) {
+	if !orcraft.IsRaftEnabled() {
+		return inst.DisableClusterRecoveries(request.ClusterName, request.Owner, request.Reason, request.DurationSeconds)
+	}
+	if _, err := orcraft.PublishCommand("disable-cluster-recoveries", request); err != nil {
+		return nil, err
+	}
+	return inst.ReadClusterRecoveryDisable(request.ClusterName)
+}
+
+// EnableClusterRecoveries lifts a per-cluster recovery suppression
+func EnableClusterRecoveries(clusterName string) (enabled bool, err error) {
+	if !orcraft.IsRaftEnabled() {
+		return inst.EnableClusterRecoveries(clusterName)
+	}
+	disable, err := inst.ReadClusterRecoveryDisable(clusterName)
+	if err != nil {
+		return false, err
+	}
+	if _, err := orcraft.PublishCommand("enable-cluster-recoveries", clusterName); err != nil {
+		return false, err
+	}
+	return disable != nil, nil
+}
+
+// ExpireClusterRecoveryDisables removes timed recovery suppressions which have passed. With raft,
+// the leader expires them on all nodes.
+func ExpireClusterRecoveryDisables() error {
+	if !orcraft.IsRaftEnabled() {
+		return inst.ExpireClusterRecoveryDisables()
+	}
+	if !orcraft.IsLeader() {
+		return nil
+	}
+	_, err := orcraft.PublishCommand("expire-cluster-recovery-disables", 0)
+	return err
+}
+
+// skipRecoveryOnDisabledCluster tells whether a recovery is skipped given the suppression on its cluster, if any.
+// Forced recoveries (e.g. via the API or CLI) proceed regardless.
+func skipRecoveryOnDisabledCluster(clusterRecoveryDisable *inst.ClusterRecoveryDisable, forceInstanceRecovery bool) bool {
+	return clusterRecoveryDisable != nil && !forceInstanceRecovery
+}
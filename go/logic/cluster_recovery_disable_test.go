@@ -0,0 +1,33 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"testing"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+func TestSkipRecoveryOnDisabledCluster(t *testing.T) {
+	disable := &inst.ClusterRecoveryDisable{ClusterName: "cluster1", Owner: "dba", Reason: "maintenance"}
+
+	test.S(t).ExpectTrue(skipRecoveryOnDisabledCluster(disable, false))
+	test.S(t).ExpectFalse(skipRecoveryOnDisabledCluster(disable, true))
+	test.S(t).ExpectFalse(skipRecoveryOnDisabledCluster(nil, false))
+	test.S(t).ExpectFalse(skipRecoveryOnDisabledCluster(nil, true))
+}
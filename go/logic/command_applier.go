@@ -18,6 +18,7 @@ package logic
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/openark/orchestrator/go/inst"
 	"github.com/openark/orchestrator/go/kv"
@@ -55,6 +56,8 @@ func (applier *CommandApplier) ApplyCommand(op string, value []byte) interface{}
 		return applier.beginDowntime(value)
 	case "end-downtime":
 		return applier.endDowntime(value)
+	case "extend-downtime":
+		return applier.extendDowntime(value)
 	case "register-candidate":
 		return applier.registerCandidate(value)
 	case "ack-recovery":
@@ -75,6 +78,10 @@ func (applier *CommandApplier) ApplyCommand(op string, value []byte) interface{}
 		return applier.disableGlobalRecoveries(value)
 	case "enable-global-recoveries":
 		return applier.enableGlobalRecoveries(value)
+	case "freeze-global-recoveries":
+		return applier.freezeGlobalRecoveries(value)
+	case "thaw-global-recoveries":
+		return applier.thawGlobalRecoveries(value)
 	case "put-key-value":
 		return applier.putKeyValue(value)
 	case "put-instance-tag":
@@ -87,6 +94,32 @@ func (applier *CommandApplier) ApplyCommand(op string, value []byte) interface{}
 		return applier.healthReport(value)
 	case "set-cluster-alias-manual-override":
 		return applier.setClusterAliasManualOverride(value)
+	case "forget-cluster-alias":
+		return applier.forgetClusterAlias(value)
+	case "set-cluster-domain":
+		return applier.setClusterDomain(value)
+	case "forget-cluster-domain":
+		return applier.forgetClusterDomain(value)
+	case "add-recovery-filter":
+		return applier.addRecoveryFilter(value)
+	case "remove-recovery-filter":
+		return applier.removeRecoveryFilter(value)
+	case "set-instance-metadata":
+		return applier.setInstanceMetadata(value)
+	case "purge-backend-history":
+		return applier.purgeBackendHistory(value)
+	case "acquire-cluster-lock":
+		return applier.acquireClusterLock(value)
+	case "release-cluster-lock":
+		return applier.releaseClusterLock(value)
+	case "expire-cluster-locks":
+		return applier.expireClusterLocks(value)
+	case "disable-cluster-recoveries":
+		return applier.disableClusterRecoveries(value)
+	case "enable-cluster-recoveries":
+		return applier.enableClusterRecoveries(value)
+	case "expire-cluster-recovery-disables":
+		return applier.expireClusterRecoveryDisables(value)
 	}
 	return log.Errorf("Unknown command op: %s", op)
 }
@@ -154,6 +187,15 @@ func (applier *CommandApplier) endDowntime(value []byte) interface{} {
 	return err
 }
 
+func (applier *CommandApplier) extendDowntime(value []byte) interface{} {
+	downtime := inst.Downtime{}
+	if err := json.Unmarshal(value, &downtime); err != nil {
+		return log.Errore(err)
+	}
+	_, err := inst.ExtendDowntime(&downtime)
+	return err
+}
+
 func (applier *CommandApplier) registerCandidate(value []byte) interface{} {
 	candidate := inst.CandidateDatabaseInstance{}
 	if err := json.Unmarshal(value, &candidate); err != nil {
@@ -255,6 +297,16 @@ func (applier *CommandApplier) enableGlobalRecoveries(value []byte) interface{}
 	return err
 }
 
+func (applier *CommandApplier) freezeGlobalRecoveries(value []byte) interface{} {
+	err := FreezeRecovery()
+	return err
+}
+
+func (applier *CommandApplier) thawGlobalRecoveries(value []byte) interface{} {
+	err := ThawRecovery()
+	return err
+}
+
 func (applier *CommandApplier) putKeyValue(value []byte) interface{} {
 	kvPair := &kv.KVPair{}
 	if err := json.Unmarshal(value, kvPair); err != nil {
@@ -309,3 +361,120 @@ func (applier *CommandApplier) setClusterAliasManualOverride(value []byte) inter
 	err := inst.SetClusterAliasManualOverride(clusterName, alias)
 	return err
 }
+
+func (applier *CommandApplier) forgetClusterAlias(value []byte) interface{} {
+	var clusterName string
+	if err := json.Unmarshal(value, &clusterName); err != nil {
+		return log.Errore(err)
+	}
+	err := inst.ForgetClusterAlias(clusterName)
+	return err
+}
+
+func (applier *CommandApplier) setClusterDomain(value []byte) interface{} {
+	var params [2]string
+	if err := json.Unmarshal(value, &params); err != nil {
+		return log.Errore(err)
+	}
+	clusterName, domainName := params[0], params[1]
+	err := inst.WriteClusterDomainName(clusterName, domainName)
+	return err
+}
+
+func (applier *CommandApplier) forgetClusterDomain(value []byte) interface{} {
+	var clusterName string
+	if err := json.Unmarshal(value, &clusterName); err != nil {
+		return log.Errore(err)
+	}
+	err := inst.DeleteClusterDomainName(clusterName)
+	return err
+}
+
+func (applier *CommandApplier) addRecoveryFilter(value []byte) interface{} {
+	filter := inst.RecoveryFilter{}
+	if err := json.Unmarshal(value, &filter); err != nil {
+		return log.Errore(err)
+	}
+	err := inst.AddRecoveryFilter(&filter)
+	return err
+}
+
+func (applier *CommandApplier) removeRecoveryFilter(value []byte) interface{} {
+	filter := inst.RecoveryFilter{}
+	if err := json.Unmarshal(value, &filter); err != nil {
+		return log.Errore(err)
+	}
+	err := inst.RemoveRecoveryFilter(&filter)
+	return err
+}
+
+func (applier *CommandApplier) setInstanceMetadata(value []byte) interface{} {
+	metadata := inst.InstanceMetadata{}
+	if err := json.Unmarshal(value, &metadata); err != nil {
+		return log.Errore(err)
+	}
+	err := inst.SetInstanceMetadata(&metadata)
+	return err
+}
+
+func (applier *CommandApplier) purgeBackendHistory(value []byte) interface{} {
+	var olderThanSeconds int
+	if err := json.Unmarshal(value, &olderThanSeconds); err != nil {
+		return log.Errore(err)
+	}
+	_, err := PurgeBackendHistory(olderThanSeconds)
+	return err
+}
+
+func (applier *CommandApplier) acquireClusterLock(value []byte) interface{} {
+	request := inst.ClusterLockRequest{}
+	if err := json.Unmarshal(value, &request); err != nil {
+		return log.Errore(err)
+	}
+	_, err := inst.AcquireClusterLock(request.ClusterName, request.Owner, request.Reason, request.DurationSeconds)
+	return err
+}
+
+func (applier *CommandApplier) releaseClusterLock(value []byte) interface{} {
+	var params [2]string
+	if err := json.Unmarshal(value, &params); err != nil {
+		return log.Errore(err)
+	}
+	clusterName, owner := params[0], params[1]
+	released, err := inst.ReleaseClusterLock(clusterName, owner)
+	if err != nil {
+		return err
+	}
+	if !released {
+		return fmt.Errorf("Cluster %s is not locked by %s", clusterName, owner)
+	}
+	return nil
+}
+
+func (applier *CommandApplier) expireClusterLocks(value []byte) interface{} {
+	err := inst.ExpireClusterLocks()
+	return err
+}
+
+func (applier *CommandApplier) disableClusterRecoveries(value []byte) interface{} {
+	request := inst.ClusterRecoveryDisableRequest{}
+	if err := json.Unmarshal(value, &request); err != nil {
+		return log.Errore(err)
+	}
+	_, err := inst.DisableClusterRecoveries(request.ClusterName, request.Owner, request.Reason, request.DurationSeconds)
+	return err
+}
+
+func (applier *CommandApplier) enableClusterRecoveries(value []byte) interface{} {
+	var clusterName string
+	if err := json.Unmarshal(value, &clusterName); err != nil {
+		return log.Errore(err)
+	}
+	_, err := inst.EnableClusterRecoveries(clusterName)
+	return err
+}
+
+func (applier *CommandApplier) expireClusterRecoveryDisables(value []byte) interface{} {
+	err := inst.ExpireClusterRecoveryDisables()
+	return err
+}
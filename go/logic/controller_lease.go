@@ -0,0 +1,207 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/kv"
+	orcraft "github.com/openark/orchestrator/go/raft"
+)
+
+// defaultControllerLeaseSeconds is the lease of a controller heartbeat which does not specify a duration
+const defaultControllerLeaseSeconds = 30
+
+// controllerLeaseMutex serializes heartbeats served by this node. With raft, all heartbeats are served by the
+// leader, and so this is what keeps two holders from both taking a lease. With a shared backend, any node may
+// serve a heartbeat, and leases are written with a compare-and-set on the backend (see writeControllerLease).
+var controllerLeaseMutex sync.Mutex
+
+// maxControllerLeaseAttempts bounds the attempts of a heartbeat which loses compare-and-set races
+const maxControllerLeaseAttempts = 3
+
+// ControllerLease is a time bounded lease held by one instance (the holder) of an automation controller. It
+// lets automation built on orchestrator run active/passive: only the holder of the lease is active. The
+// holder renews the lease via periodic heartbeats; once it stops, the lease expires and another holder may
+// take it. Leases are stored in the KV stores; every heartbeat is a write to all of them (and, with raft, to
+// the raft log).
+type ControllerLease struct {
+	Controller  string
+	Holder      string
+	AcquiredAt  time.Time
+	RenewedAt   time.Time
+	ExpiresAt   time.Time
+	SecondsLeft int
+}
+
+// ControllerLeaseHeldError is returned when a controller's lease is held by another holder
+type ControllerLeaseHeldError struct {
+	Lease  *ControllerLease
+	Holder string
+}
+
+func (this *ControllerLeaseHeldError) Error() string {
+	return fmt.Sprintf("heartbeat of controller %s by %s refused: lease held by %s until %s",
+		this.Lease.Controller, this.Holder, this.Lease.Holder, this.Lease.ExpiresAt.Format(time.RFC3339))
+}
+
+// isActive returns true when the lease has not expired by given time
+func (this *ControllerLease) isActive(now time.Time) bool {
+	return now.Before(this.ExpiresAt)
+}
+
+// controllerLeaseKey returns the KV key of given controller's lease
+func controllerLeaseKey(controller string) string {
+	return fmt.Sprintf("%s/%s", strings.TrimRight(config.Config.KVControllerLeasePrefix, "/"), controller)
+}
+
+func validateControllerLeaseRequest(controller string, holder string) error {
+	if controller == "" || strings.Contains(controller, "/") {
+		return fmt.Errorf("Invalid controller name: %q", controller)
+	}
+	if holder == "" {
+		return fmt.Errorf("Empty controller holder")
+	}
+	return nil
+}
+
+// nextControllerLease returns the lease which a heartbeat by given holder results in, given the current lease
+// (nil if none). The lease is renewed when already held by the holder, taken anew when expired or released,
+// and refused while held by another holder.
+func nextControllerLease(current *ControllerLease, controller string, holder string, duration time.Duration, now time.Time) (*ControllerLease, error) {
+	if current != nil && current.isActive(now) && current.Holder != holder {
+		return current, &ControllerLeaseHeldError{Lease: current, Holder: holder}
+	}
+	lease := &ControllerLease{
+		Controller: controller,
+		Holder:     holder,
+		AcquiredAt: now,
+		RenewedAt:  now,
+		ExpiresAt:  now.Add(duration),
+	}
+	if current != nil && current.isActive(now) {
+		lease.AcquiredAt = current.AcquiredAt
+	}
+	lease.SecondsLeft = int(duration.Seconds())
+	return lease, nil
+}
+
+// readControllerLease reads the lease of given controller from the KV stores, active or not; nil when
+// the controller never had a lease. The stored value is returned as well, for writeControllerLease to
+// compare against.
+func readControllerLease(controller string) (lease *ControllerLease, storedValue string, err error) {
+	value, found, err := kv.GetValue(controllerLeaseKey(controller))
+	if err != nil || !found {
+		return nil, "", err
+	}
+	lease = &ControllerLease{}
+	if err := json.Unmarshal([]byte(value), lease); err != nil {
+		return nil, value, fmt.Errorf("Cannot parse lease of controller %s: %+v", controller, err)
+	}
+	return lease, value, nil
+}
+
+// writeControllerLease submits given lease to the KV stores, replacing the lease read as storedValue
+// (current is nil when there was none). With raft, the lease is published, the leader having serialized
+// heartbeats. Otherwise, the lease is written only if the backend still holds storedValue; false is
+// returned when another node wrote the lease meanwhile.
+func writeControllerLease(current *ControllerLease, storedValue string, lease *ControllerLease) (written bool, err error) {
+	value, err := json.Marshal(lease)
+	if err != nil {
+		return false, err
+	}
+	if orcraft.IsRaftEnabled() {
+		_, err = submitKVPairs([]*kv.KVPair{kv.NewKVPair(controllerLeaseKey(lease.Controller), string(value))})
+		return err == nil, err
+	}
+	return kv.CompareAndPutValue(controllerLeaseKey(lease.Controller), storedValue, current != nil, string(value))
+}
+
+// HeartbeatController registers the liveness of given holder of a controller, and takes or renews the
+// controller's lease for given number of seconds (0 for the default). A *ControllerLeaseHeldError,
+// along with the current lease, is returned while another holder's lease is active.
+func HeartbeatController(controller string, holder string, leaseSeconds uint) (*ControllerLease, error) {
+	if err := validateControllerLeaseRequest(controller, holder); err != nil {
+		return nil, err
+	}
+	if leaseSeconds == 0 {
+		leaseSeconds = defaultControllerLeaseSeconds
+	}
+	controllerLeaseMutex.Lock()
+	defer controllerLeaseMutex.Unlock()
+
+	for attempt := 1; ; attempt++ {
+		current, storedValue, err := readControllerLease(controller)
+		if err != nil {
+			return nil, err
+		}
+		lease, err := nextControllerLease(current, controller, holder, time.Duration(leaseSeconds)*time.Second, time.Now())
+		if err != nil {
+			return lease, err
+		}
+		written, err := writeControllerLease(current, storedValue, lease)
+		if err != nil {
+			return nil, err
+		}
+		if written {
+			return lease, nil
+		}
+		if attempt >= maxControllerLeaseAttempts {
+			return nil, fmt.Errorf("heartbeat of controller %s by %s: lease keeps changing concurrently", controller, holder)
+		}
+		// another node wrote the lease meanwhile; judge again, against the lease it wrote
+	}
+}
+
+// ReleaseController expires the lease of a controller, if held by given holder, such that another holder
+// may take it without waiting for it to expire. It returns false when the holder does not hold the lease.
+func ReleaseController(controller string, holder string) (released bool, err error) {
+	if err := validateControllerLeaseRequest(controller, holder); err != nil {
+		return false, err
+	}
+	controllerLeaseMutex.Lock()
+	defer controllerLeaseMutex.Unlock()
+
+	now := time.Now()
+	current, storedValue, err := readControllerLease(controller)
+	if err != nil || current == nil || !current.isActive(now) || current.Holder != holder {
+		return false, err
+	}
+	lease := *current
+	lease.ExpiresAt = now
+	// Should the lease change meanwhile, it was renewed by the holder or taken by another; either way, not released
+	return writeControllerLease(current, storedValue, &lease)
+}
+
+// WhoIsActiveController returns the active lease of given controller, or nil when no holder is active
+func WhoIsActiveController(controller string) (*ControllerLease, error) {
+	lease, _, err := readControllerLease(controller)
+	if err != nil || lease == nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !lease.isActive(now) {
+		return nil, nil
+	}
+	lease.SecondsLeft = int(lease.ExpiresAt.Sub(now).Seconds())
+	return lease, nil
+}
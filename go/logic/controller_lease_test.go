@@ -0,0 +1,79 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"testing"
+	"time"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/config"
+)
+
+func TestControllerLeaseKey(t *testing.T) {
+	prefix := config.Config.KVControllerLeasePrefix
+	defer func() { config.Config.KVControllerLeasePrefix = prefix }()
+
+	config.Config.KVControllerLeasePrefix = "orchestrator/controller/"
+	test.S(t).ExpectEquals(controllerLeaseKey("failover-bot"), "orchestrator/controller/failover-bot")
+	config.Config.KVControllerLeasePrefix = "automation"
+	test.S(t).ExpectEquals(controllerLeaseKey("failover-bot"), "automation/failover-bot")
+}
+
+func TestValidateControllerLeaseRequest(t *testing.T) {
+	test.S(t).ExpectNil(validateControllerLeaseRequest("failover-bot", "host-1"))
+	test.S(t).ExpectNotNil(validateControllerLeaseRequest("", "host-1"))
+	test.S(t).ExpectNotNil(validateControllerLeaseRequest("failover/bot", "host-1"))
+	test.S(t).ExpectNotNil(validateControllerLeaseRequest("failover-bot", ""))
+}
+
+func TestNextControllerLease(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	duration := 30 * time.Second
+	{
+		lease, err := nextControllerLease(nil, "bot", "host-1", duration, now)
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(lease.Holder, "host-1")
+		test.S(t).ExpectTrue(lease.AcquiredAt.Equal(now))
+		test.S(t).ExpectTrue(lease.ExpiresAt.Equal(now.Add(duration)))
+		test.S(t).ExpectEquals(lease.SecondsLeft, 30)
+	}
+	current := &ControllerLease{Controller: "bot", Holder: "host-1", AcquiredAt: now.Add(-time.Minute), RenewedAt: now.Add(-10 * time.Second), ExpiresAt: now.Add(20 * time.Second)}
+	{
+		// renewal keeps the acquisition time
+		lease, err := nextControllerLease(current, "bot", "host-1", duration, now)
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectTrue(lease.AcquiredAt.Equal(current.AcquiredAt))
+		test.S(t).ExpectTrue(lease.RenewedAt.Equal(now))
+		test.S(t).ExpectTrue(lease.ExpiresAt.Equal(now.Add(duration)))
+	}
+	{
+		// another holder is refused while the lease is active
+		lease, err := nextControllerLease(current, "bot", "host-2", duration, now)
+		test.S(t).ExpectNotNil(err)
+		_, isHeldError := err.(*ControllerLeaseHeldError)
+		test.S(t).ExpectTrue(isHeldError)
+		test.S(t).ExpectEquals(lease.Holder, "host-1")
+	}
+	{
+		// another holder takes an expired lease anew
+		lease, err := nextControllerLease(current, "bot", "host-2", duration, now.Add(time.Minute))
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(lease.Holder, "host-2")
+		test.S(t).ExpectTrue(lease.AcquiredAt.Equal(now.Add(time.Minute)))
+	}
+}
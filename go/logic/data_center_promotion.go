@@ -0,0 +1,127 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/inst"
+	orcraft "github.com/openark/orchestrator/go/raft"
+)
+
+// DataCenterPromotionRule is the promotion rule of a replica by its data center affinity with its master
+type DataCenterPromotionRule struct {
+	Key           inst.InstanceKey
+	DataCenter    string
+	PreviousRule  inst.CandidatePromotionRule
+	PromotionRule inst.CandidatePromotionRule
+	Changed       bool // whether PromotionRule differs from PreviousRule
+}
+
+// dataCenterPromotionRules suggests promotion rules for the replicas of given master: prefer for replicas in the
+// master's data center, prefer_not for replicas elsewhere. Replicas with a must or must_not rule, which are set
+// deliberately (e.g. delayed replicas), replicas banned from promotion, and replicas of unknown data center are
+// left as they are. Nothing is suggested when the master's data center is unknown.
+func dataCenterPromotionRules(master *inst.Instance, replicas [](*inst.Instance)) []DataCenterPromotionRule {
+	rules := []DataCenterPromotionRule{}
+	if master == nil || master.DataCenter == "" {
+		return rules
+	}
+	for _, replica := range replicas {
+		if replica.PromotionRule == inst.MustPromoteRule || replica.PromotionRule == inst.MustNotPromoteRule {
+			continue
+		}
+		if replica.DataCenter == "" || inst.IsBannedFromBeingCandidateReplica(replica) {
+			continue
+		}
+		rule := DataCenterPromotionRule{
+			Key:           replica.Key,
+			DataCenter:    replica.DataCenter,
+			PreviousRule:  replica.PromotionRule,
+			PromotionRule: inst.PreferNotPromoteRule,
+		}
+		if replica.DataCenter == master.DataCenter {
+			rule.PromotionRule = inst.PreferPromoteRule
+		}
+		rule.Changed = rule.PromotionRule != rule.PreviousRule
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// ApplyDataCenterPromotionRules registers promotion rules for the replicas of a cluster's master by their data
+// center affinity with the master (see dataCenterPromotionRules), so that a failover prefers promoting a replica
+// in the master's data center. Only changed rules are registered. Registrations expire, after which the rule
+// reads as neutral and is registered anew on the next application; re-applying thus also keeps the rules
+// aligned as the master or replicas move. Returns the suggested rules, changed or not.
+func ApplyDataCenterPromotionRules(clusterName string) ([]DataCenterPromotionRule, error) {
+	masters, err := inst.ReadClusterMaster(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if len(masters) == 0 {
+		return nil, fmt.Errorf("ApplyDataCenterPromotionRules: cannot find master for cluster %s", clusterName)
+	}
+	master := masters[0]
+	if master.DataCenter == "" {
+		return nil, fmt.Errorf("ApplyDataCenterPromotionRules: data center of %+v is unknown", master.Key)
+	}
+	replicas, err := inst.ReadReplicaInstances(&master.Key)
+	if err != nil {
+		return nil, err
+	}
+	rules := dataCenterPromotionRules(master, replicas)
+	for _, rule := range rules {
+		if !rule.Changed {
+			continue
+		}
+		candidate := inst.NewCandidateDatabaseInstance(&rule.Key, rule.PromotionRule).WithCurrentTime()
+		if orcraft.IsRaftEnabled() {
+			_, err = orcraft.PublishCommand("register-candidate", candidate)
+		} else {
+			err = inst.RegisterCandidateInstance(candidate)
+		}
+		if err != nil {
+			return rules, err
+		}
+		log.Infof("data center promotion rules: registered %+v as %s (was %s), master %+v is in %s", rule.Key, rule.PromotionRule, rule.PreviousRule, master.Key, master.DataCenter)
+	}
+	return rules, nil
+}
+
+// EnforceDataCenterPromotionRules applies data center promotion rules on clusters matching
+// SameDataCenterPromotionClusterFilters
+func EnforceDataCenterPromotionRules() error {
+	if len(config.Config.SameDataCenterPromotionClusterFilters) == 0 {
+		return nil
+	}
+	clustersInfo, err := inst.ReadClustersInfo("")
+	if err != nil {
+		return log.Errore(err)
+	}
+	for _, clusterInfo := range clustersInfo {
+		if !clusterInfo.MatchesFilters(config.Config.SameDataCenterPromotionClusterFilters) {
+			continue
+		}
+		if _, err := ApplyDataCenterPromotionRules(clusterInfo.ClusterName); err != nil {
+			log.Errore(err)
+		}
+	}
+	return nil
+}
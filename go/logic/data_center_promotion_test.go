@@ -0,0 +1,78 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"testing"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+func newDataCenterInstance(hostname string, dataCenter string, promotionRule inst.CandidatePromotionRule) *inst.Instance {
+	return &inst.Instance{
+		Key:           inst.InstanceKey{Hostname: hostname, Port: 3306},
+		DataCenter:    dataCenter,
+		PromotionRule: promotionRule,
+	}
+}
+
+func TestDataCenterPromotionRules(t *testing.T) {
+	defer func(filters []string) { config.Config.PromotionIgnoreHostnameFilters = filters }(config.Config.PromotionIgnoreHostnameFilters)
+	config.Config.PromotionIgnoreHostnameFilters = []string{"ignored"}
+
+	master := newDataCenterInstance("master", "dc1", inst.NeutralPromoteRule)
+	replicas := [](*inst.Instance){
+		newDataCenterInstance("local", "dc1", inst.NeutralPromoteRule),
+		newDataCenterInstance("local-registered", "dc1", inst.PreferPromoteRule),
+		newDataCenterInstance("remote", "dc2", inst.NeutralPromoteRule),
+		newDataCenterInstance("moved-remote", "dc2", inst.PreferPromoteRule),
+		newDataCenterInstance("must", "dc2", inst.MustPromoteRule),
+		newDataCenterInstance("must-not", "dc1", inst.MustNotPromoteRule),
+		newDataCenterInstance("ignored", "dc1", inst.NeutralPromoteRule),
+		newDataCenterInstance("unknown", "", inst.NeutralPromoteRule),
+	}
+	rules := dataCenterPromotionRules(master, replicas)
+	test.S(t).ExpectEquals(len(rules), 4)
+
+	rulesMap := make(map[string]DataCenterPromotionRule)
+	for _, rule := range rules {
+		rulesMap[rule.Key.Hostname] = rule
+	}
+	test.S(t).ExpectEquals(rulesMap["local"].PromotionRule, inst.PreferPromoteRule)
+	test.S(t).ExpectTrue(rulesMap["local"].Changed)
+	test.S(t).ExpectEquals(rulesMap["local-registered"].PromotionRule, inst.PreferPromoteRule)
+	test.S(t).ExpectFalse(rulesMap["local-registered"].Changed)
+	test.S(t).ExpectEquals(rulesMap["remote"].PromotionRule, inst.PreferNotPromoteRule)
+	test.S(t).ExpectTrue(rulesMap["remote"].Changed)
+	// the master moved away from this replica's data center
+	test.S(t).ExpectEquals(rulesMap["moved-remote"].PromotionRule, inst.PreferNotPromoteRule)
+	test.S(t).ExpectEquals(rulesMap["moved-remote"].PreviousRule, inst.PreferPromoteRule)
+	test.S(t).ExpectTrue(rulesMap["moved-remote"].Changed)
+	for _, hostname := range []string{"must", "must-not", "ignored", "unknown"} {
+		_, found := rulesMap[hostname]
+		test.S(t).ExpectFalse(found)
+	}
+}
+
+func TestDataCenterPromotionRulesUnknownMasterDataCenter(t *testing.T) {
+	master := newDataCenterInstance("master", "", inst.NeutralPromoteRule)
+	replicas := [](*inst.Instance){newDataCenterInstance("replica", "dc1", inst.NeutralPromoteRule)}
+	test.S(t).ExpectEquals(len(dataCenterPromotionRules(master, replicas)), 0)
+	test.S(t).ExpectEquals(len(dataCenterPromotionRules(nil, replicas)), 0)
+}
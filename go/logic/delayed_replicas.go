@@ -0,0 +1,81 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"strconv"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/inst"
+	orcraft "github.com/openark/orchestrator/go/raft"
+)
+
+// readDelayedReplicas returns the desired SQL delay, in seconds, of instances tagged by DelayedReplicaTagName
+func readDelayedReplicas() (delays map[inst.InstanceKey]uint, err error) {
+	delays = make(map[inst.InstanceKey]uint)
+	if config.Config.DelayedReplicaTagName == "" {
+		return delays, nil
+	}
+	tagged, err := inst.GetInstanceKeysByTag(&inst.Tag{TagName: config.Config.DelayedReplicaTagName})
+	if err != nil {
+		return delays, err
+	}
+	for _, instanceKey := range tagged.GetInstanceKeys() {
+		tag := &inst.Tag{TagName: config.Config.DelayedReplicaTagName}
+		if found, err := inst.ReadInstanceTag(&instanceKey, tag); err != nil || !found {
+			continue
+		}
+		seconds, err := strconv.ParseUint(tag.TagValue, 10, 32)
+		if err != nil {
+			log.Errorf("delayed replicas: %+v has invalid %s tag value: %q", instanceKey, tag.TagName, tag.TagValue)
+			continue
+		}
+		delays[instanceKey] = uint(seconds)
+	}
+	return delays, nil
+}
+
+// EnforceDelayedReplicas applies the desired SQL delay on delayed replicas (as designated by
+// DelayedReplicaTagName), and registers them as must_not candidates so that they are never promoted.
+func EnforceDelayedReplicas() error {
+	delays, err := readDelayedReplicas()
+	if err != nil {
+		return log.Errore(err)
+	}
+	for instanceKey, seconds := range delays {
+		instanceKey := instanceKey
+		candidate := inst.NewCandidateDatabaseInstance(&instanceKey, inst.MustNotPromoteRule).WithCurrentTime()
+		if orcraft.IsRaftEnabled() {
+			_, err = orcraft.PublishCommand("register-candidate", candidate)
+		} else {
+			err = inst.RegisterCandidateInstance(candidate)
+		}
+		log.Errore(err)
+
+		instance, found, err := inst.ReadInstance(&instanceKey)
+		if err != nil || !found || !instance.IsReplica() {
+			continue
+		}
+		if instance.SQLDelay == seconds {
+			continue
+		}
+		log.Infof("delayed replicas: %+v has SQL delay %d, expected %d", instanceKey, instance.SQLDelay, seconds)
+		log.Errore(inst.DelayReplication(&instanceKey, int(seconds)))
+	}
+	return nil
+}
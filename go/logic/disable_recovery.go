@@ -56,6 +56,10 @@ func IsRecoveryDisabled() (disabled bool, err error) {
 	return disabled, err
 }
 
+// frozenRecoveryMarker is a global_recovery_disable value which marks recoveries as disabled by a freeze
+// window, as opposed to by a user. It does not by itself disable recoveries.
+const frozenRecoveryMarker = 2
+
 // DisableRecovery ensures recoveries are disabled globally
 func DisableRecovery() error {
 	_, err := db.ExecOrchestrator(`
@@ -64,6 +68,14 @@ func DisableRecovery() error {
 		VALUES  (1)
 	`,
 	)
+	if err != nil {
+		return err
+	}
+	// Recoveries are now explicitly disabled; the end of a freeze window must not enable them
+	_, err = db.ExecOrchestrator(`
+		DELETE FROM global_recovery_disable WHERE disable_recovery=?
+	`, frozenRecoveryMarker,
+	)
 	return err
 }
 
@@ -77,6 +89,46 @@ func EnableRecovery() error {
 	return err
 }
 
+// IsRecoveryFrozen returns true if recoveries were disabled by a freeze window, and not enabled since
+func IsRecoveryFrozen() (frozen bool, err error) {
+	query := `
+		SELECT
+			COUNT(*) as mycount
+		FROM
+			global_recovery_disable
+		WHERE
+			disable_recovery=?
+		`
+	err = db.QueryOrchestrator(query, sqlutils.Args(frozenRecoveryMarker), func(m sqlutils.RowMap) error {
+		frozen = (m.GetInt("mycount") > 0)
+		return nil
+	})
+	if err != nil {
+		err = log.Errorf("recovery.IsRecoveryFrozen(): %v", err)
+	}
+	return frozen, err
+}
+
+// FreezeRecovery disables recoveries globally on behalf of a freeze window
+func FreezeRecovery() error {
+	_, err := db.ExecOrchestrator(`
+		INSERT IGNORE INTO global_recovery_disable
+			(disable_recovery)
+		VALUES  (1), (?)
+	`, frozenRecoveryMarker,
+	)
+	return err
+}
+
+// ThawRecovery enables recoveries globally, if they were disabled by FreezeRecovery and not by a user since
+func ThawRecovery() error {
+	frozen, err := IsRecoveryFrozen()
+	if err != nil || !frozen {
+		return err
+	}
+	return EnableRecovery()
+}
+
 func SetRecoveryDisabled(disabled bool) error {
 	if disabled {
 		return DisableRecovery()
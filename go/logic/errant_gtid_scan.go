@@ -0,0 +1,71 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"github.com/openark/orchestrator/go/inst"
+)
+
+// InstanceErrantGTID lists the binary logs containing the errant GTID of a single instance
+type InstanceErrantGTID struct {
+	Key           inst.InstanceKey
+	GtidErrant    string
+	ErrantBinlogs []string
+}
+
+// ErrantGTIDScan is the result of locating errant GTID across a cluster. It is partial when some
+// instances could not be scanned; these are listed in Errors.
+type ErrantGTIDScan struct {
+	ClusterName string
+	Instances   []InstanceErrantGTID
+	Errors      []InstanceFanOutError
+}
+
+// ScanClusterErrantGTID locates the binary logs containing errant GTID on all instances of given cluster
+// which are known to have errant GTID. Instances are scanned concurrently, within the limits of
+// ClusterFanOutInstanceTimeoutSeconds, ClusterFanOutTimeoutSeconds and MaxConcurrentReplicaOperations.
+func ScanClusterErrantGTID(clusterName string) (*ErrantGTIDScan, error) {
+	instances, err := inst.ReadClusterInstances(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	errantInstances := [](*inst.Instance){}
+	for _, instance := range instances {
+		if instance.GtidErrant != "" {
+			errantInstances = append(errantInstances, instance)
+		}
+	}
+	results, errors := fanOutInstances(errantInstances, func(instance *inst.Instance) (interface{}, error) {
+		return inst.LocateErrantGTID(&instance.Key)
+	})
+	scan := &ErrantGTIDScan{
+		ClusterName: clusterName,
+		Instances:   []InstanceErrantGTID{},
+		Errors:      errors,
+	}
+	for i, result := range results {
+		if result.Err != nil {
+			continue
+		}
+		scan.Instances = append(scan.Instances, InstanceErrantGTID{
+			Key:           errantInstances[i].Key,
+			GtidErrant:    errantInstances[i].GtidErrant,
+			ErrantBinlogs: result.Value.([]string),
+		})
+	}
+	return scan, nil
+}
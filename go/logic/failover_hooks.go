@@ -0,0 +1,84 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/openark/orchestrator/go/inst"
+	"github.com/openark/orchestrator/go/proxysql"
+)
+
+// FailoverHook integrates external routing (e.g. ProxySQL) with master failover. A hook's failure is
+// audited, and never aborts a recovery: by the time it runs, the old master is dead anyway.
+type FailoverHook interface {
+	// Name identifies the hook in recovery audit
+	Name() string
+	// PreFailover runs before a replacement master is chosen
+	PreFailover(failedMasterKey *inst.InstanceKey) error
+	// PostFailover runs once a replica is promoted in place of the failed master
+	PostFailover(promotedMasterKey *inst.InstanceKey, failedMasterKey *inst.InstanceKey) error
+}
+
+var failoverHooksMutex sync.Mutex
+var failoverHooks = []FailoverHook{}
+
+// RegisterFailoverHook adds a hook to run on each master failover, in order of registration
+func RegisterFailoverHook(hook FailoverHook) {
+	failoverHooksMutex.Lock()
+	defer failoverHooksMutex.Unlock()
+
+	failoverHooks = append(failoverHooks, hook)
+}
+
+var initFailoverHooksOnce sync.Once
+
+// InitFailoverHooks sets up the failover hooks orchestrator ships with, i.e. ProxySQL when configured, and
+// registers them. Both the service and CLI commands (e.g. recover, graceful-master-takeover) call it, so
+// that failovers update routing either way. Calling it more than once has no further effect.
+func InitFailoverHooks() {
+	initFailoverHooksOnce.Do(func() {
+		proxysql.InitHook()
+		if hook := proxysql.GetHook(); hook.IsConfigured() {
+			RegisterFailoverHook(hook)
+		}
+	})
+}
+
+func getFailoverHooks() []FailoverHook {
+	failoverHooksMutex.Lock()
+	defer failoverHooksMutex.Unlock()
+
+	return append([]FailoverHook{}, failoverHooks...)
+}
+
+// runPreFailoverHooks runs all registered hooks' PreFailover, auditing the outcome of each
+func runPreFailoverHooks(topologyRecovery *TopologyRecovery, failedMasterKey *inst.InstanceKey) {
+	for _, hook := range getFailoverHooks() {
+		err := hook.PreFailover(failedMasterKey)
+		AuditTopologyRecovery(topologyRecovery, fmt.Sprintf("RecoverDeadMaster: %s pre-failover on %+v: success=%t", hook.Name(), *failedMasterKey, (err == nil)))
+	}
+}
+
+// runPostFailoverHooks runs all registered hooks' PostFailover, auditing the outcome of each
+func runPostFailoverHooks(topologyRecovery *TopologyRecovery, promotedMasterKey *inst.InstanceKey, failedMasterKey *inst.InstanceKey) {
+	for _, hook := range getFailoverHooks() {
+		err := hook.PostFailover(promotedMasterKey, failedMasterKey)
+		AuditTopologyRecovery(topologyRecovery, fmt.Sprintf("- RecoverDeadMaster: %s post-failover to %+v: success=%t", hook.Name(), *promotedMasterKey, (err == nil)))
+	}
+}
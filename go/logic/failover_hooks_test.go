@@ -0,0 +1,101 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/openark/golib/log"
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+func init() {
+	config.Config.HostnameResolveMethod = "none"
+	config.MarkConfigurationLoaded()
+	log.SetLevel(log.ERROR)
+}
+
+// testFailoverHook records its invocations, and fails them when err is set, as a ProxySQL admin error would
+type testFailoverHook struct {
+	name          string
+	err           error
+	preFailovers  []inst.InstanceKey
+	postFailovers []inst.InstanceKey
+}
+
+func (this *testFailoverHook) Name() string {
+	return this.name
+}
+
+func (this *testFailoverHook) PreFailover(failedMasterKey *inst.InstanceKey) error {
+	this.preFailovers = append(this.preFailovers, *failedMasterKey)
+	return this.err
+}
+
+func (this *testFailoverHook) PostFailover(promotedMasterKey *inst.InstanceKey, failedMasterKey *inst.InstanceKey) error {
+	this.postFailovers = append(this.postFailovers, *promotedMasterKey)
+	return this.err
+}
+
+func TestFailoverHooksErrorDoesNotAbortRecovery(t *testing.T) {
+	defer func(hooks []FailoverHook) { failoverHooks = hooks }(failoverHooks)
+	failoverHooks = []FailoverHook{}
+
+	failedMasterKey := inst.InstanceKey{Hostname: "failed-master", Port: 3306}
+	promotedMasterKey := inst.InstanceKey{Hostname: "promoted-master", Port: 3306}
+	failing := &testFailoverHook{name: "ProxySQL", err: fmt.Errorf("ProxySQL Admin Error: connection refused")}
+	following := &testFailoverHook{name: "routing"}
+	RegisterFailoverHook(failing)
+	RegisterFailoverHook(following)
+
+	runPreFailoverHooks(nil, &failedMasterKey)
+	runPostFailoverHooks(nil, &promotedMasterKey, &failedMasterKey)
+
+	test.S(t).ExpectEquals(len(failing.preFailovers), 1)
+	test.S(t).ExpectEquals(len(failing.postFailovers), 1)
+	// A failing hook neither panics nor prevents the hooks following it
+	test.S(t).ExpectEquals(len(following.preFailovers), 1)
+	test.S(t).ExpectTrue(following.preFailovers[0].Equals(&failedMasterKey))
+	test.S(t).ExpectEquals(len(following.postFailovers), 1)
+	test.S(t).ExpectTrue(following.postFailovers[0].Equals(&promotedMasterKey))
+}
+
+func TestFailoverHooksNoneRegistered(t *testing.T) {
+	defer func(hooks []FailoverHook) { failoverHooks = hooks }(failoverHooks)
+	failoverHooks = []FailoverHook{}
+
+	failedMasterKey := inst.InstanceKey{Hostname: "failed-master", Port: 3306}
+	runPreFailoverHooks(nil, &failedMasterKey)
+	test.S(t).ExpectEquals(len(getFailoverHooks()), 0)
+}
+
+func TestInitFailoverHooks(t *testing.T) {
+	defer func(hooks []FailoverHook) { failoverHooks = hooks }(failoverHooks)
+	failoverHooks = []FailoverHook{}
+	defer func(address string) { config.Config.ProxySQLAdminAddress = address }(config.Config.ProxySQLAdminAddress)
+	config.Config.ProxySQLAdminAddress = "proxysql.example.com"
+
+	// the service and CLI both initialize hooks; ProxySQL is registered once
+	InitFailoverHooks()
+	InitFailoverHooks()
+	hooks := getFailoverHooks()
+	test.S(t).ExpectEquals(len(hooks), 1)
+	test.S(t).ExpectEquals(hooks[0].Name(), "ProxySQL")
+}
@@ -0,0 +1,216 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openark/orchestrator/go/inst"
+)
+
+// InstanceFailoverReadiness summarizes the failover related settings of a single instance
+type InstanceFailoverReadiness struct {
+	Key                          inst.InstanceKey
+	IsMaster                     bool
+	IsLastCheckValid             bool
+	GTIDMode                     string
+	UsingGTID                    bool
+	UsingPseudoGTID              bool
+	GtidErrant                   string
+	Binlog_format                string
+	LogBinEnabled                bool
+	LogReplicationUpdatesEnabled bool
+	SemiSyncMasterEnabled        bool
+	SemiSyncReplicaEnabled       bool
+	PromotionRule                inst.CandidatePromotionRule
+	IsValidCandidate             bool
+}
+
+// FailoverReadiness is a cluster-wide report of whether an automated master failover is expected to succeed
+type FailoverReadiness struct {
+	ClusterName                string
+	ClusterAlias               string
+	MasterKey                  inst.InstanceKey
+	CoMasterKey                inst.InstanceKey // the passive co-master, in a master-master setup
+	HasAutomatedMasterRecovery bool
+	Instances                  []InstanceFailoverReadiness
+	SafeForAutoFailover        bool
+	Reasons                    []string
+	RefreshErrors              []InstanceFanOutError
+}
+
+func (this *FailoverReadiness) addReason(format string, args ...interface{}) {
+	this.Reasons = append(this.Reasons, fmt.Sprintf(format, args...))
+}
+
+// isValidFailoverCandidate returns true when given replica may be promoted in place of its master
+func isValidFailoverCandidate(replica *inst.Instance) bool {
+	if !replica.IsLastCheckValid {
+		return false
+	}
+	if !replica.LogBinEnabled || !replica.LogReplicationUpdatesEnabled {
+		return false
+	}
+	if replica.IsBinlogServer() {
+		return false
+	}
+	return !inst.IsBannedFromBeingCandidateReplica(replica)
+}
+
+// AssessFailoverReadiness checks GTID, errant GTID, semi-sync, binlog format and promotion rule settings
+// across given cluster, and reports whether the cluster is safe for automated master failover, along
+// with the reasons it is not. With refresh, instances are read directly from the servers rather than
+// from the backend; instances which could not be read are assessed by their backend copy, and make
+// the cluster unsafe.
+func AssessFailoverReadiness(clusterName string, refresh bool) (*FailoverReadiness, error) {
+	clusterInfo, err := inst.ReadClusterInfo(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	instances, err := inst.ReadClusterInstances(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	readiness := &FailoverReadiness{
+		ClusterName:                clusterInfo.ClusterName,
+		ClusterAlias:               clusterInfo.ClusterAlias,
+		HasAutomatedMasterRecovery: clusterInfo.HasAutomatedMasterRecovery,
+		Instances:                  []InstanceFailoverReadiness{},
+	}
+	if !readiness.HasAutomatedMasterRecovery {
+		readiness.addReason("cluster does not match RecoverMasterClusterFilters")
+	}
+	if disabled, err := IsRecoveryDisabled(); err != nil {
+		return nil, err
+	} else if disabled {
+		readiness.addReason("recoveries are disabled globally")
+	}
+	if disable, err := inst.ReadClusterRecoveryDisable(clusterInfo.ClusterName); err != nil {
+		return nil, err
+	} else if disable != nil {
+		readiness.addReason("recoveries are disabled on this cluster by %s: %s", disable.Owner, disable.Reason)
+	}
+
+	if refresh {
+		instances, readiness.RefreshErrors = refreshInstances(instances)
+		for _, refreshError := range readiness.RefreshErrors {
+			readiness.addReason("could not refresh %s: %s", refreshError.Key.DisplayString(), refreshError.Error)
+		}
+	}
+	assessFailoverReadiness(readiness, instances)
+	readiness.SafeForAutoFailover = len(readiness.Reasons) == 0
+	return readiness, nil
+}
+
+// assessFailoverReadiness reports on given instances of a cluster, adding the reasons the cluster is not
+// safe for automated master failover
+func assessFailoverReadiness(readiness *FailoverReadiness, instances [](*inst.Instance)) {
+	heads := inst.GetClusterHeads(instances)
+	master := heads.Active
+	if master == nil || heads.CountIndependentMasters > 1 {
+		readiness.addReason("expected a single master, found %d", heads.CountIndependentMasters)
+	}
+	if master != nil {
+		readiness.MasterKey = master.Key
+		if master.IsDowntimed {
+			readiness.addReason("master %+v is downtimed", master.Key)
+		}
+	}
+	if heads.Passive != nil {
+		readiness.CoMasterKey = heads.Passive.Key
+		if heads.BothWritable {
+			readiness.addReason("co-masters %+v and %+v are both writable", master.Key, heads.Passive.Key)
+		}
+	}
+
+	countReplicas := 0
+	countCandidates := 0
+	countGTIDReplicas := 0
+	countPseudoGTIDReplicas := 0
+	countSemiSyncReplicas := 0
+	binlogFormats := make(map[string]bool)
+	for _, instance := range instances {
+		isDirectReplica := master != nil && master.IsMasterOf(instance)
+		instanceReadiness := InstanceFailoverReadiness{
+			Key:                          instance.Key,
+			IsMaster:                     master != nil && instance.Key.Equals(&master.Key),
+			IsLastCheckValid:             instance.IsLastCheckValid,
+			GTIDMode:                     instance.GTIDMode,
+			UsingGTID:                    instance.UsingGTID(),
+			UsingPseudoGTID:              instance.UsingPseudoGTID,
+			GtidErrant:                   instance.GtidErrant,
+			Binlog_format:                instance.Binlog_format,
+			LogBinEnabled:                instance.LogBinEnabled,
+			LogReplicationUpdatesEnabled: instance.LogReplicationUpdatesEnabled,
+			SemiSyncMasterEnabled:        instance.SemiSyncMasterEnabled,
+			SemiSyncReplicaEnabled:       instance.SemiSyncReplicaEnabled,
+			PromotionRule:                instance.PromotionRule,
+			IsValidCandidate:             isDirectReplica && isValidFailoverCandidate(instance),
+		}
+		readiness.Instances = append(readiness.Instances, instanceReadiness)
+
+		if instance.GtidErrant != "" {
+			readiness.addReason("%+v has errant GTID: %s", instance.Key, instance.GtidErrant)
+		}
+		if !isDirectReplica {
+			continue
+		}
+		countReplicas++
+		if instanceReadiness.IsValidCandidate {
+			countCandidates++
+		}
+		if instance.UsingGTID() {
+			countGTIDReplicas++
+		}
+		if instance.UsingPseudoGTID {
+			countPseudoGTIDReplicas++
+		}
+		if instance.SemiSyncReplicaEnabled {
+			countSemiSyncReplicas++
+		}
+		if instance.LogBinEnabled {
+			binlogFormats[instance.Binlog_format] = true
+		}
+	}
+
+	if master != nil {
+		if countReplicas == 0 {
+			readiness.addReason("master %+v has no replicas", master.Key)
+		} else if countCandidates == 0 {
+			readiness.addReason("none of the replicas of %+v is a valid promotion candidate", master.Key)
+		}
+		if countGTIDReplicas > 0 && countGTIDReplicas < countReplicas && countPseudoGTIDReplicas < countReplicas {
+			readiness.addReason("%d out of %d replicas use GTID, and Pseudo-GTID is not available on all replicas", countGTIDReplicas, countReplicas)
+		}
+		if countGTIDReplicas == 0 && countPseudoGTIDReplicas < countReplicas && countReplicas > 1 {
+			readiness.addReason("replicas use neither GTID nor Pseudo-GTID; siblings may be lost upon failover")
+		}
+		if master.SemiSyncMasterEnabled && uint(countSemiSyncReplicas) < master.SemiSyncMasterWaitForReplicaCount {
+			readiness.addReason("master %+v waits for %d semi-sync replicas, only %d are semi-sync enabled", master.Key, master.SemiSyncMasterWaitForReplicaCount, countSemiSyncReplicas)
+		}
+	}
+	if len(binlogFormats) > 1 {
+		formats := []string{}
+		for format := range binlogFormats {
+			formats = append(formats, format)
+		}
+		sort.Strings(formats)
+		readiness.addReason("replicas use mixed binlog formats: %s", strings.Join(formats, ", "))
+	}
+}
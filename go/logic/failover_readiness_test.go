@@ -0,0 +1,144 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"strings"
+	"testing"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/inst"
+	"github.com/openark/orchestrator/go/topologysim"
+)
+
+var (
+	readinessMasterKey   = inst.InstanceKey{Hostname: "master", Port: 3306}
+	readinessReplicaKeys = []inst.InstanceKey{
+		{Hostname: "replica1", Port: 3306},
+		{Hostname: "replica2", Port: 3306},
+		{Hostname: "replica3", Port: 3306},
+	}
+)
+
+// newReadinessTopology returns a writable master with read-only, GTID replicas
+func newReadinessTopology(t *testing.T) *topologysim.Topology {
+	topology := topologysim.NewTopology()
+	_, err := topology.AddMaster(readinessMasterKey.Hostname, readinessMasterKey.Port)
+	test.S(t).ExpectNil(err)
+	for _, key := range readinessReplicaKeys {
+		replica, err := topology.AddReplica(key.Hostname, key.Port, readinessMasterKey)
+		test.S(t).ExpectNil(err)
+		replica.ReadOnly = true
+		replica.UsingOracleGTID = true
+	}
+	return topology
+}
+
+func assessTopology(topology *topologysim.Topology) *FailoverReadiness {
+	instances := [](*inst.Instance){topology.Instance(readinessMasterKey)}
+	for _, key := range readinessReplicaKeys {
+		instances = append(instances, topology.Instance(key))
+	}
+	readiness := &FailoverReadiness{}
+	assessFailoverReadiness(readiness, instances)
+	return readiness
+}
+
+func expectReason(t *testing.T, readiness *FailoverReadiness, substring string) {
+	for _, reason := range readiness.Reasons {
+		if strings.Contains(reason, substring) {
+			return
+		}
+	}
+	t.Errorf("expected a reason containing %q; got %+v", substring, readiness.Reasons)
+}
+
+func TestAssessFailoverReadiness(t *testing.T) {
+	readiness := assessTopology(newReadinessTopology(t))
+	test.S(t).ExpectEquals(len(readiness.Reasons), 0)
+	test.S(t).ExpectTrue(readiness.MasterKey.Equals(&readinessMasterKey))
+	test.S(t).ExpectEquals(len(readiness.Instances), 4)
+	test.S(t).ExpectTrue(readiness.Instances[0].IsMaster)
+	test.S(t).ExpectFalse(readiness.Instances[0].IsValidCandidate)
+	test.S(t).ExpectTrue(readiness.Instances[1].IsValidCandidate)
+}
+
+func TestAssessFailoverReadinessMixedGTID(t *testing.T) {
+	topology := newReadinessTopology(t)
+	topology.Instance(readinessReplicaKeys[0]).UsingOracleGTID = false
+	readiness := assessTopology(topology)
+	test.S(t).ExpectEquals(len(readiness.Reasons), 1)
+	expectReason(t, readiness, "2 out of 3 replicas use GTID")
+
+	// Pseudo-GTID on all replicas makes up for it
+	for _, key := range readinessReplicaKeys {
+		topology.Instance(key).UsingPseudoGTID = true
+	}
+	test.S(t).ExpectEquals(len(assessTopology(topology).Reasons), 0)
+}
+
+func TestAssessFailoverReadinessSemiSync(t *testing.T) {
+	topology := newReadinessTopology(t)
+	master := topology.Instance(readinessMasterKey)
+	master.SemiSyncMasterEnabled = true
+	master.SemiSyncMasterWaitForReplicaCount = 2
+	topology.Instance(readinessReplicaKeys[0]).SemiSyncReplicaEnabled = true
+	readiness := assessTopology(topology)
+	test.S(t).ExpectEquals(len(readiness.Reasons), 1)
+	expectReason(t, readiness, "waits for 2 semi-sync replicas, only 1 are semi-sync enabled")
+
+	topology.Instance(readinessReplicaKeys[1]).SemiSyncReplicaEnabled = true
+	test.S(t).ExpectEquals(len(assessTopology(topology).Reasons), 0)
+}
+
+func TestAssessFailoverReadinessNoCandidates(t *testing.T) {
+	topology := newReadinessTopology(t)
+	for _, key := range readinessReplicaKeys {
+		test.S(t).ExpectNil(topology.SetPromotionRule(key, inst.MustNotPromoteRule))
+	}
+	readiness := assessTopology(topology)
+	test.S(t).ExpectEquals(len(readiness.Reasons), 1)
+	expectReason(t, readiness, "none of the replicas of master:3306 is a valid promotion candidate")
+}
+
+func TestAssessFailoverReadinessCoMasters(t *testing.T) {
+	topology := newReadinessTopology(t)
+	_, err := topology.MakeCoMaster(readinessReplicaKeys[0])
+	test.S(t).ExpectNil(err)
+	topology.Instance(readinessMasterKey).UsingOracleGTID = true
+
+	readiness := assessTopology(topology)
+	test.S(t).ExpectEquals(len(readiness.Reasons), 0)
+	// The writable co-master is the cluster's master; the other co-master is a candidate replica
+	test.S(t).ExpectTrue(readiness.MasterKey.Equals(&readinessMasterKey))
+	test.S(t).ExpectTrue(readiness.CoMasterKey.Equals(&readinessReplicaKeys[0]))
+	test.S(t).ExpectTrue(readiness.Instances[0].IsMaster)
+	test.S(t).ExpectFalse(readiness.Instances[1].IsMaster)
+	test.S(t).ExpectTrue(readiness.Instances[1].IsValidCandidate)
+
+	// Either co-master may be the writable one
+	topology.Instance(readinessMasterKey).ReadOnly = true
+	topology.Instance(readinessReplicaKeys[0]).ReadOnly = false
+	readiness = assessTopology(topology)
+	test.S(t).ExpectTrue(readiness.MasterKey.Equals(&readinessReplicaKeys[0]))
+	test.S(t).ExpectTrue(readiness.CoMasterKey.Equals(&readinessMasterKey))
+
+	topology.Instance(readinessMasterKey).ReadOnly = false
+	readiness = assessTopology(topology)
+	test.S(t).ExpectEquals(len(readiness.Reasons), 1)
+	expectReason(t, readiness, "are both writable")
+}
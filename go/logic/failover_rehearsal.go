@@ -0,0 +1,147 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/inst"
+	"github.com/openark/orchestrator/go/topologysim"
+)
+
+// FailoverRehearsal is the outcome of a simulated DeadMaster on a cluster: the replica which would be
+// promoted, what would become of the other replicas, and the steps a real recovery would take
+type FailoverRehearsal struct {
+	ClusterName               string
+	ClusterAlias              string
+	FailedMasterKey           inst.InstanceKey
+	Readiness                 *FailoverReadiness
+	WouldRecoverAutomatically bool
+	PromotedReplicaKey        *inst.InstanceKey
+	ReplacementKey            *inst.InstanceKey
+	RelocatedReplicas         []inst.InstanceKey
+	LostReplicas              []inst.InstanceKey
+	Steps                     []string
+}
+
+func (this *FailoverRehearsal) addStep(format string, args ...interface{}) {
+	this.Steps = append(this.Steps, fmt.Sprintf(format, args...))
+}
+
+func (this *FailoverRehearsal) addProcessesSteps(name string, processes []string) {
+	for _, process := range processes {
+		this.addStep("%s: run %s", name, process)
+	}
+}
+
+// RehearseDeadMasterFailover simulates a DeadMaster on given cluster. The cluster's instances, as known to
+// orchestrator along with their promotion rules, are cloned into a topologysim topology, where the failover is
+// replayed. Nothing is changed on the topology nor in the backend: replication is not stopped, no hooks are
+// executed and no recovery is registered. This is intended for game-day exercises, to review which replica
+// would be promoted and how.
+func RehearseDeadMasterFailover(clusterName string) (*FailoverRehearsal, error) {
+	masters, err := inst.ReadClusterMaster(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if len(masters) == 0 {
+		return nil, fmt.Errorf("RehearseDeadMasterFailover: cannot find master of cluster %s", clusterName)
+	}
+	master := masters[0]
+	readiness, err := AssessFailoverReadiness(clusterName, false)
+	if err != nil {
+		return nil, err
+	}
+	rehearsal := &FailoverRehearsal{
+		ClusterName:               readiness.ClusterName,
+		ClusterAlias:              readiness.ClusterAlias,
+		FailedMasterKey:           master.Key,
+		Readiness:                 readiness,
+		WouldRecoverAutomatically: readiness.HasAutomatedMasterRecovery && len(readiness.Reasons) == 0,
+		RelocatedReplicas:         []inst.InstanceKey{},
+		LostReplicas:              []inst.InstanceKey{},
+		Steps:                     []string{},
+	}
+	rehearsal.addStep("detect %s on %+v", inst.DeadMaster, master.Key)
+	if rehearsal.WouldRecoverAutomatically {
+		rehearsal.addStep("automated recovery would be attempted")
+	} else {
+		rehearsal.addStep("automated recovery would not be attempted: %s", strings.Join(readiness.Reasons, "; "))
+	}
+	rehearsal.addProcessesSteps("PreFailoverProcesses", config.Config.PreFailoverProcesses)
+
+	instances, err := inst.ReadClusterInstances(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	topology, err := topologysim.CloneTopology(instances)
+	if err != nil {
+		return nil, err
+	}
+	result, err := topology.Failover(master.Key)
+	if err != nil {
+		rehearsal.addStep("no replica would be promoted: %+v", err)
+		rehearsal.addProcessesSteps("PostUnsuccessfulFailoverProcesses", config.Config.PostUnsuccessfulFailoverProcesses)
+		return rehearsal, nil
+	}
+	candidate := result.PromotedReplica
+	rehearsal.PromotedReplicaKey = &candidate.Key
+	rehearsal.RelocatedReplicas = append(rehearsal.RelocatedReplicas, result.RelocatedKeys...)
+	rehearsal.LostReplicas = append(rehearsal.LostReplicas, result.LostKeys...)
+	rehearsal.addStep("regroup replicas of %+v: promote %+v (promotion rule: %s)", master.Key, candidate.Key, candidate.PromotionRule)
+	for _, key := range rehearsal.RelocatedReplicas {
+		rehearsal.addStep("relocate %+v below %+v", key, candidate.Key)
+	}
+	for _, key := range rehearsal.LostReplicas {
+		rehearsal.addStep("lose %+v: cannot replicate from %+v", key, candidate.Key)
+	}
+
+	analysisEntry := &inst.ReplicationAnalysis{
+		AnalyzedInstanceKey:        master.Key,
+		AnalyzedInstanceDataCenter: master.DataCenter,
+		AnalyzedInstanceRegion:     master.Region,
+	}
+	if satisfied, reason := MasterFailoverGeographicConstraintSatisfied(analysisEntry, candidate); !satisfied {
+		rehearsal.PromotedReplicaKey = nil
+		rehearsal.addStep("abort promotion of %+v: %s", candidate.Key, reason)
+		rehearsal.addProcessesSteps("PostUnsuccessfulFailoverProcesses", config.Config.PostUnsuccessfulFailoverProcesses)
+		return rehearsal, nil
+	}
+
+	if candidate.PromotionRule != inst.MustPromoteRule && candidate.PromotionRule != inst.PreferPromoteRule {
+		candidateInstances, _ := inst.ReadClusterCandidateInstances(clusterName)
+		// Following SuggestReplacementForPromotedReplica: a valid preferred candidate in the failed master's data center
+		for _, candidateInstance := range candidateInstances {
+			if candidateInstance.Key.Equals(&candidate.Key) || candidateInstance.Key.Equals(&master.Key) {
+				continue
+			}
+			if !candidateInstance.IsLastCheckValid {
+				continue
+			}
+			if candidateInstance.DataCenter == master.DataCenter && candidateInstance.PromotionRule == inst.PreferPromoteRule {
+				rehearsal.ReplacementKey = &candidateInstance.Key
+				rehearsal.addStep("replace promoted replica %+v with preferred candidate %+v", candidate.Key, candidateInstance.Key)
+				break
+			}
+		}
+	}
+	rehearsal.addProcessesSteps("PostMasterFailoverProcesses", config.Config.PostMasterFailoverProcesses)
+	rehearsal.addProcessesSteps("PostFailoverProcesses", config.Config.PostFailoverProcesses)
+	return rehearsal, nil
+}
@@ -0,0 +1,87 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openark/orchestrator/go/inst"
+)
+
+// DangerousOperationError is returned when a dangerous operation fails its guardrails
+type DangerousOperationError struct {
+	Operation   string
+	ClusterName string
+	Reasons     []string
+}
+
+func (this *DangerousOperationError) Error() string {
+	return fmt.Sprintf("%s on %s refused: %s", this.Operation, this.ClusterName, strings.Join(this.Reasons, "; "))
+}
+
+// forceFailoverConfirmationRefusal returns why given confirmation does not confirm an operation on given
+// cluster, or "" when it does: the confirmation must echo the cluster name or alias
+func forceFailoverConfirmationRefusal(clusterInfo *inst.ClusterInfo, confirmation string) string {
+	if confirmation == "" {
+		return "confirmation required: provide the cluster name or alias"
+	}
+	if confirmation != clusterInfo.ClusterName && confirmation != clusterInfo.ClusterAlias {
+		return fmt.Sprintf("confirmation %s matches neither cluster name nor alias", confirmation)
+	}
+	return ""
+}
+
+// CheckForceFailoverGuardrails validates a forced failover/takeover may run on given cluster: the
+// confirmation must echo the cluster name or alias, recoveries must not be disabled globally, and
+// no other recovery may be active on the cluster. Break-glass skips the latter readiness checks, but
+// never the confirmation. A *DangerousOperationError lists all failed checks.
+func CheckForceFailoverGuardrails(operation string, clusterName string, confirmation string, breakGlass bool) error {
+	guardrailsError := &DangerousOperationError{Operation: operation, ClusterName: clusterName}
+
+	clusterInfo, err := inst.ReadClusterInfo(clusterName)
+	if err != nil {
+		return err
+	}
+	if refusal := forceFailoverConfirmationRefusal(clusterInfo, confirmation); refusal != "" {
+		guardrailsError.Reasons = append(guardrailsError.Reasons, refusal)
+	}
+	if breakGlass {
+		if len(guardrailsError.Reasons) > 0 {
+			return guardrailsError
+		}
+		inst.AuditOperation(operation, nil, fmt.Sprintf("cluster %s: readiness checks skipped via break-glass", clusterName))
+		return nil
+	}
+	if disabled, err := IsRecoveryDisabled(); err != nil {
+		return err
+	} else if disabled {
+		guardrailsError.Reasons = append(guardrailsError.Reasons, "recoveries are disabled globally")
+	}
+	activeRecoveries, err := ReadActiveClusterRecovery(clusterName)
+	if err != nil {
+		return err
+	}
+	for _, recovery := range activeRecoveries {
+		guardrailsError.Reasons = append(guardrailsError.Reasons, fmt.Sprintf("recovery %s is active on %+v", recovery.UID, recovery.AnalysisEntry.AnalyzedInstanceKey))
+	}
+
+	if len(guardrailsError.Reasons) > 0 {
+		return guardrailsError
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"testing"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+func TestForceFailoverConfirmationRefusal(t *testing.T) {
+	clusterInfo := &inst.ClusterInfo{ClusterName: "db1:3306", ClusterAlias: "mycluster"}
+	test.S(t).ExpectEquals(forceFailoverConfirmationRefusal(clusterInfo, "db1:3306"), "")
+	test.S(t).ExpectEquals(forceFailoverConfirmationRefusal(clusterInfo, "mycluster"), "")
+	test.S(t).ExpectEquals(forceFailoverConfirmationRefusal(clusterInfo, ""), "confirmation required: provide the cluster name or alias")
+	test.S(t).ExpectEquals(forceFailoverConfirmationRefusal(clusterInfo, "othercluster"), "confirmation othercluster matches neither cluster name nor alias")
+
+	clusterInfo = &inst.ClusterInfo{ClusterName: "db1:3306"}
+	test.S(t).ExpectNotEquals(forceFailoverConfirmationRefusal(clusterInfo, ""), "")
+}
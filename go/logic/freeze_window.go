@@ -0,0 +1,129 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/inst"
+	orcraft "github.com/openark/orchestrator/go/raft"
+)
+
+// freezeWindowsMutex serializes reviews of freeze windows
+var freezeWindowsMutex sync.Mutex
+
+// FreezeWindowState is a configured freeze window, and whether it is now active
+type FreezeWindowState struct {
+	config.FreezeWindow
+	IsActive bool
+}
+
+// FreezeWindowsStatus describes the freeze windows, and whether global recoveries are disabled by them
+type FreezeWindowsStatus struct {
+	Windows          []FreezeWindowState
+	IsRecoveryFrozen bool
+}
+
+// activeFreezeWindowNames returns the names of freeze windows active at given time
+func activeFreezeWindowNames(t time.Time) (names []string, err error) {
+	names = []string{}
+	for i := range config.Config.FailoverFreezeWindows {
+		window := &config.Config.FailoverFreezeWindows[i]
+		active, err := window.IsActive(t)
+		if err != nil {
+			return names, err
+		}
+		if active {
+			names = append(names, fmt.Sprintf("%d:%s", i, window.Name))
+		}
+	}
+	return names, nil
+}
+
+// ReadFreezeWindowsStatus returns the configured freeze windows and their current state
+func ReadFreezeWindowsStatus() (status *FreezeWindowsStatus, err error) {
+	status = &FreezeWindowsStatus{Windows: []FreezeWindowState{}}
+	now := time.Now()
+	for _, window := range config.Config.FailoverFreezeWindows {
+		active, err := window.IsActive(now)
+		if err != nil {
+			return status, err
+		}
+		status.Windows = append(status.Windows, FreezeWindowState{FreezeWindow: window, IsActive: active})
+	}
+	if status.IsRecoveryFrozen, err = IsRecoveryFrozen(); err != nil {
+		return status, err
+	}
+	return status, nil
+}
+
+// ReviewFreezeWindows disables global recoveries as a freeze window begins, and enables them once no window
+// is active. Overlapping or adjacent windows make for a single freeze. Recoveries already disabled by a user
+// as a freeze begins, or disabled by a user during a freeze, are left disabled once the freeze is over.
+// This runs on the leader.
+func ReviewFreezeWindows() error {
+	if len(config.Config.FailoverFreezeWindows) == 0 {
+		return nil
+	}
+	freezeWindowsMutex.Lock()
+	defer freezeWindowsMutex.Unlock()
+
+	activeNames, err := activeFreezeWindowNames(time.Now())
+	if err != nil {
+		return log.Errore(err)
+	}
+	frozen, err := IsRecoveryFrozen()
+	if err != nil {
+		return log.Errore(err)
+	}
+	if len(activeNames) > 0 && !frozen {
+		disabled, err := IsRecoveryDisabled()
+		if err != nil {
+			return log.Errore(err)
+		}
+		if disabled {
+			// Disabled by a user: not ours to enable when the window is over
+			return nil
+		}
+		if orcraft.IsRaftEnabled() {
+			_, err = orcraft.PublishCommand("freeze-global-recoveries", 0)
+		} else {
+			err = FreezeRecovery()
+		}
+		if err != nil {
+			return log.Errore(err)
+		}
+		inst.AuditOperation("freeze-window-start", nil, fmt.Sprintf("Globally disabled recoveries; active freeze windows: %s", strings.Join(activeNames, ", ")))
+	}
+	if len(activeNames) == 0 && frozen {
+		if orcraft.IsRaftEnabled() {
+			_, err = orcraft.PublishCommand("thaw-global-recoveries", 0)
+		} else {
+			err = ThawRecovery()
+		}
+		if err != nil {
+			return log.Errore(err)
+		}
+		inst.AuditOperation("freeze-window-end", nil, "Globally enabled recoveries; no active freeze windows")
+	}
+	return nil
+}
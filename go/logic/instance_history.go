@@ -0,0 +1,237 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/golib/sqlutils"
+	"github.com/openark/orchestrator/go/db"
+	"github.com/openark/orchestrator/go/discovery"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+type InstanceHistoryEntryType string
+
+const (
+	AuditInstanceHistoryEntry            InstanceHistoryEntryType = "audit"
+	FailureDetectionInstanceHistoryEntry InstanceHistoryEntryType = "failure-detection"
+	RecoveryInstanceHistoryEntry         InstanceHistoryEntryType = "recovery"
+	PromotionInstanceHistoryEntry        InstanceHistoryEntryType = "promotion"
+	MaintenanceInstanceHistoryEntry      InstanceHistoryEntryType = "maintenance"
+	DowntimeInstanceHistoryEntry         InstanceHistoryEntryType = "downtime"
+	DiscoveryFailureInstanceHistoryEntry InstanceHistoryEntryType = "discovery-failure"
+)
+
+const maxInstanceHistoryLimit = 1000
+
+// InstanceHistoryEntry is a single entry in an instance's history
+type InstanceHistoryEntry struct {
+	Type      InstanceHistoryEntryType
+	Timestamp string
+	Summary   string
+	Message   string
+}
+
+// instanceHistorySource is a query reading history entries of a single type for a given instance, most
+// recent first, and a function formatting their message
+type instanceHistorySource struct {
+	query   string
+	message func(m sqlutils.RowMap) string
+}
+
+var instanceHistorySources = map[InstanceHistoryEntryType]instanceHistorySource{
+	AuditInstanceHistoryEntry: {query: `
+		select
+			audit_timestamp as entry_timestamp,
+			audit_type as summary,
+			message
+		from
+			audit
+		where
+			hostname = ?
+			and port = ?
+		order by
+			audit_timestamp desc, audit_id desc
+		limit ?
+		`,
+		message: func(m sqlutils.RowMap) string {
+			return m.GetString("message")
+		},
+	},
+	FailureDetectionInstanceHistoryEntry: {query: `
+		select
+			start_active_period as entry_timestamp,
+			analysis as summary,
+			count_affected_slaves
+		from
+			topology_failure_detection
+		where
+			hostname = ?
+			and port = ?
+		order by
+			start_active_period desc, detection_id desc
+		limit ?
+		`,
+		message: func(m sqlutils.RowMap) string {
+			return fmt.Sprintf("affected replicas: %d", m.GetInt("count_affected_slaves"))
+		},
+	},
+	RecoveryInstanceHistoryEntry: {query: `
+		select
+			start_active_period as entry_timestamp,
+			analysis as summary,
+			is_successful,
+			ifnull(successor_hostname, '') as successor_hostname,
+			ifnull(successor_port, 0) as successor_port
+		from
+			topology_recovery
+		where
+			hostname = ?
+			and port = ?
+		order by
+			start_active_period desc, recovery_id desc
+		limit ?
+		`,
+		message: func(m sqlutils.RowMap) string {
+			if !m.GetBool("is_successful") {
+				return "failed instance; recovery unsuccessful"
+			}
+			successorKey := inst.InstanceKey{Hostname: m.GetString("successor_hostname"), Port: m.GetInt("successor_port")}
+			return fmt.Sprintf("failed instance; successor: %s", successorKey.DisplayString())
+		},
+	},
+	PromotionInstanceHistoryEntry: {query: `
+		select
+			start_active_period as entry_timestamp,
+			analysis as summary,
+			hostname as failed_hostname,
+			port as failed_port
+		from
+			topology_recovery
+		where
+			successor_hostname = ?
+			and successor_port = ?
+		order by
+			start_active_period desc, recovery_id desc
+		limit ?
+		`,
+		message: func(m sqlutils.RowMap) string {
+			failedKey := inst.InstanceKey{Hostname: m.GetString("failed_hostname"), Port: m.GetInt("failed_port")}
+			return fmt.Sprintf("promoted as successor of %s", failedKey.DisplayString())
+		},
+	},
+	MaintenanceInstanceHistoryEntry: {query: `
+		select
+			begin_timestamp as entry_timestamp,
+			owner as summary,
+			reason,
+			ifnull(end_timestamp, '') as end_timestamp
+		from
+			database_instance_maintenance
+		where
+			hostname = ?
+			and port = ?
+		order by
+			begin_timestamp desc, database_instance_maintenance_id desc
+		limit ?
+		`,
+		message: func(m sqlutils.RowMap) string {
+			return fmt.Sprintf("%s; until %s", m.GetString("reason"), m.GetString("end_timestamp"))
+		},
+	},
+	DowntimeInstanceHistoryEntry: {query: `
+		select
+			begin_timestamp as entry_timestamp,
+			owner as summary,
+			reason,
+			ifnull(end_timestamp, '') as end_timestamp
+		from
+			database_instance_downtime
+		where
+			hostname = ?
+			and port = ?
+		limit ?
+		`,
+		message: func(m sqlutils.RowMap) string {
+			return fmt.Sprintf("%s; until %s", m.GetString("reason"), m.GetString("end_timestamp"))
+		},
+	},
+}
+
+// readInstanceHistoryOfType reads up to `limit` most recent entries of given type
+func readInstanceHistoryOfType(entryType InstanceHistoryEntryType, source instanceHistorySource, instanceKey *inst.InstanceKey, limit int) (entries []InstanceHistoryEntry, err error) {
+	err = db.QueryOrchestrator(source.query, sqlutils.Args(instanceKey.Hostname, instanceKey.Port, limit), func(m sqlutils.RowMap) error {
+		entry := InstanceHistoryEntry{
+			Type:      entryType,
+			Timestamp: backendTimestamp(m.GetString("entry_timestamp")),
+			Summary:   m.GetString("summary"),
+			Message:   source.message(m),
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	return entries, log.Errore(err)
+}
+
+// readDiscoveryFailureHistory reads failed discoveries of given instance, as retained in memory by this node
+func readDiscoveryFailureHistory(instanceKey *inst.InstanceKey) (entries []InstanceHistoryEntry) {
+	for _, m := range discoveryMetrics.Metrics() {
+		metric, ok := m.(*discovery.Metric)
+		if !ok || metric.Err == nil || !metric.InstanceKey.Equals(instanceKey) {
+			continue
+		}
+		entries = append(entries, InstanceHistoryEntry{
+			Type:      DiscoveryFailureInstanceHistoryEntry,
+			Timestamp: metric.Timestamp.Format("2006-01-02 15:04:05"),
+			Summary:   "discovery",
+			Message:   metric.Err.Error(),
+		})
+	}
+	return entries
+}
+
+// ReadInstanceHistory returns the most recent `limit` history entries of given instance, in chronological order:
+// audit entries, failure detections and recoveries where the instance failed, recoveries where it was promoted,
+// maintenance and downtime, and failed discoveries as retained in memory by this node.
+func ReadInstanceHistory(instanceKey *inst.InstanceKey, limit int) (entries []InstanceHistoryEntry, err error) {
+	entries = []InstanceHistoryEntry{}
+	if limit <= 0 || limit > maxInstanceHistoryLimit {
+		limit = maxInstanceHistoryLimit
+	}
+	for entryType, source := range instanceHistorySources {
+		typeEntries, err := readInstanceHistoryOfType(entryType, source, instanceKey, limit)
+		if err != nil {
+			return entries, err
+		}
+		entries = append(entries, typeEntries...)
+	}
+	entries = append(entries, readDiscoveryFailureHistory(instanceKey)...)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Timestamp != entries[j].Timestamp {
+			return entries[i].Timestamp < entries[j].Timestamp
+		}
+		return entries[i].Type < entries[j].Type
+	})
+	if len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return entries, nil
+}
@@ -0,0 +1,123 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/orchestrator/go/inst"
+	"github.com/openark/orchestrator/go/kv"
+)
+
+// kvVerificationRetryInterval is the interval between attempts to submit and verify a cluster's master KV pairs
+const kvVerificationRetryInterval = time.Second
+
+// readKVStoreValues reads a key from all configured KV stores; tests substitute it
+var readKVStoreValues = kv.GetStoreValues
+
+// KVMismatch is a KV store which does not hold the expected value of a key
+type KVMismatch struct {
+	Key      string
+	Expected string
+	kv.StoreValue
+}
+
+// KVSubmissionVerification is the outcome of submitting a cluster's master KV pairs and reading them back
+type KVSubmissionVerification struct {
+	ClusterName string
+	MasterKey   inst.InstanceKey
+	KVPairs     [](*kv.KVPair)
+	Attempts    int
+	Verified    bool
+	Mismatches  []KVMismatch // as of the last attempt
+}
+
+// verifyKVPairs reads back given pairs from all configured KV stores, and returns those stores which do not hold
+// the expected values
+func verifyKVPairs(kvPairs [](*kv.KVPair)) (mismatches []KVMismatch) {
+	mismatches = []KVMismatch{}
+	for _, kvPair := range kvPairs {
+		for _, storeValue := range readKVStoreValues(kvPair.Key) {
+			if storeValue.Error == "" && storeValue.Found && storeValue.Value == kvPair.Value {
+				continue
+			}
+			mismatches = append(mismatches, KVMismatch{Key: kvPair.Key, Expected: kvPair.Value, StoreValue: storeValue})
+		}
+	}
+	return mismatches
+}
+
+// SubmitMastersToKvStoresVerified writes a cluster's master (or all clusters masters) to the KV stores, as
+// SubmitMastersToKvStores does, then reads the entries back from each configured KV store to verify the expected
+// master was written. Clusters whose entries do not verify are submitted again, every kvVerificationRetryInterval,
+// until all verify or until timeout. Returns the verification of each cluster, and an error if any did not verify.
+func SubmitMastersToKvStoresVerified(clusterName string, timeout time.Duration) (verifications [](*KVSubmissionVerification), err error) {
+	clustersInfo, err := inst.ReadClustersInfo(clusterName)
+	if err != nil {
+		return verifications, err
+	}
+	clusterAliases := make(map[string]string)
+	for _, clusterInfo := range clustersInfo {
+		clusterAliases[clusterInfo.ClusterName] = clusterInfo.ClusterAlias
+	}
+	masters, err := inst.ReadWriteableClustersMasters()
+	if err != nil {
+		return verifications, err
+	}
+	for _, master := range masters {
+		clusterAlias, found := clusterAliases[master.ClusterName]
+		if !found {
+			continue
+		}
+		kvPairs := inst.GetClusterMasterKVPairs(clusterAlias, &master.Key)
+		if len(kvPairs) == 0 {
+			continue
+		}
+		verifications = append(verifications, &KVSubmissionVerification{ClusterName: master.ClusterName, MasterKey: master.Key, KVPairs: kvPairs})
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		countUnverified := 0
+		for _, verification := range verifications {
+			if verification.Verified {
+				continue
+			}
+			verification.Attempts++
+			if _, err := submitKVPairs(verification.KVPairs); err != nil {
+				log.Errorf("SubmitMastersToKvStoresVerified: %s: %+v", verification.ClusterName, err)
+			}
+			if err := kv.DistributePairs(verification.KVPairs); err != nil {
+				log.Errore(err)
+			}
+			verification.Mismatches = verifyKVPairs(verification.KVPairs)
+			verification.Verified = len(verification.Mismatches) == 0
+			if !verification.Verified {
+				countUnverified++
+			}
+		}
+		if countUnverified == 0 {
+			return verifications, nil
+		}
+		if time.Now().Add(kvVerificationRetryInterval).After(deadline) {
+			return verifications, fmt.Errorf("SubmitMastersToKvStoresVerified: %d of %d clusters did not verify", countUnverified, len(verifications))
+		}
+		time.Sleep(kvVerificationRetryInterval)
+	}
+}
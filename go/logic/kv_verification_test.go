@@ -0,0 +1,58 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"testing"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/kv"
+)
+
+func TestVerifyKVPairs(t *testing.T) {
+	originalRead := readKVStoreValues
+	defer func() { readKVStoreValues = originalRead }()
+	readKVStoreValues = func(key string) []kv.StoreValue {
+		switch key {
+		case "mysql/master/c1":
+			return []kv.StoreValue{{Store: "internal", Value: "m1:3306", Found: true}, {Store: "consul", Value: "m1:3306", Found: true}}
+		case "mysql/master/c1/hostname":
+			return []kv.StoreValue{{Store: "internal", Value: "m1", Found: true}, {Store: "consul", Value: "m0", Found: true}}
+		case "mysql/master/c1/port":
+			return []kv.StoreValue{{Store: "internal", Value: "3306", Found: true}, {Store: "consul"}}
+		}
+		return []kv.StoreValue{{Store: "internal", Error: "cannot connect"}}
+	}
+
+	test.S(t).ExpectEquals(len(verifyKVPairs([](*kv.KVPair){kv.NewKVPair("mysql/master/c1", "m1:3306")})), 0)
+
+	mismatches := verifyKVPairs([](*kv.KVPair){
+		kv.NewKVPair("mysql/master/c1", "m1:3306"),
+		kv.NewKVPair("mysql/master/c1/hostname", "m1"),
+		kv.NewKVPair("mysql/master/c1/port", "3306"),
+		kv.NewKVPair("mysql/master/c2", "m2:3306"),
+	})
+	test.S(t).ExpectEquals(len(mismatches), 3)
+	test.S(t).ExpectEquals(mismatches[0].Key, "mysql/master/c1/hostname")
+	test.S(t).ExpectEquals(mismatches[0].Store, "consul")
+	test.S(t).ExpectEquals(mismatches[0].Expected, "m1")
+	test.S(t).ExpectEquals(mismatches[0].Value, "m0")
+	test.S(t).ExpectEquals(mismatches[1].Key, "mysql/master/c1/port")
+	test.S(t).ExpectFalse(mismatches[1].Found)
+	test.S(t).ExpectEquals(mismatches[2].Key, "mysql/master/c2")
+	test.S(t).ExpectEquals(mismatches[2].Error, "cannot connect")
+}
@@ -66,6 +66,8 @@ var isHealthyGauge = metrics.NewGauge()
 var isRaftHealthyGauge = metrics.NewGauge()
 var isRaftLeaderGauge = metrics.NewGauge()
 var discoveryMetrics = collection.CreateOrReturnCollection(discoveryMetricsName)
+var discoveryMetricsAggregator discovery.MetricsAggregator
+var discoveryMetricsAggregatorMutex sync.Mutex
 
 var deadInstancesDiscoveryQueueLengthGauge = metrics.NewGauge()
 
@@ -162,7 +164,7 @@ func acceptSignals() {
 // handleDiscoveryRequests iterates the discoveryQueue channel and calls upon
 // instance discovery per entry.
 func handleDiscoveryRequests() {
-	discoveryQueue = discovery.CreateOrReturnQueue("DEFAULT")
+	discoveryQueue = discovery.CreateOrReturnQueue(discovery.DefaultQueue)
 
 	// create a pool of discovery workers
 	for i := uint(0); i < config.Config.DiscoveryMaxConcurrency; i++ {
@@ -185,7 +187,7 @@ func handleDiscoveryRequests() {
 	}
 
 	if config.Config.DeadInstanceDiscoveryMaxConcurrency > 0 {
-		deadInstancesDiscoveryQueue = discovery.CreateOrReturnQueue("DEADINSTANCES")
+		deadInstancesDiscoveryQueue = discovery.CreateOrReturnQueue(discovery.DeadInstancesQueue)
 
 		// Register dead instances queue gauge only if the queue exists
 		metrics.Register("discoveries.dead_instances_queue_length", deadInstancesDiscoveryQueueLengthGauge)
@@ -215,6 +217,8 @@ func handleDiscoveryRequests() {
 	} else {
 		deadInstancesDiscoveryQueue = discoveryQueue
 	}
+
+	handlePriorityDiscoveryRequests()
 }
 
 // DiscoverInstance will attempt to discover (poll) an instance (unless
@@ -516,27 +520,29 @@ func SubmitMastersToKvStores(clusterName string, force bool) (kvPairs [](*kv.KVP
 		submitKvPairs = append(submitKvPairs, kvPair)
 	}
 	log.Debugf("kv.SubmitMastersToKvStores: submitKvPairs: %+v", len(submitKvPairs))
+	submittedCount, selectedError = submitKVPairs(submitKvPairs)
+	if err := kv.DistributePairs(kvPairs); err != nil {
+		log.Errore(err)
+	}
+	return kvPairs, submittedCount, log.Errore(selectedError)
+}
+
+// submitKVPairs writes given pairs to the KV stores, via raft if enabled
+func submitKVPairs(kvPairs [](*kv.KVPair)) (submittedCount int, err error) {
 	if orcraft.IsRaftEnabled() {
-		for _, kvPair := range submitKvPairs {
-			_, err := orcraft.PublishCommand("put-key-value", kvPair)
-			if err == nil {
+		for _, kvPair := range kvPairs {
+			if _, publishErr := orcraft.PublishCommand("put-key-value", kvPair); publishErr == nil {
 				submittedCount++
 			} else {
-				selectedError = err
+				err = publishErr
 			}
 		}
-	} else {
-		err := kv.PutKVPairs(submitKvPairs)
-		if err == nil {
-			submittedCount += len(submitKvPairs)
-		} else {
-			selectedError = err
-		}
+		return submittedCount, err
 	}
-	if err := kv.DistributePairs(kvPairs); err != nil {
-		log.Errore(err)
+	if err := kv.PutKVPairs(kvPairs); err != nil {
+		return submittedCount, err
 	}
-	return kvPairs, submittedCount, log.Errore(selectedError)
+	return len(kvPairs), nil
 }
 
 func injectSeeds(seedOnce *sync.Once) {
@@ -576,6 +582,13 @@ func ContinuousDiscovery() {
 		snapshotTopologiesTick = time.Tick(time.Duration(config.Config.SnapshotTopologiesIntervalHours) * time.Hour)
 	}
 
+	var discoveryMetricsRollupTick <-chan time.Time
+	if config.Config.DiscoveryMetricsRollupWindowSeconds > 0 {
+		rollupWindow := time.Duration(config.Config.DiscoveryMetricsRollupWindowSeconds) * time.Second
+		setDiscoveryMetricsAggregator(discovery.NewWindowedAggregator(discoveryMetrics, rollupWindow, int(config.Config.DiscoveryMetricsRollupRetainWindows), time.Now()))
+		discoveryMetricsRollupTick = time.Tick(rollupWindow)
+	}
+
 	runCheckAndRecoverOperationsTimeRipe := func() bool {
 		return time.Since(continuousDiscoveryStartTime) >= checkAndRecoverWaitPeriod
 	}
@@ -586,6 +599,7 @@ func ContinuousDiscovery() {
 	go ometrics.InitGraphiteMetrics()
 	go acceptSignals()
 	go kv.InitKVStores()
+	go InitFailoverHooks()
 	if config.Config.RaftEnabled {
 		if err := orcraft.Setup(NewCommandApplier(), NewSnapshotDataCreatorApplier(), process.ThisHostname); err != nil {
 			log.Fatale(err)
@@ -636,6 +650,8 @@ func ContinuousDiscovery() {
 					go inst.DeleteInvalidHostnameResolves()
 					go inst.ResolveUnknownMasterHostnameResolves()
 					go inst.ExpireMaintenance()
+					go ExpireClusterLocks()
+					go ExpireClusterRecoveryDisables()
 					go inst.ExpireCandidateInstances()
 					go inst.ExpireHostnameUnresolve()
 					go inst.ExpireClusterDomainName()
@@ -651,10 +667,16 @@ func ContinuousDiscovery() {
 					go ExpireFailureDetectionHistory()
 					go ExpireTopologyRecoveryHistory()
 					go ExpireTopologyRecoveryStepsHistory()
+					go AutoAcknowledgeRecoveries()
 
 					if runCheckAndRecoverOperationsTimeRipe() && IsLeader() {
 						go SubmitMastersToKvStores("", false)
 					}
+					if IsLeader() {
+						go EnforceDelayedReplicas()
+						go EnforceDataCenterPromotionRules()
+						go ReviewFreezeWindows()
+					}
 				} else {
 					// Take this opportunity to refresh yourself
 					go inst.LoadHostnameResolveCache()
@@ -670,6 +692,7 @@ func ContinuousDiscovery() {
 					go ClearActiveFailureDetections()
 					go ClearActiveRecoveries()
 					go ExpireBlockedRecoveries()
+					go CheckAgedBlockedRecoveries()
 					go AcknowledgeCrashedRecoveries()
 					go inst.ExpireInstanceAnalysisChangelog()
 
@@ -694,10 +717,35 @@ func ContinuousDiscovery() {
 					go inst.SnapshotTopologies()
 				}
 			}()
+		case <-discoveryMetricsRollupTick:
+			go func() {
+				log.Errore(getDiscoveryMetricsAggregator().Aggregate(time.Now()))
+			}()
 		}
 	}
 }
 
+func setDiscoveryMetricsAggregator(aggregator discovery.MetricsAggregator) {
+	discoveryMetricsAggregatorMutex.Lock()
+	defer discoveryMetricsAggregatorMutex.Unlock()
+	discoveryMetricsAggregator = aggregator
+}
+
+func getDiscoveryMetricsAggregator() discovery.MetricsAggregator {
+	discoveryMetricsAggregatorMutex.Lock()
+	defer discoveryMetricsAggregatorMutex.Unlock()
+	return discoveryMetricsAggregator
+}
+
+// DiscoveryMetricsRollup returns the retained rollup windows of discovery metrics, oldest first
+func DiscoveryMetricsRollup() ([]discovery.RollupWindow, error) {
+	aggregator := getDiscoveryMetricsAggregator()
+	if aggregator == nil {
+		return nil, fmt.Errorf("Discovery metrics rollup is disabled. Set DiscoveryMetricsRollupWindowSeconds to enable")
+	}
+	return aggregator.Windows(), nil
+}
+
 func pollAgent(hostname string) error {
 	polledAgent, err := agent.GetAgent(hostname)
 	agent.UpdateAgentLastChecked(hostname)
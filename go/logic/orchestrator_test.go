@@ -0,0 +1,59 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/collection"
+	"github.com/openark/orchestrator/go/discovery"
+)
+
+func TestDiscoveryMetricsRollupDisabled(t *testing.T) {
+	setDiscoveryMetricsAggregator(nil)
+	_, err := DiscoveryMetricsRollup()
+	test.S(t).ExpectNotNil(err)
+}
+
+// TestDiscoveryMetricsRollupConcurrentAccess reads rollups while the aggregator is set and aggregates; run with -race
+func TestDiscoveryMetricsRollupConcurrentAccess(t *testing.T) {
+	defer setDiscoveryMetricsAggregator(nil)
+	startTime := time.Now()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			setDiscoveryMetricsAggregator(discovery.NewWindowedAggregator(&collection.Collection{}, time.Second, 10, startTime))
+		}()
+		go func() {
+			defer wg.Done()
+			if aggregator := getDiscoveryMetricsAggregator(); aggregator != nil {
+				aggregator.Aggregate(startTime.Add(5 * time.Second))
+			}
+			DiscoveryMetricsRollup()
+		}()
+	}
+	wg.Wait()
+
+	_, err := DiscoveryMetricsRollup()
+	test.S(t).ExpectNil(err)
+}
@@ -0,0 +1,263 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openark/golib/sqlutils"
+	"github.com/openark/orchestrator/go/agent"
+	"github.com/openark/orchestrator/go/db"
+	"github.com/openark/orchestrator/go/process"
+	orcraft "github.com/openark/orchestrator/go/raft"
+)
+
+const (
+	DefaultPreUpgradeMinLeaderStableSeconds int64 = 300
+	DefaultPreUpgradeMaxBackendLatency            = 500 * time.Millisecond
+	preUpgradeBackendLatencySamples               = 3
+)
+
+// PreUpgradeThresholds are the limits a PreUpgradeCheck holds the orchestrator service to. Zero values
+// stand for the defaults.
+type PreUpgradeThresholds struct {
+	MinLeaderStableSeconds int64
+	MaxBackendLatency      time.Duration
+}
+
+func (this PreUpgradeThresholds) withDefaults() PreUpgradeThresholds {
+	if this.MinLeaderStableSeconds <= 0 {
+		this.MinLeaderStableSeconds = DefaultPreUpgradeMinLeaderStableSeconds
+	}
+	if this.MaxBackendLatency <= 0 {
+		this.MaxBackendLatency = DefaultPreUpgradeMaxBackendLatency
+	}
+	return this
+}
+
+// PreUpgradeCheckResult is the outcome of a single pre-upgrade check
+type PreUpgradeCheckResult struct {
+	Name    string
+	Passed  bool
+	Details string
+}
+
+// PreUpgradeReport is the go/no-go report of a pre-upgrade check
+type PreUpgradeReport struct {
+	Hostname      string
+	RaftEnabled   bool
+	Thresholds    PreUpgradeThresholds
+	Checks        []PreUpgradeCheckResult
+	SafeToUpgrade bool
+	Reasons       []string
+}
+
+func (this *PreUpgradeReport) addCheck(result PreUpgradeCheckResult) {
+	this.Checks = append(this.Checks, result)
+	if !result.Passed {
+		this.Reasons = append(this.Reasons, fmt.Sprintf("%s: %s", result.Name, result.Details))
+	}
+}
+
+// PreUpgradeCheck verifies the orchestrator service may lose a node to a restart: raft is healthy and
+// keeps its quorum without one member, there are no active or blocked recoveries, no pending seeds,
+// leadership has been stable for a while, and the backend responds in time. It is intended for
+// deployment pipelines to run before restarting orchestrator nodes. The report is a go/no-go; an
+// error is only returned when given context is done before all checks ran.
+func PreUpgradeCheck(ctx context.Context, thresholds PreUpgradeThresholds) (*PreUpgradeReport, error) {
+	thresholds = thresholds.withDefaults()
+	report := &PreUpgradeReport{
+		Hostname:    process.ThisHostname,
+		RaftEnabled: orcraft.IsRaftEnabled(),
+		Thresholds:  thresholds,
+	}
+	checks := []func(PreUpgradeThresholds) PreUpgradeCheckResult{
+		checkPreUpgradeRaftHealth,
+		checkPreUpgradeRecoveries,
+		checkPreUpgradeSeeds,
+		checkPreUpgradeLeaderStability,
+		checkPreUpgradeBackendLatency,
+	}
+	for _, check := range checks {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		report.addCheck(check(thresholds))
+	}
+	report.SafeToUpgrade = len(report.Reasons) == 0
+	return report, nil
+}
+
+func checkPreUpgradeRaftHealth(thresholds PreUpgradeThresholds) PreUpgradeCheckResult {
+	if !orcraft.IsRaftEnabled() {
+		return PreUpgradeCheckResult{Name: "raft-health", Passed: true, Details: "raft is not enabled"}
+	}
+	peers, err := orcraft.GetPeers()
+	if err != nil {
+		return PreUpgradeCheckResult{Name: "raft-health", Details: err.Error()}
+	}
+	return evaluatePreUpgradeRaftHealth(orcraft.IsHealthy(), orcraft.GetLeader(), orcraft.IsLeader(), len(orcraft.HealthyMembers()), len(peers))
+}
+
+// evaluatePreUpgradeRaftHealth requires this node to be a healthy member of a raft group which has a
+// leader. Member health is only known to the leader; on the leader, the healthy members must still form
+// a quorum once one of them restarts.
+func evaluatePreUpgradeRaftHealth(healthy bool, leader string, isLeader bool, healthyMembers int, peers int) PreUpgradeCheckResult {
+	result := PreUpgradeCheckResult{Name: "raft-health"}
+	if !healthy {
+		result.Details = "this node is not a healthy raft member"
+		return result
+	}
+	if leader == "" {
+		result.Details = "raft group has no leader"
+		return result
+	}
+	if !isLeader {
+		result.Passed = true
+		result.Details = fmt.Sprintf("healthy follower of %s; member health is assessed on the leader", leader)
+		return result
+	}
+	quorum := peers/2 + 1
+	if healthyMembers-1 < quorum {
+		result.Details = fmt.Sprintf("%d of %d raft members healthy; restarting one would break the quorum of %d", healthyMembers, peers, quorum)
+		return result
+	}
+	result.Passed = true
+	result.Details = fmt.Sprintf("%d of %d raft members healthy; quorum is %d", healthyMembers, peers, quorum)
+	return result
+}
+
+func checkPreUpgradeRecoveries(thresholds PreUpgradeThresholds) PreUpgradeCheckResult {
+	activeRecoveries, err := ReadActiveRecoveries()
+	if err != nil {
+		return PreUpgradeCheckResult{Name: "recoveries", Details: err.Error()}
+	}
+	blockedRecoveries, err := ReadBlockedRecoveries("")
+	if err != nil {
+		return PreUpgradeCheckResult{Name: "recoveries", Details: err.Error()}
+	}
+	return evaluatePreUpgradeRecoveries(activeRecoveries, blockedRecoveries)
+}
+
+// evaluatePreUpgradeRecoveries requires there to be neither active nor blocked recoveries
+func evaluatePreUpgradeRecoveries(activeRecoveries []*TopologyRecovery, blockedRecoveries []BlockedTopologyRecovery) PreUpgradeCheckResult {
+	result := PreUpgradeCheckResult{Name: "recoveries"}
+	descriptions := []string{}
+	for _, recovery := range activeRecoveries {
+		descriptions = append(descriptions, fmt.Sprintf("active recovery %d on %s", recovery.Id, recovery.AnalysisEntry.AnalyzedInstanceKey.DisplayString()))
+	}
+	for _, blocked := range blockedRecoveries {
+		descriptions = append(descriptions, fmt.Sprintf("blocked recovery of %s on %s", blocked.Analysis, blocked.FailedInstanceKey.DisplayString()))
+	}
+	if len(descriptions) > 0 {
+		result.Details = strings.Join(descriptions, ", ")
+		return result
+	}
+	result.Passed = true
+	result.Details = "no active or blocked recoveries"
+	return result
+}
+
+func checkPreUpgradeSeeds(thresholds PreUpgradeThresholds) PreUpgradeCheckResult {
+	seeds, err := agent.ReadActiveSeeds()
+	if err != nil {
+		return PreUpgradeCheckResult{Name: "seeds", Details: err.Error()}
+	}
+	return evaluatePreUpgradeSeeds(seeds)
+}
+
+// evaluatePreUpgradeSeeds requires there to be no pending seeds
+func evaluatePreUpgradeSeeds(seeds []agent.SeedOperation) PreUpgradeCheckResult {
+	result := PreUpgradeCheckResult{Name: "seeds"}
+	if len(seeds) > 0 {
+		descriptions := []string{}
+		for _, seed := range seeds {
+			descriptions = append(descriptions, fmt.Sprintf("seed %d from %s to %s", seed.SeedId, seed.SourceHostname, seed.TargetHostname))
+		}
+		result.Details = fmt.Sprintf("pending: %s", strings.Join(descriptions, ", "))
+		return result
+	}
+	result.Passed = true
+	result.Details = "no pending seeds"
+	return result
+}
+
+func checkPreUpgradeLeaderStability(thresholds PreUpgradeThresholds) PreUpgradeCheckResult {
+	if orcraft.IsRaftEnabled() {
+		leader, since := orcraft.LeaderObservedSince()
+		stableSeconds := int64(0)
+		if !since.IsZero() {
+			stableSeconds = int64(time.Since(since).Seconds())
+		}
+		return evaluatePreUpgradeLeaderStability(leader, stableSeconds, thresholds.MinLeaderStableSeconds)
+	}
+	node, _, err := process.ElectedNode()
+	if err != nil {
+		return PreUpgradeCheckResult{Name: "leader-stability", Details: err.Error()}
+	}
+	activeSeconds, err := process.ElectedNodeActiveSeconds()
+	if err != nil {
+		return PreUpgradeCheckResult{Name: "leader-stability", Details: err.Error()}
+	}
+	return evaluatePreUpgradeLeaderStability(node.Hostname, activeSeconds, thresholds.MinLeaderStableSeconds)
+}
+
+// evaluatePreUpgradeLeaderStability requires the same leader to have held leadership for at least given seconds
+func evaluatePreUpgradeLeaderStability(leader string, stableSeconds int64, minStableSeconds int64) PreUpgradeCheckResult {
+	result := PreUpgradeCheckResult{Name: "leader-stability"}
+	if leader == "" {
+		result.Details = "no leader observed"
+		return result
+	}
+	result.Details = fmt.Sprintf("%s is leader for %ds; minimum is %ds", leader, stableSeconds, minStableSeconds)
+	result.Passed = stableSeconds >= minStableSeconds
+	return result
+}
+
+func checkPreUpgradeBackendLatency(thresholds PreUpgradeThresholds) PreUpgradeCheckResult {
+	samples := []time.Duration{}
+	for i := 0; i < preUpgradeBackendLatencySamples; i++ {
+		startTime := time.Now()
+		err := db.QueryOrchestrator(`select now() as time_now`, nil, func(m sqlutils.RowMap) error { return nil })
+		if err != nil {
+			return PreUpgradeCheckResult{Name: "backend-latency", Details: err.Error()}
+		}
+		samples = append(samples, time.Since(startTime))
+	}
+	return evaluatePreUpgradeBackendLatency(samples, thresholds.MaxBackendLatency)
+}
+
+// evaluatePreUpgradeBackendLatency requires the slowest of given backend query samples to be within given latency
+func evaluatePreUpgradeBackendLatency(samples []time.Duration, maxLatency time.Duration) PreUpgradeCheckResult {
+	result := PreUpgradeCheckResult{Name: "backend-latency"}
+	if len(samples) == 0 {
+		result.Details = "no backend queries sampled"
+		return result
+	}
+	slowest := samples[0]
+	for _, sample := range samples {
+		if sample > slowest {
+			slowest = sample
+		}
+	}
+	result.Details = fmt.Sprintf("slowest of %d backend queries took %s; maximum is %s", len(samples), slowest.Round(time.Microsecond), maxLatency)
+	result.Passed = slowest <= maxLatency
+	return result
+}
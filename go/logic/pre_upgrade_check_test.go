@@ -0,0 +1,96 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"testing"
+	"time"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/agent"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+func TestEvaluatePreUpgradeRaftHealth(t *testing.T) {
+	test.S(t).ExpectFalse(evaluatePreUpgradeRaftHealth(false, "node1:10008", false, 0, 3).Passed)
+	test.S(t).ExpectFalse(evaluatePreUpgradeRaftHealth(true, "", false, 0, 3).Passed)
+	test.S(t).ExpectTrue(evaluatePreUpgradeRaftHealth(true, "node1:10008", false, 0, 3).Passed)
+
+	test.S(t).ExpectTrue(evaluatePreUpgradeRaftHealth(true, "node1:10008", true, 3, 3).Passed)
+	result := evaluatePreUpgradeRaftHealth(true, "node1:10008", true, 2, 3)
+	test.S(t).ExpectFalse(result.Passed)
+	test.S(t).ExpectEquals(result.Details, "2 of 3 raft members healthy; restarting one would break the quorum of 2")
+	test.S(t).ExpectTrue(evaluatePreUpgradeRaftHealth(true, "node1:10008", true, 4, 5).Passed)
+	test.S(t).ExpectFalse(evaluatePreUpgradeRaftHealth(true, "node1:10008", true, 3, 5).Passed)
+}
+
+func TestEvaluatePreUpgradeRecoveries(t *testing.T) {
+	test.S(t).ExpectTrue(evaluatePreUpgradeRecoveries(nil, nil).Passed)
+
+	recovery := &TopologyRecovery{Id: 7}
+	recovery.AnalysisEntry.AnalyzedInstanceKey = inst.InstanceKey{Hostname: "master", Port: 3306}
+	result := evaluatePreUpgradeRecoveries([]*TopologyRecovery{recovery}, nil)
+	test.S(t).ExpectFalse(result.Passed)
+	test.S(t).ExpectEquals(result.Details, "active recovery 7 on master:3306")
+
+	blocked := BlockedTopologyRecovery{FailedInstanceKey: inst.InstanceKey{Hostname: "replica", Port: 3306}, Analysis: inst.DeadMaster}
+	result = evaluatePreUpgradeRecoveries(nil, []BlockedTopologyRecovery{blocked})
+	test.S(t).ExpectFalse(result.Passed)
+	test.S(t).ExpectEquals(result.Details, "blocked recovery of DeadMaster on replica:3306")
+}
+
+func TestEvaluatePreUpgradeSeeds(t *testing.T) {
+	test.S(t).ExpectTrue(evaluatePreUpgradeSeeds(nil).Passed)
+
+	result := evaluatePreUpgradeSeeds([]agent.SeedOperation{{SeedId: 3, SourceHostname: "source", TargetHostname: "target"}})
+	test.S(t).ExpectFalse(result.Passed)
+	test.S(t).ExpectEquals(result.Details, "pending: seed 3 from source to target")
+}
+
+func TestEvaluatePreUpgradeLeaderStability(t *testing.T) {
+	test.S(t).ExpectFalse(evaluatePreUpgradeLeaderStability("", 1000, 300).Passed)
+	test.S(t).ExpectFalse(evaluatePreUpgradeLeaderStability("node1", 299, 300).Passed)
+	test.S(t).ExpectTrue(evaluatePreUpgradeLeaderStability("node1", 300, 300).Passed)
+}
+
+func TestEvaluatePreUpgradeBackendLatency(t *testing.T) {
+	test.S(t).ExpectFalse(evaluatePreUpgradeBackendLatency(nil, time.Second).Passed)
+	test.S(t).ExpectTrue(evaluatePreUpgradeBackendLatency([]time.Duration{time.Millisecond, 2 * time.Millisecond}, 10*time.Millisecond).Passed)
+
+	result := evaluatePreUpgradeBackendLatency([]time.Duration{time.Millisecond, 20 * time.Millisecond}, 10*time.Millisecond)
+	test.S(t).ExpectFalse(result.Passed)
+	test.S(t).ExpectEquals(result.Details, "slowest of 2 backend queries took 20ms; maximum is 10ms")
+}
+
+func TestPreUpgradeThresholdsDefaults(t *testing.T) {
+	thresholds := PreUpgradeThresholds{}.withDefaults()
+	test.S(t).ExpectEquals(thresholds.MinLeaderStableSeconds, DefaultPreUpgradeMinLeaderStableSeconds)
+	test.S(t).ExpectEquals(thresholds.MaxBackendLatency, DefaultPreUpgradeMaxBackendLatency)
+
+	thresholds = PreUpgradeThresholds{MinLeaderStableSeconds: 60, MaxBackendLatency: time.Second}.withDefaults()
+	test.S(t).ExpectEquals(thresholds.MinLeaderStableSeconds, int64(60))
+	test.S(t).ExpectEquals(thresholds.MaxBackendLatency, time.Second)
+}
+
+func TestPreUpgradeReportAddCheck(t *testing.T) {
+	report := &PreUpgradeReport{}
+	report.addCheck(PreUpgradeCheckResult{Name: "seeds", Passed: true, Details: "no pending seeds"})
+	report.addCheck(PreUpgradeCheckResult{Name: "leader-stability", Details: "no leader observed"})
+	test.S(t).ExpectEquals(len(report.Checks), 2)
+	test.S(t).ExpectEquals(len(report.Reasons), 1)
+	test.S(t).ExpectEquals(report.Reasons[0], "leader-stability: no leader observed")
+}
@@ -0,0 +1,196 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/discovery"
+	"github.com/openark/orchestrator/go/inst"
+	orcraft "github.com/openark/orchestrator/go/raft"
+	"github.com/openark/orchestrator/go/util"
+)
+
+// DiscoveryPriority determines how soon a requested discovery takes place
+type DiscoveryPriority string
+
+const (
+	// NormalDiscoveryPriority queues the instance for ordinary discovery
+	NormalDiscoveryPriority DiscoveryPriority = "normal"
+	// HighDiscoveryPriority queues the instance on the priority queue, served by its own workers ahead of ordinary discovery
+	HighDiscoveryPriority DiscoveryPriority = "high"
+	// ImmediateDiscoveryPriority reads the instance synchronously
+	ImmediateDiscoveryPriority DiscoveryPriority = "immediate"
+)
+
+// priorityDiscoveryQueue is served by PriorityDiscoveryMaxConcurrency workers; nil when there are none
+var priorityDiscoveryQueue *discovery.Queue
+
+// ParseDiscoveryPriority returns the priority by given name, case insensitively. An empty name means HighDiscoveryPriority.
+func ParseDiscoveryPriority(name string) (DiscoveryPriority, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return HighDiscoveryPriority, nil
+	}
+	for _, priority := range []DiscoveryPriority{NormalDiscoveryPriority, HighDiscoveryPriority, ImmediateDiscoveryPriority} {
+		if name == string(priority) {
+			return priority, nil
+		}
+	}
+	return "", fmt.Errorf("Unknown discovery priority: %q. Expected one of: normal, high, immediate", name)
+}
+
+// PriorityDiscoveryResult is the outcome of a prioritized discovery request for a single instance
+type PriorityDiscoveryResult struct {
+	Key        inst.InstanceKey
+	Priority   DiscoveryPriority // the priority the request was served with
+	Queue      discovery.QueueName
+	Discovered bool
+	Error      string
+}
+
+// handlePriorityDiscoveryRequests creates the priority discovery queue and its pool of workers. Instances
+// consumed from this queue are read unconditionally, even if recently discovered.
+func handlePriorityDiscoveryRequests() {
+	if config.Config.PriorityDiscoveryMaxConcurrency == 0 {
+		return
+	}
+	queue := discovery.CreateOrReturnQueue(discovery.PriorityQueue)
+	for i := uint(0); i < config.Config.PriorityDiscoveryMaxConcurrency; i++ {
+		go func() {
+			for {
+				instanceKey := queue.Consume()
+				if !IsLeaderOrActive() {
+					log.Debugf("Node apparently demoted. Skipping prioritized discovery of %+v. "+
+						"Remaining queue size: %+v", instanceKey, queue.QueueLen())
+					queue.Release(instanceKey)
+					continue
+				}
+				if _, err := rediscoverInstance(instanceKey); err != nil {
+					log.Errore(err)
+				}
+				queue.Release(instanceKey)
+			}
+		}()
+	}
+	priorityDiscoveryQueue = queue
+}
+
+// rediscoverInstance reads an instance from the server, regardless of when it was last discovered. With raft,
+// the discovery is then published to all nodes, as with the discover API.
+func rediscoverInstance(instanceKey inst.InstanceKey) (*inst.Instance, error) {
+	instance, err := inst.ReadTopologyInstance(&instanceKey)
+	if err != nil {
+		return instance, err
+	}
+	if orcraft.IsRaftEnabled() {
+		_, err = orcraft.PublishCommand("discover", instanceKey)
+	}
+	return instance, err
+}
+
+// DiscoverWithPriority requests the discovery of an instance with given priority. See DiscoverInstancesWithPriority.
+func DiscoverWithPriority(ctx context.Context, instanceKey inst.InstanceKey, priority DiscoveryPriority) PriorityDiscoveryResult {
+	return DiscoverInstancesWithPriority(ctx, []inst.InstanceKey{instanceKey}, priority)[0]
+}
+
+// DiscoverInstancesWithPriority requests the discovery of given instances with given priority, returning one
+// result per instance, in order:
+//   - normal and high priority requests are queued, and return once queued.
+//   - immediate priority requests read the instances synchronously, within the limits of cluster-wide scans, and
+//     return once read or once ctx is done.
+//
+// When there are no priority discovery workers, high priority requests are served as immediate.
+func DiscoverInstancesWithPriority(ctx context.Context, instanceKeys []inst.InstanceKey, priority DiscoveryPriority) (results []PriorityDiscoveryResult) {
+	if len(instanceKeys) == 0 {
+		return results
+	}
+	for _, instanceKey := range instanceKeys {
+		results = append(results, PriorityDiscoveryResult{Key: instanceKey, Priority: priority})
+	}
+	if priority == HighDiscoveryPriority && priorityDiscoveryQueue == nil {
+		for i := range results {
+			results[i].Priority = ImmediateDiscoveryPriority
+		}
+	}
+	switch results[0].Priority {
+	case NormalDiscoveryPriority:
+		queueDiscoveries(ctx, results, discoveryQueue, discovery.DefaultQueue)
+	case HighDiscoveryPriority:
+		queueDiscoveries(ctx, results, priorityDiscoveryQueue, discovery.PriorityQueue)
+	default:
+		discoverImmediately(ctx, results)
+	}
+	return results
+}
+
+// DiscoverClusterWithPriority requests the discovery of all known instances of given cluster with given priority
+func DiscoverClusterWithPriority(ctx context.Context, clusterName string, priority DiscoveryPriority) ([]PriorityDiscoveryResult, error) {
+	instances, err := inst.ReadClusterInstances(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if len(instances) == 0 {
+		return nil, fmt.Errorf("No instances found for cluster %+v", clusterName)
+	}
+	instanceKeys := []inst.InstanceKey{}
+	for _, instance := range instances {
+		instanceKeys = append(instanceKeys, instance.Key)
+	}
+	return DiscoverInstancesWithPriority(ctx, instanceKeys, priority), nil
+}
+
+func queueDiscoveries(ctx context.Context, results []PriorityDiscoveryResult, queue *discovery.Queue, queueName discovery.QueueName) {
+	for i := range results {
+		switch {
+		case queue == nil:
+			results[i].Error = fmt.Sprintf("Discovery queue %s is not in use", queueName)
+		case ctx.Err() != nil:
+			results[i].Error = ctx.Err().Error()
+		default:
+			queue.Push(results[i].Key)
+			results[i].Queue = queueName
+		}
+	}
+}
+
+func discoverImmediately(ctx context.Context, results []PriorityDiscoveryResult) {
+	options := clusterFanOutOptions()
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); options.Timeout <= 0 || remaining < options.Timeout {
+			options.Timeout = remaining
+		}
+	}
+	fanOutResults := util.FanOut(len(results), options, func(i int) (interface{}, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return rediscoverInstance(results[i].Key)
+	})
+	for i, result := range fanOutResults {
+		if result.Err != nil {
+			results[i].Error = result.Err.Error()
+			continue
+		}
+		results[i].Discovered = true
+	}
+}
@@ -0,0 +1,68 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"context"
+	"testing"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/discovery"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+func TestParseDiscoveryPriority(t *testing.T) {
+	priority, err := ParseDiscoveryPriority("")
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(priority, HighDiscoveryPriority)
+
+	priority, err = ParseDiscoveryPriority(" Immediate ")
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(priority, ImmediateDiscoveryPriority)
+
+	_, err = ParseDiscoveryPriority("urgent")
+	test.S(t).ExpectNotNil(err)
+}
+
+func TestDiscoverInstancesWithPriorityQueued(t *testing.T) {
+	originalQueue := priorityDiscoveryQueue
+	defer func() { priorityDiscoveryQueue = originalQueue }()
+	priorityDiscoveryQueue = discovery.CreateOrReturnQueue(discovery.PriorityQueue)
+
+	keys := []inst.InstanceKey{{Hostname: "host1", Port: 3306}, {Hostname: "host2", Port: 3306}}
+	test.S(t).ExpectEquals(len(DiscoverInstancesWithPriority(context.Background(), nil, HighDiscoveryPriority)), 0)
+
+	results := DiscoverInstancesWithPriority(context.Background(), keys, HighDiscoveryPriority)
+	test.S(t).ExpectEquals(len(results), 2)
+	test.S(t).ExpectEquals(results[1].Key, keys[1])
+	test.S(t).ExpectEquals(results[1].Priority, HighDiscoveryPriority)
+	test.S(t).ExpectEquals(results[1].Queue, discovery.PriorityQueue)
+	test.S(t).ExpectEquals(results[1].Error, "")
+	test.S(t).ExpectEquals(priorityDiscoveryQueue.QueueLen(), 4)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result := DiscoverWithPriority(ctx, inst.InstanceKey{Hostname: "host3", Port: 3306}, HighDiscoveryPriority)
+	test.S(t).ExpectEquals(result.Queue, discovery.QueueName(""))
+	test.S(t).ExpectEquals(result.Error, "context canceled")
+}
+
+func TestDiscoverInstancesWithPriorityQueueNotInUse(t *testing.T) {
+	result := DiscoverWithPriority(context.Background(), inst.InstanceKey{Hostname: "host1", Port: 3306}, NormalDiscoveryPriority)
+	test.S(t).ExpectFalse(result.Discovered)
+	test.S(t).ExpectEquals(result.Error, "Discovery queue DEFAULT is not in use")
+}
@@ -0,0 +1,191 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/openark/orchestrator/go/inst"
+)
+
+// PromotionCandidateConstraints narrow down and direct promotion candidate suggestions
+type PromotionCandidateConstraints struct {
+	DataCenter            string // preferred data center; defaults to that of the master
+	RequireSameDataCenter bool   // disqualify replicas outside the preferred data center
+	MaxLagSeconds         int64  // disqualify replicas lagging more than this; 0 for no limit
+}
+
+// PromotionCandidate is a replica suggested for promotion, along with the reasoning for its ranking
+type PromotionCandidate struct {
+	Key                          inst.InstanceKey
+	PromotionRule                inst.CandidatePromotionRule
+	DataCenter                   string
+	SameDataCenter               bool
+	ReplicationLagSeconds        int64
+	Version                      string
+	PriorityMajorVersion         bool
+	LogReplicationUpdatesEnabled bool
+	Eligible                     bool
+	Reasons                      []string
+
+	promotionRuleOrder int
+	execCoordinates    inst.BinlogCoordinates
+}
+
+func (this *PromotionCandidate) addReason(format string, args ...interface{}) {
+	this.Reasons = append(this.Reasons, fmt.Sprintf(format, args...))
+}
+
+// promotionRuleOrder returns the rank of given rule; lower is better
+func promotionRuleOrder(rule inst.CandidatePromotionRule) int {
+	rules := []inst.CandidatePromotionRule{inst.MustPromoteRule, inst.PreferPromoteRule, inst.NeutralPromoteRule, inst.PreferNotPromoteRule, inst.MustNotPromoteRule}
+	for i, r := range rules {
+		if r == rule {
+			return i
+		}
+	}
+	return promotionRuleOrder(inst.NeutralPromoteRule)
+}
+
+// priorityMajorVersion returns the most common major version among given replicas
+func priorityMajorVersion(replicas [](*inst.Instance)) string {
+	counts := make(map[string]int)
+	priority := ""
+	for _, replica := range replicas {
+		version := replica.MajorVersionString()
+		counts[version]++
+		if counts[version] > counts[priority] || (counts[version] == counts[priority] && version < priority) {
+			priority = version
+		}
+	}
+	return priority
+}
+
+// rankPromotionCandidates evaluates and orders the replicas of given master, best candidate first.
+func rankPromotionCandidates(master *inst.Instance, replicas [](*inst.Instance), constraints PromotionCandidateConstraints) []*PromotionCandidate {
+	dataCenter := constraints.DataCenter
+	if dataCenter == "" && master != nil {
+		dataCenter = master.DataCenter
+	}
+	majorVersion := priorityMajorVersion(replicas)
+
+	candidates := []*PromotionCandidate{}
+	for _, replica := range replicas {
+		candidate := &PromotionCandidate{
+			Key:                          replica.Key,
+			PromotionRule:                replica.PromotionRule,
+			DataCenter:                   replica.DataCenter,
+			SameDataCenter:               dataCenter != "" && replica.DataCenter == dataCenter,
+			ReplicationLagSeconds:        replica.ReplicationLagSeconds.Int64,
+			Version:                      replica.Version,
+			PriorityMajorVersion:         replica.MajorVersionString() == majorVersion,
+			LogReplicationUpdatesEnabled: replica.LogReplicationUpdatesEnabled,
+			Eligible:                     true,
+			Reasons:                      []string{},
+			promotionRuleOrder:           promotionRuleOrder(replica.PromotionRule),
+			execCoordinates:              replica.ExecBinlogCoordinates,
+		}
+		disqualify := func(format string, args ...interface{}) {
+			candidate.Eligible = false
+			candidate.addReason(format, args...)
+		}
+		if !replica.IsLastCheckValid {
+			disqualify("last check invalid")
+		}
+		if !replica.LogBinEnabled {
+			disqualify("binary logs not enabled")
+		}
+		if replica.IsBinlogServer() {
+			disqualify("binlog server")
+		}
+		if inst.IsBannedFromBeingCandidateReplica(replica) {
+			disqualify("banned by promotion rule or PromotionIgnoreHostnameFilters")
+		}
+		if constraints.RequireSameDataCenter && !candidate.SameDataCenter {
+			disqualify("not in data center %s", dataCenter)
+		}
+		if constraints.MaxLagSeconds > 0 && (!replica.ReplicationLagSeconds.Valid || replica.ReplicationLagSeconds.Int64 > constraints.MaxLagSeconds) {
+			disqualify("lag exceeds %d seconds", constraints.MaxLagSeconds)
+		}
+
+		candidate.addReason("promotion rule: %s", replica.PromotionRule)
+		if dataCenter != "" {
+			if candidate.SameDataCenter {
+				candidate.addReason("same data center as master: %s", dataCenter)
+			} else {
+				candidate.addReason("different data center: %s", replica.DataCenter)
+			}
+		}
+		if replica.ReplicationLagSeconds.Valid {
+			candidate.addReason("lag: %d seconds", replica.ReplicationLagSeconds.Int64)
+		} else {
+			candidate.addReason("lag: unknown")
+		}
+		if !candidate.PriorityMajorVersion {
+			candidate.addReason("major version %s differs from most replicas (%s)", replica.MajorVersionString(), majorVersion)
+		}
+		if !replica.LogReplicationUpdatesEnabled {
+			candidate.addReason("log_slave_updates disabled; cannot serve as master to its siblings without losing them")
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Eligible != b.Eligible {
+			return a.Eligible
+		}
+		if a.promotionRuleOrder != b.promotionRuleOrder {
+			return a.promotionRuleOrder < b.promotionRuleOrder
+		}
+		if a.SameDataCenter != b.SameDataCenter {
+			return a.SameDataCenter
+		}
+		if a.PriorityMajorVersion != b.PriorityMajorVersion {
+			return a.PriorityMajorVersion
+		}
+		if a.LogReplicationUpdatesEnabled != b.LogReplicationUpdatesEnabled {
+			return a.LogReplicationUpdatesEnabled
+		}
+		if a.ReplicationLagSeconds != b.ReplicationLagSeconds {
+			return a.ReplicationLagSeconds < b.ReplicationLagSeconds
+		}
+		return b.execCoordinates.SmallerThan(&a.execCoordinates)
+	})
+	return candidates
+}
+
+// SuggestPromotionCandidates ranks the replicas of a cluster's master by promotion rule, data center affinity
+// with the master, version, log_slave_updates and lag, and returns them best candidate first, each with the
+// reasons for its ranking. Replicas disqualified by given constraints or by their state are listed last.
+// It is advisory and does not change anything.
+func SuggestPromotionCandidates(clusterName string, constraints PromotionCandidateConstraints) ([]*PromotionCandidate, error) {
+	masters, err := inst.ReadClusterMaster(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if len(masters) == 0 {
+		return nil, fmt.Errorf("SuggestPromotionCandidates: cannot find master for cluster %s", clusterName)
+	}
+	master := masters[0]
+	replicas, err := inst.ReadReplicaInstances(&master.Key)
+	if err != nil {
+		return nil, err
+	}
+	return rankPromotionCandidates(master, replicas, constraints), nil
+}
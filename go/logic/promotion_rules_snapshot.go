@@ -0,0 +1,167 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/inst"
+	orcraft "github.com/openark/orchestrator/go/raft"
+	"github.com/openark/orchestrator/go/util"
+)
+
+// PromotionRuleChangeType tells how applying a promotion rule changes the registered rule of an instance
+type PromotionRuleChangeType string
+
+const (
+	PromotionRuleAdded     PromotionRuleChangeType = "added"
+	PromotionRuleChanged   PromotionRuleChangeType = "changed"
+	PromotionRuleUnchanged PromotionRuleChangeType = "unchanged"
+)
+
+// PromotionRuleChange is the difference between the registered promotion rule of an instance and the one applied
+type PromotionRuleChange struct {
+	Key           string
+	PreviousRule  inst.CandidatePromotionRule // empty when none is registered
+	PromotionRule inst.CandidatePromotionRule
+	Change        PromotionRuleChangeType
+	Applied       bool
+	Error         string
+}
+
+// PromotionRulesDiff lists the changes of applying promotion rules, sorted by key
+type PromotionRulesDiff struct {
+	Changes        []PromotionRuleChange
+	CountAdded     int
+	CountChanged   int
+	CountUnchanged int
+	CountFailed    int
+}
+
+// ExportPromotionRules returns the registered promotion rules, keyed by "host:port". This is a snapshot of
+// candidate configuration, to be restored with ApplyPromotionRules, e.g. once orchestrator is rebuilt on a
+// new backend.
+func ExportPromotionRules() (map[string]inst.CandidatePromotionRule, error) {
+	candidates, err := inst.BulkReadCandidateDatabaseInstance()
+	if err != nil {
+		return nil, err
+	}
+	rules := make(map[string]inst.CandidatePromotionRule)
+	for _, candidate := range candidates {
+		rules[candidate.Key().StringCode()] = candidate.PromotionRule
+	}
+	return rules, nil
+}
+
+// normalizePromotionRules validates given promotion rules, and returns them keyed by normalized "host:port"
+func normalizePromotionRules(rules map[string]inst.CandidatePromotionRule) (map[string]inst.CandidatePromotionRule, error) {
+	normalized := make(map[string]inst.CandidatePromotionRule)
+	for hostPort, rule := range rules {
+		instanceKey, err := inst.ParseRawInstanceKey(hostPort)
+		if err != nil {
+			return nil, fmt.Errorf("Cannot parse instance %q: %+v", hostPort, err)
+		}
+		if _, err := inst.ParseCandidatePromotionRule(string(rule)); err != nil {
+			return nil, fmt.Errorf("%s: %+v", hostPort, err)
+		}
+		key := instanceKey.StringCode()
+		if _, found := normalized[key]; found {
+			return nil, fmt.Errorf("Instance %s is listed more than once", key)
+		}
+		normalized[key] = rule
+	}
+	return normalized, nil
+}
+
+// diffPromotionRules compares registered promotion rules with desired ones. Registered rules of instances not
+// listed in desired rules are not part of the diff: applying rules does not remove any.
+func diffPromotionRules(registered map[string]inst.CandidatePromotionRule, desired map[string]inst.CandidatePromotionRule) *PromotionRulesDiff {
+	diff := &PromotionRulesDiff{Changes: []PromotionRuleChange{}}
+	for key, rule := range desired {
+		change := PromotionRuleChange{Key: key, PromotionRule: rule}
+		previousRule, found := registered[key]
+		switch {
+		case !found:
+			change.Change = PromotionRuleAdded
+			diff.CountAdded++
+		case previousRule != rule:
+			change.PreviousRule = previousRule
+			change.Change = PromotionRuleChanged
+			diff.CountChanged++
+		default:
+			change.PreviousRule = previousRule
+			change.Change = PromotionRuleUnchanged
+			diff.CountUnchanged++
+		}
+		diff.Changes = append(diff.Changes, change)
+	}
+	sort.Slice(diff.Changes, func(i, j int) bool { return diff.Changes[i].Key < diff.Changes[j].Key })
+	return diff
+}
+
+// DiffPromotionRules returns the changes ApplyPromotionRules would make, changing nothing
+func DiffPromotionRules(rules map[string]inst.CandidatePromotionRule) (*PromotionRulesDiff, error) {
+	desired, err := normalizePromotionRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	registered, err := ExportPromotionRules()
+	if err != nil {
+		return nil, err
+	}
+	return diffPromotionRules(registered, desired), nil
+}
+
+// ApplyPromotionRules registers given promotion rules, keyed by "host:port", as with register-candidate, up to
+// MaxConcurrentReplicaOperations at a time. Unchanged rules are registered as well, renewing them: registrations
+// expire as usual (see CandidateInstanceExpireMinutes), and a snapshot applied periodically keeps all its rules.
+// Returns the diff, with the outcome of each registration, and an error when any registration failed.
+func ApplyPromotionRules(rules map[string]inst.CandidatePromotionRule) (*PromotionRulesDiff, error) {
+	diff, err := DiffPromotionRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	results := util.FanOut(len(diff.Changes), util.FanOutOptions{Concurrency: config.Config.MaxConcurrentReplicaOperations}, func(i int) (interface{}, error) {
+		instanceKey, err := inst.ParseRawInstanceKey(diff.Changes[i].Key)
+		if err != nil {
+			return nil, err
+		}
+		candidate := inst.NewCandidateDatabaseInstance(instanceKey, diff.Changes[i].PromotionRule).WithCurrentTime()
+		if orcraft.IsRaftEnabled() {
+			_, err = orcraft.PublishCommand("register-candidate", candidate)
+		} else {
+			err = inst.RegisterCandidateInstance(candidate)
+		}
+		return nil, err
+	})
+	for i, result := range results {
+		if result.Err != nil {
+			diff.Changes[i].Error = result.Err.Error()
+			diff.CountFailed++
+			continue
+		}
+		diff.Changes[i].Applied = true
+	}
+	log.Infof("ApplyPromotionRules: %d added, %d changed, %d unchanged, %d failed", diff.CountAdded, diff.CountChanged, diff.CountUnchanged, diff.CountFailed)
+	if diff.CountFailed > 0 {
+		return diff, fmt.Errorf("ApplyPromotionRules: %d of %d registrations failed", diff.CountFailed, len(diff.Changes))
+	}
+	return diff, nil
+}
@@ -0,0 +1,77 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"testing"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+func TestNormalizePromotionRules(t *testing.T) {
+	rules, err := normalizePromotionRules(map[string]inst.CandidatePromotionRule{
+		"db1":      inst.PreferPromoteRule,
+		"db2:3307": inst.MustNotPromoteRule,
+	})
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(len(rules), 2)
+	test.S(t).ExpectEquals(rules["db1:3306"], inst.PreferPromoteRule)
+	test.S(t).ExpectEquals(rules["db2:3307"], inst.MustNotPromoteRule)
+}
+
+func TestNormalizePromotionRulesInvalid(t *testing.T) {
+	_, err := normalizePromotionRules(map[string]inst.CandidatePromotionRule{"db1:3306": "preferred"})
+	test.S(t).ExpectNotNil(err)
+
+	_, err = normalizePromotionRules(map[string]inst.CandidatePromotionRule{"db1:port": inst.PreferPromoteRule})
+	test.S(t).ExpectNotNil(err)
+
+	_, err = normalizePromotionRules(map[string]inst.CandidatePromotionRule{
+		"db1":      inst.PreferPromoteRule,
+		"db1:3306": inst.NeutralPromoteRule,
+	})
+	test.S(t).ExpectNotNil(err)
+}
+
+func TestDiffPromotionRules(t *testing.T) {
+	registered := map[string]inst.CandidatePromotionRule{
+		"db1:3306": inst.PreferPromoteRule,
+		"db2:3306": inst.PreferPromoteRule,
+		"db4:3306": inst.MustNotPromoteRule,
+	}
+	desired := map[string]inst.CandidatePromotionRule{
+		"db1:3306": inst.PreferPromoteRule,
+		"db2:3306": inst.PreferNotPromoteRule,
+		"db3:3306": inst.NeutralPromoteRule,
+	}
+	diff := diffPromotionRules(registered, desired)
+	test.S(t).ExpectEquals(len(diff.Changes), 3)
+	test.S(t).ExpectEquals(diff.CountAdded, 1)
+	test.S(t).ExpectEquals(diff.CountChanged, 1)
+	test.S(t).ExpectEquals(diff.CountUnchanged, 1)
+
+	test.S(t).ExpectEquals(diff.Changes[0].Key, "db1:3306")
+	test.S(t).ExpectEquals(diff.Changes[0].Change, PromotionRuleUnchanged)
+	test.S(t).ExpectEquals(diff.Changes[1].Key, "db2:3306")
+	test.S(t).ExpectEquals(diff.Changes[1].Change, PromotionRuleChanged)
+	test.S(t).ExpectEquals(diff.Changes[1].PreviousRule, inst.PreferPromoteRule)
+	test.S(t).ExpectEquals(diff.Changes[1].PromotionRule, inst.PreferNotPromoteRule)
+	test.S(t).ExpectEquals(diff.Changes[2].Key, "db3:3306")
+	test.S(t).ExpectEquals(diff.Changes[2].Change, PromotionRuleAdded)
+	test.S(t).ExpectEquals(diff.Changes[2].PreviousRule, inst.CandidatePromotionRule(""))
+}
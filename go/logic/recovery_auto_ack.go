@@ -0,0 +1,146 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/inst"
+	orcraft "github.com/openark/orchestrator/go/raft"
+)
+
+// AutoAcknowledgeOwner is the owner of acknowledgements made by AutoAcknowledgeRecoveryRules
+const AutoAcknowledgeOwner = "orchestrator-auto-ack"
+
+// AutoAcknowledgedRecovery is a recovery acknowledged by one of AutoAcknowledgeRecoveryRules
+type AutoAcknowledgedRecovery struct {
+	RecoveryId        int64
+	UID               string
+	Analysis          inst.AnalysisCode
+	FailedInstanceKey inst.InstanceKey
+	ClusterName       string
+	IsSuccessful      bool
+	RecoveryEndTime   string
+	RuleIndex         int
+	Comment           string
+}
+
+// recoveryAckRuleMatches checks whether given rule applies to given (completed) recovery
+func recoveryAckRuleMatches(rule *config.RecoveryAckRule, topologyRecovery *TopologyRecovery) bool {
+	if rule.SuccessfulOnly && !topologyRecovery.IsSuccessful {
+		return false
+	}
+	if len(rule.Analysis) > 0 {
+		matched := false
+		for _, analysis := range rule.Analysis {
+			if inst.AnalysisCode(analysis) == topologyRecovery.AnalysisEntry.Analysis {
+				matched = true
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(rule.ClusterFilters) > 0 && !topologyRecovery.AnalysisEntry.ClusterDetails.MatchesFilters(rule.ClusterFilters) {
+		return false
+	}
+	return true
+}
+
+// recoveryAckComment returns the acknowledgement comment for a recovery matched by given rule
+func recoveryAckComment(rule *config.RecoveryAckRule, ruleIndex int, topologyRecovery *TopologyRecovery) string {
+	if rule.Comment == "" {
+		return fmt.Sprintf("auto-acknowledged by AutoAcknowledgeRecoveryRules[%d]", ruleIndex)
+	}
+	comment, _ := prepareCommand(rule.Comment, topologyRecovery)
+	return comment
+}
+
+// ReadAutoAcknowledgeableRecoveries reads unacknowledged completed recoveries matching AutoAcknowledgeRecoveryRules.
+// A recovery matching multiple rules is attributed to the first of them.
+func ReadAutoAcknowledgeableRecoveries() (acknowledgeable []AutoAcknowledgedRecovery, err error) {
+	acknowledgeable = []AutoAcknowledgedRecovery{}
+	seen := make(map[int64]bool)
+	for i := range config.Config.AutoAcknowledgeRecoveryRules {
+		rule := &config.Config.AutoAcknowledgeRecoveryRules[i]
+		recoveries, err := ReadUnacknowledgedCompletedRecoveries(rule.MinAgeSeconds)
+		if err != nil {
+			return acknowledgeable, err
+		}
+		for _, topologyRecovery := range recoveries {
+			if seen[topologyRecovery.Id] || !recoveryAckRuleMatches(rule, topologyRecovery) {
+				continue
+			}
+			seen[topologyRecovery.Id] = true
+			acknowledgeable = append(acknowledgeable, AutoAcknowledgedRecovery{
+				RecoveryId:        topologyRecovery.Id,
+				UID:               topologyRecovery.UID,
+				Analysis:          topologyRecovery.AnalysisEntry.Analysis,
+				FailedInstanceKey: topologyRecovery.AnalysisEntry.AnalyzedInstanceKey,
+				ClusterName:       topologyRecovery.AnalysisEntry.ClusterDetails.ClusterName,
+				IsSuccessful:      topologyRecovery.IsSuccessful,
+				RecoveryEndTime:   topologyRecovery.RecoveryEndTimestamp,
+				RuleIndex:         i,
+				Comment:           recoveryAckComment(rule, i, topologyRecovery),
+			})
+		}
+	}
+	return acknowledgeable, nil
+}
+
+// acknowledgeRecoveryByRule acknowledges a single recovery matched by an auto-ack rule. With raft, the
+// acknowledgement is published by UID, to apply on all nodes; acknowledged then tells whether it was published.
+func acknowledgeRecoveryByRule(recovery AutoAcknowledgedRecovery) (acknowledged bool, err error) {
+	if orcraft.IsRaftEnabled() {
+		ack := NewRecoveryAcknowledgement(AutoAcknowledgeOwner, recovery.Comment)
+		ack.UID = recovery.UID
+		if _, err := orcraft.PublishCommand("ack-recovery", ack); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	count, err := AcknowledgeRecovery(recovery.RecoveryId, AutoAcknowledgeOwner, recovery.Comment)
+	return count > 0, err
+}
+
+// AutoAcknowledgeRecoveries acknowledges completed recoveries matching AutoAcknowledgeRecoveryRules, so that
+// unacknowledged recoveries are those which humans should look into. It returns the recoveries it acknowledged.
+func AutoAcknowledgeRecoveries() (acknowledged []AutoAcknowledgedRecovery, err error) {
+	acknowledged = []AutoAcknowledgedRecovery{}
+	if len(config.Config.AutoAcknowledgeRecoveryRules) == 0 {
+		return acknowledged, nil
+	}
+	acknowledgeable, err := ReadAutoAcknowledgeableRecoveries()
+	if err != nil {
+		return acknowledged, log.Errore(err)
+	}
+	for _, recovery := range acknowledgeable {
+		isAcknowledged, err := acknowledgeRecoveryByRule(recovery)
+		if err != nil {
+			return acknowledged, log.Errore(err)
+		}
+		if !isAcknowledged {
+			// acknowledged meanwhile
+			continue
+		}
+		inst.AuditOperation("auto-ack-recovery", &recovery.FailedInstanceKey, fmt.Sprintf("recovery %s (%s) acknowledged by rule %d: %s", recovery.UID, recovery.Analysis, recovery.RuleIndex, recovery.Comment))
+		acknowledged = append(acknowledged, recovery)
+	}
+	return acknowledged, nil
+}
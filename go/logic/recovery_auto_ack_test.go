@@ -0,0 +1,70 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"testing"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+func newAutoAckRecovery(analysis inst.AnalysisCode, clusterName string, clusterAlias string, isSuccessful bool) *TopologyRecovery {
+	analysisEntry := inst.ReplicationAnalysis{Analysis: analysis}
+	analysisEntry.ClusterDetails.ClusterName = clusterName
+	analysisEntry.ClusterDetails.ClusterAlias = clusterAlias
+	topologyRecovery := NewTopologyRecovery(analysisEntry)
+	topologyRecovery.IsSuccessful = isSuccessful
+	return topologyRecovery
+}
+
+func TestRecoveryAckRuleMatches(t *testing.T) {
+	successful := newAutoAckRecovery(inst.DeadIntermediateMaster, "db1:3306", "mycluster", true)
+	failed := newAutoAckRecovery(inst.DeadIntermediateMaster, "db1:3306", "mycluster", false)
+	deadMaster := newAutoAckRecovery(inst.DeadMaster, "db1:3306", "mycluster", true)
+	other := newAutoAckRecovery(inst.DeadIntermediateMaster, "db9:3306", "othercluster", true)
+
+	anyRule := &config.RecoveryAckRule{}
+	test.S(t).ExpectTrue(recoveryAckRuleMatches(anyRule, successful))
+	test.S(t).ExpectTrue(recoveryAckRuleMatches(anyRule, failed))
+	test.S(t).ExpectTrue(recoveryAckRuleMatches(anyRule, deadMaster))
+
+	successfulOnly := &config.RecoveryAckRule{SuccessfulOnly: true}
+	test.S(t).ExpectTrue(recoveryAckRuleMatches(successfulOnly, successful))
+	test.S(t).ExpectFalse(recoveryAckRuleMatches(successfulOnly, failed))
+
+	byAnalysis := &config.RecoveryAckRule{Analysis: []string{"DeadIntermediateMaster", "DeadCoMaster"}}
+	test.S(t).ExpectTrue(recoveryAckRuleMatches(byAnalysis, successful))
+	test.S(t).ExpectFalse(recoveryAckRuleMatches(byAnalysis, deadMaster))
+
+	byCluster := &config.RecoveryAckRule{ClusterFilters: []string{"alias=mycluster"}}
+	test.S(t).ExpectTrue(recoveryAckRuleMatches(byCluster, successful))
+	test.S(t).ExpectFalse(recoveryAckRuleMatches(byCluster, other))
+
+	combined := &config.RecoveryAckRule{Analysis: []string{"DeadIntermediateMaster"}, ClusterFilters: []string{"db1"}, SuccessfulOnly: true}
+	test.S(t).ExpectTrue(recoveryAckRuleMatches(combined, successful))
+	test.S(t).ExpectFalse(recoveryAckRuleMatches(combined, failed))
+	test.S(t).ExpectFalse(recoveryAckRuleMatches(combined, deadMaster))
+	test.S(t).ExpectFalse(recoveryAckRuleMatches(combined, other))
+}
+
+func TestRecoveryAckComment(t *testing.T) {
+	topologyRecovery := newAutoAckRecovery(inst.DeadIntermediateMaster, "db1:3306", "mycluster", true)
+	test.S(t).ExpectEquals(recoveryAckComment(&config.RecoveryAckRule{}, 2, topologyRecovery), "auto-acknowledged by AutoAcknowledgeRecoveryRules[2]")
+	test.S(t).ExpectEquals(recoveryAckComment(&config.RecoveryAckRule{Comment: "auto: {failureType} on {failureClusterAlias}"}, 0, topologyRecovery), "auto: DeadIntermediateMaster on mycluster")
+}
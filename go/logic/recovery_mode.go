@@ -0,0 +1,82 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+// RecoveryMode tells whether a recovery runs external hooks (full) or not (lite)
+type RecoveryMode string
+
+const (
+	FullRecoveryMode RecoveryMode = "full"
+	LiteRecoveryMode RecoveryMode = "lite"
+)
+
+// RecoverAutoResult describes a recovery run via RecoverAuto, along with the mode chosen for it
+type RecoverAutoResult struct {
+	FailedInstanceKey  inst.InstanceKey
+	Analysis           inst.AnalysisCode
+	Mode               RecoveryMode
+	ModeReason         string
+	RecoveryAttempted  bool
+	PromotedReplicaKey *inst.InstanceKey
+}
+
+// ChooseRecoveryMode decides whether a recovery for given analysis should run external hooks:
+// - master and co-master failures always get a full recovery: hooks are expected to redirect traffic to the promoted server
+// - analysis listed in LiteRecoveryAnalysis gets a lite recovery
+// - a failure on a downtimed instance gets a lite recovery: the instance is under planned maintenance
+// - any other failure gets a full recovery
+func ChooseRecoveryMode(analysisEntry *inst.ReplicationAnalysis) (mode RecoveryMode, reason string) {
+	switch analysisEntry.GetAnalysisInstanceType() {
+	case inst.AnalysisInstanceTypeMaster, inst.AnalysisInstanceTypeCoMaster:
+		return FullRecoveryMode, fmt.Sprintf("%s failure; hooks are required to redirect traffic", analysisEntry.GetAnalysisInstanceType())
+	}
+	for _, analysis := range config.Config.LiteRecoveryAnalysis {
+		if analysis == string(analysisEntry.Analysis) {
+			return LiteRecoveryMode, fmt.Sprintf("%s is listed in LiteRecoveryAnalysis", analysis)
+		}
+	}
+	if analysisEntry.IsDowntimed {
+		return LiteRecoveryMode, "instance is downtimed"
+	}
+	return FullRecoveryMode, "default"
+}
+
+// RecoverAuto recovers a failed instance, as does CheckAndRecover, choosing the recovery mode via ChooseRecoveryMode.
+// candidateInstanceKey is optional.
+func RecoverAuto(failedInstanceKey *inst.InstanceKey, candidateInstanceKey *inst.InstanceKey) (result *RecoverAutoResult, err error) {
+	replicationAnalysis, err := inst.GetReplicationAnalysis("", &inst.ReplicationAnalysisHints{IncludeDowntimed: true})
+	if err != nil {
+		return nil, err
+	}
+	for _, analysisEntry := range replicationAnalysis {
+		if !analysisEntry.AnalyzedInstanceKey.Equals(failedInstanceKey) {
+			continue
+		}
+		result = &RecoverAutoResult{FailedInstanceKey: *failedInstanceKey, Analysis: analysisEntry.Analysis}
+		result.Mode, result.ModeReason = ChooseRecoveryMode(&analysisEntry)
+		result.RecoveryAttempted, result.PromotedReplicaKey, err = CheckAndRecover(failedInstanceKey, candidateInstanceKey, result.Mode == LiteRecoveryMode)
+		return result, err
+	}
+	return nil, fmt.Errorf("RecoverAuto: no problem detected on %+v", *failedInstanceKey)
+}
@@ -0,0 +1,69 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"testing"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+func TestChooseRecoveryMode(t *testing.T) {
+	defer func(analysis []string) { config.Config.LiteRecoveryAnalysis = analysis }(config.Config.LiteRecoveryAnalysis)
+	config.Config.LiteRecoveryAnalysis = []string{string(inst.DeadIntermediateMaster), string(inst.DeadMaster)}
+
+	tests := []struct {
+		name         string
+		analysis     inst.ReplicationAnalysis
+		expectedMode RecoveryMode
+	}{
+		{
+			name:         "master failure, even if listed",
+			analysis:     inst.ReplicationAnalysis{Analysis: inst.DeadMaster, IsMaster: true},
+			expectedMode: FullRecoveryMode,
+		},
+		{
+			name:         "downtimed co-master failure",
+			analysis:     inst.ReplicationAnalysis{Analysis: inst.DeadCoMaster, IsMaster: true, IsCoMaster: true, IsDowntimed: true},
+			expectedMode: FullRecoveryMode,
+		},
+		{
+			name:         "listed analysis",
+			analysis:     inst.ReplicationAnalysis{Analysis: inst.DeadIntermediateMaster},
+			expectedMode: LiteRecoveryMode,
+		},
+		{
+			name:         "downtimed instance",
+			analysis:     inst.ReplicationAnalysis{Analysis: inst.DeadIntermediateMasterAndSomeReplicas, IsDowntimed: true},
+			expectedMode: LiteRecoveryMode,
+		},
+		{
+			name:         "default",
+			analysis:     inst.ReplicationAnalysis{Analysis: inst.DeadIntermediateMasterAndSomeReplicas},
+			expectedMode: FullRecoveryMode,
+		},
+	}
+	for _, tt := range tests {
+		mode, reason := ChooseRecoveryMode(&tt.analysis)
+		if mode != tt.expectedMode {
+			t.Errorf("%s: expected %s recovery mode, got %s (%s)", tt.name, tt.expectedMode, mode, reason)
+		}
+		test.S(t).ExpectTrue(reason != "")
+	}
+}
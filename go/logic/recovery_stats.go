@@ -0,0 +1,202 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/golib/sqlutils"
+	"github.com/openark/orchestrator/go/db"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+// recoveryStatsTopAnalyses is the number of most frequent analyses listed per cluster
+const recoveryStatsTopAnalyses = 5
+
+// AnalysisRecoveryStats counts recoveries of a single analysis
+type AnalysisRecoveryStats struct {
+	Analysis        inst.AnalysisCode
+	CountRecoveries int
+	CountSuccessful int
+}
+
+// ClusterRecoveryStats aggregates the recoveries of a cluster, or of all clusters, over a time window
+type ClusterRecoveryStats struct {
+	ClusterName              string
+	ClusterAlias             string
+	CountRecoveries          int
+	CountCompleted           int // recoveries which have ended; the rest are still running
+	CountSuccessful          int
+	CountFailed              int     // completed, unsuccessful recoveries
+	SuccessRate              float64 // successful out of completed recoveries, 0..1; 0 when none completed
+	MeanTimeToRecoverSeconds float64 // mean duration of completed recoveries
+	MaxTimeToRecoverSeconds  float64
+	TopAnalyses              []AnalysisRecoveryStats // most frequent analyses, most frequent first
+}
+
+// RecoveryStats is a report of recoveries over a time window, e.g. for SLO tracking
+type RecoveryStats struct {
+	WindowSeconds int
+	Since         time.Time
+	ComputedAt    time.Time
+	Totals        ClusterRecoveryStats
+	Clusters      []ClusterRecoveryStats // ordered by cluster name
+}
+
+// getFloat64 reads a numeric, possibly fractional, column; sqlutils.RowMap only offers integer getters
+func getFloat64(m sqlutils.RowMap, key string) float64 {
+	value, _ := strconv.ParseFloat(m.GetString(key), 64)
+	return value
+}
+
+// readClusterRecoveryStats aggregates recoveries started within given window, either per cluster or overall
+func readClusterRecoveryStats(windowSeconds int, perCluster bool) (stats []ClusterRecoveryStats, err error) {
+	selectCluster := `'' as cluster_name, '' as cluster_alias`
+	groupBy := ``
+	if perCluster {
+		selectCluster = `cluster_name, max(cluster_alias) as cluster_alias`
+		groupBy = `group by cluster_name order by cluster_name`
+	}
+	query := fmt.Sprintf(`
+		select
+			%s,
+			count(*) as count_recoveries,
+			ifnull(sum(end_recovery is not null), 0) as count_completed,
+			ifnull(sum(is_successful = 1), 0) as count_successful,
+			ifnull(sum(end_recovery is not null and is_successful = 0), 0) as count_failed,
+			ifnull(avg(case when end_recovery is not null then unix_timestamp(end_recovery) - unix_timestamp(start_active_period) end), 0) as mean_seconds,
+			ifnull(max(case when end_recovery is not null then unix_timestamp(end_recovery) - unix_timestamp(start_active_period) end), 0) as max_seconds
+		from
+			topology_recovery
+		where
+			start_active_period >= now() - interval ? second
+		%s
+		`, selectCluster, groupBy)
+	stats = []ClusterRecoveryStats{}
+	err = db.QueryOrchestrator(query, sqlutils.Args(windowSeconds), func(m sqlutils.RowMap) error {
+		clusterStats := ClusterRecoveryStats{
+			ClusterName:              m.GetString("cluster_name"),
+			ClusterAlias:             m.GetString("cluster_alias"),
+			CountRecoveries:          m.GetInt("count_recoveries"),
+			CountCompleted:           m.GetInt("count_completed"),
+			CountSuccessful:          m.GetInt("count_successful"),
+			CountFailed:              m.GetInt("count_failed"),
+			MeanTimeToRecoverSeconds: getFloat64(m, "mean_seconds"),
+			MaxTimeToRecoverSeconds:  getFloat64(m, "max_seconds"),
+			TopAnalyses:              []AnalysisRecoveryStats{},
+		}
+		if clusterStats.CountCompleted > 0 {
+			clusterStats.SuccessRate = float64(clusterStats.CountSuccessful) / float64(clusterStats.CountCompleted)
+		}
+		stats = append(stats, clusterStats)
+		return nil
+	})
+	return stats, log.Errore(err)
+}
+
+// readAnalysisRecoveryStats counts recoveries started within given window per cluster and analysis
+func readAnalysisRecoveryStats(windowSeconds int) (perCluster map[string][]AnalysisRecoveryStats, err error) {
+	query := `
+		select
+			cluster_name,
+			analysis,
+			count(*) as count_recoveries,
+			ifnull(sum(is_successful = 1), 0) as count_successful
+		from
+			topology_recovery
+		where
+			start_active_period >= now() - interval ? second
+		group by
+			cluster_name, analysis
+		`
+	perCluster = make(map[string][]AnalysisRecoveryStats)
+	err = db.QueryOrchestrator(query, sqlutils.Args(windowSeconds), func(m sqlutils.RowMap) error {
+		clusterName := m.GetString("cluster_name")
+		perCluster[clusterName] = append(perCluster[clusterName], AnalysisRecoveryStats{
+			Analysis:        inst.AnalysisCode(m.GetString("analysis")),
+			CountRecoveries: m.GetInt("count_recoveries"),
+			CountSuccessful: m.GetInt("count_successful"),
+		})
+		return nil
+	})
+	return perCluster, log.Errore(err)
+}
+
+// topAnalyses returns the most frequent of given analyses, most frequent first
+func topAnalyses(analyses []AnalysisRecoveryStats) []AnalysisRecoveryStats {
+	sorted := append([]AnalysisRecoveryStats{}, analyses...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].CountRecoveries != sorted[j].CountRecoveries {
+			return sorted[i].CountRecoveries > sorted[j].CountRecoveries
+		}
+		return sorted[i].Analysis < sorted[j].Analysis
+	})
+	if len(sorted) > recoveryStatsTopAnalyses {
+		sorted = sorted[:recoveryStatsTopAnalyses]
+	}
+	return sorted
+}
+
+// ReadRecoveryStats aggregates the recoveries started within the last given seconds: per cluster and overall
+// counts, success rates, mean time to recover and most frequent analyses. The window is bounded by the retention
+// of recovery history, as per AuditPurgeDays.
+func ReadRecoveryStats(windowSeconds int) (stats *RecoveryStats, err error) {
+	now := time.Now()
+	stats = &RecoveryStats{
+		WindowSeconds: windowSeconds,
+		Since:         now.Add(-time.Duration(windowSeconds) * time.Second),
+		ComputedAt:    now,
+		Clusters:      []ClusterRecoveryStats{},
+	}
+	totals, err := readClusterRecoveryStats(windowSeconds, false)
+	if err != nil {
+		return stats, err
+	}
+	if len(totals) > 0 {
+		stats.Totals = totals[0]
+	}
+	if stats.Clusters, err = readClusterRecoveryStats(windowSeconds, true); err != nil {
+		return stats, err
+	}
+	analysesPerCluster, err := readAnalysisRecoveryStats(windowSeconds)
+	if err != nil {
+		return stats, err
+	}
+
+	totalsPerAnalysis := make(map[inst.AnalysisCode]*AnalysisRecoveryStats)
+	for i := range stats.Clusters {
+		analyses := analysesPerCluster[stats.Clusters[i].ClusterName]
+		stats.Clusters[i].TopAnalyses = topAnalyses(analyses)
+		for _, analysis := range analyses {
+			if _, found := totalsPerAnalysis[analysis.Analysis]; !found {
+				totalsPerAnalysis[analysis.Analysis] = &AnalysisRecoveryStats{Analysis: analysis.Analysis}
+			}
+			totalsPerAnalysis[analysis.Analysis].CountRecoveries += analysis.CountRecoveries
+			totalsPerAnalysis[analysis.Analysis].CountSuccessful += analysis.CountSuccessful
+		}
+	}
+	totalAnalyses := []AnalysisRecoveryStats{}
+	for _, analysis := range totalsPerAnalysis {
+		totalAnalyses = append(totalAnalyses, *analysis)
+	}
+	stats.Totals.TopAnalyses = topAnalyses(totalAnalyses)
+	return stats, nil
+}
@@ -0,0 +1,123 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+// relocationFlapWindow is the window within which relocations of an instance are counted
+const relocationFlapWindow = time.Hour
+
+// FlapSuppressed is the result of an automated relocation refused because the instance has been relocated
+// too many times recently, suggesting oscillating automation decisions
+type FlapSuppressed struct {
+	Key            inst.InstanceKey
+	Relocations    int
+	MaxRelocations int
+	Window         time.Duration
+	RetryAfter     time.Duration
+}
+
+func (this *FlapSuppressed) Error() string {
+	return fmt.Sprintf("FlapSuppressed: %+v was relocated %d times within the last %v (max %d); retry after %v",
+		this.Key.DisplayString(), this.Relocations, this.Window, this.MaxRelocations, this.RetryAfter)
+}
+
+// RelocationFlapGuard tracks recent relocations per instance, and refuses automated relocations of an
+// instance which was relocated too many times within a window
+type RelocationFlapGuard struct {
+	mutex       sync.Mutex
+	window      time.Duration
+	relocations map[inst.InstanceKey][]time.Time
+	now         func() time.Time
+}
+
+// NewRelocationFlapGuard returns a guard counting relocations within given window
+func NewRelocationFlapGuard(window time.Duration) *RelocationFlapGuard {
+	return &RelocationFlapGuard{
+		window:      window,
+		relocations: make(map[inst.InstanceKey][]time.Time),
+		now:         time.Now,
+	}
+}
+
+// recentRelocations returns the relocations of given instance within the window, forgetting older ones.
+// It expects the mutex to be held.
+func (this *RelocationFlapGuard) recentRelocations(instanceKey inst.InstanceKey) []time.Time {
+	since := this.now().Add(-this.window)
+	recent := []time.Time{}
+	for _, relocatedAt := range this.relocations[instanceKey] {
+		if relocatedAt.After(since) {
+			recent = append(recent, relocatedAt)
+		}
+	}
+	if len(recent) == 0 {
+		delete(this.relocations, instanceKey)
+	} else {
+		this.relocations[instanceKey] = recent
+	}
+	return recent
+}
+
+// RecordRelocation registers a relocation of given instance, be it automated or not
+func (this *RelocationFlapGuard) RecordRelocation(instanceKey inst.InstanceKey) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.relocations[instanceKey] = append(this.recentRelocations(instanceKey), this.now())
+}
+
+// CheckAutomatedRelocation returns a FlapSuppressed error when given instance was relocated maxRelocations
+// times or more within the window; nil otherwise. A non-positive maxRelocations disables the check.
+func (this *RelocationFlapGuard) CheckAutomatedRelocation(instanceKey inst.InstanceKey, maxRelocations int) *FlapSuppressed {
+	if maxRelocations <= 0 {
+		return nil
+	}
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	recent := this.recentRelocations(instanceKey)
+	if len(recent) < maxRelocations {
+		return nil
+	}
+	// Another relocation is allowed once enough of the recent ones fall out of the window
+	expiring := recent[len(recent)-maxRelocations]
+	return &FlapSuppressed{
+		Key:            instanceKey,
+		Relocations:    len(recent),
+		MaxRelocations: maxRelocations,
+		Window:         this.window,
+		RetryAfter:     expiring.Add(this.window).Sub(this.now()),
+	}
+}
+
+var relocationFlapGuard = NewRelocationFlapGuard(relocationFlapWindow)
+
+// RecordRelocation registers a relocation of given instance with the relocation flap guard
+func RecordRelocation(instanceKey inst.InstanceKey) {
+	relocationFlapGuard.RecordRelocation(instanceKey)
+}
+
+// CheckAutomatedRelocation returns a FlapSuppressed error when an automated relocation of given instance
+// would exceed MaxAutomatedRelocationsPerHour; nil otherwise
+func CheckAutomatedRelocation(instanceKey inst.InstanceKey) *FlapSuppressed {
+	return relocationFlapGuard.CheckAutomatedRelocation(instanceKey, config.Config.MaxAutomatedRelocationsPerHour)
+}
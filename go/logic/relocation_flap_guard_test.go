@@ -0,0 +1,59 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"testing"
+	"time"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+func TestRelocationFlapGuard(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	guard := NewRelocationFlapGuard(time.Hour)
+	guard.now = func() time.Time { return now }
+	key := inst.InstanceKey{Hostname: "replica1", Port: 3306}
+	otherKey := inst.InstanceKey{Hostname: "replica2", Port: 3306}
+
+	test.S(t).ExpectTrue(guard.CheckAutomatedRelocation(key, 2) == nil)
+	guard.RecordRelocation(key)
+	now = now.Add(10 * time.Minute)
+	test.S(t).ExpectTrue(guard.CheckAutomatedRelocation(key, 2) == nil)
+	guard.RecordRelocation(key)
+	now = now.Add(10 * time.Minute)
+
+	suppressed := guard.CheckAutomatedRelocation(key, 2)
+	test.S(t).ExpectNotNil(suppressed)
+	test.S(t).ExpectEquals(suppressed.Relocations, 2)
+	test.S(t).ExpectEquals(suppressed.MaxRelocations, 2)
+	test.S(t).ExpectEquals(suppressed.RetryAfter, 40*time.Minute)
+	test.S(t).ExpectTrue(guard.CheckAutomatedRelocation(otherKey, 2) == nil)
+	test.S(t).ExpectTrue(guard.CheckAutomatedRelocation(key, 3) == nil)
+	test.S(t).ExpectTrue(guard.CheckAutomatedRelocation(key, 0) == nil)
+
+	// The first relocation falls out of the window
+	now = now.Add(41 * time.Minute)
+	test.S(t).ExpectTrue(guard.CheckAutomatedRelocation(key, 2) == nil)
+	test.S(t).ExpectEquals(len(guard.relocations[key]), 1)
+
+	now = now.Add(time.Hour)
+	test.S(t).ExpectTrue(guard.CheckAutomatedRelocation(key, 1) == nil)
+	_, tracked := guard.relocations[key]
+	test.S(t).ExpectFalse(tracked)
+}
@@ -50,7 +50,11 @@ type SnapshotData struct {
 	Detections,
 	KVStore,
 	Recovery,
-	RecoverySteps sqlutils.NamedResultData
+	RecoverySteps,
+	ClusterLocks,
+	RecoveryFilters,
+	MetadataOverrides,
+	ClusterRecoveryDisables sqlutils.NamedResultData
 
 	LeaderURI string
 }
@@ -102,6 +106,10 @@ func CreateSnapshotData() *SnapshotData {
 	readTableData("topology_recovery", &snapshotData.Recovery)
 	readTableData("topology_recovery_steps", &snapshotData.RecoverySteps)
 	readTableData("cluster_injected_pseudo_gtid", &snapshotData.InjectedPseudoGTIDClusters)
+	readTableData("cluster_lock", &snapshotData.ClusterLocks)
+	readTableData("recovery_filter", &snapshotData.RecoveryFilters)
+	readTableData("database_instance_metadata_override", &snapshotData.MetadataOverrides)
+	readTableData("cluster_recovery_disable", &snapshotData.ClusterRecoveryDisables)
 
 	log.Debugf("raft snapshot data created")
 	return snapshotData
@@ -208,6 +216,10 @@ func (this *SnapshotDataCreatorApplier) Restore(rc io.ReadCloser) error {
 	writeTableData("topology_failure_detection", &snapshotData.Detections)
 	writeTableData("topology_recovery_steps", &snapshotData.RecoverySteps)
 	writeTableData("cluster_injected_pseudo_gtid", &snapshotData.InjectedPseudoGTIDClusters)
+	writeTableData("cluster_lock", &snapshotData.ClusterLocks)
+	writeTableData("recovery_filter", &snapshotData.RecoveryFilters)
+	writeTableData("database_instance_metadata_override", &snapshotData.MetadataOverrides)
+	writeTableData("cluster_recovery_disable", &snapshotData.ClusterRecoveryDisables)
 
 	// recovery disable
 	{
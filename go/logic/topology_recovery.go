@@ -82,11 +82,13 @@ func NewInternalAcknowledgement() *RecoveryAcknowledgement {
 
 // BlockedTopologyRecovery represents an entry in the blocked_topology_recovery table
 type BlockedTopologyRecovery struct {
-	FailedInstanceKey    inst.InstanceKey
-	ClusterName          string
-	Analysis             inst.AnalysisCode
-	LastBlockedTimestamp string
-	BlockingRecoveryId   int64
+	FailedInstanceKey     inst.InstanceKey
+	ClusterName           string
+	Analysis              inst.AnalysisCode
+	FirstBlockedTimestamp string
+	LastBlockedTimestamp  string
+	BlockedSeconds        int64
+	BlockingRecoveryId    int64
 }
 
 // TopologyRecovery represents an entry in the topology_recovery table
@@ -255,6 +257,15 @@ func resolveRecovery(topologyRecovery *TopologyRecovery, successorInstance *inst
 		topologyRecovery.IsSuccessful = true
 		// Assign the current Binlog Coordinates of Successor Instance
 		topologyRecovery.SuccessorBinlogCoordinates = &successorInstance.SelfBinlogCoordinates
+		if topologyRecovery.AnalysisEntry.IsMaster || topologyRecovery.AnalysisEntry.IsCoMaster {
+			inst.PublishDomainEvent(inst.DomainEvent{
+				Type:        inst.MasterPromotedEvent,
+				AuditType:   string(topologyRecovery.AnalysisEntry.Analysis),
+				Key:         successorInstance.Key,
+				ClusterName: topologyRecovery.AnalysisEntry.ClusterDetails.ClusterName,
+				Message:     fmt.Sprintf("promoted in place of %+v", topologyRecovery.AnalysisEntry.AnalyzedInstanceKey),
+			})
+		}
 	}
 	if orcraft.IsRaftEnabled() {
 		_, err := orcraft.PublishCommand("resolve-recovery", topologyRecovery)
@@ -526,6 +537,7 @@ func recoverDeadMaster(topologyRecovery *TopologyRecovery, candidateInstanceKey
 		}
 	}
 
+	runPreFailoverHooks(topologyRecovery, failedInstanceKey)
 	AuditTopologyRecovery(topologyRecovery, fmt.Sprintf("RecoverDeadMaster: will recover %+v", *failedInstanceKey))
 
 	topologyRecovery.RecoveryType = GetMasterRecoveryType(analysisEntry)
@@ -947,6 +959,7 @@ func checkAndRecoverDeadMaster(analysisEntry inst.ReplicationAnalysis, candidate
 			err := kv.DistributePairs(kvPairs)
 			log.Errore(err)
 		}
+		runPostFailoverHooks(topologyRecovery, &promotedReplica.Key, &analysisEntry.AnalyzedInstanceKey)
 		if config.Config.MasterFailoverDetachReplicaMasterHost {
 			postponedFunction := func() error {
 				AuditTopologyRecovery(topologyRecovery, fmt.Sprintf("- RecoverDeadMaster: detaching master host on promoted master"))
@@ -1865,6 +1878,21 @@ func executeCheckAndRecoverFunction(analysisEntry inst.ReplicationAnalysis, cand
 			"skipProcesses: %v: recoveries disabled globally but forcing this recovery",
 			analysisEntry.Analysis, analysisEntry.AnalyzedInstanceKey, candidateInstanceKey, skipProcesses)
 	}
+	if clusterRecoveryDisable, derr := inst.ReadCachedClusterRecoveryDisable(analysisEntry.ClusterDetails.ClusterName); derr != nil {
+		log.Errorf("Unable to determine if recovery is disabled on cluster %s: %v", analysisEntry.ClusterDetails.ClusterName, derr)
+	} else if clusterRecoveryDisable != nil {
+		if skipRecoveryOnDisabledCluster(clusterRecoveryDisable, forceInstanceRecovery) {
+			log.Infof("CheckAndRecover: Analysis: %+v, InstanceKey: %+v, candidateInstanceKey: %+v, "+
+				"skipProcesses: %v: NOT Recovering host (disabled for cluster %s by %s: %s)",
+				analysisEntry.Analysis, analysisEntry.AnalyzedInstanceKey, candidateInstanceKey, skipProcesses,
+				clusterRecoveryDisable.ClusterName, clusterRecoveryDisable.Owner, clusterRecoveryDisable.Reason)
+
+			return false, nil, err
+		}
+		log.Infof("CheckAndRecover: Analysis: %+v, InstanceKey: %+v, candidateInstanceKey: %+v, "+
+			"skipProcesses: %v: recoveries disabled for cluster %s but forcing this recovery",
+			analysisEntry.Analysis, analysisEntry.AnalyzedInstanceKey, candidateInstanceKey, skipProcesses, clusterRecoveryDisable.ClusterName)
+	}
 
 	// Actually attempt recovery:
 	if isActionableRecovery || util.ClearToLog("executeCheckAndRecoverFunction: recovery", analysisEntry.AnalyzedInstanceKey.StringCode()) {
@@ -1975,18 +2003,23 @@ func ForceExecuteRecovery(analysisEntry inst.ReplicationAnalysis, candidateInsta
 	return executeCheckAndRecoverFunction(analysisEntry, candidateInstanceKey, true, skipProcesses)
 }
 
-// ForceMasterFailover *trusts* master of given cluster is dead and initiates a failover
+// ForceMasterFailover *trusts* master of given cluster is dead and initiates a failover.
+// In a master-master setup, the active co-master is failed over as a dead co-master.
 func ForceMasterFailover(clusterName string) (topologyRecovery *TopologyRecovery, err error) {
-	clusterMasters, err := inst.ReadClusterMaster(clusterName)
+	clusterHeads, err := inst.ReadClusterHeads(clusterName)
 	if err != nil {
 		return nil, fmt.Errorf("Cannot deduce cluster master for %+v", clusterName)
 	}
-	if len(clusterMasters) != 1 {
-		return nil, fmt.Errorf("Cannot deduce cluster master for %+v", clusterName)
+	clusterMaster, err := clusterHeads.SingleMaster()
+	if err != nil {
+		return nil, err
+	}
+	var analysisCode inst.AnalysisCode = inst.DeadMaster
+	if clusterHeads.IsCoMaster {
+		analysisCode = inst.DeadCoMaster
 	}
-	clusterMaster := clusterMasters[0]
 
-	analysisEntry, err := forceAnalysisEntry(clusterName, inst.DeadMaster, inst.ForceMasterFailoverCommandHint, &clusterMaster.Key)
+	analysisEntry, err := forceAnalysisEntry(clusterName, analysisCode, inst.ForceMasterFailoverCommandHint, &clusterMaster.Key)
 	if err != nil {
 		return nil, err
 	}
@@ -2087,14 +2120,17 @@ func getGracefulMasterTakeoverDesignatedInstance(clusterMasterKey *inst.Instance
 // for the designated replica to catch up with last position.
 // It will point old master at the newly promoted master at the correct coordinates, but will not start replication.
 func GracefulMasterTakeover(clusterName string, designatedKey *inst.InstanceKey, auto bool) (topologyRecovery *TopologyRecovery, promotedMasterCoordinates *inst.BinlogCoordinates, err error) {
-	clusterMasters, err := inst.ReadClusterMaster(clusterName)
+	clusterHeads, err := inst.ReadClusterHeads(clusterName)
 	if err != nil {
 		return nil, nil, fmt.Errorf("Cannot deduce cluster master for %+v; error: %+v", clusterName, err)
 	}
-	if len(clusterMasters) != 1 {
-		return nil, nil, fmt.Errorf("Cannot deduce cluster master for %+v. Found %+v potential masters", clusterName, len(clusterMasters))
+	clusterMaster, err := clusterHeads.SingleMaster()
+	if err != nil {
+		return nil, nil, err
+	}
+	if clusterHeads.IsCoMaster {
+		return nil, nil, fmt.Errorf("GracefulMasterTakeover: %+v has co-masters %+v (active) and %+v (passive); graceful takeover applies to single masters. To switch writes between co-masters, set %+v read-only, then set %+v writable", clusterName, clusterMaster.Key, clusterHeads.Passive.Key, clusterMaster.Key, clusterHeads.Passive.Key)
 	}
-	clusterMaster := clusterMasters[0]
 
 	clusterMasterDirectReplicas, err := inst.ReadReplicaInstances(&clusterMaster.Key)
 	if err != nil {
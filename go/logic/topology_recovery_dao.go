@@ -270,13 +270,15 @@ func ClearActiveRecoveries() error {
 // Recoveries are blocked through the in_active_period flag, which comes to avoid flapping.
 func RegisterBlockedRecoveries(analysisEntry *inst.ReplicationAnalysis, blockingRecoveries []*TopologyRecovery) error {
 	for _, recovery := range blockingRecoveries {
+		// Insert, then update, rather than upsert: first_blocked_timestamp must survive subsequent registrations
 		_, err := db.ExecOrchestrator(`
-			insert
+			insert ignore
 				into blocked_topology_recovery (
 					hostname,
 					port,
 					cluster_name,
 					analysis,
+					first_blocked_timestamp,
 					last_blocked_timestamp,
 					blocking_recovery_id
 				) values (
@@ -285,13 +287,9 @@ func RegisterBlockedRecoveries(analysisEntry *inst.ReplicationAnalysis, blocking
 					?,
 					?,
 					NOW(),
+					NOW(),
 					?
 				)
-				on duplicate key update
-					cluster_name=values(cluster_name),
-					analysis=values(analysis),
-					last_blocked_timestamp=values(last_blocked_timestamp),
-					blocking_recovery_id=values(blocking_recovery_id)
 			`, analysisEntry.AnalyzedInstanceKey.Hostname,
 			analysisEntry.AnalyzedInstanceKey.Port,
 			analysisEntry.ClusterDetails.ClusterName,
@@ -301,6 +299,27 @@ func RegisterBlockedRecoveries(analysisEntry *inst.ReplicationAnalysis, blocking
 		if err != nil {
 			log.Errore(err)
 		}
+		_, err = db.ExecOrchestrator(`
+			update
+				blocked_topology_recovery
+			set
+				cluster_name=?,
+				analysis=?,
+				first_blocked_timestamp=case when first_blocked_timestamp < '1971-01-02 00:00:00' then NOW() else first_blocked_timestamp end,
+				last_blocked_timestamp=NOW(),
+				blocking_recovery_id=?
+			where
+				hostname=?
+				and port=?
+			`, analysisEntry.ClusterDetails.ClusterName,
+			string(analysisEntry.Analysis),
+			recovery.Id,
+			analysisEntry.AnalyzedInstanceKey.Hostname,
+			analysisEntry.AnalyzedInstanceKey.Port,
+		)
+		if err != nil {
+			log.Errore(err)
+		}
 	}
 	return nil
 }
@@ -540,7 +559,7 @@ func readRecoveries(whereCondition string, limit string, args []interface{}) ([]
 			recovery_id desc
 		%s
 		`, whereCondition, limit)
-	err := db.QueryOrchestrator(query, args, func(m sqlutils.RowMap) error {
+	err := db.QueryOrchestratorBuffered(query, args, func(m sqlutils.RowMap) error {
 		topologyRecovery := *NewTopologyRecovery(inst.ReplicationAnalysis{})
 		topologyRecovery.Id = m.GetInt64("recovery_id")
 		topologyRecovery.UID = m.GetString("uid")
@@ -664,6 +683,16 @@ func ReadRecoveryByUID(recoveryUID string) ([]*TopologyRecovery, error) {
 	return readRecoveries(whereClause, ``, sqlutils.Args(recoveryUID))
 }
 
+// ReadUnacknowledgedCompletedRecoveries reads unacknowledged recoveries which completed at least given number of seconds ago
+func ReadUnacknowledgedCompletedRecoveries(completedSeconds uint) ([]*TopologyRecovery, error) {
+	whereClause := `
+		where
+			acknowledged = 0
+			and end_recovery is not null
+			and end_recovery <= NOW() - interval ? second`
+	return readRecoveries(whereClause, ``, sqlutils.Args(completedSeconds))
+}
+
 // ReadCRecoveries reads latest recovery entries from topology_recovery
 func ReadRecentRecoveries(clusterName string, clusterAlias string, unacknowledgedOnly bool, page int) ([]*TopologyRecovery, error) {
 	whereConditions := []string{}
@@ -690,7 +719,7 @@ func ReadRecentRecoveries(clusterName string, clusterAlias string, unacknowledge
 }
 
 // readRecoveries reads recovery entry/audit entries from topology_recovery
-func readFailureDetections(whereCondition string, limit string, args []interface{}) ([]*TopologyRecovery, error) {
+func readFailureDetections(whereCondition string, orderDirection string, limit string, args []interface{}) ([]*TopologyRecovery, error) {
 	res := []*TopologyRecovery{}
 	query := fmt.Sprintf(`
 		select
@@ -712,10 +741,10 @@ func readFailureDetections(whereCondition string, limit string, args []interface
 			topology_failure_detection
 		%s
 		order by
-			detection_id desc
+			detection_id %s
 		%s
-		`, whereCondition, limit)
-	err := db.QueryOrchestrator(query, args, func(m sqlutils.RowMap) error {
+		`, whereCondition, orderDirection, limit)
+	err := db.QueryOrchestratorBuffered(query, args, func(m sqlutils.RowMap) error {
 		failureDetection := TopologyRecovery{}
 		failureDetection.Id = m.GetInt64("detection_id")
 
@@ -756,31 +785,59 @@ func ReadRecentFailureDetections(clusterAlias string, page int) ([]*TopologyReco
 		limit ?
 		offset ?`
 	args = append(args, config.AuditPageSize, page*config.AuditPageSize)
-	return readFailureDetections(whereClause, limit, args)
+	return readFailureDetections(whereClause, "desc", limit, args)
+}
+
+// ReadFailureDetectionsSince returns up to a page of failure detections following given detection id, oldest
+// first. Unlike paging via ReadRecentFailureDetections, this is unaffected by detections added between calls:
+// iterating with the id of the last detection read delivers each detection exactly once.
+func ReadFailureDetectionsSince(clusterAlias string, sinceDetectionId int64) ([]*TopologyRecovery, error) {
+	whereClause := `where detection_id > ?`
+	args := sqlutils.Args(sinceDetectionId)
+	if clusterAlias != "" {
+		whereClause = `where detection_id > ? and cluster_alias = ?`
+		args = append(args, clusterAlias)
+	}
+	limit := `
+		limit ?`
+	args = append(args, config.AuditPageSize)
+	return readFailureDetections(whereClause, "asc", limit, args)
 }
 
 // ReadFailureDetection
 func ReadFailureDetection(detectionId int64) ([]*TopologyRecovery, error) {
 	whereClause := `where detection_id = ?`
-	return readFailureDetections(whereClause, ``, sqlutils.Args(detectionId))
+	return readFailureDetections(whereClause, "desc", ``, sqlutils.Args(detectionId))
 }
 
 // ReadBlockedRecoveries reads blocked recovery entries, potentially filtered by cluster name (empty to unfilter)
 func ReadBlockedRecoveries(clusterName string) ([]BlockedTopologyRecovery, error) {
-	res := []BlockedTopologyRecovery{}
 	whereClause := ""
 	args := sqlutils.Args()
 	if clusterName != "" {
 		whereClause = `where cluster_name = ?`
 		args = append(args, clusterName)
 	}
+	return readBlockedRecoveries(whereClause, args)
+}
+
+// ReadBlockedRecoveriesOlderThan reads blocked recovery entries which have been blocked for at least given number of seconds
+func ReadBlockedRecoveriesOlderThan(blockedSeconds int64) ([]BlockedTopologyRecovery, error) {
+	whereClause := `where first_blocked_timestamp <= NOW() - interval ? second`
+	return readBlockedRecoveries(whereClause, sqlutils.Args(blockedSeconds))
+}
+
+func readBlockedRecoveries(whereClause string, args []interface{}) ([]BlockedTopologyRecovery, error) {
+	res := []BlockedTopologyRecovery{}
 	query := fmt.Sprintf(`
 		select
 				hostname,
 				port,
 				cluster_name,
 				analysis,
+				first_blocked_timestamp,
 				last_blocked_timestamp,
+				unix_timestamp() - unix_timestamp(first_blocked_timestamp) as blocked_seconds,
 				blocking_recovery_id
 			from
 				blocked_topology_recovery
@@ -794,7 +851,9 @@ func ReadBlockedRecoveries(clusterName string) ([]BlockedTopologyRecovery, error
 		blockedTopologyRecovery.FailedInstanceKey.Port = m.GetInt("port")
 		blockedTopologyRecovery.ClusterName = m.GetString("cluster_name")
 		blockedTopologyRecovery.Analysis = inst.AnalysisCode(m.GetString("analysis"))
+		blockedTopologyRecovery.FirstBlockedTimestamp = m.GetString("first_blocked_timestamp")
 		blockedTopologyRecovery.LastBlockedTimestamp = m.GetString("last_blocked_timestamp")
+		blockedTopologyRecovery.BlockedSeconds = m.GetInt64("blocked_seconds")
 		blockedTopologyRecovery.BlockingRecoveryId = m.GetInt64("blocking_recovery_id")
 
 		res = append(res, blockedTopologyRecovery)
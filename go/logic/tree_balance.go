@@ -0,0 +1,283 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+const (
+	DefaultTreeBalanceMaxDepth        = 3
+	DefaultTreeBalanceMaxReplicaShare = 0.8
+	DefaultTreeBalanceMinReplicas     = 5
+)
+
+// TreeBalanceThresholds are the limits a replication tree is held to. Zero values stand for the defaults.
+type TreeBalanceThresholds struct {
+	MaxDepth        int     // replication depth beyond which instances are flagged
+	MaxReplicaShare float64 // share of a cluster's replicas beyond which an intermediate master is flagged
+	MinReplicas     int     // clusters with fewer replicas are not checked for imbalance
+}
+
+func (this TreeBalanceThresholds) withDefaults() TreeBalanceThresholds {
+	if this.MaxDepth <= 0 {
+		this.MaxDepth = DefaultTreeBalanceMaxDepth
+	}
+	if this.MaxReplicaShare <= 0 {
+		this.MaxReplicaShare = DefaultTreeBalanceMaxReplicaShare
+	}
+	if this.MinReplicas <= 0 {
+		this.MinReplicas = DefaultTreeBalanceMinReplicas
+	}
+	return this
+}
+
+// IntermediateMasterLoad is the fan-out of an intermediate master
+type IntermediateMasterLoad struct {
+	Key            inst.InstanceKey
+	Depth          int
+	DirectReplicas int
+	Replicas       int     // direct and indirect replicas
+	ReplicaShare   float64 // Replicas out of all the cluster's replicas
+}
+
+// SuggestedRelocation is a relocation which rebalances a replication tree, along with its outcome once applied
+type SuggestedRelocation struct {
+	Key     inst.InstanceKey
+	Below   inst.InstanceKey
+	Reason  string
+	Applied bool
+	Error   string
+}
+
+// TreeBalanceReport describes the shape of a cluster's replication tree: its depth and the fan-out of each
+// intermediate master, flags trees which are too deep or imbalanced, and suggests relocations to fix them
+type TreeBalanceReport struct {
+	ClusterName         string
+	MasterKey           inst.InstanceKey
+	Thresholds          TreeBalanceThresholds
+	CountReplicas       int
+	MaxDepth            int
+	IntermediateMasters []IntermediateMasterLoad
+	Balanced            bool
+	Alerts              []string
+	Relocations         []SuggestedRelocation
+}
+
+func (this *TreeBalanceReport) addAlert(format string, args ...interface{}) {
+	this.Alerts = append(this.Alerts, fmt.Sprintf(format, args...))
+}
+
+// treeNode is an instance placed in the replication tree
+type treeNode struct {
+	instance *inst.Instance
+	parent   *treeNode
+	children []*treeNode
+	depth    int
+	replicas int // direct and indirect replicas
+}
+
+// buildReplicationTree places given instances in a tree below given master. Co-masters replicate from each
+// other; each instance is placed once, below the first of its masters met. Children are sorted by key.
+func buildReplicationTree(master *inst.Instance, instances [](*inst.Instance)) (root *treeNode, nodes []*treeNode) {
+	replicasOf := make(map[inst.InstanceKey][](*inst.Instance))
+	for _, instance := range instances {
+		if instance.IsReplica() {
+			replicasOf[instance.MasterKey] = append(replicasOf[instance.MasterKey], instance)
+		}
+	}
+	visited := make(map[inst.InstanceKey]bool)
+	var place func(instance *inst.Instance, parent *treeNode) *treeNode
+	place = func(instance *inst.Instance, parent *treeNode) *treeNode {
+		node := &treeNode{instance: instance, parent: parent}
+		if parent != nil {
+			node.depth = parent.depth + 1
+		}
+		visited[instance.Key] = true
+		nodes = append(nodes, node)
+		replicas := replicasOf[instance.Key]
+		sort.Slice(replicas, func(i, j int) bool { return replicas[i].Key.SmallerThan(&replicas[j].Key) })
+		for _, replica := range replicas {
+			if visited[replica.Key] {
+				continue
+			}
+			child := place(replica, node)
+			node.children = append(node.children, child)
+			node.replicas += child.replicas + 1
+		}
+		return node
+	}
+	root = place(master, nil)
+	return root, nodes
+}
+
+// isMovableReplica tells whether a replica may be suggested for relocation
+func isMovableReplica(node *treeNode) bool {
+	instance := node.instance
+	return len(node.children) == 0 && instance.IsLastCheckValid && !instance.IsDowntimed && !instance.IsCoMaster && !instance.IsBinlogServer()
+}
+
+// canRelocateBelow tells whether given replica may be relocated below given target
+func canRelocateBelow(node *treeNode, target *treeNode) bool {
+	if !target.instance.IsLastCheckValid || target.instance.IsDowntimed {
+		return false
+	}
+	canReplicate, _ := node.instance.CanReplicateFrom(target.instance)
+	return canReplicate
+}
+
+// analyzeTreeBalance reports on the replication tree of given instances below given master
+func analyzeTreeBalance(master *inst.Instance, instances [](*inst.Instance), thresholds TreeBalanceThresholds) *TreeBalanceReport {
+	report := &TreeBalanceReport{
+		ClusterName:         master.ClusterName,
+		MasterKey:           master.Key,
+		Thresholds:          thresholds,
+		IntermediateMasters: []IntermediateMasterLoad{},
+		Alerts:              []string{},
+		Relocations:         []SuggestedRelocation{},
+	}
+	root, nodes := buildReplicationTree(master, instances)
+	report.CountReplicas = root.replicas
+	suggested := make(map[inst.InstanceKey]bool)
+	suggest := func(node *treeNode, below *treeNode, reason string) {
+		report.Relocations = append(report.Relocations, SuggestedRelocation{Key: node.instance.Key, Below: below.instance.Key, Reason: reason})
+		suggested[node.instance.Key] = true
+	}
+
+	for _, node := range nodes {
+		if node.depth > report.MaxDepth {
+			report.MaxDepth = node.depth
+		}
+		if node.parent == nil || len(node.children) == 0 {
+			continue
+		}
+		load := IntermediateMasterLoad{
+			Key:            node.instance.Key,
+			Depth:          node.depth,
+			DirectReplicas: len(node.children),
+			Replicas:       node.replicas,
+		}
+		load.ReplicaShare = float64(node.replicas) / float64(root.replicas)
+		report.IntermediateMasters = append(report.IntermediateMasters, load)
+
+		if root.replicas < thresholds.MinReplicas || load.ReplicaShare < thresholds.MaxReplicaShare {
+			continue
+		}
+		report.addAlert("%s carries %d of %d replicas (%.0f%%)", node.instance.Key.DisplayString(), node.replicas, root.replicas, load.ReplicaShare*100)
+		// Move direct replicas to the least loaded sibling intermediate master, or else up to the parent,
+		// until the intermediate master carries less than the max share
+		siblings := []*treeNode{}
+		for _, sibling := range node.parent.children {
+			if sibling != node && len(sibling.children) > 0 {
+				siblings = append(siblings, sibling)
+			}
+		}
+		replicas := node.replicas
+		for _, child := range node.children {
+			if float64(replicas)/float64(root.replicas) < thresholds.MaxReplicaShare {
+				break
+			}
+			if !isMovableReplica(child) {
+				continue
+			}
+			sort.SliceStable(siblings, func(i, j int) bool { return siblings[i].replicas < siblings[j].replicas })
+			target := node.parent
+			for _, sibling := range siblings {
+				if canRelocateBelow(child, sibling) {
+					target = sibling
+					break
+				}
+			}
+			if target == node.parent && !canRelocateBelow(child, target) {
+				continue
+			}
+			suggest(child, target, fmt.Sprintf("rebalance replicas of %s", node.instance.Key.DisplayString()))
+			target.replicas++
+			replicas--
+		}
+	}
+
+	for _, node := range nodes {
+		if node.depth <= thresholds.MaxDepth {
+			continue
+		}
+		report.addAlert("%s is at depth %d, beyond %d", node.instance.Key.DisplayString(), node.depth, thresholds.MaxDepth)
+		// Lift the shallowest instances beyond the max depth; their replicas follow along
+		if node.depth != thresholds.MaxDepth+1 || suggested[node.instance.Key] || node.instance.IsCoMaster {
+			continue
+		}
+		grandparent := node.parent.parent
+		if grandparent != nil && node.instance.IsLastCheckValid && !node.instance.IsDowntimed && canRelocateBelow(node, grandparent) {
+			suggest(node, grandparent, fmt.Sprintf("reduce depth below %s", node.parent.instance.Key.DisplayString()))
+		}
+	}
+	report.Balanced = len(report.Alerts) == 0
+	return report
+}
+
+// AnalyzeTreeBalance reports on the replication tree of given cluster: its depth and the fan-out of each
+// intermediate master. Trees deeper than the max depth, and intermediate masters carrying the max share of the
+// cluster's replicas or more, are flagged, along with suggested relocations which fix them: replicas of an
+// overloaded intermediate master move to the least loaded sibling intermediate master, or else up to its
+// master, and the shallowest instances beyond the max depth move up a level.
+func AnalyzeTreeBalance(clusterName string, thresholds TreeBalanceThresholds) (*TreeBalanceReport, error) {
+	thresholds = thresholds.withDefaults()
+	instances, err := inst.ReadClusterInstances(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	master := inst.GetClusterHeads(instances).Active
+	if master == nil {
+		return nil, fmt.Errorf("AnalyzeTreeBalance: cannot find master for cluster %s", clusterName)
+	}
+	return analyzeTreeBalance(master, instances, thresholds), nil
+}
+
+// RebalanceTree applies the relocations AnalyzeTreeBalance suggests for given cluster, in order. Relocations
+// are automated: instances relocated too often are skipped as flapping (see MaxAutomatedRelocationsPerHour).
+// Returns the report, with the outcome of each relocation, and an error when any relocation failed.
+func RebalanceTree(clusterName string, thresholds TreeBalanceThresholds) (*TreeBalanceReport, error) {
+	report, err := AnalyzeTreeBalance(clusterName, thresholds)
+	if err != nil {
+		return nil, err
+	}
+	countFailed := 0
+	for i := range report.Relocations {
+		relocation := &report.Relocations[i]
+		if suppressed := CheckAutomatedRelocation(relocation.Key); suppressed != nil {
+			relocation.Error = suppressed.Error()
+			countFailed++
+			continue
+		}
+		if _, err := inst.RelocateBelow(&relocation.Key, &relocation.Below); err != nil {
+			relocation.Error = err.Error()
+			countFailed++
+			continue
+		}
+		RecordRelocation(relocation.Key)
+		relocation.Applied = true
+		log.Infof("RebalanceTree: relocated %+v below %+v: %s", relocation.Key, relocation.Below, relocation.Reason)
+	}
+	if countFailed > 0 {
+		return report, fmt.Errorf("RebalanceTree: %d of %d relocations failed", countFailed, len(report.Relocations))
+	}
+	return report, nil
+}
@@ -0,0 +1,138 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"testing"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/inst"
+	"github.com/openark/orchestrator/go/topologysim"
+)
+
+var treeMasterKey = inst.InstanceKey{Hostname: "master", Port: 3306}
+
+func analyzeTopology(topology *topologysim.Topology, keys []inst.InstanceKey) *TreeBalanceReport {
+	instances := [](*inst.Instance){}
+	for _, key := range keys {
+		instances = append(instances, topology.Instance(key))
+	}
+	return analyzeTreeBalance(topology.Instance(treeMasterKey), instances, TreeBalanceThresholds{}.withDefaults())
+}
+
+// newImbalancedTopology returns a master with two intermediate masters: im1 carrying countReplicas replicas
+// and im2 carrying a single replica
+func newImbalancedTopology(t *testing.T, countReplicas int) (*topologysim.Topology, []inst.InstanceKey) {
+	topology := topologysim.NewTopology()
+	_, err := topology.AddMaster(treeMasterKey.Hostname, treeMasterKey.Port)
+	test.S(t).ExpectNil(err)
+	keys := []inst.InstanceKey{treeMasterKey}
+	add := func(hostname string, masterKey inst.InstanceKey) inst.InstanceKey {
+		replica, err := topology.AddReplica(hostname, 3306, masterKey)
+		test.S(t).ExpectNil(err)
+		keys = append(keys, replica.Key)
+		return replica.Key
+	}
+	im1 := add("im1", treeMasterKey)
+	im2 := add("im2", treeMasterKey)
+	for i := 0; i < countReplicas; i++ {
+		add(fmt.Sprintf("replica%02d", i), im1)
+	}
+	add("replica-im2", im2)
+	return topology, keys
+}
+
+func TestAnalyzeTreeBalanceBalanced(t *testing.T) {
+	topology, keys := newImbalancedTopology(t, 2)
+	report := analyzeTopology(topology, keys)
+	test.S(t).ExpectTrue(report.Balanced)
+	test.S(t).ExpectEquals(report.CountReplicas, 5)
+	test.S(t).ExpectEquals(report.MaxDepth, 2)
+	test.S(t).ExpectEquals(len(report.IntermediateMasters), 2)
+	test.S(t).ExpectEquals(report.IntermediateMasters[0].Key.Hostname, "im1")
+	test.S(t).ExpectEquals(report.IntermediateMasters[0].DirectReplicas, 2)
+	test.S(t).ExpectEquals(report.IntermediateMasters[0].Replicas, 2)
+	test.S(t).ExpectEquals(report.IntermediateMasters[0].ReplicaShare, 0.4)
+	test.S(t).ExpectEquals(len(report.Relocations), 0)
+}
+
+func TestAnalyzeTreeBalanceImbalanced(t *testing.T) {
+	topology, keys := newImbalancedTopology(t, 12)
+	report := analyzeTopology(topology, keys)
+	test.S(t).ExpectFalse(report.Balanced)
+	test.S(t).ExpectEquals(report.CountReplicas, 15)
+	test.S(t).ExpectEquals(len(report.Alerts), 1)
+	test.S(t).ExpectEquals(len(report.Relocations), 1)
+	test.S(t).ExpectEquals(report.Relocations[0].Key.Hostname, "replica00")
+	test.S(t).ExpectEquals(report.Relocations[0].Below.Hostname, "im2")
+}
+
+func TestAnalyzeTreeBalanceNoSibling(t *testing.T) {
+	topology, keys := newImbalancedTopology(t, 12)
+	// im2 no longer an intermediate master; replicas move up to the master
+	topology.Instance(keys[len(keys)-1]).IsLastCheckValid = false
+	topology.Instance(inst.InstanceKey{Hostname: "im2", Port: 3306}).IsDowntimed = true
+	report := analyzeTopology(topology, keys)
+	test.S(t).ExpectFalse(report.Balanced)
+	test.S(t).ExpectEquals(len(report.Relocations), 1)
+	test.S(t).ExpectEquals(report.Relocations[0].Below.Hostname, "master")
+}
+
+func TestAnalyzeTreeBalanceMinReplicas(t *testing.T) {
+	topology := topologysim.NewTopology()
+	_, err := topology.AddMaster(treeMasterKey.Hostname, treeMasterKey.Port)
+	test.S(t).ExpectNil(err)
+	replica, err := topology.AddReplica("im", 3306, treeMasterKey)
+	test.S(t).ExpectNil(err)
+	leaf, err := topology.AddReplica("leaf", 3306, replica.Key)
+	test.S(t).ExpectNil(err)
+
+	report := analyzeTopology(topology, []inst.InstanceKey{treeMasterKey, replica.Key, leaf.Key})
+	test.S(t).ExpectTrue(report.Balanced)
+	test.S(t).ExpectEquals(report.IntermediateMasters[0].ReplicaShare, 0.5)
+}
+
+func TestAnalyzeTreeBalanceDepth(t *testing.T) {
+	topology := topologysim.NewTopology()
+	_, err := topology.AddMaster(treeMasterKey.Hostname, treeMasterKey.Port)
+	test.S(t).ExpectNil(err)
+	keys := []inst.InstanceKey{treeMasterKey}
+	masterKey := treeMasterKey
+	for _, hostname := range []string{"a", "b", "c", "d", "e"} {
+		replica, err := topology.AddReplica(hostname, 3306, masterKey)
+		test.S(t).ExpectNil(err)
+		keys = append(keys, replica.Key)
+		masterKey = replica.Key
+	}
+	report := analyzeTopology(topology, keys)
+	test.S(t).ExpectFalse(report.Balanced)
+	test.S(t).ExpectEquals(report.MaxDepth, 5)
+	// a carries all replicas, d and e are too deep
+	test.S(t).ExpectEquals(len(report.Alerts), 3)
+	test.S(t).ExpectEquals(len(report.Relocations), 1)
+	test.S(t).ExpectEquals(report.Relocations[0].Key.Hostname, "d")
+	test.S(t).ExpectEquals(report.Relocations[0].Below.Hostname, "b")
+}
+
+func TestAnalyzeTreeBalanceCoMaster(t *testing.T) {
+	topology, keys := newImbalancedTopology(t, 2)
+	_, err := topology.MakeCoMaster(inst.InstanceKey{Hostname: "im1", Port: 3306})
+	test.S(t).ExpectNil(err)
+	report := analyzeTopology(topology, keys)
+	test.S(t).ExpectEquals(report.CountReplicas, 5)
+}
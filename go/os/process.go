@@ -17,12 +17,14 @@
 package os
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/openark/golib/log"
 	"github.com/openark/orchestrator/go/config"
@@ -66,6 +68,31 @@ func CommandRun(commandText string, env []string, arguments ...string) error {
 	return nil
 }
 
+// CommandOutput executes some text as a command, as CommandRun does, and returns its standard output.
+// The command is killed if it does not complete within given timeout. Unlike CommandRun, it does not log
+// each execution, as it is meant for commands which are frequently queried for data, rather than for hooks.
+func CommandOutput(commandText string, env []string, timeout time.Duration, arguments ...string) ([]byte, error) {
+	cmd, shellScript, err := generateShellScript(commandText, env, arguments...)
+	defer os.Remove(shellScript)
+	if err != nil {
+		return nil, log.Errore(err)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Run in a process group of its own, so that upon timeout the shell's children are killed along with it
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	timer := time.AfterFunc(timeout, func() { syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL) })
+	defer timer.Stop()
+	if err := cmd.Wait(); err != nil {
+		return stdout.Bytes(), fmt.Errorf("(%s) %s", err.Error(), strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
 // generateShellScript generates a temporary shell script based on
 // the given command to be executed, writes the command to a temporary
 // file and returns the exec.Command which can be executed together
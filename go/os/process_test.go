@@ -3,6 +3,7 @@ package os
 import (
 	"fmt"
 	"testing"
+	"time"
 )
 
 func TestCommandRun(t *testing.T) {
@@ -16,3 +17,27 @@ func TestCommandRun(t *testing.T) {
 		t.Errorf(fmt.Sprintf("Expected CommandRun to return an Error '%s' but got '%s'", expectedMsg, cmdErr.Error()))
 	}
 }
+
+func TestCommandOutput(t *testing.T) {
+	output, err := CommandOutput("echo \"VAR1=$VAR1\" && echo oops >&2", []string{"VAR1=a"}, time.Second)
+	if err != nil {
+		t.Errorf("Expected CommandOutput to succeed, got %+v", err)
+	}
+	if string(output) != "VAR1=a\n" {
+		t.Errorf("Expected output 'VAR1=a', got '%s'", output)
+	}
+
+	_, err = CommandOutput("echo oops >&2 && exit 3", []string{}, time.Second)
+	if err == nil || err.Error() != "(exit status 3) oops" {
+		t.Errorf("Expected CommandOutput to fail with stderr, got %+v", err)
+	}
+
+	start := time.Now()
+	_, err = CommandOutput("sleep 5", []string{}, 100*time.Millisecond)
+	if err == nil {
+		t.Error("Expected CommandOutput to time out")
+	}
+	if time.Since(start) > 3*time.Second {
+		t.Errorf("Expected CommandOutput to be killed upon timeout, took %v", time.Since(start))
+	}
+}
@@ -153,3 +153,20 @@ func ElectedNode() (node NodeHealth, isElected bool, err error) {
 	isElected = (node.Hostname == ThisHostname && node.Token == util.ProcessToken.Hash)
 	return node, isElected, log.Errore(err)
 }
+
+// ElectedNodeActiveSeconds returns the number of seconds the currently elected node has been continuously active
+func ElectedNodeActiveSeconds() (activeSeconds int64, err error) {
+	query := `
+		select
+			unix_timestamp() - unix_timestamp(first_seen_active) as active_seconds
+		from
+			active_node
+		where
+			anchor = 1
+		`
+	err = db.QueryOrchestratorRowsMap(query, func(m sqlutils.RowMap) error {
+		activeSeconds = m.GetInt64("active_seconds")
+		return nil
+	})
+	return activeSeconds, log.Errore(err)
+}
@@ -79,6 +79,7 @@ type HealthStatus struct {
 	Error              error
 	AvailableNodes     [](*NodeHealth)
 	RaftLeader         string
+	RaftLeaderNode     *orcraft.RaftNode
 	IsRaftLeader       bool
 	RaftLeaderURI      string
 	RaftAdvertise      string
@@ -125,6 +126,9 @@ func HealthTest() (health *HealthStatus, err error) {
 		health.ActiveNode.Hostname = orcraft.GetLeader()
 		health.IsActiveNode = orcraft.IsLeader()
 		health.RaftLeader = orcraft.GetLeader()
+		if health.RaftLeaderNode, err = orcraft.GetLeaderNode(); err != nil {
+			log.Errore(err)
+		}
 		health.RaftLeaderURI = orcraft.LeaderURI.Get()
 		health.IsRaftLeader = orcraft.IsLeader()
 		health.RaftAdvertise = config.Config.RaftAdvertise
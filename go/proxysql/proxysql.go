@@ -0,0 +1,170 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package proxysql
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+
+	"github.com/openark/golib/log"
+	"github.com/openark/golib/sqlutils"
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+// Hook updates ProxySQL's mysql_servers table upon master failover, such that the writer hostgroup
+// routes traffic to the newly promoted master.
+type Hook struct {
+	address         string
+	port            int
+	user            string
+	password        string
+	writerHostgroup uint
+	preFailover     string
+
+	connect func() (adminConnection, error)
+}
+
+// adminConnection is the subset of *sql.DB used by the hook, such that tests may substitute ProxySQL's admin interface
+type adminConnection interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+var hookMutex sync.Mutex
+var hookInitOnce sync.Once
+var hook *Hook
+
+// NewHook creates a ProxySQL hook based on configuration
+func NewHook() *Hook {
+	hook := &Hook{
+		address:         config.Config.ProxySQLAdminAddress,
+		port:            config.Config.ProxySQLAdminPort,
+		user:            config.Config.ProxySQLAdminUser,
+		password:        config.Config.ProxySQLAdminPassword,
+		writerHostgroup: config.Config.ProxySQLWriterHostgroup,
+		preFailover:     config.Config.ProxySQLPreFailoverAction,
+	}
+	hook.connect = func() (adminConnection, error) {
+		return hook.getDB()
+	}
+	return hook
+}
+
+// InitHook initializes the ProxySQL hook, once in the lifetime of this app.
+// Configuration reload does not affect a running instance.
+func InitHook() {
+	hookMutex.Lock()
+	defer hookMutex.Unlock()
+
+	hookInitOnce.Do(func() {
+		hook = NewHook()
+	})
+}
+
+// GetHook returns the ProxySQL hook, or nil if uninitialized
+func GetHook() *Hook {
+	hookMutex.Lock()
+	defer hookMutex.Unlock()
+
+	return hook
+}
+
+// Name identifies this hook in recovery audit
+func (this *Hook) Name() string {
+	return "ProxySQL"
+}
+
+// IsConfigured returns true when a ProxySQL admin address is provided
+func (this *Hook) IsConfigured() bool {
+	return this != nil && this.address != ""
+}
+
+func (this *Hook) getDB() (*sql.DB, error) {
+	// ProxySQL's admin interface does not support server side prepared statements
+	uri := fmt.Sprintf("%s:%s@tcp(%s:%d)/?interpolateParams=true&timeout=%ds",
+		this.user, this.password, this.address, this.port, config.Config.MySQLConnectTimeoutSeconds)
+	db, _, err := sqlutils.GetDB(uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+	return db, nil
+}
+
+func (this *Hook) execAndLoad(query string, args ...interface{}) error {
+	db, err := this.connect()
+	if err != nil {
+		return err
+	}
+	if _, err := db.Exec(query, args...); err != nil {
+		return err
+	}
+	if _, err := db.Exec("LOAD MYSQL SERVERS TO RUNTIME"); err != nil {
+		return err
+	}
+	if _, err := db.Exec("SAVE MYSQL SERVERS TO DISK"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// PreFailover takes the failed master out of rotation in the writer hostgroup, as per
+// ProxySQLPreFailoverAction
+func (this *Hook) PreFailover(failedMasterKey *inst.InstanceKey) error {
+	if !this.IsConfigured() || failedMasterKey == nil {
+		return nil
+	}
+	var query string
+	switch this.preFailover {
+	case "offline_soft":
+		query = `update mysql_servers set status='OFFLINE_SOFT' where hostgroup_id=? and hostname=? and port=?`
+	case "weight_zero":
+		query = `update mysql_servers set weight=0 where hostgroup_id=? and hostname=? and port=?`
+	default:
+		return nil
+	}
+	if err := this.execAndLoad(query, this.writerHostgroup, failedMasterKey.Hostname, failedMasterKey.Port); err != nil {
+		return log.Errorf("proxysql: pre-failover on %+v failed: %+v", *failedMasterKey, err)
+	}
+	log.Infof("proxysql: applied %s to %+v in hostgroup %d", this.preFailover, *failedMasterKey, this.writerHostgroup)
+	return nil
+}
+
+// PostFailover replaces the failed master with the promoted master in the writer hostgroup
+func (this *Hook) PostFailover(promotedMasterKey *inst.InstanceKey, failedMasterKey *inst.InstanceKey) error {
+	if !this.IsConfigured() || promotedMasterKey == nil {
+		return nil
+	}
+	db, err := this.connect()
+	if err != nil {
+		return log.Errore(err)
+	}
+	if failedMasterKey != nil {
+		if _, err := db.Exec(`delete from mysql_servers where hostgroup_id=? and hostname=? and port=?`,
+			this.writerHostgroup, failedMasterKey.Hostname, failedMasterKey.Port); err != nil {
+			return log.Errorf("proxysql: post-failover removal of %+v failed: %+v", *failedMasterKey, err)
+		}
+	}
+	err = this.execAndLoad(`replace into mysql_servers (hostgroup_id, hostname, port) values (?, ?, ?)`,
+		this.writerHostgroup, promotedMasterKey.Hostname, promotedMasterKey.Port)
+	if err != nil {
+		return log.Errorf("proxysql: post-failover registration of %+v failed: %+v", *promotedMasterKey, err)
+	}
+	log.Infof("proxysql: %+v is now the writer in hostgroup %d", *promotedMasterKey, this.writerHostgroup)
+	return nil
+}
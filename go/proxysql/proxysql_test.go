@@ -0,0 +1,121 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package proxysql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/openark/golib/log"
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+func init() {
+	log.SetLevel(log.ERROR)
+}
+
+var failedMasterKey = inst.InstanceKey{Hostname: "failed-master", Port: 3306}
+var promotedMasterKey = inst.InstanceKey{Hostname: "promoted-master", Port: 3306}
+
+// fakeAdmin records statements issued to ProxySQL's admin interface, failing those containing failOn
+type fakeAdmin struct {
+	statements []string
+	failOn     string
+}
+
+func (this *fakeAdmin) Exec(query string, args ...interface{}) (sql.Result, error) {
+	statement := strings.TrimSpace(fmt.Sprintf("%s %v", query, args))
+	if this.failOn != "" && strings.Contains(query, this.failOn) {
+		return nil, fmt.Errorf("ProxySQL Admin Error: %s", statement)
+	}
+	this.statements = append(this.statements, statement)
+	return nil, nil
+}
+
+func newTestHook(preFailover string, admin *fakeAdmin) *Hook {
+	return &Hook{
+		address:         "proxysql-admin",
+		port:            6032,
+		writerHostgroup: 10,
+		preFailover:     preFailover,
+		connect:         func() (adminConnection, error) { return admin, nil },
+	}
+}
+
+func TestPreFailover(t *testing.T) {
+	{
+		admin := &fakeAdmin{}
+		err := newTestHook("offline_soft", admin).PreFailover(&failedMasterKey)
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(len(admin.statements), 3)
+		test.S(t).ExpectEquals(admin.statements[0], "update mysql_servers set status='OFFLINE_SOFT' where hostgroup_id=? and hostname=? and port=? [10 failed-master 3306]")
+		test.S(t).ExpectEquals(admin.statements[1], "LOAD MYSQL SERVERS TO RUNTIME []")
+		test.S(t).ExpectEquals(admin.statements[2], "SAVE MYSQL SERVERS TO DISK []")
+	}
+	{
+		admin := &fakeAdmin{}
+		err := newTestHook("weight_zero", admin).PreFailover(&failedMasterKey)
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(admin.statements[0], "update mysql_servers set weight=0 where hostgroup_id=? and hostname=? and port=? [10 failed-master 3306]")
+	}
+	{
+		admin := &fakeAdmin{}
+		err := newTestHook("", admin).PreFailover(&failedMasterKey)
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(len(admin.statements), 0)
+	}
+	{
+		admin := &fakeAdmin{failOn: "LOAD"}
+		err := newTestHook("offline_soft", admin).PreFailover(&failedMasterKey)
+		test.S(t).ExpectNotNil(err)
+	}
+}
+
+func TestPostFailover(t *testing.T) {
+	{
+		admin := &fakeAdmin{}
+		err := newTestHook("", admin).PostFailover(&promotedMasterKey, &failedMasterKey)
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(len(admin.statements), 4)
+		test.S(t).ExpectEquals(admin.statements[0], "delete from mysql_servers where hostgroup_id=? and hostname=? and port=? [10 failed-master 3306]")
+		test.S(t).ExpectEquals(admin.statements[1], "replace into mysql_servers (hostgroup_id, hostname, port) values (?, ?, ?) [10 promoted-master 3306]")
+		test.S(t).ExpectEquals(admin.statements[2], "LOAD MYSQL SERVERS TO RUNTIME []")
+		test.S(t).ExpectEquals(admin.statements[3], "SAVE MYSQL SERVERS TO DISK []")
+	}
+	{
+		admin := &fakeAdmin{failOn: "replace"}
+		err := newTestHook("", admin).PostFailover(&promotedMasterKey, &failedMasterKey)
+		test.S(t).ExpectNotNil(err)
+		test.S(t).ExpectEquals(len(admin.statements), 1)
+	}
+}
+
+func TestUnconfiguredHook(t *testing.T) {
+	admin := &fakeAdmin{}
+	hook := newTestHook("offline_soft", admin)
+	hook.address = ""
+	test.S(t).ExpectFalse(hook.IsConfigured())
+	test.S(t).ExpectNil(hook.PreFailover(&failedMasterKey))
+	test.S(t).ExpectNil(hook.PostFailover(&promotedMasterKey, &failedMasterKey))
+	test.S(t).ExpectEquals(len(admin.statements), 0)
+
+	var nilHook *Hook
+	test.S(t).ExpectFalse(nilHook.IsConfigured())
+}
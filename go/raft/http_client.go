@@ -17,30 +17,43 @@
 package orcraft
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"net"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/openark/orchestrator/go/config"
 	"github.com/openark/orchestrator/go/ssl"
 
 	"github.com/openark/golib/log"
+	"github.com/rcrowley/go-metrics"
 )
 
 var (
-	httpClient    *http.Client
-	httpTransport *http.Transport
+	httpClient     *http.Client
+	httpTransport  *http.Transport
+	httpClientErr  error
+	httpClientOnce sync.Once
 )
 
-func GetRaftHttpTransport() (*http.Transport, error) {
-	// Checks whether there is a cached httpTransport to return:
-	if httpTransport != nil {
-		return httpTransport, nil
-	}
+var leaderURIChangesCounter = metrics.NewCounter()
+var leaderRequestsCounter = metrics.NewCounter()
+var leaderRequestFailuresCounter = metrics.NewCounter()
+var leaderURIUnknownCounter = metrics.NewCounter()
+
+func init() {
+	metrics.Register("raft.leader_uri.changes", leaderURIChangesCounter)
+	metrics.Register("raft.leader_http.requests", leaderRequestsCounter)
+	metrics.Register("raft.leader_http.failures", leaderRequestFailuresCounter)
+	metrics.Register("raft.leader_http.leader_unknown", leaderURIUnknownCounter)
+}
+
+func newRaftHttpTransport() (*http.Transport, error) {
 	httpTimeout := time.Duration(config.ActiveNodeExpireSeconds) * time.Second
 	dialTimeout := func(network, addr string) (net.Conn, error) {
 		return net.DialTimeout(network, addr, httpTimeout)
@@ -75,22 +88,43 @@ func GetRaftHttpTransport() (*http.Transport, error) {
 	return transport, nil
 }
 
-func setupHttpClient() error {
-	transport, err := GetRaftHttpTransport()
-	if err != nil {
-		return err
-	}
-	httpTransport = transport
-	httpClient = &http.Client{Transport: httpTransport}
+// GetRaftHttpTransport returns the transport by which nodes make HTTP requests to the leader. It is created
+// once, upon first use, and is shared: it is safe for concurrent use, e.g. by concurrently proxied requests.
+func GetRaftHttpTransport() (*http.Transport, error) {
+	httpClientOnce.Do(func() {
+		httpTransport, httpClientErr = newRaftHttpTransport()
+		if httpClientErr == nil {
+			httpClient = &http.Client{Transport: httpTransport}
+		}
+	})
+	return httpTransport, httpClientErr
+}
 
-	return nil
+func setupHttpClient() error {
+	_, err := GetRaftHttpTransport()
+	return err
 }
 
+// HttpGetLeader issues a GET request on given API path on the raft leader. The request,
+// including reading the response body, is bounded by ActiveNodeExpireSeconds.
 func HttpGetLeader(path string) (response []byte, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(config.ActiveNodeExpireSeconds)*time.Second)
+	defer cancel()
+	return HttpGetLeaderContext(ctx, path)
+}
+
+// HttpGetLeaderContext issues a GET request on given API path on the raft leader, bound to given context
+func HttpGetLeaderContext(ctx context.Context, path string) (response []byte, err error) {
+	if _, err := GetRaftHttpTransport(); err != nil {
+		return nil, err
+	}
+	// The leader is read once: a leader change during the request does not affect it
 	leaderURI := LeaderURI.Get()
 	if leaderURI == "" {
+		leaderURIUnknownCounter.Inc(1)
 		return nil, fmt.Errorf("Raft leader URI unknown")
 	}
+	leaderRequestsCounter.Inc(1)
 	leaderAPI := leaderURI
 	if config.Config.URLPrefix != "" {
 		// We know URLPrefix begind with "/"
@@ -100,7 +134,10 @@ func HttpGetLeader(path string) (response []byte, err error) {
 
 	url := fmt.Sprintf("%s/%s", leaderAPI, path)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
 	switch strings.ToLower(config.Config.AuthenticationMethod) {
 	case "basic", "multi":
 		req.SetBasicAuth(config.Config.HTTPAuthUser, config.Config.HTTPAuthPassword)
@@ -108,16 +145,19 @@ func HttpGetLeader(path string) (response []byte, err error) {
 
 	res, err := httpClient.Do(req)
 	if err != nil {
+		leaderRequestFailuresCounter.Inc(1)
 		return nil, err
 	}
 	defer res.Body.Close()
 
 	body, err := ioutil.ReadAll(res.Body)
 	if err != nil {
+		leaderRequestFailuresCounter.Inc(1)
 		return nil, err
 	}
 
 	if res.StatusCode != http.StatusOK {
+		leaderRequestFailuresCounter.Inc(1)
 		return body, log.Errorf("HttpGetLeader: got %d status on %s", res.StatusCode, url)
 	}
 
@@ -0,0 +1,77 @@
+package orcraft
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	test "github.com/openark/golib/tests"
+)
+
+func newTestLeader(name string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s:%s", name, r.URL.Path)
+	}))
+}
+
+func TestHttpGetLeaderUnknown(t *testing.T) {
+	LeaderURI.Set("")
+	_, err := HttpGetLeader("leader-check")
+	test.S(t).ExpectNotNil(err)
+}
+
+// TestHttpGetLeaderConcurrentLeaderChange issues concurrent requests while the leader changes; run with -race
+func TestHttpGetLeaderConcurrentLeaderChange(t *testing.T) {
+	leader1 := newTestLeader("leader1")
+	defer leader1.Close()
+	leader2 := newTestLeader("leader2")
+	defer leader2.Close()
+
+	LeaderURI.Set(leader1.URL)
+	defer LeaderURI.Set("")
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if i%2 == 0 {
+				LeaderURI.Set(leader2.URL)
+			} else {
+				LeaderURI.Set(leader1.URL)
+			}
+		}
+	}()
+
+	errs := make(chan error, 400)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				response, err := HttpGetLeaderContext(context.Background(), "leader-check")
+				if err != nil {
+					errs <- err
+					continue
+				}
+				if body := string(response); body != "leader1:/api/leader-check" && body != "leader2:/api/leader-check" {
+					errs <- fmt.Errorf("unexpected response: %s", body)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(done)
+	close(errs)
+	for err := range errs {
+		test.S(t).ExpectNil(err)
+	}
+	test.S(t).ExpectTrue(leaderURIChangesCounter.Count() > 0)
+}
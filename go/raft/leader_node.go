@@ -0,0 +1,127 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package orcraft
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/openark/orchestrator/go/config"
+)
+
+// RaftNode is a member of the raft group, as identified by its raft address
+type RaftNode struct {
+	Host   string
+	Port   int
+	IsSelf bool // this node is the orchestrator node reporting it, as identified by its RaftBind or RaftAdvertise
+}
+
+// String returns the node's address in host:port form
+func (node RaftNode) String() string {
+	return net.JoinHostPort(node.Host, strconv.Itoa(node.Port))
+}
+
+// ParseRaftNode parses a raft address, such as the leader's: "host:port", "[ipv6]:port", or a host alone, in
+// which case DefaultRaftPort applies. The returned node's IsSelf is false.
+func ParseRaftNode(address string) (node RaftNode, err error) {
+	if address == "" {
+		return node, fmt.Errorf("ParseRaftNode: empty address")
+	}
+	host, portString, err := net.SplitHostPort(address)
+	if err != nil {
+		if config.Config.DefaultRaftPort == 0 {
+			return node, fmt.Errorf("ParseRaftNode: cannot parse %q, and no DefaultRaftPort is configured: %+v", address, err)
+		}
+		host, portString = address, strconv.Itoa(config.Config.DefaultRaftPort)
+	}
+	port, err := strconv.Atoi(portString)
+	if err != nil || port <= 0 {
+		return node, fmt.Errorf("ParseRaftNode: invalid port in %q", address)
+	}
+	return RaftNode{Host: host, Port: port}, nil
+}
+
+// parseResolvedRaftNode parses a raft address as ParseRaftNode, resolving its host to an IP where possible
+func parseResolvedRaftNode(address string) (node RaftNode, err error) {
+	if node, err = ParseRaftNode(address); err != nil {
+		return node, err
+	}
+	if host, err := normalizeRaftHostnameIP(node.Host); err == nil {
+		node.Host = host
+	}
+	return node, nil
+}
+
+// matches tells whether given raft address identifies this node
+func (node RaftNode) matches(address string) bool {
+	if address == "" {
+		return false
+	}
+	other, err := parseResolvedRaftNode(address)
+	if err != nil {
+		return false
+	}
+	if other.Port != node.Port {
+		return false
+	}
+	if other.Host == node.Host {
+		return true
+	}
+	// e.g. "::1" and "0:0:0:0:0:0:0:1"
+	ip := net.ParseIP(node.Host)
+	return ip != nil && ip.Equal(net.ParseIP(other.Host))
+}
+
+// GetLeaderNode returns the raft leader as seen by this node, or nil when there is no known leader
+func GetLeaderNode() (*RaftNode, error) {
+	leader := GetLeader()
+	if leader == "" {
+		return nil, nil
+	}
+	node, err := parseResolvedRaftNode(leader)
+	if err != nil {
+		return nil, err
+	}
+	node.IsSelf = node.matches(GetRaftBind()) || node.matches(GetRaftAdvertise())
+	return &node, nil
+}
+
+// IsConnectedToRaftLeader tells whether this node is connected to a raft leader to which it can route requests:
+// either this node is the leader, or it is a healthy follower of a leader other than itself, which confirms,
+// within given context, its own health over HTTP.
+func IsConnectedToRaftLeader(ctx context.Context) bool {
+	if !IsRaftEnabled() {
+		return false
+	}
+	if IsLeader() {
+		return true
+	}
+	if !IsHealthy() {
+		return false
+	}
+	leader, err := GetLeaderNode()
+	if err != nil || leader == nil || leader.IsSelf {
+		return false
+	}
+	if LeaderURI.IsThisLeaderURI() {
+		return false
+	}
+	_, err = HttpGetLeaderContext(ctx, "raft-health")
+	return err == nil
+}
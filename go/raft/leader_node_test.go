@@ -0,0 +1,54 @@
+package orcraft
+
+import (
+	"context"
+	"testing"
+
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/config"
+)
+
+func TestParseRaftNode(t *testing.T) {
+	node, err := ParseRaftNode("10.0.0.1:10008")
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(node, RaftNode{Host: "10.0.0.1", Port: 10008})
+	test.S(t).ExpectEquals(node.String(), "10.0.0.1:10008")
+
+	node, err = ParseRaftNode("[fd00::1]:10008")
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(node.Host, "fd00::1")
+	test.S(t).ExpectEquals(node.String(), "[fd00::1]:10008")
+
+	_, err = ParseRaftNode("")
+	test.S(t).ExpectNotNil(err)
+	_, err = ParseRaftNode("10.0.0.1:port")
+	test.S(t).ExpectNotNil(err)
+
+	defaultRaftPort := config.Config.DefaultRaftPort
+	defer func() { config.Config.DefaultRaftPort = defaultRaftPort }()
+	config.Config.DefaultRaftPort = 0
+	_, err = ParseRaftNode("10.0.0.1")
+	test.S(t).ExpectNotNil(err)
+	config.Config.DefaultRaftPort = 10008
+	node, err = ParseRaftNode("10.0.0.1")
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(node.Port, 10008)
+}
+
+func TestRaftNodeMatches(t *testing.T) {
+	node := RaftNode{Host: "10.0.0.1", Port: 10008}
+	test.S(t).ExpectTrue(node.matches("10.0.0.1:10008"))
+	test.S(t).ExpectFalse(node.matches("10.0.0.1:10009"))
+	test.S(t).ExpectFalse(node.matches("10.0.0.2:10008"))
+	test.S(t).ExpectFalse(node.matches(""))
+
+	node = RaftNode{Host: "::1", Port: 10008}
+	test.S(t).ExpectTrue(node.matches("[0:0:0:0:0:0:0:1]:10008"))
+}
+
+func TestIsConnectedToRaftLeaderWithoutRaft(t *testing.T) {
+	test.S(t).ExpectFalse(IsConnectedToRaftLeader(context.Background()))
+	leader, err := GetLeaderNode()
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectTrue(leader == nil)
+}
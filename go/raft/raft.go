@@ -56,6 +56,13 @@ var healthRequestReportCache = cache.New(time.Second, time.Second)
 
 var fatalRaftErrorChan = make(chan error)
 
+// leaderObservation notes the raft leader as last seen by Monitor(), and since when
+var leaderObservation struct {
+	sync.Mutex
+	leader string
+	since  time.Time
+}
+
 type leaderURI struct {
 	uri string
 	sync.Mutex
@@ -73,6 +80,9 @@ func (luri *leaderURI) Get() string {
 func (luri *leaderURI) Set(uri string) {
 	luri.Lock()
 	defer luri.Unlock()
+	if uri != luri.uri {
+		leaderURIChangesCounter.Inc(1)
+	}
 	luri.uri = uri
 }
 
@@ -376,6 +386,27 @@ func HealthyMembers() (advertised []string) {
 	return advertised
 }
 
+// observeLeader notes given leader, resetting the observation time when leadership changes
+func observeLeader(leader string, now time.Time) {
+	leaderObservation.Lock()
+	defer leaderObservation.Unlock()
+
+	if leader != leaderObservation.leader || leaderObservation.since.IsZero() {
+		leaderObservation.leader = leader
+		leaderObservation.since = now
+	}
+}
+
+// LeaderObservedSince returns the raft leader as last observed by this node, and the time since which
+// this node has observed it as leader. Since the observation starts with this node, a restarted node
+// reports the leader as stable since its own startup at the earliest.
+func LeaderObservedSince() (leader string, since time.Time) {
+	leaderObservation.Lock()
+	defer leaderObservation.Unlock()
+
+	return leaderObservation.leader, leaderObservation.since
+}
+
 // Monitor is a utility function to routinely observe leadership state.
 // It doesn't actually do much; merely takes notes.
 func Monitor() {
@@ -386,6 +417,7 @@ func Monitor() {
 		select {
 		case <-t:
 			leaderHint := GetLeader()
+			observeLeader(leaderHint, time.Now())
 
 			if IsLeader() {
 				leaderHint = fmt.Sprintf("%s (this host)", leaderHint)
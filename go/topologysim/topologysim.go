@@ -0,0 +1,300 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package topologysim provides an in-memory simulation of replication topologies. It constructs
+// fake instance trees and applies orchestrator's own promotion and relocation rules on them, without
+// accessing any backend database or MySQL server. It is intended for unit testing automation logic.
+package topologysim
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/openark/orchestrator/go/inst"
+)
+
+const (
+	defaultVersion      = "5.7.30"
+	defaultBinlogFormat = "ROW"
+)
+
+// Topology is a simulated set of instances and their replication relationships
+type Topology struct {
+	instances    map[inst.InstanceKey]*inst.Instance
+	nextServerID uint
+}
+
+// NewTopology creates an empty simulated topology
+func NewTopology() *Topology {
+	return &Topology{
+		instances:    make(map[inst.InstanceKey]*inst.Instance),
+		nextServerID: 1,
+	}
+}
+
+func (this *Topology) newInstance(hostname string, port int) (*inst.Instance, error) {
+	key := inst.InstanceKey{Hostname: hostname, Port: port}
+	if !key.IsValid() {
+		return nil, fmt.Errorf("topologysim: invalid instance key %+v", key)
+	}
+	if _, found := this.instances[key]; found {
+		return nil, fmt.Errorf("topologysim: instance %+v already exists", key)
+	}
+	instance := inst.NewInstance()
+	instance.Key = key
+	instance.ServerID = this.nextServerID
+	instance.Version = defaultVersion
+	instance.Binlog_format = defaultBinlogFormat
+	instance.IsLastCheckValid = true
+	instance.IsUpToDate = true
+	instance.LogBinEnabled = true
+	instance.LogReplicationUpdatesEnabled = true
+	instance.PromotionRule = inst.NeutralPromoteRule
+	instance.SelfBinlogCoordinates = inst.BinlogCoordinates{LogFile: "mysql-bin.000001", LogPos: 4}
+	this.nextServerID++
+	this.instances[key] = instance
+	return instance, nil
+}
+
+// AddMaster adds a top level instance (one that does not replicate from any other)
+func (this *Topology) AddMaster(hostname string, port int) (*inst.Instance, error) {
+	instance, err := this.newInstance(hostname, port)
+	if err != nil {
+		return nil, err
+	}
+	instance.ClusterName = instance.Key.StringCode()
+	return instance, nil
+}
+
+// AddReplica adds an instance replicating from given master. The replica is fully caught up with its master.
+func (this *Topology) AddReplica(hostname string, port int, masterKey inst.InstanceKey) (*inst.Instance, error) {
+	master, found := this.instances[masterKey]
+	if !found {
+		return nil, fmt.Errorf("topologysim: unknown master %+v", masterKey)
+	}
+	instance, err := this.newInstance(hostname, port)
+	if err != nil {
+		return nil, err
+	}
+	this.attach(instance, master)
+	instance.ReadBinlogCoordinates = master.SelfBinlogCoordinates
+	instance.ExecBinlogCoordinates = master.SelfBinlogCoordinates
+	return instance, nil
+}
+
+// CloneTopology creates a simulated topology out of given instances, such as those read from the backend
+// for a production cluster. Instances are copied, so that simulated operations do not affect the originals.
+// Replication relationships are taken from each instance's MasterKey; masters outside the given instances
+// are ignored.
+func CloneTopology(instances [](*inst.Instance)) (*Topology, error) {
+	topology := NewTopology()
+	for _, instance := range instances {
+		if _, found := topology.instances[instance.Key]; found {
+			return nil, fmt.Errorf("topologysim: instance %+v already exists", instance.Key)
+		}
+		clone := *instance
+		clone.Replicas = make(map[inst.InstanceKey]bool)
+		topology.instances[clone.Key] = &clone
+		if clone.ServerID >= topology.nextServerID {
+			topology.nextServerID = clone.ServerID + 1
+		}
+	}
+	for _, instance := range topology.instances {
+		if !instance.IsReplica() {
+			continue
+		}
+		if master, found := topology.instances[instance.MasterKey]; found {
+			master.Replicas[instance.Key] = true
+		}
+	}
+	return topology, nil
+}
+
+// attach points instance to replicate from master, updating both ends of the relationship
+func (this *Topology) attach(instance *inst.Instance, master *inst.Instance) {
+	if instance.IsReplica() {
+		if oldMaster, found := this.instances[instance.MasterKey]; found {
+			delete(oldMaster.Replicas, instance.Key)
+		}
+	}
+	instance.MasterKey = master.Key
+	instance.ReplicationIOThreadRuning = true
+	instance.ReplicationSQLThreadRuning = true
+	master.Replicas[instance.Key] = true
+	this.setClusterName(instance, master.ClusterName)
+}
+
+// setClusterName sets the cluster name of given instance and of all instances replicating from it, directly
+// or indirectly. Co-masters replicate from each other, hence instances are visited once.
+func (this *Topology) setClusterName(instance *inst.Instance, clusterName string) {
+	visited := make(map[inst.InstanceKey]bool)
+	var setClusterName func(instance *inst.Instance)
+	setClusterName = func(instance *inst.Instance) {
+		if visited[instance.Key] {
+			return
+		}
+		visited[instance.Key] = true
+		instance.ClusterName = clusterName
+		for replicaKey := range instance.Replicas {
+			if replica, found := this.instances[replicaKey]; found {
+				setClusterName(replica)
+			}
+		}
+	}
+	setClusterName(instance)
+}
+
+// MakeCoMaster makes given replica of a master the master's co-master: the master replicates from it in turn
+func (this *Topology) MakeCoMaster(key inst.InstanceKey) (*inst.Instance, error) {
+	instance, found := this.instances[key]
+	if !found {
+		return nil, fmt.Errorf("topologysim: unknown instance %+v", key)
+	}
+	master, found := this.instances[instance.MasterKey]
+	if !found {
+		return nil, fmt.Errorf("topologysim: %+v is not a replica", key)
+	}
+	if master.IsReplica() {
+		return nil, fmt.Errorf("topologysim: master %+v of %+v is not a top level master", master.Key, key)
+	}
+	master.MasterKey = instance.Key
+	master.ReplicationIOThreadRuning = true
+	master.ReplicationSQLThreadRuning = true
+	master.ReadBinlogCoordinates = instance.SelfBinlogCoordinates
+	master.ExecBinlogCoordinates = instance.SelfBinlogCoordinates
+	instance.Replicas[master.Key] = true
+	master.IsCoMaster = true
+	instance.IsCoMaster = true
+	return instance, nil
+}
+
+// Instance returns the simulated instance by key, or nil when no such instance exists
+func (this *Topology) Instance(key inst.InstanceKey) *inst.Instance {
+	return this.instances[key]
+}
+
+// Replicas returns the direct replicas of given instance, sorted by key
+func (this *Topology) Replicas(masterKey inst.InstanceKey) (replicas [](*inst.Instance)) {
+	for _, instance := range this.instances {
+		if instance.IsReplica() && instance.MasterKey.Equals(&masterKey) {
+			replicas = append(replicas, instance)
+		}
+	}
+	sort.Slice(replicas, func(i, j int) bool {
+		return replicas[i].Key.SmallerThan(&replicas[j].Key)
+	})
+	return replicas
+}
+
+// SetPromotionRule sets the promotion rule of given instance, as if registered via register-candidate
+func (this *Topology) SetPromotionRule(key inst.InstanceKey, promotionRule inst.CandidatePromotionRule) error {
+	instance, found := this.instances[key]
+	if !found {
+		return fmt.Errorf("topologysim: unknown instance %+v", key)
+	}
+	instance.PromotionRule = promotionRule
+	return nil
+}
+
+// Advance moves given instance forward by given number of binlog positions: a replica executes
+// more of its master's binary logs, and any instance writes more to its own binary logs. Replicas
+// of the instance are not affected. Use this to make one replica more up to date than its siblings.
+func (this *Topology) Advance(key inst.InstanceKey, positions int64) error {
+	instance, found := this.instances[key]
+	if !found {
+		return fmt.Errorf("topologysim: unknown instance %+v", key)
+	}
+	if instance.IsReplica() {
+		instance.ExecBinlogCoordinates.LogPos += positions
+		instance.ReadBinlogCoordinates = instance.ExecBinlogCoordinates
+	}
+	instance.SelfBinlogCoordinates.LogPos += positions
+	return nil
+}
+
+// Relocate moves an instance to replicate from another, applying the same CanReplicateFrom
+// validation orchestrator applies on real topologies.
+func (this *Topology) Relocate(key inst.InstanceKey, otherKey inst.InstanceKey) (*inst.Instance, error) {
+	instance, found := this.instances[key]
+	if !found {
+		return nil, fmt.Errorf("topologysim: unknown instance %+v", key)
+	}
+	other, found := this.instances[otherKey]
+	if !found {
+		return nil, fmt.Errorf("topologysim: unknown instance %+v", otherKey)
+	}
+	// Co-masters replicate from each other: the ancestry is a cycle, hence ancestors are visited once
+	visited := make(map[inst.InstanceKey]bool)
+	for ancestor := other; ancestor != nil && ancestor.IsReplica() && !visited[ancestor.Key]; ancestor = this.instances[ancestor.MasterKey] {
+		visited[ancestor.Key] = true
+		if ancestor.MasterKey.Equals(&key) {
+			return nil, fmt.Errorf("topologysim: %+v replicates (possibly indirectly) from %+v", otherKey, key)
+		}
+	}
+	if canReplicate, err := instance.CanReplicateFrom(other); !canReplicate {
+		return nil, err
+	}
+	this.attach(instance, other)
+	return instance, nil
+}
+
+// FailoverResult describes the outcome of a simulated master failover
+type FailoverResult struct {
+	FailedMasterKey inst.InstanceKey
+	PromotedReplica *inst.Instance
+	RelocatedKeys   []inst.InstanceKey
+	LostKeys        []inst.InstanceKey
+}
+
+// Failover simulates the death of given master, followed by the promotion of the best
+// candidate among its replicas, as chosen by orchestrator's promotion logic. Siblings which
+// are able to replicate from the promoted replica are relocated below it; others are lost.
+func (this *Topology) Failover(masterKey inst.InstanceKey) (*FailoverResult, error) {
+	master, found := this.instances[masterKey]
+	if !found {
+		return nil, fmt.Errorf("topologysim: unknown instance %+v", masterKey)
+	}
+	replicas := this.Replicas(masterKey)
+	if len(replicas) == 0 {
+		return nil, fmt.Errorf("topologysim: %+v has no replicas", masterKey)
+	}
+	candidate, aheadReplicas, equalReplicas, laterReplicas, cannotReplicateReplicas, err := inst.ChooseCandidateReplica(replicas, master.DataCenter)
+	if candidate == nil || err != nil {
+		if err == nil {
+			err = fmt.Errorf("topologysim: no candidate found among replicas of %+v", masterKey)
+		}
+		return nil, err
+	}
+	result := &FailoverResult{FailedMasterKey: masterKey, PromotedReplica: candidate}
+
+	master.IsLastCheckValid = false
+	delete(master.Replicas, candidate.Key)
+	candidate.MasterKey = inst.InstanceKey{}
+	candidate.ReadBinlogCoordinates = inst.BinlogCoordinates{}
+	candidate.ReplicationIOThreadRuning = false
+	candidate.ReplicationSQLThreadRuning = false
+	candidate.ReadOnly = false
+	this.setClusterName(candidate, candidate.Key.StringCode())
+
+	for _, replica := range append(equalReplicas, laterReplicas...) {
+		this.attach(replica, candidate)
+		result.RelocatedKeys = append(result.RelocatedKeys, replica.Key)
+	}
+	for _, replica := range append(aheadReplicas, cannotReplicateReplicas...) {
+		result.LostKeys = append(result.LostKeys, replica.Key)
+	}
+	return result, nil
+}
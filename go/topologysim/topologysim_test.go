@@ -0,0 +1,157 @@
+package topologysim
+
+import (
+	"testing"
+
+	"github.com/openark/golib/log"
+	test "github.com/openark/golib/tests"
+	"github.com/openark/orchestrator/go/config"
+	"github.com/openark/orchestrator/go/inst"
+)
+
+var (
+	masterKey   = inst.InstanceKey{Hostname: "master", Port: 3306}
+	replica1Key = inst.InstanceKey{Hostname: "replica1", Port: 3306}
+	replica2Key = inst.InstanceKey{Hostname: "replica2", Port: 3306}
+	replica3Key = inst.InstanceKey{Hostname: "replica3", Port: 3306}
+)
+
+func init() {
+	config.Config.HostnameResolveMethod = "none"
+	config.MarkConfigurationLoaded()
+	log.SetLevel(log.ERROR)
+}
+
+func newTestTopology(t *testing.T) *Topology {
+	topology := NewTopology()
+	_, err := topology.AddMaster(masterKey.Hostname, masterKey.Port)
+	test.S(t).ExpectNil(err)
+	for _, key := range []inst.InstanceKey{replica1Key, replica2Key, replica3Key} {
+		_, err := topology.AddReplica(key.Hostname, key.Port, masterKey)
+		test.S(t).ExpectNil(err)
+	}
+	return topology
+}
+
+func TestAddReplica(t *testing.T) {
+	topology := newTestTopology(t)
+	test.S(t).ExpectEquals(len(topology.Replicas(masterKey)), 3)
+	test.S(t).ExpectTrue(topology.Instance(replica1Key).IsReplica())
+	test.S(t).ExpectEquals(topology.Instance(replica1Key).ClusterName, masterKey.StringCode())
+
+	_, err := topology.AddReplica(replica1Key.Hostname, replica1Key.Port, masterKey)
+	test.S(t).ExpectNotNil(err)
+}
+
+func TestRelocate(t *testing.T) {
+	topology := newTestTopology(t)
+	_, err := topology.Relocate(replica2Key, replica1Key)
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(len(topology.Replicas(masterKey)), 2)
+	test.S(t).ExpectEquals(len(topology.Replicas(replica1Key)), 1)
+
+	_, err = topology.Relocate(replica1Key, replica2Key)
+	test.S(t).ExpectNotNil(err)
+
+	topology.Instance(replica3Key).LogReplicationUpdatesEnabled = false
+	_, err = topology.Relocate(replica1Key, replica3Key)
+	test.S(t).ExpectNotNil(err)
+}
+
+func TestFailoverPromotesMostUpToDate(t *testing.T) {
+	topology := newTestTopology(t)
+	test.S(t).ExpectNil(topology.Advance(replica2Key, 100))
+
+	result, err := topology.Failover(masterKey)
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(result.PromotedReplica.Key, replica2Key)
+	test.S(t).ExpectEquals(len(result.RelocatedKeys), 2)
+	test.S(t).ExpectEquals(len(result.LostKeys), 0)
+	test.S(t).ExpectFalse(topology.Instance(replica2Key).IsReplica())
+	test.S(t).ExpectEquals(topology.Instance(replica1Key).MasterKey, replica2Key)
+	test.S(t).ExpectEquals(topology.Instance(replica1Key).ClusterName, replica2Key.StringCode())
+}
+
+func TestFailoverRespectsPromotionRules(t *testing.T) {
+	topology := newTestTopology(t)
+	test.S(t).ExpectNil(topology.SetPromotionRule(replica1Key, inst.MustNotPromoteRule))
+	test.S(t).ExpectNil(topology.SetPromotionRule(replica3Key, inst.PreferPromoteRule))
+
+	result, err := topology.Failover(masterKey)
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(result.PromotedReplica.Key, replica3Key)
+}
+
+func TestFailoverNoReplicas(t *testing.T) {
+	topology := NewTopology()
+	_, err := topology.AddMaster(masterKey.Hostname, masterKey.Port)
+	test.S(t).ExpectNil(err)
+	_, err = topology.Failover(masterKey)
+	test.S(t).ExpectNotNil(err)
+}
+
+func TestCloneTopology(t *testing.T) {
+	topology := newTestTopology(t)
+	topology.Advance(replica2Key, 10)
+	instances := [](*inst.Instance){}
+	for _, key := range []inst.InstanceKey{masterKey, replica1Key, replica2Key, replica3Key} {
+		instances = append(instances, topology.Instance(key))
+	}
+	clone, err := CloneTopology(instances)
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(len(clone.Replicas(masterKey)), 3)
+
+	result, err := clone.Failover(masterKey)
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectTrue(result.PromotedReplica.Key.Equals(&replica2Key))
+	// The original topology is unaffected
+	test.S(t).ExpectEquals(len(topology.Replicas(masterKey)), 3)
+	test.S(t).ExpectTrue(topology.Instance(replica2Key).MasterKey.Equals(&masterKey))
+
+	_, err = CloneTopology(append(instances, topology.Instance(replica1Key)))
+	test.S(t).ExpectNotNil(err)
+}
+
+func TestCoMasters(t *testing.T) {
+	topology := newTestTopology(t)
+	_, err := topology.MakeCoMaster(replica1Key)
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectTrue(topology.Instance(masterKey).IsCoMaster)
+	test.S(t).ExpectTrue(topology.Instance(masterKey).MasterKey.Equals(&replica1Key))
+	_, err = topology.MakeCoMaster(replica2Key)
+	test.S(t).ExpectNotNil(err)
+
+	// The ancestry of replica1 is a cycle, which does not include replica2
+	_, err = topology.Relocate(replica2Key, replica1Key)
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(topology.Instance(replica2Key).ClusterName, masterKey.StringCode())
+	_, err = topology.Relocate(replica3Key, replica2Key)
+	test.S(t).ExpectNil(err)
+	// replica2 replicates from the co-masters
+	_, err = topology.Relocate(replica1Key, replica2Key)
+	test.S(t).ExpectNotNil(err)
+
+	topology.setClusterName(topology.Instance(replica1Key), replica1Key.StringCode())
+	for _, key := range []inst.InstanceKey{masterKey, replica1Key, replica2Key, replica3Key} {
+		test.S(t).ExpectEquals(topology.Instance(key).ClusterName, replica1Key.StringCode())
+	}
+}
+
+func TestCoMastersCloneAndFailover(t *testing.T) {
+	topology := newTestTopology(t)
+	_, err := topology.MakeCoMaster(replica1Key)
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectNil(topology.Advance(replica1Key, 100))
+	instances := [](*inst.Instance){}
+	for _, key := range []inst.InstanceKey{masterKey, replica1Key, replica2Key, replica3Key} {
+		instances = append(instances, topology.Instance(key))
+	}
+	clone, err := CloneTopology(instances)
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(len(clone.Replicas(replica1Key)), 1)
+
+	result, err := clone.Failover(masterKey)
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectTrue(result.PromotedReplica.Key.Equals(&replica1Key))
+	test.S(t).ExpectEquals(clone.Instance(replica2Key).ClusterName, replica1Key.StringCode())
+}
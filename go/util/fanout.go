@@ -0,0 +1,142 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrFanOutItemTimeout is the error of an item which did not complete within FanOutOptions.ItemTimeout
+	ErrFanOutItemTimeout = errors.New("fan-out: item timed out")
+	// ErrFanOutDeadline is the error of an item which did not complete, or did not start, within FanOutOptions.Timeout
+	ErrFanOutDeadline = errors.New("fan-out: deadline exceeded")
+)
+
+// FanOutOptions control the execution of FanOut
+type FanOutOptions struct {
+	Concurrency int           // maximum number of items processed at once; 0 or less means no limit
+	ItemTimeout time.Duration // time allowed for each single item; 0 or less means no limit
+	Timeout     time.Duration // global deadline for the entire fan-out; 0 or less means no limit
+}
+
+// FanOutResult is the outcome of a single item of a fan-out
+type FanOutResult struct {
+	Index   int
+	Value   interface{}
+	Err     error
+	Elapsed time.Duration
+}
+
+// FanOut calls f for each of count items, concurrently and within the limits set by given options, and
+// returns one result per item, ordered by item index. Results are partial: items which fail, panic, time
+// out or never get to start due to the global deadline are reported with an error, while all others
+// retain their value. f is not interruptible, hence an item which times out keeps running in the
+// background; its eventual outcome is discarded, and its concurrency slot is released on timeout.
+func FanOut(count int, options FanOutOptions, f func(i int) (interface{}, error)) []FanOutResult {
+	results := make([]FanOutResult, count)
+	if count <= 0 {
+		return results
+	}
+	expired := make(chan struct{})
+	if options.Timeout > 0 {
+		timer := time.AfterFunc(options.Timeout, func() { close(expired) })
+		defer timer.Stop()
+	}
+	concurrency := options.Concurrency
+	if concurrency <= 0 || concurrency > count {
+		concurrency = count
+	}
+	slots := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		results[i] = FanOutResult{Index: i}
+		if !acquireFanOutSlot(slots, expired) {
+			results[i].Err = ErrFanOutDeadline
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			results[i] = runFanOutItem(i, options.ItemTimeout, expired, f)
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+// FanOutErrors returns the results of a fan-out which ended with an error
+func FanOutErrors(results []FanOutResult) (errored []FanOutResult) {
+	for _, result := range results {
+		if result.Err != nil {
+			errored = append(errored, result)
+		}
+	}
+	return errored
+}
+
+// acquireFanOutSlot waits for a concurrency slot, and returns false if the deadline expires first
+func acquireFanOutSlot(slots chan struct{}, expired chan struct{}) bool {
+	select {
+	case <-expired:
+		return false
+	default:
+	}
+	select {
+	case slots <- struct{}{}:
+		return true
+	case <-expired:
+		return false
+	}
+}
+
+// runFanOutItem runs f on a single item, giving up once the item times out or the deadline expires
+func runFanOutItem(i int, itemTimeout time.Duration, expired chan struct{}, f func(i int) (interface{}, error)) (result FanOutResult) {
+	startTime := time.Now()
+	done := make(chan FanOutResult, 1)
+	go func() {
+		result := FanOutResult{Index: i}
+		defer func() {
+			if r := recover(); r != nil {
+				result.Err = fmt.Errorf("fan-out: item %d panicked: %v", i, r)
+			}
+			done <- result
+		}()
+		result.Value, result.Err = f(i)
+	}()
+
+	var timedOut <-chan time.Time
+	if itemTimeout > 0 {
+		timer := time.NewTimer(itemTimeout)
+		defer timer.Stop()
+		timedOut = timer.C
+	}
+	select {
+	case result = <-done:
+	case <-timedOut:
+		result = FanOutResult{Index: i, Err: ErrFanOutItemTimeout}
+	case <-expired:
+		result = FanOutResult{Index: i, Err: ErrFanOutDeadline}
+	}
+	result.Elapsed = time.Since(startTime)
+	return result
+}
@@ -0,0 +1,101 @@
+package util
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	test "github.com/openark/golib/tests"
+)
+
+func TestFanOutValues(t *testing.T) {
+	results := FanOut(5, FanOutOptions{}, func(i int) (interface{}, error) {
+		return i * 10, nil
+	})
+	test.S(t).ExpectEquals(len(results), 5)
+	for i, result := range results {
+		test.S(t).ExpectEquals(result.Index, i)
+		test.S(t).ExpectNil(result.Err)
+		test.S(t).ExpectEquals(result.Value, i*10)
+	}
+	test.S(t).ExpectEquals(len(FanOutErrors(results)), 0)
+}
+
+func TestFanOutEmpty(t *testing.T) {
+	results := FanOut(0, FanOutOptions{}, func(i int) (interface{}, error) {
+		return nil, nil
+	})
+	test.S(t).ExpectEquals(len(results), 0)
+}
+
+func TestFanOutPartialResults(t *testing.T) {
+	failure := errors.New("unreachable")
+	results := FanOut(4, FanOutOptions{}, func(i int) (interface{}, error) {
+		if i == 1 {
+			return nil, failure
+		}
+		if i == 2 {
+			panic("boom")
+		}
+		return i, nil
+	})
+	test.S(t).ExpectEquals(results[0].Value, 0)
+	test.S(t).ExpectEquals(results[1].Err, failure)
+	test.S(t).ExpectNotNil(results[2].Err)
+	test.S(t).ExpectEquals(results[3].Value, 3)
+
+	errored := FanOutErrors(results)
+	test.S(t).ExpectEquals(len(errored), 2)
+	test.S(t).ExpectEquals(errored[0].Index, 1)
+	test.S(t).ExpectEquals(errored[1].Index, 2)
+}
+
+func TestFanOutConcurrency(t *testing.T) {
+	var running, maxRunning int32
+	FanOut(10, FanOutOptions{Concurrency: 3}, func(i int) (interface{}, error) {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil, nil
+	})
+	test.S(t).ExpectTrue(atomic.LoadInt32(&maxRunning) <= 3)
+	test.S(t).ExpectTrue(atomic.LoadInt32(&maxRunning) > 0)
+}
+
+func TestFanOutItemTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	results := FanOut(2, FanOutOptions{ItemTimeout: 20 * time.Millisecond}, func(i int) (interface{}, error) {
+		if i == 0 {
+			<-release
+		}
+		return i, nil
+	})
+	test.S(t).ExpectEquals(results[0].Err, ErrFanOutItemTimeout)
+	test.S(t).ExpectNil(results[1].Err)
+	test.S(t).ExpectEquals(results[1].Value, 1)
+}
+
+func TestFanOutDeadline(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	startTime := time.Now()
+	results := FanOut(3, FanOutOptions{Concurrency: 1, Timeout: 30 * time.Millisecond}, func(i int) (interface{}, error) {
+		if i == 0 {
+			return i, nil
+		}
+		<-release
+		return i, nil
+	})
+	test.S(t).ExpectTrue(time.Since(startTime) < time.Second)
+	test.S(t).ExpectNil(results[0].Err)
+	test.S(t).ExpectEquals(results[1].Err, ErrFanOutDeadline)
+	test.S(t).ExpectEquals(results[2].Err, ErrFanOutDeadline)
+}
@@ -0,0 +1,57 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package util
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// JSONLinesContentType is the content type of a JSON Lines stream
+const JSONLinesContentType = "application/x-ndjson"
+
+// JSONLinesWriter writes records as JSON Lines: one JSON document per line. Each record is flushed as soon as it
+// is written, when the underlying writer buffers (e.g. an HTTP response or a bufio.Writer), such that consumers
+// piping the stream into jq or a log pipeline see records as they are produced.
+type JSONLinesWriter struct {
+	writer  io.Writer
+	encoder *json.Encoder
+	Count   int
+}
+
+// NewJSONLinesWriter returns a writer of JSON Lines onto given writer
+func NewJSONLinesWriter(writer io.Writer) *JSONLinesWriter {
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	return &JSONLinesWriter{writer: writer, encoder: encoder}
+}
+
+// Write writes a single record, on its own line, and flushes it
+func (this *JSONLinesWriter) Write(record interface{}) error {
+	if err := this.encoder.Encode(record); err != nil {
+		return err
+	}
+	this.Count++
+	switch flusher := this.writer.(type) {
+	case http.Flusher:
+		flusher.Flush()
+	case interface{ Flush() error }:
+		return flusher.Flush()
+	}
+	return nil
+}
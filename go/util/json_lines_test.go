@@ -0,0 +1,39 @@
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	test "github.com/openark/golib/tests"
+)
+
+func TestJSONLinesWriter(t *testing.T) {
+	var buf bytes.Buffer
+	buffered := bufio.NewWriter(&buf)
+	writer := NewJSONLinesWriter(buffered)
+
+	err := writer.Write(map[string]string{"Message": "<a & b>"})
+	test.S(t).ExpectNil(err)
+	// flushed per record
+	test.S(t).ExpectEquals(buf.String(), `{"Message":"<a & b>"}`+"\n")
+
+	err = writer.Write([]int{1, 2})
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(buf.String(), `{"Message":"<a & b>"}`+"\n"+`[1,2]`+"\n")
+	test.S(t).ExpectEquals(writer.Count, 2)
+
+	err = writer.Write(func() {})
+	test.S(t).ExpectNotNil(err)
+	test.S(t).ExpectEquals(writer.Count, 2)
+}
+
+func TestJSONLinesWriterHTTP(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	writer := NewJSONLinesWriter(recorder)
+
+	test.S(t).ExpectNil(writer.Write("x"))
+	test.S(t).ExpectTrue(recorder.Flushed)
+	test.S(t).ExpectEquals(recorder.Body.String(), "\"x\"\n")
+}
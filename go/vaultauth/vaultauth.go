@@ -0,0 +1,364 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package vaultauth provides basic-authentication credentials for orchestrator's HTTP API out of a
+// HashiCorp Vault secret: a database secrets engine lease (e.g. database/creds/orchestrator-api) or a
+// KV secret (v1 or v2). Leases are renewed in the background, credentials are read afresh when a lease
+// can no longer be renewed, and requests answered with 401 are retried once with fresh credentials. The
+// lease of replaced credentials is revoked.
+// It talks to Vault's HTTP API directly and does not import orchestrator's own packages.
+package vaultauth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultUserField       = "username"
+	DefaultPasswordField   = "password"
+	DefaultRefreshInterval = 5 * time.Minute
+	minRenewInterval       = time.Second
+)
+
+// Options configure reading credentials off a Vault secret
+type Options struct {
+	Address         string        // Vault address, e.g. https://vault.example.com:8200
+	Token           string        // Vault token
+	SecretPath      string        // secret path, e.g. database/creds/orchestrator-api or secret/data/orchestrator
+	UserField       string        // secret field holding the user; defaults to DefaultUserField
+	PasswordField   string        // secret field holding the password; defaults to DefaultPasswordField
+	RefreshInterval time.Duration // re-read interval of secrets without a lease; defaults to DefaultRefreshInterval
+	Client          *http.Client  // client for Vault requests; defaults to http.DefaultClient
+}
+
+// Credentials are basic-authentication credentials read off a Vault secret, along with their lease
+type Credentials struct {
+	User          string
+	Password      string
+	LeaseID       string
+	LeaseDuration time.Duration
+	Renewable     bool
+	ReadAt        time.Time
+	generation    uint64 // incremented whenever the secret is read afresh; kept by renewals
+}
+
+// secretResponse is the part of Vault's secret and lease renewal responses used here
+type secretResponse struct {
+	LeaseID       string                 `json:"lease_id"`
+	LeaseDuration int                    `json:"lease_duration"`
+	Renewable     bool                   `json:"renewable"`
+	Data          map[string]interface{} `json:"data"`
+	Errors        []string               `json:"errors"`
+}
+
+// Provider reads and keeps credentials off a Vault secret
+type Provider struct {
+	options      Options
+	credentials  *Credentials
+	mutex        sync.Mutex
+	readMutex    sync.Mutex // serializes first reads, such that concurrent users do not each issue credentials
+	refreshMutex sync.Mutex // serializes reading the secret afresh, and revoking the lease it replaces
+}
+
+// NewProvider returns a provider reading credentials per given options. Credentials are read on first use.
+func NewProvider(options Options) *Provider {
+	options.Address = strings.TrimRight(options.Address, "/")
+	options.SecretPath = strings.Trim(options.SecretPath, "/")
+	if options.UserField == "" {
+		options.UserField = DefaultUserField
+	}
+	if options.PasswordField == "" {
+		options.PasswordField = DefaultPasswordField
+	}
+	if options.RefreshInterval <= 0 {
+		options.RefreshInterval = DefaultRefreshInterval
+	}
+	if options.Client == nil {
+		options.Client = http.DefaultClient
+	}
+	return &Provider{options: options}
+}
+
+// FromEnvironment returns a provider configured by VAULT_ADDR, VAULT_TOKEN and ORCHESTRATOR_VAULT_SECRET_PATH,
+// with optional ORCHESTRATOR_VAULT_USER_FIELD and ORCHESTRATOR_VAULT_PASSWORD_FIELD. It returns nil when
+// ORCHESTRATOR_VAULT_SECRET_PATH is not set.
+func FromEnvironment(client *http.Client) *Provider {
+	secretPath := os.Getenv("ORCHESTRATOR_VAULT_SECRET_PATH")
+	if secretPath == "" {
+		return nil
+	}
+	return NewProvider(Options{
+		Address:       os.Getenv("VAULT_ADDR"),
+		Token:         os.Getenv("VAULT_TOKEN"),
+		SecretPath:    secretPath,
+		UserField:     os.Getenv("ORCHESTRATOR_VAULT_USER_FIELD"),
+		PasswordField: os.Getenv("ORCHESTRATOR_VAULT_PASSWORD_FIELD"),
+		Client:        client,
+	})
+}
+
+// vaultRequest performs a request against Vault's HTTP API and decodes its response
+func (this *Provider) vaultRequest(ctx context.Context, method string, path string, body interface{}) (*secretResponse, error) {
+	var requestBody *bytes.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		requestBody = bytes.NewReader(encoded)
+	} else {
+		requestBody = bytes.NewReader(nil)
+	}
+	req, err := http.NewRequest(method, fmt.Sprintf("%s/v1/%s", this.options.Address, path), requestBody)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("X-Vault-Token", this.options.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := this.options.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	content, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	response := &secretResponse{}
+	if len(content) > 0 {
+		if err := json.Unmarshal(content, response); err != nil {
+			return nil, fmt.Errorf("vault %s %s: cannot parse response: %+v", method, path, err)
+		}
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault %s %s: %s %s", method, path, res.Status, strings.Join(response.Errors, "; "))
+	}
+	return response, nil
+}
+
+// credentialsOf extracts credentials out of a secret. KV v2 secrets nest their fields under "data".
+func (this *Provider) credentialsOf(secret *secretResponse) (*Credentials, error) {
+	data := secret.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+	user, _ := data[this.options.UserField].(string)
+	password, _ := data[this.options.PasswordField].(string)
+	if user == "" {
+		return nil, fmt.Errorf("vault secret %s has no %q field", this.options.SecretPath, this.options.UserField)
+	}
+	return &Credentials{
+		User:          user,
+		Password:      password,
+		LeaseID:       secret.LeaseID,
+		LeaseDuration: time.Duration(secret.LeaseDuration) * time.Second,
+		Renewable:     secret.Renewable,
+		ReadAt:        time.Now(),
+	}, nil
+}
+
+// Refresh reads the secret afresh; for a database secrets engine this issues new credentials. The lease
+// of the credentials replaced, if any, is revoked.
+func (this *Provider) Refresh(ctx context.Context) (*Credentials, error) {
+	this.refreshMutex.Lock()
+	defer this.refreshMutex.Unlock()
+
+	return this.refresh(ctx)
+}
+
+func (this *Provider) refresh(ctx context.Context) (*Credentials, error) {
+	secret, err := this.vaultRequest(ctx, http.MethodGet, this.options.SecretPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	credentials, err := this.credentialsOf(secret)
+	if err != nil {
+		return nil, err
+	}
+	this.mutex.Lock()
+	replaced := this.credentials
+	if replaced != nil {
+		credentials.generation = replaced.generation + 1
+	}
+	this.credentials = credentials
+	this.mutex.Unlock()
+
+	if replaced != nil && replaced.LeaseID != "" && replaced.LeaseID != credentials.LeaseID {
+		// Best effort: a lease which cannot be revoked still expires on its own
+		this.vaultRequest(ctx, http.MethodPut, "sys/leases/revoke", map[string]interface{}{
+			"lease_id": replaced.LeaseID,
+		})
+	}
+	return credentials, nil
+}
+
+// refreshGeneration reads the secret afresh, unless credentials were replaced since given generation, in which
+// case the current credentials are returned. Concurrent requests answered with 401 thus read the secret once.
+func (this *Provider) refreshGeneration(ctx context.Context, generation uint64) (*Credentials, error) {
+	this.refreshMutex.Lock()
+	defer this.refreshMutex.Unlock()
+
+	this.mutex.Lock()
+	current := this.credentials
+	this.mutex.Unlock()
+	if current != nil && current.generation != generation {
+		return current, nil
+	}
+	return this.refresh(ctx)
+}
+
+// Credentials returns current credentials, reading the secret if none were read yet
+func (this *Provider) Credentials(ctx context.Context) (*Credentials, error) {
+	this.readMutex.Lock()
+	defer this.readMutex.Unlock()
+
+	this.mutex.Lock()
+	credentials := this.credentials
+	this.mutex.Unlock()
+	if credentials != nil {
+		return credentials, nil
+	}
+	return this.Refresh(ctx)
+}
+
+// Renew extends the lease of current credentials. Credentials whose lease is not renewable, or which
+// Vault extends to less than half their original duration (a max TTL approaching), are read afresh.
+func (this *Provider) Renew(ctx context.Context) (*Credentials, error) {
+	credentials, err := this.Credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if credentials.LeaseID == "" || !credentials.Renewable {
+		return this.Refresh(ctx)
+	}
+	renewal, err := this.vaultRequest(ctx, http.MethodPut, "sys/leases/renew", map[string]interface{}{
+		"lease_id":  credentials.LeaseID,
+		"increment": int(credentials.LeaseDuration.Seconds()),
+	})
+	if err != nil {
+		return this.Refresh(ctx)
+	}
+	renewedDuration := time.Duration(renewal.LeaseDuration) * time.Second
+	if !renewal.Renewable || renewedDuration < credentials.LeaseDuration/2 {
+		return this.Refresh(ctx)
+	}
+	renewed := *credentials
+	renewed.LeaseDuration = renewedDuration
+	renewed.ReadAt = time.Now()
+
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.credentials = &renewed
+	return &renewed, nil
+}
+
+// renewInterval returns the time to wait before renewing given credentials: two thirds into their
+// lease, or the refresh interval for secrets without a lease
+func (this *Provider) renewInterval(credentials *Credentials) time.Duration {
+	if credentials == nil || credentials.LeaseDuration <= 0 {
+		return this.options.RefreshInterval
+	}
+	interval := credentials.LeaseDuration * 2 / 3
+	if interval < minRenewInterval {
+		interval = minRenewInterval
+	}
+	return interval
+}
+
+// Run renews credentials in the background until the context is done. Failures are reported to the
+// given function, if any, and retried on the refresh interval.
+func (this *Provider) Run(ctx context.Context, onError func(error)) {
+	credentials, err := this.Credentials(ctx)
+	for {
+		interval := this.renewInterval(credentials)
+		if err != nil {
+			if onError != nil {
+				onError(err)
+			}
+			interval = this.options.RefreshInterval
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+		credentials, err = this.Renew(ctx)
+	}
+}
+
+// Transport returns a round tripper setting basic authentication on each request out of current
+// credentials. A request answered with 401 is retried once with fresh credentials, provided its body can
+// be replayed. The secret is read afresh at most once per generation of credentials.
+func (this *Provider) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{provider: this, base: base}
+}
+
+type transport struct {
+	provider *Provider
+	base     http.RoundTripper
+}
+
+func (this *transport) roundTrip(req *http.Request, credentials *Credentials) (*http.Response, error) {
+	authenticated := req.Clone(req.Context())
+	authenticated.SetBasicAuth(credentials.User, credentials.Password)
+	return this.base.RoundTrip(authenticated)
+}
+
+func (this *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	credentials, err := this.provider.Credentials(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	res, err := this.roundTrip(req, credentials)
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, err
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return res, nil
+	}
+	refreshed, refreshErr := this.provider.refreshGeneration(req.Context(), credentials.generation)
+	if refreshErr != nil || refreshed.User == credentials.User && refreshed.Password == credentials.Password {
+		return res, nil
+	}
+	retry := req
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return res, nil
+		}
+		retry = req.Clone(req.Context())
+		retry.Body = body
+	}
+	res.Body.Close()
+	return this.roundTrip(retry, refreshed)
+}
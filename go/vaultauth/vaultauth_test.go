@@ -0,0 +1,222 @@
+package vaultauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	test "github.com/openark/golib/tests"
+)
+
+// fakeVault issues database credentials with a new password on each read, and renews leases
+// up to a max TTL
+type fakeVault struct {
+	mutex       sync.Mutex
+	reads       int
+	renewals    int
+	maxRenewals int
+	revoked     []string
+}
+
+func (this *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	if r.Header.Get("X-Vault-Token") != "root" {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"errors":["permission denied"]}`))
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/v1/database/creds/orchestrator-api":
+		this.reads++
+		fmt.Fprintf(w, `{"lease_id":"database/creds/orchestrator-api/%d","lease_duration":3600,"renewable":true,"data":{"username":"v-orc","password":"secret-%d"}}`, this.reads, this.reads)
+	case r.Method == http.MethodGet && r.URL.Path == "/v1/secret/data/orchestrator":
+		w.Write([]byte(`{"lease_duration":0,"data":{"data":{"user":"orc","pass":"kv-secret"},"metadata":{"version":3}}}`))
+	case r.Method == http.MethodPut && r.URL.Path == "/v1/sys/leases/renew":
+		request := map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&request)
+		this.renewals++
+		duration := 3600
+		if this.renewals > this.maxRenewals {
+			duration = 60
+		}
+		fmt.Fprintf(w, `{"lease_id":%q,"lease_duration":%d,"renewable":true}`, request["lease_id"], duration)
+	case r.Method == http.MethodPut && r.URL.Path == "/v1/sys/leases/revoke":
+		request := map[string]string{}
+		json.NewDecoder(r.Body).Decode(&request)
+		this.revoked = append(this.revoked, request["lease_id"])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors":[]}`))
+	}
+}
+
+func TestDatabaseCredentials(t *testing.T) {
+	vault := &fakeVault{maxRenewals: 1}
+	server := httptest.NewServer(vault)
+	defer server.Close()
+
+	ctx := context.Background()
+	provider := NewProvider(Options{Address: server.URL + "/", Token: "root", SecretPath: "/database/creds/orchestrator-api"})
+	credentials, err := provider.Credentials(ctx)
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(credentials.User, "v-orc")
+	test.S(t).ExpectEquals(credentials.Password, "secret-1")
+	test.S(t).ExpectEquals(credentials.LeaseID, "database/creds/orchestrator-api/1")
+	test.S(t).ExpectEquals(credentials.LeaseDuration, time.Hour)
+	test.S(t).ExpectEquals(provider.renewInterval(credentials), 40*time.Minute)
+
+	// renewed within max TTL: same credentials
+	credentials, err = provider.Renew(ctx)
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(credentials.Password, "secret-1")
+	test.S(t).ExpectEquals(vault.reads, 1)
+
+	// max TTL approaching: new credentials
+	credentials, err = provider.Renew(ctx)
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(credentials.Password, "secret-2")
+	test.S(t).ExpectEquals(vault.reads, 2)
+	test.S(t).ExpectEquals(len(vault.revoked), 1)
+	test.S(t).ExpectEquals(vault.revoked[0], "database/creds/orchestrator-api/1")
+}
+
+func TestKVCredentials(t *testing.T) {
+	server := httptest.NewServer(&fakeVault{})
+	defer server.Close()
+
+	provider := NewProvider(Options{Address: server.URL, Token: "root", SecretPath: "secret/data/orchestrator", UserField: "user", PasswordField: "pass"})
+	credentials, err := provider.Credentials(context.Background())
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(credentials.User, "orc")
+	test.S(t).ExpectEquals(credentials.Password, "kv-secret")
+	test.S(t).ExpectEquals(provider.renewInterval(credentials), DefaultRefreshInterval)
+
+	_, err = NewProvider(Options{Address: server.URL, Token: "root", SecretPath: "secret/data/orchestrator"}).Credentials(context.Background())
+	test.S(t).ExpectNotNil(err)
+	_, err = NewProvider(Options{Address: server.URL, Token: "wrong", SecretPath: "secret/data/orchestrator"}).Credentials(context.Background())
+	test.S(t).ExpectTrue(strings.Contains(err.Error(), "permission denied"))
+}
+
+func TestTransportReauthenticates(t *testing.T) {
+	vault := &fakeVault{}
+	vaultServer := httptest.NewServer(vault)
+	defer vaultServer.Close()
+
+	// orchestrator accepts only the most recently issued password, as if earlier ones were revoked
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, password, _ := r.BasicAuth()
+		vault.mutex.Lock()
+		current := fmt.Sprintf("secret-%d", vault.reads)
+		vault.mutex.Unlock()
+		if password != current {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"Code":"OK"}`))
+	}))
+	defer api.Close()
+
+	provider := NewProvider(Options{Address: vaultServer.URL, Token: "root", SecretPath: "database/creds/orchestrator-api"})
+	client := &http.Client{Transport: provider.Transport(nil)}
+
+	res, err := client.Get(api.URL + "/api/health")
+	test.S(t).ExpectNil(err)
+	res.Body.Close()
+	test.S(t).ExpectEquals(res.StatusCode, http.StatusOK)
+
+	// credentials rotated elsewhere
+	vault.mutex.Lock()
+	vault.reads++
+	vault.mutex.Unlock()
+
+	res, err = client.Post(api.URL+"/api/discover", "application/json", strings.NewReader(`{}`))
+	test.S(t).ExpectNil(err)
+	res.Body.Close()
+	test.S(t).ExpectEquals(res.StatusCode, http.StatusOK)
+	test.S(t).ExpectEquals(vault.reads, 3)
+}
+
+func TestTransportRefreshesOncePerGeneration(t *testing.T) {
+	vault := &fakeVault{}
+	vaultServer := httptest.NewServer(vault)
+	defer vaultServer.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, password, _ := r.BasicAuth()
+		vault.mutex.Lock()
+		current := fmt.Sprintf("secret-%d", vault.reads)
+		vault.mutex.Unlock()
+		if password != current {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"Code":"OK"}`))
+	}))
+	defer api.Close()
+
+	provider := NewProvider(Options{Address: vaultServer.URL, Token: "root", SecretPath: "database/creds/orchestrator-api"})
+	client := &http.Client{Transport: provider.Transport(nil)}
+	_, err := provider.Credentials(context.Background())
+	test.S(t).ExpectNil(err)
+
+	// credentials rotated elsewhere; concurrent requests are all answered with 401
+	vault.mutex.Lock()
+	vault.reads++
+	vault.mutex.Unlock()
+
+	var wg sync.WaitGroup
+	statusCodes := make(chan int, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := client.Get(api.URL + "/api/health")
+			if err != nil {
+				statusCodes <- 0
+				return
+			}
+			res.Body.Close()
+			statusCodes <- res.StatusCode
+		}()
+	}
+	wg.Wait()
+	close(statusCodes)
+	for statusCode := range statusCodes {
+		test.S(t).ExpectEquals(statusCode, http.StatusOK)
+	}
+	// read once upon first use, once upon the 401s
+	test.S(t).ExpectEquals(vault.reads, 3)
+	test.S(t).ExpectEquals(len(vault.revoked), 1)
+	test.S(t).ExpectEquals(vault.revoked[0], "database/creds/orchestrator-api/1")
+}
+
+// TestVaultDevServer runs against a real Vault dev server, as set up by script/test-vault. It is skipped
+// unless ORCHESTRATOR_TEST_VAULT_ADDR is set.
+func TestVaultDevServer(t *testing.T) {
+	address := os.Getenv("ORCHESTRATOR_TEST_VAULT_ADDR")
+	if address == "" {
+		t.Skip("ORCHESTRATOR_TEST_VAULT_ADDR not set")
+	}
+	token := os.Getenv("ORCHESTRATOR_TEST_VAULT_TOKEN")
+	provider := NewProvider(Options{Address: address, Token: token, SecretPath: "secret/data/orchestrator-vaultauth-test"})
+
+	_, err := provider.vaultRequest(context.Background(), http.MethodPost, "secret/data/orchestrator-vaultauth-test", map[string]interface{}{
+		"data": map[string]string{"username": "orc", "password": "dev-secret"},
+	})
+	test.S(t).ExpectNil(err)
+
+	credentials, err := provider.Renew(context.Background())
+	test.S(t).ExpectNil(err)
+	test.S(t).ExpectEquals(credentials.User, "orc")
+	test.S(t).ExpectEquals(credentials.Password, "dev-secret")
+}
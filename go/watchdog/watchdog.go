@@ -0,0 +1,420 @@
+/*
+   Copyright 2026 GitHub Inc.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package watchdog monitors the monitor: it periodically assesses the high availability of an
+// orchestrator deployment, via the HTTP API of each of its nodes. It combines backend database health
+// (as per /api/status), raft health, agreement on the elected node, and leader stability, into a single
+// assessment, and invokes callbacks upon each assessment. It requires no access to orchestrator's
+// configuration or backend database, and does not import orchestrator's own packages.
+package watchdog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Status is the overall result of an assessment
+type Status string
+
+const (
+	StatusHealthy  Status = "healthy"
+	StatusDegraded Status = "degraded"
+	StatusCritical Status = "critical"
+)
+
+// severity orders statuses, healthy first
+func (this Status) severity() int {
+	switch this {
+	case StatusHealthy:
+		return 0
+	case StatusDegraded:
+		return 1
+	}
+	return 2
+}
+
+// NodeHealth is what an assessment found of a single orchestrator node
+type NodeHealth struct {
+	API            string
+	Reachable      bool   // the node responded
+	Healthy        bool   // the node reports itself healthy, which implies its backend database is accessible
+	Error          string // why the node is unreachable or unhealthy
+	Hostname       string
+	IsActiveNode   bool
+	ActiveNode     string // the elected node (raft leader, with raft) as seen by this node
+	AvailableNodes int    // orchestrator nodes this node sees as available
+	RaftEnabled    bool
+	RaftHealthy    bool
+	Latency        time.Duration
+}
+
+// Assessment is a single evaluation of the deployment's health
+type Assessment struct {
+	Timestamp     time.Time
+	Status        Status
+	Reasons       []string // why the status is not healthy
+	Leader        string   // the elected node all healthy nodes agree on; empty when none is agreed on
+	LeaderChanges int      // changes of leader within Options.FlapWindow
+	Nodes         []NodeHealth
+}
+
+// Callback is invoked with each assessment, and with the one preceding it, which is nil on the first assessment
+type Callback func(assessment *Assessment, previous *Assessment)
+
+// Options configure a Watchdog
+type Options struct {
+	Nodes            []string      // API endpoints of the orchestrator nodes, e.g. "http://orchestrator-1:3000/api"
+	User             string        // basic authentication user, if any
+	Password         string        // basic authentication password
+	Client           *http.Client  // defaults to a client with a 5 seconds timeout
+	StatusPath       string        // path of the status check, relative to the API endpoint; default "status"
+	Interval         time.Duration // interval between assessments, when running; default 10s
+	FlapWindow       time.Duration // window within which leader changes are counted; default 10m
+	MaxLeaderChanges int           // more leader changes than this within FlapWindow are considered flapping; default 2
+}
+
+type apiResponse struct {
+	Code    string
+	Message string
+	Details json.RawMessage
+}
+
+type healthStatus struct {
+	Healthy      bool
+	Hostname     string
+	IsActiveNode bool
+	ActiveNode   struct {
+		Hostname string
+	}
+	AvailableNodes []json.RawMessage
+	RaftAdvertise  string
+}
+
+// Watchdog periodically assesses the health of an orchestrator deployment
+type Watchdog struct {
+	options Options
+
+	mutex          sync.Mutex
+	callbacks      []Callback
+	lastAssessment *Assessment
+	lastLeader     string
+	leaderChanges  []time.Time
+
+	isClosed       bool
+	closed         chan struct{}
+	inFlight       sync.WaitGroup
+	requestsCtx    context.Context
+	cancelRequests context.CancelFunc
+}
+
+// NewWatchdog returns a watchdog over given nodes
+func NewWatchdog(options Options) *Watchdog {
+	if options.Client == nil {
+		options.Client = &http.Client{Timeout: 5 * time.Second}
+	}
+	if options.StatusPath == "" {
+		options.StatusPath = "status"
+	}
+	if options.Interval <= 0 {
+		options.Interval = 10 * time.Second
+	}
+	if options.FlapWindow <= 0 {
+		options.FlapWindow = 10 * time.Minute
+	}
+	if options.MaxLeaderChanges <= 0 {
+		options.MaxLeaderChanges = 2
+	}
+	nodes := []string{}
+	for _, node := range options.Nodes {
+		nodes = append(nodes, strings.TrimRight(node, "/"))
+	}
+	options.Nodes = nodes
+	requestsCtx, cancelRequests := context.WithCancel(context.Background())
+	return &Watchdog{
+		options:        options,
+		closed:         make(chan struct{}),
+		requestsCtx:    requestsCtx,
+		cancelRequests: cancelRequests,
+	}
+}
+
+// OnAssessment registers a callback, invoked upon each assessment
+func (this *Watchdog) OnAssessment(callback Callback) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.callbacks = append(this.callbacks, callback)
+}
+
+// OnStatusChange registers a callback, invoked upon assessments whose status differs from the previous one's,
+// and upon the first assessment
+func (this *Watchdog) OnStatusChange(callback Callback) {
+	this.OnAssessment(func(assessment *Assessment, previous *Assessment) {
+		if previous == nil || previous.Status != assessment.Status {
+			callback(assessment, previous)
+		}
+	})
+}
+
+// LastAssessment returns the latest assessment, or nil if none was made
+func (this *Watchdog) LastAssessment() *Assessment {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	return this.lastAssessment
+}
+
+func (this *Watchdog) get(api string, path string) (statusCode int, body []byte, err error) {
+	req, err := http.NewRequestWithContext(this.requestsCtx, "GET", fmt.Sprintf("%s/%s", api, path), nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	if this.options.User != "" {
+		req.SetBasicAuth(this.options.User, this.options.Password)
+	}
+	res, err := this.options.Client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer res.Body.Close()
+	body, err = ioutil.ReadAll(res.Body)
+	return res.StatusCode, body, err
+}
+
+// probeNode reads the status, and with raft the raft health, of a single node
+func (this *Watchdog) probeNode(api string) NodeHealth {
+	node := NodeHealth{API: api}
+	startTime := time.Now()
+	statusCode, body, err := this.get(api, this.options.StatusPath)
+	node.Latency = time.Since(startTime)
+	if err != nil {
+		node.Error = err.Error()
+		return node
+	}
+	response := apiResponse{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		node.Error = fmt.Sprintf("%s: status %d: %+v", this.options.StatusPath, statusCode, err)
+		return node
+	}
+	node.Reachable = true
+	health := healthStatus{}
+	json.Unmarshal(response.Details, &health)
+	node.Hostname = health.Hostname
+	node.IsActiveNode = health.IsActiveNode
+	node.ActiveNode = health.ActiveNode.Hostname
+	node.AvailableNodes = len(health.AvailableNodes)
+	node.RaftEnabled = (health.RaftAdvertise != "")
+	node.Healthy = (statusCode == http.StatusOK && response.Code == "OK" && health.Healthy)
+	if !node.Healthy {
+		node.Error = response.Message
+	}
+
+	if node.RaftEnabled {
+		statusCode, body, err := this.get(api, "raft-health")
+		node.RaftHealthy = (err == nil && statusCode == http.StatusOK && strings.TrimSpace(string(body)) == `"healthy"`)
+	}
+	return node
+}
+
+// recordLeader accounts for the agreed leader of an assessment, and returns the number of leader changes within
+// the flap window
+func (this *Watchdog) recordLeader(leader string, now time.Time) int {
+	if leader != "" {
+		if this.lastLeader != "" && leader != this.lastLeader {
+			this.leaderChanges = append(this.leaderChanges, now)
+		}
+		this.lastLeader = leader
+	}
+	recentChanges := []time.Time{}
+	for _, changeTime := range this.leaderChanges {
+		if now.Sub(changeTime) <= this.options.FlapWindow {
+			recentChanges = append(recentChanges, changeTime)
+		}
+	}
+	this.leaderChanges = recentChanges
+	return len(this.leaderChanges)
+}
+
+// evaluate sets the status, reasons and leader of an assessment based on its nodes
+func (this *Watchdog) evaluate(assessment *Assessment) {
+	raise := func(status Status, reason string, args ...interface{}) {
+		if status.severity() > assessment.Status.severity() {
+			assessment.Status = status
+		}
+		assessment.Reasons = append(assessment.Reasons, fmt.Sprintf(reason, args...))
+	}
+	countNodes := len(assessment.Nodes)
+	countReachable, countHealthy, countRaftEnabled, countRaftHealthy := 0, 0, 0, 0
+	leaders := map[string]bool{}
+	for _, node := range assessment.Nodes {
+		if node.Reachable {
+			countReachable++
+		}
+		if node.Healthy {
+			countHealthy++
+			if node.ActiveNode != "" {
+				leaders[node.ActiveNode] = true
+			}
+		}
+		if node.RaftEnabled {
+			countRaftEnabled++
+		}
+		if node.RaftHealthy {
+			countRaftHealthy++
+		}
+	}
+	switch {
+	case countReachable == 0:
+		raise(StatusCritical, "no node is reachable")
+	case countReachable < countNodes:
+		raise(StatusDegraded, "%d of %d nodes unreachable", countNodes-countReachable, countNodes)
+	}
+	switch {
+	case countReachable > 0 && countHealthy == 0:
+		raise(StatusCritical, "no node is healthy")
+	case countHealthy < countReachable:
+		raise(StatusDegraded, "%d of %d reachable nodes unhealthy", countReachable-countHealthy, countReachable)
+	}
+	if countRaftEnabled > 0 {
+		quorum := countNodes/2 + 1
+		switch {
+		case countRaftHealthy < quorum:
+			raise(StatusCritical, "raft: %d of %d nodes healthy; quorum is %d", countRaftHealthy, countNodes, quorum)
+		case countRaftHealthy < countNodes:
+			raise(StatusDegraded, "raft: %d of %d nodes healthy", countRaftHealthy, countNodes)
+		}
+	}
+
+	leaderNames := []string{}
+	for leader := range leaders {
+		leaderNames = append(leaderNames, leader)
+	}
+	sort.Strings(leaderNames)
+	switch {
+	case countHealthy > 0 && len(leaderNames) == 0:
+		raise(StatusCritical, "no elected node")
+	case len(leaderNames) > 1:
+		raise(StatusCritical, "nodes disagree on the elected node: %s", strings.Join(leaderNames, ", "))
+	case len(leaderNames) == 1:
+		assessment.Leader = leaderNames[0]
+	}
+	assessment.LeaderChanges = this.recordLeader(assessment.Leader, assessment.Timestamp)
+	if assessment.LeaderChanges > this.options.MaxLeaderChanges {
+		raise(StatusDegraded, "leader flapping: %d changes within %v", assessment.LeaderChanges, this.options.FlapWindow)
+	}
+}
+
+// Assess probes all nodes, evaluates their health, and invokes the callbacks. It returns nil once the
+// watchdog is closed.
+func (this *Watchdog) Assess() *Assessment {
+	if !this.beginInFlight() {
+		return nil
+	}
+	defer this.inFlight.Done()
+
+	assessment := &Assessment{
+		Timestamp: time.Now(),
+		Status:    StatusHealthy,
+		Reasons:   []string{},
+		Nodes:     make([]NodeHealth, len(this.options.Nodes)),
+	}
+	var wg sync.WaitGroup
+	for i, api := range this.options.Nodes {
+		wg.Add(1)
+		go func(i int, api string) {
+			defer wg.Done()
+			assessment.Nodes[i] = this.probeNode(api)
+		}(i, api)
+	}
+	wg.Wait()
+
+	this.mutex.Lock()
+	this.evaluate(assessment)
+	previous := this.lastAssessment
+	this.lastAssessment = assessment
+	callbacks := this.callbacks
+	this.mutex.Unlock()
+
+	for _, callback := range callbacks {
+		callback(assessment, previous)
+	}
+	return assessment
+}
+
+// Run assesses the deployment once every Options.Interval, until stop is closed or the watchdog is closed
+func (this *Watchdog) Run(stop <-chan struct{}) {
+	if !this.beginInFlight() {
+		return
+	}
+	defer this.inFlight.Done()
+
+	ticker := time.NewTicker(this.options.Interval)
+	defer ticker.Stop()
+	for {
+		this.Assess()
+		select {
+		case <-stop:
+			return
+		case <-this.closed:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// beginInFlight registers an in-flight assessment or Run loop, and returns false if the watchdog is closed
+func (this *Watchdog) beginInFlight() bool {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	if this.isClosed {
+		return false
+	}
+	this.inFlight.Add(1)
+	return true
+}
+
+// Close shuts the watchdog down: further assessments are refused and Run loops return. Close waits for
+// in-flight assessments, including their callbacks, until ctx is done, at which point their outstanding
+// requests are cancelled and ctx's error is returned. Idle connections of Options.Client are closed.
+// Close may be called more than once.
+func (this *Watchdog) Close(ctx context.Context) error {
+	this.mutex.Lock()
+	if !this.isClosed {
+		this.isClosed = true
+		close(this.closed)
+	}
+	this.mutex.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		this.inFlight.Wait()
+		close(drained)
+	}()
+	var err error
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		this.cancelRequests()
+		err = ctx.Err()
+	}
+	this.options.Client.CloseIdleConnections()
+	return err
+}
@@ -0,0 +1,209 @@
+package watchdog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	test "github.com/openark/golib/tests"
+)
+
+// fakeNode serves the status and raft health of an orchestrator node
+type fakeNode struct {
+	mutex       sync.Mutex
+	hostname    string
+	healthy     bool
+	activeNode  string
+	raft        bool
+	raftHealthy bool
+}
+
+func (this *fakeNode) set(f func(node *fakeNode)) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	f(this)
+}
+
+func (this *fakeNode) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	switch req.URL.Path {
+	case "/api/status":
+		health := map[string]interface{}{
+			"Healthy":        this.healthy,
+			"Hostname":       this.hostname,
+			"IsActiveNode":   this.hostname == this.activeNode,
+			"ActiveNode":     map[string]string{"Hostname": this.activeNode},
+			"AvailableNodes": []string{},
+		}
+		if this.raft {
+			health["RaftAdvertise"] = this.hostname
+		}
+		code := "OK"
+		if !this.healthy {
+			code = "ERROR"
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"Code": code, "Message": "", "Details": health})
+	case "/api/raft-health":
+		if !this.raftHealthy {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"Code":"ERROR","Message":"unhealthy"}`))
+			return
+		}
+		w.Write([]byte(`"healthy"`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newFakeNodes(count int, raft bool) (nodes []*fakeNode, apis []string, closeAll func()) {
+	servers := []*httptest.Server{}
+	for i := 0; i < count; i++ {
+		node := &fakeNode{hostname: string(rune('a' + i)), healthy: true, activeNode: "a", raft: raft, raftHealthy: raft}
+		server := httptest.NewServer(node)
+		nodes = append(nodes, node)
+		servers = append(servers, server)
+		apis = append(apis, server.URL+"/api/")
+	}
+	return nodes, apis, func() {
+		for _, server := range servers {
+			server.Close()
+		}
+	}
+}
+
+func TestAssessHealthy(t *testing.T) {
+	_, apis, closeAll := newFakeNodes(3, true)
+	defer closeAll()
+
+	assessment := NewWatchdog(Options{Nodes: apis}).Assess()
+	test.S(t).ExpectEquals(assessment.Status, StatusHealthy)
+	test.S(t).ExpectEquals(len(assessment.Reasons), 0)
+	test.S(t).ExpectEquals(assessment.Leader, "a")
+	test.S(t).ExpectEquals(len(assessment.Nodes), 3)
+	for _, node := range assessment.Nodes {
+		test.S(t).ExpectTrue(node.Healthy)
+		test.S(t).ExpectTrue(node.RaftHealthy)
+	}
+}
+
+func TestAssessUnhealthy(t *testing.T) {
+	nodes, apis, closeAll := newFakeNodes(3, true)
+	defer closeAll()
+	w := NewWatchdog(Options{Nodes: apis})
+
+	nodes[2].set(func(node *fakeNode) { node.healthy = false; node.raftHealthy = false })
+	assessment := w.Assess()
+	test.S(t).ExpectEquals(assessment.Status, StatusDegraded)
+	test.S(t).ExpectEquals(len(assessment.Reasons), 2)
+	test.S(t).ExpectTrue(assessment.Nodes[2].Reachable)
+	test.S(t).ExpectFalse(assessment.Nodes[2].Healthy)
+
+	nodes[1].set(func(node *fakeNode) { node.raftHealthy = false })
+	assessment = w.Assess()
+	test.S(t).ExpectEquals(assessment.Status, StatusCritical)
+
+	nodes[1].set(func(node *fakeNode) { node.raftHealthy = true; node.activeNode = "b" })
+	assessment = w.Assess()
+	test.S(t).ExpectEquals(assessment.Status, StatusCritical)
+	test.S(t).ExpectEquals(assessment.Leader, "")
+}
+
+func TestAssessUnreachable(t *testing.T) {
+	_, apis, closeAll := newFakeNodes(2, false)
+	w := NewWatchdog(Options{Nodes: append(apis, "http://127.0.0.1:1/api")})
+	assessment := w.Assess()
+	test.S(t).ExpectEquals(assessment.Status, StatusDegraded)
+	test.S(t).ExpectFalse(assessment.Nodes[2].Reachable)
+	test.S(t).ExpectTrue(assessment.Nodes[2].Error != "")
+
+	closeAll()
+	assessment = w.Assess()
+	test.S(t).ExpectEquals(assessment.Status, StatusCritical)
+}
+
+func TestLeaderFlapping(t *testing.T) {
+	nodes, apis, closeAll := newFakeNodes(1, false)
+	defer closeAll()
+	w := NewWatchdog(Options{Nodes: apis, MaxLeaderChanges: 2})
+
+	statusChanges := []Status{}
+	w.OnStatusChange(func(assessment *Assessment, previous *Assessment) {
+		statusChanges = append(statusChanges, assessment.Status)
+	})
+	countAssessments := 0
+	w.OnAssessment(func(assessment *Assessment, previous *Assessment) {
+		countAssessments++
+	})
+
+	for _, leader := range []string{"a", "b", "a", "a", "b"} {
+		nodes[0].set(func(node *fakeNode) { node.activeNode = leader })
+		w.Assess()
+	}
+	test.S(t).ExpectEquals(w.LastAssessment().LeaderChanges, 3)
+	test.S(t).ExpectEquals(w.LastAssessment().Status, StatusDegraded)
+	test.S(t).ExpectEquals(countAssessments, 5)
+	test.S(t).ExpectEquals(len(statusChanges), 2)
+	test.S(t).ExpectEquals(statusChanges[0], StatusHealthy)
+	test.S(t).ExpectEquals(statusChanges[1], StatusDegraded)
+}
+
+func TestCloseRefusesAssessments(t *testing.T) {
+	_, apis, closeAll := newFakeNodes(1, false)
+	defer closeAll()
+	w := NewWatchdog(Options{Nodes: apis})
+	test.S(t).ExpectNotNil(w.Assess())
+
+	test.S(t).ExpectNil(w.Close(context.Background()))
+	test.S(t).ExpectTrue(w.Assess() == nil)
+	test.S(t).ExpectNil(w.Close(context.Background()))
+}
+
+func TestCloseStopsRun(t *testing.T) {
+	_, apis, closeAll := newFakeNodes(1, false)
+	defer closeAll()
+	w := NewWatchdog(Options{Nodes: apis, Interval: time.Hour})
+	assessed := make(chan struct{}, 1)
+	w.OnAssessment(func(assessment *Assessment, previous *Assessment) {
+		assessed <- struct{}{}
+	})
+	returned := make(chan struct{})
+	go func() {
+		w.Run(make(chan struct{}))
+		close(returned)
+	}()
+	<-assessed
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	test.S(t).ExpectNil(w.Close(ctx))
+	<-returned
+}
+
+func TestCloseCancelsInFlightRequests(t *testing.T) {
+	arrived := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		arrived <- struct{}{}
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	w := NewWatchdog(Options{Nodes: []string{server.URL + "/api"}, Client: &http.Client{}})
+	assessments := make(chan *Assessment, 1)
+	go func() {
+		assessments <- w.Assess()
+	}()
+	<-arrived
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	test.S(t).ExpectEquals(w.Close(ctx), context.DeadlineExceeded)
+	assessment := <-assessments
+	test.S(t).ExpectFalse(assessment.Nodes[0].Reachable)
+	test.S(t).ExpectEquals(assessment.Status, StatusCritical)
+}